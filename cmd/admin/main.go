@@ -0,0 +1,209 @@
+// Command admin runs administrative tasks against the admin service database.
+//
+// Usage:
+//
+//	admin import [flags] <file>
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/config"
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository/postgres"
+	"github.com/todo-app/services/admin-service/internal/service"
+	"github.com/todo-app/services/admin-service/pkg/db"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+// command is an admin subcommand; it returns the process exit code
+type command struct {
+	summary string
+	run     func(args []string) int
+}
+
+var commands = map[string]command{
+	"import": {summary: "Import tasks from a CSV or JSON Lines file", run: runImport},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	os.Exit(cmd.run(os.Args[2:]))
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: admin <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for name, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, cmd.summary)
+	}
+}
+
+// runImport imports tasks straight into the database. Database settings come from
+// the usual configuration layers (-config file and environment variables).
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	configFile := fs.String("config", "", "path to a YAML or JSON config file")
+	format := fs.String("format", "", "file format: csv or jsonl (default: from the file extension)")
+	dryRun := fs.Bool("dry-run", false, "validate every row and print the report without creating tasks")
+	batchSize := fs.Int("batch-size", domain.DefaultImportBatchSize, "tasks committed per transaction")
+	resume := fs.String("resume", "", "ID of a failed import to resume with the same file")
+	reportFile := fs.String("report", "", "write the full JSON report to this file")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: admin import [flags] <file|->")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	path := fs.Arg(0)
+
+	importFormat, err := importFormat(*format, path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	var configArgs []string
+	if *configFile != "" {
+		configArgs = []string{"-config", *configFile}
+	}
+	cfg, err := config.Load(configArgs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+
+	var src io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open %s: %v\n", path, err)
+			return 1
+		}
+		defer file.Close()
+		src = file
+	}
+
+	log := logger.NewLogger(cfg.LogLevel)
+
+	dbConn, err := db.NewConnection(cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer dbConn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := dbConn.HealthCheck(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Database health check failed: %v\n", err)
+		return 1
+	}
+	dbConn.SetServiceContext(context.Background(), "admin-service")
+
+	services := service.NewServices(service.ServiceDependencies{
+		UserRepo:     postgres.NewUserRepository(dbConn.DB),
+		TaskRepo:     postgres.NewTaskRepository(dbConn.DB),
+		CategoryRepo: postgres.NewCategoryRepository(dbConn.DB),
+		TagRepo:      postgres.NewTagRepository(dbConn.DB),
+		ImportRepo:   postgres.NewTaskImportRepository(dbConn.DB),
+		TxManager:    postgres.NewTransactionManager(dbConn.DB),
+		Logger:       log,
+	})
+
+	report, err := services.Import.ImportTasks(context.Background(), src, domain.ImportOptions{
+		Format:         importFormat,
+		DryRun:         *dryRun,
+		BatchSize:      *batchSize,
+		ResumeImportID: *resume,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Import failed: %v\n", err)
+		return 1
+	}
+
+	if *reportFile != "" {
+		if err := writeReport(*reportFile, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write report: %v\n", err)
+			return 1
+		}
+	}
+
+	printReport(os.Stdout, report)
+	if report.Status == domain.ImportStatusFailed {
+		return 1
+	}
+	return 0
+}
+
+// importFormat uses the -format flag, falling back to the file extension
+func importFormat(format, path string) (domain.ImportFormat, error) {
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+
+	switch strings.ToLower(format) {
+	case "csv":
+		return domain.ImportFormatCSV, nil
+	case "jsonl", "ndjson":
+		return domain.ImportFormatJSONL, nil
+	case "":
+		return "", fmt.Errorf("-format is required when reading from stdin")
+	default:
+		return "", fmt.Errorf("unsupported format %q (use csv or jsonl)", format)
+	}
+}
+
+func writeReport(path string, report *domain.ImportReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// printReport prints a summary and every row that needs attention
+func printReport(w io.Writer, report *domain.ImportReport) {
+	for _, row := range report.Rows {
+		if len(row.Errors) > 0 {
+			fmt.Fprintf(w, "row %d %s: %s\n", row.Row, row.Status, strings.Join(row.Errors, "; "))
+		}
+	}
+
+	if report.DryRun {
+		fmt.Fprintf(w, "Dry run: %d rows, %d valid, %d invalid\n", report.TotalRows, report.ValidRows, report.InvalidRows)
+		return
+	}
+
+	fmt.Fprintf(w, "Import %s %s: %d rows, %d created, %d invalid, %d skipped\n",
+		report.ImportID, report.Status, report.TotalRows, report.CreatedRows, report.InvalidRows, report.SkippedRows)
+	if report.Status == domain.ImportStatusFailed {
+		fmt.Fprintf(w, "Error: %s\n", report.Error)
+		fmt.Fprintf(w, "Rows up to %d were committed. Fix the problem and rerun with -resume %s\n", report.LastCommittedRow, report.ImportID)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func TestImportFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		path    string
+		want    domain.ImportFormat
+		wantErr bool
+	}{
+		{path: "tasks.csv", want: domain.ImportFormatCSV},
+		{path: "export.JSONL", want: domain.ImportFormatJSONL},
+		{format: "ndjson", path: "-", want: domain.ImportFormatJSONL},
+		{format: "csv", path: "tasks.txt", want: domain.ImportFormatCSV},
+		{path: "-", wantErr: true},
+		{path: "tasks.xlsx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := importFormat(tt.format, tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("importFormat(%q, %q) = %q, %v; want %q, error %v", tt.format, tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log/slog"
 	"net"
@@ -9,28 +10,38 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Export time zones must resolve in images without a system zoneinfo database
+	_ "time/tzdata"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/todo-app/services/admin-service/internal/blobstore"
 	"github.com/todo-app/services/admin-service/internal/config"
+	"github.com/todo-app/services/admin-service/internal/events"
 	grpchandler "github.com/todo-app/services/admin-service/internal/handler/grpc"
+	"github.com/todo-app/services/admin-service/internal/middleware"
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/notify"
+	"github.com/todo-app/services/admin-service/internal/repository"
 	"github.com/todo-app/services/admin-service/internal/repository/postgres"
 	"github.com/todo-app/services/admin-service/internal/service"
 	"github.com/todo-app/services/admin-service/pkg/db"
 	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
 )
 
 func main() {
 	// Load configuration
-	cfg, err := config.LoadConfig()
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
 		slog.Error("Failed to load configuration", "error", err)
 		os.Exit(1)
 	}
 
 	// Initialize logger
-	log := logger.NewLogger(cfg.LogLevel)
+	logLevel := logger.NewLevel(cfg.LogLevel)
+	log := logger.NewLoggerWithLevel(logLevel)
 	log.Info(context.Background(), "Starting TODO Admin Service", "version", "1.0.0")
 
 	// Initialize database connection
@@ -58,18 +69,93 @@ func main() {
 	taskRepo := postgres.NewTaskRepository(dbConn.DB)
 	categoryRepo := postgres.NewCategoryRepository(dbConn.DB)
 	tagRepo := postgres.NewTagRepository(dbConn.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(dbConn.DB)
+	importRepo := postgres.NewTaskImportRepository(dbConn.DB)
+	viewRepo := postgres.NewSavedViewRepository(dbConn.DB)
+	workflowRepo := postgres.NewWorkflowRepository(dbConn.DB)
+	seriesRepo := postgres.NewTaskSeriesRepository(dbConn.DB)
+	commentRepo := postgres.NewCommentRepository(dbConn.DB)
+	attachmentRepo := postgres.NewAttachmentRepository(dbConn.DB)
+	notificationRepo := postgres.NewNotificationRepository(dbConn.DB)
+	txManager := postgres.NewTransactionManager(dbConn.DB)
+
+	// Attachment content lives outside the database
+	blobStore, err := blobstore.New(cfg.Attachments)
+	if err != nil {
+		log.Error(context.Background(), "Failed to initialize attachment storage", "error", err, "storage", cfg.Attachments.Storage)
+		os.Exit(1)
+	}
+
+	// Domain events are delivered in-process to the subscribers registered on the bus
+	eventBus := events.NewBus(log)
 
 	// Initialize services
-	services := &service.Services{
-		User:     service.NewUserService(userRepo, log),
-		Task:     service.NewTaskService(taskRepo, userRepo, categoryRepo, tagRepo, log),
-		Category: service.NewCategoryService(categoryRepo, taskRepo, log),
-		Tag:      service.NewTagService(tagRepo, taskRepo, log),
+	services := service.NewServices(service.ServiceDependencies{
+		UserRepo:     userRepo,
+		TaskRepo:     taskRepo,
+		CategoryRepo: categoryRepo,
+		TagRepo:      tagRepo,
+		ImportRepo:   importRepo,
+		ViewRepo:     viewRepo,
+		WorkflowRepo: workflowRepo,
+		SeriesRepo:   seriesRepo,
+		CommentRepo:  commentRepo,
+		TxManager:    txManager,
+		Logger:       log,
+		Events:       eventBus,
+
+		AttachmentRepo: attachmentRepo,
+		BlobStore:      blobStore,
+
+		// No delivery provider is configured yet, so notifications are only logged
+		NotificationRepo: notificationRepo,
+		Notifier:         notify.NewLog(log),
+
+		CategoryMaxDepth:  cfg.Categories.MaxDepth,
+		TagSuggestTimeout: cfg.Tags.SuggestTimeout,
+		SubtaskRules: domain.SubtaskRules{
+			BlockParentCompletion: cfg.Tasks.BlockParentCompletion,
+			AutoCompleteParent:    cfg.Tasks.AutoCompleteParent,
+		},
+		AttachmentLimits: domain.AttachmentLimits{
+			MaxSizeBytes:   cfg.Attachments.MaxSize,
+			AllowedTypes:   cfg.Attachments.AllowedTypes,
+			UserQuotaBytes: cfg.Attachments.UserQuota,
+		},
+		NotificationRules: domain.NotificationRules{
+			DueSoon:    cfg.Notifications.DueSoon,
+			DigestHour: cfg.Notifications.DigestHour,
+		},
+	})
+
+	// Rate limiting counters are exposed through expvar (published once per process)
+	rateLimiter := middleware.NewRateLimiter(cfg.RateLimit)
+	if expvar.Get("rate_limit") == nil {
+		expvar.Publish("rate_limit", rateLimiter.Metrics())
+	}
+
+	// Caller identity comes from the gateway's metadata only when it is trusted to set it
+	requestContextOptions := middleware.RequestContextOptions{TrustGatewayIdentity: cfg.Server.TrustGatewayIdentity}
+	if !requestContextOptions.TrustGatewayIdentity {
+		log.Warn(context.Background(), "Gateway identity is not trusted; all callers are anonymous")
 	}
 
 	// Initialize gRPC server
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(loggingInterceptor(log)),
+		grpc.ChainUnaryInterceptor(
+			middleware.UnaryRequestContextInterceptor(requestContextOptions),
+			loggingInterceptor(log),
+			middleware.UnaryRateLimitInterceptor(rateLimiter),
+			middleware.UnaryIdempotencyInterceptor(idempotencyRepo, middleware.IdempotencyOptions{
+				TTL:         cfg.Idempotency.TTL,
+				LockTimeout: cfg.Idempotency.LockTimeout,
+				Methods:     middleware.DefaultIdempotentMethods,
+			}, log),
+		),
+		grpc.ChainStreamInterceptor(
+			middleware.StreamRequestContextInterceptor(requestContextOptions),
+			middleware.StreamRateLimitInterceptor(rateLimiter),
+		),
 	)
 
 	// Register gRPC handlers (to be implemented)
@@ -87,6 +173,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Register for signals before serving; an unhandled SIGHUP would terminate the process
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
+
 	log.Info(context.Background(), "Starting gRPC server", "address", address)
 
 	// Start server in goroutine
@@ -95,9 +187,33 @@ func main() {
 		serverErrors <- grpcServer.Serve(listener)
 	}()
 
+	// Purge expired idempotency keys in the background
+	go purgeExpiredIdempotencyKeys(idempotencyRepo, log)
+
+	// Generate the next instance of recurring tasks whose latest instance falls due
+	if cfg.Tasks.RecurrenceInterval > 0 {
+		go generateRecurringTasks(services.Task, cfg.Tasks.RecurrenceInterval, log)
+	}
+
+	// Delete the content of attachments whose tasks were purged
+	if cfg.Attachments.GCInterval > 0 {
+		go collectAttachmentGarbage(services.Attachment, cfg.Attachments.GCInterval, log)
+	}
+
+	// Notify watchers of task activity, approaching due dates and their daily digests
+	if cfg.Notifications.DispatchInterval > 0 {
+		go dispatchNotifications(services.Notification, cfg.Notifications.DispatchInterval, log)
+	}
+
+	// Hot-reload safe settings on SIGHUP without restarting the gRPC server
+	reloader := config.NewReloader(cfg, os.Args[1:])
+	reloader.Subscribe(func(c *config.Config) {
+		logLevel.Set(c.LogLevel)
+		rateLimiter.Update(c.RateLimit)
+	})
+	go watchReload(reload, reloader, log)
+
 	// Wait for shutdown signal
-	shutdown := make(chan os.Signal, 1)
-	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
 
 	select {
 	case err := <-serverErrors:
@@ -134,7 +250,87 @@ func main() {
 	log.Info(context.Background(), "Server stopped")
 }
 
-// loggingInterceptor provides request logging for gRPC calls
+// watchReload reloads the configuration for every SIGHUP delivered on reload.
+// An invalid configuration is logged and the previous one stays active.
+func watchReload(reload <-chan os.Signal, reloader *config.Reloader, log logger.Logger) {
+	for range reload {
+		cfg, err := reloader.Reload()
+		if err != nil {
+			log.Error(context.Background(), "Configuration reload failed, keeping current configuration", "error", err)
+			continue
+		}
+		log.Info(context.Background(), "Configuration reloaded",
+			"log_level", cfg.LogLevel,
+			"rate_limit_enabled", cfg.RateLimit.Enabled,
+		)
+	}
+}
+
+// purgeExpiredIdempotencyKeys periodically deletes idempotency keys past their TTL
+func purgeExpiredIdempotencyKeys(repo repository.IdempotencyRepository, log logger.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := repo.DeleteExpired(context.Background())
+		if err != nil {
+			log.Warn(context.Background(), "Failed to purge expired idempotency keys", "error", err)
+			continue
+		}
+		log.Debug(context.Background(), "Purged expired idempotency keys", "count", deleted)
+	}
+}
+
+// generateRecurringTasks periodically continues recurring task series that are due
+func generateRecurringTasks(tasks service.TaskService, interval time.Duration, log logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := requestctx.WithInternal(context.Background())
+	for range ticker.C {
+		generated, err := tasks.GenerateRecurringTasks(ctx, time.Now())
+		if err != nil {
+			log.Warn(context.Background(), "Failed to generate recurring tasks", "error", err)
+			continue
+		}
+		log.Debug(context.Background(), "Generated recurring tasks", "count", generated)
+	}
+}
+
+// collectAttachmentGarbage periodically deletes blobs queued for deletion by task purges
+func collectAttachmentGarbage(attachments service.AttachmentService, interval time.Duration, log logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := requestctx.WithInternal(context.Background())
+	for range ticker.C {
+		deleted, err := attachments.CollectGarbage(ctx)
+		if err != nil {
+			log.Warn(context.Background(), "Failed to collect attachment garbage", "error", err)
+			continue
+		}
+		log.Debug(context.Background(), "Collected attachment garbage", "count", deleted)
+	}
+}
+
+// dispatchNotifications periodically turns task activity into notifications and delivers them
+func dispatchNotifications(notifications service.NotificationService, interval time.Duration, log logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := requestctx.WithInternal(context.Background())
+	for range ticker.C {
+		delivered, err := notifications.DispatchNotifications(ctx, time.Now())
+		if err != nil {
+			log.Warn(context.Background(), "Failed to dispatch notifications", "error", err)
+			continue
+		}
+		log.Debug(context.Background(), "Dispatched notifications", "count", delivered)
+	}
+}
+
+// loggingInterceptor provides request logging for gRPC calls.
+// Request ID, user, method and trace ID are attached by the logger from the request context.
 func loggingInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -150,13 +346,11 @@ func loggingInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
 
 		if err != nil {
 			log.Error(ctx, "gRPC request failed",
-				"method", info.FullMethod,
 				"duration", duration,
 				"error", err,
 			)
 		} else {
 			log.Info(ctx, "gRPC request completed",
-				"method", info.FullMethod,
 				"duration", duration,
 			)
 		}
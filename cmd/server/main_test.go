@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"flag"
 	"net"
+	"os"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 
 	"github.com/todo-app/services/admin-service/internal/config"
 	grpchandler "github.com/todo-app/services/admin-service/internal/handler/grpc"
+	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository/postgres"
 	"github.com/todo-app/services/admin-service/internal/service"
 	"github.com/todo-app/services/admin-service/pkg/db"
@@ -21,6 +24,13 @@ import (
 
 const bufSize = 1024 * 1024
 
+// TestMain strips the go test flags so tests that run main() don't hand them to the config loader
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Args = os.Args[:1]
+	os.Exit(m.Run())
+}
+
 func setupTestServer(t *testing.T) (*grpc.Server, *bufconn.Listener, todov1.AdminServiceClient) {
 	// Create buffer connection for testing
 	lis := bufconn.Listen(bufSize)
@@ -69,13 +79,16 @@ func setupTestServer(t *testing.T) (*grpc.Server, *bufconn.Listener, todov1.Admi
 	taskRepo := postgres.NewTaskRepository(dbConn.DB)
 	categoryRepo := postgres.NewCategoryRepository(dbConn.DB)
 	tagRepo := postgres.NewTagRepository(dbConn.DB)
+	workflowRepo := postgres.NewWorkflowRepository(dbConn.DB)
+	seriesRepo := postgres.NewTaskSeriesRepository(dbConn.DB)
+	txManager := postgres.NewTransactionManager(dbConn.DB)
 
 	// Initialize services
 	services := &service.Services{
 		User:     service.NewUserService(userRepo, log),
-		Task:     service.NewTaskService(taskRepo, userRepo, categoryRepo, tagRepo, log),
-		Category: service.NewCategoryService(categoryRepo, taskRepo, log),
-		Tag:      service.NewTagService(tagRepo, taskRepo, log),
+		Task:     service.NewTaskService(taskRepo, userRepo, categoryRepo, tagRepo, workflowRepo, seriesRepo, txManager, domain.SubtaskRules{}, log),
+		Category: service.NewCategoryService(categoryRepo, taskRepo, txManager, 0, log),
+		Tag:      service.NewTagService(tagRepo, taskRepo, txManager, 0, log),
 	}
 
 	// Create gRPC server
@@ -0,0 +1,18 @@
+-- Idempotency keys for mutating RPCs
+-- Stores the first response for each (caller, key) pair so retried requests can be replayed
+
+CREATE TABLE idempotency_keys (
+    caller_id VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(128) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    response_type VARCHAR(255),
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (caller_id, idempotency_key)
+);
+
+-- Create indexes for idempotency_keys table
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,17 @@
+-- Task imports
+-- Tracks committed imports so a failed import can be resumed from the last committed row
+
+CREATE TABLE task_imports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    format VARCHAR(10) NOT NULL CHECK (format IN ('CSV', 'JSONL')),
+    status VARCHAR(20) NOT NULL DEFAULT 'RUNNING' CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    actor_id UUID NOT NULL REFERENCES users(id),
+    last_committed_row INTEGER NOT NULL DEFAULT 0,
+    created_count INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for task_imports table
+CREATE INDEX idx_task_imports_actor_id ON task_imports(actor_id);
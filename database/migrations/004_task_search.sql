@@ -0,0 +1,12 @@
+-- Full-text search over tasks
+-- Titles weigh more than descriptions; category and tag names are matched through their own indexes
+
+ALTER TABLE tasks ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+) STORED;
+
+-- Create search indexes
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
+CREATE INDEX idx_categories_name_search ON categories USING GIN (to_tsvector('english', name)) WHERE NOT is_deleted;
+CREATE INDEX idx_tags_name_search ON tags USING GIN (to_tsvector('english', name)) WHERE NOT is_deleted;
@@ -0,0 +1,28 @@
+-- Saved views
+-- Named task filters with sort order and visible columns, private to their owner or shared with a role
+
+CREATE TABLE saved_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_id UUID NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'PRIVATE' CHECK (visibility IN ('PRIVATE', 'ROLE')),
+    shared_role VARCHAR(50) CHECK (shared_role IN ('user', 'admin')),
+    filter JSONB NOT NULL DEFAULT '{}',
+    sort_by VARCHAR(50),
+    sort_desc BOOLEAN NOT NULL DEFAULT FALSE,
+    columns TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version BIGINT NOT NULL DEFAULT 1,
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK ((visibility = 'ROLE') = (shared_role IS NOT NULL))
+);
+
+-- Create indexes for saved_views table
+CREATE UNIQUE INDEX idx_saved_views_owner_name ON saved_views(owner_id, lower(name)) WHERE NOT is_deleted;
+CREATE INDEX idx_saved_views_shared_role ON saved_views(shared_role) WHERE NOT is_deleted AND visibility = 'ROLE';
+
+CREATE TRIGGER update_saved_views_updated_at BEFORE UPDATE ON saved_views FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER handle_saved_views_version BEFORE UPDATE ON saved_views FOR EACH ROW EXECUTE FUNCTION handle_version_and_locking();
@@ -0,0 +1,34 @@
+-- Category hierarchy
+-- A category can never become its own ancestor. The service reports cycles with a clear
+-- message; this trigger also catches concurrent moves that only form a cycle together.
+
+CREATE OR REPLACE FUNCTION prevent_category_cycle()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    -- Serialize parent changes so the ancestor walk below sees every committed move
+    PERFORM pg_advisory_xact_lock(hashtext('categories.parent_id'));
+
+    IF EXISTS (
+        WITH RECURSIVE ancestors(id, path) AS (
+            SELECT NEW.parent_id, ARRAY[NEW.parent_id]
+            UNION ALL
+            SELECT c.parent_id, a.path || c.parent_id
+            FROM categories c
+            JOIN ancestors a ON c.id = a.id
+            WHERE c.parent_id IS NOT NULL AND NOT c.parent_id = ANY(a.path)
+        )
+        SELECT 1 FROM ancestors WHERE id = NEW.id
+    ) THEN
+        RAISE EXCEPTION 'category % cannot be its own ancestor', NEW.id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'categories_no_cycle';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER prevent_categories_cycle BEFORE INSERT OR UPDATE OF parent_id ON categories FOR EACH ROW EXECUTE FUNCTION prevent_category_cycle();
@@ -0,0 +1,14 @@
+-- Category History table for audit trail of moves, merges, deletes and restores
+CREATE TABLE category_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    action VARCHAR(100) NOT NULL,
+    actor_id UUID NOT NULL REFERENCES users(id),
+    service_name VARCHAR(100) NOT NULL DEFAULT 'admin-service',
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    details JSONB
+);
+
+-- Create indexes for category_history table
+CREATE INDEX idx_category_history_category_id ON category_history(category_id);
+CREATE INDEX idx_category_history_timestamp ON category_history(timestamp);
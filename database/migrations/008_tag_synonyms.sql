@@ -0,0 +1,12 @@
+-- Tag synonyms
+-- Maps alternative spellings (already normalized, e.g. "bugs" or "defect") to a canonical tag.
+-- Renamed and merged tags leave their old names behind as synonyms.
+CREATE TABLE tag_synonyms (
+    synonym VARCHAR(100) PRIMARY KEY,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for tag_synonyms table
+CREATE INDEX idx_tag_synonyms_tag_id ON tag_synonyms(tag_id);
@@ -0,0 +1,8 @@
+-- Tag type-ahead
+-- Trigram matching on tag names; the GIN trigram index also serves prefix (LIKE 'abc%') lookups.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_tags_name_trgm ON tags USING GIN (name gin_trgm_ops) WHERE NOT is_deleted;
+
+-- Ranking by the caller's recent usage reads their latest task history
+CREATE INDEX idx_task_history_actor_timestamp ON task_history(actor_id, timestamp);
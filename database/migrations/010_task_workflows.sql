@@ -0,0 +1,34 @@
+-- Task workflows
+-- A workflow lists the statuses a task may take and the transitions between them, with guards
+-- and the fields a task needs to enter a status. Categories pick a workflow; a category without
+-- one uses its parent's. Tasks outside any workflow follow the default one, which is built into
+-- the service until a row is marked is_default.
+
+-- Review step for workflows that need one
+ALTER TABLE tasks DROP CONSTRAINT tasks_status_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check
+    CHECK (status IN ('OPEN', 'IN_PROGRESS', 'IN_REVIEW', 'COMPLETED', 'CANCELLED'));
+
+CREATE TABLE workflows (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    definition JSONB NOT NULL, -- {"states": [...], "transitions": [...]}
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    creator_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version BIGINT NOT NULL DEFAULT 1,
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for workflows table
+CREATE UNIQUE INDEX idx_workflows_name_unique ON workflows(LOWER(name)) WHERE NOT is_deleted;
+CREATE UNIQUE INDEX idx_workflows_default ON workflows(is_default) WHERE is_default AND NOT is_deleted;
+
+CREATE TRIGGER update_workflows_updated_at BEFORE UPDATE ON workflows FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER handle_workflows_version BEFORE UPDATE ON workflows FOR EACH ROW EXECUTE FUNCTION handle_version_and_locking();
+
+ALTER TABLE categories ADD COLUMN workflow_id UUID REFERENCES workflows(id);
+CREATE INDEX idx_categories_workflow_id ON categories(workflow_id) WHERE NOT is_deleted;
@@ -0,0 +1,7 @@
+-- Blocked tasks
+-- Tasks that cannot go on for now, for example while waiting on someone else. Mobile clients
+-- mark such tasks as undoable.
+
+ALTER TABLE tasks DROP CONSTRAINT tasks_status_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check
+    CHECK (status IN ('OPEN', 'IN_PROGRESS', 'IN_REVIEW', 'BLOCKED', 'COMPLETED', 'CANCELLED'));
@@ -0,0 +1,38 @@
+-- Subtasks
+-- A task may have a parent task, with no limit on nesting. As with categories, a task can
+-- never become its own ancestor, even through concurrent moves.
+
+ALTER TABLE tasks ADD COLUMN parent_id UUID REFERENCES tasks(id);
+
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id) WHERE NOT is_deleted;
+
+CREATE OR REPLACE FUNCTION prevent_task_cycle()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    -- Serialize parent changes so the ancestor walk below sees every committed move
+    PERFORM pg_advisory_xact_lock(hashtext('tasks.parent_id'));
+
+    IF EXISTS (
+        WITH RECURSIVE ancestors(id, path) AS (
+            SELECT NEW.parent_id, ARRAY[NEW.parent_id]
+            UNION ALL
+            SELECT t.parent_id, a.path || t.parent_id
+            FROM tasks t
+            JOIN ancestors a ON t.id = a.id
+            WHERE t.parent_id IS NOT NULL AND NOT t.parent_id = ANY(a.path)
+        )
+        SELECT 1 FROM ancestors WHERE id = NEW.id
+    ) THEN
+        RAISE EXCEPTION 'task % cannot be its own ancestor', NEW.id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'tasks_no_cycle';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER prevent_tasks_cycle BEFORE INSERT OR UPDATE OF parent_id ON tasks FOR EACH ROW EXECUTE FUNCTION prevent_task_cycle();
@@ -0,0 +1,41 @@
+-- Task dependencies
+-- A task can be blocked by any number of other tasks. The links form a directed graph
+-- that must stay acyclic, even through concurrent inserts.
+
+CREATE TABLE task_dependencies (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocker_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    creator_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, blocker_id),
+    CONSTRAINT task_dependencies_no_self CHECK (task_id <> blocker_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocker_id ON task_dependencies(blocker_id);
+
+CREATE OR REPLACE FUNCTION prevent_task_dependency_cycle()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Serialize new links so the walk below sees every committed one
+    PERFORM pg_advisory_xact_lock(hashtext('task_dependencies'));
+
+    IF EXISTS (
+        WITH RECURSIVE upstream(id, path) AS (
+            SELECT NEW.blocker_id, ARRAY[NEW.blocker_id]
+            UNION ALL
+            SELECT d.blocker_id, u.path || d.blocker_id
+            FROM task_dependencies d
+            JOIN upstream u ON d.task_id = u.id
+            WHERE NOT d.blocker_id = ANY(u.path)
+        )
+        SELECT 1 FROM upstream WHERE id = NEW.task_id
+    ) THEN
+        RAISE EXCEPTION 'task % cannot block itself through its dependencies', NEW.task_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'task_dependencies_no_cycle';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER prevent_task_dependencies_cycle BEFORE INSERT ON task_dependencies FOR EACH ROW EXECUTE FUNCTION prevent_task_dependency_cycle();
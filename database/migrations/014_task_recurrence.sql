@@ -0,0 +1,25 @@
+-- Recurring tasks
+-- A series holds a recurrence rule (an RRULE subset) anchored at the due date of its first task.
+-- Each instance is a regular task linked back to the series by its position in it; the latest
+-- instance is the template for the next one.
+
+CREATE TABLE task_series (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rule TEXT NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    instance_count INTEGER NOT NULL DEFAULT 1 CHECK (instance_count > 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE, -- cleared once the rule runs out or the series is stopped
+    creator_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version BIGINT NOT NULL DEFAULT 1
+);
+
+CREATE TRIGGER update_task_series_updated_at BEFORE UPDATE ON task_series FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER handle_task_series_version BEFORE UPDATE ON task_series FOR EACH ROW EXECUTE FUNCTION handle_version_and_locking();
+
+ALTER TABLE tasks ADD COLUMN series_id UUID REFERENCES task_series(id);
+ALTER TABLE tasks ADD COLUMN series_index INTEGER;
+
+-- One task per position, deleted ones included, so an instance is never generated twice
+CREATE UNIQUE INDEX idx_tasks_series_index ON tasks(series_id, series_index) WHERE series_id IS NOT NULL;
@@ -0,0 +1,26 @@
+-- Task comments
+-- Discussion on a task. Comments are merged with task_history into the task's activity feed.
+
+CREATE TABLE task_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES users(id),
+    body TEXT NOT NULL CHECK (length(body) > 0),
+    mentioned_user_ids UUID[] NOT NULL DEFAULT '{}', -- resolved @email mentions, kept in sync with the body
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    edited_at TIMESTAMP WITH TIME ZONE, -- set when the body is changed
+    version BIGINT NOT NULL DEFAULT 1,
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- The activity feed reads a task's live comments in order
+CREATE INDEX idx_task_comments_task_created ON task_comments(task_id, created_at) WHERE NOT is_deleted;
+CREATE INDEX idx_task_comments_author_id ON task_comments(author_id);
+
+CREATE TRIGGER update_task_comments_updated_at BEFORE UPDATE ON task_comments FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER handle_task_comments_version BEFORE UPDATE ON task_comments FOR EACH ROW EXECUTE FUNCTION handle_version_and_locking();
+
+-- The activity feed pages through a task's history in order
+CREATE INDEX idx_task_history_task_timestamp ON task_history(task_id, timestamp);
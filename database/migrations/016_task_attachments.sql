@@ -0,0 +1,43 @@
+-- Task attachments
+-- Attachment metadata lives here; the content is kept in the configured blob store under storage_key.
+
+CREATE TABLE task_attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    uploader_id UUID NOT NULL REFERENCES users(id),
+    file_name VARCHAR(255) NOT NULL CHECK (length(file_name) > 0),
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    checksum_sha256 CHAR(64) NOT NULL, -- hex encoded
+    storage_key VARCHAR(512) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version BIGINT NOT NULL DEFAULT 1,
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_task_attachments_task_id ON task_attachments(task_id) WHERE NOT is_deleted;
+-- Quota checks sum the uploader's live attachments
+CREATE INDEX idx_task_attachments_uploader ON task_attachments(uploader_id) WHERE NOT is_deleted;
+
+CREATE TRIGGER update_task_attachments_updated_at BEFORE UPDATE ON task_attachments FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER handle_task_attachments_version BEFORE UPDATE ON task_attachments FOR EACH ROW EXECUTE FUNCTION handle_version_and_locking();
+
+-- Blobs waiting to be removed from the blob store. Rows are queued when attachment metadata is
+-- hard deleted, which happens when its task is purged, and cleared by the garbage collector.
+CREATE TABLE attachment_blob_deletions (
+    storage_key VARCHAR(512) PRIMARY KEY,
+    queued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION queue_attachment_blob_deletion()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO attachment_blob_deletions (storage_key) VALUES (OLD.storage_key)
+    ON CONFLICT (storage_key) DO NOTHING;
+    RETURN OLD;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER queue_task_attachments_blob_deletion AFTER DELETE ON task_attachments FOR EACH ROW EXECUTE FUNCTION queue_attachment_blob_deletion();
@@ -0,0 +1,85 @@
+-- Watchers and notifications
+-- Users watch tasks, or categories to watch every task in them and in their subcategories. The
+-- notification dispatcher reads task_history and task_comments after the fact, so no write path
+-- has to know about notifications.
+
+CREATE TABLE task_watchers (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX idx_task_watchers_user_id ON task_watchers(user_id);
+
+CREATE TABLE category_watchers (
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (category_id, user_id)
+);
+
+CREATE INDEX idx_category_watchers_user_id ON category_watchers(user_id);
+
+-- Users without a row use the defaults: UTC and no quiet hours
+CREATE TABLE notification_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC', -- IANA name
+    quiet_hours_start SMALLINT CHECK (quiet_hours_start BETWEEN 0 AND 1439), -- minutes after local midnight
+    quiet_hours_end SMALLINT CHECK (quiet_hours_end BETWEEN 0 AND 1439),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version BIGINT NOT NULL DEFAULT 1,
+    CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL))
+);
+
+CREATE TRIGGER update_notification_settings_updated_at BEFORE UPDATE ON notification_settings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER handle_notification_settings_version BEFORE UPDATE ON notification_settings FOR EACH ROW EXECUTE FUNCTION handle_version_and_locking();
+
+-- Overrides of the default mode of an event on a channel
+CREATE TABLE notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL CHECK (event IN ('STATUS_CHANGED', 'REASSIGNED', 'COMMENTED', 'DUE_SOON')),
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('IN_APP', 'EMAIL')),
+    mode VARCHAR(20) NOT NULL CHECK (mode IN ('IMMEDIATE', 'DIGEST', 'OFF')),
+    PRIMARY KEY (user_id, event, channel)
+);
+
+-- When each user's last digest was assembled, per channel
+CREATE TABLE notification_digests (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL,
+    last_sent_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, channel)
+);
+
+-- Notifications waiting for delivery, e.g. until quiet hours end. Rows are removed once delivered.
+CREATE TABLE notification_queue (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL,
+    is_digest BOOLEAN NOT NULL DEFAULT FALSE,
+    items JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deliver_after TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_notification_queue_deliver_after ON notification_queue(deliver_after);
+
+-- How far the dispatcher has read task activity. The row is locked while a dispatcher runs, so
+-- concurrent service instances take turns.
+CREATE TABLE notification_cursors (
+    name VARCHAR(100) PRIMARY KEY,
+    position TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Due dates already announced as approaching; a task whose due date changes is announced again
+CREATE TABLE task_due_notifications (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    due_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    notified_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, due_date)
+);
+
+-- The dispatcher reads comments by creation time across all tasks
+CREATE INDEX idx_task_comments_created_at ON task_comments(created_at) WHERE NOT is_deleted;
@@ -0,0 +1,60 @@
+-- Task participants
+-- The users taking part in a task and their roles. A user may hold several roles on the same
+-- task. tasks.assignee_id remains the primary assignee, which always has the ASSIGNEE role;
+-- the trigger below keeps that row in step with the column, so every write path that sets
+-- assignee_id keeps working.
+
+CREATE TABLE task_participants (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('OWNER', 'ASSIGNEE', 'REVIEWER', 'COLLABORATOR')),
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, user_id, role)
+);
+
+-- Task lists filter by participant, optionally by role
+CREATE INDEX idx_task_participants_user_role ON task_participants(user_id, role);
+
+CREATE OR REPLACE FUNCTION sync_task_assignee_participant()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.assignee_id = OLD.assignee_id THEN
+            RETURN NEW;
+        END IF;
+        -- Reassigning replaces the primary assignee
+        DELETE FROM task_participants
+        WHERE task_id = OLD.id AND user_id = OLD.assignee_id AND role = 'ASSIGNEE';
+    END IF;
+
+    INSERT INTO task_participants (task_id, user_id, role)
+    VALUES (NEW.id, NEW.assignee_id, 'ASSIGNEE')
+    ON CONFLICT (task_id, user_id, role) DO NOTHING;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER sync_tasks_assignee_participant AFTER INSERT OR UPDATE OF assignee_id ON tasks FOR EACH ROW EXECUTE FUNCTION sync_task_assignee_participant();
+
+-- Existing tasks: the assignee, and whoever created the task as its owner
+INSERT INTO task_participants (task_id, user_id, role, added_at)
+SELECT id, assignee_id, 'ASSIGNEE', created_at FROM tasks
+ON CONFLICT DO NOTHING;
+
+-- Tasks created by the system user (seeds, scheduled generation) have no owner
+INSERT INTO task_participants (task_id, user_id, role, added_at)
+SELECT DISTINCT ON (th.task_id) th.task_id, th.actor_id, 'OWNER', th.timestamp
+FROM task_history th
+WHERE upper(th.action) = 'CREATED' AND th.actor_id <> '00000000-0000-0000-0000-000000000002'
+ORDER BY th.task_id, th.timestamp
+ON CONFLICT DO NOTHING;
+
+-- CreateTask has not recorded CREATED history, so most tasks have none; their assignee, the
+-- only user such a task records, owns them
+INSERT INTO task_participants (task_id, user_id, role, added_at)
+SELECT t.id, t.assignee_id, 'OWNER', t.created_at
+FROM tasks t
+WHERE NOT EXISTS (
+    SELECT 1 FROM task_history th WHERE th.task_id = t.id AND upper(th.action) = 'CREATED'
+)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,26 @@
+-- Task creator
+-- Who created (reported) a task, as categories and tags record theirs. Tasks created before
+-- this migration take the actor of their CREATED history entry. CreateTask did not record one
+-- until now, so most tasks have none and keep no creator rather than a guessed one.
+
+ALTER TABLE tasks ADD COLUMN creator_id UUID REFERENCES users(id);
+
+-- Filling in the creator is not an edit, so versions and update times stay as they are
+ALTER TABLE tasks DISABLE TRIGGER update_tasks_updated_at;
+ALTER TABLE tasks DISABLE TRIGGER handle_tasks_version;
+
+UPDATE tasks t
+SET creator_id = created.actor_id
+FROM (
+    SELECT DISTINCT ON (task_id) task_id, actor_id
+    FROM task_history
+    WHERE upper(action) = 'CREATED'
+    ORDER BY task_id, timestamp
+) created
+WHERE created.task_id = t.id;
+
+ALTER TABLE tasks ENABLE TRIGGER update_tasks_updated_at;
+ALTER TABLE tasks ENABLE TRIGGER handle_tasks_version;
+
+-- "Tasks I created" lists
+CREATE INDEX idx_tasks_creator_id ON tasks(creator_id) WHERE NOT is_deleted;
@@ -0,0 +1,8 @@
+-- System user
+-- Work the service starts itself, such as imports and scheduled jobs, is recorded as the System
+-- user, and so are the default workflow and auto-created tags. History, creators and workflows
+-- reference users, so the user has to exist without the seed data too.
+
+INSERT INTO users (id, name, email, role)
+VALUES ('00000000-0000-0000-0000-000000000002', 'System', 'system@todo-app.com', 'admin')
+ON CONFLICT DO NOTHING;
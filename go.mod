@@ -7,6 +7,7 @@ require (
 	github.com/lib/pq v1.10.9
 	google.golang.org/grpc v1.62.1
 	google.golang.org/protobuf v1.32.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
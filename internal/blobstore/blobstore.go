@@ -0,0 +1,95 @@
+// Package blobstore keeps attachment content outside the database.
+//
+// Two repository.BlobStore implementations are provided: LocalStore writes blobs as files
+// under a directory and S3Store talks to any S3-compatible object store (AWS S3, MinIO, ...).
+// Keys are relative, slash separated paths such as "tasks/<task id>/<attachment id>".
+package blobstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/todo-app/services/admin-service/internal/config"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+// ErrSizeMismatch is returned by Put when the content is shorter or longer than its declared size
+var ErrSizeMismatch = errors.New("blob content does not match its declared size")
+
+// New creates the blob store selected by the attachment configuration
+func New(cfg config.AttachmentConfig) (repository.BlobStore, error) {
+	switch cfg.Storage {
+	case "local":
+		return NewLocalStore(cfg.LocalDir)
+	case "s3":
+		return NewS3Store(S3Options{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			PathStyle:       cfg.S3.PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob storage %q", cfg.Storage)
+	}
+}
+
+// validateKey accepts relative paths made of letters, digits, ".", "-" and "_"
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("blob key is required")
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+		for _, r := range segment {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_') {
+				return fmt.Errorf("invalid blob key %q", key)
+			}
+		}
+	}
+	return nil
+}
+
+// exactReader reads exactly size bytes from r and fails with ErrSizeMismatch when r ends
+// early or holds more data
+type exactReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func newExactReader(r io.Reader, size int64) *exactReader {
+	return &exactReader{r: r, remaining: size}
+}
+
+func (e *exactReader) Read(p []byte) (int, error) {
+	if e.remaining <= 0 {
+		// Anything after the declared size means the caller got the size wrong
+		var probe [1]byte
+		n, err := io.ReadFull(e.r, probe[:])
+		if n > 0 {
+			return 0, ErrSizeMismatch
+		}
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > e.remaining {
+		p = p[:e.remaining]
+	}
+	n, err := e.r.Read(p)
+	e.remaining -= int64(n)
+	if err == io.EOF {
+		if e.remaining > 0 {
+			return n, ErrSizeMismatch
+		}
+		err = nil
+	}
+	return n, err
+}
@@ -0,0 +1,102 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := NewLocalStore(root)
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+
+	key := "tasks/task-1/attachment-1"
+	if err := store.Put(ctx, key, strings.NewReader("hello"), 5); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if got := readBlob(t, store, key); got != "hello" {
+		t.Errorf("Get() = %q, want %q", got, "hello")
+	}
+
+	// Put replaces an existing blob
+	if err := store.Put(ctx, key, strings.NewReader("bye"), 3); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if got := readBlob(t, store, key); got != "bye" {
+		t.Errorf("Get() after replace = %q, want %q", got, "bye")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, key); !domain.IsNotFoundError(err) {
+		t.Errorf("Get() after Delete error = %v, want not found", err)
+	}
+	// Deleting again succeeds and the emptied task directory is gone
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of missing blob error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "tasks")); !os.IsNotExist(err) {
+		t.Errorf("empty directories left behind: %v", err)
+	}
+}
+
+func TestLocalStore_RejectsBadInput(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+
+	for _, key := range []string{"", "../escape", "tasks//x", "/abs", "tasks/a b"} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1); err == nil {
+			t.Errorf("Put(%q) expected error", key)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		size    int64
+	}{
+		{"short content", "abc", 5},
+		{"long content", "abcdef", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := store.Put(ctx, "tasks/t/a", strings.NewReader(tt.content), tt.size)
+			if !errors.Is(err, ErrSizeMismatch) {
+				t.Fatalf("Put() error = %v, want ErrSizeMismatch", err)
+			}
+			// A failed upload leaves nothing behind
+			if _, err := store.Get(ctx, "tasks/t/a"); !domain.IsNotFoundError(err) {
+				t.Errorf("Get() error = %v, want not found", err)
+			}
+		})
+	}
+}
+
+func readBlob(t *testing.T, store repository.BlobStore, key string) string {
+	t.Helper()
+	body, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%q) error = %v", key, err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("reading %q: %v", key, err)
+	}
+	return string(data)
+}
@@ -1,10 +1,20 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"math"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds all configuration for the admin service
@@ -17,11 +27,36 @@ type Config struct {
 
 	// Logging configuration
 	LogLevel string `json:"log_level"`
+
+	// Rate limiting configuration
+	RateLimit RateLimitConfig `json:"rate_limit"`
+
+	// Idempotency key configuration
+	Idempotency IdempotencyConfig `json:"idempotency"`
+
+	// Category hierarchy configuration
+	Categories CategoryConfig `json:"categories"`
+
+	// Tag configuration
+	Tags TagConfig `json:"tags"`
+
+	// Task hierarchy configuration
+	Tasks TaskConfig `json:"tasks"`
+
+	// Task attachment configuration
+	Attachments AttachmentConfig `json:"attachments"`
+
+	// Watcher notification configuration
+	Notifications NotificationConfig `json:"notifications"`
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
 	Port int `json:"port"`
+	// TrustGatewayIdentity accepts the caller identity in x-user-id / x-user-role metadata.
+	// Enable it only when every call arrives through an API gateway that authenticates the
+	// caller and overwrites those headers; otherwise callers are anonymous.
+	TrustGatewayIdentity bool `json:"trust_gateway_identity"`
 }
 
 // DatabaseConfig holds database connection settings
@@ -30,36 +65,544 @@ type DatabaseConfig struct {
 	Port            int           `json:"port"`
 	Name            string        `json:"name"`
 	User            string        `json:"user"`
-	Password        string        `json:"password"`
+	Password        string        `json:"-"`
+	PasswordFile    string        `json:"password_file"`
 	SSLMode         string        `json:"ssl_mode"`
 	MaxOpenConns    int           `json:"max_open_conns"`
 	MaxIdleConns    int           `json:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
 }
 
-// LoadConfig loads configuration from environment variables with sensible defaults
-func LoadConfig() (*Config, error) {
-	config := &Config{
-		LogLevel: getEnvString("LOG_LEVEL", "info"),
+// RateLimitConfig holds per-caller request rate limits
+type RateLimitConfig struct {
+	Enabled           bool                   `json:"enabled"`
+	RequestsPerSecond float64                `json:"requests_per_second"`
+	Burst             int                    `json:"burst"`
+	Methods           map[string]MethodLimit `json:"methods"`      // keyed by full gRPC method name
+	DailyQuotas       map[string]int         `json:"daily_quotas"` // keyed by full gRPC method name
+}
+
+// IdempotencyConfig controls how long idempotent responses are kept
+type IdempotencyConfig struct {
+	TTL         time.Duration `json:"ttl"`
+	LockTimeout time.Duration `json:"lock_timeout"`
+}
+
+// CategoryConfig limits the category hierarchy
+type CategoryConfig struct {
+	MaxDepth int `json:"max_depth"` // levels allowed in a category tree, top level included
+}
+
+// TagConfig holds tag settings
+type TagConfig struct {
+	SuggestTimeout time.Duration `json:"suggest_timeout"` // latency budget of SuggestTags
+}
+
+// TaskConfig holds the rules between parent tasks and their subtasks
+type TaskConfig struct {
+	BlockParentCompletion bool          `json:"block_parent_completion"` // a parent cannot be completed while subtasks are open
+	AutoCompleteParent    bool          `json:"auto_complete_parent"`    // completing the last open subtask completes the parent
+	RecurrenceInterval    time.Duration `json:"recurrence_interval"`     // how often due recurring tasks are generated; 0 disables the scheduler
+}
+
+// AttachmentConfig holds where attachment content is stored and what users may upload
+type AttachmentConfig struct {
+	Storage      string        `json:"storage"`       // blob store backend: local or s3
+	LocalDir     string        `json:"local_dir"`     // root directory of the local blob store
+	S3           S3Config      `json:"s3"`            // used when storage is s3
+	MaxSize      int64         `json:"max_size"`      // bytes per attachment
+	AllowedTypes []string      `json:"allowed_types"` // accepted media types, "image/*" style patterns allowed; empty accepts any type
+	UserQuota    int64         `json:"user_quota"`    // bytes of live attachments per uploader; 0 disables the quota
+	GCInterval   time.Duration `json:"gc_interval"`   // how often blobs of purged tasks are deleted; 0 disables the collector
+}
+
+// NotificationConfig holds when watchers are notified
+type NotificationConfig struct {
+	DispatchInterval time.Duration `json:"dispatch_interval"` // how often task activity is turned into notifications; 0 disables the dispatcher
+	DueSoon          time.Duration `json:"due_soon"`          // how long before its due date a task is announced; 0 disables the announcements
+	DigestHour       int           `json:"digest_hour"`       // hour of the day, in each user's time zone, daily digests are assembled at
+}
+
+// S3Config holds the connection settings of an S3-compatible blob store
+type S3Config struct {
+	Endpoint            string `json:"endpoint"` // e.g. https://s3.eu-west-1.amazonaws.com or http://localhost:9000
+	Region              string `json:"region"`
+	Bucket              string `json:"bucket"`
+	AccessKeyID         string `json:"access_key_id"`
+	SecretAccessKey     string `json:"-"`
+	SecretAccessKeyFile string `json:"secret_access_key_file"`
+	PathStyle           bool   `json:"path_style"` // address the bucket in the path instead of the host name
+}
+
+// MethodLimit overrides the default token bucket for a single gRPC method
+type MethodLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+}
+
+// setting describes a single configuration value and where it can be supplied.
+// Each layer (file, env, flags) provides raw strings that are parsed strictly by set.
+type setting struct {
+	key  string // dotted key in the config file
+	env  string // environment variable name
+	flag string // command line flag name, empty if not settable from flags
+	set  func(cfg *Config, value string) error
+
+	// mapValue settings are written as a mapping in the config file and as
+	// comma-separated key=value pairs in env and flags
+	mapValue bool
+}
+
+// settings lists every configurable value, in the order they are documented
+var settings = []setting{
+	{key: "log_level", env: "LOG_LEVEL", flag: "log-level", set: func(c *Config, v string) error {
+		c.LogLevel = strings.ToLower(v)
+		return nil
+	}},
+	{key: "server.port", env: "SERVER_PORT", flag: "port", set: func(c *Config, v string) error {
+		return parseInt(v, &c.Server.Port)
+	}},
+	{key: "server.trust_gateway_identity", env: "SERVER_TRUST_GATEWAY_IDENTITY", flag: "trust-gateway-identity", set: func(c *Config, v string) error {
+		return parseBool(v, &c.Server.TrustGatewayIdentity)
+	}},
+	{key: "database.host", env: "DB_HOST", flag: "db-host", set: func(c *Config, v string) error {
+		c.Database.Host = v
+		return nil
+	}},
+	{key: "database.port", env: "DB_PORT", flag: "db-port", set: func(c *Config, v string) error {
+		return parseInt(v, &c.Database.Port)
+	}},
+	{key: "database.name", env: "DB_NAME", flag: "db-name", set: func(c *Config, v string) error {
+		c.Database.Name = v
+		return nil
+	}},
+	{key: "database.user", env: "DB_USER", flag: "db-user", set: func(c *Config, v string) error {
+		c.Database.User = v
+		return nil
+	}},
+	// Passwords are deliberately not accepted as flags since they would show up in process listings
+	{key: "database.password", env: "DB_PASSWORD", set: func(c *Config, v string) error {
+		c.Database.Password = v
+		return nil
+	}},
+	{key: "database.password_file", env: "DB_PASSWORD_FILE", flag: "db-password-file", set: func(c *Config, v string) error {
+		c.Database.PasswordFile = v
+		return nil
+	}},
+	{key: "database.ssl_mode", env: "DB_SSL_MODE", flag: "db-ssl-mode", set: func(c *Config, v string) error {
+		c.Database.SSLMode = v
+		return nil
+	}},
+	{key: "database.max_open_conns", env: "DB_MAX_OPEN_CONNS", flag: "db-max-open-conns", set: func(c *Config, v string) error {
+		return parseInt(v, &c.Database.MaxOpenConns)
+	}},
+	{key: "database.max_idle_conns", env: "DB_MAX_IDLE_CONNS", flag: "db-max-idle-conns", set: func(c *Config, v string) error {
+		return parseInt(v, &c.Database.MaxIdleConns)
+	}},
+	{key: "database.conn_max_lifetime", env: "DB_CONN_MAX_LIFETIME", flag: "db-conn-max-lifetime", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.Database.ConnMaxLifetime)
+	}},
+	{key: "rate_limit.enabled", env: "RATE_LIMIT_ENABLED", flag: "rate-limit-enabled", set: func(c *Config, v string) error {
+		return parseBool(v, &c.RateLimit.Enabled)
+	}},
+	{key: "rate_limit.requests_per_second", env: "RATE_LIMIT_RPS", flag: "rate-limit-rps", set: func(c *Config, v string) error {
+		return parseFloat(v, &c.RateLimit.RequestsPerSecond)
+	}},
+	{key: "rate_limit.burst", env: "RATE_LIMIT_BURST", flag: "rate-limit-burst", set: func(c *Config, v string) error {
+		return parseInt(v, &c.RateLimit.Burst)
+	}},
+	{key: "rate_limit.methods", env: "RATE_LIMIT_METHODS", flag: "rate-limit-methods", mapValue: true, set: func(c *Config, v string) error {
+		return parseMethodLimits(v, &c.RateLimit.Methods)
+	}},
+	{key: "rate_limit.daily_quotas", env: "RATE_LIMIT_DAILY_QUOTAS", flag: "rate-limit-daily-quotas", mapValue: true, set: func(c *Config, v string) error {
+		return parseQuotas(v, &c.RateLimit.DailyQuotas)
+	}},
+	{key: "idempotency.ttl", env: "IDEMPOTENCY_TTL", flag: "idempotency-ttl", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.Idempotency.TTL)
+	}},
+	{key: "idempotency.lock_timeout", env: "IDEMPOTENCY_LOCK_TIMEOUT", flag: "idempotency-lock-timeout", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.Idempotency.LockTimeout)
+	}},
+	{key: "categories.max_depth", env: "CATEGORY_MAX_DEPTH", flag: "category-max-depth", set: func(c *Config, v string) error {
+		return parseInt(v, &c.Categories.MaxDepth)
+	}},
+	{key: "tags.suggest_timeout", env: "TAG_SUGGEST_TIMEOUT", flag: "tag-suggest-timeout", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.Tags.SuggestTimeout)
+	}},
+	{key: "tasks.block_parent_completion", env: "TASK_BLOCK_PARENT_COMPLETION", flag: "task-block-parent-completion", set: func(c *Config, v string) error {
+		return parseBool(v, &c.Tasks.BlockParentCompletion)
+	}},
+	{key: "tasks.auto_complete_parent", env: "TASK_AUTO_COMPLETE_PARENT", flag: "task-auto-complete-parent", set: func(c *Config, v string) error {
+		return parseBool(v, &c.Tasks.AutoCompleteParent)
+	}},
+	{key: "tasks.recurrence_interval", env: "TASK_RECURRENCE_INTERVAL", flag: "task-recurrence-interval", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.Tasks.RecurrenceInterval)
+	}},
+	{key: "attachments.storage", env: "ATTACHMENT_STORAGE", flag: "attachment-storage", set: func(c *Config, v string) error {
+		c.Attachments.Storage = strings.ToLower(v)
+		return nil
+	}},
+	{key: "attachments.local_dir", env: "ATTACHMENT_LOCAL_DIR", flag: "attachment-local-dir", set: func(c *Config, v string) error {
+		c.Attachments.LocalDir = v
+		return nil
+	}},
+	{key: "attachments.s3.endpoint", env: "ATTACHMENT_S3_ENDPOINT", flag: "attachment-s3-endpoint", set: func(c *Config, v string) error {
+		c.Attachments.S3.Endpoint = v
+		return nil
+	}},
+	{key: "attachments.s3.region", env: "ATTACHMENT_S3_REGION", flag: "attachment-s3-region", set: func(c *Config, v string) error {
+		c.Attachments.S3.Region = v
+		return nil
+	}},
+	{key: "attachments.s3.bucket", env: "ATTACHMENT_S3_BUCKET", flag: "attachment-s3-bucket", set: func(c *Config, v string) error {
+		c.Attachments.S3.Bucket = v
+		return nil
+	}},
+	{key: "attachments.s3.access_key_id", env: "ATTACHMENT_S3_ACCESS_KEY_ID", flag: "attachment-s3-access-key-id", set: func(c *Config, v string) error {
+		c.Attachments.S3.AccessKeyID = v
+		return nil
+	}},
+	// Like database.password, the secret key is kept out of process listings
+	{key: "attachments.s3.secret_access_key", env: "ATTACHMENT_S3_SECRET_ACCESS_KEY", set: func(c *Config, v string) error {
+		c.Attachments.S3.SecretAccessKey = v
+		return nil
+	}},
+	{key: "attachments.s3.secret_access_key_file", env: "ATTACHMENT_S3_SECRET_ACCESS_KEY_FILE", flag: "attachment-s3-secret-access-key-file", set: func(c *Config, v string) error {
+		c.Attachments.S3.SecretAccessKeyFile = v
+		return nil
+	}},
+	{key: "attachments.s3.path_style", env: "ATTACHMENT_S3_PATH_STYLE", flag: "attachment-s3-path-style", set: func(c *Config, v string) error {
+		return parseBool(v, &c.Attachments.S3.PathStyle)
+	}},
+	{key: "attachments.max_size", env: "ATTACHMENT_MAX_SIZE", flag: "attachment-max-size", set: func(c *Config, v string) error {
+		return parseSize(v, &c.Attachments.MaxSize)
+	}},
+	{key: "attachments.allowed_types", env: "ATTACHMENT_ALLOWED_TYPES", flag: "attachment-allowed-types", set: func(c *Config, v string) error {
+		c.Attachments.AllowedTypes = parseList(v)
+		return nil
+	}},
+	{key: "attachments.user_quota", env: "ATTACHMENT_USER_QUOTA", flag: "attachment-user-quota", set: func(c *Config, v string) error {
+		return parseSize(v, &c.Attachments.UserQuota)
+	}},
+	{key: "attachments.gc_interval", env: "ATTACHMENT_GC_INTERVAL", flag: "attachment-gc-interval", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.Attachments.GCInterval)
+	}},
+	{key: "notifications.dispatch_interval", env: "NOTIFICATION_DISPATCH_INTERVAL", flag: "notification-dispatch-interval", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.Notifications.DispatchInterval)
+	}},
+	{key: "notifications.due_soon", env: "NOTIFICATION_DUE_SOON", flag: "notification-due-soon", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.Notifications.DueSoon)
+	}},
+	{key: "notifications.digest_hour", env: "NOTIFICATION_DIGEST_HOUR", flag: "notification-digest-hour", set: func(c *Config, v string) error {
+		return parseInt(v, &c.Notifications.DigestHour)
+	}},
+}
+
+// ConfigFileEnv names the environment variable that points at a config file
+const ConfigFileEnv = "CONFIG_FILE"
+
+// DefaultConfig returns the built-in configuration defaults
+func DefaultConfig() *Config {
+	return &Config{
+		LogLevel: "info",
 
 		Server: ServerConfig{
-			Port: getEnvInt("SERVER_PORT", 8080),
+			Port: 8080,
 		},
 
 		Database: DatabaseConfig{
-			Host:            getEnvString("DB_HOST", "localhost"),
-			Port:            getEnvInt("DB_PORT", 5432),
-			Name:            getEnvString("DB_NAME", "todo_app"),
-			User:            getEnvString("DB_USER", "postgres"),
-			Password:        getEnvString("DB_PASSWORD", "postgres"),
-			SSLMode:         getEnvString("DB_SSL_MODE", "disable"),
-			MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
-			MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
-			ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+			Host:            "localhost",
+			Port:            5432,
+			Name:            "todo_app",
+			User:            "postgres",
+			Password:        "postgres",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 5 * time.Minute,
+		},
+
+		RateLimit: RateLimitConfig{
+			Enabled:           true,
+			RequestsPerSecond: 20,
+			Burst:             40,
+			Methods: map[string]MethodLimit{
+				// Mobile clients sync in the background; a looping client must not exhaust the pool
+				"/todo.v1.UserService/SyncTasks": {RequestsPerSecond: 1, Burst: 5},
+			},
+		},
+
+		Idempotency: IdempotencyConfig{
+			TTL:         24 * time.Hour,
+			LockTimeout: 30 * time.Second,
 		},
+
+		Categories: CategoryConfig{
+			MaxDepth: 5,
+		},
+
+		Tags: TagConfig{
+			SuggestTimeout: 200 * time.Millisecond,
+		},
+
+		Tasks: TaskConfig{
+			BlockParentCompletion: true,
+			RecurrenceInterval:    15 * time.Minute,
+		},
+
+		Attachments: AttachmentConfig{
+			Storage:  "local",
+			LocalDir: "data/attachments",
+			S3: S3Config{
+				Region: "us-east-1",
+			},
+			MaxSize:      25 << 20,
+			AllowedTypes: []string{"image/*", "application/pdf", "text/plain", "text/csv"},
+			UserQuota:    1 << 30,
+			GCInterval:   time.Hour,
+		},
+
+		Notifications: NotificationConfig{
+			DispatchInterval: time.Minute,
+			DueSoon:          24 * time.Hour,
+			DigestHour:       8,
+		},
+	}
+}
+
+// LoadConfig loads configuration from defaults, an optional config file (CONFIG_FILE)
+// and environment variables
+func LoadConfig() (*Config, error) {
+	return Load(nil)
+}
+
+// Load builds the configuration in layers: defaults, then the YAML/JSON config file
+// (-config flag or CONFIG_FILE), then environment variables, then command line flags.
+// Every value is validated and all problems are reported together.
+func Load(args []string) (*Config, error) {
+	fs := flag.NewFlagSet("admin-service", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv(ConfigFileEnv), "path to a YAML or JSON config file")
+	flagValues := make(map[string]*string, len(settings))
+	for _, s := range settings {
+		if s.flag != "" {
+			flagValues[s.flag] = fs.String(s.flag, "", fmt.Sprintf("overrides %s (env %s)", s.key, s.env))
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("invalid command line: %w", err)
+	}
+
+	cfg := DefaultConfig()
+	var errs []error
+
+	// Layer 1: config file
+	if *configFile != "" {
+		values, err := readConfigFile(*configFile)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, applyFileValues(cfg, *configFile, values)...)
+	}
+
+	// Layer 2: environment variables
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env); ok && value != "" {
+			if err := s.set(cfg, value); err != nil {
+				errs = append(errs, fmt.Errorf("env %s: %w", s.env, err))
+			}
+		}
+	}
+
+	// Layer 3: command line flags (only those explicitly set)
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.flag == f.Name {
+				if err := s.set(cfg, *flagValues[f.Name]); err != nil {
+					errs = append(errs, fmt.Errorf("flag -%s: %w", f.Name, err))
+				}
+			}
+		}
+	})
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+
+	// Secrets from files take precedence over inline values
+	if cfg.Database.PasswordFile != "" {
+		password, err := readSecretFile(cfg.Database.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration: database password file: %w", err)
+		}
+		cfg.Database.Password = password
+	}
+	if cfg.Attachments.S3.SecretAccessKeyFile != "" {
+		secret, err := readSecretFile(cfg.Attachments.S3.SecretAccessKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration: attachment S3 secret access key file: %w", err)
+		}
+		cfg.Attachments.S3.SecretAccessKey = secret
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that every configuration value is usable
+func (c *Config) Validate() error {
+	var errs []error
+
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log_level must be one of debug, info, warn, error (got %q)", c.LogLevel))
+	}
+
+	if c.Server.Port < 0 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port must be between 0 and 65535 (got %d)", c.Server.Port))
+	}
+
+	if c.Database.Host == "" {
+		errs = append(errs, errors.New("database.host is required"))
+	}
+	if c.Database.Port < 1 || c.Database.Port > 65535 {
+		errs = append(errs, fmt.Errorf("database.port must be between 1 and 65535 (got %d)", c.Database.Port))
+	}
+	if c.Database.Name == "" {
+		errs = append(errs, errors.New("database.name is required"))
+	}
+	if c.Database.User == "" {
+		errs = append(errs, errors.New("database.user is required"))
+	}
+	switch c.Database.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		errs = append(errs, fmt.Errorf("database.ssl_mode %q is not a valid PostgreSQL sslmode", c.Database.SSLMode))
+	}
+	if c.Database.MaxOpenConns < 1 {
+		errs = append(errs, fmt.Errorf("database.max_open_conns must be positive (got %d)", c.Database.MaxOpenConns))
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("database.max_idle_conns must be between 0 and max_open_conns (got %d)", c.Database.MaxIdleConns))
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		errs = append(errs, fmt.Errorf("database.conn_max_lifetime must not be negative (got %s)", c.Database.ConnMaxLifetime))
+	}
+
+	errs = append(errs, c.RateLimit.validate()...)
+
+	if c.Idempotency.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("idempotency.ttl must be positive (got %s)", c.Idempotency.TTL))
+	}
+	if c.Idempotency.LockTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("idempotency.lock_timeout must be positive (got %s)", c.Idempotency.LockTimeout))
+	}
+
+	if c.Categories.MaxDepth < 1 {
+		errs = append(errs, fmt.Errorf("categories.max_depth must be at least 1 (got %d)", c.Categories.MaxDepth))
+	}
+
+	if c.Tags.SuggestTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("tags.suggest_timeout must be positive (got %s)", c.Tags.SuggestTimeout))
 	}
 
-	return config, nil
+	if c.Tasks.RecurrenceInterval < 0 {
+		errs = append(errs, fmt.Errorf("tasks.recurrence_interval must not be negative (got %s)", c.Tasks.RecurrenceInterval))
+	}
+
+	errs = append(errs, c.Attachments.validate()...)
+
+	if c.Notifications.DispatchInterval < 0 {
+		errs = append(errs, fmt.Errorf("notifications.dispatch_interval must not be negative (got %s)", c.Notifications.DispatchInterval))
+	}
+	if c.Notifications.DueSoon < 0 {
+		errs = append(errs, fmt.Errorf("notifications.due_soon must not be negative (got %s)", c.Notifications.DueSoon))
+	}
+	if c.Notifications.DigestHour < 0 || c.Notifications.DigestHour > 23 {
+		errs = append(errs, fmt.Errorf("notifications.digest_hour must be between 0 and 23 (got %d)", c.Notifications.DigestHour))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func (a AttachmentConfig) validate() []error {
+	var errs []error
+
+	switch a.Storage {
+	case "local":
+		if a.LocalDir == "" {
+			errs = append(errs, errors.New("attachments.local_dir is required for local storage"))
+		}
+	case "s3":
+		if a.S3.Endpoint == "" {
+			errs = append(errs, errors.New("attachments.s3.endpoint is required for s3 storage"))
+		} else if u, err := url.Parse(a.S3.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("attachments.s3.endpoint must be an http or https URL (got %q)", a.S3.Endpoint))
+		}
+		if a.S3.Region == "" {
+			errs = append(errs, errors.New("attachments.s3.region is required for s3 storage"))
+		}
+		if a.S3.Bucket == "" {
+			errs = append(errs, errors.New("attachments.s3.bucket is required for s3 storage"))
+		}
+		if a.S3.AccessKeyID == "" || a.S3.SecretAccessKey == "" {
+			errs = append(errs, errors.New("attachments.s3.access_key_id and secret_access_key are required for s3 storage"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("attachments.storage must be local or s3 (got %q)", a.Storage))
+	}
+
+	if a.MaxSize <= 0 {
+		errs = append(errs, fmt.Errorf("attachments.max_size must be positive (got %d)", a.MaxSize))
+	}
+	for _, pattern := range a.AllowedTypes {
+		if !strings.Contains(pattern, "/") {
+			errs = append(errs, fmt.Errorf("attachments.allowed_types: %q is not a media type such as image/png or image/*", pattern))
+		}
+	}
+	if a.UserQuota < 0 {
+		errs = append(errs, fmt.Errorf("attachments.user_quota must not be negative (got %d)", a.UserQuota))
+	}
+	if a.GCInterval < 0 {
+		errs = append(errs, fmt.Errorf("attachments.gc_interval must not be negative (got %s)", a.GCInterval))
+	}
+	return errs
+}
+
+func (r RateLimitConfig) validate() []error {
+	var errs []error
+
+	if r.RequestsPerSecond <= 0 {
+		errs = append(errs, fmt.Errorf("rate_limit.requests_per_second must be positive (got %v)", r.RequestsPerSecond))
+	}
+	if r.Burst < 1 {
+		errs = append(errs, fmt.Errorf("rate_limit.burst must be at least 1 (got %d)", r.Burst))
+	}
+	for _, method := range sortedKeys(r.Methods) {
+		limit := r.Methods[method]
+		if !strings.HasPrefix(method, "/") {
+			errs = append(errs, fmt.Errorf("rate_limit.methods: %q must be a full gRPC method name such as /todo.v1.UserService/SyncTasks", method))
+		}
+		if limit.RequestsPerSecond <= 0 || limit.Burst < 1 {
+			errs = append(errs, fmt.Errorf("rate_limit.methods: %s needs a positive rate and a burst of at least 1", method))
+		}
+	}
+	for _, method := range sortedKeys(r.DailyQuotas) {
+		if !strings.HasPrefix(method, "/") {
+			errs = append(errs, fmt.Errorf("rate_limit.daily_quotas: %q must be a full gRPC method name", method))
+		}
+		if r.DailyQuotas[method] < 1 {
+			errs = append(errs, fmt.Errorf("rate_limit.daily_quotas: %s must be positive (got %d)", method, r.DailyQuotas[method]))
+		}
+	}
+
+	return errs
 }
 
 // ConnectionString returns PostgreSQL connection string
@@ -70,28 +613,248 @@ func (d DatabaseConfig) ConnectionString() string {
 	)
 }
 
-// Helper functions for environment variable parsing
-func getEnvString(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// readConfigFile parses a YAML or JSON config file into flattened dotted keys
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	raw := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q (use .yaml, .yml or .json)", filepath.Ext(path))
 	}
-	return defaultValue
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	mapKeys := make(map[string]bool)
+	for _, s := range settings {
+		if s.mapValue {
+			mapKeys[s.key] = true
+		}
+	}
+	if err := flatten("", raw, mapKeys, values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return values, nil
 }
 
-func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intVal, err := strconv.Atoi(value); err == nil {
-			return intVal
+func applyFileValues(cfg *Config, path string, values map[string]string) []error {
+	known := make(map[string]setting, len(settings))
+	for _, s := range settings {
+		known[s.key] = s
+	}
+
+	// Sort keys so errors are reported deterministically
+	var errs []error
+	for _, key := range sortedKeys(values) {
+		s, ok := known[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("file %s: unknown key %q", path, key))
+			continue
+		}
+		if err := s.set(cfg, values[key]); err != nil {
+			errs = append(errs, fmt.Errorf("file %s: %s: %w", path, key, err))
 		}
 	}
-	return defaultValue
+	return errs
 }
 
-func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
-			return duration
+func flatten(prefix string, raw map[string]interface{}, mapKeys map[string]bool, out map[string]string) error {
+	for key, value := range raw {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if mapKeys[fullKey] {
+				// Map-valued settings use the same "key=value,..." form as env and flags
+				pairs := make([]string, 0, len(v))
+				for _, k := range sortedKeys(v) {
+					pairs = append(pairs, k+"="+scalarString(v[k]))
+				}
+				out[fullKey] = strings.Join(pairs, ",")
+				continue
+			}
+			if err := flatten(fullKey, v, mapKeys, out); err != nil {
+				return err
+			}
+		case []interface{}:
+			return fmt.Errorf("%s: lists are not supported", fullKey)
+		case nil:
+			// An explicit null keeps the lower layer's value
+		default:
+			out[fullKey] = scalarString(v)
+		}
+	}
+	return nil
+}
+
+func scalarString(value interface{}) string {
+	// JSON numbers decode as float64; keep integers free of exponent notation
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimRight(string(data), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return secret, nil
+}
+
+// Helper functions for strict value parsing
+func parseInt(value string, target *int) error {
+	intVal, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("%q is not a valid integer", value)
+	}
+	*target = intVal
+	return nil
+}
+
+// parseSize parses a byte count with an optional KB, MB or GB suffix (multiples of 1024)
+func parseSize(value string, target *int64) error {
+	number := strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"B", 1}} {
+		if trimmed, ok := strings.CutSuffix(number, unit.suffix); ok {
+			number, multiplier = strings.TrimSpace(trimmed), unit.size
+			break
+		}
+	}
+	size, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || size > math.MaxInt64/multiplier {
+		return fmt.Errorf("%q is not a valid size (e.g. 1048576, 512KB, 25MB)", value)
+	}
+	*target = size * multiplier
+	return nil
+}
+
+// parseList splits a comma-separated list, dropping empty items
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseDuration(value string, target *time.Duration) error {
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("%q is not a valid duration (e.g. 30s, 5m)", value)
+	}
+	*target = duration
+	return nil
+}
+
+func parseFloat(value string, target *float64) error {
+	floatVal, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid number", value)
+	}
+	*target = floatVal
+	return nil
+}
+
+func parseBool(value string, target *bool) error {
+	boolVal, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("%q is not a valid boolean", value)
+	}
+	*target = boolVal
+	return nil
+}
+
+// parsePairs splits "key=value,key=value" into a map
+func parsePairs(value string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("%q is not a key=value pair", pair)
+		}
+		pairs[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return pairs, nil
+}
+
+// parseMethodLimits parses "/pkg.Service/Method=rps:burst,..."
+func parseMethodLimits(value string, target *map[string]MethodLimit) error {
+	pairs, err := parsePairs(value)
+	if err != nil {
+		return err
+	}
+
+	limits := make(map[string]MethodLimit, len(pairs))
+	for method, spec := range pairs {
+		rate, burst, ok := strings.Cut(spec, ":")
+		if !ok {
+			return fmt.Errorf("%s: %q must be in the form rate:burst", method, spec)
+		}
+		var limit MethodLimit
+		if err := parseFloat(rate, &limit.RequestsPerSecond); err != nil {
+			return fmt.Errorf("%s: %w", method, err)
+		}
+		if err := parseInt(burst, &limit.Burst); err != nil {
+			return fmt.Errorf("%s: %w", method, err)
+		}
+		limits[method] = limit
+	}
+	*target = limits
+	return nil
+}
+
+// parseQuotas parses "/pkg.Service/Method=count,..."
+func parseQuotas(value string, target *map[string]int) error {
+	pairs, err := parsePairs(value)
+	if err != nil {
+		return err
+	}
+
+	quotas := make(map[string]int, len(pairs))
+	for method, count := range pairs {
+		var quota int
+		if err := parseInt(count, &quota); err != nil {
+			return fmt.Errorf("%s: %w", method, err)
 		}
+		quotas[method] = quota
 	}
-	return defaultValue
+	*target = quotas
+	return nil
 }
@@ -2,7 +2,10 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -96,3 +99,390 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoad_Layers(t *testing.T) {
+	s3SecretFile := writeFile(t, "s3_secret", "minio-secret\n")
+	yamlFile := writeFile(t, "config.yaml", `
+log_level: warn
+server:
+  port: 9090
+database:
+  host: filehost
+  port: 6543
+  conn_max_lifetime: 1m
+`)
+	jsonFile := writeFile(t, "config.json", `{"log_level": "error", "database": {"max_open_conns": 50}}`)
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		args  []string
+		check func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.Port != 8080 || cfg.Database.Host != "localhost" || cfg.LogLevel != "info" {
+					t.Errorf("unexpected defaults: %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "yaml file overrides defaults",
+			args: []string{"-config", yamlFile},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.LogLevel != "warn" || cfg.Server.Port != 9090 || cfg.Database.Host != "filehost" || cfg.Database.Port != 6543 {
+					t.Errorf("file values not applied: %+v", cfg)
+				}
+				if cfg.Database.ConnMaxLifetime != time.Minute {
+					t.Errorf("ConnMaxLifetime = %v, want 1m", cfg.Database.ConnMaxLifetime)
+				}
+				if cfg.Database.Name != "todo_app" {
+					t.Errorf("Database.Name = %v, want default todo_app", cfg.Database.Name)
+				}
+			},
+		},
+		{
+			name: "json file from CONFIG_FILE",
+			env:  map[string]string{ConfigFileEnv: jsonFile},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.LogLevel != "error" || cfg.Database.MaxOpenConns != 50 {
+					t.Errorf("file values not applied: %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "env overrides file",
+			env:  map[string]string{"DB_HOST": "envhost", "LOG_LEVEL": "debug"},
+			args: []string{"-config", yamlFile},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Database.Host != "envhost" || cfg.LogLevel != "debug" {
+					t.Errorf("env values not applied: %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "flags override env",
+			env:  map[string]string{"DB_HOST": "envhost", "SERVER_PORT": "7000"},
+			args: []string{"-config", yamlFile, "-db-host", "flaghost"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Database.Host != "flaghost" {
+					t.Errorf("Database.Host = %v, want flaghost", cfg.Database.Host)
+				}
+				if cfg.Server.Port != 7000 {
+					t.Errorf("Server.Port = %v, want 7000 from env", cfg.Server.Port)
+				}
+			},
+		},
+		{
+			name: "gateway identity trusted only when enabled",
+			args: []string{"-trust-gateway-identity=true"},
+			check: func(t *testing.T, cfg *Config) {
+				if !cfg.Server.TrustGatewayIdentity || DefaultConfig().Server.TrustGatewayIdentity {
+					t.Errorf("TrustGatewayIdentity = %v, want true from the flag and false by default", cfg.Server.TrustGatewayIdentity)
+				}
+			},
+		},
+		{
+			name: "task hierarchy rules",
+			env:  map[string]string{"TASK_AUTO_COMPLETE_PARENT": "true"},
+			args: []string{"-task-block-parent-completion=false"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Tasks.BlockParentCompletion || !cfg.Tasks.AutoCompleteParent {
+					t.Errorf("Tasks = %+v, want auto-completion without blocking", cfg.Tasks)
+				}
+			},
+		},
+		{
+			name: "recurrence scheduler disabled",
+			args: []string{"-task-recurrence-interval", "0s"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Tasks.RecurrenceInterval != 0 {
+					t.Errorf("Tasks.RecurrenceInterval = %v, want 0", cfg.Tasks.RecurrenceInterval)
+				}
+			},
+		},
+		{
+			name: "attachment limits",
+			env:  map[string]string{"ATTACHMENT_MAX_SIZE": "10MB", "ATTACHMENT_ALLOWED_TYPES": "image/*, application/pdf"},
+			args: []string{"-attachment-user-quota", "0"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Attachments.MaxSize != 10<<20 || cfg.Attachments.UserQuota != 0 {
+					t.Errorf("Attachments = %+v, want a 10MB limit without quota", cfg.Attachments)
+				}
+				if got := strings.Join(cfg.Attachments.AllowedTypes, "|"); got != "image/*|application/pdf" {
+					t.Errorf("Attachments.AllowedTypes = %v", cfg.Attachments.AllowedTypes)
+				}
+			},
+		},
+		{
+			name: "s3 attachment storage",
+			env: map[string]string{
+				"ATTACHMENT_STORAGE":                   "s3",
+				"ATTACHMENT_S3_ENDPOINT":               "http://localhost:9000",
+				"ATTACHMENT_S3_BUCKET":                 "attachments",
+				"ATTACHMENT_S3_ACCESS_KEY_ID":          "minio",
+				"ATTACHMENT_S3_SECRET_ACCESS_KEY_FILE": s3SecretFile,
+			},
+			args: []string{"-attachment-s3-path-style=true"},
+			check: func(t *testing.T, cfg *Config) {
+				s3 := cfg.Attachments.S3
+				if s3.SecretAccessKey != "minio-secret" || !s3.PathStyle || s3.Region != "us-east-1" {
+					t.Errorf("Attachments.S3 = %+v, want secret from file, path style and default region", s3)
+				}
+			},
+		},
+		{
+			name: "notification schedule",
+			env:  map[string]string{"NOTIFICATION_DUE_SOON": "2h"},
+			args: []string{"-notification-digest-hour", "18"},
+			check: func(t *testing.T, cfg *Config) {
+				n := cfg.Notifications
+				if n.DueSoon != 2*time.Hour || n.DigestHour != 18 || n.DispatchInterval != time.Minute {
+					t.Errorf("Notifications = %+v, want 2h due soon, 18h digests and the default interval", n)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load(tt.args)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoad_InvalidValues(t *testing.T) {
+	unknownKeyFile := writeFile(t, "unknown.yaml", "database:\n  hots: typo\n")
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		args    []string
+		wantMsg string
+	}{
+		{
+			name:    "non-numeric port in env",
+			env:     map[string]string{"DB_PORT": "abc"},
+			wantMsg: "env DB_PORT",
+		},
+		{
+			name:    "invalid duration flag",
+			args:    []string{"-db-conn-max-lifetime", "forever"},
+			wantMsg: "flag -db-conn-max-lifetime",
+		},
+		{
+			name:    "unknown log level",
+			env:     map[string]string{"LOG_LEVEL": "verbose"},
+			wantMsg: "log_level",
+		},
+		{
+			name:    "port out of range",
+			args:    []string{"-port", "70000"},
+			wantMsg: "server.port",
+		},
+		{
+			name:    "idle connections exceed open connections",
+			env:     map[string]string{"DB_MAX_OPEN_CONNS": "2", "DB_MAX_IDLE_CONNS": "5"},
+			wantMsg: "database.max_idle_conns",
+		},
+		{
+			name:    "invalid ssl mode",
+			env:     map[string]string{"DB_SSL_MODE": "sometimes"},
+			wantMsg: "database.ssl_mode",
+		},
+		{
+			name:    "category depth below one",
+			env:     map[string]string{"CATEGORY_MAX_DEPTH": "0"},
+			wantMsg: "categories.max_depth",
+		},
+		{
+			name:    "zero tag suggestion timeout",
+			env:     map[string]string{"TAG_SUGGEST_TIMEOUT": "0s"},
+			wantMsg: "tags.suggest_timeout",
+		},
+		{
+			name:    "invalid task rule boolean",
+			env:     map[string]string{"TASK_AUTO_COMPLETE_PARENT": "sometimes"},
+			wantMsg: "env TASK_AUTO_COMPLETE_PARENT",
+		},
+		{
+			name:    "negative recurrence interval",
+			env:     map[string]string{"TASK_RECURRENCE_INTERVAL": "-1m"},
+			wantMsg: "tasks.recurrence_interval",
+		},
+		{
+			name:    "invalid attachment size",
+			env:     map[string]string{"ATTACHMENT_MAX_SIZE": "lots"},
+			wantMsg: "env ATTACHMENT_MAX_SIZE",
+		},
+		{
+			name:    "unknown attachment storage",
+			args:    []string{"-attachment-storage", "ftp"},
+			wantMsg: "attachments.storage",
+		},
+		{
+			name:    "s3 storage without bucket",
+			env:     map[string]string{"ATTACHMENT_STORAGE": "s3", "ATTACHMENT_S3_ENDPOINT": "http://localhost:9000"},
+			wantMsg: "attachments.s3.bucket",
+		},
+		{
+			name:    "attachment type without subtype",
+			env:     map[string]string{"ATTACHMENT_ALLOWED_TYPES": "image"},
+			wantMsg: "attachments.allowed_types",
+		},
+		{
+			name:    "digest hour out of range",
+			env:     map[string]string{"NOTIFICATION_DIGEST_HOUR": "24"},
+			wantMsg: "notifications.digest_hour",
+		},
+		{
+			name:    "negative due soon window",
+			args:    []string{"-notification-due-soon", "-1h"},
+			wantMsg: "notifications.due_soon",
+		},
+		{
+			name:    "unknown file key",
+			args:    []string{"-config", unknownKeyFile},
+			wantMsg: `unknown key "database.hots"`,
+		},
+		{
+			name:    "missing config file",
+			args:    []string{"-config", filepath.Join(t.TempDir(), "missing.yaml")},
+			wantMsg: "failed to read config file",
+		},
+		{
+			name:    "missing password file",
+			env:     map[string]string{"DB_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing")},
+			wantMsg: "database password file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := Load(tt.args)
+			if err == nil {
+				t.Fatal("Load() expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Load() error = %v, want message containing %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestLoad_RateLimit(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+rate_limit:
+  requests_per_second: 5
+  burst: 10
+  methods:
+    /todo.v1.UserService/SyncTasks: "0.5:2"
+  daily_quotas:
+    /todo.v1.AdminService/ListTasks: 1000
+`)
+
+	cfg, err := Load([]string{"-config", path})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.RateLimit.Enabled || cfg.RateLimit.RequestsPerSecond != 5 || cfg.RateLimit.Burst != 10 {
+		t.Errorf("unexpected rate limit: %+v", cfg.RateLimit)
+	}
+	want := MethodLimit{RequestsPerSecond: 0.5, Burst: 2}
+	if got := cfg.RateLimit.Methods["/todo.v1.UserService/SyncTasks"]; got != want {
+		t.Errorf("SyncTasks limit = %+v, want %+v", got, want)
+	}
+	if got := cfg.RateLimit.DailyQuotas["/todo.v1.AdminService/ListTasks"]; got != 1000 {
+		t.Errorf("ListTasks quota = %d, want 1000", got)
+	}
+
+	// Env replaces the whole method map
+	t.Setenv("RATE_LIMIT_METHODS", "/todo.v1.AdminService/ListTasks=2:4")
+	cfg, err = Load([]string{"-config", path})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.RateLimit.Methods) != 1 || cfg.RateLimit.Methods["/todo.v1.AdminService/ListTasks"].Burst != 4 {
+		t.Errorf("Methods = %+v, want only ListTasks from env", cfg.RateLimit.Methods)
+	}
+
+	t.Setenv("RATE_LIMIT_METHODS", "SyncTasks=fast")
+	if _, err := Load(nil); err == nil || !strings.Contains(err.Error(), "env RATE_LIMIT_METHODS") {
+		t.Errorf("Load() error = %v, want RATE_LIMIT_METHODS error", err)
+	}
+}
+
+func TestLoad_PasswordFile(t *testing.T) {
+	passwordFile := writeFile(t, "db_password", "s3cret\n")
+	t.Setenv("DB_PASSWORD", "inline")
+	t.Setenv("DB_PASSWORD_FILE", passwordFile)
+
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Database.Password != "s3cret" {
+		t.Errorf("Database.Password = %q, want %q", cfg.Database.Password, "s3cret")
+	}
+}
+
+func TestReloader_Reload(t *testing.T) {
+	path := writeFile(t, "config.yaml", "log_level: info\nserver:\n  port: 9090\n")
+	args := []string{"-config", path}
+
+	cfg, err := Load(args)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	reloader := NewReloader(cfg, args)
+	var notified *Config
+	reloader.Subscribe(func(c *Config) { notified = c })
+
+	// Only hot-reloadable settings change; the port needs a restart
+	if err := os.WriteFile(path, []byte("log_level: debug\nserver:\n  port: 9191\n"), 0o600); err != nil {
+		t.Fatalf("Failed to rewrite config: %v", err)
+	}
+	if _, err := reloader.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if notified == nil || notified.LogLevel != "debug" {
+		t.Fatalf("subscriber not notified with new log level: %+v", notified)
+	}
+	if current := reloader.Current(); current.LogLevel != "debug" || current.Server.Port != 9090 {
+		t.Errorf("Current() = %+v, want log level debug and port 9090", current)
+	}
+
+	// An invalid file is rejected and the active configuration is kept
+	if err := os.WriteFile(path, []byte("log_level: loud\n"), 0o600); err != nil {
+		t.Fatalf("Failed to rewrite config: %v", err)
+	}
+	if _, err := reloader.Reload(); err == nil {
+		t.Error("Reload() expected error for invalid config")
+	}
+	if reloader.Current().LogLevel != "debug" {
+		t.Errorf("LogLevel = %v, want previous value debug", reloader.Current().LogLevel)
+	}
+}
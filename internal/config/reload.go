@@ -0,0 +1,68 @@
+package config
+
+import (
+	"sync"
+)
+
+// Reloader re-reads the configuration on demand (typically on SIGHUP) and
+// publishes the settings that are safe to change while the server is running.
+// Settings such as the listen port or database connection are only read at
+// startup and are left untouched by a reload.
+type Reloader struct {
+	args []string
+
+	mu          sync.RWMutex
+	current     *Config
+	subscribers []func(*Config)
+}
+
+// NewReloader creates a Reloader starting from cfg, re-using the command line
+// arguments that produced it so flags keep their precedence on reload
+func NewReloader(cfg *Config, args []string) *Reloader {
+	return &Reloader{
+		args:    args,
+		current: cfg,
+	}
+}
+
+// Current returns the active configuration
+func (r *Reloader) Current() *Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// Subscribe registers fn to be called with the new configuration after each successful reload
+func (r *Reloader) Subscribe(fn func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Reload loads the configuration again and applies its hot-reloadable settings.
+// On error the active configuration is kept unchanged.
+func (r *Reloader) Reload() (*Config, error) {
+	loaded, err := Load(r.args)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	next := *r.current
+	applyReloadable(&next, loaded)
+	r.current = &next
+	subscribers := append([]func(*Config){}, r.subscribers...)
+	r.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(&next)
+	}
+
+	return &next, nil
+}
+
+// applyReloadable copies the settings that can change without a restart
+func applyReloadable(dst, src *Config) {
+	dst.LogLevel = src.LogLevel
+	dst.RateLimit = src.RateLimit
+}
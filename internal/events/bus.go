@@ -0,0 +1,59 @@
+// Package events delivers domain events to the parts of the service that react to them.
+//
+// Services publish an event once the change that raised it is committed. Delivery is
+// synchronous and in-process: a Bus calls every handler subscribed to the event's type,
+// in subscription order. A failing handler is logged and does not stop the others or
+// fail the change that raised the event.
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+// Publisher publishes domain events
+type Publisher interface {
+	Publish(ctx context.Context, event domain.Event)
+}
+
+// Handler reacts to a domain event
+type Handler func(ctx context.Context, event domain.Event) error
+
+// Bus is an in-process Publisher that dispatches events to subscribed handlers
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[domain.EventType][]Handler
+	logger   logger.Logger
+}
+
+// NewBus creates an event bus without subscribers
+func NewBus(log logger.Logger) *Bus {
+	return &Bus{
+		handlers: make(map[domain.EventType][]Handler),
+		logger:   log,
+	}
+}
+
+// Subscribe registers a handler for events of the given type
+func (b *Bus) Subscribe(eventType domain.EventType, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish calls every handler subscribed to the event's type
+func (b *Bus) Publish(ctx context.Context, event domain.Event) {
+	b.mu.RLock()
+	handlers := b.handlers[event.Type()]
+	b.mu.RUnlock()
+
+	b.logger.Debug(ctx, "Publishing domain event", "type", event.Type(), "handlers", len(handlers))
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			b.logger.Warn(ctx, "Domain event handler failed", "type", event.Type(), "error", err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+func TestBus_Publish(t *testing.T) {
+	ctx := context.Background()
+	bus := NewBus(logger.NewLogger("error"))
+
+	var calls []string
+	bus.Subscribe(domain.EventUserMentioned, func(ctx context.Context, event domain.Event) error {
+		calls = append(calls, "failing")
+		return errors.New("delivery failed")
+	})
+	bus.Subscribe(domain.EventUserMentioned, func(ctx context.Context, event domain.Event) error {
+		mention := event.(*domain.UserMentionedEvent)
+		calls = append(calls, mention.MentionedUserID)
+		return nil
+	})
+
+	bus.Publish(ctx, &domain.UserMentionedEvent{MentionedUserID: "user-1"})
+	// A failing handler does not stop the ones after it
+	if len(calls) != 2 || calls[1] != "user-1" {
+		t.Errorf("calls = %v, want both handlers in order", calls)
+	}
+
+	// Events without subscribers are dropped
+	NewBus(logger.NewLogger("error")).Publish(ctx, &domain.UserMentionedEvent{})
+}
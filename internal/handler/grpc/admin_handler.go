@@ -2,10 +2,14 @@ package grpc
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
 	"github.com/todo-app/services/admin-service/internal/service"
 	"github.com/todo-app/services/admin-service/pkg/logger"
 	todov1 "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
@@ -46,20 +50,120 @@ func (h *AdminHandler) GetUser(ctx context.Context, req *todov1.GetUserRequest)
 
 // Task management methods
 
-// CreateTask creates a new task
+// CreateTask creates a new open task; the caller becomes its creator and owner
 func (h *AdminHandler) CreateTask(ctx context.Context, req *todov1.CreateTaskRequest) (*todov1.CreateTaskResponse, error) {
 	h.logger.Info(ctx, "Creating task via gRPC", "title", req.GetTitle())
 
-	// TODO: Implement task creation
-	return nil, status.Error(codes.Unimplemented, "CreateTask not yet implemented")
+	task := &domain.Task{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		AssigneeID:  req.GetAssigneeId(),
+		Status:      domain.TaskStatusOpen,
+		Priority:    domain.TaskPriorityMedium,
+	}
+	if req.GetPriority() != todov1.TaskPriority_TASK_PRIORITY_UNSPECIFIED {
+		task.Priority = domain.TaskPriorityFromProtobuf(req.GetPriority())
+	}
+	if req.GetDueDate() != nil {
+		dueDate := req.GetDueDate().AsTime()
+		task.DueDate = &dueDate
+	}
+	if req.GetParentId() != "" {
+		parentID := req.GetParentId()
+		task.ParentID = &parentID
+	}
+
+	created, err := h.services.Task.CreateTask(ctx, task)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.CreateTaskResponse{Task: created.ToProtobuf()}, nil
 }
 
 // ListTasks lists tasks with filtering and pagination
 func (h *AdminHandler) ListTasks(ctx context.Context, req *todov1.ListTasksRequest) (*todov1.ListTasksResponse, error) {
-	h.logger.Info(ctx, "Listing tasks via gRPC", "assignee_id", req.GetAssigneeId())
+	h.logger.Info(ctx, "Listing tasks via gRPC", "assignee_id", req.GetAssigneeId(), "query", req.GetQuery(), "view_id", req.GetViewId())
+
+	if req.GetViewId() != "" {
+		return h.listTasksByView(ctx, req)
+	}
+
+	opts := &repository.TaskListOptions{}
+	if req.GetFilter() != nil {
+		opts = taskListOptionsFromFilter(req.GetFilter())
+	}
+	if req.GetAssigneeId() != "" {
+		opts.AssigneeID = req.GetAssigneeId()
+	}
+	if req.GetStatus() != todov1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		opts.Status = domain.TaskStatusFromProtobuf(req.GetStatus())
+	}
+	opts.Query = req.GetQuery()
+	opts.Page = req.GetPage()
+	opts.PageSize = req.GetPageSize()
+
+	tasks, total, err := h.services.Task.ListTasks(ctx, *opts)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListTasksResponse{TotalCount: int32(total)}
+	for _, task := range tasks {
+		resp.Tasks = append(resp.Tasks, task.ToProtobuf())
+	}
+
+	if len(req.GetFacets()) > 0 {
+		facets, err := h.services.Task.GetTaskFacets(ctx, *opts, taskFacetsFromProto(req.GetFacets()))
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		resp.Facets = facetCountsToProto(facets)
+	}
+	return resp, nil
+}
+
+// listTasksByView lists tasks with a saved view's filter and sort
+func (h *AdminHandler) listTasksByView(ctx context.Context, req *todov1.ListTasksRequest) (*todov1.ListTasksResponse, error) {
+	if req.GetFilter() != nil || req.GetQuery() != "" || req.GetAssigneeId() != "" ||
+		req.GetStatus() != todov1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "filters cannot be combined with view_id")
+	}
+
+	tasks, total, view, err := h.services.SavedView.ListTasks(ctx, req.GetViewId(), req.GetPage(), req.GetPageSize())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListTasksResponse{TotalCount: int32(total), View: view.ToProtobuf()}
+	for _, task := range tasks {
+		resp.Tasks = append(resp.Tasks, task.ToProtobuf())
+	}
+
+	if len(req.GetFacets()) > 0 {
+		facets, err := h.services.SavedView.GetTaskFacets(ctx, req.GetViewId(), taskFacetsFromProto(req.GetFacets()))
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		resp.Facets = facetCountsToProto(facets)
+	}
+	return resp, nil
+}
+
+func taskFacetsFromProto(facets []todov1.TaskFacet) []domain.TaskFacet {
+	result := make([]domain.TaskFacet, 0, len(facets))
+	for _, facet := range facets {
+		result = append(result, domain.TaskFacetFromProtobuf(facet))
+	}
+	return result
+}
 
-	// TODO: Implement task listing
-	return nil, status.Error(codes.Unimplemented, "ListTasks not yet implemented")
+func facetCountsToProto(facets []*domain.FacetCounts) []*todov1.FacetCounts {
+	result := make([]*todov1.FacetCounts, 0, len(facets))
+	for _, facet := range facets {
+		result = append(result, facet.ToProtobuf())
+	}
+	return result
 }
 
 // GetTask retrieves a task by ID
@@ -70,12 +174,89 @@ func (h *AdminHandler) GetTask(ctx context.Context, req *todov1.GetTaskRequest)
 	return nil, status.Error(codes.Unimplemented, "GetTask not yet implemented")
 }
 
-// UpdateTask updates an existing task
+// SearchTasks runs a full-text search over tasks and returns highlighted results
+func (h *AdminHandler) SearchTasks(ctx context.Context, req *todov1.SearchTasksRequest) (*todov1.SearchTasksResponse, error) {
+	h.logger.Info(ctx, "Searching tasks via gRPC", "query", req.GetFilter().GetSearchQuery())
+
+	opts := &repository.TaskListOptions{}
+	if req.GetFilter() != nil {
+		opts = taskListOptionsFromFilter(req.GetFilter())
+	}
+	opts.Page = req.GetPage()
+	opts.PageSize = req.GetPageSize()
+
+	results, total, err := h.services.Task.SearchTasks(ctx, *opts)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.SearchTasksResponse{TotalCount: int32(total)}
+	for _, result := range results {
+		resp.Results = append(resp.Results, &todov1.TaskSearchResult{
+			Task:               result.Task.ToProtobuf(),
+			Rank:               result.Rank,
+			TitleHighlight:     result.TitleHighlight,
+			DescriptionSnippet: result.DescriptionSnippet,
+			MatchedLabels:      result.MatchedLabels,
+		})
+	}
+	return resp, nil
+}
+
+// UpdateTask changes the fields set in the request and keeps the others
 func (h *AdminHandler) UpdateTask(ctx context.Context, req *todov1.UpdateTaskRequest) (*todov1.UpdateTaskResponse, error) {
-	h.logger.Info(ctx, "Updating task via gRPC", "task_id", req.GetTaskId())
+	h.logger.Info(ctx, "Updating task via gRPC", "task_id", req.GetTaskId(), "version", req.GetVersion())
+
+	if req.GetClearDueDate() && req.GetDueDate() != nil {
+		return nil, status.Error(codes.InvalidArgument, "due_date and clear_due_date cannot both be set")
+	}
 
-	// TODO: Implement task update
-	return nil, status.Error(codes.Unimplemented, "UpdateTask not yet implemented")
+	task, err := h.services.Task.GetTaskByID(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if req.Title != nil {
+		task.Title = req.GetTitle()
+	}
+	if req.Description != nil {
+		task.Description = req.GetDescription()
+	}
+	if req.AssigneeId != nil {
+		task.AssigneeID = req.GetAssigneeId()
+	}
+	if req.GetStatus() != todov1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		task.Status = domain.TaskStatusFromProtobuf(req.GetStatus())
+	}
+	if req.GetPriority() != todov1.TaskPriority_TASK_PRIORITY_UNSPECIFIED {
+		task.Priority = domain.TaskPriorityFromProtobuf(req.GetPriority())
+	}
+	if req.GetClearDueDate() {
+		task.DueDate = nil
+	}
+	if req.GetDueDate() != nil {
+		dueDate := req.GetDueDate().AsTime()
+		task.DueDate = &dueDate
+	}
+	task.Version = req.GetVersion()
+
+	updated, err := h.services.Task.UpdateTask(ctx, task)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.UpdateTaskResponse{Task: updated.ToProtobuf()}, nil
+}
+
+// DeleteTask soft deletes a task, and its subtasks when cascading
+func (h *AdminHandler) DeleteTask(ctx context.Context, req *todov1.DeleteTaskRequest) (*todov1.DeleteTaskResponse, error) {
+	h.logger.Info(ctx, "Deleting task via gRPC", "task_id", req.GetTaskId(), "cascade", req.GetCascade())
+
+	if err := h.services.Task.DeleteTask(ctx, req.GetTaskId(), req.GetVersion(), req.GetCascade()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.DeleteTaskResponse{Success: true}, nil
 }
 
 // GetTaskHistory retrieves task history
@@ -85,3 +266,598 @@ func (h *AdminHandler) GetTaskHistory(ctx context.Context, req *todov1.GetTaskHi
 	// TODO: Implement task history retrieval
 	return nil, status.Error(codes.Unimplemented, "GetTaskHistory not yet implemented")
 }
+
+// Subtask methods
+
+// MoveTask moves a task under another task or to the top level
+func (h *AdminHandler) MoveTask(ctx context.Context, req *todov1.MoveTaskRequest) (*todov1.MoveTaskResponse, error) {
+	h.logger.Info(ctx, "Moving task via gRPC", "task_id", req.GetTaskId(), "parent_id", req.GetParentId())
+
+	task, err := h.services.Task.MoveTask(ctx, req.GetTaskId(), req.GetParentId(), req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.MoveTaskResponse{Task: task.ToProtobuf()}, nil
+}
+
+// ListSubtasks lists the direct subtasks of a task along with its progress
+func (h *AdminHandler) ListSubtasks(ctx context.Context, req *todov1.ListSubtasksRequest) (*todov1.ListSubtasksResponse, error) {
+	h.logger.Info(ctx, "Listing subtasks via gRPC", "parent_id", req.GetParentId())
+
+	parent, err := h.services.Task.ListSubtasks(ctx, req.GetParentId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListSubtasksResponse{Progress: parent.Progress.ToProtobuf(parent.Task.Status)}
+	for _, child := range parent.Children {
+		resp.Subtasks = append(resp.Subtasks, child.Task.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// GetTaskTree returns a task with all of its subtasks
+func (h *AdminHandler) GetTaskTree(ctx context.Context, req *todov1.GetTaskTreeRequest) (*todov1.GetTaskTreeResponse, error) {
+	h.logger.Info(ctx, "Getting task tree via gRPC", "task_id", req.GetTaskId())
+
+	root, err := h.services.Task.GetTaskTree(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.GetTaskTreeResponse{Root: root.ToProtobuf()}, nil
+}
+
+// Task participants
+
+// AddTaskParticipants adds users to a task in the given roles
+func (h *AdminHandler) AddTaskParticipants(ctx context.Context, req *todov1.AddTaskParticipantsRequest) (*todov1.AddTaskParticipantsResponse, error) {
+	h.logger.Info(ctx, "Adding task participants via gRPC", "task_id", req.GetTaskId(), "participants", len(req.GetParticipants()))
+
+	task, err := h.services.Task.AddTaskParticipants(ctx, req.GetTaskId(), taskParticipantsFromProto(req.GetParticipants()), req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.AddTaskParticipantsResponse{Task: task.ToProtobuf()}, nil
+}
+
+// RemoveTaskParticipants takes roles on a task away from users
+func (h *AdminHandler) RemoveTaskParticipants(ctx context.Context, req *todov1.RemoveTaskParticipantsRequest) (*todov1.RemoveTaskParticipantsResponse, error) {
+	h.logger.Info(ctx, "Removing task participants via gRPC", "task_id", req.GetTaskId(), "participants", len(req.GetParticipants()))
+
+	task, err := h.services.Task.RemoveTaskParticipants(ctx, req.GetTaskId(), taskParticipantsFromProto(req.GetParticipants()), req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.RemoveTaskParticipantsResponse{Task: task.ToProtobuf()}, nil
+}
+
+func taskParticipantsFromProto(participants []*todov1.TaskParticipant) []domain.TaskParticipant {
+	converted := make([]domain.TaskParticipant, 0, len(participants))
+	for _, participant := range participants {
+		converted = append(converted, domain.TaskParticipantFromProtobuf(participant))
+	}
+	return converted
+}
+
+// Task dependencies
+
+// AddTaskDependency records that a task is blocked by another task
+func (h *AdminHandler) AddTaskDependency(ctx context.Context, req *todov1.AddTaskDependencyRequest) (*todov1.AddTaskDependencyResponse, error) {
+	h.logger.Info(ctx, "Adding task dependency via gRPC", "task_id", req.GetTaskId(), "blocker_id", req.GetBlockerId())
+
+	dependency, err := h.services.Task.AddTaskDependency(ctx, req.GetTaskId(), req.GetBlockerId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.AddTaskDependencyResponse{Dependency: dependency.ToProtobuf()}, nil
+}
+
+// RemoveTaskDependency removes a blocker from a task
+func (h *AdminHandler) RemoveTaskDependency(ctx context.Context, req *todov1.RemoveTaskDependencyRequest) (*todov1.RemoveTaskDependencyResponse, error) {
+	h.logger.Info(ctx, "Removing task dependency via gRPC", "task_id", req.GetTaskId(), "blocker_id", req.GetBlockerId())
+
+	if err := h.services.Task.RemoveTaskDependency(ctx, req.GetTaskId(), req.GetBlockerId()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.RemoveTaskDependencyResponse{Success: true}, nil
+}
+
+// ListTaskBlockers lists the tasks blocking a task
+func (h *AdminHandler) ListTaskBlockers(ctx context.Context, req *todov1.ListTaskBlockersRequest) (*todov1.ListTaskBlockersResponse, error) {
+	h.logger.Info(ctx, "Listing task blockers via gRPC", "task_id", req.GetTaskId())
+
+	blockers, err := h.services.Task.ListTaskBlockers(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListTaskBlockersResponse{}
+	for _, blocker := range blockers {
+		resp.Blockers = append(resp.Blockers, blocker.ToProtobuf())
+		if domain.IsOpenBlocker(blocker) {
+			resp.OpenCount++
+		}
+	}
+	return resp, nil
+}
+
+// ListTaskDependents lists the tasks a task blocks
+func (h *AdminHandler) ListTaskDependents(ctx context.Context, req *todov1.ListTaskDependentsRequest) (*todov1.ListTaskDependentsResponse, error) {
+	h.logger.Info(ctx, "Listing task dependents via gRPC", "task_id", req.GetTaskId())
+
+	dependents, err := h.services.Task.ListTaskDependents(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListTaskDependentsResponse{}
+	for _, dependent := range dependents {
+		resp.Dependents = append(resp.Dependents, dependent.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// GetCriticalPath computes the chain of dependent tasks that finishes last
+func (h *AdminHandler) GetCriticalPath(ctx context.Context, req *todov1.GetCriticalPathRequest) (*todov1.GetCriticalPathResponse, error) {
+	h.logger.Info(ctx, "Computing critical path via gRPC", "tasks", len(req.GetTaskIds()))
+
+	path, err := h.services.Task.GetCriticalPath(ctx, req.GetTaskIds())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.GetCriticalPathResponse{}
+	for _, task := range path.Tasks {
+		resp.Path = append(resp.Path, task.ToProtobuf())
+	}
+	if path.ProjectedFinish != nil {
+		resp.ProjectedFinish = domain.TimeToProtobuf(*path.ProjectedFinish)
+	}
+	for _, conflict := range path.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, conflict.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// Recurring tasks
+
+// SetTaskRecurrence turns a task into the first instance of a recurring series
+func (h *AdminHandler) SetTaskRecurrence(ctx context.Context, req *todov1.SetTaskRecurrenceRequest) (*todov1.SetTaskRecurrenceResponse, error) {
+	h.logger.Info(ctx, "Setting task recurrence via gRPC", "task_id", req.GetTaskId(), "rule", req.GetRule())
+
+	rule, err := domain.ParseRecurrenceRule(req.GetRule())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	task, series, err := h.services.Task.SetTaskRecurrence(ctx, req.GetTaskId(), rule, req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.SetTaskRecurrenceResponse{Task: task.ToProtobuf(), Series: series.ToProtobuf()}, nil
+}
+
+// GetTaskSeries returns a recurring series with its instances in order
+func (h *AdminHandler) GetTaskSeries(ctx context.Context, req *todov1.GetTaskSeriesRequest) (*todov1.GetTaskSeriesResponse, error) {
+	h.logger.Info(ctx, "Getting task series via gRPC", "series_id", req.GetSeriesId())
+
+	series, instances, err := h.services.Task.GetTaskSeries(ctx, req.GetSeriesId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.GetTaskSeriesResponse{Series: series.ToProtobuf()}
+	for _, task := range instances {
+		resp.Instances = append(resp.Instances, task.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// UpdateTaskSeries edits the rule of a series and the fields of its open instances
+func (h *AdminHandler) UpdateTaskSeries(ctx context.Context, req *todov1.UpdateTaskSeriesRequest) (*todov1.UpdateTaskSeriesResponse, error) {
+	h.logger.Info(ctx, "Updating task series via gRPC", "series_id", req.GetSeriesId())
+
+	var changes domain.TaskSeriesChanges
+	if req.Title != nil {
+		title := req.GetTitle()
+		changes.Title = &title
+	}
+	if req.Description != nil {
+		description := req.GetDescription()
+		changes.Description = &description
+	}
+	if req.AssigneeId != nil {
+		assigneeID := req.GetAssigneeId()
+		changes.AssigneeID = &assigneeID
+	}
+	if req.GetPriority() != todov1.TaskPriority_TASK_PRIORITY_UNSPECIFIED {
+		priority := domain.TaskPriorityFromProtobuf(req.GetPriority())
+		changes.Priority = &priority
+	}
+	if req.Rule != nil {
+		rule, err := domain.ParseRecurrenceRule(req.GetRule())
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		changes.Rule = rule
+	}
+
+	series, updated, err := h.services.Task.UpdateTaskSeries(ctx, req.GetSeriesId(), changes, req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.UpdateTaskSeriesResponse{Series: series.ToProtobuf()}
+	for _, task := range updated {
+		resp.UpdatedTasks = append(resp.UpdatedTasks, task.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// StopTaskSeries ends a series; its existing instances are kept
+func (h *AdminHandler) StopTaskSeries(ctx context.Context, req *todov1.StopTaskSeriesRequest) (*todov1.StopTaskSeriesResponse, error) {
+	h.logger.Info(ctx, "Stopping task series via gRPC", "series_id", req.GetSeriesId())
+
+	series, err := h.services.Task.StopTaskSeries(ctx, req.GetSeriesId(), req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.StopTaskSeriesResponse{Series: series.ToProtobuf()}, nil
+}
+
+// Bulk task operations
+
+// BulkUpdateTasks sets the assignee, status or priority of many tasks at once
+func (h *AdminHandler) BulkUpdateTasks(ctx context.Context, req *todov1.BulkUpdateTasksRequest) (*todov1.BulkUpdateTasksResponse, error) {
+	h.logger.Info(ctx, "Bulk updating tasks via gRPC", "tasks", len(req.GetTasks()), "mode", req.GetMode())
+
+	var changes domain.TaskChanges
+	if req.AssigneeId != nil {
+		assigneeID := req.GetAssigneeId()
+		changes.AssigneeID = &assigneeID
+	}
+	if req.GetStatus() != todov1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		taskStatus := domain.TaskStatusFromProtobuf(req.GetStatus())
+		changes.Status = &taskStatus
+	}
+	if req.GetPriority() != todov1.TaskPriority_TASK_PRIORITY_UNSPECIFIED {
+		priority := domain.TaskPriorityFromProtobuf(req.GetPriority())
+		changes.Priority = &priority
+	}
+
+	result, err := h.services.Task.BulkUpdateTasks(ctx, bulkSelectionFromProto(req.GetTasks(), req.GetFilter()), changes, bulkModeFromProto(req.GetMode()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	results, succeeded, failed := bulkResultToProto(result)
+	return &todov1.BulkUpdateTasksResponse{
+		Results:        results,
+		SucceededCount: succeeded,
+		FailedCount:    failed,
+		RolledBack:     result.RolledBack,
+	}, nil
+}
+
+// BulkDeleteTasks soft deletes many tasks at once
+func (h *AdminHandler) BulkDeleteTasks(ctx context.Context, req *todov1.BulkDeleteTasksRequest) (*todov1.BulkDeleteTasksResponse, error) {
+	h.logger.Info(ctx, "Bulk deleting tasks via gRPC", "tasks", len(req.GetTasks()), "mode", req.GetMode(), "cascade", req.GetCascade())
+
+	result, err := h.services.Task.BulkDeleteTasks(ctx, bulkSelectionFromProto(req.GetTasks(), req.GetFilter()), bulkModeFromProto(req.GetMode()), req.GetCascade())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	results, succeeded, failed := bulkResultToProto(result)
+	return &todov1.BulkDeleteTasksResponse{
+		Results:        results,
+		SucceededCount: succeeded,
+		FailedCount:    failed,
+		RolledBack:     result.RolledBack,
+	}, nil
+}
+
+// BulkRestoreTasks restores many soft-deleted tasks at once
+func (h *AdminHandler) BulkRestoreTasks(ctx context.Context, req *todov1.BulkRestoreTasksRequest) (*todov1.BulkRestoreTasksResponse, error) {
+	h.logger.Info(ctx, "Bulk restoring tasks via gRPC", "tasks", len(req.GetTasks()), "mode", req.GetMode(), "cascade", req.GetCascade())
+
+	result, err := h.services.Task.BulkRestoreTasks(ctx, bulkSelectionFromProto(req.GetTasks(), req.GetFilter()), bulkModeFromProto(req.GetMode()), req.GetCascade())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	results, succeeded, failed := bulkResultToProto(result)
+	return &todov1.BulkRestoreTasksResponse{
+		Results:        results,
+		SucceededCount: succeeded,
+		FailedCount:    failed,
+		RolledBack:     result.RolledBack,
+	}, nil
+}
+
+func bulkModeFromProto(mode todov1.BulkMode) domain.BulkMode {
+	if mode == todov1.BulkMode_BULK_MODE_BEST_EFFORT {
+		return domain.BulkModeBestEffort
+	}
+	return domain.BulkModeAllOrNothing
+}
+
+func bulkSelectionFromProto(refs []*todov1.TaskRef, filter *todov1.TaskFilter) repository.BulkTaskSelection {
+	var selection repository.BulkTaskSelection
+	for _, ref := range refs {
+		selection.Tasks = append(selection.Tasks, domain.TaskRef{ID: ref.GetTaskId(), Version: ref.GetVersion()})
+	}
+	if filter != nil {
+		selection.Filter = taskListOptionsFromFilter(filter)
+	}
+	return selection
+}
+
+// taskListOptionsFromFilter converts a TaskFilter into repository list options
+func taskListOptionsFromFilter(filter *todov1.TaskFilter) *repository.TaskListOptions {
+	opts := &repository.TaskListOptions{
+		AssigneeID:  filter.GetAssigneeId(),
+		CategoryIDs: filter.GetCategoryIds(),
+		TagIDs:      filter.GetTagIds(),
+	}
+	opts.SearchQuery = filter.GetSearchQuery()
+	opts.SearchLabels = filter.GetSearchLabels()
+	opts.AssigneeRoles = domain.TaskParticipantRolesFromProtobuf(filter.GetAssigneeRoles())
+	opts.CreatorID = filter.GetCreatorId()
+
+	if filter.GetStatus() != todov1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		opts.Status = domain.TaskStatusFromProtobuf(filter.GetStatus())
+	}
+	if filter.GetPriority() != todov1.TaskPriority_TASK_PRIORITY_UNSPECIFIED {
+		opts.Priority = domain.TaskPriorityFromProtobuf(filter.GetPriority())
+	}
+	if filter.GetDueBefore() != nil {
+		dueBefore := filter.GetDueBefore().AsTime().Format(time.RFC3339)
+		opts.DueBefore = &dueBefore
+	}
+	if filter.GetDueAfter() != nil {
+		dueAfter := filter.GetDueAfter().AsTime().Format(time.RFC3339)
+		opts.DueAfter = &dueAfter
+	}
+
+	return opts
+}
+
+func bulkResultToProto(result *domain.BulkResult) ([]*todov1.BulkTaskResult, int32, int32) {
+	results := make([]*todov1.BulkTaskResult, 0, len(result.Results))
+	for _, item := range result.Results {
+		pbResult := &todov1.BulkTaskResult{TaskId: item.TaskID, Success: item.Success}
+		if item.Task != nil {
+			pbResult.Task = item.Task.ToProtobuf()
+		}
+		if item.Error != nil {
+			pbResult.ErrorCode, pbResult.ErrorMessage = errorDetails(item.Error)
+		}
+		results = append(results, pbResult)
+	}
+	return results, int32(result.Succeeded), int32(result.Failed)
+}
+
+// Task import
+
+// ImportTasks imports tasks from a CSV or JSON Lines file streamed in chunks.
+// The first message carries the import options.
+func (h *AdminHandler) ImportTasks(stream todov1.AdminService_ImportTasksServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return status.Error(codes.InvalidArgument, "import options are required")
+		}
+		return err
+	}
+	opts := first.GetOptions()
+	if opts == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the import options")
+	}
+
+	h.logger.Info(ctx, "Importing tasks via gRPC", "format", opts.GetFormat(), "dry_run", opts.GetDryRun())
+
+	report, err := h.services.Import.ImportTasks(ctx, &importChunkReader{stream: stream}, domain.ImportOptions{
+		Format:         importFormatFromProto(opts.GetFormat()),
+		DryRun:         opts.GetDryRun(),
+		BatchSize:      int(opts.GetBatchSize()),
+		ResumeImportID: opts.GetResumeImportId(),
+	})
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	return stream.SendAndClose(importReportToProto(report))
+}
+
+// importChunkReader exposes the uploaded chunks of an import stream as an io.Reader
+type importChunkReader struct {
+	stream todov1.AdminService_ImportTasksServer
+	buf    []byte
+}
+
+func (r *importChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetOptions() != nil {
+			return 0, domain.ErrInvalidInput("import options must only be sent in the first message")
+		}
+		r.buf = msg.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func importFormatFromProto(format todov1.ImportFormat) domain.ImportFormat {
+	switch format {
+	case todov1.ImportFormat_IMPORT_FORMAT_CSV:
+		return domain.ImportFormatCSV
+	case todov1.ImportFormat_IMPORT_FORMAT_JSONL:
+		return domain.ImportFormatJSONL
+	default:
+		return ""
+	}
+}
+
+func importReportToProto(report *domain.ImportReport) *todov1.ImportTasksResponse {
+	resp := &todov1.ImportTasksResponse{
+		ImportId:         report.ImportID,
+		DryRun:           report.DryRun,
+		Status:           importStatusToProto(report.Status),
+		TotalRows:        int32(report.TotalRows),
+		ValidRows:        int32(report.ValidRows),
+		InvalidRows:      int32(report.InvalidRows),
+		CreatedRows:      int32(report.CreatedRows),
+		SkippedRows:      int32(report.SkippedRows),
+		LastCommittedRow: int32(report.LastCommittedRow),
+		Error:            report.Error,
+	}
+
+	for _, row := range report.Rows {
+		resp.Rows = append(resp.Rows, &todov1.ImportRowResult{
+			Row:    int32(row.Row),
+			Title:  row.Title,
+			Status: importRowStatusToProto(row.Status),
+			TaskId: row.TaskID,
+			Errors: row.Errors,
+		})
+	}
+	return resp
+}
+
+func importStatusToProto(importStatus domain.ImportStatus) todov1.ImportStatus {
+	switch importStatus {
+	case domain.ImportStatusRunning:
+		return todov1.ImportStatus_IMPORT_STATUS_RUNNING
+	case domain.ImportStatusCompleted:
+		return todov1.ImportStatus_IMPORT_STATUS_COMPLETED
+	case domain.ImportStatusFailed:
+		return todov1.ImportStatus_IMPORT_STATUS_FAILED
+	default:
+		return todov1.ImportStatus_IMPORT_STATUS_UNSPECIFIED
+	}
+}
+
+func importRowStatusToProto(rowStatus domain.ImportRowStatus) todov1.ImportRowStatus {
+	switch rowStatus {
+	case domain.ImportRowValid:
+		return todov1.ImportRowStatus_IMPORT_ROW_STATUS_VALID
+	case domain.ImportRowInvalid:
+		return todov1.ImportRowStatus_IMPORT_ROW_STATUS_INVALID
+	case domain.ImportRowCreated:
+		return todov1.ImportRowStatus_IMPORT_ROW_STATUS_CREATED
+	case domain.ImportRowSkipped:
+		return todov1.ImportRowStatus_IMPORT_ROW_STATUS_SKIPPED
+	case domain.ImportRowNotCommitted:
+		return todov1.ImportRowStatus_IMPORT_ROW_STATUS_NOT_COMMITTED
+	default:
+		return todov1.ImportRowStatus_IMPORT_ROW_STATUS_UNSPECIFIED
+	}
+}
+
+// Task export
+
+// exportChunkSize is the amount of export output buffered per stream message
+const exportChunkSize = 32 * 1024
+
+// ExportTasks streams the tasks matching the filter as a CSV, JSON Lines or
+// iCalendar file. The first message carries the content type.
+func (h *AdminHandler) ExportTasks(req *todov1.ExportTasksRequest, stream todov1.AdminService_ExportTasksServer) error {
+	ctx := stream.Context()
+	h.logger.Info(ctx, "Exporting tasks via gRPC", "format", req.GetFormat())
+
+	filter := &repository.TaskListOptions{}
+	if req.GetFilter() != nil {
+		filter = taskListOptionsFromFilter(req.GetFilter())
+	}
+
+	format := exportFormatFromProto(req.GetFormat())
+	writer := &exportChunkWriter{stream: stream, contentType: exportContentType(format)}
+	_, err := h.services.Export.ExportTasks(ctx, *filter, domain.ExportOptions{
+		Format:   format,
+		Columns:  req.GetColumns(),
+		TimeZone: req.GetTimeZone(),
+	}, writer)
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	return writer.flush()
+}
+
+// exportChunkWriter buffers export output and sends it in chunks of exportChunkSize
+type exportChunkWriter struct {
+	stream      todov1.AdminService_ExportTasksServer
+	contentType string
+	buf         []byte
+	sent        bool
+}
+
+func (w *exportChunkWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for len(w.buf) >= exportChunkSize {
+		if err := w.send(w.buf[:exportChunkSize]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[exportChunkSize:]
+	}
+	return len(p), nil
+}
+
+// flush sends the remaining output; an empty export still reports its content type
+func (w *exportChunkWriter) flush() error {
+	if len(w.buf) == 0 && w.sent {
+		return nil
+	}
+	err := w.send(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *exportChunkWriter) send(chunk []byte) error {
+	msg := &todov1.ExportTasksResponse{Chunk: chunk}
+	if !w.sent {
+		msg.ContentType = w.contentType
+		w.sent = true
+	}
+	return w.stream.Send(msg)
+}
+
+func exportFormatFromProto(format todov1.ExportFormat) domain.ExportFormat {
+	switch format {
+	case todov1.ExportFormat_EXPORT_FORMAT_CSV:
+		return domain.ExportFormatCSV
+	case todov1.ExportFormat_EXPORT_FORMAT_JSONL:
+		return domain.ExportFormatJSONL
+	case todov1.ExportFormat_EXPORT_FORMAT_ICALENDAR:
+		return domain.ExportFormatICalendar
+	default:
+		return ""
+	}
+}
+
+func exportContentType(format domain.ExportFormat) string {
+	switch format {
+	case domain.ExportFormatJSONL:
+		return "application/x-ndjson"
+	case domain.ExportFormatICalendar:
+		return "text/calendar; charset=utf-8"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
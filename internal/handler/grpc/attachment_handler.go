@@ -0,0 +1,166 @@
+package grpc
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/service"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	todov1 "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// attachmentChunkSize is the amount of attachment content sent per stream message
+const attachmentChunkSize = 64 * 1024
+
+// AttachmentHandler implements the gRPC AttachmentService
+type AttachmentHandler struct {
+	todov1.UnimplementedAttachmentServiceServer
+	attachmentService service.AttachmentService
+	logger            logger.Logger
+}
+
+// NewAttachmentHandler creates a new attachment gRPC handler
+func NewAttachmentHandler(attachmentService service.AttachmentService, logger logger.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		logger:            logger,
+	}
+}
+
+// UploadAttachment stores a file streamed in chunks as an attachment of a task.
+// The first message carries the attachment info.
+func (h *AttachmentHandler) UploadAttachment(stream todov1.AttachmentService_UploadAttachmentServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return status.Error(codes.InvalidArgument, "attachment info is required")
+		}
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the attachment info")
+	}
+
+	h.logger.Info(ctx, "Uploading attachment via gRPC", "task_id", info.GetTaskId(), "size", info.GetSizeBytes())
+
+	attachment, err := h.attachmentService.UploadAttachment(ctx, domain.AttachmentUpload{
+		TaskID:         info.GetTaskId(),
+		FileName:       info.GetFileName(),
+		ContentType:    info.GetContentType(),
+		SizeBytes:      info.GetSizeBytes(),
+		ChecksumSHA256: info.GetChecksumSha256(),
+	}, &attachmentChunkReader{stream: stream})
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	return stream.SendAndClose(&todov1.UploadAttachmentResponse{Attachment: attachment.ToProtobuf()})
+}
+
+// attachmentChunkReader exposes the uploaded chunks of an attachment stream as an io.Reader
+type attachmentChunkReader struct {
+	stream todov1.AttachmentService_UploadAttachmentServer
+	buf    []byte
+}
+
+func (r *attachmentChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetInfo() != nil {
+			return 0, domain.ErrInvalidInput("attachment info must only be sent in the first message")
+		}
+		r.buf = msg.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// DownloadAttachment streams an attachment's content in chunks.
+// The first message carries the attachment.
+func (h *AttachmentHandler) DownloadAttachment(req *todov1.DownloadAttachmentRequest, stream todov1.AttachmentService_DownloadAttachmentServer) error {
+	ctx := stream.Context()
+	h.logger.Info(ctx, "Downloading attachment via gRPC", "attachment_id", req.GetAttachmentId())
+
+	attachment, content, err := h.attachmentService.OpenAttachment(ctx, req.GetAttachmentId())
+	if err != nil {
+		return toStatusError(err)
+	}
+	defer content.Close()
+
+	if err := stream.Send(&todov1.DownloadAttachmentResponse{
+		Payload: &todov1.DownloadAttachmentResponse_Attachment{Attachment: attachment.ToProtobuf()},
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		n, err := io.ReadFull(content, buf)
+		if n > 0 {
+			chunk := &todov1.DownloadAttachmentResponse{
+				Payload: &todov1.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]},
+			}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			h.logger.Error(ctx, "Failed to read attachment content", "error", err, "attachment_id", attachment.ID)
+			return status.Error(codes.Internal, "failed to read attachment content")
+		}
+	}
+}
+
+// ListAttachments lists the attachments of a task, oldest first
+func (h *AttachmentHandler) ListAttachments(ctx context.Context, req *todov1.ListAttachmentsRequest) (*todov1.ListAttachmentsResponse, error) {
+	h.logger.Info(ctx, "Listing attachments via gRPC", "task_id", req.GetTaskId())
+
+	attachments, err := h.attachmentService.ListAttachments(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListAttachmentsResponse{}
+	for _, attachment := range attachments {
+		resp.Attachments = append(resp.Attachments, attachment.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// DeleteAttachment deletes an attachment and its content
+func (h *AttachmentHandler) DeleteAttachment(ctx context.Context, req *todov1.DeleteAttachmentRequest) (*todov1.DeleteAttachmentResponse, error) {
+	h.logger.Info(ctx, "Deleting attachment via gRPC", "attachment_id", req.GetAttachmentId())
+
+	if err := h.attachmentService.DeleteAttachment(ctx, req.GetAttachmentId(), req.GetVersion()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.DeleteAttachmentResponse{Success: true}, nil
+}
+
+// GetAttachmentUsage reports the caller's attachment usage and quota
+func (h *AttachmentHandler) GetAttachmentUsage(ctx context.Context, req *todov1.GetAttachmentUsageRequest) (*todov1.GetAttachmentUsageResponse, error) {
+	h.logger.Info(ctx, "Getting attachment usage via gRPC")
+
+	usage, err := h.attachmentService.GetUsage(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.GetAttachmentUsageResponse{UsedBytes: usage.UsedBytes, QuotaBytes: usage.QuotaBytes}, nil
+}
@@ -50,10 +50,69 @@ func (h *CategoryHandler) UpdateCategory(ctx context.Context, req *todov1.Update
 	return nil, status.Error(codes.Unimplemented, "UpdateCategory not yet implemented")
 }
 
-// DeleteCategory deletes a category
+// DeleteCategory soft deletes a category, and its subcategories when cascading
 func (h *CategoryHandler) DeleteCategory(ctx context.Context, req *todov1.DeleteCategoryRequest) (*todov1.DeleteCategoryResponse, error) {
-	h.logger.Info(ctx, "Deleting category via gRPC", "category_id", req.GetCategoryId())
+	h.logger.Info(ctx, "Deleting category via gRPC", "category_id", req.GetCategoryId(), "cascade", req.GetCascade())
 
-	// TODO: Implement category deletion
-	return nil, status.Error(codes.Unimplemented, "DeleteCategory not yet implemented")
+	if err := h.categoryService.DeleteCategory(ctx, req.GetCategoryId(), req.GetVersion(), req.GetCascade()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.DeleteCategoryResponse{Success: true}, nil
+}
+
+// RestoreCategory restores a deleted category, and the subcategories deleted with it when cascading
+func (h *CategoryHandler) RestoreCategory(ctx context.Context, req *todov1.RestoreCategoryRequest) (*todov1.RestoreCategoryResponse, error) {
+	h.logger.Info(ctx, "Restoring category via gRPC", "category_id", req.GetCategoryId(), "cascade", req.GetCascade())
+
+	category, err := h.categoryService.RestoreCategory(ctx, req.GetCategoryId(), req.GetVersion(), req.GetCascade())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.RestoreCategoryResponse{Category: category.ToProtobuf()}, nil
+}
+
+// MoveCategory re-parents a category together with its subcategories
+func (h *CategoryHandler) MoveCategory(ctx context.Context, req *todov1.MoveCategoryRequest) (*todov1.MoveCategoryResponse, error) {
+	h.logger.Info(ctx, "Moving category via gRPC", "category_id", req.GetCategoryId(), "parent_id", req.GetParentId())
+
+	category, err := h.categoryService.MoveCategory(ctx, req.GetCategoryId(), req.GetParentId(), req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.MoveCategoryResponse{Category: category.ToProtobuf()}, nil
+}
+
+// MergeCategories moves the tasks and subcategories of one category to another and deletes it
+func (h *CategoryHandler) MergeCategories(ctx context.Context, req *todov1.MergeCategoriesRequest) (*todov1.MergeCategoriesResponse, error) {
+	h.logger.Info(ctx, "Merging categories via gRPC", "source_id", req.GetSourceCategoryId(), "target_id", req.GetTargetCategoryId())
+
+	target, moved, err := h.categoryService.MergeCategories(ctx,
+		req.GetSourceCategoryId(), req.GetSourceVersion(), req.GetTargetCategoryId(), req.GetTargetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.MergeCategoriesResponse{Target: target.ToProtobuf(), MovedTaskCount: int32(moved)}, nil
+}
+
+// GetCategoryTree returns the category hierarchy under a category, or the whole hierarchy
+func (h *CategoryHandler) GetCategoryTree(ctx context.Context, req *todov1.GetCategoryTreeRequest) (*todov1.GetCategoryTreeResponse, error) {
+	h.logger.Info(ctx, "Getting category tree via gRPC", "root_id", req.GetRootId(), "max_depth", req.GetMaxDepth())
+
+	roots, ancestors, err := h.categoryService.GetCategoryTree(ctx, req.GetRootId(), int(req.GetMaxDepth()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.GetCategoryTreeResponse{}
+	for _, root := range roots {
+		resp.Roots = append(resp.Roots, root.ToProtobuf())
+	}
+	for _, ancestor := range ancestors {
+		resp.Ancestors = append(resp.Ancestors, ancestor.ToProtobuf())
+	}
+	return resp, nil
 }
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/service"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	todov1 "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// CommentHandler implements the gRPC CommentService
+type CommentHandler struct {
+	todov1.UnimplementedCommentServiceServer
+	commentService service.CommentService
+	logger         logger.Logger
+}
+
+// NewCommentHandler creates a new comment gRPC handler
+func NewCommentHandler(commentService service.CommentService, logger logger.Logger) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		logger:         logger,
+	}
+}
+
+// CreateComment adds a comment by the caller to a task
+func (h *CommentHandler) CreateComment(ctx context.Context, req *todov1.CreateCommentRequest) (*todov1.CreateCommentResponse, error) {
+	h.logger.Info(ctx, "Creating comment via gRPC", "task_id", req.GetTaskId())
+
+	comment, err := h.commentService.CreateComment(ctx, req.GetTaskId(), req.GetBody())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.CreateCommentResponse{Comment: comment.ToProtobuf()}, nil
+}
+
+// UpdateComment changes the body of one of the caller's comments
+func (h *CommentHandler) UpdateComment(ctx context.Context, req *todov1.UpdateCommentRequest) (*todov1.UpdateCommentResponse, error) {
+	h.logger.Info(ctx, "Updating comment via gRPC", "comment_id", req.GetCommentId())
+
+	comment, err := h.commentService.UpdateComment(ctx, req.GetCommentId(), req.GetBody(), req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.UpdateCommentResponse{Comment: comment.ToProtobuf()}, nil
+}
+
+// DeleteComment soft deletes a comment
+func (h *CommentHandler) DeleteComment(ctx context.Context, req *todov1.DeleteCommentRequest) (*todov1.DeleteCommentResponse, error) {
+	h.logger.Info(ctx, "Deleting comment via gRPC", "comment_id", req.GetCommentId())
+
+	if err := h.commentService.DeleteComment(ctx, req.GetCommentId(), req.GetVersion()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.DeleteCommentResponse{Success: true}, nil
+}
+
+// ListTaskActivity lists a task's history and comments, oldest first
+func (h *CommentHandler) ListTaskActivity(ctx context.Context, req *todov1.ListTaskActivityRequest) (*todov1.ListTaskActivityResponse, error) {
+	h.logger.Info(ctx, "Listing task activity via gRPC", "task_id", req.GetTaskId(), "page", req.GetPage())
+
+	items, total, err := h.commentService.ListTaskActivity(ctx, req.GetTaskId(), repository.ListOptions{
+		Page:     req.GetPage(),
+		PageSize: req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListTaskActivityResponse{TotalCount: int32(total)}
+	for _, item := range items {
+		resp.Items = append(resp.Items, item.ToProtobuf())
+	}
+	return resp, nil
+}
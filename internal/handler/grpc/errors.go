@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+// toStatusError converts a service error into a gRPC status error. Domain errors
+// keep their message; anything else is reported as an internal error.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var domainErr domain.DomainError
+	if !errors.As(err, &domainErr) {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	return status.Error(domainErrorCode(domainErr), domainErr.Message)
+}
+
+// domainErrorCode maps a domain error type to the closest gRPC code
+func domainErrorCode(err domain.DomainError) codes.Code {
+	switch err.Type {
+	case "NOT_FOUND":
+		return codes.NotFound
+	case "INVALID_INPUT":
+		return codes.InvalidArgument
+	case "CONFLICT":
+		return codes.AlreadyExists
+	case "VERSION_CONFLICT", "ABORTED":
+		return codes.Aborted
+	case "UNAUTHORIZED":
+		return codes.Unauthenticated
+	case "FORBIDDEN", "PERMISSION_DENIED":
+		return codes.PermissionDenied
+	case "BUSINESS_RULE_VIOLATION":
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
+
+// errorDetails returns the domain error type and message reported for a failed item
+func errorDetails(err error) (string, string) {
+	var domainErr domain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Type, domainErr.Message
+	}
+	return "INTERNAL", "internal error"
+}
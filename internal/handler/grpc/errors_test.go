@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "not found", err: domain.ErrNotFound("task"), want: codes.NotFound},
+		{name: "invalid input", err: domain.ErrInvalidInput("bad"), want: codes.InvalidArgument},
+		{name: "version conflict", err: domain.ErrVersionConflict("task", 1, 2), want: codes.Aborted},
+		{name: "business rule", err: domain.ErrBusinessRule("nope"), want: codes.FailedPrecondition},
+		{name: "wrapped domain error", err: fmt.Errorf("failed: %w", domain.ErrForbidden("no")), want: codes.PermissionDenied},
+		{name: "status error passes through", err: status.Error(codes.Unavailable, "down"), want: codes.Unavailable},
+		{name: "unknown error", err: errors.New("boom"), want: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(toStatusError(tt.err)); got != tt.want {
+				t.Errorf("toStatusError() code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -36,5 +36,25 @@ func (h *Handler) RegisterServices(server *grpc.Server) {
 	tagHandler := NewTagHandler(h.services.Tag, h.logger)
 	todov1.RegisterTagServiceServer(server, tagHandler)
 
+	// Register saved view service
+	savedViewHandler := NewSavedViewHandler(h.services.SavedView, h.logger)
+	todov1.RegisterSavedViewServiceServer(server, savedViewHandler)
+
+	// Register workflow service
+	workflowHandler := NewWorkflowHandler(h.services.Workflow, h.logger)
+	todov1.RegisterWorkflowServiceServer(server, workflowHandler)
+
+	// Register comment service
+	commentHandler := NewCommentHandler(h.services.Comment, h.logger)
+	todov1.RegisterCommentServiceServer(server, commentHandler)
+
+	// Register attachment service
+	attachmentHandler := NewAttachmentHandler(h.services.Attachment, h.logger)
+	todov1.RegisterAttachmentServiceServer(server, attachmentHandler)
+
+	// Register notification service
+	notificationHandler := NewNotificationHandler(h.services.Notification, h.logger)
+	todov1.RegisterNotificationServiceServer(server, notificationHandler)
+
 	// Note: UserService is for mobile interface - implement separately if needed
 }
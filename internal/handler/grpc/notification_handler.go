@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/service"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	todov1 "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// NotificationHandler implements the gRPC NotificationService
+type NotificationHandler struct {
+	todov1.UnimplementedNotificationServiceServer
+	notificationService service.NotificationService
+	logger              logger.Logger
+}
+
+// NewNotificationHandler creates a new notification gRPC handler
+func NewNotificationHandler(notificationService service.NotificationService, logger logger.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// WatchTask makes the caller a watcher of a task
+func (h *NotificationHandler) WatchTask(ctx context.Context, req *todov1.WatchTaskRequest) (*todov1.WatchTaskResponse, error) {
+	h.logger.Info(ctx, "Watching task via gRPC", "task_id", req.GetTaskId())
+
+	if err := h.notificationService.WatchTask(ctx, req.GetTaskId()); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &todov1.WatchTaskResponse{Success: true}, nil
+}
+
+// UnwatchTask stops the caller from watching a task
+func (h *NotificationHandler) UnwatchTask(ctx context.Context, req *todov1.UnwatchTaskRequest) (*todov1.UnwatchTaskResponse, error) {
+	h.logger.Info(ctx, "Unwatching task via gRPC", "task_id", req.GetTaskId())
+
+	if err := h.notificationService.UnwatchTask(ctx, req.GetTaskId()); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &todov1.UnwatchTaskResponse{Success: true}, nil
+}
+
+// WatchCategory makes the caller a watcher of every task in a category and its subcategories
+func (h *NotificationHandler) WatchCategory(ctx context.Context, req *todov1.WatchCategoryRequest) (*todov1.WatchCategoryResponse, error) {
+	h.logger.Info(ctx, "Watching category via gRPC", "category_id", req.GetCategoryId())
+
+	if err := h.notificationService.WatchCategory(ctx, req.GetCategoryId()); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &todov1.WatchCategoryResponse{Success: true}, nil
+}
+
+// UnwatchCategory stops the caller from watching a category
+func (h *NotificationHandler) UnwatchCategory(ctx context.Context, req *todov1.UnwatchCategoryRequest) (*todov1.UnwatchCategoryResponse, error) {
+	h.logger.Info(ctx, "Unwatching category via gRPC", "category_id", req.GetCategoryId())
+
+	if err := h.notificationService.UnwatchCategory(ctx, req.GetCategoryId()); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &todov1.UnwatchCategoryResponse{Success: true}, nil
+}
+
+// ListWatches lists the tasks and categories the caller watches
+func (h *NotificationHandler) ListWatches(ctx context.Context, req *todov1.ListWatchesRequest) (*todov1.ListWatchesResponse, error) {
+	h.logger.Info(ctx, "Listing watches via gRPC")
+
+	watches, err := h.notificationService.ListWatches(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &todov1.ListWatchesResponse{TaskIds: watches.TaskIDs, CategoryIds: watches.CategoryIDs}, nil
+}
+
+// GetNotificationSettings returns the caller's notification settings
+func (h *NotificationHandler) GetNotificationSettings(ctx context.Context, req *todov1.GetNotificationSettingsRequest) (*todov1.GetNotificationSettingsResponse, error) {
+	h.logger.Info(ctx, "Getting notification settings via gRPC")
+
+	settings, err := h.notificationService.GetSettings(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &todov1.GetNotificationSettingsResponse{Settings: settings.ToProtobuf()}, nil
+}
+
+// UpdateNotificationSettings replaces the caller's notification settings
+func (h *NotificationHandler) UpdateNotificationSettings(ctx context.Context, req *todov1.UpdateNotificationSettingsRequest) (*todov1.UpdateNotificationSettingsResponse, error) {
+	h.logger.Info(ctx, "Updating notification settings via gRPC", "version", req.GetVersion())
+
+	quietHours, err := domain.QuietHoursFromProtobuf(req.GetQuietHours())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	settings := &domain.NotificationSettings{
+		TimeZone:   req.GetTimeZone(),
+		QuietHours: quietHours,
+		Version:    req.GetVersion(),
+	}
+	for _, pref := range req.GetPreferences() {
+		settings.Preferences = append(settings.Preferences, domain.NotificationPreferenceFromProtobuf(pref))
+	}
+
+	updated, err := h.notificationService.UpdateSettings(ctx, settings)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &todov1.UpdateNotificationSettingsResponse{Settings: updated.ToProtobuf()}, nil
+}
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/service"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	todov1 "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// SavedViewHandler implements the gRPC SavedViewService
+type SavedViewHandler struct {
+	todov1.UnimplementedSavedViewServiceServer
+	savedViewService service.SavedViewService
+	logger           logger.Logger
+}
+
+// NewSavedViewHandler creates a new saved view gRPC handler
+func NewSavedViewHandler(savedViewService service.SavedViewService, logger logger.Logger) *SavedViewHandler {
+	return &SavedViewHandler{
+		savedViewService: savedViewService,
+		logger:           logger,
+	}
+}
+
+// CreateSavedView saves a task filter for the caller
+func (h *SavedViewHandler) CreateSavedView(ctx context.Context, req *todov1.CreateSavedViewRequest) (*todov1.CreateSavedViewResponse, error) {
+	h.logger.Info(ctx, "Creating saved view via gRPC", "name", req.GetName())
+
+	view, err := h.savedViewService.CreateView(ctx, &domain.SavedView{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Visibility:  domain.SavedViewVisibilityFromProtobuf(req.GetVisibility()),
+		SharedRole:  sharedRoleFromProto(req.GetSharedRole()),
+		Filter:      domain.SavedViewFilterFromProtobuf(req.GetFilter(), req.GetQuery()),
+		SortBy:      req.GetSortBy(),
+		SortDesc:    req.GetSortDesc(),
+		Columns:     req.GetColumns(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.CreateSavedViewResponse{View: view.ToProtobuf()}, nil
+}
+
+// GetSavedView retrieves a saved view visible to the caller
+func (h *SavedViewHandler) GetSavedView(ctx context.Context, req *todov1.GetSavedViewRequest) (*todov1.GetSavedViewResponse, error) {
+	h.logger.Info(ctx, "Getting saved view via gRPC", "view_id", req.GetViewId())
+
+	view, err := h.savedViewService.GetView(ctx, req.GetViewId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.GetSavedViewResponse{View: view.ToProtobuf()}, nil
+}
+
+// ListSavedViews lists the caller's views and those shared with their role
+func (h *SavedViewHandler) ListSavedViews(ctx context.Context, req *todov1.ListSavedViewsRequest) (*todov1.ListSavedViewsResponse, error) {
+	h.logger.Info(ctx, "Listing saved views via gRPC", "page", req.GetPage())
+
+	views, total, err := h.savedViewService.ListViews(ctx, repository.ListOptions{
+		Page:     req.GetPage(),
+		PageSize: req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListSavedViewsResponse{TotalCount: int32(total)}
+	for _, view := range views {
+		resp.Views = append(resp.Views, view.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// UpdateSavedView replaces a saved view's settings
+func (h *SavedViewHandler) UpdateSavedView(ctx context.Context, req *todov1.UpdateSavedViewRequest) (*todov1.UpdateSavedViewResponse, error) {
+	h.logger.Info(ctx, "Updating saved view via gRPC", "view_id", req.GetViewId())
+
+	view, err := h.savedViewService.UpdateView(ctx, &domain.SavedView{
+		ID:          req.GetViewId(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Visibility:  domain.SavedViewVisibilityFromProtobuf(req.GetVisibility()),
+		SharedRole:  sharedRoleFromProto(req.GetSharedRole()),
+		Filter:      domain.SavedViewFilterFromProtobuf(req.GetFilter(), req.GetQuery()),
+		SortBy:      req.GetSortBy(),
+		SortDesc:    req.GetSortDesc(),
+		Columns:     req.GetColumns(),
+		Version:     req.GetVersion(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.UpdateSavedViewResponse{View: view.ToProtobuf()}, nil
+}
+
+// DeleteSavedView deletes a saved view
+func (h *SavedViewHandler) DeleteSavedView(ctx context.Context, req *todov1.DeleteSavedViewRequest) (*todov1.DeleteSavedViewResponse, error) {
+	h.logger.Info(ctx, "Deleting saved view via gRPC", "view_id", req.GetViewId())
+
+	if err := h.savedViewService.DeleteView(ctx, req.GetViewId(), req.GetVersion()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.DeleteSavedViewResponse{Success: true}, nil
+}
+
+// sharedRoleFromProto maps an unspecified role to no role
+func sharedRoleFromProto(role todov1.UserRole) domain.UserRole {
+	if role == todov1.UserRole_USER_ROLE_UNSPECIFIED {
+		return ""
+	}
+	return domain.UserRoleFromProtobuf(role)
+}
@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/service"
 	"github.com/todo-app/services/admin-service/pkg/logger"
 	todov1 "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
@@ -57,3 +58,92 @@ func (h *TagHandler) DeleteTag(ctx context.Context, req *todov1.DeleteTagRequest
 	// TODO: Implement tag deletion
 	return nil, status.Error(codes.Unimplemented, "DeleteTag not yet implemented")
 }
+
+// MergeTags moves the tasks of duplicate tags to a canonical tag and deletes the duplicates
+func (h *TagHandler) MergeTags(ctx context.Context, req *todov1.MergeTagsRequest) (*todov1.MergeTagsResponse, error) {
+	h.logger.Info(ctx, "Merging tags via gRPC", "canonical_id", req.GetCanonical().GetTagId(), "sources", len(req.GetSources()))
+
+	canonical := domain.TagRef{ID: req.GetCanonical().GetTagId(), Version: req.GetCanonical().GetVersion()}
+	sources := make([]domain.TagRef, 0, len(req.GetSources()))
+	for _, ref := range req.GetSources() {
+		sources = append(sources, domain.TagRef{ID: ref.GetTagId(), Version: ref.GetVersion()})
+	}
+
+	tag, moved, err := h.tagService.MergeTags(ctx, canonical, sources)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.MergeTagsResponse{Canonical: tag.ToProtobuf(), MovedTaskCount: int32(moved)}, nil
+}
+
+// AddTagSynonym makes a name resolve to an existing tag
+func (h *TagHandler) AddTagSynonym(ctx context.Context, req *todov1.AddTagSynonymRequest) (*todov1.AddTagSynonymResponse, error) {
+	h.logger.Info(ctx, "Adding tag synonym via gRPC", "tag_id", req.GetTagId(), "synonym", req.GetSynonym())
+
+	synonym, err := h.tagService.AddTagSynonym(ctx, req.GetTagId(), req.GetSynonym())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.AddTagSynonymResponse{Synonym: synonym.ToProtobuf()}, nil
+}
+
+// RemoveTagSynonym removes a tag synonym
+func (h *TagHandler) RemoveTagSynonym(ctx context.Context, req *todov1.RemoveTagSynonymRequest) (*todov1.RemoveTagSynonymResponse, error) {
+	h.logger.Info(ctx, "Removing tag synonym via gRPC", "synonym", req.GetSynonym())
+
+	if err := h.tagService.RemoveTagSynonym(ctx, req.GetSynonym()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.RemoveTagSynonymResponse{Success: true}, nil
+}
+
+// ListTagSynonyms lists the synonyms of a tag
+func (h *TagHandler) ListTagSynonyms(ctx context.Context, req *todov1.ListTagSynonymsRequest) (*todov1.ListTagSynonymsResponse, error) {
+	h.logger.Info(ctx, "Listing tag synonyms via gRPC", "tag_id", req.GetTagId())
+
+	synonyms, err := h.tagService.ListTagSynonyms(ctx, req.GetTagId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListTagSynonymsResponse{}
+	for _, synonym := range synonyms {
+		resp.Synonyms = append(resp.Synonyms, synonym.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// GetDuplicateTagReport lists pairs of tags that are probably duplicates, for review before merging
+func (h *TagHandler) GetDuplicateTagReport(ctx context.Context, req *todov1.GetDuplicateTagReportRequest) (*todov1.GetDuplicateTagReportResponse, error) {
+	h.logger.Info(ctx, "Getting duplicate tag report via gRPC", "limit", req.GetLimit())
+
+	duplicates, err := h.tagService.GetDuplicateTagReport(ctx, int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.GetDuplicateTagReportResponse{}
+	for _, duplicate := range duplicates {
+		resp.Pairs = append(resp.Pairs, duplicate.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// SuggestTags returns tags completing a type-ahead query
+func (h *TagHandler) SuggestTags(ctx context.Context, req *todov1.SuggestTagsRequest) (*todov1.SuggestTagsResponse, error) {
+	h.logger.Info(ctx, "Suggesting tags via gRPC", "query", req.GetQuery(), "limit", req.GetLimit())
+
+	suggestions, timedOut, err := h.tagService.SuggestTags(ctx, req.GetQuery(), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.SuggestTagsResponse{TimedOut: timedOut}
+	for _, suggestion := range suggestions {
+		resp.Suggestions = append(resp.Suggestions, suggestion.ToProtobuf())
+	}
+	return resp, nil
+}
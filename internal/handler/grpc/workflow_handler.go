@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/service"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	todov1 "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// WorkflowHandler implements the gRPC WorkflowService
+type WorkflowHandler struct {
+	todov1.UnimplementedWorkflowServiceServer
+	workflowService service.WorkflowService
+	logger          logger.Logger
+}
+
+// NewWorkflowHandler creates a new workflow gRPC handler
+func NewWorkflowHandler(workflowService service.WorkflowService, logger logger.Logger) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+		logger:          logger,
+	}
+}
+
+// CreateWorkflow creates a task workflow
+func (h *WorkflowHandler) CreateWorkflow(ctx context.Context, req *todov1.CreateWorkflowRequest) (*todov1.CreateWorkflowResponse, error) {
+	h.logger.Info(ctx, "Creating workflow via gRPC", "name", req.GetName())
+
+	definition := domain.WorkflowDefinitionFromProtobuf(req.GetStates(), req.GetTransitions())
+	workflow, err := h.workflowService.CreateWorkflow(ctx, &domain.Workflow{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		States:      definition.States,
+		Transitions: definition.Transitions,
+		IsDefault:   req.GetIsDefault(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.CreateWorkflowResponse{Workflow: workflow.ToProtobuf()}, nil
+}
+
+// GetWorkflow retrieves a workflow by ID
+func (h *WorkflowHandler) GetWorkflow(ctx context.Context, req *todov1.GetWorkflowRequest) (*todov1.GetWorkflowResponse, error) {
+	h.logger.Info(ctx, "Getting workflow via gRPC", "workflow_id", req.GetWorkflowId())
+
+	workflow, err := h.workflowService.GetWorkflow(ctx, req.GetWorkflowId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.GetWorkflowResponse{Workflow: workflow.ToProtobuf()}, nil
+}
+
+// ListWorkflows lists workflows along with the default one
+func (h *WorkflowHandler) ListWorkflows(ctx context.Context, req *todov1.ListWorkflowsRequest) (*todov1.ListWorkflowsResponse, error) {
+	h.logger.Info(ctx, "Listing workflows via gRPC", "page", req.GetPage())
+
+	workflows, total, defaultWorkflow, err := h.workflowService.ListWorkflows(ctx, repository.ListOptions{
+		Page:     req.GetPage(),
+		PageSize: req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &todov1.ListWorkflowsResponse{
+		TotalCount:      int32(total),
+		DefaultWorkflow: defaultWorkflow.ToProtobuf(),
+	}
+	for _, workflow := range workflows {
+		resp.Workflows = append(resp.Workflows, workflow.ToProtobuf())
+	}
+	return resp, nil
+}
+
+// UpdateWorkflow replaces a workflow's definition
+func (h *WorkflowHandler) UpdateWorkflow(ctx context.Context, req *todov1.UpdateWorkflowRequest) (*todov1.UpdateWorkflowResponse, error) {
+	h.logger.Info(ctx, "Updating workflow via gRPC", "workflow_id", req.GetWorkflowId())
+
+	definition := domain.WorkflowDefinitionFromProtobuf(req.GetStates(), req.GetTransitions())
+	workflow, err := h.workflowService.UpdateWorkflow(ctx, &domain.Workflow{
+		ID:          req.GetWorkflowId(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		States:      definition.States,
+		Transitions: definition.Transitions,
+		IsDefault:   req.GetIsDefault(),
+		Version:     req.GetVersion(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.UpdateWorkflowResponse{Workflow: workflow.ToProtobuf()}, nil
+}
+
+// DeleteWorkflow deletes a workflow no category uses
+func (h *WorkflowHandler) DeleteWorkflow(ctx context.Context, req *todov1.DeleteWorkflowRequest) (*todov1.DeleteWorkflowResponse, error) {
+	h.logger.Info(ctx, "Deleting workflow via gRPC", "workflow_id", req.GetWorkflowId())
+
+	if err := h.workflowService.DeleteWorkflow(ctx, req.GetWorkflowId(), req.GetVersion()); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.DeleteWorkflowResponse{Success: true}, nil
+}
+
+// SetCategoryWorkflow sets the workflow enforced for tasks in a category
+func (h *WorkflowHandler) SetCategoryWorkflow(ctx context.Context, req *todov1.SetCategoryWorkflowRequest) (*todov1.SetCategoryWorkflowResponse, error) {
+	h.logger.Info(ctx, "Setting category workflow via gRPC", "category_id", req.GetCategoryId(), "workflow_id", req.GetWorkflowId())
+
+	category, err := h.workflowService.SetCategoryWorkflow(ctx, req.GetCategoryId(), req.GetWorkflowId(), req.GetVersion())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &todov1.SetCategoryWorkflowResponse{Category: category.ToProtobuf()}, nil
+}
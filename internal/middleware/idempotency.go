@@ -0,0 +1,314 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+// Metadata keys used by idempotent requests
+const (
+	IdempotencyKeyHeader      = "idempotency-key"
+	IdempotencyReplayedHeader = "idempotency-replayed"
+)
+
+// DefaultIdempotentMethods lists the mutating RPCs that honour the idempotency-key header
+var DefaultIdempotentMethods = []string{
+	"/todo.v1.AdminService/CreateTask",
+	"/todo.v1.AdminService/UpdateTask",
+	"/todo.v1.AdminService/DeleteTask",
+	"/todo.v1.AdminService/MoveTask",
+	"/todo.v1.AdminService/AddTaskParticipants",
+	"/todo.v1.AdminService/RemoveTaskParticipants",
+	"/todo.v1.AdminService/AddTaskDependency",
+	"/todo.v1.AdminService/RemoveTaskDependency",
+	"/todo.v1.AdminService/SetTaskRecurrence",
+	"/todo.v1.AdminService/UpdateTaskSeries",
+	"/todo.v1.AdminService/StopTaskSeries",
+	"/todo.v1.AdminService/BulkUpdateTasks",
+	"/todo.v1.AdminService/BulkDeleteTasks",
+	"/todo.v1.AdminService/BulkRestoreTasks",
+	"/todo.v1.CategoryService/CreateCategory",
+	"/todo.v1.CategoryService/UpdateCategory",
+	"/todo.v1.CategoryService/DeleteCategory",
+	"/todo.v1.CategoryService/RestoreCategory",
+	"/todo.v1.CategoryService/MoveCategory",
+	"/todo.v1.CategoryService/MergeCategories",
+	"/todo.v1.TagService/CreateTag",
+	"/todo.v1.TagService/UpdateTag",
+	"/todo.v1.TagService/DeleteTag",
+	"/todo.v1.TagService/MergeTags",
+	"/todo.v1.TagService/AddTagSynonym",
+	"/todo.v1.TagService/RemoveTagSynonym",
+	"/todo.v1.SavedViewService/CreateSavedView",
+	"/todo.v1.SavedViewService/UpdateSavedView",
+	"/todo.v1.SavedViewService/DeleteSavedView",
+	"/todo.v1.WorkflowService/CreateWorkflow",
+	"/todo.v1.WorkflowService/UpdateWorkflow",
+	"/todo.v1.WorkflowService/DeleteWorkflow",
+	"/todo.v1.WorkflowService/SetCategoryWorkflow",
+	"/todo.v1.CommentService/CreateComment",
+	"/todo.v1.CommentService/UpdateComment",
+	"/todo.v1.CommentService/DeleteComment",
+	"/todo.v1.AttachmentService/DeleteAttachment",
+	"/todo.v1.NotificationService/UpdateNotificationSettings",
+}
+
+// idempotencyPollInterval is how often a duplicate waits for the original request to finish
+const idempotencyPollInterval = 50 * time.Millisecond
+
+// IdempotencyOptions configures UnaryIdempotencyInterceptor
+type IdempotencyOptions struct {
+	// TTL is how long a stored response can be replayed
+	TTL time.Duration
+	// LockTimeout bounds how long a duplicate waits for the original request and
+	// after which an unfinished claim is considered abandoned. A running request
+	// renews its claim, so only a claim whose instance died is taken over.
+	LockTimeout time.Duration
+	// Methods are the full gRPC method names the interceptor applies to
+	Methods []string
+}
+
+// UnaryIdempotencyInterceptor makes mutating RPCs safe to retry. When a request carries an
+// idempotency-key header, the first response for that caller and key is stored; replays
+// return it without running the handler again, and reusing the key with a different
+// request fails with AlreadyExists. Concurrent duplicates wait for the original to finish.
+// Failed requests are not stored so they can be retried with the same key.
+func UnaryIdempotencyInterceptor(store repository.IdempotencyRepository, opts IdempotencyOptions, log logger.Logger) grpc.UnaryServerInterceptor {
+	methods := make(map[string]bool, len(opts.Methods))
+	for _, method := range opts.Methods {
+		methods[method] = true
+	}
+	locks := newKeyedMutex()
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		key := firstMetadataValue(md, IdempotencyKeyHeader)
+		if key == "" {
+			return handler(ctx, req)
+		}
+		if !isValidRequestID(key) {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"%s must be 1-%d printable ASCII characters", IdempotencyKeyHeader, maxRequestIDLength)
+		}
+
+		message, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		requestHash, err := hashRequest(info.FullMethod, message)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash request: %v", err)
+		}
+
+		callerID := callerKey(ctx)
+
+		// Serialize duplicates within this instance; the store serializes across instances
+		unlock := locks.lock(callerID + "\x00" + key)
+		defer unlock()
+
+		record := &domain.IdempotencyRecord{
+			CallerID:    callerID,
+			Key:         key,
+			Method:      info.FullMethod,
+			RequestHash: requestHash,
+			ExpiresAt:   time.Now().Add(opts.TTL),
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, opts.LockTimeout)
+		defer cancel()
+
+		for {
+			existing, claimed, err := store.Claim(ctx, record, opts.LockTimeout)
+			if err != nil {
+				log.Error(ctx, "Failed to claim idempotency key", "error", err)
+				return nil, status.Error(codes.Unavailable, "idempotency store unavailable")
+			}
+
+			if claimed {
+				return executeIdempotent(ctx, store, record, opts.LockTimeout, req, handler, log)
+			}
+
+			if !existing.Matches(info.FullMethod, requestHash) {
+				return nil, status.Errorf(codes.AlreadyExists,
+					"%s %q was already used for a different request", IdempotencyKeyHeader, key)
+			}
+
+			if existing.IsCompleted() {
+				return replayResponse(ctx, existing)
+			}
+
+			// The original request is still running, possibly on another instance
+			select {
+			case <-waitCtx.Done():
+				return nil, status.Errorf(codes.Aborted,
+					"a request with %s %q is still in progress", IdempotencyKeyHeader, key)
+			case <-time.After(idempotencyPollInterval):
+			}
+		}
+	}
+}
+
+// executeIdempotent runs the handler for a claimed key and stores its response
+func executeIdempotent(
+	ctx context.Context,
+	store repository.IdempotencyRepository,
+	record *domain.IdempotencyRecord,
+	lockTimeout time.Duration,
+	req interface{},
+	handler grpc.UnaryHandler,
+	log logger.Logger,
+) (interface{}, error) {
+	// A handler outlasting the lock timeout would otherwise be taken over and run twice
+	stopRenewing := renewClaim(ctx, store, record, lockTimeout/3, log)
+	resp, err := handler(ctx, req)
+	stopRenewing()
+
+	// Persist the outcome even if the client has gone away
+	storeCtx := context.WithoutCancel(ctx)
+
+	if err != nil {
+		if releaseErr := store.Release(storeCtx, record.CallerID, record.Key, record.RequestHash); releaseErr != nil {
+			log.Warn(ctx, "Failed to release idempotency key", "error", releaseErr)
+		}
+		return resp, err
+	}
+
+	if message, ok := resp.(proto.Message); ok {
+		data, marshalErr := proto.Marshal(message)
+		if marshalErr == nil {
+			record.ResponseType = string(message.ProtoReflect().Descriptor().FullName())
+			record.Response = data
+			marshalErr = store.Complete(storeCtx, record)
+		}
+		if marshalErr != nil {
+			// The response is still returned; a retry re-runs once the claim goes stale
+			log.Warn(ctx, "Failed to store idempotent response", "error", marshalErr)
+		}
+	}
+
+	return resp, nil
+}
+
+// renewClaim renews the claim on record every interval until the returned stop is called
+func renewClaim(ctx context.Context, store repository.IdempotencyRepository, record *domain.IdempotencyRecord, interval time.Duration, log logger.Logger) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		renewCtx := context.WithoutCancel(ctx)
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := store.Renew(renewCtx, record.CallerID, record.Key, record.RequestHash); err != nil {
+					log.Warn(ctx, "Failed to renew idempotency claim", "error", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// replayResponse rebuilds the stored response of a completed request
+func replayResponse(ctx context.Context, record *domain.IdempotencyRecord) (interface{}, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(record.ResponseType))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unknown stored response type %q", record.ResponseType)
+	}
+
+	message := messageType.New().Interface()
+	if err := proto.Unmarshal(record.Response, message); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode stored response: %v", err)
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(IdempotencyReplayedHeader, "true"))
+	return message, nil
+}
+
+// hashRequest fingerprints the method and request payload
+func hashRequest(method string, message proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// keyedMutex provides one mutex per key, dropping it once no one holds or waits for it
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*refMutex
+}
+
+type refMutex struct {
+	sync.Mutex
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*refMutex)}
+}
+
+func (k *keyedMutex) lock(key string) (unlock func()) {
+	k.mu.Lock()
+	m, exists := k.locks[key]
+	if !exists {
+		m = &refMutex{}
+		k.locks[key] = m
+	}
+	m.refs++
+	k.mu.Unlock()
+
+	m.Lock()
+
+	return func() {
+		m.Unlock()
+		k.mu.Lock()
+		m.refs--
+		if m.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
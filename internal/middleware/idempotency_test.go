@@ -0,0 +1,243 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// memoryIdempotencyStore is an in-memory repository.IdempotencyRepository for tests
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Claim(ctx context.Context, record *domain.IdempotencyRecord, staleAfter time.Duration) (*domain.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	id := record.CallerID + "/" + record.Key
+	if existing, ok := s.records[id]; ok {
+		stale := !existing.IsCompleted() && existing.CreatedAt.Before(now.Add(-staleAfter))
+		if existing.ExpiresAt.After(now) && !stale {
+			copied := *existing
+			return &copied, false, nil
+		}
+	}
+
+	claimed := *record
+	claimed.CreatedAt = now
+	s.records[id] = &claimed
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Get(ctx context.Context, callerID, key string) (*domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.records[callerID+"/"+key]; ok {
+		copied := *record
+		return &copied, nil
+	}
+	return nil, domain.ErrNotFound("idempotency key")
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	stored := s.records[record.CallerID+"/"+record.Key]
+	stored.ResponseType = record.ResponseType
+	stored.Response = record.Response
+	stored.CompletedAt = &now
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Renew(ctx context.Context, callerID, key, requestHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.records[callerID+"/"+key]; ok && record.RequestHash == requestHash && !record.IsCompleted() {
+		record.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, callerID, key, requestHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, callerID+"/"+key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) DeleteExpired(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestUnaryIdempotencyInterceptor(t *testing.T) {
+	const method = "/todo.v1.AdminService/CreateTask"
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	opts := IdempotencyOptions{TTL: time.Hour, LockTimeout: time.Second, Methods: []string{method}}
+
+	newCtx := func(key string) context.Context {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, key))
+		return requestctx.WithUser(ctx, "user-1", "user")
+	}
+
+	t.Run("replay returns the original response", func(t *testing.T) {
+		interceptor := UnaryIdempotencyInterceptor(newMemoryIdempotencyStore(), opts, logger.NewLogger("error"))
+		var calls int32
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			n := atomic.AddInt32(&calls, 1)
+			return wrapperspb.Int32(n), nil
+		}
+
+		for i := 0; i < 3; i++ {
+			resp, err := interceptor(newCtx("key-1"), wrapperspb.String("task"), info, handler)
+			if err != nil {
+				t.Fatalf("call %d error = %v", i+1, err)
+			}
+			if got := resp.(*wrapperspb.Int32Value).GetValue(); got != 1 {
+				t.Errorf("call %d response = %d, want original response 1", i+1, got)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("handler called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("different payload with same key is a conflict", func(t *testing.T) {
+		interceptor := UnaryIdempotencyInterceptor(newMemoryIdempotencyStore(), opts, logger.NewLogger("error"))
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+
+		if _, err := interceptor(newCtx("key-2"), wrapperspb.String("first"), info, handler); err != nil {
+			t.Fatalf("first call error = %v", err)
+		}
+		_, err := interceptor(newCtx("key-2"), wrapperspb.String("second"), info, handler)
+		if status.Code(err) != codes.AlreadyExists {
+			t.Errorf("error = %v, want AlreadyExists", err)
+		}
+	})
+
+	t.Run("failed requests can be retried", func(t *testing.T) {
+		interceptor := UnaryIdempotencyInterceptor(newMemoryIdempotencyStore(), opts, logger.NewLogger("error"))
+		var calls int32
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return nil, errors.New("transient failure")
+			}
+			return req, nil
+		}
+
+		if _, err := interceptor(newCtx("key-3"), wrapperspb.String("task"), info, handler); err == nil {
+			t.Fatal("first call should fail")
+		}
+		if _, err := interceptor(newCtx("key-3"), wrapperspb.String("task"), info, handler); err != nil {
+			t.Errorf("retry error = %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("handler called %d times, want 2", calls)
+		}
+	})
+
+	t.Run("concurrent duplicates run once", func(t *testing.T) {
+		interceptor := UnaryIdempotencyInterceptor(newMemoryIdempotencyStore(), opts, logger.NewLogger("error"))
+		var calls int32
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(20 * time.Millisecond)
+			return req, nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := interceptor(newCtx("key-4"), wrapperspb.String("task"), info, handler); err != nil {
+					t.Errorf("concurrent call error = %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if calls != 1 {
+			t.Errorf("handler called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("a slow request keeps its claim", func(t *testing.T) {
+		// Two instances share the store; the second must not take over the running claim
+		store := newMemoryIdempotencyStore()
+		short := IdempotencyOptions{TTL: time.Hour, LockTimeout: 60 * time.Millisecond, Methods: []string{method}}
+		first := UnaryIdempotencyInterceptor(store, short, logger.NewLogger("error"))
+		second := UnaryIdempotencyInterceptor(store, short, logger.NewLogger("error"))
+		var calls int32
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(300 * time.Millisecond)
+			return req, nil
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := first(newCtx("key-6"), wrapperspb.String("task"), info, handler)
+			done <- err
+		}()
+		time.Sleep(150 * time.Millisecond)
+
+		if _, err := second(newCtx("key-6"), wrapperspb.String("task"), info, handler); status.Code(err) != codes.Aborted {
+			t.Errorf("duplicate error = %v, want Aborted while the original runs", err)
+		}
+		if err := <-done; err != nil {
+			t.Errorf("original error = %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("handler called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("requests without a key or for other methods pass through", func(t *testing.T) {
+		interceptor := UnaryIdempotencyInterceptor(newMemoryIdempotencyStore(), opts, logger.NewLogger("error"))
+		var calls int32
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return req, nil
+		}
+
+		otherInfo := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.AdminService/GetTask"}
+		for i := 0; i < 2; i++ {
+			interceptor(context.Background(), wrapperspb.String("task"), info, handler)
+			interceptor(newCtx("key-5"), wrapperspb.String("task"), otherInfo, handler)
+		}
+		if calls != 4 {
+			t.Errorf("handler called %d times, want 4", calls)
+		}
+	})
+
+	t.Run("invalid key is rejected", func(t *testing.T) {
+		interceptor := UnaryIdempotencyInterceptor(newMemoryIdempotencyStore(), opts, logger.NewLogger("error"))
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+
+		_, err := interceptor(newCtx("bad key"), wrapperspb.String("task"), info, handler)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("error = %v, want InvalidArgument", err)
+		}
+	})
+}
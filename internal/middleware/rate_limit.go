@@ -0,0 +1,227 @@
+package middleware
+
+import (
+	"context"
+	"expvar"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/todo-app/services/admin-service/internal/config"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// RetryAfterHeader is the response metadata key telling a limited caller when to retry
+const RetryAfterHeader = "retry-after"
+
+// bucketIdleTimeout is how long an unused bucket is kept before it is evicted
+const bucketIdleTimeout = 10 * time.Minute
+
+// RateLimiter enforces token-bucket rate limits and daily quotas per caller and method.
+// Callers are identified by authenticated user, then peer IP.
+type RateLimiter struct {
+	mu        sync.Mutex
+	cfg       config.RateLimitConfig
+	buckets   map[bucketKey]*tokenBucket
+	quotas    map[bucketKey]int
+	quotaDay  string
+	lastSweep time.Time
+	now       func() time.Time
+
+	metrics *expvar.Map
+}
+
+type bucketKey struct {
+	caller string
+	method string
+}
+
+type tokenBucket struct {
+	tokens   float64
+	rate     float64
+	burst    float64
+	lastSeen time.Time
+}
+
+// NewRateLimiter creates a rate limiter from the given configuration
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		cfg:     cfg,
+		buckets: make(map[bucketKey]*tokenBucket),
+		quotas:  make(map[bucketKey]int),
+		now:     time.Now,
+		metrics: new(expvar.Map).Init(),
+	}
+}
+
+// Update replaces the limits, e.g. after a configuration reload.
+// Existing buckets are dropped so the new limits apply immediately; daily quota usage is kept.
+func (l *RateLimiter) Update(cfg config.RateLimitConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cfg = cfg
+	l.buckets = make(map[bucketKey]*tokenBucket)
+}
+
+// Metrics returns counters of allowed, rate limited and quota exceeded calls,
+// keyed as "<outcome>:<method>". Publish it with expvar.Publish to expose it.
+func (l *RateLimiter) Metrics() *expvar.Map {
+	return l.metrics
+}
+
+// Allow reports whether the caller may invoke method now. When it may not,
+// the returned duration says how long the caller should wait before retrying.
+func (l *RateLimiter) Allow(caller, method string) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.cfg.Enabled {
+		return true, 0, nil
+	}
+
+	now := l.now()
+	l.sweep(now)
+	key := bucketKey{caller: caller, method: method}
+
+	// Daily quotas are checked first so a caller over quota doesn't consume tokens
+	quota, hasQuota := l.cfg.DailyQuotas[method]
+	if hasQuota {
+		l.resetQuotasIfNewDay(now)
+		if l.quotas[key] >= quota {
+			l.metrics.Add("quota_exceeded:"+method, 1)
+			return false, untilNextDay(now), status.Errorf(codes.ResourceExhausted,
+				"daily quota of %d calls to %s exceeded", quota, method)
+		}
+	}
+
+	bucket := l.bucket(key, now)
+	if bucket.tokens < 1 {
+		wait := time.Duration(math.Ceil((1 - bucket.tokens) / bucket.rate * float64(time.Second)))
+		l.metrics.Add("rate_limited:"+method, 1)
+		return false, wait, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", method)
+	}
+	bucket.tokens--
+
+	if hasQuota {
+		l.quotas[key]++
+	}
+	l.metrics.Add("allowed:"+method, 1)
+	return true, 0, nil
+}
+
+// bucket returns the refilled bucket for key, creating a full one if needed
+func (l *RateLimiter) bucket(key bucketKey, now time.Time) *tokenBucket {
+	bucket, exists := l.buckets[key]
+	if !exists {
+		rate, burst := l.cfg.RequestsPerSecond, l.cfg.Burst
+		if limit, ok := l.cfg.Methods[key.method]; ok {
+			rate, burst = limit.RequestsPerSecond, limit.Burst
+		}
+		bucket = &tokenBucket{tokens: float64(burst), rate: rate, burst: float64(burst), lastSeen: now}
+		l.buckets[key] = bucket
+		return bucket
+	}
+
+	elapsed := now.Sub(bucket.lastSeen).Seconds()
+	bucket.tokens = math.Min(bucket.burst, bucket.tokens+elapsed*bucket.rate)
+	bucket.lastSeen = now
+	return bucket
+}
+
+// sweep evicts buckets that have not been used recently so memory stays bounded
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < bucketIdleTimeout {
+		return
+	}
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) > bucketIdleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func (l *RateLimiter) resetQuotasIfNewDay(now time.Time) {
+	day := now.UTC().Format("2006-01-02")
+	if day != l.quotaDay {
+		l.quotas = make(map[bucketKey]int)
+		l.quotaDay = day
+	}
+}
+
+func untilNextDay(now time.Time) time.Duration {
+	utc := now.UTC()
+	midnight := time.Date(utc.Year(), utc.Month(), utc.Day()+1, 0, 0, 0, 0, time.UTC)
+	return midnight.Sub(utc)
+}
+
+// UnaryRateLimitInterceptor rejects calls over the limit with ResourceExhausted and a
+// retry-after header (in whole seconds). It must run after the request context interceptor.
+func UnaryRateLimitInterceptor(limiter *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		allowed, retryAfter, err := limiter.Allow(callerKey(ctx), info.FullMethod)
+		if !allowed {
+			_ = grpc.SetHeader(ctx, retryAfterMetadata(retryAfter))
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor is the streaming counterpart of UnaryRateLimitInterceptor;
+// a stream counts as a single call
+func StreamRateLimitInterceptor(limiter *RateLimiter) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		allowed, retryAfter, err := limiter.Allow(callerKey(ss.Context()), info.FullMethod)
+		if !allowed {
+			_ = ss.SetHeader(retryAfterMetadata(retryAfter))
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func retryAfterMetadata(retryAfter time.Duration) metadata.MD {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return metadata.Pairs(RetryAfterHeader, strconv.FormatInt(seconds, 10))
+}
+
+// callerKey identifies the caller for rate limiting: the authenticated user, else the peer
+// IP address. Unverified metadata such as an API key is not used, since a client could
+// send a new value on every call and get a fresh bucket each time; API keys are verified
+// by the gateway, which forwards the user they belong to.
+func callerKey(ctx context.Context) string {
+	if userID := requestctx.UserID(ctx); userID != "" {
+		return "user:" + userID
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "ip:" + host
+		}
+		return "ip:" + p.Addr.String()
+	}
+
+	return "anonymous"
+}
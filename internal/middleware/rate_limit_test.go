@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/todo-app/services/admin-service/internal/config"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+const syncMethod = "/todo.v1.UserService/SyncTasks"
+
+func newTestRateLimiter(cfg config.RateLimitConfig, now *time.Time) *RateLimiter {
+	limiter := NewRateLimiter(cfg)
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(config.RateLimitConfig{
+		Enabled:           true,
+		RequestsPerSecond: 10,
+		Burst:             10,
+		Methods:           map[string]config.MethodLimit{syncMethod: {RequestsPerSecond: 1, Burst: 2}},
+	}, &now)
+
+	// Burst is available immediately, then the caller is limited
+	for i := 0; i < 2; i++ {
+		if allowed, _, err := limiter.Allow("user:1", syncMethod); !allowed {
+			t.Fatalf("call %d should be allowed, got %v", i+1, err)
+		}
+	}
+	allowed, retryAfter, err := limiter.Allow("user:1", syncMethod)
+	if allowed {
+		t.Fatal("third call should be rate limited")
+	}
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("error code = %v, want ResourceExhausted", status.Code(err))
+	}
+	if retryAfter != time.Second {
+		t.Errorf("retryAfter = %v, want 1s", retryAfter)
+	}
+
+	// Other callers and other methods have their own buckets
+	if allowed, _, _ := limiter.Allow("user:2", syncMethod); !allowed {
+		t.Error("a different caller should not be limited")
+	}
+	if allowed, _, _ := limiter.Allow("user:1", "/todo.v1.AdminService/ListTasks"); !allowed {
+		t.Error("a different method should use the default limit")
+	}
+
+	// Tokens refill over time
+	now = now.Add(time.Second)
+	if allowed, _, err := limiter.Allow("user:1", syncMethod); !allowed {
+		t.Errorf("call after refill should be allowed, got %v", err)
+	}
+
+	if got := limiter.Metrics().Get("rate_limited:" + syncMethod).String(); got != "1" {
+		t.Errorf("rate_limited metric = %s, want 1", got)
+	}
+}
+
+func TestRateLimiter_DailyQuota(t *testing.T) {
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	method := "/todo.v1.AdminService/ExportTasks"
+	limiter := newTestRateLimiter(config.RateLimitConfig{
+		Enabled:           true,
+		RequestsPerSecond: 100,
+		Burst:             100,
+		DailyQuotas:       map[string]int{method: 2},
+	}, &now)
+
+	for i := 0; i < 2; i++ {
+		if allowed, _, err := limiter.Allow("user:1", method); !allowed {
+			t.Fatalf("call %d should be allowed, got %v", i+1, err)
+		}
+	}
+
+	allowed, retryAfter, _ := limiter.Allow("user:1", method)
+	if allowed {
+		t.Fatal("call over the daily quota should be rejected")
+	}
+	if retryAfter != time.Hour {
+		t.Errorf("retryAfter = %v, want time until midnight UTC (1h)", retryAfter)
+	}
+
+	// The quota resets the next day
+	now = now.Add(2 * time.Hour)
+	if allowed, _, err := limiter.Allow("user:1", method); !allowed {
+		t.Errorf("call on the next day should be allowed, got %v", err)
+	}
+}
+
+func TestRateLimiter_DisabledAndUpdate(t *testing.T) {
+	now := time.Now()
+	limiter := newTestRateLimiter(config.RateLimitConfig{Enabled: false, RequestsPerSecond: 1, Burst: 1}, &now)
+
+	for i := 0; i < 5; i++ {
+		if allowed, _, _ := limiter.Allow("user:1", syncMethod); !allowed {
+			t.Fatal("disabled limiter should allow every call")
+		}
+	}
+
+	limiter.Update(config.RateLimitConfig{Enabled: true, RequestsPerSecond: 1, Burst: 1})
+	limiter.Allow("user:1", syncMethod)
+	if allowed, _, _ := limiter.Allow("user:1", syncMethod); allowed {
+		t.Error("updated limits should apply immediately")
+	}
+}
+
+func TestUnaryRateLimitInterceptor(t *testing.T) {
+	limiter := NewRateLimiter(config.RateLimitConfig{Enabled: true, RequestsPerSecond: 1, Burst: 1})
+	interceptor := UnaryRateLimitInterceptor(limiter)
+	info := &grpc.UnaryServerInfo{FullMethod: syncMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+	if _, err := interceptor(ctx, nil, info, handler); err != nil {
+		t.Fatalf("first call should succeed, got %v", err)
+	}
+	_, err := interceptor(ctx, nil, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("second call error = %v, want ResourceExhausted", err)
+	}
+}
+
+func TestCallerKey(t *testing.T) {
+	peerCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 51234},
+	})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "authenticated user wins",
+			ctx:  requestctx.WithUser(peerCtx, "user-1", "admin"),
+			want: "user:user-1",
+		},
+		{
+			name: "peer IP without port",
+			ctx:  peerCtx,
+			want: "ip:10.0.0.7",
+		},
+		{
+			name: "no identity",
+			ctx:  context.Background(),
+			want: "anonymous",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := callerKey(tt.ctx); got != tt.want {
+				t.Errorf("callerKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Unverified identity metadata must not buy a fresh bucket
+	for _, md := range []metadata.MD{
+		metadata.Pairs("x-api-key", "random-1"),
+		metadata.Pairs(UserIDHeader, "random-2"),
+	} {
+		if got := callerKey(metadata.NewIncomingContext(peerCtx, md)); got != "ip:10.0.0.7" {
+			t.Errorf("callerKey(%v) = %q, want the peer IP", md, got)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// Metadata keys used to propagate request-scoped values
+const (
+	RequestIDHeader   = "x-request-id"
+	TraceParentHeader = "traceparent"
+	TraceIDHeader     = "x-trace-id"
+	UserIDHeader      = "x-user-id"
+	UserRoleHeader    = "x-user-role"
+)
+
+// maxRequestIDLength bounds client-supplied request IDs so they can't bloat log lines
+const maxRequestIDLength = 128
+
+// RequestContextOptions configures how the request context is established
+type RequestContextOptions struct {
+	// TrustGatewayIdentity takes the caller from x-user-id / x-user-role metadata, which an
+	// authenticating API gateway sets. Without it those headers are ignored and every
+	// caller is anonymous, since any client could send them.
+	TrustGatewayIdentity bool
+}
+
+// UnaryRequestContextInterceptor populates the request context with the request ID,
+// authenticated user, gRPC method and trace ID, and echoes the request ID back
+// in the response headers
+func UnaryRequestContextInterceptor(opts RequestContextOptions) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx = newRequestContext(ctx, info.FullMethod, opts)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestctx.RequestID(ctx)))
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRequestContextInterceptor is the streaming counterpart of UnaryRequestContextInterceptor
+func StreamRequestContextInterceptor(opts RequestContextOptions) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := newRequestContext(ss.Context(), info.FullMethod, opts)
+		_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, requestctx.RequestID(ctx)))
+
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// newRequestContext derives a context carrying all request-scoped values from incoming metadata
+func newRequestContext(ctx context.Context, method string, opts RequestContextOptions) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := firstMetadataValue(md, RequestIDHeader)
+	if !isValidRequestID(requestID) {
+		requestID = uuid.New().String()
+	}
+
+	ctx = requestctx.WithRequestID(ctx, requestID)
+	ctx = requestctx.WithMethod(ctx, method)
+
+	if traceID := traceIDFromMetadata(md); traceID != "" {
+		ctx = requestctx.WithTraceID(ctx, traceID)
+	}
+
+	// Only a trusted gateway, which authenticated the caller, may name the user
+	if !opts.TrustGatewayIdentity {
+		return ctx
+	}
+	if userID := firstMetadataValue(md, UserIDHeader); userID != "" {
+		ctx = requestctx.WithUser(ctx, userID, firstMetadataValue(md, UserRoleHeader))
+	}
+
+	return ctx
+}
+
+// traceIDFromMetadata extracts the trace ID from a W3C traceparent header,
+// falling back to a plain x-trace-id header
+func traceIDFromMetadata(md metadata.MD) string {
+	if traceParent := firstMetadataValue(md, TraceParentHeader); traceParent != "" {
+		// Format: version-traceid-parentid-flags
+		parts := strings.Split(traceParent, "-")
+		if len(parts) == 4 && len(parts[1]) == 32 {
+			return parts[1]
+		}
+	}
+	return firstMetadataValue(md, TraceIDHeader)
+}
+
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, char := range requestID {
+		if char < 0x21 || char > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// wrappedServerStream overrides the context of a grpc.ServerStream
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedServerStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+func TestUnaryRequestContextInterceptor(t *testing.T) {
+	interceptor := UnaryRequestContextInterceptor(RequestContextOptions{TrustGatewayIdentity: true})
+	info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.AdminService/GetTask"}
+
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		wantRequestID string
+		wantTraceID   string
+		wantUserID    string
+	}{
+		{
+			name:          "request ID taken from metadata",
+			md:            metadata.Pairs(RequestIDHeader, "client-req-1", UserIDHeader, "user-1", UserRoleHeader, "admin"),
+			wantRequestID: "client-req-1",
+			wantUserID:    "user-1",
+		},
+		{
+			name:        "trace ID from traceparent",
+			md:          metadata.Pairs(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:        "trace ID from x-trace-id",
+			md:          metadata.Pairs(TraceIDHeader, "trace-abc"),
+			wantTraceID: "trace-abc",
+		},
+		{
+			name: "invalid request ID is replaced",
+			md:   metadata.Pairs(RequestIDHeader, "bad id with spaces"),
+		},
+		{
+			name: "no metadata",
+			md:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var handlerCtx context.Context
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerCtx = ctx
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatalf("interceptor returned error: %v", err)
+			}
+
+			requestID := requestctx.RequestID(handlerCtx)
+			if tt.wantRequestID != "" && requestID != tt.wantRequestID {
+				t.Errorf("RequestID = %q, want %q", requestID, tt.wantRequestID)
+			}
+			if requestID == "" || requestID == "bad id with spaces" {
+				t.Errorf("RequestID = %q, expected a generated ID", requestID)
+			}
+			if got := requestctx.Method(handlerCtx); got != info.FullMethod {
+				t.Errorf("Method = %q, want %q", got, info.FullMethod)
+			}
+			if got := requestctx.TraceID(handlerCtx); got != tt.wantTraceID {
+				t.Errorf("TraceID = %q, want %q", got, tt.wantTraceID)
+			}
+			if got := requestctx.UserID(handlerCtx); got != tt.wantUserID {
+				t.Errorf("UserID = %q, want %q", got, tt.wantUserID)
+			}
+		})
+	}
+}
+
+func TestUnaryRequestContextInterceptor_UntrustedIdentity(t *testing.T) {
+	interceptor := UnaryRequestContextInterceptor(RequestContextOptions{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/todo.v1.AdminService/GetTask"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(UserIDHeader, "user-1", UserRoleHeader, "admin"))
+
+	var handlerCtx context.Context
+	if _, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCtx = ctx
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("interceptor returned error: %v", err)
+	}
+
+	// Any client can send these headers, so they are ignored unless a gateway vouches for them
+	if userID, role := requestctx.UserID(handlerCtx), requestctx.UserRole(handlerCtx); userID != "" || role != "" {
+		t.Errorf("caller = %q (%q), want anonymous", userID, role)
+	}
+}
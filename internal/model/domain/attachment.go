@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+	"unicode"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// MaxAttachmentFileNameLength bounds the file name of an attachment, in characters
+const MaxAttachmentFileNameLength = 255
+
+// Attachment is a file attached to a task. The content lives in a blob store under StorageKey.
+type Attachment struct {
+	ID     string `json:"id" db:"id"`
+	TaskID string `json:"task_id" db:"task_id"`
+	// UploaderID is the authenticated user who uploaded the file; their quota is charged for it
+	UploaderID  string `json:"uploader_id" db:"uploader_id"`
+	FileName    string `json:"file_name" db:"file_name"`
+	ContentType string `json:"content_type" db:"content_type"`
+	SizeBytes   int64  `json:"size_bytes" db:"size_bytes"`
+	// ChecksumSHA256 is the hex encoded SHA-256 of the content
+	ChecksumSHA256 string     `json:"checksum_sha256" db:"checksum_sha256"`
+	StorageKey     string     `json:"-" db:"storage_key"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+	Version        int64      `json:"version" db:"version"`
+	IsDeleted      bool       `json:"is_deleted" db:"is_deleted"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// AttachmentStorageKey returns the blob store key of an attachment's content
+func AttachmentStorageKey(taskID, attachmentID string) string {
+	return "tasks/" + taskID + "/" + attachmentID
+}
+
+// IsValid validates the attachment data
+func (a *Attachment) IsValid() error {
+	if a.TaskID == "" {
+		return ErrInvalidInput("attachment must belong to a task")
+	}
+	if a.UploaderID == "" {
+		return ErrInvalidInput("attachment must have an uploader")
+	}
+	if err := ValidateAttachmentFileName(a.FileName); err != nil {
+		return err
+	}
+	if a.ContentType == "" {
+		return ErrInvalidInput("attachment content type is required")
+	}
+	if a.SizeBytes < 0 {
+		return ErrInvalidInput("attachment size cannot be negative")
+	}
+	if len(a.ChecksumSHA256) != 64 {
+		return ErrInvalidInput("attachment checksum must be a hex encoded SHA-256")
+	}
+	return nil
+}
+
+// ValidateAttachmentFileName checks that a file name is a plain name without a path
+func ValidateAttachmentFileName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrInvalidInput("attachment file name is required")
+	}
+	if len([]rune(name)) > MaxAttachmentFileNameLength {
+		return ErrInvalidInput(fmt.Sprintf("attachment file name must be at most %d characters", MaxAttachmentFileNameLength))
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return ErrInvalidInput("attachment file name must not contain a path")
+	}
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return ErrInvalidInput("attachment file name must not contain control characters")
+	}
+	return nil
+}
+
+// NormalizeContentType lowercases a media type and drops its parameters, so
+// "Text/Plain; charset=utf-8" becomes "text/plain"
+func NormalizeContentType(contentType string) (string, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return "", ErrInvalidInput("attachment content type is required")
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.Contains(mediaType, "/") {
+		return "", ErrInvalidInput(fmt.Sprintf("invalid content type %q", contentType))
+	}
+	return mediaType, nil
+}
+
+// AttachmentLimits bound what users can upload
+type AttachmentLimits struct {
+	// MaxSizeBytes bounds a single attachment; zero means no limit
+	MaxSizeBytes int64
+	// AllowedTypes lists the accepted media types. A pattern ending in "*" matches every type
+	// with that prefix, e.g. "image/*". Empty accepts any type.
+	AllowedTypes []string
+	// UserQuotaBytes bounds the total size of a user's live attachments; zero means no quota
+	UserQuotaBytes int64
+}
+
+// AllowsType reports whether a normalized media type is accepted
+func (l AttachmentLimits) AllowsType(mediaType string) bool {
+	if len(l.AllowedTypes) == 0 {
+		return true
+	}
+	for _, pattern := range l.AllowedTypes {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(mediaType, prefix) {
+				return true
+			}
+		} else if mediaType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// Check validates an upload of the given type and size by a user who already stores usedBytes
+func (l AttachmentLimits) Check(mediaType string, sizeBytes, usedBytes int64) error {
+	if !l.AllowsType(mediaType) {
+		return ErrInvalidInput(fmt.Sprintf("content type %s is not allowed", mediaType))
+	}
+	if sizeBytes < 0 {
+		return ErrInvalidInput("attachment size cannot be negative")
+	}
+	if l.MaxSizeBytes > 0 && sizeBytes > l.MaxSizeBytes {
+		return ErrInvalidInput(fmt.Sprintf("attachment is %d bytes, the limit is %d", sizeBytes, l.MaxSizeBytes))
+	}
+	if l.UserQuotaBytes > 0 && usedBytes+sizeBytes > l.UserQuotaBytes {
+		return ErrBusinessRule(fmt.Sprintf("attachment quota exceeded: %d of %d bytes used", usedBytes, l.UserQuotaBytes))
+	}
+	return nil
+}
+
+// AttachmentUpload describes an upload before its content is received
+type AttachmentUpload struct {
+	TaskID      string
+	FileName    string
+	ContentType string
+	// SizeBytes is the exact size of the content
+	SizeBytes int64
+	// ChecksumSHA256 optionally carries the expected hex encoded SHA-256 of the content
+	ChecksumSHA256 string
+}
+
+// AttachmentUsage is the storage a user's live attachments take up
+type AttachmentUsage struct {
+	UsedBytes  int64
+	QuotaBytes int64
+}
+
+// ToProtobuf converts an Attachment to protobuf
+func (a *Attachment) ToProtobuf() *pb.Attachment {
+	return &pb.Attachment{
+		Id:             a.ID,
+		TaskId:         a.TaskID,
+		UploaderId:     a.UploaderID,
+		FileName:       a.FileName,
+		ContentType:    a.ContentType,
+		SizeBytes:      a.SizeBytes,
+		ChecksumSha256: a.ChecksumSHA256,
+		CreatedAt:      TimeToProtobuf(a.CreatedAt),
+		Version:        a.Version,
+	}
+}
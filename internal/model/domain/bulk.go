@@ -0,0 +1,48 @@
+package domain
+
+// BulkMode controls how failures are handled in bulk operations
+type BulkMode string
+
+const (
+	// BulkModeAllOrNothing applies every change in one transaction; any failure rolls back all of them
+	BulkModeAllOrNothing BulkMode = "ALL_OR_NOTHING"
+	// BulkModeBestEffort applies each change independently and reports failures per item
+	BulkModeBestEffort BulkMode = "BEST_EFFORT"
+)
+
+// MaxBulkTasks bounds the number of tasks a single bulk operation may touch
+const MaxBulkTasks = 1000
+
+// TaskRef identifies a task at the version the caller last saw
+type TaskRef struct {
+	ID      string `json:"id"`
+	Version int64  `json:"version"`
+}
+
+// TaskChanges describes the fields a bulk update sets; nil fields are left unchanged
+type TaskChanges struct {
+	AssigneeID *string       `json:"assignee_id,omitempty"`
+	Status     *TaskStatus   `json:"status,omitempty"`
+	Priority   *TaskPriority `json:"priority,omitempty"`
+}
+
+// IsEmpty reports whether no change is requested
+func (c TaskChanges) IsEmpty() bool {
+	return c.AssigneeID == nil && c.Status == nil && c.Priority == nil
+}
+
+// BulkItemResult is the outcome of a bulk operation for a single task
+type BulkItemResult struct {
+	TaskID  string `json:"task_id"`
+	Success bool   `json:"success"`
+	Task    *Task  `json:"task,omitempty"`
+	Error   error  `json:"-"`
+}
+
+// BulkResult aggregates per-task outcomes of a bulk operation
+type BulkResult struct {
+	Results    []BulkItemResult `json:"results"`
+	Succeeded  int              `json:"succeeded"`
+	Failed     int              `json:"failed"`
+	RolledBack bool             `json:"rolled_back"`
+}
@@ -11,6 +11,11 @@ func (c *Category) IsValid() error {
 	return nil
 }
 
+// VisibleTo reports whether a user with the given role can see the category
+func (c *Category) VisibleTo(userID string, role UserRole) bool {
+	return c.IsPublic || c.CreatorID == userID || role == UserRoleAdmin
+}
+
 // IsValid validates the tag data
 func (t *Tag) IsValid() error {
 	if t.Name == "" {
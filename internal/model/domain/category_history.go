@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CategoryHistoryAction represents changes recorded for a category
+type CategoryHistoryAction string
+
+const (
+	CategoryHistoryActionMoved    CategoryHistoryAction = "MOVED"
+	CategoryHistoryActionMerged   CategoryHistoryAction = "MERGED"
+	CategoryHistoryActionDeleted  CategoryHistoryAction = "DELETED"
+	CategoryHistoryActionRestored CategoryHistoryAction = "RESTORED"
+
+	CategoryHistoryActionWorkflowChanged CategoryHistoryAction = "WORKFLOW_CHANGED"
+)
+
+// CategoryHistory represents an audit trail entry for changes to the category hierarchy.
+// Details use the same structure as task history entries.
+type CategoryHistory struct {
+	ID         string                `json:"id" db:"id"`
+	CategoryID string                `json:"category_id" db:"category_id"`
+	Action     CategoryHistoryAction `json:"action" db:"action"`
+	ActorID    string                `json:"actor_id" db:"actor_id"`
+	Timestamp  time.Time             `json:"timestamp" db:"timestamp"`
+	Details    json.RawMessage       `json:"details,omitempty" db:"details"`
+}
+
+// GetDetails unmarshals the details field
+func (ch *CategoryHistory) GetDetails() (*TaskHistoryDetails, error) {
+	if ch.Details == nil {
+		return nil, nil
+	}
+
+	var details TaskHistoryDetails
+	if err := json.Unmarshal(ch.Details, &details); err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// DefaultCategoryMaxDepth is the number of category levels allowed when none is configured
+const DefaultCategoryMaxDepth = 5
+
+// CategoryNode is a category in a category tree. Depth is 1 for the top level of the tree.
+type CategoryNode struct {
+	Category *Category       `json:"category"`
+	Depth    int             `json:"depth"`
+	Children []*CategoryNode `json:"children,omitempty"`
+}
+
+// BuildCategoryTree links nodes listed parents first into trees and returns the roots.
+// Nodes whose parent is not listed, or is dropped by keep, are left out with their subtrees.
+func BuildCategoryTree(nodes []*CategoryNode, keep func(*Category) bool) []*CategoryNode {
+	byID := make(map[string]*CategoryNode, len(nodes))
+	var roots []*CategoryNode
+	for _, node := range nodes {
+		if keep != nil && !keep(node.Category) {
+			continue
+		}
+		node.Children = nil
+		if node.Depth == 1 {
+			roots = append(roots, node)
+		} else if node.Category.ParentID != nil {
+			parent, ok := byID[*node.Category.ParentID]
+			if !ok {
+				continue
+			}
+			parent.Children = append(parent.Children, node)
+		} else {
+			continue
+		}
+		byID[node.Category.ID] = node
+	}
+	return roots
+}
+
+// Height returns the number of levels in the subtree rooted at the node
+func (n *CategoryNode) Height() int {
+	height := 0
+	for _, child := range n.Children {
+		height = max(height, child.Height())
+	}
+	return height + 1
+}
+
+// ToProtobuf converts a CategoryNode and its subtree to protobuf
+func (n *CategoryNode) ToProtobuf() *pb.CategoryNode {
+	node := &pb.CategoryNode{
+		Category: n.Category.ToProtobuf(),
+		Depth:    int32(n.Depth),
+	}
+	for _, child := range n.Children {
+		node.Children = append(node.Children, child.ToProtobuf())
+	}
+	return node
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// MaxCommentLength bounds the body of a comment, in characters
+const MaxCommentLength = 10000
+
+// MaxCommentMentions bounds the users a single comment can mention
+const MaxCommentMentions = 20
+
+// mentionPattern matches "@" followed by an email address. The "@" must not follow a word
+// character, so the domain part of a plain email address is not taken for a mention.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.@])@([\w.%+-]+@[\w-]+(?:\.[\w-]+)*\.[A-Za-z]{2,})`)
+
+// Comment is a message in the discussion of a task
+type Comment struct {
+	ID     string `json:"id" db:"id"`
+	TaskID string `json:"task_id" db:"task_id"`
+	// AuthorID is the authenticated user who wrote the comment
+	AuthorID string `json:"author_id" db:"author_id"`
+	Body     string `json:"body" db:"body"`
+	// MentionedUserIDs are the existing users mentioned in the body, in order of appearance
+	MentionedUserIDs []string   `json:"mentioned_user_ids,omitempty" db:"mentioned_user_ids"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+	EditedAt         *time.Time `json:"edited_at,omitempty" db:"edited_at"`
+	Version          int64      `json:"version" db:"version"`
+	IsDeleted        bool       `json:"is_deleted" db:"is_deleted"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// IsValid validates the comment data
+func (c *Comment) IsValid() error {
+	if c.TaskID == "" {
+		return ErrInvalidInput("comment must belong to a task")
+	}
+	if c.AuthorID == "" {
+		return ErrInvalidInput("comment must have an author")
+	}
+	if strings.TrimSpace(c.Body) == "" {
+		return ErrInvalidInput("comment body is required")
+	}
+	if len([]rune(c.Body)) > MaxCommentLength {
+		return ErrInvalidInput(fmt.Sprintf("comment body must be at most %d characters", MaxCommentLength))
+	}
+	return nil
+}
+
+// ExtractMentions returns the email addresses mentioned as @email in a comment body, in order
+// of appearance. An address mentioned again, in any case, is only returned the first time.
+func ExtractMentions(body string) []string {
+	var emails []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := match[1]
+		if key := strings.ToLower(email); !seen[key] {
+			seen[key] = true
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// ToProtobuf converts a Comment to protobuf
+func (c *Comment) ToProtobuf() *pb.Comment {
+	return &pb.Comment{
+		Id:               c.ID,
+		TaskId:           c.TaskID,
+		AuthorId:         c.AuthorID,
+		Body:             c.Body,
+		MentionedUserIds: c.MentionedUserIDs,
+		CreatedAt:        TimeToProtobuf(c.CreatedAt),
+		UpdatedAt:        TimeToProtobuf(c.UpdatedAt),
+		Edited:           c.EditedAt != nil,
+		Version:          c.Version,
+	}
+}
+
+// ActivityItem is an entry of a task's activity feed: either a history entry or a comment
+type ActivityItem struct {
+	Timestamp time.Time
+	History   *TaskHistory
+	Comment   *Comment
+}
+
+// ToProtobuf converts an ActivityItem to protobuf
+func (a *ActivityItem) ToProtobuf() *pb.ActivityItem {
+	item := &pb.ActivityItem{Timestamp: TimeToProtobuf(a.Timestamp)}
+	switch {
+	case a.History != nil:
+		item.Item = &pb.ActivityItem_History{History: a.History.ToProtobuf()}
+	case a.Comment != nil:
+		item.Item = &pb.ActivityItem_Comment{Comment: a.Comment.ToProtobuf()}
+	}
+	return item
+}
@@ -1,7 +1,11 @@
 package domain
 
 import (
+	"strings"
 	"testing"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
 )
 
 func TestUser_IsValid(t *testing.T) {
@@ -149,6 +153,50 @@ func TestTask_ToProtobuf(t *testing.T) {
 	}
 }
 
+func TestTaskFromProtobuf_RoundTrip(t *testing.T) {
+	dueDate := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	seriesID := "series-1"
+	for _, status := range []TaskStatus{
+		TaskStatusOpen, TaskStatusInProgress, TaskStatusInReview,
+		TaskStatusBlocked, TaskStatusCompleted, TaskStatusCancelled,
+	} {
+		task := &Task{
+			ID:          "task-123",
+			Title:       "Test Task",
+			AssigneeID:  "user-123",
+			CreatorID:   "user-456",
+			Status:      status,
+			Priority:    TaskPriorityHigh,
+			DueDate:     &dueDate,
+			SeriesID:    &seriesID,
+			SeriesIndex: 2,
+			Version:     3,
+			Categories:  []Category{{ID: "category-1"}},
+			Tags:        []Tag{{ID: "tag-1"}},
+		}
+
+		got := TaskFromProtobuf(task.ToProtobuf())
+		if got.Status != status {
+			t.Errorf("round trip of %s gave status %s", status, got.Status)
+		}
+		if got.ID != task.ID || got.Priority != task.Priority || got.Version != task.Version ||
+			got.DueDate == nil || !got.DueDate.Equal(dueDate) {
+			t.Errorf("round trip of %+v gave %+v", task, got)
+		}
+		if len(got.Categories) != 1 || got.Categories[0].ID != "category-1" || len(got.Tags) != 1 || got.Tags[0].ID != "tag-1" {
+			t.Errorf("round trip lost categories or tags: %+v", got)
+		}
+		if got.CreatorID != task.CreatorID || got.SeriesID == nil || *got.SeriesID != seriesID || got.SeriesIndex != task.SeriesIndex {
+			t.Errorf("round trip lost the creator or series: %+v", got)
+		}
+	}
+
+	// Older clients still send the undoable status
+	if got := TaskStatusFromProtobuf(pb.TaskStatus_TASK_STATUS_UNDOABLE); got != TaskStatusBlocked { //nolint:staticcheck
+		t.Errorf("TaskStatusFromProtobuf(UNDOABLE) = %s, want %s", got, TaskStatusBlocked)
+	}
+}
+
 func TestDomainErrors(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -185,3 +233,848 @@ func TestDomainErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestSavedView_IsValid(t *testing.T) {
+	valid := func() *SavedView {
+		return &SavedView{
+			Name:       "My overdue urgent tasks",
+			OwnerID:    "user-1",
+			Visibility: SavedViewPrivate,
+			SortBy:     "due_date",
+			Columns:    []string{"title", "due_date"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(v *SavedView)
+		wantErr bool
+	}{
+		{"valid private view", func(v *SavedView) {}, false},
+		{"valid shared view", func(v *SavedView) { v.Visibility, v.SharedRole = SavedViewRole, UserRoleUser }, false},
+		{"missing name", func(v *SavedView) { v.Name = "  " }, true},
+		{"missing owner", func(v *SavedView) { v.OwnerID = "" }, true},
+		{"private view with a role", func(v *SavedView) { v.SharedRole = UserRoleAdmin }, true},
+		{"shared view without a role", func(v *SavedView) { v.Visibility = SavedViewRole }, true},
+		{"unknown sort field", func(v *SavedView) { v.SortBy = "assignee" }, true},
+		{"unknown column", func(v *SavedView) { v.Columns = []string{"owner"} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := valid()
+			tt.modify(view)
+			err := view.IsValid()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SavedView.IsValid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSavedView_VisibleTo(t *testing.T) {
+	private := &SavedView{OwnerID: "user-1", Visibility: SavedViewPrivate}
+	shared := &SavedView{OwnerID: "user-1", Visibility: SavedViewRole, SharedRole: UserRoleUser}
+
+	tests := []struct {
+		name   string
+		view   *SavedView
+		userID string
+		role   UserRole
+		want   bool
+	}{
+		{"owner", private, "user-1", UserRoleUser, true},
+		{"other user", private, "user-2", UserRoleUser, false},
+		{"admin", private, "user-2", UserRoleAdmin, true},
+		{"user with shared role", shared, "user-2", UserRoleUser, true},
+		{"user without shared role", shared, "user-2", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.view.VisibleTo(tt.userID, tt.role); got != tt.want {
+				t.Errorf("SavedView.VisibleTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	parent := func(id string) *string { return &id }
+	nodes := []*CategoryNode{
+		{Category: &Category{ID: "work", IsPublic: true}, Depth: 1},
+		{Category: &Category{ID: "home", IsPublic: true}, Depth: 1},
+		{Category: &Category{ID: "projects", ParentID: parent("work"), IsPublic: true}, Depth: 2},
+		{Category: &Category{ID: "secret", ParentID: parent("work"), CreatorID: "user-2"}, Depth: 2},
+		{Category: &Category{ID: "alpha", ParentID: parent("projects"), IsPublic: true}, Depth: 3},
+		{Category: &Category{ID: "hidden-child", ParentID: parent("secret"), IsPublic: true}, Depth: 3},
+	}
+
+	roots := BuildCategoryTree(nodes, func(c *Category) bool { return c.VisibleTo("user-1", UserRoleUser) })
+	if len(roots) != 2 || roots[0].Category.ID != "work" || roots[1].Category.ID != "home" {
+		t.Fatalf("roots = %v, want work and home", roots)
+	}
+
+	work := roots[0]
+	if len(work.Children) != 1 || work.Children[0].Category.ID != "projects" {
+		t.Errorf("work children = %v, want only projects", work.Children)
+	}
+	if work.Height() != 3 || roots[1].Height() != 1 {
+		t.Errorf("heights = %d, %d; want 3, 1", work.Height(), roots[1].Height())
+	}
+
+	// Without a filter the private branch is kept
+	roots = BuildCategoryTree(nodes, nil)
+	if len(roots[0].Children) != 2 || len(roots[0].Children[1].Children) != 1 {
+		t.Errorf("unfiltered work subtree = %v", roots[0].Children)
+	}
+}
+
+func TestBuildTaskTree(t *testing.T) {
+	parent := func(id string) *string { return &id }
+	nodes := []*TaskNode{
+		{Task: &Task{ID: "release", Status: TaskStatusInProgress}, Depth: 1},
+		{Task: &Task{ID: "build", ParentID: parent("release"), Status: TaskStatusCompleted}, Depth: 2},
+		{Task: &Task{ID: "docs", ParentID: parent("release"), Status: TaskStatusOpen}, Depth: 2},
+		{Task: &Task{ID: "legacy", ParentID: parent("release"), Status: TaskStatusCancelled}, Depth: 2},
+		{Task: &Task{ID: "api-docs", ParentID: parent("docs"), Status: TaskStatusCompleted}, Depth: 3},
+		{Task: &Task{ID: "legacy-docs", ParentID: parent("legacy"), Status: TaskStatusOpen}, Depth: 3},
+		{Task: &Task{ID: "orphan", ParentID: parent("elsewhere"), Status: TaskStatusOpen}, Depth: 3},
+	}
+
+	root := BuildTaskTree(nodes)
+	if root == nil || root.Task.ID != "release" || len(root.Children) != 3 {
+		t.Fatalf("root = %+v, want release with three subtasks", root)
+	}
+
+	// Cancelled subtasks and everything under them are left out of the counts
+	if root.Progress != (TaskProgress{Completed: 2, Total: 3}) || root.Progress.Percent(root.Task.Status) != 66 {
+		t.Errorf("root progress = %+v", root.Progress)
+	}
+	docs := root.Children[1]
+	if docs.Progress != (TaskProgress{Completed: 1, Total: 1}) || docs.Progress.Percent(docs.Task.Status) != 100 {
+		t.Errorf("docs progress = %+v", docs.Progress)
+	}
+	if build := root.Children[0]; build.Progress.Percent(build.Task.Status) != 100 {
+		t.Errorf("completed leaf percent = %d, want 100", build.Progress.Percent(build.Task.Status))
+	}
+
+	open := root.OpenDescendants()
+	if len(open) != 1 || open[0].ID != "docs" {
+		t.Errorf("OpenDescendants() = %v, want only docs", open)
+	}
+
+	if BuildTaskTree(nil) != nil {
+		t.Error("BuildTaskTree(nil) should be nil")
+	}
+}
+
+func TestComputeCriticalPath(t *testing.T) {
+	day := func(n int) *time.Time {
+		d := time.Date(2026, time.March, n, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	tasks := []*Task{
+		{ID: "design", Status: TaskStatusCompleted, DueDate: day(1)},
+		{ID: "build", Status: TaskStatusInProgress, DueDate: day(5)},
+		{ID: "test", Status: TaskStatusOpen, DueDate: day(3)},
+		{ID: "docs", Status: TaskStatusOpen, DueDate: day(2)},
+		{ID: "legacy", Status: TaskStatusCancelled, DueDate: day(20)},
+	}
+	dependencies := []*TaskDependency{
+		{TaskID: "build", BlockerID: "design"},
+		{TaskID: "test", BlockerID: "build"},
+		{TaskID: "docs", BlockerID: "design"},
+		{TaskID: "test", BlockerID: "legacy"},
+		{TaskID: "test", BlockerID: "elsewhere"},
+	}
+
+	path := ComputeCriticalPath(tasks, dependencies)
+	var ids []string
+	for _, task := range path.Tasks {
+		ids = append(ids, task.ID)
+	}
+	if strings.Join(ids, ",") != "design,build,test" {
+		t.Errorf("path = %v, want design, build, test", ids)
+	}
+	// Test cannot finish before build does, whatever its own due date says
+	if path.ProjectedFinish == nil || !path.ProjectedFinish.Equal(*day(5)) {
+		t.Errorf("projected finish = %v, want %v", path.ProjectedFinish, day(5))
+	}
+	if len(path.Conflicts) != 1 || path.Conflicts[0].TaskID != "test" || path.Conflicts[0].BlockerID != "build" {
+		t.Errorf("conflicts = %+v, want build due after test", path.Conflicts)
+	}
+
+	// Without due dates the longest chain is critical
+	undated := []*Task{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	path = ComputeCriticalPath(undated, []*TaskDependency{
+		{TaskID: "b", BlockerID: "a"}, {TaskID: "c", BlockerID: "b"}, {TaskID: "d", BlockerID: "a"},
+	})
+	if len(path.Tasks) != 3 || path.Tasks[2].ID != "c" || path.ProjectedFinish != nil {
+		t.Errorf("undated path = %+v, want a, b, c without a finish", path)
+	}
+
+	if path := ComputeCriticalPath(nil, nil); len(path.Tasks) != 0 {
+		t.Errorf("empty path = %+v", path)
+	}
+}
+
+func TestFindDuplicateTags(t *testing.T) {
+	tags := []*Tag{
+		{ID: "1", Name: "bug"},
+		{ID: "2", Name: "bugs"},
+		{ID: "3", Name: "defect"},
+		{ID: "4", Name: "frontend"},
+		{ID: "5", Name: "front-end"},
+		{ID: "6", Name: "ux"},
+		{ID: "7", Name: "ui"},
+	}
+	counts := map[string]int64{"2": 10, "1": 3, "4": 1}
+
+	duplicates := FindDuplicateTags(tags, counts)
+	pairs := make(map[string]*TagDuplicate)
+	for _, d := range duplicates {
+		pairs[d.Tag.Name+"/"+d.Duplicate.Name] = d
+	}
+
+	bug, ok := pairs["bugs/bug"]
+	if !ok {
+		t.Fatalf("expected bugs/bug to be reported with the more used tag first, got %v", pairs)
+	}
+	if bug.Distance != 1 || bug.TaskCount != 10 || bug.DuplicateTaskCount != 3 {
+		t.Errorf("unexpected bugs/bug pair: %+v", bug)
+	}
+	if _, ok := pairs["frontend/front-end"]; !ok {
+		t.Errorf("expected frontend/front-end to be reported, got %v", pairs)
+	}
+	for key := range pairs {
+		if key == "bug/defect" || key == "defect/bug" || key == "ux/ui" || key == "ui/ux" {
+			t.Errorf("unexpected pair %s", key)
+		}
+	}
+
+	for i := 1; i < len(duplicates); i++ {
+		if duplicates[i].Similarity > duplicates[i-1].Similarity {
+			t.Errorf("pairs are not ordered by similarity: %v", duplicates)
+		}
+	}
+}
+
+func TestTrigrams(t *testing.T) {
+	got := Trigrams("Bug")
+	want := []string{"  b", " bu", "bug", "ug "}
+	if len(got) != len(want) {
+		t.Fatalf("Trigrams() = %v, want %v", got, want)
+	}
+	for _, gram := range want {
+		if !got[gram] {
+			t.Errorf("Trigrams() missing %q", gram)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"bug", "bug", 0},
+		{"bug", "bugs", 1},
+		{"bug", "defect", 6},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := EditDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("EditDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRankTagSuggestions(t *testing.T) {
+	suggestions := []*TagSuggestion{
+		{Tag: &Tag{Name: "debug"}, Similarity: 0.4, UsageCount: 5},
+		{Tag: &Tag{Name: "bugfix"}, PrefixMatch: true, Similarity: 0.4},
+		{Tag: &Tag{Name: "bug"}, PrefixMatch: true, Similarity: 1},
+		{Tag: &Tag{Name: "bugzilla"}, PrefixMatch: true, Similarity: 0.3, UsageCount: 100, RecentUsageCount: 10},
+	}
+
+	ranked := RankTagSuggestions(suggestions, 3)
+	if len(ranked) != 3 {
+		t.Fatalf("RankTagSuggestions() returned %d suggestions, want 3", len(ranked))
+	}
+	want := []string{"bugzilla", "bug", "bugfix"}
+	for i, name := range want {
+		if ranked[i].Tag.Name != name {
+			t.Errorf("rank %d = %s, want %s", i, ranked[i].Tag.Name, name)
+		}
+	}
+	if ranked[0].Score <= ranked[1].Score {
+		t.Errorf("expected scores in descending order, got %v and %v", ranked[0].Score, ranked[1].Score)
+	}
+}
+
+func TestDefaultWorkflow(t *testing.T) {
+	workflow := DefaultWorkflow()
+	if err := workflow.IsValid(); err != nil {
+		t.Fatalf("DefaultWorkflow() is invalid: %v", err)
+	}
+
+	// The transitions tasks had before workflows could be configured, plus blocking
+	allowed := map[TaskStatus][]TaskStatus{
+		TaskStatusOpen:       {TaskStatusInProgress, TaskStatusCompleted, TaskStatusCancelled, TaskStatusBlocked},
+		TaskStatusInProgress: {TaskStatusCompleted, TaskStatusOpen, TaskStatusCancelled, TaskStatusBlocked},
+		TaskStatusBlocked:    {TaskStatusOpen, TaskStatusInProgress, TaskStatusCancelled},
+		TaskStatusCompleted:  {TaskStatusOpen, TaskStatusInProgress},
+		TaskStatusCancelled:  {TaskStatusOpen, TaskStatusInProgress},
+	}
+	for from, targets := range allowed {
+		for _, to := range []TaskStatus{TaskStatusOpen, TaskStatusInProgress, TaskStatusBlocked, TaskStatusCompleted, TaskStatusCancelled} {
+			if from == to {
+				continue
+			}
+			want := false
+			for _, target := range targets {
+				want = want || target == to
+			}
+			err := workflow.CheckTransition(&Task{Status: from}, to, "", UserRoleUser)
+			if (err == nil) != want {
+				t.Errorf("CheckTransition(%s -> %s) error = %v, want allowed %v", from, to, err, want)
+			}
+		}
+	}
+}
+
+func TestWorkflow_IsValid(t *testing.T) {
+	states := []WorkflowState{{Status: TaskStatusOpen}, {Status: TaskStatusCompleted}}
+	tests := []struct {
+		name     string
+		workflow *Workflow
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			workflow: &Workflow{Name: "simple", States: states, Transitions: []WorkflowTransition{{From: TaskStatusOpen, To: TaskStatusCompleted}}},
+		},
+		{
+			name:     "missing name",
+			workflow: &Workflow{States: states},
+			wantErr:  true,
+		},
+		{
+			name:     "without open",
+			workflow: &Workflow{Name: "closed", States: []WorkflowState{{Status: TaskStatusCompleted}}},
+			wantErr:  true,
+		},
+		{
+			name:     "duplicate state",
+			workflow: &Workflow{Name: "twice", States: append(states, WorkflowState{Status: TaskStatusOpen})},
+			wantErr:  true,
+		},
+		{
+			name:     "transition outside the workflow",
+			workflow: &Workflow{Name: "outside", States: states, Transitions: []WorkflowTransition{{From: TaskStatusOpen, To: TaskStatusInReview}}},
+			wantErr:  true,
+		},
+		{
+			name:     "self transition",
+			workflow: &Workflow{Name: "loop", States: states, Transitions: []WorkflowTransition{{From: TaskStatusOpen, To: TaskStatusOpen}}},
+			wantErr:  true,
+		},
+		{
+			name: "unknown guard",
+			workflow: &Workflow{Name: "guarded", States: states, Transitions: []WorkflowTransition{
+				{From: TaskStatusOpen, To: TaskStatusCompleted, Guards: []WorkflowGuard{"HAS_LUCK"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:     "unknown required field",
+			workflow: &Workflow{Name: "fields", States: []WorkflowState{{Status: TaskStatusOpen, RequiredFields: []string{"budget"}}}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.workflow.IsValid()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsValid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflow_CheckTransition(t *testing.T) {
+	workflow := &Workflow{
+		Name: "review",
+		States: []WorkflowState{
+			{Status: TaskStatusOpen},
+			{Status: TaskStatusInProgress},
+			{Status: TaskStatusInReview, RequiredFields: []string{WorkflowFieldDescription}},
+			{Status: TaskStatusCompleted},
+		},
+		Transitions: []WorkflowTransition{
+			{From: TaskStatusOpen, To: TaskStatusInProgress, Guards: []WorkflowGuard{WorkflowGuardHasAssignee}},
+			{From: TaskStatusInProgress, To: TaskStatusInReview, Guards: []WorkflowGuard{WorkflowGuardCallerIsAssignee}},
+			{From: TaskStatusInReview, To: TaskStatusCompleted, Guards: []WorkflowGuard{WorkflowGuardCallerIsAdmin}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		task    *Task
+		to      TaskStatus
+		caller  string
+		role    UserRole
+		wantErr bool
+	}{
+		{"guard holds", &Task{Status: TaskStatusOpen, AssigneeID: "user-1"}, TaskStatusInProgress, "user-2", UserRoleUser, false},
+		{"missing assignee", &Task{Status: TaskStatusOpen}, TaskStatusInProgress, "user-2", UserRoleUser, true},
+		{"skipping review", &Task{Status: TaskStatusInProgress, AssigneeID: "user-1"}, TaskStatusCompleted, "user-1", UserRoleAdmin, true},
+		{"assignee submits for review", &Task{Status: TaskStatusInProgress, AssigneeID: "user-1", Description: "done"}, TaskStatusInReview, "user-1", UserRoleUser, false},
+		{"someone else submits for review", &Task{Status: TaskStatusInProgress, AssigneeID: "user-1", Description: "done"}, TaskStatusInReview, "user-2", UserRoleUser, true},
+		{"review needs a description", &Task{Status: TaskStatusInProgress, AssigneeID: "user-1"}, TaskStatusInReview, "user-1", UserRoleUser, true},
+		{"admin approves", &Task{Status: TaskStatusInReview}, TaskStatusCompleted, "admin-1", UserRoleAdmin, false},
+		{"user approves", &Task{Status: TaskStatusInReview}, TaskStatusCompleted, "user-1", UserRoleUser, true},
+		{"no reopening", &Task{Status: TaskStatusCompleted}, TaskStatusOpen, "admin-1", UserRoleAdmin, true},
+		{"status outside the workflow", &Task{Status: TaskStatusOpen}, TaskStatusCancelled, "admin-1", UserRoleAdmin, true},
+		{"task from another workflow", &Task{Status: TaskStatusCancelled}, TaskStatusOpen, "user-1", UserRoleUser, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := workflow.CheckTransition(tt.task, tt.to, tt.caller, tt.role)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !IsBusinessRuleError(err) {
+				t.Errorf("CheckTransition() error = %v, want a business rule error", err)
+			}
+		})
+	}
+
+	// Edits within a status keep the fields it requires
+	if err := workflow.CheckRequiredFields(&Task{Status: TaskStatusInReview}); !IsBusinessRuleError(err) {
+		t.Errorf("CheckRequiredFields() without a description error = %v, want a business rule error", err)
+	}
+	if err := workflow.CheckRequiredFields(&Task{Status: TaskStatusInReview, Description: "done"}); err != nil {
+		t.Errorf("CheckRequiredFields() error = %v", err)
+	}
+	if err := workflow.CheckRequiredFields(&Task{Status: TaskStatusCancelled}); err != nil {
+		t.Errorf("CheckRequiredFields() for a status outside the workflow error = %v", err)
+	}
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY", false},
+		{"RRULE:freq=weekly;byday=th,mo,th;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", false},
+		{"FREQ=MONTHLY;BYMONTHDAY=31;COUNT=6", "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=6", false},
+		{"FREQ=DAILY;UNTIL=20261231", "FREQ=DAILY;UNTIL=20261231T235959Z", false},
+		{"", "", true},
+		{"INTERVAL=2", "", true},
+		{"FREQ=YEARLY", "", true},
+		{"FREQ=DAILY;INTERVAL=0", "", true},
+		{"FREQ=DAILY;BYDAY=MO", "", true},
+		{"FREQ=MONTHLY;BYMONTHDAY=32", "", true},
+		{"FREQ=DAILY;COUNT=3;UNTIL=20261231", "", true},
+		{"FREQ=DAILY;FREQ=WEEKLY", "", true},
+		{"FREQ=WEEKLY;BYDAY=XX", "", true},
+		{"FREQ=DAILY;BYHOUR=9", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRecurrenceRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !IsInvalidInputError(err) {
+					t.Errorf("ParseRecurrenceRule() error = %v, want invalid input", err)
+				}
+				return
+			}
+			if rule.String() != tt.want {
+				t.Errorf("String() = %q, want %q", rule.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRule_Next(t *testing.T) {
+	// Monday 2 March 2026, 09:00
+	start := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		rule   string
+		start  time.Time
+		after  time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{"daily", "FREQ=DAILY", start, start, at(time.March, 3), true},
+		{"every third day skips missed ones", "FREQ=DAILY;INTERVAL=3", start, at(time.March, 9), at(time.March, 11), true},
+		{"weekly on the start weekday", "FREQ=WEEKLY", start, start, at(time.March, 9), true},
+		{"weekly by day", "FREQ=WEEKLY;BYDAY=MO,TH", start, start, at(time.March, 5), true},
+		{"weekly by day wraps the week", "FREQ=WEEKLY;BYDAY=MO,TH", start, at(time.March, 5), at(time.March, 9), true},
+		{"fortnightly", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", start, start, at(time.March, 16), true},
+		{"monthly", "FREQ=MONTHLY", start, start, at(time.April, 2), true},
+		{"monthly clamps to the last day", "FREQ=MONTHLY;BYMONTHDAY=31", at(time.January, 31), at(time.January, 31), at(time.February, 28), true},
+		{"monthly returns to the day", "FREQ=MONTHLY;BYMONTHDAY=31", at(time.January, 31), at(time.February, 28), at(time.March, 31), true},
+		{"until includes its day", "FREQ=DAILY;UNTIL=20260303", start, start, at(time.March, 3), true},
+		{"until ends the rule", "FREQ=DAILY;UNTIL=20260303", start, at(time.March, 3), time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule() error = %v", err)
+			}
+			got, ok := rule.Next(tt.start, tt.after)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTaskSeries_NextDueDate(t *testing.T) {
+	start := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	series := &TaskSeries{Rule: RecurrenceRule{Frequency: RecurrenceDaily, Interval: 1, Count: 2}, StartsAt: start, InstanceCount: 1, IsActive: true}
+
+	if next, ok := series.NextDueDate(start); !ok || !next.Equal(start.AddDate(0, 0, 1)) {
+		t.Errorf("NextDueDate() = %v, %v; want the next day", next, ok)
+	}
+	series.InstanceCount = 2
+	if _, ok := series.NextDueDate(start); ok {
+		t.Error("NextDueDate() past COUNT should report no next instance")
+	}
+	series.InstanceCount, series.IsActive = 1, false
+	if _, ok := series.NextDueDate(start); ok {
+		t.Error("NextDueDate() of a stopped series should report no next instance")
+	}
+}
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		body string
+		want []string
+	}{
+		{"@alice@example.com can you check?", []string{"alice@example.com"}},
+		{"cc @bob.smith+todo@mail.example.org, @carol@example.io.", []string{"bob.smith+todo@mail.example.org", "carol@example.io"}},
+		{"(@dave@example.com) and again @DAVE@example.com", []string{"dave@example.com"}},
+		{"mail erin@example.com directly", nil},
+		{"a handle like @frank is not an address", nil},
+		{"no mention in foo@@example.com", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			got := ExtractMentions(tt.body)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ExtractMentions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComment_IsValid(t *testing.T) {
+	valid := Comment{TaskID: "task-1", AuthorID: "user-1", Body: "hello"}
+	if err := valid.IsValid(); err != nil {
+		t.Errorf("IsValid() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Comment)
+	}{
+		{"missing task", func(c *Comment) { c.TaskID = "" }},
+		{"missing author", func(c *Comment) { c.AuthorID = "" }},
+		{"blank body", func(c *Comment) { c.Body = " \n " }},
+		{"body too long", func(c *Comment) { c.Body = strings.Repeat("é", MaxCommentLength+1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment := valid
+			tt.modify(&comment)
+			if err := comment.IsValid(); !IsInvalidInputError(err) {
+				t.Errorf("IsValid() error = %v, want invalid input", err)
+			}
+		})
+	}
+}
+
+func TestActivityItem_ToProtobuf(t *testing.T) {
+	at := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	history := (&ActivityItem{Timestamp: at, History: &TaskHistory{ID: "h-1", Action: TaskHistoryActionAssigned, Details: []byte(`{"changes":["assignee_id"]}`)}}).ToProtobuf()
+	if history.GetHistory().GetAction() != "ASSIGNED" || history.GetHistory().GetDetails() == "" || history.GetComment() != nil {
+		t.Errorf("history item = %v", history)
+	}
+
+	edited := at.Add(time.Minute)
+	comment := (&ActivityItem{Timestamp: at, Comment: &Comment{ID: "c-1", Body: "hi", EditedAt: &edited}}).ToProtobuf()
+	if comment.GetComment().GetBody() != "hi" || !comment.GetComment().GetEdited() || !comment.GetTimestamp().AsTime().Equal(at) {
+		t.Errorf("comment item = %v", comment)
+	}
+}
+
+func TestValidateAttachmentFileName(t *testing.T) {
+	for _, name := range []string{"report.pdf", "Screenshot 2026-03-02 at 09.00.png", "résumé.docx"} {
+		if err := ValidateAttachmentFileName(name); err != nil {
+			t.Errorf("ValidateAttachmentFileName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "  ", "..", "dir/file.txt", `C:\file.txt`, "bell\a.txt", strings.Repeat("a", MaxAttachmentFileNameLength+1)} {
+		if err := ValidateAttachmentFileName(name); !IsInvalidInputError(err) {
+			t.Errorf("ValidateAttachmentFileName(%q) error = %v, want invalid input", name, err)
+		}
+	}
+}
+
+func TestNormalizeContentType(t *testing.T) {
+	got, err := NormalizeContentType("Text/HTML; charset=UTF-8")
+	if err != nil || got != "text/html" {
+		t.Errorf("NormalizeContentType() = %q, %v, want text/html", got, err)
+	}
+	for _, value := range []string{"", "text", "text/plain; charset"} {
+		if _, err := NormalizeContentType(value); !IsInvalidInputError(err) {
+			t.Errorf("NormalizeContentType(%q) error = %v, want invalid input", value, err)
+		}
+	}
+}
+
+func TestAttachmentLimits_Check(t *testing.T) {
+	limits := AttachmentLimits{
+		MaxSizeBytes:   100,
+		AllowedTypes:   []string{"image/*", "application/pdf"},
+		UserQuotaBytes: 150,
+	}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		size      int64
+		used      int64
+		checkFn   func(error) bool
+	}{
+		{"allowed by pattern", "image/png", 100, 50, nil},
+		{"allowed exactly", "application/pdf", 1, 0, nil},
+		{"type not allowed", "application/pdfx", 1, 0, IsInvalidInputError},
+		{"too large", "image/png", 101, 0, IsInvalidInputError},
+		{"over quota", "image/png", 60, 100, IsBusinessRuleError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limits.Check(tt.mediaType, tt.size, tt.used)
+			if tt.checkFn == nil {
+				if err != nil {
+					t.Errorf("Check() error = %v", err)
+				}
+			} else if !tt.checkFn(err) {
+				t.Errorf("Check() error = %v", err)
+			}
+		})
+	}
+
+	// Zero limits accept anything
+	if err := (AttachmentLimits{}).Check("application/x-anything", 1<<40, 1<<40); err != nil {
+		t.Errorf("Check() without limits error = %v", err)
+	}
+}
+
+func TestNotificationSettings_DeliverAfter(t *testing.T) {
+	settings := &NotificationSettings{UserID: "u", TimeZone: "Europe/Berlin", QuietHours: &QuietHours{Start: 22 * 60, End: 7 * 60}}
+	if err := settings.IsValid(); err != nil {
+		t.Fatalf("IsValid() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Time
+	}{
+		{"before quiet hours", time.Date(2026, 3, 2, 20, 59, 0, 0, time.UTC), time.Date(2026, 3, 2, 20, 59, 0, 0, time.UTC)},
+		{"quiet before midnight", time.Date(2026, 3, 2, 21, 0, 0, 0, time.UTC), time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC)},
+		{"quiet after midnight", time.Date(2026, 3, 3, 5, 30, 0, 0, time.UTC), time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC)},
+		{"quiet hours ended", time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC), time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := settings.DeliverAfter(tt.at); !got.Equal(tt.want) {
+				t.Errorf("DeliverAfter(%v) = %v, want %v", tt.at, got.UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationSettings_DigestSlot(t *testing.T) {
+	settings := &NotificationSettings{UserID: "u", TimeZone: "America/New_York"}
+
+	// 08:00 in New York is 13:00 UTC in March before daylight saving time starts
+	before := settings.DigestSlot(time.Date(2026, 3, 2, 12, 59, 0, 0, time.UTC), 8)
+	if want := time.Date(2026, 3, 1, 13, 0, 0, 0, time.UTC); !before.Equal(want) {
+		t.Errorf("DigestSlot() before the hour = %v, want %v", before.UTC(), want)
+	}
+	at := settings.DigestSlot(time.Date(2026, 3, 2, 13, 0, 0, 0, time.UTC), 8)
+	if want := time.Date(2026, 3, 2, 13, 0, 0, 0, time.UTC); !at.Equal(want) {
+		t.Errorf("DigestSlot() at the hour = %v, want %v", at.UTC(), want)
+	}
+}
+
+func TestNotificationSettings_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings NotificationSettings
+		wantErr  bool
+	}{
+		{"defaults", *DefaultNotificationSettings("u"), false},
+		{"preferences", NotificationSettings{UserID: "u", TimeZone: "UTC", Preferences: []NotificationPreference{
+			{Event: NotificationDueSoon, Channel: NotificationChannelEmail, Mode: NotificationImmediate},
+		}}, false},
+		{"local time zone", NotificationSettings{UserID: "u", TimeZone: "Local"}, true},
+		{"quiet hours out of range", NotificationSettings{UserID: "u", TimeZone: "UTC", QuietHours: &QuietHours{Start: 0, End: 24 * 60}}, true},
+		{"unknown channel", NotificationSettings{UserID: "u", TimeZone: "UTC", Preferences: []NotificationPreference{
+			{Event: NotificationDueSoon, Channel: "SMS", Mode: NotificationOff},
+		}}, true},
+		{"duplicate preference", NotificationSettings{UserID: "u", TimeZone: "UTC", Preferences: []NotificationPreference{
+			{Event: NotificationDueSoon, Channel: NotificationChannelEmail, Mode: NotificationOff},
+			{Event: NotificationDueSoon, Channel: NotificationChannelEmail, Mode: NotificationDigest},
+		}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.IsValid(); (err != nil) != tt.wantErr {
+				t.Errorf("IsValid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHistoryNotificationItems(t *testing.T) {
+	entry := &TaskHistory{TaskID: "t", ActorID: "actor", Timestamp: time.Now()}
+	entry.SetDetails(&TaskHistoryDetails{
+		Changes:   []string{"title", "status", "assignee_id"},
+		OldValues: map[string]interface{}{"title": "a", "status": "OPEN", "assignee_id": nil},
+		NewValues: map[string]interface{}{"title": "b", "status": "BLOCKED", "assignee_id": "new"},
+	})
+
+	items := HistoryNotificationItems(entry, "Title")
+	if len(items) != 2 {
+		t.Fatalf("HistoryNotificationItems() = %+v, want 2 items", items)
+	}
+	if items[0].Event != NotificationStatusChanged || items[0].Summary != "Status changed from OPEN to BLOCKED" {
+		t.Errorf("status item = %+v", items[0])
+	}
+	if items[1].Event != NotificationReassigned || len(items[1].Involved) != 1 || items[1].Involved[0] != "new" {
+		t.Errorf("reassignment item = %+v", items[1])
+	}
+}
+
+func TestHistoryNotificationItems_Participants(t *testing.T) {
+	entry := &TaskHistory{TaskID: "t", ActorID: "actor", Timestamp: time.Now()}
+	entry.SetDetails(&TaskHistoryDetails{
+		Changes: []string{"participants", "assignee_id"},
+		OldValues: map[string]interface{}{
+			"participants": ParticipantsByRole([]TaskParticipant{{UserID: "a", Role: TaskRoleAssignee}, {UserID: "b", Role: TaskRoleAssignee}, {UserID: "c", Role: TaskRoleOwner}}),
+			"assignee_id":  "a",
+		},
+		NewValues: map[string]interface{}{
+			"participants": ParticipantsByRole([]TaskParticipant{{UserID: "b", Role: TaskRoleAssignee}, {UserID: "c", Role: TaskRoleOwner}, {UserID: "d", Role: TaskRoleReviewer}}),
+			"assignee_id":  "b",
+		},
+	})
+
+	items := HistoryNotificationItems(entry, "Title")
+	if len(items) != 1 || items[0].Event != NotificationReassigned {
+		t.Fatalf("HistoryNotificationItems() = %+v, want one reassignment", items)
+	}
+	if got := strings.Join(items[0].Involved, ","); got != "a,d,b" {
+		t.Errorf("involved = %s, want the removed and added participants, then the new primary assignee", got)
+	}
+}
+
+func TestTask_HasRole(t *testing.T) {
+	task := &Task{AssigneeID: "primary", Participants: []TaskParticipant{
+		{UserID: "pair", Role: TaskRoleAssignee},
+		{UserID: "rev", Role: TaskRoleReviewer},
+	}}
+
+	tests := []struct {
+		userID string
+		role   TaskParticipantRole
+		want   bool
+	}{
+		{"primary", TaskRoleAssignee, true}, // even when participants were not loaded
+		{"pair", TaskRoleAssignee, true},
+		{"rev", TaskRoleReviewer, true},
+		{"rev", TaskRoleAssignee, false},
+		{"", TaskRoleAssignee, false},
+	}
+	for _, tt := range tests {
+		if got := task.HasRole(tt.userID, tt.role); got != tt.want {
+			t.Errorf("HasRole(%q, %s) = %v, want %v", tt.userID, tt.role, got, tt.want)
+		}
+	}
+	if got := task.ParticipantsWithRole(TaskRoleAssignee); len(got) != 1 || got[0] != "pair" {
+		t.Errorf("ParticipantsWithRole() = %v, want [pair]", got)
+	}
+
+	err := checkWorkflowGuard(WorkflowGuardCallerIsAssignee, task, "pair", UserRoleUser)
+	if err != nil {
+		t.Errorf("CALLER_IS_ASSIGNEE guard for a second assignee error = %v", err)
+	}
+	if err := checkWorkflowGuard(WorkflowGuardCallerIsAssignee, task, "rev", UserRoleUser); !IsBusinessRuleError(err) {
+		t.Errorf("CALLER_IS_ASSIGNEE guard for a reviewer error = %v, want business rule violation", err)
+	}
+}
+
+func TestTask_EditableBy(t *testing.T) {
+	task := &Task{CreatorID: "reporter", AssigneeID: "primary", Participants: []TaskParticipant{
+		{UserID: "rev", Role: TaskRoleReviewer},
+	}}
+
+	tests := []struct {
+		userID string
+		role   UserRole
+		want   bool
+	}{
+		{"reporter", UserRoleUser, true},
+		{"primary", UserRoleUser, true},
+		{"rev", UserRoleUser, true},
+		{"other", UserRoleUser, false},
+		{"other", UserRoleAdmin, true},
+		{"", UserRoleUser, false},
+	}
+	for _, tt := range tests {
+		if got := task.EditableBy(tt.userID, tt.role); got != tt.want {
+			t.Errorf("EditableBy(%q, %s) = %v, want %v", tt.userID, tt.role, got, tt.want)
+		}
+	}
+
+	// Without a known creator only participants and administrators may edit
+	if (&Task{AssigneeID: "primary"}).EditableBy("other", UserRoleUser) {
+		t.Error("EditableBy() = true for an unrelated user on a task without a creator")
+	}
+}
+
+func TestTaskParticipant_Protobuf(t *testing.T) {
+	participant := TaskParticipant{UserID: "u", Role: TaskRoleReviewer, AddedAt: time.Now()}
+	if got := TaskParticipantFromProtobuf(participant.ToProtobuf()); got.UserID != "u" || got.Role != TaskRoleReviewer {
+		t.Errorf("round trip = %+v", got)
+	}
+	unspecified := TaskParticipantFromProtobuf(&pb.TaskParticipant{UserId: "u"})
+	if err := unspecified.IsValid(); !IsInvalidInputError(err) {
+		t.Errorf("IsValid() without a role error = %v, want invalid input", err)
+	}
+}
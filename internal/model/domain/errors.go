@@ -80,6 +80,16 @@ func ErrBusinessRule(message string) error {
 	}
 }
 
+// ErrBulkRolledBack marks items that succeeded but were undone because another item
+// of an all-or-nothing batch failed
+func ErrBulkRolledBack() error {
+	return DomainError{
+		Type:    "ABORTED",
+		Message: "not applied because another task in the batch failed",
+		Code:    409,
+	}
+}
+
 // Error type checkers
 func IsNotFoundError(err error) bool {
 	if domainErr, ok := err.(DomainError); ok {
@@ -115,3 +125,10 @@ func IsInvalidInputError(err error) bool {
 	}
 	return false
 }
+
+func IsPermissionDeniedError(err error) bool {
+	if domainErr, ok := err.(DomainError); ok {
+		return domainErr.Type == "PERMISSION_DENIED"
+	}
+	return false
+}
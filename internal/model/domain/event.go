@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// EventType names a kind of domain event
+type EventType string
+
+const (
+	// EventUserMentioned is raised for each user newly mentioned in a comment
+	EventUserMentioned EventType = "USER_MENTIONED"
+)
+
+// Event is something that happened in the domain that other parts of the service react to.
+// Events are published once the change that raised them is committed.
+type Event interface {
+	Type() EventType
+	OccurredAt() time.Time
+}
+
+// UserMentionedEvent reports that a comment mentions a user as @email
+type UserMentionedEvent struct {
+	TaskID          string
+	CommentID       string
+	AuthorID        string
+	MentionedUserID string
+	Email           string
+	At              time.Time
+}
+
+// Type implements Event
+func (e *UserMentionedEvent) Type() EventType { return EventUserMentioned }
+
+// OccurredAt implements Event
+func (e *UserMentionedEvent) OccurredAt() time.Time { return e.At }
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"time"
+)
+
+// ExportFormat is the output format of a task export
+type ExportFormat string
+
+const (
+	ExportFormatCSV       ExportFormat = "CSV"
+	ExportFormatJSONL     ExportFormat = "JSONL"
+	ExportFormatICalendar ExportFormat = "ICALENDAR"
+)
+
+// Export columns. The CSV names match the import columns so exports can be re-imported.
+const (
+	ExportColumnID            = "id"
+	ExportColumnTitle         = "title"
+	ExportColumnDescription   = "description"
+	ExportColumnStatus        = "status"
+	ExportColumnPriority      = "priority"
+	ExportColumnAssigneeID    = "assignee_id"
+	ExportColumnAssigneeEmail = "assignee_email"
+	ExportColumnDueDate       = "due_date"
+	ExportColumnCategories    = "categories"
+	ExportColumnTags          = "tags"
+	ExportColumnCreatedAt     = "created_at"
+	ExportColumnUpdatedAt     = "updated_at"
+	ExportColumnVersion       = "version"
+)
+
+// ExportColumns lists every exportable column in default order
+var ExportColumns = []string{
+	ExportColumnID,
+	ExportColumnTitle,
+	ExportColumnDescription,
+	ExportColumnStatus,
+	ExportColumnPriority,
+	ExportColumnAssigneeID,
+	ExportColumnAssigneeEmail,
+	ExportColumnDueDate,
+	ExportColumnCategories,
+	ExportColumnTags,
+	ExportColumnCreatedAt,
+	ExportColumnUpdatedAt,
+	ExportColumnVersion,
+}
+
+// ExportOptions controls how exported tasks are rendered
+type ExportOptions struct {
+	Format ExportFormat `json:"format"`
+	// Columns selects and orders CSV and JSON Lines fields; empty means all columns.
+	// iCalendar output always uses the VTODO properties.
+	Columns []string `json:"columns,omitempty"`
+	// TimeZone is the IANA zone timestamps are rendered in; empty means UTC
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// TaskReminder is a point in time at which a user is reminded about a task
+type TaskReminder struct {
+	ID       string    `json:"id" db:"id"`
+	TaskID   string    `json:"task_id" db:"task_id"`
+	UserID   string    `json:"user_id" db:"user_id"`
+	RemindAt time.Time `json:"remind_at" db:"reminder_time"`
+	Message  string    `json:"message,omitempty" db:"message"`
+	IsSent   bool      `json:"is_sent" db:"is_sent"`
+}
+
+// TaskExportRecord is a task with the related data needed to export it.
+// Categories and tags carry their names.
+type TaskExportRecord struct {
+	Task          *Task
+	AssigneeEmail string
+	Reminders     []TaskReminder
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// TaskFacet is a task attribute that task lists can be counted by
+type TaskFacet string
+
+const (
+	TaskFacetStatus   TaskFacet = "status"
+	TaskFacetPriority TaskFacet = "priority"
+	TaskFacetAssignee TaskFacet = "assignee"
+	TaskFacetCategory TaskFacet = "category"
+	TaskFacetTag      TaskFacet = "tag"
+)
+
+// TaskFacets lists every facet in display order
+var TaskFacets = []TaskFacet{TaskFacetStatus, TaskFacetPriority, TaskFacetAssignee, TaskFacetCategory, TaskFacetTag}
+
+// MaxFacetValues bounds the values reported for a facet; the most frequent ones are kept
+const MaxFacetValues = 100
+
+// FacetValue is the number of tasks with one value of a facet
+type FacetValue struct {
+	Value string `json:"value"` // Status or priority name, or assignee, category or tag ID
+	Label string `json:"label"` // Display name
+	Count int64  `json:"count"`
+}
+
+// FacetCounts holds the counts of one facet, most frequent value first. Counts ignore
+// the list's own filter on the facet, so they show what selecting each value would yield.
+type FacetCounts struct {
+	Facet  TaskFacet    `json:"facet"`
+	Values []FacetValue `json:"values"`
+}
+
+// ToProtobuf converts domain FacetCounts to protobuf FacetCounts
+func (f *FacetCounts) ToProtobuf() *pb.FacetCounts {
+	counts := &pb.FacetCounts{Facet: taskFacetToProtobuf(f.Facet)}
+	for _, value := range f.Values {
+		counts.Values = append(counts.Values, &pb.FacetValue{
+			Value: value.Value,
+			Label: value.Label,
+			Count: int32(value.Count),
+		})
+	}
+	return counts
+}
+
+func taskFacetToProtobuf(facet TaskFacet) pb.TaskFacet {
+	switch facet {
+	case TaskFacetStatus:
+		return pb.TaskFacet_TASK_FACET_STATUS
+	case TaskFacetPriority:
+		return pb.TaskFacet_TASK_FACET_PRIORITY
+	case TaskFacetAssignee:
+		return pb.TaskFacet_TASK_FACET_ASSIGNEE
+	case TaskFacetCategory:
+		return pb.TaskFacet_TASK_FACET_CATEGORY
+	case TaskFacetTag:
+		return pb.TaskFacet_TASK_FACET_TAG
+	default:
+		return pb.TaskFacet_TASK_FACET_UNSPECIFIED
+	}
+}
+
+// TaskFacetFromProtobuf converts a protobuf TaskFacet; unknown values become an empty facet
+func TaskFacetFromProtobuf(facet pb.TaskFacet) TaskFacet {
+	switch facet {
+	case pb.TaskFacet_TASK_FACET_STATUS:
+		return TaskFacetStatus
+	case pb.TaskFacet_TASK_FACET_PRIORITY:
+		return TaskFacetPriority
+	case pb.TaskFacet_TASK_FACET_ASSIGNEE:
+		return TaskFacetAssignee
+	case pb.TaskFacet_TASK_FACET_CATEGORY:
+		return TaskFacetCategory
+	case pb.TaskFacet_TASK_FACET_TAG:
+		return TaskFacetTag
+	default:
+		return ""
+	}
+}
@@ -21,3 +21,13 @@ func TimePtr(t time.Time) *time.Time {
 	}
 	return &t
 }
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
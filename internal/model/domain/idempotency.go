@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+// IdempotencyRecord stores the outcome of a mutating request identified by
+// a client-supplied idempotency key, scoped to the caller
+type IdempotencyRecord struct {
+	CallerID     string     `json:"caller_id" db:"caller_id"`
+	Key          string     `json:"key" db:"idempotency_key"`
+	Method       string     `json:"method" db:"method"`
+	RequestHash  string     `json:"request_hash" db:"request_hash"`
+	ResponseType string     `json:"response_type,omitempty" db:"response_type"`
+	Response     []byte     `json:"response,omitempty" db:"response"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt    time.Time  `json:"expires_at" db:"expires_at"`
+}
+
+// IsCompleted reports whether the original request finished and its response was stored
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.CompletedAt != nil
+}
+
+// Matches reports whether a request with the given method and hash is a replay of this record
+func (r *IdempotencyRecord) Matches(method, requestHash string) bool {
+	return r.Method == method && r.RequestHash == requestHash
+}
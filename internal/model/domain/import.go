@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"time"
+)
+
+// ImportFormat is the file format of a task import
+type ImportFormat string
+
+const (
+	ImportFormatCSV   ImportFormat = "CSV"
+	ImportFormatJSONL ImportFormat = "JSONL"
+)
+
+// ImportStatus represents the state of a task import
+type ImportStatus string
+
+const (
+	ImportStatusRunning   ImportStatus = "RUNNING"
+	ImportStatusCompleted ImportStatus = "COMPLETED"
+	ImportStatusFailed    ImportStatus = "FAILED"
+)
+
+// ImportRowStatus is the outcome of a single imported row
+type ImportRowStatus string
+
+const (
+	// ImportRowValid rows passed validation in a dry run
+	ImportRowValid ImportRowStatus = "VALID"
+	// ImportRowInvalid rows failed parsing or validation and are never imported
+	ImportRowInvalid ImportRowStatus = "INVALID"
+	// ImportRowCreated rows were committed as new tasks
+	ImportRowCreated ImportRowStatus = "CREATED"
+	// ImportRowSkipped rows were already committed by the import being resumed
+	ImportRowSkipped ImportRowStatus = "SKIPPED"
+	// ImportRowNotCommitted rows were valid but their batch failed or was never reached
+	ImportRowNotCommitted ImportRowStatus = "NOT_COMMITTED"
+)
+
+// Import batch size bounds
+const (
+	DefaultImportBatchSize = 100
+	MaxImportBatchSize     = 1000
+)
+
+// TaskImport tracks a committed import so it can be resumed after a failure.
+// LastCommittedRow is advanced in the same transaction as each batch of tasks.
+type TaskImport struct {
+	ID               string       `json:"id" db:"id"`
+	Format           ImportFormat `json:"format" db:"format"`
+	Status           ImportStatus `json:"status" db:"status"`
+	ActorID          string       `json:"actor_id" db:"actor_id"`
+	LastCommittedRow int          `json:"last_committed_row" db:"last_committed_row"`
+	CreatedCount     int          `json:"created_count" db:"created_count"`
+	Error            string       `json:"error,omitempty" db:"error"`
+	CreatedAt        time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// ImportOptions controls how a task import runs
+type ImportOptions struct {
+	Format    ImportFormat `json:"format"`
+	DryRun    bool         `json:"dry_run"`
+	BatchSize int          `json:"batch_size"`
+	// ResumeImportID continues a failed import, skipping the rows it already committed.
+	// The same file must be supplied again.
+	ResumeImportID string `json:"resume_import_id,omitempty"`
+}
+
+// ImportRowResult reports what happened to a single data row (1-based, header excluded)
+type ImportRowResult struct {
+	Row    int             `json:"row"`
+	Title  string          `json:"title,omitempty"`
+	Status ImportRowStatus `json:"status"`
+	TaskID string          `json:"task_id,omitempty"`
+	Errors []string        `json:"errors,omitempty"`
+}
+
+// ImportReport is the row-by-row outcome of a task import
+type ImportReport struct {
+	ImportID         string            `json:"import_id,omitempty"`
+	DryRun           bool              `json:"dry_run"`
+	Status           ImportStatus      `json:"status"`
+	TotalRows        int               `json:"total_rows"`
+	ValidRows        int               `json:"valid_rows"`
+	InvalidRows      int               `json:"invalid_rows"`
+	CreatedRows      int               `json:"created_rows"`
+	SkippedRows      int               `json:"skipped_rows"`
+	LastCommittedRow int               `json:"last_committed_row"`
+	Error            string            `json:"error,omitempty"`
+	Rows             []ImportRowResult `json:"rows"`
+}
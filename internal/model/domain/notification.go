@@ -0,0 +1,506 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// NotificationEvent is a kind of task change watchers are notified of
+type NotificationEvent string
+
+const (
+	NotificationStatusChanged NotificationEvent = "STATUS_CHANGED"
+	NotificationReassigned    NotificationEvent = "REASSIGNED"
+	NotificationCommented     NotificationEvent = "COMMENTED"
+	NotificationDueSoon       NotificationEvent = "DUE_SOON"
+)
+
+// NotificationEvents lists every notification event
+var NotificationEvents = []NotificationEvent{
+	NotificationStatusChanged, NotificationReassigned, NotificationCommented, NotificationDueSoon,
+}
+
+// NotificationChannel is a way of delivering notifications to a user
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "IN_APP"
+	NotificationChannelEmail NotificationChannel = "EMAIL"
+)
+
+// NotificationChannels lists every notification channel
+var NotificationChannels = []NotificationChannel{NotificationChannelInApp, NotificationChannelEmail}
+
+// NotificationMode is how a user wants an event delivered on a channel
+type NotificationMode string
+
+const (
+	// NotificationImmediate delivers the event as soon as quiet hours allow
+	NotificationImmediate NotificationMode = "IMMEDIATE"
+	// NotificationDigest collects the event into the daily digest
+	NotificationDigest NotificationMode = "DIGEST"
+	// NotificationOff drops the event
+	NotificationOff NotificationMode = "OFF"
+)
+
+// MaxNotificationExcerptLength bounds the comment excerpt carried by a notification, in characters
+const MaxNotificationExcerptLength = 200
+
+// DefaultNotificationMode is the mode of every event on a channel the user has no preference for:
+// in-app notifications are immediate, emails are collected into the digest
+func DefaultNotificationMode(channel NotificationChannel) NotificationMode {
+	if channel == NotificationChannelEmail {
+		return NotificationDigest
+	}
+	return NotificationImmediate
+}
+
+// NotificationPreference overrides the mode of an event on a channel
+type NotificationPreference struct {
+	Event   NotificationEvent   `json:"event"`
+	Channel NotificationChannel `json:"channel"`
+	Mode    NotificationMode    `json:"mode"`
+}
+
+// QuietHours is a daily period, in the user's time zone, during which immediate notifications
+// are held back. It spans midnight when End is before Start.
+type QuietHours struct {
+	Start int `json:"start"` // minutes after local midnight
+	End   int `json:"end"`   // minutes after local midnight
+}
+
+// IsValid validates the quiet hours
+func (q *QuietHours) IsValid() error {
+	if q.Start < 0 || q.Start >= 24*60 || q.End < 0 || q.End >= 24*60 {
+		return ErrInvalidInput("quiet hours must be times of day")
+	}
+	if q.Start == q.End {
+		return ErrInvalidInput("quiet hours must not start and end at the same time")
+	}
+	return nil
+}
+
+// Contains reports whether the local time falls within the quiet hours
+func (q *QuietHours) Contains(local time.Time) bool {
+	minute := local.Hour()*60 + local.Minute()
+	if q.Start < q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+// Until returns when the quiet hours that contain the local time end
+func (q *QuietHours) Until(local time.Time) time.Time {
+	year, month, day := local.Date()
+	end := time.Date(year, month, day, q.End/60, q.End%60, 0, 0, local.Location())
+	if !end.After(local) {
+		end = time.Date(year, month, day+1, q.End/60, q.End%60, 0, 0, local.Location())
+	}
+	return end
+}
+
+// ParseClock parses an "HH:MM" time of day into minutes after midnight
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, ErrInvalidInput(fmt.Sprintf("invalid time of day %q, expected HH:MM", value))
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock formats minutes after midnight as "HH:MM"
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// NotificationSettings is a user's notification configuration
+type NotificationSettings struct {
+	UserID string `json:"user_id" db:"user_id"`
+	// TimeZone is the IANA name quiet hours and the digest hour are read in
+	TimeZone   string      `json:"time_zone" db:"time_zone"`
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+	// Preferences override the default mode of some events on some channels
+	Preferences []NotificationPreference `json:"preferences,omitempty"`
+	UpdatedAt   time.Time                `json:"updated_at" db:"updated_at"`
+	// Version is zero until the settings are first saved
+	Version int64 `json:"version" db:"version"`
+}
+
+// DefaultNotificationSettings returns the settings of a user who never saved any
+func DefaultNotificationSettings(userID string) *NotificationSettings {
+	return &NotificationSettings{UserID: userID, TimeZone: "UTC"}
+}
+
+// IsValid validates the notification settings
+func (s *NotificationSettings) IsValid() error {
+	if s.UserID == "" {
+		return ErrInvalidInput("notification settings must belong to a user")
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil || s.TimeZone == "" || strings.EqualFold(s.TimeZone, "local") {
+		return ErrInvalidInput(fmt.Sprintf("unknown time zone %q", s.TimeZone))
+	}
+	if s.QuietHours != nil {
+		if err := s.QuietHours.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, pref := range s.Preferences {
+		if !isNotificationEvent(pref.Event) {
+			return ErrInvalidInput(fmt.Sprintf("unknown notification event %q", pref.Event))
+		}
+		if !isNotificationChannel(pref.Channel) {
+			return ErrInvalidInput(fmt.Sprintf("unknown notification channel %q", pref.Channel))
+		}
+		switch pref.Mode {
+		case NotificationImmediate, NotificationDigest, NotificationOff:
+		default:
+			return ErrInvalidInput(fmt.Sprintf("unknown notification mode %q", pref.Mode))
+		}
+		key := string(pref.Event) + "/" + string(pref.Channel)
+		if seen[key] {
+			return ErrInvalidInput(fmt.Sprintf("more than one preference for %s on %s", pref.Event, pref.Channel))
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Mode returns how the user wants the event delivered on the channel
+func (s *NotificationSettings) Mode(event NotificationEvent, channel NotificationChannel) NotificationMode {
+	for _, pref := range s.Preferences {
+		if pref.Event == event && pref.Channel == channel {
+			return pref.Mode
+		}
+	}
+	return DefaultNotificationMode(channel)
+}
+
+// UsesDigest reports whether any event is collected into the digest on the channel
+func (s *NotificationSettings) UsesDigest(channel NotificationChannel) bool {
+	for _, event := range NotificationEvents {
+		if s.Mode(event, channel) == NotificationDigest {
+			return true
+		}
+	}
+	return false
+}
+
+// Location returns the user's time zone, UTC if it cannot be loaded
+func (s *NotificationSettings) Location() *time.Location {
+	if loc, err := time.LoadLocation(s.TimeZone); err == nil && s.TimeZone != "" {
+		return loc
+	}
+	return time.UTC
+}
+
+// DeliverAfter returns the earliest time a notification raised at t may reach the user:
+// t itself, or the end of the quiet hours t falls within
+func (s *NotificationSettings) DeliverAfter(t time.Time) time.Time {
+	if s.QuietHours == nil {
+		return t
+	}
+	local := t.In(s.Location())
+	if !s.QuietHours.Contains(local) {
+		return t
+	}
+	return s.QuietHours.Until(local)
+}
+
+// DigestSlot returns the latest start of the given local hour at or before now, which is
+// when the user's most recent daily digest fell due
+func (s *NotificationSettings) DigestSlot(now time.Time, hour int) time.Time {
+	local := now.In(s.Location())
+	year, month, day := local.Date()
+	slot := time.Date(year, month, day, hour, 0, 0, 0, local.Location())
+	if slot.After(local) {
+		slot = time.Date(year, month, day-1, hour, 0, 0, 0, local.Location())
+	}
+	return slot
+}
+
+// ToProtobuf converts NotificationSettings to protobuf, listing the mode of every event on
+// every channel
+func (s *NotificationSettings) ToProtobuf() *pb.NotificationSettings {
+	settings := &pb.NotificationSettings{
+		UserId:   s.UserID,
+		TimeZone: s.TimeZone,
+		Version:  s.Version,
+	}
+	if !s.UpdatedAt.IsZero() {
+		settings.UpdatedAt = TimeToProtobuf(s.UpdatedAt)
+	}
+	if s.QuietHours != nil {
+		settings.QuietHours = &pb.QuietHours{Start: FormatClock(s.QuietHours.Start), End: FormatClock(s.QuietHours.End)}
+	}
+	for _, event := range NotificationEvents {
+		for _, channel := range NotificationChannels {
+			settings.Preferences = append(settings.Preferences, &pb.NotificationPreference{
+				Event:   notificationEventToProtobuf(event),
+				Channel: notificationChannelToProtobuf(channel),
+				Mode:    notificationModeToProtobuf(s.Mode(event, channel)),
+			})
+		}
+	}
+	return settings
+}
+
+// QuietHoursFromProtobuf converts protobuf quiet hours; nil means none
+func QuietHoursFromProtobuf(quiet *pb.QuietHours) (*QuietHours, error) {
+	if quiet == nil {
+		return nil, nil
+	}
+	start, err := ParseClock(quiet.GetStart())
+	if err != nil {
+		return nil, err
+	}
+	end, err := ParseClock(quiet.GetEnd())
+	if err != nil {
+		return nil, err
+	}
+	return &QuietHours{Start: start, End: end}, nil
+}
+
+// NotificationPreferenceFromProtobuf converts a protobuf preference. Unspecified values are
+// left empty and rejected by NotificationSettings.IsValid.
+func NotificationPreferenceFromProtobuf(pref *pb.NotificationPreference) NotificationPreference {
+	p := NotificationPreference{}
+	switch pref.GetEvent() {
+	case pb.NotificationEvent_NOTIFICATION_EVENT_STATUS_CHANGED:
+		p.Event = NotificationStatusChanged
+	case pb.NotificationEvent_NOTIFICATION_EVENT_REASSIGNED:
+		p.Event = NotificationReassigned
+	case pb.NotificationEvent_NOTIFICATION_EVENT_COMMENTED:
+		p.Event = NotificationCommented
+	case pb.NotificationEvent_NOTIFICATION_EVENT_DUE_SOON:
+		p.Event = NotificationDueSoon
+	}
+	switch pref.GetChannel() {
+	case pb.NotificationChannel_NOTIFICATION_CHANNEL_IN_APP:
+		p.Channel = NotificationChannelInApp
+	case pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL:
+		p.Channel = NotificationChannelEmail
+	}
+	switch pref.GetMode() {
+	case pb.NotificationMode_NOTIFICATION_MODE_IMMEDIATE:
+		p.Mode = NotificationImmediate
+	case pb.NotificationMode_NOTIFICATION_MODE_DIGEST:
+		p.Mode = NotificationDigest
+	case pb.NotificationMode_NOTIFICATION_MODE_OFF:
+		p.Mode = NotificationOff
+	}
+	return p
+}
+
+func notificationEventToProtobuf(event NotificationEvent) pb.NotificationEvent {
+	switch event {
+	case NotificationStatusChanged:
+		return pb.NotificationEvent_NOTIFICATION_EVENT_STATUS_CHANGED
+	case NotificationReassigned:
+		return pb.NotificationEvent_NOTIFICATION_EVENT_REASSIGNED
+	case NotificationCommented:
+		return pb.NotificationEvent_NOTIFICATION_EVENT_COMMENTED
+	case NotificationDueSoon:
+		return pb.NotificationEvent_NOTIFICATION_EVENT_DUE_SOON
+	default:
+		return pb.NotificationEvent_NOTIFICATION_EVENT_UNSPECIFIED
+	}
+}
+
+func notificationChannelToProtobuf(channel NotificationChannel) pb.NotificationChannel {
+	switch channel {
+	case NotificationChannelInApp:
+		return pb.NotificationChannel_NOTIFICATION_CHANNEL_IN_APP
+	case NotificationChannelEmail:
+		return pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+	default:
+		return pb.NotificationChannel_NOTIFICATION_CHANNEL_UNSPECIFIED
+	}
+}
+
+func notificationModeToProtobuf(mode NotificationMode) pb.NotificationMode {
+	switch mode {
+	case NotificationImmediate:
+		return pb.NotificationMode_NOTIFICATION_MODE_IMMEDIATE
+	case NotificationDigest:
+		return pb.NotificationMode_NOTIFICATION_MODE_DIGEST
+	case NotificationOff:
+		return pb.NotificationMode_NOTIFICATION_MODE_OFF
+	default:
+		return pb.NotificationMode_NOTIFICATION_MODE_UNSPECIFIED
+	}
+}
+
+func isNotificationEvent(event NotificationEvent) bool {
+	for _, known := range NotificationEvents {
+		if event == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotificationChannel(channel NotificationChannel) bool {
+	for _, known := range NotificationChannels {
+		if channel == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Watches lists what a user watches
+type Watches struct {
+	TaskIDs     []string `json:"task_ids"`
+	CategoryIDs []string `json:"category_ids"`
+}
+
+// NotificationItem describes a single task change a user is notified of
+type NotificationItem struct {
+	Event     NotificationEvent `json:"event"`
+	TaskID    string            `json:"task_id"`
+	TaskTitle string            `json:"task_title"`
+	ActorID   string            `json:"actor_id,omitempty"`
+	Summary   string            `json:"summary"`
+	At        time.Time         `json:"at"`
+
+	// Involved are users notified besides the task's watchers, e.g. the previous assignee
+	Involved []string `json:"-"`
+}
+
+// Notification is what a Notifier delivers to a user on a channel: a single item, or the
+// items of a digest
+type Notification struct {
+	ID           string              `json:"id" db:"id"`
+	UserID       string              `json:"user_id" db:"user_id"`
+	Channel      NotificationChannel `json:"channel" db:"channel"`
+	Digest       bool                `json:"digest" db:"is_digest"`
+	Items        []NotificationItem  `json:"items" db:"items"`
+	CreatedAt    time.Time           `json:"created_at" db:"created_at"`
+	DeliverAfter time.Time           `json:"deliver_after" db:"deliver_after"`
+}
+
+// HistoryNotificationItems returns the items a task history entry notifies watchers of:
+// a status change, a reassignment, or both. Participant changes count as a reassignment
+// and involve the users who gained or lost a role.
+func HistoryNotificationItems(entry *TaskHistory, taskTitle string) []NotificationItem {
+	details, err := entry.GetDetails()
+	if err != nil || details == nil {
+		return nil
+	}
+
+	var items []NotificationItem
+	reassigned := -1
+	for _, field := range details.Changes {
+		item := NotificationItem{TaskID: entry.TaskID, TaskTitle: taskTitle, ActorID: entry.ActorID, At: entry.Timestamp}
+		oldValue, newValue := historyValue(details.OldValues, field), historyValue(details.NewValues, field)
+		switch field {
+		case "status":
+			item.Event = NotificationStatusChanged
+			item.Summary = fmt.Sprintf("Status changed from %s to %s", oldValue, newValue)
+		case "assignee_id":
+			item.Event = NotificationReassigned
+			item.Summary = "Task reassigned"
+			for _, userID := range []string{oldValue, newValue} {
+				if userID != "" {
+					item.Involved = append(item.Involved, userID)
+				}
+			}
+		case "participants":
+			item.Event = NotificationReassigned
+			item.Summary = "Participants changed"
+			item.Involved = changedParticipants(
+				participantsFromHistory(details.OldValues[field]), participantsFromHistory(details.NewValues[field]))
+		default:
+			continue
+		}
+		if item.Event == NotificationReassigned {
+			// A primary assignee handed over with the participants is one reassignment
+			if reassigned >= 0 {
+				items[reassigned].Involved = appendMissing(items[reassigned].Involved, item.Involved...)
+				continue
+			}
+			reassigned = len(items)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// changedParticipants returns the users holding a role in one of the values but not the other
+func changedParticipants(oldValue, newValue map[string][]string) []string {
+	var changed []string
+	for _, pair := range [][2]map[string][]string{{oldValue, newValue}, {newValue, oldValue}} {
+		for role, userIDs := range pair[0] {
+			for _, userID := range userIDs {
+				if !containsString(pair[1][role], userID) {
+					changed = appendMissing(changed, userID)
+				}
+			}
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func appendMissing(values []string, more ...string) []string {
+	for _, value := range more {
+		if !containsString(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func historyValue(values map[string]interface{}, field string) string {
+	value, ok := values[field]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// CommentNotificationItem returns the item a new comment notifies watchers of. Users
+// mentioned in the comment are notified as well.
+func CommentNotificationItem(comment *Comment, taskTitle string) NotificationItem {
+	excerpt := strings.Join(strings.Fields(comment.Body), " ")
+	if runes := []rune(excerpt); len(runes) > MaxNotificationExcerptLength {
+		excerpt = string(runes[:MaxNotificationExcerptLength-1]) + "…"
+	}
+	return NotificationItem{
+		Event:     NotificationCommented,
+		TaskID:    comment.TaskID,
+		TaskTitle: taskTitle,
+		ActorID:   comment.AuthorID,
+		Summary:   excerpt,
+		At:        comment.CreatedAt,
+		Involved:  comment.MentionedUserIDs,
+	}
+}
+
+// DueSoonNotificationItem returns the item announcing that a task's due date approaches
+func DueSoonNotificationItem(task *Task, at time.Time) NotificationItem {
+	item := NotificationItem{Event: NotificationDueSoon, TaskID: task.ID, TaskTitle: task.Title, At: at}
+	if task.DueDate != nil {
+		item.Summary = "Due " + task.DueDate.UTC().Format(time.RFC3339)
+	}
+	return item
+}
+
+// NotificationRules control when notifications are raised
+type NotificationRules struct {
+	// DueSoon is how long before its due date a task is announced; zero disables the announcements
+	DueSoon time.Duration
+	// DigestHour is the hour of the day, in each user's time zone, daily digests are assembled at
+	DigestHour int
+}
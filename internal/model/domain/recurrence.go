@@ -0,0 +1,314 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// RecurrenceFrequency is the unit a recurrence rule repeats in
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// MaxRecurrenceInterval bounds the INTERVAL of a recurrence rule
+const MaxRecurrenceInterval = 999
+
+// maxRecurrenceSteps bounds the occurrences walked to find the next one, which covers
+// a daily rule for well over a century
+const maxRecurrenceSteps = 100000
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// RecurrenceRule is the supported subset of an iCalendar RRULE: daily, weekly on given days or
+// monthly on a given day, every Interval units, ending at Until or after Count instances
+type RecurrenceRule struct {
+	Frequency RecurrenceFrequency `json:"frequency"`
+	Interval  int                 `json:"interval"`
+	// ByDay lists the weekdays of a weekly rule; empty means the weekday of the first instance
+	ByDay []time.Weekday `json:"by_day,omitempty"`
+	// ByMonthDay is the day of a monthly rule; zero means the day of the first instance.
+	// Months too short for the day use their last day.
+	ByMonthDay int        `json:"by_month_day,omitempty"`
+	Until      *time.Time `json:"until,omitempty"`
+	Count      int        `json:"count,omitempty"`
+}
+
+// ParseRecurrenceRule parses a rule such as "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=10". An "RRULE:"
+// prefix is accepted. UNTIL takes a date (20261231) or a UTC time (20261231T170000Z).
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, ErrInvalidInput("recurrence rule is required")
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if !ok || val == "" {
+			return nil, ErrInvalidInput(fmt.Sprintf("invalid recurrence rule part %q", part))
+		}
+		if seen[key] {
+			return nil, ErrInvalidInput(fmt.Sprintf("recurrence rule sets %s twice", key))
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Frequency = RecurrenceFrequency(val)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, ok := weekdayCodes[code]
+				if !ok {
+					return nil, ErrInvalidInput(fmt.Sprintf("unsupported BYDAY value %q", code))
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = strconv.Atoi(val)
+		case "UNTIL":
+			var until time.Time
+			if until, err = parseRecurrenceTime(val); err == nil {
+				rule.Until = &until
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+		default:
+			return nil, ErrInvalidInput(fmt.Sprintf("unsupported recurrence rule part %s", key))
+		}
+		if err != nil {
+			return nil, ErrInvalidInput(fmt.Sprintf("invalid %s value %q", key, val))
+		}
+	}
+
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+	rule.normalize()
+	return rule, nil
+}
+
+func parseRecurrenceTime(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	// A bare date includes the whole day
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(24*time.Hour - time.Second), nil
+}
+
+// IsValid validates the recurrence rule
+func (r *RecurrenceRule) IsValid() error {
+	switch r.Frequency {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+	case "":
+		return ErrInvalidInput("recurrence rule needs a FREQ")
+	default:
+		return ErrInvalidInput(fmt.Sprintf("unsupported recurrence frequency %s", r.Frequency))
+	}
+	if r.Interval < 1 || r.Interval > MaxRecurrenceInterval {
+		return ErrInvalidInput(fmt.Sprintf("recurrence interval must be between 1 and %d", MaxRecurrenceInterval))
+	}
+	if len(r.ByDay) > 0 && r.Frequency != RecurrenceWeekly {
+		return ErrInvalidInput("BYDAY is only supported for weekly rules")
+	}
+	if r.ByMonthDay != 0 {
+		if r.Frequency != RecurrenceMonthly {
+			return ErrInvalidInput("BYMONTHDAY is only supported for monthly rules")
+		}
+		if r.ByMonthDay < 1 || r.ByMonthDay > 31 {
+			return ErrInvalidInput("BYMONTHDAY must be between 1 and 31")
+		}
+	}
+	if r.Count < 0 {
+		return ErrInvalidInput("COUNT must be positive")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return ErrInvalidInput("a recurrence rule cannot have both COUNT and UNTIL")
+	}
+	return nil
+}
+
+// normalize sorts and deduplicates the weekdays, Monday first
+func (r *RecurrenceRule) normalize() {
+	seen := make(map[time.Weekday]bool)
+	days := r.ByDay[:0]
+	for _, day := range r.ByDay {
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return weekdayOffset(days[i]) < weekdayOffset(days[j]) })
+	r.ByDay = days
+}
+
+// weekdayOffset counts days from Monday
+func weekdayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// String formats the rule in its canonical RRULE form
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = strings.ToUpper(day.String()[:2])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.ByMonthDay > 0 {
+		parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", r.ByMonthDay))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after the given time for a series starting at start.
+// Occurrences keep the time of day of start. It returns false once the rule has no more
+// occurrences within its UNTIL; COUNT limits instances and is left to the caller.
+func (r *RecurrenceRule) Next(start, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.walk(start, func(occurrence time.Time) bool {
+		if occurrence.After(after) {
+			next, found = occurrence, true
+			return false
+		}
+		return true
+	})
+	if !found || (r.Until != nil && next.After(*r.Until)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// walk calls fn for each occurrence from start on, in order, until fn returns false
+func (r *RecurrenceRule) walk(start time.Time, fn func(time.Time) bool) {
+	year, month, day := start.Date()
+	clock := start.Sub(time.Date(year, month, day, 0, 0, 0, 0, start.Location()))
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, start.Location()).Add(clock)
+	}
+
+	for step := 0; step < maxRecurrenceSteps; step++ {
+		switch r.Frequency {
+		case RecurrenceDaily:
+			if !fn(at(year, month, day+step*r.Interval)) {
+				return
+			}
+		case RecurrenceWeekly:
+			days := r.ByDay
+			if len(days) == 0 {
+				days = []time.Weekday{start.Weekday()}
+			}
+			monday := day - weekdayOffset(start.Weekday()) + step*7*r.Interval
+			for _, weekday := range days {
+				occurrence := at(year, month, monday+weekdayOffset(weekday))
+				if occurrence.Before(start) {
+					continue
+				}
+				if !fn(occurrence) {
+					return
+				}
+			}
+		case RecurrenceMonthly:
+			target := r.ByMonthDay
+			if target == 0 {
+				target = day
+			}
+			// Day zero of the following month is the last day of this one
+			m := month + time.Month(step*r.Interval)
+			last := time.Date(year, m+1, 0, 0, 0, 0, 0, start.Location()).Day()
+			if target > last {
+				target = last
+			}
+			occurrence := at(year, m, target)
+			if occurrence.Before(start) {
+				continue
+			}
+			if !fn(occurrence) {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// TaskSeries links the instances of a recurring task. The latest instance is the template
+// of the next one.
+type TaskSeries struct {
+	ID            string         `json:"id" db:"id"`
+	Rule          RecurrenceRule `json:"rule" db:"rule"`
+	StartsAt      time.Time      `json:"starts_at" db:"starts_at"`
+	InstanceCount int            `json:"instance_count" db:"instance_count"`
+	IsActive      bool           `json:"is_active" db:"is_active"`
+	CreatorID     string         `json:"creator_id" db:"creator_id"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
+	Version       int64          `json:"version" db:"version"`
+}
+
+// NextDueDate returns the due date of the instance that follows one due at the given time,
+// and false when the series has run out
+func (s *TaskSeries) NextDueDate(after time.Time) (time.Time, bool) {
+	if !s.IsActive || (s.Rule.Count > 0 && s.InstanceCount >= s.Rule.Count) {
+		return time.Time{}, false
+	}
+	return s.Rule.Next(s.StartsAt, after)
+}
+
+// TaskSeriesChanges are the fields an edit to a whole series sets on the series and on every
+// open instance. Nil fields are left unchanged.
+type TaskSeriesChanges struct {
+	Title       *string
+	Description *string
+	AssigneeID  *string
+	Priority    *TaskPriority
+	Rule        *RecurrenceRule
+}
+
+// ToProtobuf converts a TaskSeries to protobuf
+func (s *TaskSeries) ToProtobuf() *pb.TaskSeries {
+	return &pb.TaskSeries{
+		Id:            s.ID,
+		Rule:          s.Rule.String(),
+		StartsAt:      TimeToProtobuf(s.StartsAt),
+		InstanceCount: int32(s.InstanceCount),
+		IsActive:      s.IsActive,
+		CreatorId:     s.CreatorID,
+		CreatedAt:     TimeToProtobuf(s.CreatedAt),
+		UpdatedAt:     TimeToProtobuf(s.UpdatedAt),
+		Version:       s.Version,
+	}
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// SavedViewVisibility controls who can see a saved view
+type SavedViewVisibility string
+
+const (
+	// SavedViewPrivate views are visible to their owner only
+	SavedViewPrivate SavedViewVisibility = "PRIVATE"
+	// SavedViewRole views are also visible to every user with the shared role
+	SavedViewRole SavedViewVisibility = "ROLE"
+)
+
+// MaxSavedViewNameLength bounds saved view names
+const MaxSavedViewNameLength = 100
+
+// SavedViewSortFields are the task fields a saved view can sort by
+var SavedViewSortFields = []string{"title", "status", "priority", "due_date", "created_at", "updated_at", "relevance"}
+
+// SavedViewFilter is the persisted form of a task list filter
+type SavedViewFilter struct {
+	AssigneeID   string       `json:"assignee_id,omitempty"`
+	Status       TaskStatus   `json:"status,omitempty"`
+	Priority     TaskPriority `json:"priority,omitempty"`
+	CategoryIDs  []string     `json:"category_ids,omitempty"`
+	TagIDs       []string     `json:"tag_ids,omitempty"`
+	DueBefore    *time.Time   `json:"due_before,omitempty"`
+	DueAfter     *time.Time   `json:"due_after,omitempty"`
+	SearchQuery  string       `json:"search_query,omitempty"`
+	SearchLabels bool         `json:"search_labels,omitempty"`
+	// AssigneeRoles are the roles AssigneeID must hold; none matches any role
+	AssigneeRoles []TaskParticipantRole `json:"assignee_roles,omitempty"`
+	CreatorID     string                `json:"creator_id,omitempty"`
+	// Query is kept as text so "me" and relative dates resolve for whoever opens the view
+	Query string `json:"query,omitempty"`
+}
+
+// SavedView is a named task filter with its sort order and visible columns
+type SavedView struct {
+	ID          string              `json:"id" db:"id"`
+	OwnerID     string              `json:"owner_id" db:"owner_id"`
+	Name        string              `json:"name" db:"name"`
+	Description string              `json:"description" db:"description"`
+	Visibility  SavedViewVisibility `json:"visibility" db:"visibility"`
+	SharedRole  UserRole            `json:"shared_role,omitempty" db:"shared_role"`
+	Filter      SavedViewFilter     `json:"filter" db:"filter"`
+	SortBy      string              `json:"sort_by,omitempty" db:"sort_by"`
+	SortDesc    bool                `json:"sort_desc" db:"sort_desc"`
+	Columns     []string            `json:"columns" db:"columns"` // Export column names
+	CreatedAt   time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at" db:"updated_at"`
+	Version     int64               `json:"version" db:"version"`
+	IsDeleted   bool                `json:"is_deleted" db:"is_deleted"`
+	DeletedAt   *time.Time          `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Set when the view is loaded: filter categories and tags that no longer exist
+	MissingCategoryIDs []string `json:"missing_category_ids,omitempty"`
+	MissingTagIDs      []string `json:"missing_tag_ids,omitempty"`
+}
+
+// IsStale reports whether the view refers to deleted categories or tags
+func (v *SavedView) IsStale() bool {
+	return len(v.MissingCategoryIDs) > 0 || len(v.MissingTagIDs) > 0
+}
+
+// VisibleTo reports whether a user with the given role can see the view
+func (v *SavedView) VisibleTo(userID string, role UserRole) bool {
+	if v.OwnerID == userID || role == UserRoleAdmin {
+		return true
+	}
+	return v.Visibility == SavedViewRole && v.SharedRole == role
+}
+
+// IsValid validates the saved view data
+func (v *SavedView) IsValid() error {
+	if strings.TrimSpace(v.Name) == "" {
+		return ErrInvalidInput("saved view name is required")
+	}
+	if len(v.Name) > MaxSavedViewNameLength {
+		return ErrInvalidInput(fmt.Sprintf("saved view name must be at most %d characters", MaxSavedViewNameLength))
+	}
+	if v.OwnerID == "" {
+		return ErrInvalidInput("saved view owner is required")
+	}
+
+	switch v.Visibility {
+	case SavedViewPrivate:
+		if v.SharedRole != "" {
+			return ErrInvalidInput("private saved views cannot have a shared role")
+		}
+	case SavedViewRole:
+		if v.SharedRole != UserRoleUser && v.SharedRole != UserRoleAdmin {
+			return ErrInvalidInput("shared saved views need a shared role of user or admin")
+		}
+	default:
+		return ErrInvalidInput(fmt.Sprintf("invalid saved view visibility: %s", v.Visibility))
+	}
+
+	if v.SortBy != "" && !containsString(SavedViewSortFields, v.SortBy) {
+		return ErrInvalidInput(fmt.Sprintf("cannot sort by %q (fields: %s)", v.SortBy, strings.Join(SavedViewSortFields, ", ")))
+	}
+
+	for _, column := range v.Columns {
+		if !containsString(ExportColumns, column) {
+			return ErrInvalidInput(fmt.Sprintf("unknown column %q", column))
+		}
+	}
+	return nil
+}
+
+// ToProtobuf converts domain SavedView to protobuf SavedView
+func (v *SavedView) ToProtobuf() *pb.SavedView {
+	view := &pb.SavedView{
+		Id:                 v.ID,
+		OwnerId:            v.OwnerID,
+		Name:               v.Name,
+		Description:        v.Description,
+		Visibility:         savedViewVisibilityToProtobuf(v.Visibility),
+		Filter:             v.Filter.ToProtobuf(),
+		Query:              v.Filter.Query,
+		SortBy:             v.SortBy,
+		SortDesc:           v.SortDesc,
+		Columns:            v.Columns,
+		CreatedAt:          TimeToProtobuf(v.CreatedAt),
+		UpdatedAt:          TimeToProtobuf(v.UpdatedAt),
+		Version:            v.Version,
+		Stale:              v.IsStale(),
+		MissingCategoryIds: v.MissingCategoryIDs,
+		MissingTagIds:      v.MissingTagIDs,
+	}
+	if v.SharedRole != "" {
+		view.SharedRole = userRoleToProtobuf(v.SharedRole)
+	}
+	return view
+}
+
+// ToProtobuf converts the filter to a protobuf TaskFilter; the query is carried separately
+func (f SavedViewFilter) ToProtobuf() *pb.TaskFilter {
+	filter := &pb.TaskFilter{
+		AssigneeId:   f.AssigneeID,
+		CategoryIds:  f.CategoryIDs,
+		TagIds:       f.TagIDs,
+		SearchQuery:  f.SearchQuery,
+		SearchLabels: f.SearchLabels,
+	}
+	filter.AssigneeRoles = taskParticipantRolesToProtobuf(f.AssigneeRoles)
+	filter.CreatorId = f.CreatorID
+	if f.Status != "" {
+		filter.Status = taskStatusToProtobuf(f.Status)
+	}
+	if f.Priority != "" {
+		filter.Priority = taskPriorityToProtobuf(f.Priority)
+	}
+	if f.DueBefore != nil {
+		filter.DueBefore = TimeToProtobuf(*f.DueBefore)
+	}
+	if f.DueAfter != nil {
+		filter.DueAfter = TimeToProtobuf(*f.DueAfter)
+	}
+	return filter
+}
+
+// SavedViewFilterFromProtobuf converts a protobuf TaskFilter and task query to a saved view filter
+func SavedViewFilterFromProtobuf(filter *pb.TaskFilter, query string) SavedViewFilter {
+	f := SavedViewFilter{
+		AssigneeID:   filter.GetAssigneeId(),
+		CategoryIDs:  filter.GetCategoryIds(),
+		TagIDs:       filter.GetTagIds(),
+		SearchQuery:  filter.GetSearchQuery(),
+		SearchLabels: filter.GetSearchLabels(),
+		Query:        query,
+	}
+	f.AssigneeRoles = TaskParticipantRolesFromProtobuf(filter.GetAssigneeRoles())
+	f.CreatorID = filter.GetCreatorId()
+	if filter.GetStatus() != pb.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		f.Status = TaskStatusFromProtobuf(filter.GetStatus())
+	}
+	if filter.GetPriority() != pb.TaskPriority_TASK_PRIORITY_UNSPECIFIED {
+		f.Priority = TaskPriorityFromProtobuf(filter.GetPriority())
+	}
+	if filter.GetDueBefore() != nil {
+		f.DueBefore = TimePtr(filter.GetDueBefore().AsTime())
+	}
+	if filter.GetDueAfter() != nil {
+		f.DueAfter = TimePtr(filter.GetDueAfter().AsTime())
+	}
+	return f
+}
+
+func savedViewVisibilityToProtobuf(visibility SavedViewVisibility) pb.SavedViewVisibility {
+	switch visibility {
+	case SavedViewPrivate:
+		return pb.SavedViewVisibility_SAVED_VIEW_VISIBILITY_PRIVATE
+	case SavedViewRole:
+		return pb.SavedViewVisibility_SAVED_VIEW_VISIBILITY_ROLE
+	default:
+		return pb.SavedViewVisibility_SAVED_VIEW_VISIBILITY_UNSPECIFIED
+	}
+}
+
+// SavedViewVisibilityFromProtobuf converts a protobuf visibility; unspecified means private
+func SavedViewVisibilityFromProtobuf(visibility pb.SavedViewVisibility) SavedViewVisibility {
+	switch visibility {
+	case pb.SavedViewVisibility_SAVED_VIEW_VISIBILITY_ROLE:
+		return SavedViewRole
+	default:
+		return SavedViewPrivate
+	}
+}
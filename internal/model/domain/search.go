@@ -0,0 +1,22 @@
+package domain
+
+// Search highlight markers wrapped around matching words in snippets
+const (
+	SearchHighlightStart = "<mark>"
+	SearchHighlightStop  = "</mark>"
+)
+
+// MaxSearchQueryLength bounds the length of a full-text search query
+const MaxSearchQueryLength = 256
+
+// TaskSearchResult is a task matching a full-text search
+type TaskSearchResult struct {
+	Task *Task `json:"task"`
+	// Rank is the relevance of the title and description match; higher is better
+	Rank float64 `json:"rank"`
+	// TitleHighlight and DescriptionSnippet mark matching words with the highlight markers
+	TitleHighlight     string `json:"title_highlight"`
+	DescriptionSnippet string `json:"description_snippet"`
+	// MatchedLabels are the category and tag names that matched the query
+	MatchedLabels []string `json:"matched_labels,omitempty"`
+}
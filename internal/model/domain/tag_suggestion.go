@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// Tag suggestion limits
+const (
+	DefaultTagSuggestionLimit = 10
+	MaxTagSuggestionLimit     = 50
+	TagSuggestionCandidates   = 100 // best matches ranked by usage for each request
+
+	// Queries shorter than this only match by prefix; they have too few trigrams to compare
+	TagSuggestionMinTrigramQuery = 3
+
+	TagSuggestionRecentWindow = 30 * 24 * time.Hour
+	DefaultTagSuggestTimeout  = 200 * time.Millisecond
+)
+
+// Weights of the parts of a suggestion's score. A prefix match outranks any trigram match of
+// an unused tag; usage counts are damped so a popular tag cannot bury a close match.
+const (
+	tagSuggestionPrefixWeight = 1.0
+	tagSuggestionUsageWeight  = 0.25
+	tagSuggestionRecentWeight = 0.5
+)
+
+// TagSuggestion is a tag matching a type-ahead query
+type TagSuggestion struct {
+	Tag              *Tag    `json:"tag"`
+	PrefixMatch      bool    `json:"prefix_match"`
+	Similarity       float64 `json:"similarity"`         // trigram similarity to the query
+	UsageCount       int64   `json:"usage_count"`        // live tasks using the tag
+	RecentUsageCount int64   `json:"recent_usage_count"` // tasks with the tag the caller changed recently
+	Score            float64 `json:"score"`
+}
+
+// ToProtobuf converts a TagSuggestion to protobuf
+func (s *TagSuggestion) ToProtobuf() *pb.TagSuggestion {
+	return &pb.TagSuggestion{
+		Tag:              s.Tag.ToProtobuf(),
+		UsageCount:       s.UsageCount,
+		RecentUsageCount: s.RecentUsageCount,
+		Score:            s.Score,
+	}
+}
+
+// RankTagSuggestions scores suggestions by how well they match and how much they are used,
+// and returns the best ones, highest score first
+func RankTagSuggestions(suggestions []*TagSuggestion, limit int) []*TagSuggestion {
+	for _, s := range suggestions {
+		s.Score = s.Similarity +
+			tagSuggestionUsageWeight*math.Log10(1+float64(s.UsageCount)) +
+			tagSuggestionRecentWeight*math.Log10(1+float64(s.RecentUsageCount))
+		if s.PrefixMatch {
+			s.Score += tagSuggestionPrefixWeight
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Tag.Name < suggestions[j].Tag.Name
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// Thresholds for reporting two tags as likely duplicates
+const (
+	DuplicateTagMinSimilarity = 0.5 // trigram similarity, as computed by pg_trgm
+	DuplicateTagMaxDistance   = 2   // edit distance, further limited to a third of the shorter name
+
+	DefaultDuplicateTagLimit = 50
+	MaxDuplicateTagLimit     = 200
+)
+
+// TagSynonym maps an alternative tag name to its canonical tag
+type TagSynonym struct {
+	Synonym   string    `json:"synonym" db:"synonym"`
+	TagID     string    `json:"tag_id" db:"tag_id"`
+	CreatedBy string    `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// TagRef identifies a tag at a specific version
+type TagRef struct {
+	ID      string `json:"id"`
+	Version int64  `json:"version"`
+}
+
+// TagDuplicate is a pair of tags whose names probably mean the same thing. Tag is the one used
+// by more tasks, and so the suggested canonical tag.
+type TagDuplicate struct {
+	Tag                *Tag    `json:"tag"`
+	TaskCount          int64   `json:"task_count"`
+	Duplicate          *Tag    `json:"duplicate"`
+	DuplicateTaskCount int64   `json:"duplicate_task_count"`
+	Distance           int     `json:"distance"`
+	Similarity         float64 `json:"similarity"`
+}
+
+// ToProtobuf converts a TagSynonym to protobuf
+func (s *TagSynonym) ToProtobuf() *pb.TagSynonym {
+	return &pb.TagSynonym{
+		Synonym:   s.Synonym,
+		TagId:     s.TagID,
+		CreatedBy: s.CreatedBy,
+		CreatedAt: TimeToProtobuf(s.CreatedAt),
+	}
+}
+
+// ToProtobuf converts a TagDuplicate to protobuf
+func (d *TagDuplicate) ToProtobuf() *pb.DuplicateTagPair {
+	return &pb.DuplicateTagPair{
+		Tag:                d.Tag.ToProtobuf(),
+		TaskCount:          d.TaskCount,
+		Duplicate:          d.Duplicate.ToProtobuf(),
+		DuplicateTaskCount: d.DuplicateTaskCount,
+		EditDistance:       int32(d.Distance),
+		Similarity:         d.Similarity,
+	}
+}
+
+// FindDuplicateTags pairs up tags with similar names. Candidates share at least one trigram;
+// a pair is reported when its trigram similarity or edit distance is within the thresholds.
+// Pairs are ordered most similar first.
+func FindDuplicateTags(tags []*Tag, taskCounts map[string]int64) []*TagDuplicate {
+	grams := make([]map[string]bool, len(tags))
+	index := make(map[string][]int)
+	for i, tag := range tags {
+		grams[i] = Trigrams(tag.Name)
+		for gram := range grams[i] {
+			index[gram] = append(index[gram], i)
+		}
+	}
+
+	var duplicates []*TagDuplicate
+	for i := range tags {
+		// Count shared trigrams with every later tag
+		shared := make(map[int]int)
+		for gram := range grams[i] {
+			for _, j := range index[gram] {
+				if j > i {
+					shared[j]++
+				}
+			}
+		}
+
+		for j, common := range shared {
+			a, b := tags[i], tags[j]
+			similarity := float64(common) / float64(len(grams[i])+len(grams[j])-common)
+			distance := EditDistance(a.Name, b.Name)
+			limit := min(DuplicateTagMaxDistance, min(len(a.Name), len(b.Name))/3)
+			if similarity < DuplicateTagMinSimilarity && distance > limit {
+				continue
+			}
+
+			if taskCounts[b.ID] > taskCounts[a.ID] {
+				a, b = b, a
+			}
+			duplicates = append(duplicates, &TagDuplicate{
+				Tag:                a,
+				TaskCount:          taskCounts[a.ID],
+				Duplicate:          b,
+				DuplicateTaskCount: taskCounts[b.ID],
+				Distance:           distance,
+				Similarity:         similarity,
+			})
+		}
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		di, dj := duplicates[i], duplicates[j]
+		if di.Similarity != dj.Similarity {
+			return di.Similarity > dj.Similarity
+		}
+		if di.Distance != dj.Distance {
+			return di.Distance < dj.Distance
+		}
+		if di.Tag.Name != dj.Tag.Name {
+			return di.Tag.Name < dj.Tag.Name
+		}
+		return di.Duplicate.Name < dj.Duplicate.Name
+	})
+	return duplicates
+}
+
+// Trigrams returns the trigrams of a name the way pg_trgm does: each word is lowercased and
+// padded with two spaces in front and one behind. Hyphens and underscores separate words.
+func Trigrams(name string) map[string]bool {
+	grams := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			grams[string(padded[i:i+3])] = true
+		}
+	}
+	return grams
+}
+
+// EditDistance returns the Levenshtein distance between two strings
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -13,8 +13,10 @@ const (
 	TaskStatusUnspecified TaskStatus = "unspecified"
 	TaskStatusOpen        TaskStatus = "OPEN"
 	TaskStatusInProgress  TaskStatus = "IN_PROGRESS"
+	TaskStatusInReview    TaskStatus = "IN_REVIEW"
 	TaskStatusCompleted   TaskStatus = "COMPLETED"
 	TaskStatusCancelled   TaskStatus = "CANCELLED"
+	TaskStatusBlocked     TaskStatus = "BLOCKED"
 )
 
 // TaskPriority represents task importance
@@ -37,6 +39,10 @@ type Task struct {
 	Status      TaskStatus   `json:"status" db:"status"`
 	Priority    TaskPriority `json:"priority" db:"priority"`
 	DueDate     *time.Time   `json:"due_date,omitempty" db:"due_date"`
+	ParentID    *string      `json:"parent_id,omitempty" db:"parent_id"`
+	SeriesID    *string      `json:"series_id,omitempty" db:"series_id"`
+	SeriesIndex int          `json:"series_index,omitempty" db:"series_index"`
+	CreatorID   string       `json:"creator_id,omitempty" db:"creator_id"` // Empty for tasks created before creators were recorded
 	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
 	Version     int64        `json:"version" db:"version"`
@@ -44,9 +50,10 @@ type Task struct {
 	DeletedAt   *time.Time   `json:"deleted_at,omitempty" db:"deleted_at"`
 
 	// Related data (loaded separately)
-	Categories []Category         `json:"categories,omitempty"`
-	Tags       []Tag              `json:"tags,omitempty"`
-	History    []TaskHistoryEntry `json:"history,omitempty"`
+	Categories   []Category         `json:"categories,omitempty"`
+	Tags         []Tag              `json:"tags,omitempty"`
+	History      []TaskHistoryEntry `json:"history,omitempty"`
+	Participants []TaskParticipant  `json:"participants,omitempty"`
 }
 
 // Category and Tag are defined in separate files to avoid circular dependencies
@@ -64,41 +71,14 @@ type TaskHistoryEntry struct {
 
 // ToProtobuf converts domain Task to protobuf Task
 func (t *Task) ToProtobuf() *pb.Task {
-	var status pb.TaskStatus
-	switch t.Status {
-	case TaskStatusOpen:
-		status = pb.TaskStatus_TASK_STATUS_OPEN
-	case TaskStatusInProgress:
-		status = pb.TaskStatus_TASK_STATUS_IN_PROGRESS
-	case TaskStatusCompleted:
-		status = pb.TaskStatus_TASK_STATUS_COMPLETED
-	case TaskStatusCancelled:
-		status = pb.TaskStatus_TASK_STATUS_COMPLETED // Use completed for now until proto is updated
-	default:
-		status = pb.TaskStatus_TASK_STATUS_UNSPECIFIED
-	}
-
-	var priority pb.TaskPriority
-	switch t.Priority {
-	case TaskPriorityLow:
-		priority = pb.TaskPriority_TASK_PRIORITY_LOW
-	case TaskPriorityMedium:
-		priority = pb.TaskPriority_TASK_PRIORITY_MEDIUM
-	case TaskPriorityHigh:
-		priority = pb.TaskPriority_TASK_PRIORITY_HIGH
-	case TaskPriorityUrgent:
-		priority = pb.TaskPriority_TASK_PRIORITY_URGENT
-	default:
-		priority = pb.TaskPriority_TASK_PRIORITY_UNSPECIFIED
-	}
-
 	task := &pb.Task{
 		Id:          t.ID,
 		Title:       t.Title,
 		Description: t.Description,
 		AssigneeId:  t.AssigneeID,
-		Status:      status,
-		Priority:    priority,
+		CreatorId:   t.CreatorID,
+		Status:      taskStatusToProtobuf(t.Status),
+		Priority:    taskPriorityToProtobuf(t.Priority),
 		CreatedAt:   TimeToProtobuf(t.CreatedAt),
 		UpdatedAt:   TimeToProtobuf(t.UpdatedAt),
 		Version:     t.Version,
@@ -108,6 +88,13 @@ func (t *Task) ToProtobuf() *pb.Task {
 	if t.DueDate != nil {
 		task.DueDate = TimeToProtobuf(*t.DueDate)
 	}
+	if t.ParentID != nil {
+		task.ParentId = *t.ParentID
+	}
+	if t.SeriesID != nil {
+		task.SeriesId = *t.SeriesID
+		task.SeriesIndex = int32(t.SeriesIndex)
+	}
 
 	// Convert categories
 	for _, cat := range t.Categories {
@@ -124,9 +111,134 @@ func (t *Task) ToProtobuf() *pb.Task {
 		task.History = append(task.History, entry.ToProtobuf())
 	}
 
+	// Convert participants
+	for _, participant := range t.Participants {
+		task.Participants = append(task.Participants, participant.ToProtobuf())
+	}
+
 	return task
 }
 
+func taskStatusToProtobuf(status TaskStatus) pb.TaskStatus {
+	switch status {
+	case TaskStatusOpen:
+		return pb.TaskStatus_TASK_STATUS_OPEN
+	case TaskStatusInProgress:
+		return pb.TaskStatus_TASK_STATUS_IN_PROGRESS
+	case TaskStatusInReview:
+		return pb.TaskStatus_TASK_STATUS_IN_REVIEW
+	case TaskStatusCompleted:
+		return pb.TaskStatus_TASK_STATUS_COMPLETED
+	case TaskStatusCancelled:
+		return pb.TaskStatus_TASK_STATUS_CANCELLED
+	case TaskStatusBlocked:
+		return pb.TaskStatus_TASK_STATUS_BLOCKED
+	default:
+		return pb.TaskStatus_TASK_STATUS_UNSPECIFIED
+	}
+}
+
+func taskPriorityToProtobuf(priority TaskPriority) pb.TaskPriority {
+	switch priority {
+	case TaskPriorityLow:
+		return pb.TaskPriority_TASK_PRIORITY_LOW
+	case TaskPriorityMedium:
+		return pb.TaskPriority_TASK_PRIORITY_MEDIUM
+	case TaskPriorityHigh:
+		return pb.TaskPriority_TASK_PRIORITY_HIGH
+	case TaskPriorityUrgent:
+		return pb.TaskPriority_TASK_PRIORITY_URGENT
+	default:
+		return pb.TaskPriority_TASK_PRIORITY_UNSPECIFIED
+	}
+}
+
+// TaskStatusFromProtobuf converts a protobuf TaskStatus to the domain status
+func TaskStatusFromProtobuf(status pb.TaskStatus) TaskStatus {
+	switch status {
+	case pb.TaskStatus_TASK_STATUS_OPEN:
+		return TaskStatusOpen
+	case pb.TaskStatus_TASK_STATUS_IN_PROGRESS:
+		return TaskStatusInProgress
+	case pb.TaskStatus_TASK_STATUS_IN_REVIEW:
+		return TaskStatusInReview
+	case pb.TaskStatus_TASK_STATUS_COMPLETED:
+		return TaskStatusCompleted
+	case pb.TaskStatus_TASK_STATUS_CANCELLED:
+		return TaskStatusCancelled
+	// Older mobile clients mark tasks they cannot do as undoable
+	case pb.TaskStatus_TASK_STATUS_BLOCKED, pb.TaskStatus_TASK_STATUS_UNDOABLE: //nolint:staticcheck
+		return TaskStatusBlocked
+	default:
+		return TaskStatusUnspecified
+	}
+}
+
+// TaskFromProtobuf converts protobuf Task to domain Task. Categories and tags carry only
+// their IDs, participants only their users and roles, and history is left out. The creator
+// and the series link are carried over, though updates cannot change them.
+func TaskFromProtobuf(pbTask *pb.Task) *Task {
+	task := &Task{
+		ID:          pbTask.Id,
+		Title:       pbTask.Title,
+		Description: pbTask.Description,
+		AssigneeID:  pbTask.AssigneeId,
+		CreatorID:   pbTask.CreatorId,
+		Status:      TaskStatusFromProtobuf(pbTask.Status),
+		Priority:    TaskPriorityFromProtobuf(pbTask.Priority),
+		Version:     pbTask.Version,
+		IsDeleted:   pbTask.IsDeleted,
+	}
+
+	if pbTask.DueDate != nil {
+		dueDate := pbTask.DueDate.AsTime()
+		task.DueDate = &dueDate
+	}
+	if pbTask.ParentId != "" {
+		parentID := pbTask.ParentId
+		task.ParentID = &parentID
+	}
+	if pbTask.SeriesId != "" {
+		seriesID := pbTask.SeriesId
+		task.SeriesID = &seriesID
+		task.SeriesIndex = int(pbTask.SeriesIndex)
+	}
+	if pbTask.CreatedAt != nil {
+		task.CreatedAt = pbTask.CreatedAt.AsTime()
+	}
+	if pbTask.UpdatedAt != nil {
+		task.UpdatedAt = pbTask.UpdatedAt.AsTime()
+	}
+
+	for _, id := range pbTask.CategoryIds {
+		task.Categories = append(task.Categories, Category{ID: id})
+	}
+	for _, id := range pbTask.TagIds {
+		task.Tags = append(task.Tags, Tag{ID: id})
+	}
+	for _, participant := range pbTask.Participants {
+		task.Participants = append(task.Participants, TaskParticipantFromProtobuf(participant))
+	}
+
+	return task
+}
+
+// TaskPriorityFromProtobuf converts a protobuf TaskPriority to the domain priority
+func TaskPriorityFromProtobuf(priority pb.TaskPriority) TaskPriority {
+	switch priority {
+	case pb.TaskPriority_TASK_PRIORITY_LOW:
+		return TaskPriorityLow
+	case pb.TaskPriority_TASK_PRIORITY_MEDIUM:
+		return TaskPriorityMedium
+	case pb.TaskPriority_TASK_PRIORITY_HIGH:
+		return TaskPriorityHigh
+	case pb.TaskPriority_TASK_PRIORITY_URGENT:
+		return TaskPriorityUrgent
+	default:
+		return TaskPriorityUnspecified
+	}
+}
+
 // ToProtobuf converts TaskHistoryEntry to protobuf
 func (h *TaskHistoryEntry) ToProtobuf() *pb.TaskHistoryEntry {
 	return &pb.TaskHistoryEntry{
@@ -154,3 +266,23 @@ func (t *Task) IsValid() error {
 	}
 	return nil
 }
+
+// EditableBy reports whether a user may change the task: administrators, whoever created it,
+// and its participants may
+func (t *Task) EditableBy(userID string, role UserRole) bool {
+	if role == UserRoleAdmin {
+		return true
+	}
+	if userID == "" {
+		return false
+	}
+	if t.CreatorID == userID || t.AssigneeID == userID {
+		return true
+	}
+	for _, participant := range t.Participants {
+		if participant.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// MaxCriticalPathTasks bounds the number of tasks a critical path is computed over
+const MaxCriticalPathTasks = 1000
+
+// TaskDependency records that a task is blocked by another task
+type TaskDependency struct {
+	TaskID    string    `json:"task_id" db:"task_id"`
+	BlockerID string    `json:"blocker_id" db:"blocker_id"`
+	CreatorID string    `json:"creator_id" db:"creator_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// IsOpenBlocker reports whether a blocking task still holds up the tasks it blocks
+func IsOpenBlocker(blocker *Task) bool {
+	return blocker.Status != TaskStatusCompleted && blocker.Status != TaskStatusCancelled
+}
+
+// RequiresClosedBlockers reports whether moving a task to the status starts or completes
+// its work, which waits for every blocker to be done
+func RequiresClosedBlockers(status TaskStatus) bool {
+	switch status {
+	case TaskStatusInProgress, TaskStatusInReview, TaskStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// CriticalPath is the chain of dependent tasks that finishes last
+type CriticalPath struct {
+	// Tasks runs from the first blocker to the last dependent
+	Tasks []*Task `json:"tasks"`
+	// ProjectedFinish is the latest due date along the path, nil when no task on it has one
+	ProjectedFinish *time.Time `json:"projected_finish,omitempty"`
+	// Conflicts are the links whose blocker is due after the task it blocks
+	Conflicts []*TaskDependency `json:"conflicts,omitempty"`
+}
+
+type pathStep struct {
+	finish *time.Time
+	length int
+	prev   string
+}
+
+// laterThan orders steps by finish, then by path length; a step with a finish comes after one without
+func (s pathStep) laterThan(other pathStep) bool {
+	switch {
+	case s.finish == nil && other.finish != nil:
+		return false
+	case s.finish != nil && other.finish == nil:
+		return true
+	case s.finish != nil && !s.finish.Equal(*other.finish):
+		return s.finish.After(*other.finish)
+	}
+	return s.length > other.length
+}
+
+// ComputeCriticalPath finds the critical path through the tasks. A task cannot finish before
+// its own due date nor before its blockers do, so its projected finish is the later of the two;
+// the path ends at the task projected to finish last and follows, at each step, the blocker that
+// holds it up the longest. Only links between the given tasks count, and cancelled tasks are left
+// out since they no longer block anything.
+func ComputeCriticalPath(tasks []*Task, dependencies []*TaskDependency) *CriticalPath {
+	byID := make(map[string]*Task, len(tasks))
+	var ids []string
+	for _, task := range tasks {
+		if task.Status == TaskStatusCancelled {
+			continue
+		}
+		if _, ok := byID[task.ID]; !ok {
+			ids = append(ids, task.ID)
+		}
+		byID[task.ID] = task
+	}
+	sort.Strings(ids)
+
+	result := &CriticalPath{}
+	blockers := make(map[string][]string)
+	for _, dep := range dependencies {
+		task, ok := byID[dep.TaskID]
+		if !ok {
+			continue
+		}
+		blocker, ok := byID[dep.BlockerID]
+		if !ok {
+			continue
+		}
+		blockers[dep.TaskID] = append(blockers[dep.TaskID], dep.BlockerID)
+		if task.DueDate != nil && blocker.DueDate != nil && blocker.DueDate.After(*task.DueDate) {
+			result.Conflicts = append(result.Conflicts, dep)
+		}
+	}
+	if len(ids) == 0 {
+		return result
+	}
+
+	steps := make(map[string]pathStep, len(ids))
+	visiting := make(map[string]bool)
+	var visit func(id string) pathStep
+	visit = func(id string) pathStep {
+		if step, ok := steps[id]; ok {
+			return step
+		}
+		// A cycle cannot be stored, but a link on it would otherwise recurse forever
+		if visiting[id] {
+			return pathStep{}
+		}
+		visiting[id] = true
+
+		step := pathStep{finish: byID[id].DueDate, length: 1}
+		var held pathStep
+		sort.Strings(blockers[id])
+		for _, blockerID := range blockers[id] {
+			candidate := visit(blockerID)
+			if candidate.length == 0 {
+				continue
+			}
+			if held.length == 0 || candidate.laterThan(held) {
+				held = candidate
+				step.prev = blockerID
+			}
+		}
+		if held.length > 0 {
+			step.length = held.length + 1
+			if held.finish != nil && (step.finish == nil || held.finish.After(*step.finish)) {
+				step.finish = held.finish
+			}
+		}
+
+		visiting[id] = false
+		steps[id] = step
+		return step
+	}
+
+	var end string
+	for _, id := range ids {
+		if end == "" || visit(id).laterThan(visit(end)) {
+			end = id
+		}
+	}
+
+	result.ProjectedFinish = steps[end].finish
+	for id := end; id != ""; id = steps[id].prev {
+		result.Tasks = append([]*Task{byID[id]}, result.Tasks...)
+	}
+	return result
+}
+
+// ToProtobuf converts a TaskDependency to protobuf
+func (d *TaskDependency) ToProtobuf() *pb.TaskDependency {
+	return &pb.TaskDependency{
+		TaskId:    d.TaskID,
+		BlockerId: d.BlockerID,
+		CreatorId: d.CreatorID,
+		CreatedAt: TimeToProtobuf(d.CreatedAt),
+	}
+}
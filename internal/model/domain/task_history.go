@@ -3,6 +3,8 @@ package domain
 import (
 	"encoding/json"
 	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
 )
 
 // TaskHistoryAction represents different types of task actions
@@ -82,3 +84,15 @@ func (th *TaskHistory) Validate() error {
 	}
 	return nil
 }
+
+// ToProtobuf converts a TaskHistory entry to protobuf
+func (th *TaskHistory) ToProtobuf() *pb.TaskHistoryEntry {
+	return &pb.TaskHistoryEntry{
+		Id:        th.ID,
+		TaskId:    th.TaskID,
+		Action:    string(th.Action),
+		ActorId:   th.ActorID,
+		Timestamp: TimeToProtobuf(th.Timestamp),
+		Details:   string(th.Details),
+	}
+}
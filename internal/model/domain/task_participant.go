@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// TaskParticipantRole is the part a user plays in a task
+type TaskParticipantRole string
+
+const (
+	TaskRoleOwner        TaskParticipantRole = "OWNER"
+	TaskRoleAssignee     TaskParticipantRole = "ASSIGNEE"
+	TaskRoleReviewer     TaskParticipantRole = "REVIEWER"
+	TaskRoleCollaborator TaskParticipantRole = "COLLABORATOR"
+)
+
+// TaskParticipantRoles lists every participant role
+var TaskParticipantRoles = []TaskParticipantRole{TaskRoleOwner, TaskRoleAssignee, TaskRoleReviewer, TaskRoleCollaborator}
+
+// TaskParticipant is a user taking part in a task in a role. A user may hold several roles
+// on the same task. The task's AssigneeID is its primary assignee, who always holds the
+// ASSIGNEE role.
+type TaskParticipant struct {
+	UserID  string              `json:"user_id" db:"user_id"`
+	Role    TaskParticipantRole `json:"role" db:"role"`
+	AddedAt time.Time           `json:"added_at" db:"added_at"`
+}
+
+// IsValid validates the participant
+func (p *TaskParticipant) IsValid() error {
+	if p.UserID == "" {
+		return ErrInvalidInput("participant user is required")
+	}
+	if !IsTaskParticipantRole(p.Role) {
+		return ErrInvalidInput(fmt.Sprintf("unknown participant role %q", p.Role))
+	}
+	return nil
+}
+
+// IsTaskParticipantRole reports whether role is a known participant role
+func IsTaskParticipantRole(role TaskParticipantRole) bool {
+	for _, known := range TaskParticipantRoles {
+		if role == known {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole reports whether the user holds the role on the task. The primary assignee holds
+// the ASSIGNEE role even when the participants were not loaded.
+func (t *Task) HasRole(userID string, role TaskParticipantRole) bool {
+	if userID == "" {
+		return false
+	}
+	if role == TaskRoleAssignee && t.AssigneeID == userID {
+		return true
+	}
+	for _, participant := range t.Participants {
+		if participant.UserID == userID && participant.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ParticipantsWithRole returns the users holding the role on the task, longest-standing first
+func (t *Task) ParticipantsWithRole(role TaskParticipantRole) []string {
+	var userIDs []string
+	for _, participant := range t.Participants {
+		if participant.Role == role {
+			userIDs = append(userIDs, participant.UserID)
+		}
+	}
+	return userIDs
+}
+
+// ParticipantsByRole returns the users of each role, sorted, as recorded in task history
+func ParticipantsByRole(participants []TaskParticipant) map[string][]string {
+	byRole := make(map[string][]string)
+	for _, participant := range participants {
+		byRole[string(participant.Role)] = append(byRole[string(participant.Role)], participant.UserID)
+	}
+	for _, userIDs := range byRole {
+		sort.Strings(userIDs)
+	}
+	return byRole
+}
+
+// participantsFromHistory reads a value written by ParticipantsByRole back from history details
+func participantsFromHistory(value interface{}) map[string][]string {
+	byRole := make(map[string][]string)
+	if value == nil {
+		return byRole
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return byRole
+	}
+	_ = json.Unmarshal(data, &byRole)
+	return byRole
+}
+
+// ToProtobuf converts a TaskParticipant to protobuf
+func (p *TaskParticipant) ToProtobuf() *pb.TaskParticipant {
+	participant := &pb.TaskParticipant{
+		UserId: p.UserID,
+		Role:   taskParticipantRoleToProtobuf(p.Role),
+	}
+	if !p.AddedAt.IsZero() {
+		participant.AddedAt = TimeToProtobuf(p.AddedAt)
+	}
+	return participant
+}
+
+// TaskParticipantFromProtobuf converts a protobuf participant. An unspecified role is left
+// empty and rejected by TaskParticipant.IsValid.
+func TaskParticipantFromProtobuf(participant *pb.TaskParticipant) TaskParticipant {
+	return TaskParticipant{
+		UserID: participant.GetUserId(),
+		Role:   TaskParticipantRoleFromProtobuf(participant.GetRole()),
+	}
+}
+
+// TaskParticipantRoleFromProtobuf converts a protobuf role; unspecified converts to empty
+func TaskParticipantRoleFromProtobuf(role pb.TaskParticipantRole) TaskParticipantRole {
+	switch role {
+	case pb.TaskParticipantRole_TASK_PARTICIPANT_ROLE_OWNER:
+		return TaskRoleOwner
+	case pb.TaskParticipantRole_TASK_PARTICIPANT_ROLE_ASSIGNEE:
+		return TaskRoleAssignee
+	case pb.TaskParticipantRole_TASK_PARTICIPANT_ROLE_REVIEWER:
+		return TaskRoleReviewer
+	case pb.TaskParticipantRole_TASK_PARTICIPANT_ROLE_COLLABORATOR:
+		return TaskRoleCollaborator
+	default:
+		return ""
+	}
+}
+
+// TaskParticipantRolesFromProtobuf converts protobuf roles, dropping unspecified ones
+func TaskParticipantRolesFromProtobuf(roles []pb.TaskParticipantRole) []TaskParticipantRole {
+	var converted []TaskParticipantRole
+	for _, role := range roles {
+		if r := TaskParticipantRoleFromProtobuf(role); r != "" {
+			converted = append(converted, r)
+		}
+	}
+	return converted
+}
+
+func taskParticipantRolesToProtobuf(roles []TaskParticipantRole) []pb.TaskParticipantRole {
+	var converted []pb.TaskParticipantRole
+	for _, role := range roles {
+		converted = append(converted, taskParticipantRoleToProtobuf(role))
+	}
+	return converted
+}
+
+func taskParticipantRoleToProtobuf(role TaskParticipantRole) pb.TaskParticipantRole {
+	switch role {
+	case TaskRoleOwner:
+		return pb.TaskParticipantRole_TASK_PARTICIPANT_ROLE_OWNER
+	case TaskRoleAssignee:
+		return pb.TaskParticipantRole_TASK_PARTICIPANT_ROLE_ASSIGNEE
+	case TaskRoleReviewer:
+		return pb.TaskParticipantRole_TASK_PARTICIPANT_ROLE_REVIEWER
+	case TaskRoleCollaborator:
+		return pb.TaskParticipantRole_TASK_PARTICIPANT_ROLE_COLLABORATOR
+	default:
+		return pb.TaskParticipantRole_TASK_PARTICIPANT_ROLE_UNSPECIFIED
+	}
+}
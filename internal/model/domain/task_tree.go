@@ -0,0 +1,121 @@
+package domain
+
+import (
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// SubtaskRules control how a parent task's status follows its subtasks
+type SubtaskRules struct {
+	// BlockParentCompletion refuses to complete a task while any of its subtasks is open
+	BlockParentCompletion bool
+	// AutoCompleteParent completes a parent once its last open subtask is completed
+	AutoCompleteParent bool
+}
+
+// TaskNode is a task in a task tree. Depth is 1 for the root of the tree.
+type TaskNode struct {
+	Task     *Task        `json:"task"`
+	Depth    int          `json:"depth"`
+	Children []*TaskNode  `json:"children,omitempty"`
+	Progress TaskProgress `json:"progress"`
+}
+
+// TaskProgress counts the completed subtasks of a task at any depth. Cancelled subtasks,
+// and everything under them, count toward neither number.
+type TaskProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// Percent returns the share of completed subtasks. A task without subtasks is done or not.
+func (p TaskProgress) Percent(status TaskStatus) int {
+	if p.Total == 0 {
+		if status == TaskStatusCompleted {
+			return 100
+		}
+		return 0
+	}
+	return p.Completed * 100 / p.Total
+}
+
+// BuildTaskTree links nodes listed parents first into a tree under the first node, which
+// must be the root, and computes the progress of every node. Nodes whose parent is not
+// listed are left out with their subtrees.
+func BuildTaskTree(nodes []*TaskNode) *TaskNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	root := nodes[0]
+	root.Children = nil
+	byID := map[string]*TaskNode{root.Task.ID: root}
+	for _, node := range nodes[1:] {
+		node.Children = nil
+		if node.Task.ParentID == nil {
+			continue
+		}
+		parent, ok := byID[*node.Task.ParentID]
+		if !ok {
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+		byID[node.Task.ID] = node
+	}
+
+	root.computeProgress()
+	return root
+}
+
+func (n *TaskNode) computeProgress() {
+	n.Progress = TaskProgress{}
+	for _, child := range n.Children {
+		child.computeProgress()
+		// Work under a cancelled subtask is not expected to be done
+		if child.Task.Status == TaskStatusCancelled {
+			continue
+		}
+		n.Progress.Completed += child.Progress.Completed
+		n.Progress.Total += child.Progress.Total + 1
+		if child.Task.Status == TaskStatusCompleted {
+			n.Progress.Completed++
+		}
+	}
+}
+
+// OpenDescendants returns the subtasks at any depth that are neither completed nor
+// cancelled, leaving out those under a cancelled subtask
+func (n *TaskNode) OpenDescendants() []*Task {
+	var open []*Task
+	for _, child := range n.Children {
+		if child.Task.Status == TaskStatusCancelled {
+			continue
+		}
+		if child.Task.Status != TaskStatusCompleted {
+			open = append(open, child.Task)
+		}
+		open = append(open, child.OpenDescendants()...)
+	}
+	return open
+}
+
+// ToProtobuf converts a TaskProgress to protobuf for a task with the given status
+func (p TaskProgress) ToProtobuf(status TaskStatus) *pb.TaskProgress {
+	return &pb.TaskProgress{
+		CompletedCount: int32(p.Completed),
+		TotalCount:     int32(p.Total),
+		Percent:        int32(p.Percent(status)),
+	}
+}
+
+// ToProtobuf converts a TaskNode and its subtree to protobuf
+func (n *TaskNode) ToProtobuf() *pb.TaskNode {
+	node := &pb.TaskNode{
+		Task:     n.Task.ToProtobuf(),
+		Depth:    int32(n.Depth),
+		Progress: n.Progress.ToProtobuf(n.Task.Status),
+	}
+	for _, child := range n.Children {
+		node.Children = append(node.Children, child.ToProtobuf())
+	}
+	return node
+}
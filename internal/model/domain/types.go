@@ -14,6 +14,7 @@ type Category struct {
 	Color       string     `json:"color" db:"color"`
 	ParentID    *string    `json:"parent_id,omitempty" db:"parent_id"`
 	IsPublic    bool       `json:"is_public" db:"is_public"`
+	WorkflowID  *string    `json:"workflow_id,omitempty" db:"workflow_id"` // nil uses the parent's workflow
 	CreatorID   string     `json:"creator_id" db:"creator_id"`
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
@@ -53,6 +54,9 @@ func (c *Category) ToProtobuf() *pb.Category {
 	if c.ParentID != nil {
 		category.ParentId = *c.ParentID
 	}
+	if c.WorkflowID != nil {
+		category.WorkflowId = *c.WorkflowID
+	}
 
 	return category
 }
@@ -15,6 +15,10 @@ const (
 	UserRoleAdmin       UserRole = "admin"
 )
 
+// SystemUserID is the seeded "System" user, recorded as the actor of changes
+// made without an authenticated user
+const SystemUserID = "00000000-0000-0000-0000-000000000002"
+
 // User represents a user in the system
 type User struct {
 	ID        string     `json:"id" db:"id"`
@@ -30,21 +34,11 @@ type User struct {
 
 // ToProtobuf converts domain User to protobuf User
 func (u *User) ToProtobuf() *pb.User {
-	var role pb.UserRole
-	switch u.Role {
-	case UserRoleAdmin:
-		role = pb.UserRole_USER_ROLE_ADMIN
-	case UserRoleUser:
-		role = pb.UserRole_USER_ROLE_USER
-	default:
-		role = pb.UserRole_USER_ROLE_UNSPECIFIED
-	}
-
 	return &pb.User{
 		Id:        u.ID,
 		Name:      u.Name,
 		Email:     u.Email,
-		Role:      role,
+		Role:      userRoleToProtobuf(u.Role),
 		CreatedAt: TimeToProtobuf(u.CreatedAt),
 		UpdatedAt: TimeToProtobuf(u.UpdatedAt),
 		Version:   u.Version,
@@ -52,23 +46,36 @@ func (u *User) ToProtobuf() *pb.User {
 	}
 }
 
-// UserFromProtobuf converts protobuf User to domain User
-func UserFromProtobuf(pbUser *pb.User) *User {
-	var role UserRole
-	switch pbUser.Role {
+func userRoleToProtobuf(role UserRole) pb.UserRole {
+	switch role {
+	case UserRoleAdmin:
+		return pb.UserRole_USER_ROLE_ADMIN
+	case UserRoleUser:
+		return pb.UserRole_USER_ROLE_USER
+	default:
+		return pb.UserRole_USER_ROLE_UNSPECIFIED
+	}
+}
+
+// UserRoleFromProtobuf converts a protobuf UserRole to the domain role
+func UserRoleFromProtobuf(role pb.UserRole) UserRole {
+	switch role {
 	case pb.UserRole_USER_ROLE_ADMIN:
-		role = UserRoleAdmin
+		return UserRoleAdmin
 	case pb.UserRole_USER_ROLE_USER:
-		role = UserRoleUser
+		return UserRoleUser
 	default:
-		role = UserRoleUnspecified
+		return UserRoleUnspecified
 	}
+}
 
+// UserFromProtobuf converts protobuf User to domain User
+func UserFromProtobuf(pbUser *pb.User) *User {
 	user := &User{
 		ID:        pbUser.Id,
 		Name:      pbUser.Name,
 		Email:     pbUser.Email,
-		Role:      role,
+		Role:      UserRoleFromProtobuf(pbUser.Role),
 		Version:   pbUser.Version,
 		IsDeleted: pbUser.IsDeleted,
 	}
@@ -0,0 +1,370 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/todo-app/services/admin-service/proto/gen/go/todo/v1"
+)
+
+// WorkflowGuard is a condition a status transition requires
+type WorkflowGuard string
+
+const (
+	WorkflowGuardHasAssignee      WorkflowGuard = "HAS_ASSIGNEE"
+	WorkflowGuardHasDueDate       WorkflowGuard = "HAS_DUE_DATE"
+	WorkflowGuardCallerIsAssignee WorkflowGuard = "CALLER_IS_ASSIGNEE"
+	WorkflowGuardCallerIsAdmin    WorkflowGuard = "CALLER_IS_ADMIN"
+)
+
+// Task fields a workflow state can require
+const (
+	WorkflowFieldDescription = "description"
+	WorkflowFieldDueDate     = "due_date"
+	WorkflowFieldCategories  = "categories"
+	WorkflowFieldTags        = "tags"
+)
+
+// WorkflowFields are the task fields a workflow state can require
+var WorkflowFields = []string{WorkflowFieldDescription, WorkflowFieldDueDate, WorkflowFieldCategories, WorkflowFieldTags}
+
+// WorkflowStatuses are the task statuses a workflow can use
+var WorkflowStatuses = []TaskStatus{TaskStatusOpen, TaskStatusInProgress, TaskStatusInReview, TaskStatusBlocked, TaskStatusCompleted, TaskStatusCancelled}
+
+// MaxWorkflowNameLength bounds workflow names
+const MaxWorkflowNameLength = 100
+
+// DefaultWorkflowName names the built-in workflow used until another one is made the default
+const DefaultWorkflowName = "Default"
+
+// WorkflowState is a status of a workflow with the fields a task needs to enter it
+type WorkflowState struct {
+	Status         TaskStatus `json:"status"`
+	RequiredFields []string   `json:"required_fields,omitempty"`
+}
+
+// WorkflowTransition allows tasks to move from one status to another when every guard holds
+type WorkflowTransition struct {
+	From   TaskStatus      `json:"from"`
+	To     TaskStatus      `json:"to"`
+	Guards []WorkflowGuard `json:"guards,omitempty"`
+}
+
+// Workflow is a named set of task statuses and the transitions between them.
+// States and transitions are stored together as the workflow definition.
+type Workflow struct {
+	ID          string               `json:"id" db:"id"`
+	Name        string               `json:"name" db:"name"`
+	Description string               `json:"description" db:"description"`
+	States      []WorkflowState      `json:"states"`
+	Transitions []WorkflowTransition `json:"transitions"`
+	IsDefault   bool                 `json:"is_default" db:"is_default"`
+	CreatorID   string               `json:"creator_id" db:"creator_id"`
+	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at" db:"updated_at"`
+	Version     int64                `json:"version" db:"version"`
+	IsDeleted   bool                 `json:"is_deleted" db:"is_deleted"`
+	DeletedAt   *time.Time           `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// WorkflowDefinition is the stored form of a workflow's states and transitions
+type WorkflowDefinition struct {
+	States      []WorkflowState      `json:"states"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// DefaultWorkflow returns the built-in workflow. It keeps the transitions tasks had before
+// workflows could be configured: any change between open, in progress, completed and
+// cancelled, except from completed or cancelled to each other. Open and in progress tasks can
+// be blocked, and blocked tasks resume or are cancelled.
+func DefaultWorkflow() *Workflow {
+	transition := func(from TaskStatus, to ...TaskStatus) []WorkflowTransition {
+		transitions := make([]WorkflowTransition, len(to))
+		for i, status := range to {
+			transitions[i] = WorkflowTransition{From: from, To: status}
+		}
+		return transitions
+	}
+
+	var transitions []WorkflowTransition
+	transitions = append(transitions, transition(TaskStatusOpen, TaskStatusInProgress, TaskStatusCompleted, TaskStatusCancelled, TaskStatusBlocked)...)
+	transitions = append(transitions, transition(TaskStatusInProgress, TaskStatusCompleted, TaskStatusOpen, TaskStatusCancelled, TaskStatusBlocked)...)
+	transitions = append(transitions, transition(TaskStatusBlocked, TaskStatusOpen, TaskStatusInProgress, TaskStatusCancelled)...)
+	transitions = append(transitions, transition(TaskStatusCompleted, TaskStatusOpen, TaskStatusInProgress)...)
+	transitions = append(transitions, transition(TaskStatusCancelled, TaskStatusOpen, TaskStatusInProgress)...)
+
+	return &Workflow{
+		Name:        DefaultWorkflowName,
+		Description: "Built-in workflow used until another one is made the default",
+		States: []WorkflowState{
+			{Status: TaskStatusOpen},
+			{Status: TaskStatusInProgress},
+			{Status: TaskStatusBlocked},
+			{Status: TaskStatusCompleted},
+			{Status: TaskStatusCancelled},
+		},
+		Transitions: transitions,
+		IsDefault:   true,
+		CreatorID:   SystemUserID,
+	}
+}
+
+// Definition returns the stored form of the workflow's states and transitions
+func (w *Workflow) Definition() WorkflowDefinition {
+	return WorkflowDefinition{States: w.States, Transitions: w.Transitions}
+}
+
+// State returns the workflow state of a status, or nil if the workflow does not use it
+func (w *Workflow) State(status TaskStatus) *WorkflowState {
+	for i := range w.States {
+		if w.States[i].Status == status {
+			return &w.States[i]
+		}
+	}
+	return nil
+}
+
+// IsValid validates the workflow definition
+func (w *Workflow) IsValid() error {
+	if strings.TrimSpace(w.Name) == "" {
+		return ErrInvalidInput("workflow name is required")
+	}
+	if len(w.Name) > MaxWorkflowNameLength {
+		return ErrInvalidInput(fmt.Sprintf("workflow name must be at most %d characters", MaxWorkflowNameLength))
+	}
+
+	seen := make(map[TaskStatus]bool, len(w.States))
+	for _, state := range w.States {
+		if !isWorkflowStatus(state.Status) {
+			return ErrInvalidInput(fmt.Sprintf("invalid workflow status: %s", state.Status))
+		}
+		if seen[state.Status] {
+			return ErrInvalidInput(fmt.Sprintf("workflow status %s is listed more than once", state.Status))
+		}
+		seen[state.Status] = true
+
+		for _, field := range state.RequiredFields {
+			if !containsString(WorkflowFields, field) {
+				return ErrInvalidInput(fmt.Sprintf("unknown required field %q (fields: %s)", field, strings.Join(WorkflowFields, ", ")))
+			}
+		}
+	}
+	// New tasks start open, so every workflow needs somewhere for them to start
+	if !seen[TaskStatusOpen] {
+		return ErrInvalidInput("workflow must include the OPEN status")
+	}
+
+	transitions := make(map[[2]TaskStatus]bool, len(w.Transitions))
+	for _, transition := range w.Transitions {
+		if !seen[transition.From] || !seen[transition.To] {
+			return ErrInvalidInput(fmt.Sprintf("transition from %s to %s uses a status outside the workflow", transition.From, transition.To))
+		}
+		if transition.From == transition.To {
+			return ErrInvalidInput(fmt.Sprintf("transition from %s to itself", transition.From))
+		}
+		key := [2]TaskStatus{transition.From, transition.To}
+		if transitions[key] {
+			return ErrInvalidInput(fmt.Sprintf("transition from %s to %s is listed more than once", transition.From, transition.To))
+		}
+		transitions[key] = true
+
+		for _, guard := range transition.Guards {
+			if !isWorkflowGuard(guard) {
+				return ErrInvalidInput(fmt.Sprintf("invalid workflow guard: %s", guard))
+			}
+		}
+	}
+	return nil
+}
+
+// CheckTransition reports whether the caller may move a task to a status. A task whose
+// current status is not part of the workflow, for example after its category changed
+// workflows, may move to any status of the workflow.
+func (w *Workflow) CheckTransition(task *Task, to TaskStatus, callerID string, callerRole UserRole) error {
+	target := w.State(to)
+	if target == nil {
+		return ErrBusinessRule(fmt.Sprintf("status %s is not part of the %s workflow", to, w.Name))
+	}
+
+	if w.State(task.Status) != nil {
+		transition := w.transition(task.Status, to)
+		if transition == nil {
+			return ErrBusinessRule(fmt.Sprintf("invalid status transition from %s to %s", task.Status, to))
+		}
+		for _, guard := range transition.Guards {
+			if err := checkWorkflowGuard(guard, task, callerID, callerRole); err != nil {
+				return err
+			}
+		}
+	}
+
+	return checkRequiredFields(target, task)
+}
+
+// CheckRequiredFields reports whether a task has the fields its current status requires,
+// so that an edit cannot clear them. Statuses outside the workflow require nothing.
+func (w *Workflow) CheckRequiredFields(task *Task) error {
+	state := w.State(task.Status)
+	if state == nil {
+		return nil
+	}
+	return checkRequiredFields(state, task)
+}
+
+func checkRequiredFields(state *WorkflowState, task *Task) error {
+	for _, field := range state.RequiredFields {
+		if !taskHasField(task, field) {
+			return ErrBusinessRule(fmt.Sprintf("status %s requires %s", state.Status, strings.ReplaceAll(field, "_", " ")))
+		}
+	}
+	return nil
+}
+
+func (w *Workflow) transition(from, to TaskStatus) *WorkflowTransition {
+	for i := range w.Transitions {
+		if w.Transitions[i].From == from && w.Transitions[i].To == to {
+			return &w.Transitions[i]
+		}
+	}
+	return nil
+}
+
+func checkWorkflowGuard(guard WorkflowGuard, task *Task, callerID string, callerRole UserRole) error {
+	switch guard {
+	case WorkflowGuardHasAssignee:
+		if task.AssigneeID == "" {
+			return ErrBusinessRule("task must have an assignee")
+		}
+	case WorkflowGuardHasDueDate:
+		if task.DueDate == nil {
+			return ErrBusinessRule("task must have a due date")
+		}
+	case WorkflowGuardCallerIsAssignee:
+		if !task.HasRole(callerID, TaskRoleAssignee) {
+			return ErrBusinessRule("only an assignee can make this status change")
+		}
+	case WorkflowGuardCallerIsAdmin:
+		if callerRole != UserRoleAdmin {
+			return ErrBusinessRule("only administrators can make this status change")
+		}
+	}
+	return nil
+}
+
+func taskHasField(task *Task, field string) bool {
+	switch field {
+	case WorkflowFieldDescription:
+		return strings.TrimSpace(task.Description) != ""
+	case WorkflowFieldDueDate:
+		return task.DueDate != nil
+	case WorkflowFieldCategories:
+		return len(task.Categories) > 0
+	case WorkflowFieldTags:
+		return len(task.Tags) > 0
+	}
+	return false
+}
+
+func isWorkflowStatus(status TaskStatus) bool {
+	for _, known := range WorkflowStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isWorkflowGuard(guard WorkflowGuard) bool {
+	switch guard {
+	case WorkflowGuardHasAssignee, WorkflowGuardHasDueDate, WorkflowGuardCallerIsAssignee, WorkflowGuardCallerIsAdmin:
+		return true
+	}
+	return false
+}
+
+// ToProtobuf converts domain Workflow to protobuf Workflow
+func (w *Workflow) ToProtobuf() *pb.Workflow {
+	workflow := &pb.Workflow{
+		Id:          w.ID,
+		Name:        w.Name,
+		Description: w.Description,
+		IsDefault:   w.IsDefault,
+		CreatorId:   w.CreatorID,
+		Version:     w.Version,
+	}
+	if !w.CreatedAt.IsZero() {
+		workflow.CreatedAt = TimeToProtobuf(w.CreatedAt)
+		workflow.UpdatedAt = TimeToProtobuf(w.UpdatedAt)
+	}
+
+	for _, state := range w.States {
+		workflow.States = append(workflow.States, &pb.WorkflowState{
+			Status:         taskStatusToProtobuf(state.Status),
+			RequiredFields: state.RequiredFields,
+		})
+	}
+	for _, transition := range w.Transitions {
+		pbTransition := &pb.WorkflowTransition{
+			From: taskStatusToProtobuf(transition.From),
+			To:   taskStatusToProtobuf(transition.To),
+		}
+		for _, guard := range transition.Guards {
+			pbTransition.Guards = append(pbTransition.Guards, workflowGuardToProtobuf(guard))
+		}
+		workflow.Transitions = append(workflow.Transitions, pbTransition)
+	}
+	return workflow
+}
+
+// WorkflowDefinitionFromProtobuf converts protobuf states and transitions to a workflow definition
+func WorkflowDefinitionFromProtobuf(states []*pb.WorkflowState, transitions []*pb.WorkflowTransition) WorkflowDefinition {
+	var definition WorkflowDefinition
+	for _, state := range states {
+		definition.States = append(definition.States, WorkflowState{
+			Status:         TaskStatusFromProtobuf(state.GetStatus()),
+			RequiredFields: state.GetRequiredFields(),
+		})
+	}
+	for _, transition := range transitions {
+		t := WorkflowTransition{
+			From: TaskStatusFromProtobuf(transition.GetFrom()),
+			To:   TaskStatusFromProtobuf(transition.GetTo()),
+		}
+		for _, guard := range transition.GetGuards() {
+			t.Guards = append(t.Guards, workflowGuardFromProtobuf(guard))
+		}
+		definition.Transitions = append(definition.Transitions, t)
+	}
+	return definition
+}
+
+func workflowGuardToProtobuf(guard WorkflowGuard) pb.WorkflowGuard {
+	switch guard {
+	case WorkflowGuardHasAssignee:
+		return pb.WorkflowGuard_WORKFLOW_GUARD_HAS_ASSIGNEE
+	case WorkflowGuardHasDueDate:
+		return pb.WorkflowGuard_WORKFLOW_GUARD_HAS_DUE_DATE
+	case WorkflowGuardCallerIsAssignee:
+		return pb.WorkflowGuard_WORKFLOW_GUARD_CALLER_IS_ASSIGNEE
+	case WorkflowGuardCallerIsAdmin:
+		return pb.WorkflowGuard_WORKFLOW_GUARD_CALLER_IS_ADMIN
+	default:
+		return pb.WorkflowGuard_WORKFLOW_GUARD_UNSPECIFIED
+	}
+}
+
+func workflowGuardFromProtobuf(guard pb.WorkflowGuard) WorkflowGuard {
+	switch guard {
+	case pb.WorkflowGuard_WORKFLOW_GUARD_HAS_ASSIGNEE:
+		return WorkflowGuardHasAssignee
+	case pb.WorkflowGuard_WORKFLOW_GUARD_HAS_DUE_DATE:
+		return WorkflowGuardHasDueDate
+	case pb.WorkflowGuard_WORKFLOW_GUARD_CALLER_IS_ASSIGNEE:
+		return WorkflowGuardCallerIsAssignee
+	case pb.WorkflowGuard_WORKFLOW_GUARD_CALLER_IS_ADMIN:
+		return WorkflowGuardCallerIsAdmin
+	default:
+		return WorkflowGuard(guard.String())
+	}
+}
@@ -0,0 +1,88 @@
+// Package notify delivers notifications to users.
+//
+// The notification service decides who is notified of what and when; a Notifier only
+// carries a notification to its user on the notification's channel. Delivery providers,
+// such as an email sender, implement Notifier.
+package notify
+
+import (
+	"context"
+	"sync"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+// Notifier delivers notifications
+type Notifier interface {
+	// Notify delivers a notification. A failed notification stays queued and is retried.
+	Notify(ctx context.Context, notification *domain.Notification) error
+}
+
+// Memory is a Notifier that keeps every notification it is given, for tests
+type Memory struct {
+	mu   sync.Mutex
+	sent []*domain.Notification
+	err  error
+}
+
+// NewMemory creates an empty in-memory notifier
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Notify records the notification, or fails with the error set by FailWith
+func (m *Memory) Notify(ctx context.Context, notification *domain.Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, notification)
+	return nil
+}
+
+// FailWith makes Notify fail with err until it is called again with nil
+func (m *Memory) FailWith(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+// Sent returns the notifications delivered so far, in order
+func (m *Memory) Sent() []*domain.Notification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*domain.Notification(nil), m.sent...)
+}
+
+// SentTo returns the notifications delivered to a user on a channel, in order
+func (m *Memory) SentTo(userID string, channel domain.NotificationChannel) []*domain.Notification {
+	var sent []*domain.Notification
+	for _, notification := range m.Sent() {
+		if notification.UserID == userID && notification.Channel == channel {
+			sent = append(sent, notification)
+		}
+	}
+	return sent
+}
+
+// Log is a Notifier that only logs notifications, for deployments without a delivery provider
+type Log struct {
+	logger logger.Logger
+}
+
+// NewLog creates a notifier that logs every notification
+func NewLog(log logger.Logger) *Log {
+	return &Log{logger: log}
+}
+
+func (l *Log) Notify(ctx context.Context, notification *domain.Notification) error {
+	l.logger.Info(ctx, "Notification",
+		"user_id", notification.UserID,
+		"channel", notification.Channel,
+		"digest", notification.Digest,
+		"items", len(notification.Items),
+	)
+	return nil
+}
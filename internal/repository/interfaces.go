@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"io"
+	"time"
 
 	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/taskquery"
 )
 
 // UserRepository defines user data access operations
@@ -22,20 +25,62 @@ type UserRepository interface {
 type TaskRepository interface {
 	Create(ctx context.Context, task *domain.Task) error
 	GetByID(ctx context.Context, id string) (*domain.Task, error)
+	// GetDeletedByID returns a soft-deleted task, so it can be checked before it is restored
+	GetDeletedByID(ctx context.Context, id string) (*domain.Task, error)
 	List(ctx context.Context, opts TaskListOptions) ([]*domain.Task, int64, error)
+	// StreamForExport reads every task matching opts through a server-side cursor and
+	// calls fn for each one in order; returning an error from fn stops the stream
+	StreamForExport(ctx context.Context, opts TaskListOptions, fn func(*domain.TaskExportRecord) error) error
+	// Search lists tasks matching opts.SearchQuery with their rank and highlighted snippets
+	Search(ctx context.Context, opts TaskListOptions) ([]*domain.TaskSearchResult, int64, error)
+	// Facets counts the tasks matching opts by each facet, ignoring the filter on that facet
+	Facets(ctx context.Context, opts TaskListOptions, facets []domain.TaskFacet) ([]*domain.FacetCounts, error)
 	Update(ctx context.Context, task *domain.Task) error
 	SoftDelete(ctx context.Context, id string, version int64) error
 	Restore(ctx context.Context, id string, version int64) error
 
+	// Subtasks. GetSubtree returns a live task and every live task under it, parents first,
+	// without children linked.
+	GetSubtree(ctx context.Context, rootID string) ([]*domain.TaskNode, error)
+	// SoftDeleteDescendants deletes every subtask of a task and returns their IDs.
+	// Called in the same transaction as SoftDelete, so the subtree shares one deletion time.
+	SoftDeleteDescendants(ctx context.Context, id string) ([]string, error)
+	// RestoreDescendants restores the subtasks deleted together with a still deleted task
+	RestoreDescendants(ctx context.Context, id string) ([]string, error)
+
+	// Dependencies. AddDependency refuses a link that would let a task block itself.
+	AddDependency(ctx context.Context, dependency *domain.TaskDependency) error
+	RemoveDependency(ctx context.Context, taskID, blockerID string) error
+	// ListBlockers returns the live tasks blocking a task
+	ListBlockers(ctx context.Context, taskID string) ([]*domain.Task, error)
+	// ListDependents returns the live tasks a task blocks
+	ListDependents(ctx context.Context, taskID string) ([]*domain.Task, error)
+	// IsBlockedBy reports whether blockerID blocks taskID, directly or through other tasks
+	IsBlockedBy(ctx context.Context, taskID, blockerID string) (bool, error)
+	// ListDependencies returns the links between the given tasks
+	ListDependencies(ctx context.Context, taskIDs []string) ([]*domain.TaskDependency, error)
+
+	// Recurring series. LinkToSeries sets the series and position of a task, which Update leaves alone.
+	LinkToSeries(ctx context.Context, task *domain.Task) error
+	// ListSeriesInstances returns the live instances of a series, oldest first
+	ListSeriesInstances(ctx context.Context, seriesID string) ([]*domain.Task, error)
+
 	// Category associations
 	AddCategories(ctx context.Context, taskID string, categoryIDs []string, version int64) error
 	RemoveCategories(ctx context.Context, taskID string, categoryIDs []string, version int64) error
 
+	// Participants. AddParticipants leaves roles users already hold alone. RemoveParticipants
+	// makes the longest-standing remaining assignee primary when the primary assignee loses
+	// the role.
+	AddParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) error
+	RemoveParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) error
+
 	// Tag associations
 	AddTags(ctx context.Context, taskID string, tagIDs []string, version int64) error
 	RemoveTags(ctx context.Context, taskID string, tagIDs []string, version int64) error
 
 	// History
+	AddHistory(ctx context.Context, entry *domain.TaskHistory) error
 	GetHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error)
 }
 
@@ -43,20 +88,58 @@ type TaskRepository interface {
 type CategoryRepository interface {
 	Create(ctx context.Context, category *domain.Category) error
 	GetByID(ctx context.Context, id string) (*domain.Category, error)
+	// GetByName finds a category by case-insensitive name; the oldest one wins if names repeat
+	GetByName(ctx context.Context, name string) (*domain.Category, error)
 	List(ctx context.Context, opts CategoryListOptions) ([]*domain.Category, int64, error)
+	// GetSubtree returns the categories under rootID, or under every top-level category when
+	// rootID is empty, down to maxDepth levels. Nodes come parents first, without children.
+	GetSubtree(ctx context.Context, rootID string, maxDepth int) ([]*domain.CategoryNode, error)
+	// GetAncestors returns the parents of a category, nearest first, including deleted ones
+	GetAncestors(ctx context.Context, id string) ([]*domain.Category, error)
 	Update(ctx context.Context, category *domain.Category) error
 	SoftDelete(ctx context.Context, id string, version int64) error
 	Restore(ctx context.Context, id string, version int64) error
+
+	// SoftDeleteDescendants deletes every subcategory of a category and returns their IDs.
+	// Called in the same transaction as SoftDelete, so the subtree shares one deletion time.
+	SoftDeleteDescendants(ctx context.Context, id string) ([]string, error)
+	// RestoreDescendants restores the subcategories deleted together with a still deleted category
+	RestoreDescendants(ctx context.Context, id string) ([]string, error)
+	// ReassignTasks moves every task link from the source category to the target and returns the task IDs
+	ReassignTasks(ctx context.Context, sourceID, targetID string) ([]string, error)
+	// MoveChildren re-parents the direct subcategories of one category under another
+	MoveChildren(ctx context.Context, fromID, toID string) ([]string, error)
+	// LockHierarchy holds off other moves until the transaction in ctx ends, so that a cycle
+	// check and the move it admits are not interleaved with another's
+	LockHierarchy(ctx context.Context) error
+	AddHistory(ctx context.Context, entry *domain.CategoryHistory) error
 }
 
 // TagRepository defines tag data access operations
 type TagRepository interface {
 	Create(ctx context.Context, tag *domain.Tag) error
 	GetByID(ctx context.Context, id string) (*domain.Tag, error)
+	// GetByName finds a tag by its normalized name; the oldest one wins if names repeat
+	GetByName(ctx context.Context, name string) (*domain.Tag, error)
 	List(ctx context.Context, opts TagListOptions) ([]*domain.Tag, int64, error)
+	// Suggest returns the best prefix and trigram matches for a type-ahead query with their
+	// usage counts. The caller ranks them.
+	Suggest(ctx context.Context, opts TagSuggestOptions) ([]*domain.TagSuggestion, error)
 	Update(ctx context.Context, tag *domain.Tag) error
 	SoftDelete(ctx context.Context, id string, version int64) error
 	Restore(ctx context.Context, id string, version int64) error
+
+	// ReassignTasks moves every task link from the source tag to the target and returns the task IDs
+	ReassignTasks(ctx context.Context, sourceID, targetID string) ([]string, error)
+	// CountTasks counts the live tasks using each tag; unused tags are left out
+	CountTasks(ctx context.Context, tagIDs []string) (map[string]int64, error)
+
+	// Synonyms. AddSynonym re-points an existing synonym; GetSynonym ignores synonyms of deleted tags.
+	AddSynonym(ctx context.Context, synonym *domain.TagSynonym) error
+	GetSynonym(ctx context.Context, synonym string) (*domain.TagSynonym, error)
+	ListSynonyms(ctx context.Context, tagID string) ([]*domain.TagSynonym, error)
+	DeleteSynonym(ctx context.Context, synonym string) error
+	MoveSynonyms(ctx context.Context, fromID, toID string) error
 }
 
 // TaskHistoryRepository defines task history operations
@@ -64,6 +147,151 @@ type TaskHistoryRepository interface {
 	GetByTaskID(ctx context.Context, taskID string) ([]*domain.TaskHistoryEntry, error)
 }
 
+// IdempotencyRepository stores responses of mutating requests keyed by caller and idempotency key
+type IdempotencyRepository interface {
+	// Claim reserves the key for a new request. If the key is already held by an
+	// unexpired record (pending or completed), that record is returned with claimed=false.
+	// Pending records older than staleAfter are taken over.
+	Claim(ctx context.Context, record *domain.IdempotencyRecord, staleAfter time.Duration) (existing *domain.IdempotencyRecord, claimed bool, err error)
+	Get(ctx context.Context, callerID, key string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *domain.IdempotencyRecord) error
+	// Renew restarts the stale timer of a pending claim whose request is still running
+	Renew(ctx context.Context, callerID, key, requestHash string) error
+	// Release drops a pending claim so the request can be retried
+	Release(ctx context.Context, callerID, key, requestHash string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// TaskImportRepository tracks task imports and their progress
+type TaskImportRepository interface {
+	Create(ctx context.Context, taskImport *domain.TaskImport) error
+	GetByID(ctx context.Context, id string) (*domain.TaskImport, error)
+	Update(ctx context.Context, taskImport *domain.TaskImport) error
+}
+
+// SavedViewRepository defines saved view data access operations. Loaded views
+// report the categories and tags in their filter that no longer exist.
+type SavedViewRepository interface {
+	Create(ctx context.Context, view *domain.SavedView) error
+	GetByID(ctx context.Context, id string) (*domain.SavedView, error)
+	List(ctx context.Context, opts SavedViewListOptions) ([]*domain.SavedView, int64, error)
+	Update(ctx context.Context, view *domain.SavedView) error
+	SoftDelete(ctx context.Context, id string, version int64) error
+}
+
+// WorkflowRepository defines workflow data access operations
+type WorkflowRepository interface {
+	// Create and Update unmark any other default workflow when the workflow is the default
+	Create(ctx context.Context, workflow *domain.Workflow) error
+	GetByID(ctx context.Context, id string) (*domain.Workflow, error)
+	// GetDefault returns the workflow marked as default, or a not found error if there is none
+	GetDefault(ctx context.Context) (*domain.Workflow, error)
+	// GetForCategories returns the distinct workflows of the given categories. A category
+	// without a workflow uses the nearest parent's; categories with none are left out.
+	GetForCategories(ctx context.Context, categoryIDs []string) ([]*domain.Workflow, error)
+	List(ctx context.Context, opts ListOptions) ([]*domain.Workflow, int64, error)
+	Update(ctx context.Context, workflow *domain.Workflow) error
+	SoftDelete(ctx context.Context, id string, version int64) error
+	// CountCategories counts the live categories that use the workflow directly
+	CountCategories(ctx context.Context, id string) (int64, error)
+}
+
+// TaskSeriesRepository defines recurring task series data access operations
+type TaskSeriesRepository interface {
+	Create(ctx context.Context, series *domain.TaskSeries) error
+	GetByID(ctx context.Context, id string) (*domain.TaskSeries, error)
+	Update(ctx context.Context, series *domain.TaskSeries) error
+	// ListDue returns active series whose latest instance is live and due at or before now,
+	// the longest overdue first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.TaskSeries, error)
+}
+
+// CommentRepository defines task comment data access operations
+type CommentRepository interface {
+	Create(ctx context.Context, comment *domain.Comment) error
+	GetByID(ctx context.Context, id string) (*domain.Comment, error)
+	// Update saves the body and mentions of a comment and marks it as edited
+	Update(ctx context.Context, comment *domain.Comment) error
+	SoftDelete(ctx context.Context, id string, version int64) error
+	// ListActivity pages through a task's history entries and live comments, oldest first
+	ListActivity(ctx context.Context, taskID string, opts ListOptions) ([]*domain.ActivityItem, int64, error)
+}
+
+// AttachmentRepository defines task attachment metadata operations
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *domain.Attachment) error
+	GetByID(ctx context.Context, id string) (*domain.Attachment, error)
+	ListByTask(ctx context.Context, taskID string) ([]*domain.Attachment, error)
+	SoftDelete(ctx context.Context, id string, version int64) error
+	// UsageByUploader sums the sizes of a user's live attachments
+	UsageByUploader(ctx context.Context, uploaderID string) (int64, error)
+	// LockUploader holds off other uploads by the user until the transaction in ctx ends, so
+	// that a quota check and the attachment it admits are not interleaved with another's
+	LockUploader(ctx context.Context, uploaderID string) error
+	// PendingBlobDeletions returns up to limit storage keys whose metadata was purged
+	PendingBlobDeletions(ctx context.Context, limit int) ([]string, error)
+	// ClearBlobDeletions removes storage keys from the deletion queue once their blobs are gone
+	ClearBlobDeletions(ctx context.Context, keys []string) error
+}
+
+// NotificationRepository defines watcher, notification settings and delivery operations
+type NotificationRepository interface {
+	// WatchTask and WatchCategory succeed if the user already watches, as do their counterparts
+	// if the user does not
+	WatchTask(ctx context.Context, userID, taskID string) error
+	UnwatchTask(ctx context.Context, userID, taskID string) error
+	WatchCategory(ctx context.Context, userID, categoryID string) error
+	UnwatchCategory(ctx context.Context, userID, categoryID string) error
+	ListWatches(ctx context.Context, userID string) (*domain.Watches, error)
+	// Watchers returns the users who watch a task directly or through one of its categories or
+	// their parent categories, and its participants
+	Watchers(ctx context.Context, taskID string) ([]string, error)
+	// WatchedTaskIDs returns the live tasks a user would be notified of, as Watchers counts them
+	WatchedTaskIDs(ctx context.Context, userID string) ([]string, error)
+	// ListNotifiedUsers returns the users Watchers can return for some live task
+	ListNotifiedUsers(ctx context.Context) ([]string, error)
+
+	// GetSettings returns a user's notification settings, the defaults if they never saved any
+	GetSettings(ctx context.Context, userID string) (*domain.NotificationSettings, error)
+	// SaveSettings creates the settings at version zero and otherwise updates them if the
+	// version matches, replacing every preference
+	SaveSettings(ctx context.Context, settings *domain.NotificationSettings) error
+	// LastDigest returns when the user's last digest on the channel was assembled, nil if never
+	LastDigest(ctx context.Context, userID string, channel domain.NotificationChannel) (*time.Time, error)
+	MarkDigestSent(ctx context.Context, userID string, channel domain.NotificationChannel, at time.Time) error
+
+	// LockCursor returns the position of the named activity cursor, starting it at initial,
+	// and locks it until the surrounding transaction ends
+	LockCursor(ctx context.Context, name string, initial time.Time) (time.Time, error)
+	SetCursor(ctx context.Context, name string, position time.Time) error
+	// ListActivity returns the history entries and live comments of live tasks made after from
+	// and at or before to, oldest first. A nil taskIDs covers every task.
+	ListActivity(ctx context.Context, from, to time.Time, taskIDs []string) ([]*domain.ActivityItem, error)
+	// ClaimDueSoon returns the open live tasks due after from and at or before to that were
+	// not returned for the same due date before
+	ClaimDueSoon(ctx context.Context, from, to time.Time) ([]*domain.Task, error)
+	// ListDueTasks returns those of the given tasks that are open and due after from and at
+	// or before to, soonest first
+	ListDueTasks(ctx context.Context, taskIDs []string, from, to time.Time) ([]*domain.Task, error)
+
+	Enqueue(ctx context.Context, notifications []*domain.Notification) error
+	// PendingNotifications returns up to limit queued notifications due at now, oldest first,
+	// and locks them until the surrounding transaction ends. Notifications locked by another
+	// transaction are skipped.
+	PendingNotifications(ctx context.Context, now time.Time, limit int) ([]*domain.Notification, error)
+	DeleteNotifications(ctx context.Context, ids []string) error
+}
+
+// BlobStore stores attachment content by key
+type BlobStore interface {
+	// Put stores exactly size bytes read from r under key, replacing any existing blob
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get opens the blob stored under key; a missing blob is a not found error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key; deleting a missing blob succeeds
+	Delete(ctx context.Context, key string) error
+}
+
 // TransactionManager defines transaction operations
 type TransactionManager interface {
 	WithTransaction(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error
@@ -82,19 +310,36 @@ type ListOptions struct {
 // TaskListOptions defines task-specific list options
 type TaskListOptions struct {
 	ListOptions
-	AssigneeID  string              `json:"assignee_id"`
-	Status      domain.TaskStatus   `json:"status"`
-	Priority    domain.TaskPriority `json:"priority"`
-	CategoryIDs []string            `json:"category_ids"`
-	TagIDs      []string            `json:"tag_ids"`
-	DueBefore   *string             `json:"due_before"` // ISO timestamp
-	DueAfter    *string             `json:"due_after"`  // ISO timestamp
+	AssigneeID   string              `json:"assignee_id"` // Matches participants, see AssigneeRoles
+	Status       domain.TaskStatus   `json:"status"`
+	Priority     domain.TaskPriority `json:"priority"`
+	CategoryIDs  []string            `json:"category_ids"`
+	TagIDs       []string            `json:"tag_ids"`
+	DueBefore    *string             `json:"due_before"` // ISO timestamp
+	DueAfter     *string             `json:"due_after"`  // ISO timestamp
+	DeletedOnly  bool                `json:"deleted_only"`
+	SearchLabels bool                `json:"search_labels"`   // SearchQuery also matches category and tag names
+	Query        string              `json:"query,omitempty"` // Task query language, see package taskquery
+	QueryExpr    taskquery.Expr      `json:"-"`               // Query parsed and bound by the service
+	// AssigneeRoles are the roles AssigneeID must hold on a task; none matches any role
+	AssigneeRoles []domain.TaskParticipantRole `json:"assignee_roles,omitempty"`
+	CreatorID     string                       `json:"creator_id,omitempty"` // Tasks created by this user
+}
+
+// SortByRelevance orders search results by rank; it is the default when searching
+const SortByRelevance = "relevance"
+
+// BulkTaskSelection selects the tasks of a bulk operation: either explicit
+// references with versions, or every task matching a filter
+type BulkTaskSelection struct {
+	Tasks  []domain.TaskRef
+	Filter *TaskListOptions
 }
 
 // CategoryListOptions defines category-specific list options
 type CategoryListOptions struct {
 	ListOptions
-	ParentID   *string `json:"parent_id"`
+	ParentID   *string `json:"parent_id"` // An empty ID selects top-level categories
 	PublicOnly bool    `json:"public_only"`
 	CreatorID  string  `json:"creator_id"`
 }
@@ -105,12 +350,37 @@ type TagListOptions struct {
 	CreatorID string `json:"creator_id"`
 }
 
+// TagSuggestOptions selects the tags suggested for a type-ahead query
+type TagSuggestOptions struct {
+	Query       string    `json:"query"`        // Normalized tag name prefix
+	Trigram     bool      `json:"trigram"`      // Also match by trigram similarity
+	UserID      string    `json:"user_id"`      // Caller, for visibility and recent usage
+	AllTags     bool      `json:"all_tags"`     // Skip the visibility check (admins)
+	RecentSince time.Time `json:"recent_since"` // Start of the caller's recent usage window
+	Limit       int       `json:"limit"`        // Number of candidates
+}
+
+// SavedViewListOptions selects the views a user can see: their own and those shared with their role
+type SavedViewListOptions struct {
+	ListOptions
+	UserID string          `json:"user_id"`
+	Role   domain.UserRole `json:"role"`
+}
+
 // Repositories aggregates all repository interfaces
 type Repositories struct {
-	Users       UserRepository
-	Tasks       TaskRepository
-	Categories  CategoryRepository
-	Tags        TagRepository
-	TaskHistory TaskHistoryRepository
-	Transaction TransactionManager
+	Users         UserRepository
+	Tasks         TaskRepository
+	Categories    CategoryRepository
+	Tags          TagRepository
+	TaskHistory   TaskHistoryRepository
+	Idempotency   IdempotencyRepository
+	TaskImports   TaskImportRepository
+	SavedViews    SavedViewRepository
+	Workflows     WorkflowRepository
+	TaskSeries    TaskSeriesRepository
+	Comments      CommentRepository
+	Attachments   AttachmentRepository
+	Notifications NotificationRepository
+	Transaction   TransactionManager
 }
@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+const attachmentColumns = `
+		a.id, a.task_id, a.uploader_id, a.file_name, a.content_type, a.size_bytes, a.checksum_sha256,
+		a.storage_key, a.created_at, a.updated_at, a.version, a.is_deleted, a.deleted_at`
+
+type attachmentRepository struct {
+	db *sql.DB
+}
+
+// NewAttachmentRepository creates a new task attachment repository
+func NewAttachmentRepository(db *sql.DB) repository.AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	if attachment.ID == "" {
+		attachment.ID = uuid.New().String()
+	}
+	if attachment.StorageKey == "" {
+		attachment.StorageKey = domain.AttachmentStorageKey(attachment.TaskID, attachment.ID)
+	}
+
+	now := time.Now()
+	attachment.CreatedAt = now
+	attachment.UpdatedAt = now
+	attachment.Version = 1
+
+	if err := attachment.IsValid(); err != nil {
+		return fmt.Errorf("invalid attachment: %w", err)
+	}
+
+	query := `
+		INSERT INTO task_attachments (id, task_id, uploader_id, file_name, content_type, size_bytes,
+			checksum_sha256, storage_key, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		attachment.ID, attachment.TaskID, attachment.UploaderID, attachment.FileName, attachment.ContentType,
+		attachment.SizeBytes, attachment.ChecksumSHA256, attachment.StorageKey,
+		attachment.CreatedAt, attachment.UpdatedAt, attachment.Version)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + `
+		FROM task_attachments a
+		WHERE a.id = $1 AND a.is_deleted = false`
+
+	attachment, err := scanAttachment(executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("attachment")
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (r *attachmentRepository) ListByTask(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + `
+		FROM task_attachments a
+		WHERE a.task_id = $1 AND a.is_deleted = false
+		ORDER BY a.created_at, a.id`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []*domain.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *attachmentRepository) SoftDelete(ctx context.Context, id string, version int64) error {
+	query := `
+		UPDATE task_attachments
+		SET is_deleted = true, deleted_at = NOW()
+		WHERE id = $1 AND version = $2 AND is_deleted = false`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionConflict("attachment", version, version+1)
+	}
+
+	return nil
+}
+
+func (r *attachmentRepository) UsageByUploader(ctx context.Context, uploaderID string) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(size_bytes), 0)
+		FROM task_attachments
+		WHERE uploader_id = $1 AND is_deleted = false`
+
+	var used int64
+	if err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, uploaderID).Scan(&used); err != nil {
+		return 0, fmt.Errorf("failed to get attachment usage: %w", err)
+	}
+
+	return used, nil
+}
+
+func (r *attachmentRepository) LockUploader(ctx context.Context, uploaderID string) error {
+	// NO KEY UPDATE leaves rows referencing the user free to be inserted meanwhile
+	query := `SELECT 1 FROM users WHERE id = $1 FOR NO KEY UPDATE`
+
+	var locked int
+	if err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, uploaderID).Scan(&locked); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrNotFound("user")
+		}
+		return fmt.Errorf("failed to lock uploader: %w", err)
+	}
+	return nil
+}
+
+func (r *attachmentRepository) PendingBlobDeletions(ctx context.Context, limit int) ([]string, error) {
+	query := `
+		SELECT storage_key
+		FROM attachment_blob_deletions
+		ORDER BY queued_at, storage_key
+		LIMIT $1`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending blob deletions: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan pending blob deletion: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list pending blob deletions: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *attachmentRepository) ClearBlobDeletions(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	query := `DELETE FROM attachment_blob_deletions WHERE storage_key = ANY($1)`
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, pq.Array(keys)); err != nil {
+		return fmt.Errorf("failed to clear blob deletions: %w", err)
+	}
+
+	return nil
+}
+
+func scanAttachment(row rowScanner) (*domain.Attachment, error) {
+	attachment := &domain.Attachment{}
+
+	err := row.Scan(
+		&attachment.ID, &attachment.TaskID, &attachment.UploaderID, &attachment.FileName, &attachment.ContentType,
+		&attachment.SizeBytes, &attachment.ChecksumSHA256, &attachment.StorageKey,
+		&attachment.CreatedAt, &attachment.UpdatedAt, &attachment.Version, &attachment.IsDeleted, &attachment.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
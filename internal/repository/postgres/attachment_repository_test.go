@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func TestAttachmentRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn, ownerID := setupTagTestDB(t)
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	repo := NewAttachmentRepository(dbConn.DB)
+	taskRepo := NewTaskRepository(dbConn.DB)
+
+	task := &domain.Task{Title: "With files", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, AssigneeID: ownerID}
+	if err := taskRepo.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	newAttachment := func(name string, size int64) *domain.Attachment {
+		return &domain.Attachment{
+			TaskID:         task.ID,
+			UploaderID:     ownerID,
+			FileName:       name,
+			ContentType:    "text/plain",
+			SizeBytes:      size,
+			ChecksumSHA256: strings.Repeat("a", 64),
+		}
+	}
+	first := newAttachment("notes.txt", 100)
+	second := newAttachment("more.txt", 50)
+
+	t.Run("Create, get and list", func(t *testing.T) {
+		for _, attachment := range []*domain.Attachment{first, second} {
+			if err := repo.Create(ctx, attachment); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+		if first.StorageKey != domain.AttachmentStorageKey(task.ID, first.ID) {
+			t.Errorf("StorageKey = %q", first.StorageKey)
+		}
+
+		stored, err := repo.GetByID(ctx, first.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if stored.FileName != "notes.txt" || stored.SizeBytes != 100 || stored.Version != 1 {
+			t.Errorf("stored attachment = %+v", stored)
+		}
+
+		attachments, err := repo.ListByTask(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("ListByTask() error = %v", err)
+		}
+		if len(attachments) != 2 || attachments[0].ID != first.ID {
+			t.Errorf("ListByTask() = %d attachments, want first then second", len(attachments))
+		}
+	})
+
+	t.Run("Usage counts live attachments", func(t *testing.T) {
+		before, err := repo.UsageByUploader(ctx, ownerID)
+		if err != nil {
+			t.Fatalf("UsageByUploader() error = %v", err)
+		}
+
+		if err := repo.SoftDelete(ctx, second.ID, second.Version+1); !domain.IsVersionConflictError(err) {
+			t.Errorf("SoftDelete() with a wrong version error = %v, want version conflict", err)
+		}
+		if err := repo.SoftDelete(ctx, second.ID, second.Version); err != nil {
+			t.Fatalf("SoftDelete() error = %v", err)
+		}
+
+		after, _ := repo.UsageByUploader(ctx, ownerID)
+		if before-after != 50 {
+			t.Errorf("usage went from %d to %d, want 50 bytes less", before, after)
+		}
+		if _, err := repo.GetByID(ctx, second.ID); !domain.IsNotFoundError(err) {
+			t.Errorf("GetByID() of deleted attachment error = %v, want not found", err)
+		}
+	})
+
+	t.Run("Locking an uploader", func(t *testing.T) {
+		err := NewTransactionManager(dbConn.DB).WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			return repo.LockUploader(txCtx, ownerID)
+		})
+		if err != nil {
+			t.Errorf("LockUploader() error = %v", err)
+		}
+		if err := repo.LockUploader(ctx, uuid.New().String()); !domain.IsNotFoundError(err) {
+			t.Errorf("LockUploader() of a missing user error = %v, want not found", err)
+		}
+	})
+
+	t.Run("Purging the task queues its blobs", func(t *testing.T) {
+		if _, err := dbConn.DB.ExecContext(ctx, `DELETE FROM tasks WHERE id = $1`, task.ID); err != nil {
+			t.Fatalf("Failed to purge task: %v", err)
+		}
+
+		pending, err := repo.PendingBlobDeletions(ctx, 1000)
+		if err != nil {
+			t.Fatalf("PendingBlobDeletions() error = %v", err)
+		}
+		queued := make(map[string]bool)
+		for _, key := range pending {
+			queued[key] = true
+		}
+		if !queued[first.StorageKey] || !queued[second.StorageKey] {
+			t.Fatalf("pending deletions %v miss the task's blobs", pending)
+		}
+
+		if err := repo.ClearBlobDeletions(ctx, []string{first.StorageKey, second.StorageKey}); err != nil {
+			t.Fatalf("ClearBlobDeletions() error = %v", err)
+		}
+		pending, _ = repo.PendingBlobDeletions(ctx, 1000)
+		for _, key := range pending {
+			if key == first.StorageKey || key == second.StorageKey {
+				t.Errorf("%s still pending after clear", key)
+			}
+		}
+	})
+}
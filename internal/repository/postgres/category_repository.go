@@ -3,15 +3,21 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+
 	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository"
 )
 
+// categoryCycleConstraint names the trigger check that keeps categories from becoming their own ancestors
+const categoryCycleConstraint = "categories_no_cycle"
+
 type categoryRepository struct {
 	db *sql.DB
 }
@@ -36,12 +42,12 @@ func (r *categoryRepository) Create(ctx context.Context, category *domain.Catego
 	}
 
 	query := `
-		INSERT INTO categories (id, name, description, color, parent_id, is_public, creator_id, created_at, updated_at, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		INSERT INTO categories (id, name, description, color, parent_id, is_public, workflow_id, creator_id, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
 		category.ID, category.Name, category.Description, category.Color,
-		category.ParentID, category.IsPublic, category.CreatorID,
+		category.ParentID, category.IsPublic, category.WorkflowID, category.CreatorID,
 		category.CreatedAt, category.UpdatedAt, category.Version)
 
 	return err
@@ -49,16 +55,16 @@ func (r *categoryRepository) Create(ctx context.Context, category *domain.Catego
 
 func (r *categoryRepository) GetByID(ctx context.Context, id string) (*domain.Category, error) {
 	query := `
-		SELECT id, name, description, color, parent_id, is_public, creator_id,
+		SELECT id, name, description, color, parent_id, is_public, workflow_id, creator_id,
 		       created_at, updated_at, version, is_deleted, deleted_at
 		FROM categories
 		WHERE id = $1 AND is_deleted = false`
 
 	category := &domain.Category{}
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&category.ID, &category.Name, &category.Description, &category.Color,
-		&category.ParentID, &category.IsPublic, &category.CreatorID,
+		&category.ParentID, &category.IsPublic, &category.WorkflowID, &category.CreatorID,
 		&category.CreatedAt, &category.UpdatedAt, &category.Version,
 		&category.IsDeleted, &category.DeletedAt)
 
@@ -72,6 +78,33 @@ func (r *categoryRepository) GetByID(ctx context.Context, id string) (*domain.Ca
 	return category, nil
 }
 
+func (r *categoryRepository) GetByName(ctx context.Context, name string) (*domain.Category, error) {
+	query := `
+		SELECT id, name, description, color, parent_id, is_public, workflow_id, creator_id,
+		       created_at, updated_at, version, is_deleted, deleted_at
+		FROM categories
+		WHERE LOWER(name) = LOWER($1) AND is_deleted = false
+		ORDER BY created_at
+		LIMIT 1`
+
+	category := &domain.Category{}
+
+	err := r.db.QueryRowContext(ctx, query, name).Scan(
+		&category.ID, &category.Name, &category.Description, &category.Color,
+		&category.ParentID, &category.IsPublic, &category.WorkflowID, &category.CreatorID,
+		&category.CreatedAt, &category.UpdatedAt, &category.Version,
+		&category.IsDeleted, &category.DeletedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("category")
+		}
+		return nil, fmt.Errorf("failed to get category by name: %w", err)
+	}
+
+	return category, nil
+}
+
 func (r *categoryRepository) List(ctx context.Context, opts repository.CategoryListOptions) ([]*domain.Category, int64, error) {
 	// Build WHERE clause
 	var conditions []string
@@ -91,7 +124,9 @@ func (r *categoryRepository) List(ctx context.Context, opts repository.CategoryL
 	}
 
 	// Add parent filter
-	if opts.ParentID != nil {
+	if opts.ParentID != nil && *opts.ParentID == "" {
+		conditions = append(conditions, "parent_id IS NULL")
+	} else if opts.ParentID != nil {
 		argIndex++
 		conditions = append(conditions, fmt.Sprintf("parent_id = $%d", argIndex))
 		args = append(args, *opts.ParentID)
@@ -142,7 +177,7 @@ func (r *categoryRepository) List(ctx context.Context, opts repository.CategoryL
 
 	// Build main query
 	query := fmt.Sprintf(`
-		SELECT id, name, description, color, parent_id, is_public, creator_id,
+		SELECT id, name, description, color, parent_id, is_public, workflow_id, creator_id,
 		       created_at, updated_at, version, is_deleted, deleted_at
 		FROM categories
 		%s
@@ -164,7 +199,7 @@ func (r *categoryRepository) List(ctx context.Context, opts repository.CategoryL
 
 		err := rows.Scan(
 			&category.ID, &category.Name, &category.Description, &category.Color,
-			&category.ParentID, &category.IsPublic, &category.CreatorID,
+			&category.ParentID, &category.IsPublic, &category.WorkflowID, &category.CreatorID,
 			&category.CreatedAt, &category.UpdatedAt, &category.Version,
 			&category.IsDeleted, &category.DeletedAt)
 
@@ -178,6 +213,113 @@ func (r *categoryRepository) List(ctx context.Context, opts repository.CategoryL
 	return categories, total, nil
 }
 
+func (r *categoryRepository) GetSubtree(ctx context.Context, rootID string, maxDepth int) ([]*domain.CategoryNode, error) {
+	// Without a root the tree starts at categories whose parent is unset or deleted. The
+	// path guards against cycles left by data written before the cycle check existed.
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT c.id, c.name, c.description, c.color, c.parent_id, c.is_public, c.workflow_id, c.creator_id,
+			       c.created_at, c.updated_at, c.version, c.is_deleted, c.deleted_at,
+			       1 AS depth, ARRAY[c.id] AS path
+			FROM categories c
+			WHERE NOT c.is_deleted
+			  AND CASE WHEN $1 = ''
+			           THEN c.parent_id IS NULL OR NOT EXISTS (
+			                    SELECT 1 FROM categories p WHERE p.id = c.parent_id AND NOT p.is_deleted)
+			           ELSE c.id::text = $1
+			      END
+			UNION ALL
+			SELECT c.id, c.name, c.description, c.color, c.parent_id, c.is_public, c.workflow_id, c.creator_id,
+			       c.created_at, c.updated_at, c.version, c.is_deleted, c.deleted_at,
+			       tree.depth + 1, tree.path || c.id
+			FROM categories c
+			JOIN tree ON c.parent_id = tree.id
+			WHERE NOT c.is_deleted AND tree.depth < $2 AND NOT c.id = ANY(tree.path)
+		)
+		SELECT id, name, description, color, parent_id, is_public, workflow_id, creator_id,
+		       created_at, updated_at, version, is_deleted, deleted_at, depth
+		FROM tree
+		ORDER BY depth, lower(name), id`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, rootID, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category subtree: %w", err)
+	}
+	defer rows.Close()
+
+	var nodes []*domain.CategoryNode
+	for rows.Next() {
+		category := &domain.Category{}
+		node := &domain.CategoryNode{Category: category}
+
+		err := rows.Scan(
+			&category.ID, &category.Name, &category.Description, &category.Color,
+			&category.ParentID, &category.IsPublic, &category.WorkflowID, &category.CreatorID,
+			&category.CreatedAt, &category.UpdatedAt, &category.Version,
+			&category.IsDeleted, &category.DeletedAt, &node.Depth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get category subtree: %w", err)
+	}
+
+	return nodes, nil
+}
+
+func (r *categoryRepository) GetAncestors(ctx context.Context, id string) ([]*domain.Category, error) {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT p.id, p.name, p.description, p.color, p.parent_id, p.is_public, p.workflow_id, p.creator_id,
+			       p.created_at, p.updated_at, p.version, p.is_deleted, p.deleted_at,
+			       1 AS distance, ARRAY[c.id, p.id] AS path
+			FROM categories c
+			JOIN categories p ON p.id = c.parent_id
+			WHERE c.id = $1
+			UNION ALL
+			SELECT p.id, p.name, p.description, p.color, p.parent_id, p.is_public, p.workflow_id, p.creator_id,
+			       p.created_at, p.updated_at, p.version, p.is_deleted, p.deleted_at,
+			       a.distance + 1, a.path || p.id
+			FROM ancestors a
+			JOIN categories p ON p.id = a.parent_id
+			WHERE NOT p.id = ANY(a.path)
+		)
+		SELECT id, name, description, color, parent_id, is_public, workflow_id, creator_id,
+		       created_at, updated_at, version, is_deleted, deleted_at
+		FROM ancestors
+		ORDER BY distance`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category ancestors: %w", err)
+	}
+	defer rows.Close()
+
+	var ancestors []*domain.Category
+	for rows.Next() {
+		category := &domain.Category{}
+
+		err := rows.Scan(
+			&category.ID, &category.Name, &category.Description, &category.Color,
+			&category.ParentID, &category.IsPublic, &category.WorkflowID, &category.CreatorID,
+			&category.CreatedAt, &category.UpdatedAt, &category.Version,
+			&category.IsDeleted, &category.DeletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+
+		ancestors = append(ancestors, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get category ancestors: %w", err)
+	}
+
+	return ancestors, nil
+}
+
 func (r *categoryRepository) Update(ctx context.Context, category *domain.Category) error {
 	if err := category.IsValid(); err != nil {
 		return fmt.Errorf("invalid category: %w", err)
@@ -185,14 +327,18 @@ func (r *categoryRepository) Update(ctx context.Context, category *domain.Catego
 
 	query := `
 		UPDATE categories 
-		SET name = $2, description = $3, color = $4, parent_id = $5, is_public = $6, updated_at = NOW()
-		WHERE id = $1 AND version = $7 AND is_deleted = false`
+		SET name = $2, description = $3, color = $4, parent_id = $5, is_public = $6, workflow_id = $7, updated_at = NOW()
+		WHERE id = $1 AND version = $8 AND is_deleted = false`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
 		category.ID, category.Name, category.Description, category.Color,
-		category.ParentID, category.IsPublic, category.Version)
+		category.ParentID, category.IsPublic, category.WorkflowID, category.Version)
 
 	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == categoryCycleConstraint {
+			return domain.ErrBusinessRule("a category cannot be moved under its own subcategory")
+		}
 		return fmt.Errorf("failed to update category: %w", err)
 	}
 
@@ -218,7 +364,7 @@ func (r *categoryRepository) SoftDelete(ctx context.Context, id string, version
 		SET is_deleted = true, deleted_at = NOW()
 		WHERE id = $1 AND version = $2 AND is_deleted = false`
 
-	result, err := r.db.ExecContext(ctx, query, id, version)
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
 	if err != nil {
 		return fmt.Errorf("failed to soft delete category: %w", err)
 	}
@@ -235,13 +381,165 @@ func (r *categoryRepository) SoftDelete(ctx context.Context, id string, version
 	return nil
 }
 
+func (r *categoryRepository) SoftDeleteDescendants(ctx context.Context, id string) ([]string, error) {
+	// Subcategories of an already deleted category were left behind by an earlier
+	// delete and are not part of this one
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT c.id, ARRAY[c.parent_id, c.id] AS path
+			FROM categories c
+			WHERE c.parent_id = $1 AND NOT c.is_deleted
+			UNION ALL
+			SELECT c.id, tree.path || c.id
+			FROM categories c
+			JOIN tree ON c.parent_id = tree.id
+			WHERE NOT c.is_deleted AND NOT c.id = ANY(tree.path)
+		)
+		UPDATE categories
+		SET is_deleted = true, deleted_at = NOW()
+		WHERE id IN (SELECT id FROM tree)
+		RETURNING id`
+
+	ids, err := queryIDs(ctx, executorFromContext(ctx, r.db), query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to soft delete subcategories: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *categoryRepository) RestoreDescendants(ctx context.Context, id string) ([]string, error) {
+	// A cascading delete stamps the whole subtree with the transaction time, so matching
+	// deleted_at tells those subcategories apart from ones deleted on their own
+	query := `
+		WITH RECURSIVE root AS (
+			SELECT id, deleted_at FROM categories WHERE id = $1 AND is_deleted
+		), tree AS (
+			SELECT c.id, ARRAY[root.id, c.id] AS path
+			FROM categories c
+			JOIN root ON c.parent_id = root.id
+			WHERE c.is_deleted AND c.deleted_at = root.deleted_at
+			UNION ALL
+			SELECT c.id, tree.path || c.id
+			FROM categories c
+			JOIN tree ON c.parent_id = tree.id
+			CROSS JOIN root
+			WHERE c.is_deleted AND c.deleted_at = root.deleted_at AND NOT c.id = ANY(tree.path)
+		)
+		UPDATE categories
+		SET is_deleted = false, deleted_at = NULL
+		WHERE id IN (SELECT id FROM tree)
+		RETURNING id`
+
+	ids, err := queryIDs(ctx, executorFromContext(ctx, r.db), query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore subcategories: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *categoryRepository) ReassignTasks(ctx context.Context, sourceID, targetID string) ([]string, error) {
+	// Tasks already in the target keep a single link; every moved task gets a new version
+	query := `
+		WITH moved AS (
+			DELETE FROM task_categories WHERE category_id = $1
+			RETURNING task_id
+		), linked AS (
+			INSERT INTO task_categories (task_id, category_id)
+			SELECT task_id, $2 FROM moved
+			ON CONFLICT (task_id, category_id) DO NOTHING
+		)
+		UPDATE tasks SET updated_at = NOW()
+		WHERE id IN (SELECT task_id FROM moved)
+		RETURNING id`
+
+	ids, err := queryIDs(ctx, executorFromContext(ctx, r.db), query, sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign category tasks: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *categoryRepository) MoveChildren(ctx context.Context, fromID, toID string) ([]string, error) {
+	query := `
+		UPDATE categories
+		SET parent_id = $2, updated_at = NOW()
+		WHERE parent_id = $1 AND NOT is_deleted
+		RETURNING id`
+
+	ids, err := queryIDs(ctx, executorFromContext(ctx, r.db), query, fromID, toID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == categoryCycleConstraint {
+			return nil, domain.ErrBusinessRule("a category cannot be moved under its own subcategory")
+		}
+		return nil, fmt.Errorf("failed to move subcategories: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *categoryRepository) LockHierarchy(ctx context.Context) error {
+	// Released when the transaction ends
+	query := `SELECT pg_advisory_xact_lock(hashtext('categories.parent_id'))`
+
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to lock category hierarchy: %w", err)
+	}
+	return nil
+}
+
+// AddHistory records an audit trail entry for a category
+func (r *categoryRepository) AddHistory(ctx context.Context, entry *domain.CategoryHistory) error {
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	query := `
+		INSERT INTO category_history (id, category_id, action, actor_id, service_name, timestamp, details)
+		VALUES ($1, $2, $3, $4, COALESCE(NULLIF(current_setting('app.service_name', true), ''), 'admin-service'), $5, $6)`
+
+	var details interface{}
+	if len(entry.Details) > 0 {
+		details = []byte(entry.Details)
+	}
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		entry.ID, entry.CategoryID, string(entry.Action), entry.ActorID, entry.Timestamp, details)
+	if err != nil {
+		return fmt.Errorf("failed to add category history: %w", err)
+	}
+
+	return nil
+}
+
+// queryIDs runs a query returning a single ID column
+func queryIDs(ctx context.Context, exec executor, query string, args ...interface{}) ([]string, error) {
+	rows, err := exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 func (r *categoryRepository) Restore(ctx context.Context, id string, version int64) error {
 	query := `
 		UPDATE categories 
 		SET is_deleted = false, deleted_at = NULL
 		WHERE id = $1 AND version = $2 AND is_deleted = true`
 
-	result, err := r.db.ExecContext(ctx, query, id, version)
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
 	if err != nil {
 		return fmt.Errorf("failed to restore category: %w", err)
 	}
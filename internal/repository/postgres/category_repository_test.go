@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+func TestCategoryRepository_Tree_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn, creatorID := setupTagTestDB(t)
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	repo := NewCategoryRepository(dbConn.DB)
+	suffix := time.Now().UnixNano()
+
+	create := func(name string, parentID *string) *domain.Category {
+		t.Helper()
+		category := &domain.Category{Name: fmt.Sprintf("%s-%d", name, suffix), Color: "#3366FF", ParentID: parentID, CreatorID: creatorID}
+		if err := repo.Create(ctx, category); err != nil {
+			t.Fatalf("Failed to create category %s: %v", name, err)
+		}
+		return category
+	}
+
+	root := create("tree-root", nil)
+	child := create("tree-child", &root.ID)
+	grandchild := create("tree-grandchild", &child.ID)
+
+	t.Run("Subtree", func(t *testing.T) {
+		nodes, err := repo.GetSubtree(ctx, root.ID, 10)
+		if err != nil {
+			t.Fatalf("GetSubtree() error = %v", err)
+		}
+		if len(nodes) != 3 || nodes[0].Category.ID != root.ID || nodes[2].Category.ID != grandchild.ID || nodes[2].Depth != 3 {
+			t.Errorf("GetSubtree() = %v, want root, child and grandchild", nodes)
+		}
+
+		nodes, err = repo.GetSubtree(ctx, root.ID, 2)
+		if err != nil {
+			t.Fatalf("GetSubtree() error = %v", err)
+		}
+		if len(nodes) != 2 {
+			t.Errorf("GetSubtree() with max depth 2 returned %d nodes, want 2", len(nodes))
+		}
+	})
+
+	t.Run("Ancestors", func(t *testing.T) {
+		ancestors, err := repo.GetAncestors(ctx, grandchild.ID)
+		if err != nil {
+			t.Fatalf("GetAncestors() error = %v", err)
+		}
+		if len(ancestors) != 2 || ancestors[0].ID != child.ID || ancestors[1].ID != root.ID {
+			t.Errorf("GetAncestors() = %v, want child then root", ancestors)
+		}
+	})
+
+	t.Run("Top level filter", func(t *testing.T) {
+		topLevel := ""
+		categories, _, err := repo.List(ctx, repository.CategoryListOptions{
+			ListOptions: repository.ListOptions{PageSize: 100, SearchQuery: fmt.Sprint(suffix)},
+			ParentID:    &topLevel,
+		})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(categories) != 1 || categories[0].ID != root.ID {
+			t.Errorf("List() top level = %v, want only the root", categories)
+		}
+	})
+
+	t.Run("Cycle rejected", func(t *testing.T) {
+		moved := *root
+		moved.ParentID = &grandchild.ID
+		if err := repo.Update(ctx, &moved); !domain.IsBusinessRuleError(err) {
+			t.Errorf("Update() error = %v, want business rule violation", err)
+		}
+	})
+
+	t.Run("Locking the hierarchy", func(t *testing.T) {
+		err := NewTransactionManager(dbConn.DB).WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			return repo.LockHierarchy(txCtx)
+		})
+		if err != nil {
+			t.Errorf("LockHierarchy() error = %v", err)
+		}
+	})
+
+	t.Run("Cascade delete and restore", func(t *testing.T) {
+		// Deleted on its own first, so the cascading restore leaves it deleted
+		loner := create("tree-loner", &root.ID)
+		if err := repo.SoftDelete(ctx, loner.ID, loner.Version); err != nil {
+			t.Fatalf("SoftDelete() error = %v", err)
+		}
+
+		txManager := NewTransactionManager(dbConn.DB)
+		var deleted []string
+		err := txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			if err := repo.SoftDelete(txCtx, root.ID, root.Version); err != nil {
+				return err
+			}
+			var err error
+			deleted, err = repo.SoftDeleteDescendants(txCtx, root.ID)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("cascading delete error = %v", err)
+		}
+		if len(deleted) != 2 {
+			t.Errorf("SoftDeleteDescendants() = %v, want child and grandchild", deleted)
+		}
+
+		restored, err := repo.RestoreDescendants(ctx, root.ID)
+		if err != nil {
+			t.Fatalf("RestoreDescendants() error = %v", err)
+		}
+		if len(restored) != 2 {
+			t.Errorf("RestoreDescendants() = %v, want child and grandchild", restored)
+		}
+		if _, err := repo.GetByID(ctx, loner.ID); !domain.IsNotFoundError(err) {
+			t.Errorf("GetByID() of separately deleted category error = %v, want not found", err)
+		}
+		if err := repo.Restore(ctx, root.ID, root.Version+1); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		source := create("merge-source", nil)
+		target := create("merge-target", nil)
+		sourceChild := create("merge-source-child", &source.ID)
+
+		taskRepo := NewTaskRepository(dbConn.DB)
+		task := &domain.Task{Title: fmt.Sprintf("merge task %d", suffix), AssigneeID: creatorID,
+			Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium}
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		if err := taskRepo.AddCategories(ctx, task.ID, []string{source.ID, target.ID}, task.Version); err != nil {
+			t.Fatalf("Failed to add categories: %v", err)
+		}
+
+		taskIDs, err := repo.ReassignTasks(ctx, source.ID, target.ID)
+		if err != nil {
+			t.Fatalf("ReassignTasks() error = %v", err)
+		}
+		if len(taskIDs) != 1 || taskIDs[0] != task.ID {
+			t.Errorf("ReassignTasks() = %v, want %s", taskIDs, task.ID)
+		}
+		stored, err := taskRepo.GetByID(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("GetByID() task error = %v", err)
+		}
+		if len(stored.Categories) != 1 || stored.Categories[0].ID != target.ID {
+			t.Errorf("task categories = %v, want only the target", stored.Categories)
+		}
+
+		children, err := repo.MoveChildren(ctx, source.ID, target.ID)
+		if err != nil || len(children) != 1 || children[0] != sourceChild.ID {
+			t.Errorf("MoveChildren() = %v, %v; want %s", children, err, sourceChild.ID)
+		}
+
+		if err := repo.AddHistory(ctx, &domain.CategoryHistory{
+			CategoryID: source.ID,
+			Action:     domain.CategoryHistoryActionMerged,
+			ActorID:    creatorID,
+		}); err != nil {
+			t.Errorf("AddHistory() error = %v", err)
+		}
+	})
+}
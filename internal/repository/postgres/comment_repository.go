@@ -0,0 +1,236 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+const commentColumns = `
+		c.id, c.task_id, c.author_id, c.body, c.mentioned_user_ids,
+		c.created_at, c.updated_at, c.edited_at, c.version, c.is_deleted, c.deleted_at`
+
+type commentRepository struct {
+	db *sql.DB
+}
+
+// NewCommentRepository creates a new task comment repository
+func NewCommentRepository(db *sql.DB) repository.CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	comment.CreatedAt = now
+	comment.UpdatedAt = now
+	comment.Version = 1
+
+	if err := comment.IsValid(); err != nil {
+		return fmt.Errorf("invalid comment: %w", err)
+	}
+
+	query := `
+		INSERT INTO task_comments (id, task_id, author_id, body, mentioned_user_ids, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		comment.ID, comment.TaskID, comment.AuthorID, comment.Body, pq.Array(nonNilStrings(comment.MentionedUserIDs)),
+		comment.CreatedAt, comment.UpdatedAt, comment.Version)
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *commentRepository) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	query := `SELECT ` + commentColumns + `
+		FROM task_comments c
+		WHERE c.id = $1 AND c.is_deleted = false`
+
+	comment, err := scanComment(executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("comment")
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	if err := comment.IsValid(); err != nil {
+		return fmt.Errorf("invalid comment: %w", err)
+	}
+
+	query := `
+		UPDATE task_comments
+		SET body = $2, mentioned_user_ids = $3, edited_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND version = $4 AND is_deleted = false
+		RETURNING edited_at`
+
+	var editedAt time.Time
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query,
+		comment.ID, comment.Body, pq.Array(nonNilStrings(comment.MentionedUserIDs)), comment.Version).Scan(&editedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrVersionConflict("comment", comment.Version, comment.Version+1)
+		}
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	// Update version in memory
+	comment.Version++
+	comment.UpdatedAt = editedAt
+	comment.EditedAt = &editedAt
+
+	return nil
+}
+
+func (r *commentRepository) SoftDelete(ctx context.Context, id string, version int64) error {
+	query := `
+		UPDATE task_comments
+		SET is_deleted = true, deleted_at = NOW()
+		WHERE id = $1 AND version = $2 AND is_deleted = false`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete comment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionConflict("comment", version, version+1)
+	}
+
+	return nil
+}
+
+func (r *commentRepository) ListActivity(ctx context.Context, taskID string, opts repository.ListOptions) ([]*domain.ActivityItem, int64, error) {
+	executor := executorFromContext(ctx, r.db)
+
+	var total int64
+	countQuery := `
+		SELECT (SELECT COUNT(*) FROM task_history WHERE task_id = $1)
+		     + (SELECT COUNT(*) FROM task_comments WHERE task_id = $1 AND is_deleted = false)`
+	if err := executor.QueryRowContext(ctx, countQuery, taskID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count task activity: %w", err)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	offset := opts.Page * pageSize
+
+	// History entries sort before comments made at the same instant, so a comment written
+	// right after a change reads as a reply to it
+	query := `
+		SELECT kind, id, at, actor_id, action, details, body, mentioned_user_ids, updated_at, edited_at, version
+		FROM (
+			SELECT 1 AS kind, th.id, th.timestamp AS at, th.actor_id, th.action, th.details::text AS details,
+			       NULL::text AS body, NULL::uuid[] AS mentioned_user_ids, NULL::timestamptz AS updated_at,
+			       NULL::timestamptz AS edited_at, 0::bigint AS version
+			FROM task_history th
+			WHERE th.task_id = $1
+			UNION ALL
+			SELECT 2, c.id, c.created_at, c.author_id, '', NULL, c.body, c.mentioned_user_ids, c.updated_at,
+			       c.edited_at, c.version
+			FROM task_comments c
+			WHERE c.task_id = $1 AND c.is_deleted = false
+		) activity
+		ORDER BY at, kind, id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := executor.QueryContext(ctx, query, taskID, pageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list task activity: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.ActivityItem
+	for rows.Next() {
+		var (
+			kind                int
+			id, actorID, action string
+			at                  time.Time
+			details, body       sql.NullString
+			mentions            pq.StringArray
+			updatedAt, editedAt *time.Time
+			version             int64
+		)
+		err := rows.Scan(&kind, &id, &at, &actorID, &action, &details, &body, &mentions, &updatedAt, &editedAt, &version)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan task activity: %w", err)
+		}
+
+		item := &domain.ActivityItem{Timestamp: at}
+		if kind == 1 {
+			item.History = &domain.TaskHistory{
+				ID:        id,
+				TaskID:    taskID,
+				Action:    domain.TaskHistoryAction(action),
+				ActorID:   actorID,
+				Timestamp: at,
+			}
+			if details.Valid {
+				item.History.Details = []byte(details.String)
+			}
+		} else {
+			item.Comment = &domain.Comment{
+				ID:        id,
+				TaskID:    taskID,
+				AuthorID:  actorID,
+				Body:      body.String,
+				CreatedAt: at,
+				EditedAt:  editedAt,
+				Version:   version,
+			}
+			if len(mentions) > 0 {
+				item.Comment.MentionedUserIDs = mentions
+			}
+			if updatedAt != nil {
+				item.Comment.UpdatedAt = *updatedAt
+			}
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list task activity: %w", err)
+	}
+
+	return items, total, nil
+}
+
+func scanComment(row rowScanner) (*domain.Comment, error) {
+	comment := &domain.Comment{}
+	var mentions pq.StringArray
+
+	err := row.Scan(
+		&comment.ID, &comment.TaskID, &comment.AuthorID, &comment.Body, &mentions,
+		&comment.CreatedAt, &comment.UpdatedAt, &comment.EditedAt, &comment.Version, &comment.IsDeleted, &comment.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	if len(mentions) > 0 {
+		comment.MentionedUserIDs = mentions
+	}
+
+	return comment, nil
+}
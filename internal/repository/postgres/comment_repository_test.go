@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+func TestCommentRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn, ownerID := setupTagTestDB(t)
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	repo := NewCommentRepository(dbConn.DB)
+	taskRepo := NewTaskRepository(dbConn.DB)
+
+	task := &domain.Task{Title: "Discussed", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, AssigneeID: ownerID}
+	if err := taskRepo.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	created := &domain.TaskHistory{TaskID: task.ID, Action: domain.TaskHistoryActionCreated, ActorID: ownerID, Timestamp: time.Now().Add(-time.Minute)}
+	if err := taskRepo.AddHistory(ctx, created); err != nil {
+		t.Fatalf("Failed to add history: %v", err)
+	}
+
+	comment := &domain.Comment{TaskID: task.ID, AuthorID: ownerID, Body: "First", MentionedUserIDs: []string{ownerID}}
+
+	t.Run("Create and get", func(t *testing.T) {
+		if err := repo.Create(ctx, comment); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		stored, err := repo.GetByID(ctx, comment.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if stored.Body != "First" || len(stored.MentionedUserIDs) != 1 || stored.EditedAt != nil {
+			t.Errorf("stored comment = %+v", stored)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		comment.Body = "First, edited"
+		comment.MentionedUserIDs = nil
+		if err := repo.Update(ctx, comment); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		stale := *comment
+		stale.Version--
+		if err := repo.Update(ctx, &stale); !domain.IsVersionConflictError(err) {
+			t.Errorf("Update() with a stale version error = %v, want version conflict", err)
+		}
+
+		stored, _ := repo.GetByID(ctx, comment.ID)
+		if stored.Body != "First, edited" || stored.EditedAt == nil || stored.Version != comment.Version || len(stored.MentionedUserIDs) != 0 {
+			t.Errorf("stored comment = %+v, want %+v", stored, comment)
+		}
+	})
+
+	t.Run("Activity", func(t *testing.T) {
+		second := &domain.Comment{TaskID: task.ID, AuthorID: ownerID, Body: "Second"}
+		if err := repo.Create(ctx, second); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if err := repo.SoftDelete(ctx, second.ID, second.Version); err != nil {
+			t.Fatalf("SoftDelete() error = %v", err)
+		}
+
+		items, total, err := repo.ListActivity(ctx, task.ID, repository.ListOptions{PageSize: 10})
+		if err != nil {
+			t.Fatalf("ListActivity() error = %v", err)
+		}
+		// The history entry is older than the comments; the deleted comment is left out
+		last := items[len(items)-1]
+		if total != int64(len(items)) || last.Comment == nil || last.Comment.ID != comment.ID || items[0].History == nil {
+			t.Errorf("ListActivity() = %d items of %d, last = %+v", len(items), total, last)
+		}
+	})
+}
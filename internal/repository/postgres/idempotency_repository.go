@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+type idempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(db *sql.DB) repository.IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+func (r *idempotencyRepository) Claim(ctx context.Context, record *domain.IdempotencyRecord, staleAfter time.Duration) (*domain.IdempotencyRecord, bool, error) {
+	now := time.Now()
+	record.CreatedAt = now
+	record.CompletedAt = nil
+
+	// Insert the claim, or take over a row that expired or whose owner never finished
+	query := `
+		INSERT INTO idempotency_keys (caller_id, idempotency_key, method, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (caller_id, idempotency_key) DO UPDATE SET
+			method = EXCLUDED.method,
+			request_hash = EXCLUDED.request_hash,
+			response_type = NULL,
+			response = NULL,
+			created_at = EXCLUDED.created_at,
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= $5
+		   OR (idempotency_keys.completed_at IS NULL AND idempotency_keys.created_at <= $7)`
+
+	result, err := r.db.ExecContext(ctx, query,
+		record.CallerID, record.Key, record.Method, record.RequestHash,
+		record.CreatedAt, record.ExpiresAt, now.Add(-staleAfter))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected > 0 {
+		return nil, true, nil
+	}
+
+	existing, err := r.Get(ctx, record.CallerID, record.Key)
+	if err != nil {
+		return nil, false, err
+	}
+	return existing, false, nil
+}
+
+func (r *idempotencyRepository) Get(ctx context.Context, callerID, key string) (*domain.IdempotencyRecord, error) {
+	query := `
+		SELECT caller_id, idempotency_key, method, request_hash, response_type, response,
+		       created_at, completed_at, expires_at
+		FROM idempotency_keys
+		WHERE caller_id = $1 AND idempotency_key = $2`
+
+	record := &domain.IdempotencyRecord{}
+	var responseType sql.NullString
+
+	err := r.db.QueryRowContext(ctx, query, callerID, key).Scan(
+		&record.CallerID, &record.Key, &record.Method, &record.RequestHash,
+		&responseType, &record.Response,
+		&record.CreatedAt, &record.CompletedAt, &record.ExpiresAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("idempotency key")
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	record.ResponseType = responseType.String
+	return record, nil
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	now := time.Now()
+
+	query := `
+		UPDATE idempotency_keys
+		SET response_type = $4, response = $5, completed_at = $6
+		WHERE caller_id = $1 AND idempotency_key = $2 AND request_hash = $3 AND completed_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query,
+		record.CallerID, record.Key, record.RequestHash,
+		record.ResponseType, record.Response, now)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound("pending idempotency key")
+	}
+
+	record.CompletedAt = &now
+	return nil
+}
+
+func (r *idempotencyRepository) Renew(ctx context.Context, callerID, key, requestHash string) error {
+	// created_at is the claim time that Claim compares against staleAfter
+	query := `
+		UPDATE idempotency_keys
+		SET created_at = $4
+		WHERE caller_id = $1 AND idempotency_key = $2 AND request_hash = $3 AND completed_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, callerID, key, requestHash, time.Now()); err != nil {
+		return fmt.Errorf("failed to renew idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) Release(ctx context.Context, callerID, key, requestHash string) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE caller_id = $1 AND idempotency_key = $2 AND request_hash = $3 AND completed_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, callerID, key, requestHash); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
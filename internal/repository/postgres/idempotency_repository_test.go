@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func TestIdempotencyRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn := setupTestDB(t)
+	defer dbConn.Close()
+
+	repo := NewIdempotencyRepository(dbConn.DB)
+	ctx := context.Background()
+
+	record := &domain.IdempotencyRecord{
+		CallerID:    "user:integration-test",
+		Key:         fmt.Sprintf("key-%d", time.Now().UnixNano()),
+		Method:      "/todo.v1.AdminService/CreateTask",
+		RequestHash: fmt.Sprintf("%064d", 1),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	t.Run("Claim new key", func(t *testing.T) {
+		existing, claimed, err := repo.Claim(ctx, record, time.Minute)
+		if err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		if !claimed || existing != nil {
+			t.Errorf("Claim() claimed = %v, existing = %v, want a fresh claim", claimed, existing)
+		}
+	})
+
+	t.Run("Claim pending key returns existing record", func(t *testing.T) {
+		duplicate := *record
+		existing, claimed, err := repo.Claim(ctx, &duplicate, time.Minute)
+		if err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		if claimed {
+			t.Fatal("Claim() should not claim a pending key")
+		}
+		if existing.IsCompleted() || !existing.Matches(record.Method, record.RequestHash) {
+			t.Errorf("existing record = %+v, want matching pending record", existing)
+		}
+	})
+
+	t.Run("Renew keeps a pending claim from going stale", func(t *testing.T) {
+		time.Sleep(200 * time.Millisecond)
+		if err := repo.Renew(ctx, record.CallerID, record.Key, record.RequestHash); err != nil {
+			t.Fatalf("Renew() error = %v", err)
+		}
+		duplicate := *record
+		if _, claimed, err := repo.Claim(ctx, &duplicate, 100*time.Millisecond); err != nil || claimed {
+			t.Errorf("Claim() after Renew() = %v, %v; want the renewed claim kept", claimed, err)
+		}
+	})
+
+	t.Run("Complete stores the response", func(t *testing.T) {
+		record.ResponseType = "todo.v1.CreateTaskResponse"
+		record.Response = []byte{0x0a, 0x00}
+		if err := repo.Complete(ctx, record); err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+
+		stored, err := repo.Get(ctx, record.CallerID, record.Key)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if !stored.IsCompleted() || stored.ResponseType != record.ResponseType {
+			t.Errorf("stored record = %+v, want completed with response type", stored)
+		}
+	})
+
+	t.Run("Release does not drop completed keys", func(t *testing.T) {
+		if err := repo.Release(ctx, record.CallerID, record.Key, record.RequestHash); err != nil {
+			t.Fatalf("Release() error = %v", err)
+		}
+		if _, err := repo.Get(ctx, record.CallerID, record.Key); err != nil {
+			t.Errorf("Get() after Release() error = %v, want record kept", err)
+		}
+	})
+}
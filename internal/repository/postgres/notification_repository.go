@@ -0,0 +1,549 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+const dueTaskColumns = `t.id, t.title, t.status, t.assignee_id, t.due_date`
+
+type notificationRepository struct {
+	db *sql.DB
+}
+
+// NewNotificationRepository creates a new watcher and notification repository
+func NewNotificationRepository(db *sql.DB) repository.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) WatchTask(ctx context.Context, userID, taskID string) error {
+	query := `
+		INSERT INTO task_watchers (task_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (task_id, user_id) DO NOTHING`
+
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, taskID, userID); err != nil {
+		return fmt.Errorf("failed to watch task: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) UnwatchTask(ctx context.Context, userID, taskID string) error {
+	query := `DELETE FROM task_watchers WHERE task_id = $1 AND user_id = $2`
+
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, taskID, userID); err != nil {
+		return fmt.Errorf("failed to unwatch task: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) WatchCategory(ctx context.Context, userID, categoryID string) error {
+	query := `
+		INSERT INTO category_watchers (category_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (category_id, user_id) DO NOTHING`
+
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, categoryID, userID); err != nil {
+		return fmt.Errorf("failed to watch category: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) UnwatchCategory(ctx context.Context, userID, categoryID string) error {
+	query := `DELETE FROM category_watchers WHERE category_id = $1 AND user_id = $2`
+
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, categoryID, userID); err != nil {
+		return fmt.Errorf("failed to unwatch category: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) ListWatches(ctx context.Context, userID string) (*domain.Watches, error) {
+	executor := executorFromContext(ctx, r.db)
+	watches := &domain.Watches{}
+
+	taskQuery := `
+		SELECT w.task_id
+		FROM task_watchers w
+		JOIN tasks t ON t.id = w.task_id AND t.is_deleted = false
+		WHERE w.user_id = $1
+		ORDER BY w.created_at, w.task_id`
+	taskIDs, err := queryIDs(ctx, executor, taskQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watched tasks: %w", err)
+	}
+	watches.TaskIDs = taskIDs
+
+	categoryQuery := `
+		SELECT w.category_id
+		FROM category_watchers w
+		JOIN categories c ON c.id = w.category_id AND c.is_deleted = false
+		WHERE w.user_id = $1
+		ORDER BY w.created_at, w.category_id`
+	categoryIDs, err := queryIDs(ctx, executor, categoryQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watched categories: %w", err)
+	}
+	watches.CategoryIDs = categoryIDs
+
+	return watches, nil
+}
+
+func (r *notificationRepository) Watchers(ctx context.Context, taskID string) ([]string, error) {
+	// Watching a category covers its subcategories, so the task's categories are walked up
+	query := `
+		WITH RECURSIVE task_category_ancestors(id) AS (
+			SELECT category_id FROM task_categories WHERE task_id = $1
+			UNION
+			SELECT c.parent_id
+			FROM categories c
+			JOIN task_category_ancestors a ON c.id = a.id
+			WHERE c.parent_id IS NOT NULL
+		)
+		SELECT r.user_id
+		FROM (
+			SELECT user_id FROM task_watchers WHERE task_id = $1
+			UNION
+			SELECT w.user_id FROM category_watchers w JOIN task_category_ancestors a ON w.category_id = a.id
+			UNION
+			SELECT user_id FROM task_participants WHERE task_id = $1
+		) r
+		JOIN users u ON u.id = r.user_id AND u.is_deleted = false
+		ORDER BY r.user_id`
+
+	userIDs, err := queryIDs(ctx, executorFromContext(ctx, r.db), query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task watchers: %w", err)
+	}
+	return userIDs, nil
+}
+
+func (r *notificationRepository) WatchedTaskIDs(ctx context.Context, userID string) ([]string, error) {
+	query := `
+		WITH RECURSIVE watched_categories(id) AS (
+			SELECT category_id FROM category_watchers WHERE user_id = $1
+			UNION
+			SELECT c.id FROM categories c JOIN watched_categories w ON c.parent_id = w.id
+		)
+		SELECT t.id
+		FROM tasks t
+		WHERE t.is_deleted = false AND (
+			EXISTS (SELECT 1 FROM task_participants p WHERE p.task_id = t.id AND p.user_id = $1)
+			OR EXISTS (SELECT 1 FROM task_watchers w WHERE w.task_id = t.id AND w.user_id = $1)
+			OR EXISTS (
+				SELECT 1 FROM task_categories tc JOIN watched_categories w ON tc.category_id = w.id
+				WHERE tc.task_id = t.id
+			)
+		)
+		ORDER BY t.id`
+
+	taskIDs, err := queryIDs(ctx, executorFromContext(ctx, r.db), query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watched tasks: %w", err)
+	}
+	return taskIDs, nil
+}
+
+func (r *notificationRepository) ListNotifiedUsers(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT u.id
+		FROM users u
+		WHERE u.is_deleted = false AND (
+			EXISTS (SELECT 1 FROM task_watchers w WHERE w.user_id = u.id)
+			OR EXISTS (SELECT 1 FROM category_watchers w WHERE w.user_id = u.id)
+			OR EXISTS (
+				SELECT 1 FROM task_participants p JOIN tasks t ON t.id = p.task_id
+				WHERE p.user_id = u.id AND t.is_deleted = false
+			)
+		)
+		ORDER BY u.id`
+
+	userIDs, err := queryIDs(ctx, executorFromContext(ctx, r.db), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notified users: %w", err)
+	}
+	return userIDs, nil
+}
+
+func (r *notificationRepository) GetSettings(ctx context.Context, userID string) (*domain.NotificationSettings, error) {
+	executor := executorFromContext(ctx, r.db)
+	settings := domain.DefaultNotificationSettings(userID)
+
+	query := `
+		SELECT time_zone, quiet_hours_start, quiet_hours_end, updated_at, version
+		FROM notification_settings
+		WHERE user_id = $1`
+
+	var start, end sql.NullInt32
+	err := executor.QueryRowContext(ctx, query, userID).Scan(&settings.TimeZone, &start, &end, &settings.UpdatedAt, &settings.Version)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+	if start.Valid && end.Valid {
+		settings.QuietHours = &domain.QuietHours{Start: int(start.Int32), End: int(end.Int32)}
+	}
+
+	prefQuery := `
+		SELECT event, channel, mode
+		FROM notification_preferences
+		WHERE user_id = $1
+		ORDER BY event, channel`
+
+	rows, err := executor.QueryContext(ctx, prefQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pref domain.NotificationPreference
+		if err := rows.Scan(&pref.Event, &pref.Channel, &pref.Mode); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+		settings.Preferences = append(settings.Preferences, pref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return settings, nil
+}
+
+func (r *notificationRepository) SaveSettings(ctx context.Context, settings *domain.NotificationSettings) error {
+	if err := settings.IsValid(); err != nil {
+		return fmt.Errorf("invalid notification settings: %w", err)
+	}
+
+	var start, end interface{}
+	if settings.QuietHours != nil {
+		start, end = settings.QuietHours.Start, settings.QuietHours.End
+	}
+
+	query := `
+		UPDATE notification_settings
+		SET time_zone = $2, quiet_hours_start = $3, quiet_hours_end = $4
+		WHERE user_id = $1 AND version = $5
+		RETURNING updated_at, version`
+	args := []interface{}{settings.UserID, settings.TimeZone, start, end, settings.Version}
+	if settings.Version == 0 {
+		// Saving the defaults for the first time; a concurrent first save wins
+		query = `
+			INSERT INTO notification_settings (user_id, time_zone, quiet_hours_start, quiet_hours_end)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id) DO NOTHING
+			RETURNING updated_at, version`
+		args = args[:4]
+	}
+
+	tx, err := beginScopedTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var updatedAt time.Time
+	var version int64
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&updatedAt, &version); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrVersionConflict("notification settings", settings.Version, settings.Version+1)
+		}
+		return fmt.Errorf("failed to save notification settings: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM notification_preferences WHERE user_id = $1`, settings.UserID); err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	for _, pref := range settings.Preferences {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notification_preferences (user_id, event, channel, mode)
+			VALUES ($1, $2, $3, $4)`,
+			settings.UserID, string(pref.Event), string(pref.Channel), string(pref.Mode))
+		if err != nil {
+			return fmt.Errorf("failed to save notification preferences: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit notification settings: %w", err)
+	}
+
+	settings.UpdatedAt = updatedAt
+	settings.Version = version
+	return nil
+}
+
+func (r *notificationRepository) LastDigest(ctx context.Context, userID string, channel domain.NotificationChannel) (*time.Time, error) {
+	query := `SELECT last_sent_at FROM notification_digests WHERE user_id = $1 AND channel = $2`
+
+	var lastSent time.Time
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, userID, string(channel)).Scan(&lastSent)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get last digest: %w", err)
+	}
+	return &lastSent, nil
+}
+
+func (r *notificationRepository) MarkDigestSent(ctx context.Context, userID string, channel domain.NotificationChannel, at time.Time) error {
+	query := `
+		INSERT INTO notification_digests (user_id, channel, last_sent_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, channel) DO UPDATE SET last_sent_at = EXCLUDED.last_sent_at`
+
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, userID, string(channel), at); err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) LockCursor(ctx context.Context, name string, initial time.Time) (time.Time, error) {
+	executor := executorFromContext(ctx, r.db)
+
+	insert := `
+		INSERT INTO notification_cursors (name, position)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO NOTHING`
+	if _, err := executor.ExecContext(ctx, insert, name, initial); err != nil {
+		return time.Time{}, fmt.Errorf("failed to start notification cursor: %w", err)
+	}
+
+	var position time.Time
+	query := `SELECT position FROM notification_cursors WHERE name = $1 FOR UPDATE`
+	if err := executor.QueryRowContext(ctx, query, name).Scan(&position); err != nil {
+		return time.Time{}, fmt.Errorf("failed to lock notification cursor: %w", err)
+	}
+	return position, nil
+}
+
+func (r *notificationRepository) SetCursor(ctx context.Context, name string, position time.Time) error {
+	query := `UPDATE notification_cursors SET position = $2 WHERE name = $1`
+
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, name, position); err != nil {
+		return fmt.Errorf("failed to move notification cursor: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) ListActivity(ctx context.Context, from, to time.Time, taskIDs []string) ([]*domain.ActivityItem, error) {
+	// History entries sort before comments made at the same instant, as in a task's activity feed
+	query := `
+		SELECT kind, id, task_id, at, actor_id, action, details, body, mentioned_user_ids
+		FROM (
+			SELECT 1 AS kind, th.id, th.task_id, th.timestamp AS at, th.actor_id, th.action,
+			       th.details::text AS details, NULL::text AS body, NULL::uuid[] AS mentioned_user_ids
+			FROM task_history th
+			JOIN tasks t ON t.id = th.task_id AND t.is_deleted = false
+			WHERE th.timestamp > $1 AND th.timestamp <= $2 AND ($3::uuid[] IS NULL OR th.task_id = ANY($3))
+			UNION ALL
+			SELECT 2, c.id, c.task_id, c.created_at, c.author_id, '', NULL, c.body, c.mentioned_user_ids
+			FROM task_comments c
+			JOIN tasks t ON t.id = c.task_id AND t.is_deleted = false
+			WHERE c.is_deleted = false AND c.created_at > $1 AND c.created_at <= $2
+			  AND ($3::uuid[] IS NULL OR c.task_id = ANY($3))
+		) activity
+		ORDER BY at, kind, id`
+
+	var ids interface{}
+	if taskIDs != nil {
+		ids = pq.Array(nonNilStrings(taskIDs))
+	}
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, from, to, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task activity: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.ActivityItem
+	for rows.Next() {
+		var (
+			kind                        int
+			id, taskID, actorID, action string
+			at                          time.Time
+			details, body               sql.NullString
+			mentions                    pq.StringArray
+		)
+		if err := rows.Scan(&kind, &id, &taskID, &at, &actorID, &action, &details, &body, &mentions); err != nil {
+			return nil, fmt.Errorf("failed to scan task activity: %w", err)
+		}
+
+		item := &domain.ActivityItem{Timestamp: at}
+		if kind == 1 {
+			item.History = &domain.TaskHistory{
+				ID:        id,
+				TaskID:    taskID,
+				Action:    domain.TaskHistoryAction(action),
+				ActorID:   actorID,
+				Timestamp: at,
+			}
+			if details.Valid {
+				item.History.Details = []byte(details.String)
+			}
+		} else {
+			item.Comment = &domain.Comment{
+				ID:        id,
+				TaskID:    taskID,
+				AuthorID:  actorID,
+				Body:      body.String,
+				CreatedAt: at,
+			}
+			if len(mentions) > 0 {
+				item.Comment.MentionedUserIDs = mentions
+			}
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list task activity: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *notificationRepository) ClaimDueSoon(ctx context.Context, from, to time.Time) ([]*domain.Task, error) {
+	query := `
+		WITH claimed AS (
+			INSERT INTO task_due_notifications (task_id, due_date)
+			SELECT id, due_date
+			FROM tasks
+			WHERE is_deleted = false AND status NOT IN ('COMPLETED', 'CANCELLED')
+			  AND due_date > $1 AND due_date <= $2
+			ON CONFLICT (task_id, due_date) DO NOTHING
+			RETURNING task_id
+		)
+		SELECT ` + dueTaskColumns + `
+		FROM tasks t
+		JOIN claimed c ON c.task_id = t.id
+		ORDER BY t.due_date, t.id`
+
+	tasks, err := r.queryDueTasks(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim tasks due soon: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *notificationRepository) ListDueTasks(ctx context.Context, taskIDs []string, from, to time.Time) ([]*domain.Task, error) {
+	if len(taskIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + dueTaskColumns + `
+		FROM tasks t
+		WHERE t.is_deleted = false AND t.status NOT IN ('COMPLETED', 'CANCELLED')
+		  AND t.due_date > $1 AND t.due_date <= $2 AND t.id = ANY($3)
+		ORDER BY t.due_date, t.id`
+
+	tasks, err := r.queryDueTasks(ctx, query, from, to, pq.Array(taskIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *notificationRepository) queryDueTasks(ctx context.Context, query string, args ...interface{}) ([]*domain.Task, error) {
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task := &domain.Task{}
+		if err := rows.Scan(&task.ID, &task.Title, &task.Status, &task.AssigneeID, &task.DueDate); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+func (r *notificationRepository) Enqueue(ctx context.Context, notifications []*domain.Notification) error {
+	executor := executorFromContext(ctx, r.db)
+	query := `
+		INSERT INTO notification_queue (id, user_id, channel, is_digest, items, created_at, deliver_after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	for _, notification := range notifications {
+		if notification.ID == "" {
+			notification.ID = uuid.New().String()
+		}
+		if notification.CreatedAt.IsZero() {
+			notification.CreatedAt = time.Now()
+		}
+		items, err := json.Marshal(notification.Items)
+		if err != nil {
+			return fmt.Errorf("failed to encode notification items: %w", err)
+		}
+
+		_, err = executor.ExecContext(ctx, query,
+			notification.ID, notification.UserID, string(notification.Channel), notification.Digest, items,
+			notification.CreatedAt, notification.DeliverAfter)
+		if err != nil {
+			return fmt.Errorf("failed to enqueue notification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) PendingNotifications(ctx context.Context, now time.Time, limit int) ([]*domain.Notification, error) {
+	query := `
+		SELECT id, user_id, channel, is_digest, items, created_at, deliver_after
+		FROM notification_queue
+		WHERE deliver_after <= $1
+		ORDER BY deliver_after, created_at, id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*domain.Notification
+	for rows.Next() {
+		notification := &domain.Notification{}
+		var items []byte
+		err := rows.Scan(&notification.ID, &notification.UserID, &notification.Channel, &notification.Digest,
+			&items, &notification.CreatedAt, &notification.DeliverAfter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending notification: %w", err)
+		}
+		if err := json.Unmarshal(items, &notification.Items); err != nil {
+			return nil, fmt.Errorf("failed to decode notification items: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list pending notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepository) DeleteNotifications(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `DELETE FROM notification_queue WHERE id = ANY($1)`
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to delete notifications: %w", err)
+	}
+	return nil
+}
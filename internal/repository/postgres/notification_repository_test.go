@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func TestNotificationRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn, ownerID := setupTagTestDB(t)
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	repo := NewNotificationRepository(dbConn.DB)
+	taskRepo := NewTaskRepository(dbConn.DB)
+	txManager := NewTransactionManager(dbConn.DB)
+
+	watcher := &domain.User{Name: "Watcher", Email: fmt.Sprintf("watcher-%d@example.com", time.Now().UnixNano()), Role: domain.UserRoleUser}
+	if err := NewUserRepository(dbConn.DB).Create(ctx, watcher); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	due := time.Now().Add(2 * time.Hour)
+	task := &domain.Task{Title: "Watched", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, AssigneeID: ownerID, DueDate: &due}
+	if err := taskRepo.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	t.Run("Watchers", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if err := repo.WatchTask(ctx, watcher.ID, task.ID); err != nil {
+				t.Fatalf("WatchTask() error = %v", err)
+			}
+		}
+		watchers, err := repo.Watchers(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("Watchers() error = %v", err)
+		}
+		if len(watchers) != 2 {
+			t.Errorf("Watchers() = %v, want the assignee and the watcher", watchers)
+		}
+
+		watched, err := repo.WatchedTaskIDs(ctx, watcher.ID)
+		if err != nil {
+			t.Fatalf("WatchedTaskIDs() error = %v", err)
+		}
+		if len(watched) != 1 || watched[0] != task.ID {
+			t.Errorf("WatchedTaskIDs() = %v, want [%s]", watched, task.ID)
+		}
+
+		if err := repo.UnwatchTask(ctx, watcher.ID, task.ID); err != nil {
+			t.Fatalf("UnwatchTask() error = %v", err)
+		}
+		if watches, _ := repo.ListWatches(ctx, watcher.ID); len(watches.TaskIDs) != 0 {
+			t.Errorf("ListWatches() after unwatching = %+v", watches)
+		}
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		settings, err := repo.GetSettings(ctx, watcher.ID)
+		if err != nil {
+			t.Fatalf("GetSettings() error = %v", err)
+		}
+		if settings.Version != 0 || settings.TimeZone != "UTC" {
+			t.Errorf("default settings = %+v", settings)
+		}
+
+		settings.TimeZone = "Europe/Berlin"
+		settings.QuietHours = &domain.QuietHours{Start: 22 * 60, End: 7 * 60}
+		settings.Preferences = []domain.NotificationPreference{{Event: domain.NotificationCommented, Channel: domain.NotificationChannelEmail, Mode: domain.NotificationOff}}
+		if err := repo.SaveSettings(ctx, settings); err != nil {
+			t.Fatalf("SaveSettings() error = %v", err)
+		}
+		stale := *settings
+		stale.Version = 0
+		if err := repo.SaveSettings(ctx, &stale); !domain.IsVersionConflictError(err) {
+			t.Errorf("SaveSettings() with a stale version error = %v, want version conflict", err)
+		}
+
+		stored, _ := repo.GetSettings(ctx, watcher.ID)
+		if stored.Version != settings.Version || stored.QuietHours == nil || *stored.QuietHours != *settings.QuietHours ||
+			stored.Mode(domain.NotificationCommented, domain.NotificationChannelEmail) != domain.NotificationOff {
+			t.Errorf("stored settings = %+v, want %+v", stored, settings)
+		}
+	})
+
+	t.Run("Due soon is claimed once", func(t *testing.T) {
+		now := time.Now()
+		for i, want := range []int{1, 0} {
+			tasks, err := repo.ClaimDueSoon(ctx, now, now.Add(3*time.Hour))
+			if err != nil {
+				t.Fatalf("ClaimDueSoon() error = %v", err)
+			}
+			claimed := 0
+			for _, claimedTask := range tasks {
+				if claimedTask.ID == task.ID {
+					claimed++
+				}
+			}
+			if claimed != want {
+				t.Errorf("claim %d returned the task %d times, want %d", i+1, claimed, want)
+			}
+		}
+	})
+
+	t.Run("Queue", func(t *testing.T) {
+		now := time.Now()
+		later := &domain.Notification{UserID: watcher.ID, Channel: domain.NotificationChannelInApp, CreatedAt: now, DeliverAfter: now.Add(time.Hour),
+			Items: []domain.NotificationItem{{Event: domain.NotificationDueSoon, TaskID: task.ID, TaskTitle: task.Title, At: now}}}
+		ready := &domain.Notification{UserID: watcher.ID, Channel: domain.NotificationChannelEmail, Digest: true, CreatedAt: now, DeliverAfter: now,
+			Items: []domain.NotificationItem{{Event: domain.NotificationCommented, TaskID: task.ID, TaskTitle: task.Title, Summary: "Hi", At: now}}}
+		if err := repo.Enqueue(ctx, []*domain.Notification{later, ready}); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+
+		err := txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			pending, err := repo.PendingNotifications(txCtx, now.Add(time.Second), 10)
+			if err != nil {
+				return err
+			}
+			found := 0
+			for _, notification := range pending {
+				if notification.ID == later.ID {
+					t.Errorf("PendingNotifications() returned a notification held until later")
+				}
+				if notification.ID == ready.ID {
+					found++
+					if !notification.Digest || len(notification.Items) != 1 || notification.Items[0].Summary != "Hi" {
+						t.Errorf("pending notification = %+v", notification)
+					}
+				}
+			}
+			if found != 1 {
+				t.Errorf("PendingNotifications() did not return the ready notification")
+			}
+			return repo.DeleteNotifications(txCtx, []string{ready.ID, later.ID})
+		})
+		if err != nil {
+			t.Fatalf("delivery transaction error = %v", err)
+		}
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		name := fmt.Sprintf("test-%d", time.Now().UnixNano())
+		initial := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+		err := txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			position, err := repo.LockCursor(txCtx, name, initial)
+			if err != nil {
+				return err
+			}
+			if !position.Equal(initial) {
+				t.Errorf("LockCursor() = %v, want %v", position, initial)
+			}
+			return repo.SetCursor(txCtx, name, initial.Add(time.Minute))
+		})
+		if err != nil {
+			t.Fatalf("cursor transaction error = %v", err)
+		}
+
+		position, err := repo.LockCursor(ctx, name, time.Now())
+		if err != nil {
+			t.Fatalf("LockCursor() error = %v", err)
+		}
+		if !position.Equal(initial.Add(time.Minute)) {
+			t.Errorf("LockCursor() after SetCursor = %v, want %v", position, initial.Add(time.Minute))
+		}
+	})
+}
@@ -0,0 +1,251 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+// savedViewColumns selects a saved view along with the filter's categories and tags
+// that have since been deleted
+const savedViewColumns = `
+		sv.id, sv.owner_id, sv.name, COALESCE(sv.description, ''), sv.visibility,
+		COALESCE(sv.shared_role, ''), sv.filter, COALESCE(sv.sort_by, ''), sv.sort_desc, sv.columns,
+		sv.created_at, sv.updated_at, sv.version, sv.is_deleted, sv.deleted_at,
+		ARRAY(SELECT f.id FROM jsonb_array_elements_text(sv.filter->'category_ids') AS f(id)
+		      WHERE NOT EXISTS (SELECT 1 FROM categories cat WHERE cat.id::text = f.id AND NOT cat.is_deleted)),
+		ARRAY(SELECT f.id FROM jsonb_array_elements_text(sv.filter->'tag_ids') AS f(id)
+		      WHERE NOT EXISTS (SELECT 1 FROM tags tg WHERE tg.id::text = f.id AND NOT tg.is_deleted))`
+
+// uniqueViolation is the PostgreSQL error code for unique constraint violations
+const uniqueViolation = "23505"
+
+type savedViewRepository struct {
+	db *sql.DB
+}
+
+// NewSavedViewRepository creates a new saved view repository
+func NewSavedViewRepository(db *sql.DB) repository.SavedViewRepository {
+	return &savedViewRepository{db: db}
+}
+
+func (r *savedViewRepository) Create(ctx context.Context, view *domain.SavedView) error {
+	if view.ID == "" {
+		view.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	view.CreatedAt = now
+	view.UpdatedAt = now
+	view.Version = 1
+
+	if err := view.IsValid(); err != nil {
+		return fmt.Errorf("invalid saved view: %w", err)
+	}
+
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to encode saved view filter: %w", err)
+	}
+
+	query := `
+		INSERT INTO saved_views (id, owner_id, name, description, visibility, shared_role, filter,
+		                         sort_by, sort_desc, columns, created_at, updated_at, version)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, NULLIF($6, ''), $7, NULLIF($8, ''), $9, $10, $11, $12, $13)`
+
+	_, err = executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		view.ID, view.OwnerID, view.Name, view.Description, string(view.Visibility), string(view.SharedRole),
+		filter, view.SortBy, view.SortDesc, pq.Array(nonNilStrings(view.Columns)),
+		view.CreatedAt, view.UpdatedAt, view.Version)
+	if err != nil {
+		return savedViewWriteError("create", view.Name, err)
+	}
+
+	return nil
+}
+
+func (r *savedViewRepository) GetByID(ctx context.Context, id string) (*domain.SavedView, error) {
+	query := `SELECT ` + savedViewColumns + `
+		FROM saved_views sv
+		WHERE sv.id = $1 AND sv.is_deleted = false`
+
+	view, err := scanSavedView(executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("saved view")
+		}
+		return nil, fmt.Errorf("failed to get saved view: %w", err)
+	}
+
+	return view, nil
+}
+
+func (r *savedViewRepository) List(ctx context.Context, opts repository.SavedViewListOptions) ([]*domain.SavedView, int64, error) {
+	whereClause := `WHERE sv.is_deleted = false AND (sv.owner_id::text = $1 OR (sv.visibility = 'ROLE' AND sv.shared_role = $2))`
+	args := []interface{}{opts.UserID, string(opts.Role)}
+
+	executor := executorFromContext(ctx, r.db)
+
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM saved_views sv " + whereClause
+	if err := executor.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count saved views: %w", err)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	offset := opts.Page * pageSize
+
+	// The caller's own views come first, then shared ones, each by name
+	query := fmt.Sprintf(`SELECT %s
+		FROM saved_views sv
+		%s
+		ORDER BY (sv.owner_id::text = $1) DESC, lower(sv.name), sv.id
+		LIMIT $3 OFFSET $4`, savedViewColumns, whereClause)
+	args = append(args, pageSize, offset)
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list saved views: %w", err)
+	}
+	defer rows.Close()
+
+	var views []*domain.SavedView
+	for rows.Next() {
+		view, err := scanSavedView(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan saved view: %w", err)
+		}
+		views = append(views, view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list saved views: %w", err)
+	}
+
+	return views, total, nil
+}
+
+func (r *savedViewRepository) Update(ctx context.Context, view *domain.SavedView) error {
+	if err := view.IsValid(); err != nil {
+		return fmt.Errorf("invalid saved view: %w", err)
+	}
+
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to encode saved view filter: %w", err)
+	}
+
+	query := `
+		UPDATE saved_views
+		SET name = $2, description = NULLIF($3, ''), visibility = $4, shared_role = NULLIF($5, ''),
+		    filter = $6, sort_by = NULLIF($7, ''), sort_desc = $8, columns = $9, updated_at = NOW()
+		WHERE id = $1 AND version = $10 AND is_deleted = false`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		view.ID, view.Name, view.Description, string(view.Visibility), string(view.SharedRole),
+		filter, view.SortBy, view.SortDesc, pq.Array(nonNilStrings(view.Columns)), view.Version)
+	if err != nil {
+		return savedViewWriteError("update", view.Name, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionConflict("saved view", view.Version, view.Version+1)
+	}
+
+	// Update version in memory
+	view.Version++
+	view.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *savedViewRepository) SoftDelete(ctx context.Context, id string, version int64) error {
+	query := `
+		UPDATE saved_views
+		SET is_deleted = true, deleted_at = NOW()
+		WHERE id = $1 AND version = $2 AND is_deleted = false`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete saved view: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionConflict("saved view", version, version+1)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSavedView(row rowScanner) (*domain.SavedView, error) {
+	view := &domain.SavedView{}
+	var visibility, sharedRole string
+	var filter []byte
+	var columns, missingCategories, missingTags pq.StringArray
+
+	err := row.Scan(
+		&view.ID, &view.OwnerID, &view.Name, &view.Description, &visibility,
+		&sharedRole, &filter, &view.SortBy, &view.SortDesc, &columns,
+		&view.CreatedAt, &view.UpdatedAt, &view.Version, &view.IsDeleted, &view.DeletedAt,
+		&missingCategories, &missingTags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(filter, &view.Filter); err != nil {
+		return nil, fmt.Errorf("failed to decode saved view filter: %w", err)
+	}
+	view.Visibility = domain.SavedViewVisibility(visibility)
+	view.SharedRole = domain.UserRole(sharedRole)
+	view.Columns = columns
+	if len(missingCategories) > 0 {
+		view.MissingCategoryIDs = missingCategories
+	}
+	if len(missingTags) > 0 {
+		view.MissingTagIDs = missingTags
+	}
+
+	return view, nil
+}
+
+// savedViewWriteError reports a duplicate view name as a conflict
+func savedViewWriteError(action, name string, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return domain.ErrConflict(fmt.Sprintf("a saved view named %q already exists", name))
+	}
+	return fmt.Errorf("failed to %s saved view: %w", action, err)
+}
+
+// nonNilStrings keeps empty lists from being stored as NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+func TestSavedViewRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn, ownerID := setupTagTestDB(t)
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	repo := NewSavedViewRepository(dbConn.DB)
+	categoryRepo := NewCategoryRepository(dbConn.DB)
+	suffix := time.Now().UnixNano()
+
+	category := &domain.Category{Name: fmt.Sprintf("view-category-%d", suffix), Color: "#3366FF", CreatorID: ownerID}
+	if err := categoryRepo.Create(ctx, category); err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+
+	view := &domain.SavedView{
+		OwnerID:    ownerID,
+		Name:       fmt.Sprintf("Team backlog %d", suffix),
+		Visibility: domain.SavedViewRole,
+		SharedRole: domain.UserRoleAdmin,
+		Filter: domain.SavedViewFilter{
+			Priority:    domain.TaskPriorityUrgent,
+			CategoryIDs: []string{category.ID},
+			Query:       "assignee:me due<today",
+		},
+		SortBy:  "due_date",
+		Columns: []string{"title", "due_date"},
+	}
+
+	t.Run("Create and get", func(t *testing.T) {
+		if err := repo.Create(ctx, view); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		stored, err := repo.GetByID(ctx, view.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if stored.Filter.Query != view.Filter.Query || stored.Filter.CategoryIDs[0] != category.ID {
+			t.Errorf("stored filter = %+v", stored.Filter)
+		}
+		if stored.SharedRole != domain.UserRoleAdmin || len(stored.Columns) != 2 || stored.IsStale() {
+			t.Errorf("stored view = %+v", stored)
+		}
+	})
+
+	t.Run("Duplicate name", func(t *testing.T) {
+		duplicate := &domain.SavedView{OwnerID: ownerID, Name: view.Name, Visibility: domain.SavedViewPrivate}
+		if err := repo.Create(ctx, duplicate); !domain.IsConflictError(err) {
+			t.Errorf("Create() error = %v, want conflict", err)
+		}
+	})
+
+	t.Run("List by owner and role", func(t *testing.T) {
+		views, _, err := repo.List(ctx, repository.SavedViewListOptions{UserID: ownerID, Role: domain.UserRoleUser})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if !containsView(views, view.ID) {
+			t.Errorf("owner's views do not include %s", view.ID)
+		}
+
+		views, _, err = repo.List(ctx, repository.SavedViewListOptions{UserID: "00000000-0000-0000-0000-00000000ffff", Role: domain.UserRoleAdmin})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if !containsView(views, view.ID) {
+			t.Errorf("views shared with admins do not include %s", view.ID)
+		}
+
+		views, _, err = repo.List(ctx, repository.SavedViewListOptions{UserID: "00000000-0000-0000-0000-00000000ffff", Role: domain.UserRoleUser})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if containsView(views, view.ID) {
+			t.Errorf("view %s is visible to a user outside its role", view.ID)
+		}
+	})
+
+	t.Run("Deleted category is flagged", func(t *testing.T) {
+		if err := categoryRepo.SoftDelete(ctx, category.ID, category.Version); err != nil {
+			t.Fatalf("SoftDelete() category error = %v", err)
+		}
+
+		stored, err := repo.GetByID(ctx, view.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if !stored.IsStale() || len(stored.MissingCategoryIDs) != 1 || stored.MissingCategoryIDs[0] != category.ID {
+			t.Errorf("missing categories = %v, want %s", stored.MissingCategoryIDs, category.ID)
+		}
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		view.Visibility, view.SharedRole = domain.SavedViewPrivate, ""
+		if err := repo.Update(ctx, view); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if err := repo.SoftDelete(ctx, view.ID, 1); !domain.IsVersionConflictError(err) {
+			t.Errorf("SoftDelete() with stale version error = %v, want version conflict", err)
+		}
+		if err := repo.SoftDelete(ctx, view.ID, view.Version); err != nil {
+			t.Fatalf("SoftDelete() error = %v", err)
+		}
+		if _, err := repo.GetByID(ctx, view.ID); !domain.IsNotFoundError(err) {
+			t.Errorf("GetByID() after delete error = %v, want not found", err)
+		}
+	})
+}
+
+func containsView(views []*domain.SavedView, id string) bool {
+	for _, view := range views {
+		if view.ID == id {
+			return true
+		}
+	}
+	return false
+}
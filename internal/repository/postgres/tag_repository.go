@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+
 	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository"
 )
@@ -39,7 +41,7 @@ func (r *tagRepository) Create(ctx context.Context, tag *domain.Tag) error {
 		INSERT INTO tags (id, name, color, creator_id, created_at, updated_at, version)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
 		tag.ID, tag.Name, tag.Color, tag.CreatorID,
 		tag.CreatedAt, tag.UpdatedAt, tag.Version)
 
@@ -54,7 +56,7 @@ func (r *tagRepository) GetByID(ctx context.Context, id string) (*domain.Tag, er
 
 	tag := &domain.Tag{}
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&tag.ID, &tag.Name, &tag.Color, &tag.CreatorID,
 		&tag.CreatedAt, &tag.UpdatedAt, &tag.Version,
 		&tag.IsDeleted, &tag.DeletedAt)
@@ -171,7 +173,7 @@ func (r *tagRepository) Update(ctx context.Context, tag *domain.Tag) error {
 		SET name = $2, color = $3, updated_at = NOW()
 		WHERE id = $1 AND version = $4 AND is_deleted = false`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
 		tag.ID, tag.Name, tag.Color, tag.Version)
 
 	if err != nil {
@@ -200,7 +202,7 @@ func (r *tagRepository) SoftDelete(ctx context.Context, id string, version int64
 		SET is_deleted = true, deleted_at = NOW()
 		WHERE id = $1 AND version = $2 AND is_deleted = false`
 
-	result, err := r.db.ExecContext(ctx, query, id, version)
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
 	if err != nil {
 		return fmt.Errorf("failed to soft delete tag: %w", err)
 	}
@@ -223,7 +225,7 @@ func (r *tagRepository) Restore(ctx context.Context, id string, version int64) e
 		SET is_deleted = false, deleted_at = NULL
 		WHERE id = $1 AND version = $2 AND is_deleted = true`
 
-	result, err := r.db.ExecContext(ctx, query, id, version)
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
 	if err != nil {
 		return fmt.Errorf("failed to restore tag: %w", err)
 	}
@@ -239,3 +241,227 @@ func (r *tagRepository) Restore(ctx context.Context, id string, version int64) e
 
 	return nil
 }
+
+func (r *tagRepository) ReassignTasks(ctx context.Context, sourceID, targetID string) ([]string, error) {
+	// Tasks already tagged with the target keep a single link; every moved task gets a new version
+	query := `
+		WITH moved AS (
+			DELETE FROM task_tags WHERE tag_id = $1
+			RETURNING task_id
+		), linked AS (
+			INSERT INTO task_tags (task_id, tag_id)
+			SELECT task_id, $2 FROM moved
+			ON CONFLICT (task_id, tag_id) DO NOTHING
+		)
+		UPDATE tasks SET updated_at = NOW()
+		WHERE id IN (SELECT task_id FROM moved)
+		RETURNING id`
+
+	ids, err := queryIDs(ctx, executorFromContext(ctx, r.db), query, sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign tag tasks: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *tagRepository) CountTasks(ctx context.Context, tagIDs []string) (map[string]int64, error) {
+	query := `
+		SELECT tt.tag_id, COUNT(*)
+		FROM task_tags tt
+		JOIN tasks t ON t.id = tt.task_id AND NOT t.is_deleted
+		WHERE tt.tag_id = ANY($1::uuid[])
+		GROUP BY tt.tag_id`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, pq.Array(tagIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tag tasks: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64, len(tagIDs))
+	for rows.Next() {
+		var tagID string
+		var count int64
+		if err := rows.Scan(&tagID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag task count: %w", err)
+		}
+		counts[tagID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count tag tasks: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *tagRepository) AddSynonym(ctx context.Context, synonym *domain.TagSynonym) error {
+	if synonym.CreatedAt.IsZero() {
+		synonym.CreatedAt = time.Now()
+	}
+
+	// Adding an existing synonym points it at the new tag, which merges rely on
+	query := `
+		INSERT INTO tag_synonyms (synonym, tag_id, created_by, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (synonym) DO UPDATE SET tag_id = EXCLUDED.tag_id`
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		synonym.Synonym, synonym.TagID, synonym.CreatedBy, synonym.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add tag synonym: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepository) GetSynonym(ctx context.Context, synonym string) (*domain.TagSynonym, error) {
+	query := `
+		SELECT s.synonym, s.tag_id, s.created_by, s.created_at
+		FROM tag_synonyms s
+		JOIN tags t ON t.id = s.tag_id AND NOT t.is_deleted
+		WHERE s.synonym = $1`
+
+	result := &domain.TagSynonym{}
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, synonym).Scan(
+		&result.Synonym, &result.TagID, &result.CreatedBy, &result.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("tag synonym")
+		}
+		return nil, fmt.Errorf("failed to get tag synonym: %w", err)
+	}
+	return result, nil
+}
+
+func (r *tagRepository) ListSynonyms(ctx context.Context, tagID string) ([]*domain.TagSynonym, error) {
+	query := `
+		SELECT synonym, tag_id, created_by, created_at
+		FROM tag_synonyms
+		WHERE tag_id = $1
+		ORDER BY synonym`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, tagID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tag synonyms: %w", err)
+	}
+	defer rows.Close()
+
+	var synonyms []*domain.TagSynonym
+	for rows.Next() {
+		synonym := &domain.TagSynonym{}
+		if err := rows.Scan(&synonym.Synonym, &synonym.TagID, &synonym.CreatedBy, &synonym.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tag synonym: %w", err)
+		}
+		synonyms = append(synonyms, synonym)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tag synonyms: %w", err)
+	}
+	return synonyms, nil
+}
+
+func (r *tagRepository) DeleteSynonym(ctx context.Context, synonym string) error {
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx,
+		"DELETE FROM tag_synonyms WHERE synonym = $1", synonym)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag synonym: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound("tag synonym")
+	}
+	return nil
+}
+
+func (r *tagRepository) MoveSynonyms(ctx context.Context, fromID, toID string) error {
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx,
+		"UPDATE tag_synonyms SET tag_id = $2 WHERE tag_id = $1", fromID, toID)
+	if err != nil {
+		return fmt.Errorf("failed to move tag synonyms: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepository) GetByName(ctx context.Context, name string) (*domain.Tag, error) {
+	query := `
+		SELECT id, name, color, creator_id, created_at, updated_at, version, is_deleted, deleted_at
+		FROM tags
+		WHERE name = $1 AND is_deleted = false
+		ORDER BY created_at
+		LIMIT 1`
+
+	tag := &domain.Tag{}
+
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, name).Scan(
+		&tag.ID, &tag.Name, &tag.Color, &tag.CreatorID,
+		&tag.CreatedAt, &tag.UpdatedAt, &tag.Version,
+		&tag.IsDeleted, &tag.DeletedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("tag")
+		}
+		return nil, fmt.Errorf("failed to get tag by name: %w", err)
+	}
+
+	return tag, nil
+}
+
+func (r *tagRepository) Suggest(ctx context.Context, opts repository.TagSuggestOptions) ([]*domain.TagSuggestion, error) {
+	// Candidates come from idx_tags_name_trgm, best matches first; only those are counted.
+	// Callers see their own tags, system tags and tags on tasks assigned to them.
+	query := `
+		WITH candidates AS (
+			SELECT t.id, t.name, t.color, t.creator_id, t.created_at, t.updated_at, t.version,
+			       t.is_deleted, t.deleted_at,
+			       t.name LIKE $2 AS prefix_match,
+			       similarity(t.name, $1) AS similarity
+			FROM tags t
+			WHERE NOT t.is_deleted
+			  AND (t.name LIKE $2 OR ($3 AND t.name % $1))
+			  AND ($4 OR t.creator_id = $5 OR t.creator_id = NULLIF($6, '')::uuid OR EXISTS (
+			      SELECT 1 FROM task_tags tt
+			      JOIN tasks k ON k.id = tt.task_id AND NOT k.is_deleted
+			      WHERE tt.tag_id = t.id AND k.assignee_id = NULLIF($6, '')::uuid))
+			ORDER BY prefix_match DESC, similarity DESC, t.name
+			LIMIT $7
+		)
+		SELECT c.id, c.name, c.color, c.creator_id, c.created_at, c.updated_at, c.version,
+		       c.is_deleted, c.deleted_at, c.prefix_match, c.similarity,
+		       (SELECT COUNT(*) FROM task_tags tt
+		        JOIN tasks k ON k.id = tt.task_id AND NOT k.is_deleted
+		        WHERE tt.tag_id = c.id),
+		       (SELECT COUNT(DISTINCT h.task_id) FROM task_history h
+		        JOIN task_tags tt ON tt.task_id = h.task_id AND tt.tag_id = c.id
+		        WHERE h.actor_id = NULLIF($6, '')::uuid AND h.timestamp >= $8)
+		FROM candidates c`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query,
+		opts.Query, escapeLike(opts.Query)+"%", opts.Trigram, opts.AllTags,
+		domain.SystemUserID, opts.UserID, opts.Limit, opts.RecentSince)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest tags: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []*domain.TagSuggestion
+	for rows.Next() {
+		tag := &domain.Tag{}
+		suggestion := &domain.TagSuggestion{Tag: tag}
+		if err := rows.Scan(
+			&tag.ID, &tag.Name, &tag.Color, &tag.CreatorID,
+			&tag.CreatedAt, &tag.UpdatedAt, &tag.Version,
+			&tag.IsDeleted, &tag.DeletedAt,
+			&suggestion.PrefixMatch, &suggestion.Similarity,
+			&suggestion.UsageCount, &suggestion.RecentUsageCount); err != nil {
+			return nil, fmt.Errorf("failed to scan tag suggestion: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to suggest tags: %w", err)
+	}
+	return suggestions, nil
+}
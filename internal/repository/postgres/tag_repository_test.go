@@ -347,4 +347,151 @@ func TestTagRepository_Integration(t *testing.T) {
 		// Note: The actual error type depends on database constraints
 		// In a real implementation, this might be a unique constraint violation
 	})
+
+	t.Run("Synonyms", func(t *testing.T) {
+		suffix := time.Now().UnixNano()
+		bug := &domain.Tag{Name: fmt.Sprintf("bug-%d", suffix), Color: "#FF0077", CreatorID: testCreatorID}
+		bugs := &domain.Tag{Name: fmt.Sprintf("bugs-%d", suffix), Color: "#FF0088", CreatorID: testCreatorID}
+		for _, tag := range []*domain.Tag{bug, bugs} {
+			if err := tagRepo.Create(ctx, tag); err != nil {
+				t.Fatalf("Failed to create test tag: %v", err)
+			}
+		}
+
+		synonym := &domain.TagSynonym{Synonym: fmt.Sprintf("defect-%d", suffix), TagID: bugs.ID, CreatedBy: testCreatorID}
+		if err := tagRepo.AddSynonym(ctx, synonym); err != nil {
+			t.Fatalf("Failed to add synonym: %v", err)
+		}
+		found, err := tagRepo.GetSynonym(ctx, synonym.Synonym)
+		if err != nil || found.TagID != bugs.ID {
+			t.Fatalf("GetSynonym() = %+v, %v; want tag %s", found, err, bugs.ID)
+		}
+
+		if err := tagRepo.MoveSynonyms(ctx, bugs.ID, bug.ID); err != nil {
+			t.Fatalf("Failed to move synonyms: %v", err)
+		}
+		synonyms, err := tagRepo.ListSynonyms(ctx, bug.ID)
+		if err != nil || len(synonyms) != 1 || synonyms[0].Synonym != synonym.Synonym {
+			t.Fatalf("ListSynonyms() = %+v, %v; want the moved synonym", synonyms, err)
+		}
+
+		if err := tagRepo.SoftDelete(ctx, bug.ID, bug.Version); err != nil {
+			t.Fatalf("Failed to delete tag: %v", err)
+		}
+		if _, err := tagRepo.GetSynonym(ctx, synonym.Synonym); !domain.IsNotFoundError(err) {
+			t.Errorf("Expected synonyms of deleted tags to be ignored, got: %v", err)
+		}
+
+		if err := tagRepo.DeleteSynonym(ctx, synonym.Synonym); err != nil {
+			t.Fatalf("Failed to delete synonym: %v", err)
+		}
+		if err := tagRepo.DeleteSynonym(ctx, synonym.Synonym); !domain.IsNotFoundError(err) {
+			t.Errorf("Expected not found error, got: %v", err)
+		}
+	})
+
+	t.Run("ReassignTasks", func(t *testing.T) {
+		suffix := time.Now().UnixNano()
+		source := &domain.Tag{Name: fmt.Sprintf("merge-source-%d", suffix), Color: "#FF0099", CreatorID: testCreatorID}
+		target := &domain.Tag{Name: fmt.Sprintf("merge-target-%d", suffix), Color: "#FF00AA", CreatorID: testCreatorID}
+		for _, tag := range []*domain.Tag{source, target} {
+			if err := tagRepo.Create(ctx, tag); err != nil {
+				t.Fatalf("Failed to create test tag: %v", err)
+			}
+		}
+
+		taskRepo := NewTaskRepository(dbConn.DB)
+		var taskIDs []string
+		for i := 0; i < 2; i++ {
+			task := &domain.Task{
+				Title:      fmt.Sprintf("Tag merge task %d-%d", suffix, i),
+				AssigneeID: testCreatorID,
+				Status:     domain.TaskStatusOpen,
+				Priority:   domain.TaskPriorityMedium,
+			}
+			if err := taskRepo.Create(ctx, task); err != nil {
+				t.Fatalf("Failed to create test task: %v", err)
+			}
+			tagIDs := []string{source.ID}
+			if i == 0 {
+				tagIDs = append(tagIDs, target.ID)
+			}
+			if err := taskRepo.AddTags(ctx, task.ID, tagIDs, task.Version); err != nil {
+				t.Fatalf("Failed to tag test task: %v", err)
+			}
+			taskIDs = append(taskIDs, task.ID)
+		}
+
+		moved, err := tagRepo.ReassignTasks(ctx, source.ID, target.ID)
+		if err != nil {
+			t.Fatalf("Failed to reassign tasks: %v", err)
+		}
+		if len(moved) != len(taskIDs) {
+			t.Errorf("ReassignTasks() moved %d tasks, want %d", len(moved), len(taskIDs))
+		}
+
+		counts, err := tagRepo.CountTasks(ctx, []string{source.ID, target.ID})
+		if err != nil {
+			t.Fatalf("Failed to count tasks: %v", err)
+		}
+		if counts[source.ID] != 0 || counts[target.ID] != 2 {
+			t.Errorf("CountTasks() = %v, want the target to have both tasks", counts)
+		}
+	})
+
+	t.Run("GetByName", func(t *testing.T) {
+		tag := &domain.Tag{Name: fmt.Sprintf("by-name-%d", time.Now().UnixNano()), Color: "#FF00BB", CreatorID: testCreatorID}
+		if err := tagRepo.Create(ctx, tag); err != nil {
+			t.Fatalf("Failed to create test tag: %v", err)
+		}
+
+		found, err := tagRepo.GetByName(ctx, tag.Name)
+		if err != nil || found.ID != tag.ID {
+			t.Fatalf("GetByName() = %+v, %v; want tag %s", found, err, tag.ID)
+		}
+		if _, err := tagRepo.GetByName(ctx, tag.Name+"-missing"); !domain.IsNotFoundError(err) {
+			t.Errorf("Expected not found error, got: %v", err)
+		}
+	})
+
+	t.Run("Suggest", func(t *testing.T) {
+		prefix := fmt.Sprintf("suggest%d", time.Now().UnixNano())
+		own := &domain.Tag{Name: prefix + "-own", Color: "#FF00CC", CreatorID: testCreatorID}
+		system := &domain.Tag{Name: prefix + "-system", Color: "#FF00DD", CreatorID: domain.SystemUserID}
+		for _, tag := range []*domain.Tag{own, system} {
+			if err := tagRepo.Create(ctx, tag); err != nil {
+				t.Fatalf("Failed to create test tag: %v", err)
+			}
+		}
+
+		suggestions, err := tagRepo.Suggest(ctx, repository.TagSuggestOptions{
+			Query:       prefix,
+			Trigram:     true,
+			UserID:      testCreatorID,
+			RecentSince: time.Now().Add(-time.Hour),
+			Limit:       10,
+		})
+		if err != nil {
+			t.Fatalf("Failed to suggest tags: %v", err)
+		}
+		if len(suggestions) != 2 {
+			t.Fatalf("Suggest() returned %d tags, want 2", len(suggestions))
+		}
+		for _, suggestion := range suggestions {
+			if !suggestion.PrefixMatch || suggestion.Similarity <= 0 {
+				t.Errorf("Expected a scored prefix match, got %+v", suggestion)
+			}
+		}
+
+		// Tags of other users are only offered when visibility is not checked
+		suggestions, err = tagRepo.Suggest(ctx, repository.TagSuggestOptions{
+			Query: prefix + "-own", UserID: domain.SystemUserID, RecentSince: time.Now(), Limit: 10,
+		})
+		if err != nil {
+			t.Fatalf("Failed to suggest tags: %v", err)
+		}
+		if len(suggestions) != 0 {
+			t.Errorf("Expected another user's tag to be hidden, got %d suggestions", len(suggestions))
+		}
+	})
 }
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+// Trigger and table checks that keep tasks from blocking themselves
+const (
+	taskDependencyCycleConstraint = "task_dependencies_no_cycle"
+	taskDependencySelfConstraint  = "task_dependencies_no_self"
+)
+
+func (r *taskRepository) AddDependency(ctx context.Context, dependency *domain.TaskDependency) error {
+	dependency.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO task_dependencies (task_id, blocker_id, creator_id, created_at)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		dependency.TaskID, dependency.BlockerID, dependency.CreatorID, dependency.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch {
+			case pqErr.Code == uniqueViolation:
+				return domain.ErrConflict("the task is already blocked by this task")
+			case pqErr.Constraint == taskDependencySelfConstraint:
+				return domain.ErrBusinessRule("a task cannot block itself")
+			case pqErr.Constraint == taskDependencyCycleConstraint:
+				return domain.ErrBusinessRule("the dependency would make the task block itself")
+			}
+		}
+		return fmt.Errorf("failed to add task dependency: %w", err)
+	}
+
+	return nil
+}
+
+func (r *taskRepository) RemoveDependency(ctx context.Context, taskID, blockerID string) error {
+	query := `DELETE FROM task_dependencies WHERE task_id = $1 AND blocker_id = $2`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, taskID, blockerID)
+	if err != nil {
+		return fmt.Errorf("failed to remove task dependency: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound("task dependency")
+	}
+
+	return nil
+}
+
+func (r *taskRepository) ListBlockers(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	query := `
+		SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.parent_id,
+			   t.series_id, COALESCE(t.series_index, 0), COALESCE(t.creator_id::text, ''), t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.blocker_id
+		WHERE d.task_id = $1 AND NOT t.is_deleted
+		ORDER BY t.due_date NULLS LAST, t.created_at, t.id`
+
+	tasks, err := r.queryTasks(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task blockers: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) ListDependents(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	query := `
+		SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.parent_id,
+			   t.series_id, COALESCE(t.series_index, 0), COALESCE(t.creator_id::text, ''), t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		WHERE d.blocker_id = $1 AND NOT t.is_deleted
+		ORDER BY t.due_date NULLS LAST, t.created_at, t.id`
+
+	tasks, err := r.queryTasks(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task dependents: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) IsBlockedBy(ctx context.Context, taskID, blockerID string) (bool, error) {
+	// The path keeps the walk finite should a cycle ever be stored
+	query := `
+		WITH RECURSIVE upstream(id, path) AS (
+			SELECT d.blocker_id, ARRAY[d.task_id, d.blocker_id]
+			FROM task_dependencies d
+			WHERE d.task_id = $1
+			UNION ALL
+			SELECT d.blocker_id, u.path || d.blocker_id
+			FROM task_dependencies d
+			JOIN upstream u ON d.task_id = u.id
+			WHERE NOT d.blocker_id = ANY(u.path)
+		)
+		SELECT EXISTS (SELECT 1 FROM upstream WHERE id = $2)`
+
+	var blocked bool
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, taskID, blockerID).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check task dependencies: %w", err)
+	}
+	return blocked, nil
+}
+
+func (r *taskRepository) ListDependencies(ctx context.Context, taskIDs []string) ([]*domain.TaskDependency, error) {
+	query := `
+		SELECT task_id, blocker_id, creator_id, created_at
+		FROM task_dependencies
+		WHERE task_id = ANY($1::uuid[]) AND blocker_id = ANY($1::uuid[])
+		ORDER BY task_id, blocker_id`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, pq.Array(taskIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	var dependencies []*domain.TaskDependency
+	for rows.Next() {
+		dependency := &domain.TaskDependency{}
+		if err := rows.Scan(&dependency.TaskID, &dependency.BlockerID, &dependency.CreatorID, &dependency.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task dependency: %w", err)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list task dependencies: %w", err)
+	}
+
+	return dependencies, nil
+}
+
+// queryTasks runs a query selecting the task columns and loads the relations of every task
+func (r *taskRepository) queryTasks(ctx context.Context, query string, args ...interface{}) ([]*domain.Task, error) {
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*domain.Task
+	for rows.Next() {
+		task := &domain.Task{}
+		var status, priority string
+
+		err := rows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.AssigneeID,
+			&status, &priority, &task.DueDate, &task.ParentID, &task.SeriesID, &task.SeriesIndex, &task.CreatorID,
+			&task.CreatedAt, &task.UpdatedAt, &task.Version,
+			&task.IsDeleted, &task.DeletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+
+		task.Status = domain.TaskStatus(status)
+		task.Priority = domain.TaskPriority(priority)
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// Relations are loaded once the rows are closed, since a transaction runs one query at a time
+	for _, task := range tasks {
+		if err := r.loadTaskRelations(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to load task relations: %w", err)
+		}
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/taskquery"
+)
+
+// taskFacetQueries select value, label and task count for each facet; the task filter
+// is substituted as the WHERE clause. A task counts once for each of its participants,
+// categories and tags.
+var taskFacetQueries = map[domain.TaskFacet]string{
+	domain.TaskFacetStatus: `
+		SELECT t.status, t.status, COUNT(*)
+		FROM tasks t
+		%s
+		GROUP BY t.status`,
+	domain.TaskFacetPriority: `
+		SELECT t.priority, t.priority, COUNT(*)
+		FROM tasks t
+		%s
+		GROUP BY t.priority`,
+	domain.TaskFacetAssignee: `
+		SELECT fp.user_id::text, COALESCE(MIN(fu.name), ''), COUNT(DISTINCT t.id)
+		FROM tasks t
+		JOIN task_participants fp ON fp.task_id = t.id
+		LEFT JOIN users fu ON fu.id = fp.user_id
+		%s
+		GROUP BY fp.user_id`,
+	domain.TaskFacetCategory: `
+		SELECT fc.id::text, fc.name, COUNT(*)
+		FROM tasks t
+		JOIN task_categories ftc ON ftc.task_id = t.id
+		JOIN categories fc ON fc.id = ftc.category_id AND NOT fc.is_deleted
+		%s
+		GROUP BY fc.id, fc.name`,
+	domain.TaskFacetTag: `
+		SELECT ft.id::text, ft.name, COUNT(*)
+		FROM tasks t
+		JOIN task_tags ftt ON ftt.task_id = t.id
+		JOIN tags ft ON ft.id = ftt.tag_id AND NOT ft.is_deleted
+		%s
+		GROUP BY ft.id, ft.name`,
+}
+
+// taskFacetFields are the query language fields filtering on each facet
+var taskFacetFields = map[domain.TaskFacet]string{
+	domain.TaskFacetStatus:   taskquery.FieldStatus,
+	domain.TaskFacetPriority: taskquery.FieldPriority,
+	domain.TaskFacetAssignee: taskquery.FieldAssignee,
+	domain.TaskFacetCategory: taskquery.FieldCategory,
+	domain.TaskFacetTag:      taskquery.FieldTag,
+}
+
+func (r *taskRepository) Facets(ctx context.Context, opts repository.TaskListOptions, facets []domain.TaskFacet) ([]*domain.FacetCounts, error) {
+	executor := executorFromContext(ctx, r.db)
+
+	results := make([]*domain.FacetCounts, 0, len(facets))
+	for _, facet := range facets {
+		query, ok := taskFacetQueries[facet]
+		if !ok {
+			return nil, domain.ErrInvalidInput(fmt.Sprintf("unknown task facet %q", facet))
+		}
+
+		whereClause, args, err := buildTaskFilter(withoutFacetFilter(opts, facet))
+		if err != nil {
+			return nil, err
+		}
+		query = fmt.Sprintf(query, whereClause) + fmt.Sprintf(`
+		ORDER BY 3 DESC, 2, 1
+		LIMIT %d`, domain.MaxFacetValues)
+
+		rows, err := executor.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count tasks by %s: %w", facet, err)
+		}
+
+		counts := &domain.FacetCounts{Facet: facet, Values: []domain.FacetValue{}}
+		for rows.Next() {
+			var value domain.FacetValue
+			if err := rows.Scan(&value.Value, &value.Label, &value.Count); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan %s facet: %w", facet, err)
+			}
+			counts.Values = append(counts.Values, value)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to count tasks by %s: %w", facet, err)
+		}
+
+		results = append(results, counts)
+	}
+
+	return results, nil
+}
+
+// withoutFacetFilter clears the list's filter on facet, including query conditions on it,
+// so the counts show what selecting each value would yield
+func withoutFacetFilter(opts repository.TaskListOptions, facet domain.TaskFacet) repository.TaskListOptions {
+	switch facet {
+	case domain.TaskFacetStatus:
+		opts.Status = ""
+	case domain.TaskFacetPriority:
+		opts.Priority = ""
+	case domain.TaskFacetAssignee:
+		opts.AssigneeID = ""
+		opts.AssigneeRoles = nil
+	case domain.TaskFacetCategory:
+		opts.CategoryIDs = nil
+	case domain.TaskFacetTag:
+		opts.TagIDs = nil
+	}
+	if opts.QueryExpr != nil {
+		opts.QueryExpr = taskquery.WithoutField(opts.QueryExpr, taskFacetFields[facet])
+	}
+	return opts
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/taskquery"
+)
+
+func TestWithoutFacetFilter(t *testing.T) {
+	expr, err := taskquery.Parse("status:open tag:backend")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	opts := repository.TaskListOptions{
+		Status:      domain.TaskStatusOpen,
+		Priority:    domain.TaskPriorityHigh,
+		CategoryIDs: []string{"cat-1"},
+		QueryExpr:   expr,
+	}
+
+	status := withoutFacetFilter(opts, domain.TaskFacetStatus)
+	if status.Status != "" || status.Priority != domain.TaskPriorityHigh || taskquery.String(status.QueryExpr) != "tag:backend" {
+		t.Errorf("status facet options = %+v, query %s", status, taskquery.String(status.QueryExpr))
+	}
+
+	category := withoutFacetFilter(opts, domain.TaskFacetCategory)
+	if category.CategoryIDs != nil || category.Status != domain.TaskStatusOpen {
+		t.Errorf("category facet options = %+v", category)
+	}
+	if taskquery.String(category.QueryExpr) != "(status:open AND tag:backend)" {
+		t.Errorf("category facet query = %s, want it unchanged", taskquery.String(category.QueryExpr))
+	}
+
+	// The list's own options are left alone
+	if opts.Status != domain.TaskStatusOpen || len(opts.CategoryIDs) != 1 {
+		t.Errorf("original options changed: %+v", opts)
+	}
+}
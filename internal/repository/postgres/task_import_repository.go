@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+type taskImportRepository struct {
+	db *sql.DB
+}
+
+// NewTaskImportRepository creates a new task import repository
+func NewTaskImportRepository(db *sql.DB) repository.TaskImportRepository {
+	return &taskImportRepository{db: db}
+}
+
+func (r *taskImportRepository) Create(ctx context.Context, taskImport *domain.TaskImport) error {
+	if taskImport.ID == "" {
+		taskImport.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	taskImport.CreatedAt = now
+	taskImport.UpdatedAt = now
+
+	query := `
+		INSERT INTO task_imports (id, format, status, actor_id, last_committed_row, created_count, error, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9)`
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		taskImport.ID, string(taskImport.Format), string(taskImport.Status), taskImport.ActorID,
+		taskImport.LastCommittedRow, taskImport.CreatedCount, taskImport.Error,
+		taskImport.CreatedAt, taskImport.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create task import: %w", err)
+	}
+
+	return nil
+}
+
+func (r *taskImportRepository) GetByID(ctx context.Context, id string) (*domain.TaskImport, error) {
+	query := `
+		SELECT id, format, status, actor_id, last_committed_row, created_count, COALESCE(error, ''),
+		       created_at, updated_at
+		FROM task_imports
+		WHERE id = $1`
+
+	taskImport := &domain.TaskImport{}
+	var format, status string
+
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&taskImport.ID, &format, &status, &taskImport.ActorID,
+		&taskImport.LastCommittedRow, &taskImport.CreatedCount, &taskImport.Error,
+		&taskImport.CreatedAt, &taskImport.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("task import")
+		}
+		return nil, fmt.Errorf("failed to get task import: %w", err)
+	}
+
+	taskImport.Format = domain.ImportFormat(format)
+	taskImport.Status = domain.ImportStatus(status)
+	return taskImport, nil
+}
+
+func (r *taskImportRepository) Update(ctx context.Context, taskImport *domain.TaskImport) error {
+	taskImport.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE task_imports
+		SET status = $2, last_committed_row = $3, created_count = $4, error = NULLIF($5, ''), updated_at = $6
+		WHERE id = $1`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		taskImport.ID, string(taskImport.Status), taskImport.LastCommittedRow,
+		taskImport.CreatedCount, taskImport.Error, taskImport.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update task import: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound("task import")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func TestTaskImportRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn := setupTestDB(t)
+	defer dbConn.Close()
+
+	repo := NewTaskImportRepository(dbConn.DB)
+	ctx := context.Background()
+
+	taskImport := &domain.TaskImport{
+		Format:  domain.ImportFormatCSV,
+		Status:  domain.ImportStatusRunning,
+		ActorID: domain.SystemUserID,
+	}
+
+	t.Run("Create and get", func(t *testing.T) {
+		if err := repo.Create(ctx, taskImport); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		stored, err := repo.GetByID(ctx, taskImport.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if stored.Status != domain.ImportStatusRunning || stored.LastCommittedRow != 0 {
+			t.Errorf("stored import = %+v", stored)
+		}
+	})
+
+	t.Run("Update checkpoint inside a transaction", func(t *testing.T) {
+		txManager := NewTransactionManager(dbConn.DB)
+		err := txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			taskImport.LastCommittedRow = 100
+			taskImport.CreatedCount = 95
+			return repo.Update(txCtx, taskImport)
+		})
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		stored, err := repo.GetByID(ctx, taskImport.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if stored.LastCommittedRow != 100 || stored.CreatedCount != 95 {
+			t.Errorf("stored import = %+v, want checkpoint at row 100", stored)
+		}
+	})
+
+	t.Run("Get unknown import", func(t *testing.T) {
+		if _, err := repo.GetByID(ctx, "00000000-0000-0000-0000-00000000ffff"); !domain.IsNotFoundError(err) {
+			t.Errorf("GetByID() error = %v, want not found", err)
+		}
+	})
+}
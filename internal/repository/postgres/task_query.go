@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/todo-app/services/admin-service/internal/taskquery"
+)
+
+// taskQueryColumns maps date fields to task columns
+var taskQueryColumns = map[string]string{
+	taskquery.FieldDue:     "t.due_date",
+	taskquery.FieldCreated: "t.created_at",
+	taskquery.FieldUpdated: "t.updated_at",
+}
+
+// taskQueryCompiler turns a bound query into a condition over tasks aliased as t.
+// Every value is passed as a parameter.
+type taskQueryCompiler struct {
+	args []interface{}
+}
+
+// compileTaskQuery appends the query's parameters to args and returns its condition
+func compileTaskQuery(expr taskquery.Expr, args []interface{}) (string, []interface{}, error) {
+	c := &taskQueryCompiler{args: args}
+	condition, err := c.compile(expr)
+	if err != nil {
+		return "", nil, err
+	}
+	return condition, c.args, nil
+}
+
+func (c *taskQueryCompiler) param(value interface{}) string {
+	c.args = append(c.args, value)
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *taskQueryCompiler) compile(expr taskquery.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *taskquery.And:
+		return c.compileBinary(e.Left, e.Right, "AND")
+	case *taskquery.Or:
+		return c.compileBinary(e.Left, e.Right, "OR")
+	case *taskquery.Not:
+		operand, err := c.compile(e.Expr)
+		if err != nil {
+			return "", err
+		}
+		// A NULL comparison counts as not matching, so -due<7d includes undated tasks
+		return fmt.Sprintf("NOT COALESCE(%s, false)", operand), nil
+	case *taskquery.Term:
+		return c.compileTerm(e)
+	default:
+		return "", fmt.Errorf("unsupported query node %T", expr)
+	}
+}
+
+func (c *taskQueryCompiler) compileBinary(left, right taskquery.Expr, op string) (string, error) {
+	l, err := c.compile(left)
+	if err != nil {
+		return "", err
+	}
+	r, err := c.compile(right)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(%s %s %s)", l, op, r), nil
+}
+
+func (c *taskQueryCompiler) compileTerm(t *taskquery.Term) (string, error) {
+	var condition string
+	switch t.Field {
+	case taskquery.FieldText:
+		parse := "plainto_tsquery"
+		if t.Quoted {
+			parse = "phraseto_tsquery"
+		}
+		return fmt.Sprintf("t.search_vector @@ %s('english', %s)", parse, c.param(t.Value)), nil
+	case taskquery.FieldTitle:
+		condition = fmt.Sprintf("t.title ILIKE %s", c.param("%"+escapeLike(t.Value)+"%"))
+	case taskquery.FieldStatus:
+		condition = fmt.Sprintf("t.status = ANY(%s)", c.param(pq.Array(t.Values)))
+	case taskquery.FieldPriority:
+		condition = fmt.Sprintf("t.priority = ANY(%s)", c.param(pq.Array(t.Values)))
+	case taskquery.FieldAssignee:
+		values := c.param(pq.Array(t.Values))
+		// Like the assignee filter, this matches participants in any role
+		condition = fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM task_participants tp WHERE tp.task_id = t.id"+
+				" AND (tp.user_id::text = ANY(%[1]s) OR tp.user_id IN (SELECT u.id FROM users u WHERE lower(u.email) = ANY(%[1]s))))", values)
+	case taskquery.FieldTag:
+		condition = "EXISTS (SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = t.id AND NOT tg.is_deleted"
+		if !t.None {
+			condition += fmt.Sprintf(" AND lower(tg.name) = ANY(%s)", c.param(pq.Array(t.Values)))
+		}
+		condition += ")"
+	case taskquery.FieldCategory:
+		condition = "EXISTS (SELECT 1 FROM task_categories tc JOIN categories cat ON cat.id = tc.category_id WHERE tc.task_id = t.id AND NOT cat.is_deleted"
+		if !t.None {
+			condition += fmt.Sprintf(" AND lower(cat.name) = ANY(%s)", c.param(pq.Array(t.Values)))
+		}
+		condition += ")"
+	case taskquery.FieldDue, taskquery.FieldCreated, taskquery.FieldUpdated:
+		column := taskQueryColumns[t.Field]
+		if t.None {
+			if t.Op == taskquery.OpNe {
+				return column + " IS NOT NULL", nil
+			}
+			return column + " IS NULL", nil
+		}
+		return fmt.Sprintf("%s %s %s", column, t.Op, c.param(t.Time)), nil
+	default:
+		return "", fmt.Errorf("unsupported query field %q", t.Field)
+	}
+
+	// tag:none means "has no tags", the opposite of the existence check above
+	negate := t.Op == taskquery.OpNe
+	if t.None {
+		negate = !negate
+	}
+	if negate {
+		return "NOT " + condition, nil
+	}
+	return condition, nil
+}
+
+// escapeLike escapes LIKE wildcards so a value matches literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
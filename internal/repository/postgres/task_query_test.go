@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/taskquery"
+)
+
+func TestCompileTaskQuery(t *testing.T) {
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	expr, err := taskquery.Parse(`status:open priority>=high assignee:me (tag:backend OR title:"50%") due<7d -category:archived`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	bound, err := taskquery.Bind(expr, taskquery.Env{UserID: "user-1", Now: now})
+	if err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	// The query's parameters follow those already bound
+	condition, args, err := compileTaskQuery(bound, []interface{}{"existing"})
+	if err != nil {
+		t.Fatalf("compileTaskQuery() error = %v", err)
+	}
+
+	want := "(((((t.status = ANY($2)" +
+		" AND t.priority = ANY($3))" +
+		" AND EXISTS (SELECT 1 FROM task_participants tp WHERE tp.task_id = t.id AND (tp.user_id::text = ANY($4) OR tp.user_id IN (SELECT u.id FROM users u WHERE lower(u.email) = ANY($4)))))" +
+		" AND (EXISTS (SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = t.id AND NOT tg.is_deleted AND lower(tg.name) = ANY($5))" +
+		" OR t.title ILIKE $6))" +
+		" AND t.due_date < $7)" +
+		" AND NOT COALESCE(EXISTS (SELECT 1 FROM task_categories tc JOIN categories cat ON cat.id = tc.category_id WHERE tc.task_id = t.id AND NOT cat.is_deleted AND lower(cat.name) = ANY($8)), false))"
+	if condition != want {
+		t.Errorf("condition =\n%s\nwant\n%s", condition, want)
+	}
+
+	if len(args) != 8 {
+		t.Fatalf("got %d args, want 8", len(args))
+	}
+	if args[5] != `%50\%%` {
+		t.Errorf("title pattern = %v, want LIKE wildcards escaped", args[5])
+	}
+	if due, ok := args[6].(time.Time); !ok || !due.Equal(now.AddDate(0, 0, 7)) {
+		t.Errorf("due bound = %v, want %v", args[6], now.AddDate(0, 0, 7))
+	}
+}
+
+func TestCompileTaskQuery_None(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"due:none", "t.due_date IS NULL"},
+		{"due!=none", "t.due_date IS NOT NULL"},
+		{"tag:none", "NOT EXISTS (SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = t.id AND NOT tg.is_deleted)"},
+		{"category!=none", "EXISTS (SELECT 1 FROM task_categories tc JOIN categories cat ON cat.id = tc.category_id WHERE tc.task_id = t.id AND NOT cat.is_deleted)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := taskquery.Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			bound, err := taskquery.Bind(expr, taskquery.Env{})
+			if err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+			condition, args, err := compileTaskQuery(bound, nil)
+			if err != nil {
+				t.Fatalf("compileTaskQuery() error = %v", err)
+			}
+			if condition != tt.want || len(args) != 0 {
+				t.Errorf("got %s with %d args, want %s", condition, len(args), tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -13,6 +15,9 @@ import (
 	"github.com/todo-app/services/admin-service/internal/repository"
 )
 
+// taskCycleConstraint names the trigger check that keeps tasks from becoming their own ancestors
+const taskCycleConstraint = "tasks_no_cycle"
+
 type taskRepository struct {
 	db *sql.DB
 }
@@ -37,30 +42,63 @@ func (r *taskRepository) Create(ctx context.Context, task *domain.Task) error {
 	}
 
 	query := `
-		INSERT INTO tasks (id, title, description, assignee_id, status, priority, due_date, created_at, updated_at, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		INSERT INTO tasks (id, title, description, assignee_id, status, priority, due_date, parent_id,
+			series_id, series_index, creator_id, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, 0), NULLIF($11, '')::uuid, $12, $13, $14)`
 
-	_, err := r.db.ExecContext(ctx, query,
+	tx, err := beginScopedTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query,
 		task.ID, task.Title, task.Description, task.AssigneeID,
-		string(task.Status), string(task.Priority), task.DueDate,
-		task.CreatedAt, task.UpdatedAt, task.Version)
+		string(task.Status), string(task.Priority), task.DueDate, task.ParentID,
+		task.SeriesID, task.SeriesIndex, task.CreatorID, task.CreatedAt, task.UpdatedAt, task.Version)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// The primary assignee is added by trigger; other participants are added here
+	if err := insertTaskParticipants(ctx, tx, task.ID, task.Participants); err != nil {
+		return err
+	}
+	participants := []domain.TaskParticipant{{UserID: task.AssigneeID, Role: domain.TaskRoleAssignee}}
+	for _, participant := range task.Participants {
+		if participant.UserID != task.AssigneeID || participant.Role != domain.TaskRoleAssignee {
+			participants = append(participants, participant)
+		}
+	}
+	for i := range participants {
+		participants[i].AddedAt = now
+	}
+	task.Participants = participants
+
+	return tx.Commit()
 }
 
 func (r *taskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	return r.getTask(ctx, id, false)
+}
+
+func (r *taskRepository) GetDeletedByID(ctx context.Context, id string) (*domain.Task, error) {
+	return r.getTask(ctx, id, true)
+}
+
+func (r *taskRepository) getTask(ctx context.Context, id string, deleted bool) (*domain.Task, error) {
 	query := `
-		SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date,
-			   t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at
+		SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.parent_id,
+			   t.series_id, COALESCE(t.series_index, 0), COALESCE(t.creator_id::text, ''), t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at
 		FROM tasks t
-		WHERE t.id = $1 AND t.is_deleted = false`
+		WHERE t.id = $1 AND t.is_deleted = $2`
 
 	task := &domain.Task{}
 	var status, priority string
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id, deleted).Scan(
 		&task.ID, &task.Title, &task.Description, &task.AssigneeID,
-		&status, &priority, &task.DueDate,
+		&status, &priority, &task.DueDate, &task.ParentID, &task.SeriesID, &task.SeriesIndex, &task.CreatorID,
 		&task.CreatedAt, &task.UpdatedAt, &task.Version,
 		&task.IsDeleted, &task.DeletedAt)
 
@@ -83,73 +121,17 @@ func (r *taskRepository) GetByID(ctx context.Context, id string) (*domain.Task,
 }
 
 func (r *taskRepository) List(ctx context.Context, opts repository.TaskListOptions) ([]*domain.Task, int64, error) {
-	// Build WHERE clause
-	var conditions []string
-	var args []interface{}
-	argIndex := 0
-
-	// Base condition for soft deletes
-	if !opts.IncludeDeleted {
-		conditions = append(conditions, "t.is_deleted = false")
-	}
-
-	// Add filters
-	if opts.AssigneeID != "" {
-		argIndex++
-		conditions = append(conditions, fmt.Sprintf("t.assignee_id = $%d", argIndex))
-		args = append(args, opts.AssigneeID)
-	}
-
-	if opts.Status != "" && opts.Status != domain.TaskStatusUnspecified {
-		argIndex++
-		conditions = append(conditions, fmt.Sprintf("t.status = $%d", argIndex))
-		args = append(args, string(opts.Status))
-	}
-
-	if opts.Priority != "" && opts.Priority != domain.TaskPriorityUnspecified {
-		argIndex++
-		conditions = append(conditions, fmt.Sprintf("t.priority = $%d", argIndex))
-		args = append(args, string(opts.Priority))
-	}
-
-	if opts.SearchQuery != "" {
-		argIndex++
-		conditions = append(conditions, fmt.Sprintf("(t.title ILIKE $%d OR t.description ILIKE $%d)", argIndex, argIndex))
-		args = append(args, "%"+opts.SearchQuery+"%")
-	}
-
-	if opts.DueBefore != nil {
-		argIndex++
-		conditions = append(conditions, fmt.Sprintf("t.due_date <= $%d", argIndex))
-		args = append(args, *opts.DueBefore)
-	}
-
-	if opts.DueAfter != nil {
-		argIndex++
-		conditions = append(conditions, fmt.Sprintf("t.due_date >= $%d", argIndex))
-		args = append(args, *opts.DueAfter)
-	}
-
-	// Build WHERE clause
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = "WHERE " + strings.Join(conditions, " AND ")
-	}
-
-	// Build ORDER BY clause
-	orderClause := "ORDER BY t.created_at DESC"
-	if opts.SortBy != "" {
-		direction := "ASC"
-		if opts.SortDesc {
-			direction = "DESC"
-		}
-		orderClause = fmt.Sprintf("ORDER BY t.%s %s", opts.SortBy, direction)
+	whereClause, args, err := buildTaskFilter(opts)
+	if err != nil {
+		return nil, 0, err
 	}
+	argIndex := len(args)
+	orderClause := buildTaskOrder(opts)
 
 	// Count total items
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM tasks t %s", whereClause)
 	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err = r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count tasks: %w", err)
 	}
@@ -163,8 +145,8 @@ func (r *taskRepository) List(ctx context.Context, opts repository.TaskListOptio
 
 	// Build main query
 	query := fmt.Sprintf(`
-		SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date,
-			   t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at
+		SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.parent_id,
+			   t.series_id, COALESCE(t.series_index, 0), COALESCE(t.creator_id::text, ''), t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at
 		FROM tasks t
 		%s
 		%s
@@ -186,7 +168,7 @@ func (r *taskRepository) List(ctx context.Context, opts repository.TaskListOptio
 
 		err := rows.Scan(
 			&task.ID, &task.Title, &task.Description, &task.AssigneeID,
-			&status, &priority, &task.DueDate,
+			&status, &priority, &task.DueDate, &task.ParentID, &task.SeriesID, &task.SeriesIndex, &task.CreatorID,
 			&task.CreatedAt, &task.UpdatedAt, &task.Version,
 			&task.IsDeleted, &task.DeletedAt)
 
@@ -208,6 +190,210 @@ func (r *taskRepository) List(ctx context.Context, opts repository.TaskListOptio
 	return tasks, total, nil
 }
 
+// Search lists tasks matching opts.SearchQuery with ranking and highlighted snippets.
+// Postgres evaluates ts_headline after the LIMIT, so only the returned page is highlighted.
+func (r *taskRepository) Search(ctx context.Context, opts repository.TaskListOptions) ([]*domain.TaskSearchResult, int64, error) {
+	if opts.SearchQuery == "" {
+		return nil, 0, domain.ErrInvalidInput("search query is required")
+	}
+
+	whereClause, args, err := buildTaskFilter(opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	argIndex := len(args)
+	orderClause := buildTaskOrder(opts)
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM tasks t %s", whereClause)
+	var total int64
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	offset := opts.Page * pageSize
+
+	highlight := fmt.Sprintf("StartSel=%s, StopSel=%s", domain.SearchHighlightStart, domain.SearchHighlightStop)
+	matchedLabels := "ARRAY[]::text[]"
+	if opts.SearchLabels {
+		matchedLabels = fmt.Sprintf(`ARRAY(
+			SELECT c.name FROM task_categories tc JOIN categories c ON c.id = tc.category_id
+			WHERE tc.task_id = t.id AND NOT c.is_deleted AND to_tsvector('english', c.name) @@ %[1]s
+			UNION ALL
+			SELECT tg.name FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+			WHERE tt.task_id = t.id AND NOT tg.is_deleted AND to_tsvector('english', tg.name) @@ %[1]s)`, taskSearchQuery)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.parent_id,
+			   t.series_id, COALESCE(t.series_index, 0), COALESCE(t.creator_id::text, ''), t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at,
+			   %[1]s,
+			   ts_headline('english', t.title, %[2]s, '%[3]s, HighlightAll=true'),
+			   ts_headline('english', COALESCE(t.description, ''), %[2]s, '%[3]s, MaxWords=35, MinWords=15, MaxFragments=2'),
+			   %[4]s
+		FROM tasks t
+		%[5]s
+		%[6]s
+		LIMIT $%[7]d OFFSET $%[8]d`,
+		taskSearchRank, taskSearchQuery, highlight, matchedLabels,
+		whereClause, orderClause, argIndex+1, argIndex+2)
+
+	args = append(args, pageSize, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*domain.TaskSearchResult
+	for rows.Next() {
+		task := &domain.Task{}
+		result := &domain.TaskSearchResult{Task: task}
+		var status, priority string
+		var labels pq.StringArray
+
+		err := rows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.AssigneeID,
+			&status, &priority, &task.DueDate, &task.ParentID, &task.SeriesID, &task.SeriesIndex, &task.CreatorID,
+			&task.CreatedAt, &task.UpdatedAt, &task.Version,
+			&task.IsDeleted, &task.DeletedAt,
+			&result.Rank, &result.TitleHighlight, &result.DescriptionSnippet, &labels)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan search result: %w", err)
+		}
+
+		task.Status = domain.TaskStatus(status)
+		task.Priority = domain.TaskPriority(priority)
+		result.MatchedLabels = labels
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	// Relations are loaded once the result set is closed
+	for _, result := range results {
+		if err := r.loadTaskRelations(ctx, result.Task); err != nil {
+			return nil, 0, fmt.Errorf("failed to load task relations: %w", err)
+		}
+	}
+
+	return results, total, nil
+}
+
+// exportFetchSize is the number of rows fetched from the export cursor at a time
+const exportFetchSize = 500
+
+func (r *taskRepository) StreamForExport(ctx context.Context, opts repository.TaskListOptions, fn func(*domain.TaskExportRecord) error) error {
+	whereClause, args, err := buildTaskFilter(opts)
+	if err != nil {
+		return err
+	}
+	orderClause := buildTaskOrder(opts)
+
+	// Cursors only live inside a transaction
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`
+		DECLARE task_export NO SCROLL CURSOR FOR
+		SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.parent_id,
+			   t.series_id, COALESCE(t.series_index, 0), COALESCE(t.creator_id::text, ''), t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at,
+			   COALESCE(u.email, ''),
+			   ARRAY(SELECT c.name FROM task_categories tc
+			         JOIN categories c ON c.id = tc.category_id
+			         WHERE tc.task_id = t.id AND c.is_deleted = false ORDER BY c.name),
+			   ARRAY(SELECT tg.name FROM task_tags tt
+			         JOIN tags tg ON tg.id = tt.tag_id
+			         WHERE tt.task_id = t.id AND tg.is_deleted = false ORDER BY tg.name),
+			   COALESCE((SELECT json_agg(json_build_object(
+			                 'id', rm.id, 'task_id', rm.task_id, 'user_id', rm.user_id,
+			                 'remind_at', rm.reminder_time, 'message', COALESCE(rm.message, ''), 'is_sent', rm.is_sent)
+			             ORDER BY rm.reminder_time)
+			             FROM task_reminders rm WHERE rm.task_id = t.id), '[]')
+		FROM tasks t
+		LEFT JOIN users u ON u.id = t.assignee_id
+		%s
+		%s`, whereClause, orderClause)
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to open export cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM task_export", exportFetchSize)
+	for {
+		fetched, err := r.fetchExportBatch(ctx, tx, fetch, fn)
+		if err != nil {
+			return err
+		}
+		if fetched < exportFetchSize {
+			break
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "CLOSE task_export"); err != nil {
+		return fmt.Errorf("failed to close export cursor: %w", err)
+	}
+	return tx.Commit()
+}
+
+// fetchExportBatch fetches the next rows from the export cursor and hands them to fn
+func (r *taskRepository) fetchExportBatch(ctx context.Context, tx *sql.Tx, fetch string, fn func(*domain.TaskExportRecord) error) (int, error) {
+	rows, err := tx.QueryContext(ctx, fetch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch tasks for export: %w", err)
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		task := &domain.Task{}
+		record := &domain.TaskExportRecord{Task: task}
+		var status, priority string
+		var categories, tags pq.StringArray
+		var reminders []byte
+
+		err := rows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.AssigneeID,
+			&status, &priority, &task.DueDate, &task.ParentID, &task.SeriesID, &task.SeriesIndex, &task.CreatorID,
+			&task.CreatedAt, &task.UpdatedAt, &task.Version,
+			&task.IsDeleted, &task.DeletedAt,
+			&record.AssigneeEmail, &categories, &tags, &reminders)
+		if err != nil {
+			return fetched, fmt.Errorf("failed to scan task: %w", err)
+		}
+
+		task.Status = domain.TaskStatus(status)
+		task.Priority = domain.TaskPriority(priority)
+		for _, name := range categories {
+			task.Categories = append(task.Categories, domain.Category{Name: name})
+		}
+		for _, name := range tags {
+			task.Tags = append(task.Tags, domain.Tag{Name: name})
+		}
+		if err := json.Unmarshal(reminders, &record.Reminders); err != nil {
+			return fetched, fmt.Errorf("failed to decode reminders: %w", err)
+		}
+
+		fetched++
+		if err := fn(record); err != nil {
+			return fetched, err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fetched, fmt.Errorf("failed to read tasks for export: %w", err)
+	}
+	return fetched, nil
+}
+
 func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
 	if err := task.IsValid(); err != nil {
 		return fmt.Errorf("invalid task: %w", err)
@@ -216,14 +402,18 @@ func (r *taskRepository) Update(ctx context.Context, task *domain.Task) error {
 	query := `
 		UPDATE tasks 
 		SET title = $2, description = $3, assignee_id = $4, status = $5, priority = $6, 
-			due_date = $7, updated_at = NOW()
-		WHERE id = $1 AND version = $8 AND is_deleted = false`
+			due_date = $7, parent_id = $8, updated_at = NOW()
+		WHERE id = $1 AND version = $9 AND is_deleted = false`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
 		task.ID, task.Title, task.Description, task.AssigneeID,
-		string(task.Status), string(task.Priority), task.DueDate, task.Version)
+		string(task.Status), string(task.Priority), task.DueDate, task.ParentID, task.Version)
 
 	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == taskCycleConstraint {
+			return domain.ErrBusinessRule("a task cannot be moved under its own subtask")
+		}
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 
@@ -250,7 +440,7 @@ func (r *taskRepository) SoftDelete(ctx context.Context, id string, version int6
 		SET is_deleted = true, deleted_at = NOW()
 		WHERE id = $1 AND version = $2 AND is_deleted = false`
 
-	result, err := r.db.ExecContext(ctx, query, id, version)
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
 	if err != nil {
 		return fmt.Errorf("failed to soft delete task: %w", err)
 	}
@@ -273,7 +463,7 @@ func (r *taskRepository) Restore(ctx context.Context, id string, version int64)
 		SET is_deleted = false, deleted_at = NULL, version = version + 1
 		WHERE id = $1 AND version = $2 AND is_deleted = true`
 
-	result, err := r.db.ExecContext(ctx, query, id, version)
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
 	if err != nil {
 		return fmt.Errorf("failed to restore task: %w", err)
 	}
@@ -290,13 +480,173 @@ func (r *taskRepository) Restore(ctx context.Context, id string, version int64)
 	return nil
 }
 
+func (r *taskRepository) GetSubtree(ctx context.Context, rootID string) ([]*domain.TaskNode, error) {
+	// The path guards against cycles left by data written before the cycle check existed
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.parent_id,
+			       t.series_id, t.series_index, t.creator_id, t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at,
+			       1 AS depth, ARRAY[t.id] AS path
+			FROM tasks t
+			WHERE t.id = $1 AND NOT t.is_deleted
+			UNION ALL
+			SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.parent_id,
+			       t.series_id, t.series_index, t.creator_id, t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at,
+			       tree.depth + 1, tree.path || t.id
+			FROM tasks t
+			JOIN tree ON t.parent_id = tree.id
+			WHERE NOT t.is_deleted AND NOT t.id = ANY(tree.path)
+		)
+		SELECT id, title, description, assignee_id, status, priority, due_date, parent_id,
+		       series_id, COALESCE(series_index, 0), COALESCE(creator_id::text, ''), created_at, updated_at, version, is_deleted, deleted_at, depth
+		FROM tree
+		ORDER BY depth, created_at, id`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, rootID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task subtree: %w", err)
+	}
+	defer rows.Close()
+
+	var nodes []*domain.TaskNode
+	for rows.Next() {
+		task := &domain.Task{}
+		node := &domain.TaskNode{Task: task}
+		var status, priority string
+
+		err := rows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.AssigneeID,
+			&status, &priority, &task.DueDate, &task.ParentID, &task.SeriesID, &task.SeriesIndex, &task.CreatorID,
+			&task.CreatedAt, &task.UpdatedAt, &task.Version,
+			&task.IsDeleted, &task.DeletedAt, &node.Depth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+
+		task.Status = domain.TaskStatus(status)
+		task.Priority = domain.TaskPriority(priority)
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get task subtree: %w", err)
+	}
+	rows.Close()
+
+	// Relations are loaded once the rows are closed, since a transaction runs one query at a time
+	for _, node := range nodes {
+		if err := r.loadTaskRelations(ctx, node.Task); err != nil {
+			return nil, fmt.Errorf("failed to load task relations: %w", err)
+		}
+	}
+
+	return nodes, nil
+}
+
+func (r *taskRepository) SoftDeleteDescendants(ctx context.Context, id string) ([]string, error) {
+	// Subtasks of an already deleted task were left behind by an earlier delete and
+	// are not part of this one
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT t.id, ARRAY[t.parent_id, t.id] AS path
+			FROM tasks t
+			WHERE t.parent_id = $1 AND NOT t.is_deleted
+			UNION ALL
+			SELECT t.id, tree.path || t.id
+			FROM tasks t
+			JOIN tree ON t.parent_id = tree.id
+			WHERE NOT t.is_deleted AND NOT t.id = ANY(tree.path)
+		)
+		UPDATE tasks
+		SET is_deleted = true, deleted_at = NOW()
+		WHERE id IN (SELECT id FROM tree)
+		RETURNING id`
+
+	ids, err := queryIDs(ctx, executorFromContext(ctx, r.db), query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to soft delete subtasks: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *taskRepository) RestoreDescendants(ctx context.Context, id string) ([]string, error) {
+	// A cascading delete stamps the whole subtree with the transaction time, so matching
+	// deleted_at tells those subtasks apart from ones deleted on their own
+	query := `
+		WITH RECURSIVE root AS (
+			SELECT id, deleted_at FROM tasks WHERE id = $1 AND is_deleted
+		), tree AS (
+			SELECT t.id, ARRAY[root.id, t.id] AS path
+			FROM tasks t
+			JOIN root ON t.parent_id = root.id
+			WHERE t.is_deleted AND t.deleted_at = root.deleted_at
+			UNION ALL
+			SELECT t.id, tree.path || t.id
+			FROM tasks t
+			JOIN tree ON t.parent_id = tree.id
+			CROSS JOIN root
+			WHERE t.is_deleted AND t.deleted_at = root.deleted_at AND NOT t.id = ANY(tree.path)
+		)
+		UPDATE tasks
+		SET is_deleted = false, deleted_at = NULL
+		WHERE id IN (SELECT id FROM tree)
+		RETURNING id`
+
+	ids, err := queryIDs(ctx, executorFromContext(ctx, r.db), query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore subtasks: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *taskRepository) LinkToSeries(ctx context.Context, task *domain.Task) error {
+	query := `
+		UPDATE tasks
+		SET series_id = $2, series_index = NULLIF($3, 0), updated_at = NOW()
+		WHERE id = $1 AND version = $4 AND is_deleted = false`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		task.ID, task.SeriesID, task.SeriesIndex, task.Version)
+	if err != nil {
+		return fmt.Errorf("failed to link task to series: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionConflict("task", task.Version, task.Version+1)
+	}
+
+	task.Version++
+	task.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *taskRepository) ListSeriesInstances(ctx context.Context, seriesID string) ([]*domain.Task, error) {
+	query := `
+		SELECT t.id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.parent_id,
+			   t.series_id, COALESCE(t.series_index, 0), COALESCE(t.creator_id::text, ''), t.created_at, t.updated_at, t.version, t.is_deleted, t.deleted_at
+		FROM tasks t
+		WHERE t.series_id = $1 AND NOT t.is_deleted
+		ORDER BY t.series_index`
+
+	tasks, err := r.queryTasks(ctx, query, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series instances: %w", err)
+	}
+	return tasks, nil
+}
+
 func (r *taskRepository) AddCategories(ctx context.Context, taskID string, categoryIDs []string, version int64) error {
 	if len(categoryIDs) == 0 {
 		return nil
 	}
 
 	// Start transaction
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := beginScopedTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -339,7 +689,7 @@ func (r *taskRepository) RemoveCategories(ctx context.Context, taskID string, ca
 	}
 
 	// Start transaction
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := beginScopedTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -372,6 +722,127 @@ func (r *taskRepository) RemoveCategories(ctx context.Context, taskID string, ca
 	return tx.Commit()
 }
 
+func (r *taskRepository) AddParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) error {
+	if len(participants) == 0 {
+		return nil
+	}
+
+	tx, err := beginScopedTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Verify task exists and version matches
+	var currentVersion int64
+	err = tx.QueryRowContext(ctx, "SELECT version FROM tasks WHERE id = $1 AND is_deleted = false", taskID).Scan(&currentVersion)
+	if err != nil {
+		return fmt.Errorf("failed to verify task: %w", err)
+	}
+
+	if currentVersion != version {
+		return domain.ErrVersionConflict("task", version, currentVersion)
+	}
+
+	if err := insertTaskParticipants(ctx, tx, taskID, participants); err != nil {
+		return err
+	}
+
+	// Update task version (trigger will handle this)
+	_, err = tx.ExecContext(ctx, "UPDATE tasks SET updated_at = NOW() WHERE id = $1", taskID)
+	if err != nil {
+		return fmt.Errorf("failed to update task timestamp: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *taskRepository) RemoveParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) error {
+	if len(participants) == 0 {
+		return nil
+	}
+
+	tx, err := beginScopedTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Verify task exists and version matches
+	var currentVersion int64
+	err = tx.QueryRowContext(ctx, "SELECT version FROM tasks WHERE id = $1 AND is_deleted = false", taskID).Scan(&currentVersion)
+	if err != nil {
+		return fmt.Errorf("failed to verify task: %w", err)
+	}
+
+	if currentVersion != version {
+		return domain.ErrVersionConflict("task", version, currentVersion)
+	}
+
+	userIDs, roles := participantArrays(participants)
+	query := `
+		DELETE FROM task_participants p
+		USING unnest($2::uuid[], $3::text[]) AS r(user_id, role)
+		WHERE p.task_id = $1 AND p.user_id = r.user_id AND p.role = r.role`
+	_, err = tx.ExecContext(ctx, query, taskID, pq.Array(userIDs), pq.Array(roles))
+	if err != nil {
+		return fmt.Errorf("failed to remove participants: %w", err)
+	}
+
+	// Without its ASSIGNEE row the primary assignee hands over to the longest-standing
+	// remaining assignee. With none left the column is set to NULL and the update fails,
+	// which the service prevents by keeping an assignee.
+	query = `
+		UPDATE tasks t SET assignee_id = (
+			SELECT p.user_id FROM task_participants p
+			WHERE p.task_id = t.id AND p.role = 'ASSIGNEE'
+			ORDER BY p.added_at, p.user_id
+			LIMIT 1)
+		WHERE t.id = $1 AND NOT EXISTS (
+			SELECT 1 FROM task_participants p
+			WHERE p.task_id = t.id AND p.user_id = t.assignee_id AND p.role = 'ASSIGNEE')`
+	_, err = tx.ExecContext(ctx, query, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to replace primary assignee: %w", err)
+	}
+
+	// Update task version (trigger will handle this)
+	_, err = tx.ExecContext(ctx, "UPDATE tasks SET updated_at = NOW() WHERE id = $1", taskID)
+	if err != nil {
+		return fmt.Errorf("failed to update task timestamp: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// insertTaskParticipants adds participants to a task, leaving roles users already hold alone
+func insertTaskParticipants(ctx context.Context, tx *scopedTx, taskID string, participants []domain.TaskParticipant) error {
+	if len(participants) == 0 {
+		return nil
+	}
+
+	userIDs, roles := participantArrays(participants)
+	query := `
+		INSERT INTO task_participants (task_id, user_id, role)
+		SELECT $1, r.user_id, r.role FROM unnest($2::uuid[], $3::text[]) AS r(user_id, role)
+		ON CONFLICT (task_id, user_id, role) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, taskID, pq.Array(userIDs), pq.Array(roles)); err != nil {
+		return fmt.Errorf("failed to add participants: %w", err)
+	}
+	return nil
+}
+
+// participantArrays splits participants into parallel user and role arrays for unnest
+func participantArrays(participants []domain.TaskParticipant) ([]string, []string) {
+	userIDs := make([]string, 0, len(participants))
+	roles := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		userIDs = append(userIDs, participant.UserID)
+		roles = append(roles, string(participant.Role))
+	}
+	return userIDs, roles
+}
+
 func (r *taskRepository) AssignTags(ctx context.Context, taskID string, tagIDs []string) error {
 	if len(tagIDs) == 0 {
 		return nil
@@ -392,7 +863,7 @@ func (r *taskRepository) RemoveTags(ctx context.Context, taskID string, tagIDs [
 	}
 
 	// Start transaction
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := beginScopedTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -431,7 +902,7 @@ func (r *taskRepository) AddTags(ctx context.Context, taskID string, tagIDs []st
 	}
 
 	// Start transaction
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := beginScopedTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -468,6 +939,33 @@ func (r *taskRepository) AddTags(ctx context.Context, taskID string, tagIDs []st
 	return tx.Commit()
 }
 
+// AddHistory records an audit trail entry for a task
+func (r *taskRepository) AddHistory(ctx context.Context, entry *domain.TaskHistory) error {
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	query := `
+		INSERT INTO task_history (id, task_id, action, actor_id, service_name, timestamp, details)
+		VALUES ($1, $2, $3, $4, COALESCE(NULLIF(current_setting('app.service_name', true), ''), 'admin-service'), $5, $6)`
+
+	var details interface{}
+	if len(entry.Details) > 0 {
+		details = []byte(entry.Details)
+	}
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		entry.ID, entry.TaskID, string(entry.Action), entry.ActorID, entry.Timestamp, details)
+	if err != nil {
+		return fmt.Errorf("failed to add task history: %w", err)
+	}
+
+	return nil
+}
+
 // GetHistory gets the history of a task
 func (r *taskRepository) GetHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error) {
 	query := `
@@ -514,17 +1012,19 @@ func (r *taskRepository) GetHistory(ctx context.Context, taskID string) ([]*doma
 	return history, nil
 }
 
-// loadTaskRelations loads categories, tags, history, and reminders for a task
+// loadTaskRelations loads categories, tags, participants, history, and reminders for a task
 func (r *taskRepository) loadTaskRelations(ctx context.Context, task *domain.Task) error {
+	db := executorFromContext(ctx, r.db)
+
 	// Load categories
 	categoryQuery := `
-		SELECT c.id, c.name, c.description, c.color, c.parent_id, c.is_public, c.creator_id,
+		SELECT c.id, c.name, c.description, c.color, c.parent_id, c.is_public, c.workflow_id, c.creator_id,
 			   c.created_at, c.updated_at, c.version, c.is_deleted, c.deleted_at
 		FROM categories c
 		INNER JOIN task_categories tc ON c.id = tc.category_id
 		WHERE tc.task_id = $1 AND c.is_deleted = false`
 
-	categoryRows, err := r.db.QueryContext(ctx, categoryQuery, task.ID)
+	categoryRows, err := db.QueryContext(ctx, categoryQuery, task.ID)
 	if err != nil {
 		return fmt.Errorf("failed to load categories: %w", err)
 	}
@@ -534,7 +1034,7 @@ func (r *taskRepository) loadTaskRelations(ctx context.Context, task *domain.Tas
 		category := domain.Category{}
 		err := categoryRows.Scan(
 			&category.ID, &category.Name, &category.Description, &category.Color,
-			&category.ParentID, &category.IsPublic, &category.CreatorID,
+			&category.ParentID, &category.IsPublic, &category.WorkflowID, &category.CreatorID,
 			&category.CreatedAt, &category.UpdatedAt, &category.Version,
 			&category.IsDeleted, &category.DeletedAt)
 		if err != nil {
@@ -551,7 +1051,7 @@ func (r *taskRepository) loadTaskRelations(ctx context.Context, task *domain.Tas
 		INNER JOIN task_tags tt ON t.id = tt.tag_id
 		WHERE tt.task_id = $1 AND t.is_deleted = false`
 
-	tagRows, err := r.db.QueryContext(ctx, tagQuery, task.ID)
+	tagRows, err := db.QueryContext(ctx, tagQuery, task.ID)
 	if err != nil {
 		return fmt.Errorf("failed to load tags: %w", err)
 	}
@@ -569,6 +1069,28 @@ func (r *taskRepository) loadTaskRelations(ctx context.Context, task *domain.Tas
 		task.Tags = append(task.Tags, tag)
 	}
 
+	// Load participants
+	participantQuery := `
+		SELECT user_id, role, added_at
+		FROM task_participants
+		WHERE task_id = $1
+		ORDER BY added_at, role, user_id`
+
+	participantRows, err := db.QueryContext(ctx, participantQuery, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load participants: %w", err)
+	}
+	defer participantRows.Close()
+
+	for participantRows.Next() {
+		participant := domain.TaskParticipant{}
+		err := participantRows.Scan(&participant.UserID, &participant.Role, &participant.AddedAt)
+		if err != nil {
+			return fmt.Errorf("failed to scan participant: %w", err)
+		}
+		task.Participants = append(task.Participants, participant)
+	}
+
 	// Load history
 	historyQuery := `
 		SELECT id, task_id, action, actor_id, service_name, timestamp, details
@@ -576,7 +1098,7 @@ func (r *taskRepository) loadTaskRelations(ctx context.Context, task *domain.Tas
 		WHERE task_id = $1
 		ORDER BY timestamp DESC`
 
-	historyRows, err := r.db.QueryContext(ctx, historyQuery, task.ID)
+	historyRows, err := db.QueryContext(ctx, historyQuery, task.ID)
 	if err != nil {
 		return fmt.Errorf("failed to load history: %w", err)
 	}
@@ -595,3 +1117,142 @@ func (r *taskRepository) loadTaskRelations(ctx context.Context, task *domain.Tas
 
 	return nil
 }
+
+// taskSortColumns are the columns tasks may be sorted by
+var taskSortColumns = map[string]bool{
+	"title":      true,
+	"status":     true,
+	"priority":   true,
+	"due_date":   true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// taskSearchQuery is the parsed search query. buildTaskFilter always binds the search
+// text as $1 so ranking and highlighting expressions can refer to it.
+const taskSearchQuery = "websearch_to_tsquery('english', $1)"
+
+// taskSearchRank ranks matches by title and description; label-only matches rank 0
+const taskSearchRank = "ts_rank(t.search_vector, " + taskSearchQuery + ")"
+
+// buildTaskFilter turns list options into a WHERE clause over tasks aliased as t
+func buildTaskFilter(opts repository.TaskListOptions) (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+	argIndex := 0
+
+	if opts.SearchQuery != "" {
+		argIndex++
+		args = append(args, opts.SearchQuery)
+		condition := "t.search_vector @@ " + taskSearchQuery
+		if opts.SearchLabels {
+			// Uncorrelated subqueries let the name indexes do the matching
+			condition = fmt.Sprintf(`(%s
+				OR t.id IN (SELECT tc.task_id FROM task_categories tc JOIN categories c ON c.id = tc.category_id
+				            WHERE NOT c.is_deleted AND to_tsvector('english', c.name) @@ %s)
+				OR t.id IN (SELECT tt.task_id FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+				            WHERE NOT tg.is_deleted AND to_tsvector('english', tg.name) @@ %s))`,
+				condition, taskSearchQuery, taskSearchQuery)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	// Base condition for soft deletes
+	if opts.DeletedOnly {
+		conditions = append(conditions, "t.is_deleted = true")
+	} else if !opts.IncludeDeleted {
+		conditions = append(conditions, "t.is_deleted = false")
+	}
+
+	// Add filters
+	if opts.AssigneeID != "" {
+		argIndex++
+		condition := fmt.Sprintf("EXISTS (SELECT 1 FROM task_participants tp WHERE tp.task_id = t.id AND tp.user_id = $%d", argIndex)
+		args = append(args, opts.AssigneeID)
+		if len(opts.AssigneeRoles) > 0 {
+			roles := make([]string, 0, len(opts.AssigneeRoles))
+			for _, role := range opts.AssigneeRoles {
+				roles = append(roles, string(role))
+			}
+			argIndex++
+			condition += fmt.Sprintf(" AND tp.role = ANY($%d)", argIndex)
+			args = append(args, pq.Array(roles))
+		}
+		conditions = append(conditions, condition+")")
+	}
+
+	if opts.CreatorID != "" {
+		argIndex++
+		conditions = append(conditions, fmt.Sprintf("t.creator_id = $%d", argIndex))
+		args = append(args, opts.CreatorID)
+	}
+
+	if opts.Status != "" && opts.Status != domain.TaskStatusUnspecified {
+		argIndex++
+		conditions = append(conditions, fmt.Sprintf("t.status = $%d", argIndex))
+		args = append(args, string(opts.Status))
+	}
+
+	if opts.Priority != "" && opts.Priority != domain.TaskPriorityUnspecified {
+		argIndex++
+		conditions = append(conditions, fmt.Sprintf("t.priority = $%d", argIndex))
+		args = append(args, string(opts.Priority))
+	}
+
+	if len(opts.CategoryIDs) > 0 {
+		argIndex++
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM task_categories tc WHERE tc.task_id = t.id AND tc.category_id = ANY($%d::uuid[]))", argIndex))
+		args = append(args, pq.Array(opts.CategoryIDs))
+	}
+
+	if len(opts.TagIDs) > 0 {
+		argIndex++
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM task_tags tt WHERE tt.task_id = t.id AND tt.tag_id = ANY($%d::uuid[]))", argIndex))
+		args = append(args, pq.Array(opts.TagIDs))
+	}
+
+	if opts.DueBefore != nil {
+		argIndex++
+		conditions = append(conditions, fmt.Sprintf("t.due_date <= $%d", argIndex))
+		args = append(args, *opts.DueBefore)
+	}
+
+	if opts.DueAfter != nil {
+		argIndex++
+		conditions = append(conditions, fmt.Sprintf("t.due_date >= $%d", argIndex))
+		args = append(args, *opts.DueAfter)
+	}
+
+	if opts.QueryExpr != nil {
+		condition, queryArgs, err := compileTaskQuery(opts.QueryExpr, args)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to compile task query: %w", err)
+		}
+		conditions = append(conditions, condition)
+		args = queryArgs
+	}
+
+	if len(conditions) == 0 {
+		return "", args, nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+// buildTaskOrder returns the ORDER BY clause; the task ID breaks ties so the order is stable.
+// Searches are ordered by relevance unless another sort column is requested.
+func buildTaskOrder(opts repository.TaskListOptions) string {
+	if opts.SearchQuery != "" && (opts.SortBy == "" || opts.SortBy == repository.SortByRelevance) {
+		return "ORDER BY " + taskSearchRank + " DESC, t.created_at DESC, t.id"
+	}
+	if !taskSortColumns[opts.SortBy] {
+		return "ORDER BY t.created_at DESC, t.id"
+	}
+
+	direction := "ASC"
+	if opts.SortDesc {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("ORDER BY t.%s %s, t.id", opts.SortBy, direction)
+}
@@ -2,13 +2,16 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/todo-app/services/admin-service/internal/config"
 	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/taskquery"
 	"github.com/todo-app/services/admin-service/pkg/db"
 )
 
@@ -206,5 +209,372 @@ func TestTaskRepository_Integration(t *testing.T) {
 		if !domain.IsNotFoundError(err) {
 			t.Errorf("Expected not found error, got: %v", err)
 		}
+
+		deleted, err := taskRepo.GetDeletedByID(ctx, testTask.ID)
+		if err != nil || !deleted.IsDeleted || deleted.Title != testTask.Title {
+			t.Errorf("GetDeletedByID() = %+v, %v; want the deleted task", deleted, err)
+		}
+		if _, err := taskRepo.GetDeletedByID(ctx, assigneeID); !domain.IsNotFoundError(err) {
+			t.Errorf("GetDeletedByID() of a missing task error = %v, want not found", err)
+		}
+	})
+
+	t.Run("StreamForExport", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			task := &domain.Task{
+				Title:      fmt.Sprintf("Export Test Task %d", i),
+				AssigneeID: assigneeID,
+				Status:     domain.TaskStatusOpen,
+				Priority:   domain.TaskPriorityMedium,
+			}
+			if err := taskRepo.Create(ctx, task); err != nil {
+				t.Fatalf("Failed to create test task: %v", err)
+			}
+		}
+
+		opts := repository.TaskListOptions{ListOptions: repository.ListOptions{SortBy: "title"}, AssigneeID: assigneeID}
+		want, _, err := taskRepo.List(ctx, opts)
+		if err != nil {
+			t.Fatalf("Failed to list tasks: %v", err)
+		}
+
+		var got []*domain.TaskExportRecord
+		err = taskRepo.StreamForExport(ctx, opts, func(record *domain.TaskExportRecord) error {
+			got = append(got, record)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to stream tasks: %v", err)
+		}
+
+		if len(got) != len(want) {
+			t.Fatalf("Streamed %d tasks, want %d", len(got), len(want))
+		}
+		for i, record := range got {
+			if record.Task.ID != want[i].ID {
+				t.Errorf("Task %d: got %s, want %s", i, record.Task.ID, want[i].ID)
+			}
+			if record.AssigneeEmail == "" {
+				t.Errorf("Task %d: assignee email should be set", i)
+			}
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		for _, task := range []*domain.Task{
+			{Title: "Reconcile quarterly budgets", Description: "Compare spending against the plan"},
+			{Title: "Team offsite", Description: "Book rooms; reconciling the budget comes later"},
+			{Title: "Unrelated chore", Description: "Water the plants"},
+		} {
+			task.AssigneeID = assigneeID
+			task.Status = domain.TaskStatusOpen
+			task.Priority = domain.TaskPriorityMedium
+			if err := taskRepo.Create(ctx, task); err != nil {
+				t.Fatalf("Failed to create test task: %v", err)
+			}
+		}
+
+		opts := repository.TaskListOptions{ListOptions: repository.ListOptions{SearchQuery: "budget reconciling"}, AssigneeID: assigneeID}
+		results, total, err := taskRepo.Search(ctx, opts)
+		if err != nil {
+			t.Fatalf("Failed to search tasks: %v", err)
+		}
+
+		// Stemming matches both tasks; the title match ranks first
+		if total != 2 || len(results) != 2 {
+			t.Fatalf("Got %d results (total %d), want 2", len(results), total)
+		}
+		if results[0].Task.Title != "Reconcile quarterly budgets" {
+			t.Errorf("Title match should rank first, got %q", results[0].Task.Title)
+		}
+		if results[0].Rank <= results[1].Rank {
+			t.Errorf("Ranks should be descending: %f, %f", results[0].Rank, results[1].Rank)
+		}
+		if !strings.Contains(results[0].TitleHighlight, domain.SearchHighlightStart) {
+			t.Errorf("Title should be highlighted, got %q", results[0].TitleHighlight)
+		}
+		if !strings.Contains(results[1].DescriptionSnippet, domain.SearchHighlightStart) {
+			t.Errorf("Description snippet should be highlighted, got %q", results[1].DescriptionSnippet)
+		}
+	})
+
+	t.Run("Facets", func(t *testing.T) {
+		prefix := fmt.Sprintf("facet-%d", time.Now().UnixNano())
+		for _, task := range []*domain.Task{
+			{Title: prefix + " one", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityHigh},
+			{Title: prefix + " two", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityLow},
+			{Title: prefix + " three", Status: domain.TaskStatusCompleted, Priority: domain.TaskPriorityHigh},
+		} {
+			task.AssigneeID = assigneeID
+			if err := taskRepo.Create(ctx, task); err != nil {
+				t.Fatalf("Failed to create test task: %v", err)
+			}
+		}
+
+		expr, err := taskquery.Parse("title:" + prefix + " status:open")
+		if err != nil {
+			t.Fatalf("Failed to parse query: %v", err)
+		}
+		opts := repository.TaskListOptions{}
+		if opts.QueryExpr, err = taskquery.Bind(expr, taskquery.Env{}); err != nil {
+			t.Fatalf("Failed to bind query: %v", err)
+		}
+
+		facets, err := taskRepo.Facets(ctx, opts, []domain.TaskFacet{domain.TaskFacetStatus, domain.TaskFacetPriority, domain.TaskFacetAssignee})
+		if err != nil {
+			t.Fatalf("Failed to count facets: %v", err)
+		}
+
+		render := func(counts *domain.FacetCounts) string {
+			var values []string
+			for _, value := range counts.Values {
+				values = append(values, fmt.Sprintf("%s=%d", value.Label, value.Count))
+			}
+			return strings.Join(values, ",")
+		}
+		// The status facet ignores status:open; the others respect it
+		if got := render(facets[0]); got != "OPEN=2,COMPLETED=1" {
+			t.Errorf("status facet = %s", got)
+		}
+		if got := render(facets[1]); got != "HIGH=1,LOW=1" {
+			t.Errorf("priority facet = %s", got)
+		}
+		if len(facets[2].Values) != 1 || facets[2].Values[0].Value != assigneeID || facets[2].Values[0].Count != 2 {
+			t.Errorf("assignee facet = %+v", facets[2].Values)
+		}
 	})
+
+	t.Run("Subtasks", func(t *testing.T) {
+		newTask := func(title string, parentID *string) *domain.Task {
+			task := &domain.Task{
+				Title:      title,
+				AssigneeID: assigneeID,
+				Status:     domain.TaskStatusOpen,
+				Priority:   domain.TaskPriorityMedium,
+				ParentID:   parentID,
+			}
+			if err := taskRepo.Create(ctx, task); err != nil {
+				t.Fatalf("Failed to create task %s: %v", title, err)
+			}
+			return task
+		}
+		root := newTask("Subtask root", nil)
+		child := newTask("Subtask child", &root.ID)
+		grandchild := newTask("Subtask grandchild", &child.ID)
+
+		nodes, err := taskRepo.GetSubtree(ctx, root.ID)
+		if err != nil {
+			t.Fatalf("Failed to get subtree: %v", err)
+		}
+		if len(nodes) != 3 || nodes[2].Task.ID != grandchild.ID || nodes[2].Depth != 3 {
+			t.Fatalf("subtree = %d nodes, want root, child and grandchild in order", len(nodes))
+		}
+
+		// The trigger refuses cycles even when the service check is bypassed
+		root.ParentID = &grandchild.ID
+		if err := taskRepo.Update(ctx, root); !domain.IsBusinessRuleError(err) {
+			t.Errorf("Update() forming a cycle error = %v, want business rule violation", err)
+		}
+		root.ParentID = nil
+
+		// Descendants are matched by deletion time on restore, so the delete is one transaction
+		var deleted []string
+		err = NewTransactionManager(dbConn.DB).WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			if err := taskRepo.SoftDelete(txCtx, root.ID, root.Version); err != nil {
+				return err
+			}
+			var err error
+			deleted, err = taskRepo.SoftDeleteDescendants(txCtx, root.ID)
+			return err
+		})
+		if err != nil || len(deleted) != 2 {
+			t.Fatalf("cascading delete = %v, %v; want both subtasks", deleted, err)
+		}
+
+		restored, err := taskRepo.RestoreDescendants(ctx, root.ID)
+		if err != nil || len(restored) != 2 {
+			t.Errorf("RestoreDescendants() = %v, %v; want both subtasks", restored, err)
+		}
+	})
+
+	t.Run("Dependencies", func(t *testing.T) {
+		newTask := func(title string) *domain.Task {
+			task := &domain.Task{
+				Title:      title,
+				AssigneeID: assigneeID,
+				Status:     domain.TaskStatusOpen,
+				Priority:   domain.TaskPriorityMedium,
+			}
+			if err := taskRepo.Create(ctx, task); err != nil {
+				t.Fatalf("Failed to create task %s: %v", title, err)
+			}
+			return task
+		}
+		design, build, release := newTask("Design"), newTask("Build"), newTask("Release")
+		link := func(task, blocker *domain.Task) error {
+			return taskRepo.AddDependency(ctx, &domain.TaskDependency{TaskID: task.ID, BlockerID: blocker.ID, CreatorID: assigneeID})
+		}
+
+		if err := link(build, design); err != nil {
+			t.Fatalf("Failed to add dependency: %v", err)
+		}
+		if err := link(release, build); err != nil {
+			t.Fatalf("Failed to add dependency: %v", err)
+		}
+		if err := link(release, build); !domain.IsConflictError(err) {
+			t.Errorf("AddDependency() twice error = %v, want conflict", err)
+		}
+		// The trigger refuses cycles even when the service check is bypassed
+		if err := link(design, release); !domain.IsBusinessRuleError(err) {
+			t.Errorf("AddDependency() forming a cycle error = %v, want business rule violation", err)
+		}
+
+		blocked, err := taskRepo.IsBlockedBy(ctx, release.ID, design.ID)
+		if err != nil || !blocked {
+			t.Errorf("IsBlockedBy(release, design) = %v, %v; want true", blocked, err)
+		}
+		blockers, err := taskRepo.ListBlockers(ctx, release.ID)
+		if err != nil || len(blockers) != 1 || blockers[0].ID != build.ID {
+			t.Errorf("ListBlockers() = %v, %v; want build", blockers, err)
+		}
+		dependents, err := taskRepo.ListDependents(ctx, design.ID)
+		if err != nil || len(dependents) != 1 || dependents[0].ID != build.ID {
+			t.Errorf("ListDependents() = %v, %v; want build", dependents, err)
+		}
+		dependencies, err := taskRepo.ListDependencies(ctx, []string{design.ID, build.ID})
+		if err != nil || len(dependencies) != 1 || dependencies[0].BlockerID != design.ID {
+			t.Errorf("ListDependencies() = %v, %v; want only the link between the given tasks", dependencies, err)
+		}
+
+		if err := taskRepo.RemoveDependency(ctx, release.ID, build.ID); err != nil {
+			t.Errorf("Failed to remove dependency: %v", err)
+		}
+		if err := taskRepo.RemoveDependency(ctx, release.ID, build.ID); !domain.IsNotFoundError(err) {
+			t.Errorf("RemoveDependency() twice error = %v, want not found", err)
+		}
+	})
+}
+
+func TestTaskRepository_Participants(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn, assigneeID := setupTaskTestDB(t)
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	repo := NewTaskRepository(dbConn.DB)
+
+	pair := &domain.User{Name: "Pair", Email: fmt.Sprintf("pair-%d@example.com", time.Now().UnixNano()), Role: domain.UserRoleUser}
+	if err := NewUserRepository(dbConn.DB).Create(ctx, pair); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	task := &domain.Task{Title: "Pair work", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, AssigneeID: assigneeID,
+		Participants: []domain.TaskParticipant{{UserID: pair.ID, Role: domain.TaskRoleReviewer}}}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if len(stored.Participants) != 2 || !stored.HasRole(pair.ID, domain.TaskRoleReviewer) {
+		t.Errorf("participants = %+v, want the primary assignee and the reviewer", stored.Participants)
+	}
+
+	assignee := []domain.TaskParticipant{{UserID: pair.ID, Role: domain.TaskRoleAssignee}}
+	if err := repo.AddParticipants(ctx, task.ID, assignee, stored.Version+1); !domain.IsVersionConflictError(err) {
+		t.Errorf("AddParticipants() with a stale version error = %v, want version conflict", err)
+	}
+	if err := repo.AddParticipants(ctx, task.ID, assignee, stored.Version); err != nil {
+		t.Fatalf("AddParticipants() error = %v", err)
+	}
+
+	for _, tc := range []struct {
+		roles []domain.TaskParticipantRole
+		want  bool
+	}{
+		{nil, true},
+		{[]domain.TaskParticipantRole{domain.TaskRoleAssignee}, true},
+		{[]domain.TaskParticipantRole{domain.TaskRoleOwner}, false},
+	} {
+		tasks, _, err := repo.List(ctx, repository.TaskListOptions{AssigneeID: pair.ID, AssigneeRoles: tc.roles})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		found := false
+		for _, listed := range tasks {
+			found = found || listed.ID == task.ID
+		}
+		if found != tc.want {
+			t.Errorf("List(roles %v) found the task = %v, want %v", tc.roles, found, tc.want)
+		}
+	}
+
+	stored, _ = repo.GetByID(ctx, task.ID)
+	primary := []domain.TaskParticipant{{UserID: assigneeID, Role: domain.TaskRoleAssignee}}
+	if err := repo.RemoveParticipants(ctx, task.ID, primary, stored.Version); err != nil {
+		t.Fatalf("RemoveParticipants() error = %v", err)
+	}
+	stored, _ = repo.GetByID(ctx, task.ID)
+	if stored.AssigneeID != pair.ID || stored.HasRole(assigneeID, domain.TaskRoleAssignee) {
+		t.Errorf("primary assignee = %s, want the remaining assignee to take over", stored.AssigneeID)
+	}
+
+	// Reassigning through an update moves the primary assignee's role along
+	stored.AssigneeID = assigneeID
+	if err := repo.Update(ctx, stored); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	stored, _ = repo.GetByID(ctx, task.ID)
+	if !stored.HasRole(assigneeID, domain.TaskRoleAssignee) || len(stored.ParticipantsWithRole(domain.TaskRoleAssignee)) != 1 {
+		t.Errorf("assignees after reassigning = %v, want only the new primary", stored.ParticipantsWithRole(domain.TaskRoleAssignee))
+	}
+}
+
+func TestTaskRepository_Creator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn, assigneeID := setupTaskTestDB(t)
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	repo := NewTaskRepository(dbConn.DB)
+
+	reporter := &domain.User{Name: "Reporter", Email: fmt.Sprintf("reporter-%d@example.com", time.Now().UnixNano()), Role: domain.UserRoleUser}
+	if err := NewUserRepository(dbConn.DB).Create(ctx, reporter); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	reported := &domain.Task{Title: "Reported", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, AssigneeID: assigneeID, CreatorID: reporter.ID}
+	unknown := &domain.Task{Title: "Creator unknown", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, AssigneeID: assigneeID}
+	for _, task := range []*domain.Task{reported, unknown} {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	stored, err := repo.GetByID(ctx, reported.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if stored.CreatorID != reporter.ID {
+		t.Errorf("CreatorID = %q, want %q", stored.CreatorID, reporter.ID)
+	}
+	if stored, err := repo.GetByID(ctx, unknown.ID); err != nil || stored.CreatorID != "" {
+		t.Errorf("GetByID() of a task without a creator = %+v, %v, want an empty CreatorID", stored, err)
+	}
+
+	tasks, total, err := repo.List(ctx, repository.TaskListOptions{CreatorID: reporter.ID})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if total != 1 || len(tasks) != 1 || tasks[0].ID != reported.ID {
+		t.Errorf("List(creator) = %d tasks, want only %s", total, reported.ID)
+	}
 }
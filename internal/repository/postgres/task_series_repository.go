@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+const taskSeriesColumns = `
+		s.id, s.rule, s.starts_at, s.instance_count, s.is_active, s.creator_id,
+		s.created_at, s.updated_at, s.version`
+
+type taskSeriesRepository struct {
+	db *sql.DB
+}
+
+// NewTaskSeriesRepository creates a new recurring task series repository
+func NewTaskSeriesRepository(db *sql.DB) repository.TaskSeriesRepository {
+	return &taskSeriesRepository{db: db}
+}
+
+func (r *taskSeriesRepository) Create(ctx context.Context, series *domain.TaskSeries) error {
+	if series.ID == "" {
+		series.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	series.CreatedAt = now
+	series.UpdatedAt = now
+	series.Version = 1
+
+	if err := series.Rule.IsValid(); err != nil {
+		return fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+
+	query := `
+		INSERT INTO task_series (id, rule, starts_at, instance_count, is_active, creator_id, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		series.ID, series.Rule.String(), series.StartsAt, series.InstanceCount, series.IsActive,
+		series.CreatorID, series.CreatedAt, series.UpdatedAt, series.Version)
+	if err != nil {
+		return fmt.Errorf("failed to create task series: %w", err)
+	}
+
+	return nil
+}
+
+func (r *taskSeriesRepository) GetByID(ctx context.Context, id string) (*domain.TaskSeries, error) {
+	query := `SELECT ` + taskSeriesColumns + `
+		FROM task_series s
+		WHERE s.id = $1`
+
+	series, err := scanTaskSeries(executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("task series")
+		}
+		return nil, fmt.Errorf("failed to get task series: %w", err)
+	}
+
+	return series, nil
+}
+
+func (r *taskSeriesRepository) Update(ctx context.Context, series *domain.TaskSeries) error {
+	if err := series.Rule.IsValid(); err != nil {
+		return fmt.Errorf("invalid recurrence rule: %w", err)
+	}
+
+	query := `
+		UPDATE task_series
+		SET rule = $2, instance_count = $3, is_active = $4, updated_at = NOW()
+		WHERE id = $1 AND version = $5`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		series.ID, series.Rule.String(), series.InstanceCount, series.IsActive, series.Version)
+	if err != nil {
+		return fmt.Errorf("failed to update task series: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionConflict("task series", series.Version, series.Version+1)
+	}
+
+	// Update version in memory
+	series.Version++
+	series.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *taskSeriesRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.TaskSeries, error) {
+	query := `SELECT ` + taskSeriesColumns + `
+		FROM task_series s
+		JOIN tasks t ON t.series_id = s.id AND t.series_index = s.instance_count
+		WHERE s.is_active AND NOT t.is_deleted AND t.due_date <= $1
+		ORDER BY t.due_date, s.id
+		LIMIT $2`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due task series: %w", err)
+	}
+	defer rows.Close()
+
+	var due []*domain.TaskSeries
+	for rows.Next() {
+		series, err := scanTaskSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task series: %w", err)
+		}
+		due = append(due, series)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list due task series: %w", err)
+	}
+
+	return due, nil
+}
+
+func scanTaskSeries(row rowScanner) (*domain.TaskSeries, error) {
+	series := &domain.TaskSeries{}
+	var rule string
+
+	err := row.Scan(
+		&series.ID, &rule, &series.StartsAt, &series.InstanceCount, &series.IsActive, &series.CreatorID,
+		&series.CreatedAt, &series.UpdatedAt, &series.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := domain.ParseRecurrenceRule(rule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored recurrence rule %q: %w", rule, err)
+	}
+	series.Rule = *parsed
+
+	return series, nil
+}
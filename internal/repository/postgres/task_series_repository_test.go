@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func TestTaskSeriesRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn, ownerID := setupTagTestDB(t)
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	repo := NewTaskSeriesRepository(dbConn.DB)
+	taskRepo := NewTaskRepository(dbConn.DB)
+
+	due := time.Now().Add(-time.Hour).Truncate(time.Second)
+	rule, err := domain.ParseRecurrenceRule("FREQ=WEEKLY;BYDAY=MO,TH;COUNT=4")
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule() error = %v", err)
+	}
+	series := &domain.TaskSeries{Rule: *rule, StartsAt: due, InstanceCount: 1, IsActive: true, CreatorID: ownerID}
+
+	t.Run("Create and get", func(t *testing.T) {
+		if err := repo.Create(ctx, series); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		stored, err := repo.GetByID(ctx, series.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if stored.Rule.String() != rule.String() || !stored.StartsAt.Equal(due) || !stored.IsActive {
+			t.Errorf("stored series = %+v", stored)
+		}
+
+		if _, err := repo.GetByID(ctx, "00000000-0000-0000-0000-000000000000"); !domain.IsNotFoundError(err) {
+			t.Errorf("GetByID() error = %v, want not found", err)
+		}
+	})
+
+	task := &domain.Task{Title: "Recurring", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, AssigneeID: ownerID, DueDate: &due}
+	if err := taskRepo.Create(ctx, task); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	t.Run("Link and list due", func(t *testing.T) {
+		task.SeriesID, task.SeriesIndex = &series.ID, 1
+		if err := taskRepo.LinkToSeries(ctx, task); err != nil {
+			t.Fatalf("LinkToSeries() error = %v", err)
+		}
+
+		instances, err := taskRepo.ListSeriesInstances(ctx, series.ID)
+		if err != nil || len(instances) != 1 || instances[0].SeriesIndex != 1 {
+			t.Fatalf("ListSeriesInstances() = %v, %v", instances, err)
+		}
+
+		due, err := repo.ListDue(ctx, time.Now(), 100)
+		if err != nil {
+			t.Fatalf("ListDue() error = %v", err)
+		}
+		found := false
+		for _, s := range due {
+			found = found || s.ID == series.ID
+		}
+		if !found {
+			t.Errorf("ListDue() = %v, want series %s", due, series.ID)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		series.InstanceCount = 2
+		series.IsActive = false
+		if err := repo.Update(ctx, series); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		stale := *series
+		stale.Version--
+		if err := repo.Update(ctx, &stale); !domain.IsVersionConflictError(err) {
+			t.Errorf("Update() with a stale version error = %v, want version conflict", err)
+		}
+
+		stored, _ := repo.GetByID(ctx, series.ID)
+		if stored.InstanceCount != 2 || stored.IsActive || stored.Version != series.Version {
+			t.Errorf("stored series = %+v, want %+v", stored, series)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+// txContextKey carries the active transaction through the context so repository
+// methods called inside WithTransaction take part in it
+type txContextKey struct{}
+
+// executor is the subset of *sql.DB and *sql.Tx used by repositories
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// executorFromContext returns the transaction in ctx, or db when there is none
+func executorFromContext(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// scopedTx is a transaction owned by a single repository method. When the context
+// already carries a transaction it joins it, and Commit and Rollback are left to its owner.
+type scopedTx struct {
+	*sql.Tx
+	owned bool
+}
+
+// beginScopedTx starts a transaction unless ctx already carries one
+func beginScopedTx(ctx context.Context, db *sql.DB) (*scopedTx, error) {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return &scopedTx{Tx: tx}, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &scopedTx{Tx: tx, owned: true}, nil
+}
+
+func (t *scopedTx) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *scopedTx) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+type transactionManager struct {
+	db *sql.DB
+}
+
+// NewTransactionManager creates a transaction manager whose transactions are
+// picked up by repositories through the context
+func NewTransactionManager(db *sql.DB) repository.TransactionManager {
+	return &transactionManager{db: db}
+}
+
+func (m *transactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	// Nested calls join the outer transaction
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx, tx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx), tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
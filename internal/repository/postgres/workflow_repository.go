@@ -0,0 +1,317 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+const workflowColumns = `
+		w.id, w.name, COALESCE(w.description, ''), w.definition, w.is_default, w.creator_id,
+		w.created_at, w.updated_at, w.version, w.is_deleted, w.deleted_at`
+
+type workflowRepository struct {
+	db *sql.DB
+}
+
+// NewWorkflowRepository creates a new workflow repository
+func NewWorkflowRepository(db *sql.DB) repository.WorkflowRepository {
+	return &workflowRepository{db: db}
+}
+
+func (r *workflowRepository) Create(ctx context.Context, workflow *domain.Workflow) error {
+	if workflow.ID == "" {
+		workflow.ID = uuid.New().String()
+	}
+
+	now := time.Now()
+	workflow.CreatedAt = now
+	workflow.UpdatedAt = now
+	workflow.Version = 1
+
+	if err := workflow.IsValid(); err != nil {
+		return fmt.Errorf("invalid workflow: %w", err)
+	}
+
+	definition, err := json.Marshal(workflow.Definition())
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow definition: %w", err)
+	}
+
+	executor := executorFromContext(ctx, r.db)
+	if workflow.IsDefault {
+		if err := r.clearDefault(ctx, workflow.ID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO workflows (id, name, description, definition, is_default, creator_id, created_at, updated_at, version)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9)`
+
+	_, err = executor.ExecContext(ctx, query,
+		workflow.ID, workflow.Name, workflow.Description, definition, workflow.IsDefault,
+		workflow.CreatorID, workflow.CreatedAt, workflow.UpdatedAt, workflow.Version)
+	if err != nil {
+		return workflowWriteError("create", workflow.Name, err)
+	}
+
+	return nil
+}
+
+func (r *workflowRepository) GetByID(ctx context.Context, id string) (*domain.Workflow, error) {
+	query := `SELECT ` + workflowColumns + `
+		FROM workflows w
+		WHERE w.id = $1 AND w.is_deleted = false`
+
+	workflow, err := scanWorkflow(executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("workflow")
+		}
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	return workflow, nil
+}
+
+func (r *workflowRepository) GetDefault(ctx context.Context) (*domain.Workflow, error) {
+	query := `SELECT ` + workflowColumns + `
+		FROM workflows w
+		WHERE w.is_default AND w.is_deleted = false`
+
+	workflow, err := scanWorkflow(executorFromContext(ctx, r.db).QueryRowContext(ctx, query))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound("workflow")
+		}
+		return nil, fmt.Errorf("failed to get default workflow: %w", err)
+	}
+
+	return workflow, nil
+}
+
+func (r *workflowRepository) GetForCategories(ctx context.Context, categoryIDs []string) ([]*domain.Workflow, error) {
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+
+	// Each category walks up its parents until one has a workflow. The path guards
+	// against cycles left by data written before the cycle check existed.
+	query := `
+		WITH RECURSIVE chain AS (
+			SELECT c.id AS category_id, c.parent_id, c.workflow_id, 0 AS distance, ARRAY[c.id] AS path
+			FROM categories c
+			WHERE c.id::text = ANY($1)
+			UNION ALL
+			SELECT chain.category_id, p.parent_id, p.workflow_id, chain.distance + 1, chain.path || p.id
+			FROM chain
+			JOIN categories p ON p.id = chain.parent_id
+			WHERE chain.workflow_id IS NULL AND NOT p.id = ANY(chain.path)
+		),
+		nearest AS (
+			SELECT DISTINCT ON (category_id) workflow_id
+			FROM chain
+			WHERE workflow_id IS NOT NULL
+			ORDER BY category_id, distance
+		)
+		SELECT ` + workflowColumns + `
+		FROM workflows w
+		WHERE w.id IN (SELECT workflow_id FROM nearest) AND w.is_deleted = false
+		ORDER BY lower(w.name), w.id`
+
+	rows, err := executorFromContext(ctx, r.db).QueryContext(ctx, query, pq.Array(categoryIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category workflows: %w", err)
+	}
+	defer rows.Close()
+
+	var workflows []*domain.Workflow
+	for rows.Next() {
+		workflow, err := scanWorkflow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workflow: %w", err)
+		}
+		workflows = append(workflows, workflow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get category workflows: %w", err)
+	}
+
+	return workflows, nil
+}
+
+func (r *workflowRepository) List(ctx context.Context, opts repository.ListOptions) ([]*domain.Workflow, int64, error) {
+	whereClause := `WHERE w.is_deleted = false`
+	var args []interface{}
+	if opts.SearchQuery != "" {
+		whereClause += ` AND w.name ILIKE $1`
+		args = append(args, "%"+escapeLike(opts.SearchQuery)+"%")
+	}
+
+	executor := executorFromContext(ctx, r.db)
+
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM workflows w " + whereClause
+	if err := executor.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count workflows: %w", err)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	offset := opts.Page * pageSize
+
+	query := fmt.Sprintf(`SELECT %s
+		FROM workflows w
+		%s
+		ORDER BY lower(w.name), w.id
+		LIMIT $%d OFFSET $%d`, workflowColumns, whereClause, len(args)+1, len(args)+2)
+	args = append(args, pageSize, offset)
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list workflows: %w", err)
+	}
+	defer rows.Close()
+
+	var workflows []*domain.Workflow
+	for rows.Next() {
+		workflow, err := scanWorkflow(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan workflow: %w", err)
+		}
+		workflows = append(workflows, workflow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	return workflows, total, nil
+}
+
+func (r *workflowRepository) Update(ctx context.Context, workflow *domain.Workflow) error {
+	if err := workflow.IsValid(); err != nil {
+		return fmt.Errorf("invalid workflow: %w", err)
+	}
+
+	definition, err := json.Marshal(workflow.Definition())
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow definition: %w", err)
+	}
+
+	if workflow.IsDefault {
+		if err := r.clearDefault(ctx, workflow.ID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		UPDATE workflows
+		SET name = $2, description = NULLIF($3, ''), definition = $4, is_default = $5, updated_at = NOW()
+		WHERE id = $1 AND version = $6 AND is_deleted = false`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query,
+		workflow.ID, workflow.Name, workflow.Description, definition, workflow.IsDefault, workflow.Version)
+	if err != nil {
+		return workflowWriteError("update", workflow.Name, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionConflict("workflow", workflow.Version, workflow.Version+1)
+	}
+
+	// Update version in memory
+	workflow.Version++
+	workflow.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (r *workflowRepository) SoftDelete(ctx context.Context, id string, version int64) error {
+	query := `
+		UPDATE workflows
+		SET is_deleted = true, is_default = false, deleted_at = NOW()
+		WHERE id = $1 AND version = $2 AND is_deleted = false`
+
+	result, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, id, version)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete workflow: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionConflict("workflow", version, version+1)
+	}
+
+	return nil
+}
+
+func (r *workflowRepository) CountCategories(ctx context.Context, id string) (int64, error) {
+	query := `SELECT COUNT(*) FROM categories WHERE workflow_id = $1 AND is_deleted = false`
+
+	var count int64
+	if err := executorFromContext(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count workflow categories: %w", err)
+	}
+	return count, nil
+}
+
+// clearDefault unmarks the current default workflow so another one can take its place
+func (r *workflowRepository) clearDefault(ctx context.Context, exceptID string) error {
+	query := `UPDATE workflows SET is_default = false WHERE is_default AND id <> $1`
+	if _, err := executorFromContext(ctx, r.db).ExecContext(ctx, query, exceptID); err != nil {
+		return fmt.Errorf("failed to clear default workflow: %w", err)
+	}
+	return nil
+}
+
+func scanWorkflow(row rowScanner) (*domain.Workflow, error) {
+	workflow := &domain.Workflow{}
+	var definition []byte
+
+	err := row.Scan(
+		&workflow.ID, &workflow.Name, &workflow.Description, &definition, &workflow.IsDefault,
+		&workflow.CreatorID, &workflow.CreatedAt, &workflow.UpdatedAt, &workflow.Version,
+		&workflow.IsDeleted, &workflow.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded domain.WorkflowDefinition
+	if err := json.Unmarshal(definition, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow definition: %w", err)
+	}
+	workflow.States = decoded.States
+	workflow.Transitions = decoded.Transitions
+
+	return workflow, nil
+}
+
+// workflowWriteError reports a duplicate workflow name as a conflict
+func workflowWriteError(action, name string, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return domain.ErrConflict(fmt.Sprintf("a workflow named %q already exists", name))
+	}
+	return fmt.Errorf("failed to %s workflow: %w", action, err)
+}
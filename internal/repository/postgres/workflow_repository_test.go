@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func TestWorkflowRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	dbConn, ownerID := setupTagTestDB(t)
+	defer dbConn.Close()
+
+	ctx := context.Background()
+	repo := NewWorkflowRepository(dbConn.DB)
+	categoryRepo := NewCategoryRepository(dbConn.DB)
+	suffix := time.Now().UnixNano()
+
+	workflow := &domain.Workflow{
+		Name: fmt.Sprintf("review-%d", suffix),
+		States: []domain.WorkflowState{
+			{Status: domain.TaskStatusOpen},
+			{Status: domain.TaskStatusInReview, RequiredFields: []string{domain.WorkflowFieldDescription}},
+		},
+		Transitions: []domain.WorkflowTransition{
+			{From: domain.TaskStatusOpen, To: domain.TaskStatusInReview, Guards: []domain.WorkflowGuard{domain.WorkflowGuardHasAssignee}},
+		},
+		CreatorID: ownerID,
+	}
+
+	t.Run("Create and get", func(t *testing.T) {
+		if err := repo.Create(ctx, workflow); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		stored, err := repo.GetByID(ctx, workflow.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if len(stored.States) != 2 || stored.States[1].RequiredFields[0] != domain.WorkflowFieldDescription {
+			t.Errorf("stored states = %+v", stored.States)
+		}
+		if len(stored.Transitions) != 1 || stored.Transitions[0].Guards[0] != domain.WorkflowGuardHasAssignee {
+			t.Errorf("stored transitions = %+v", stored.Transitions)
+		}
+	})
+
+	t.Run("Duplicate name", func(t *testing.T) {
+		duplicate := &domain.Workflow{Name: workflow.Name, States: workflow.States, CreatorID: ownerID}
+		if err := repo.Create(ctx, duplicate); !domain.IsConflictError(err) {
+			t.Errorf("Create() error = %v, want conflict", err)
+		}
+	})
+
+	parent := &domain.Category{Name: fmt.Sprintf("workflow-parent-%d", suffix), Color: "#3366FF", CreatorID: ownerID, WorkflowID: &workflow.ID}
+	if err := categoryRepo.Create(ctx, parent); err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+	child := &domain.Category{Name: fmt.Sprintf("workflow-child-%d", suffix), Color: "#3366FF", CreatorID: ownerID, ParentID: &parent.ID}
+	if err := categoryRepo.Create(ctx, child); err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+
+	t.Run("Category workflows", func(t *testing.T) {
+		workflows, err := repo.GetForCategories(ctx, []string{parent.ID, child.ID})
+		if err != nil {
+			t.Fatalf("GetForCategories() error = %v", err)
+		}
+		if len(workflows) != 1 || workflows[0].ID != workflow.ID {
+			t.Errorf("GetForCategories() = %v, want only %s", workflows, workflow.ID)
+		}
+
+		count, err := repo.CountCategories(ctx, workflow.ID)
+		if err != nil {
+			t.Fatalf("CountCategories() error = %v", err)
+		}
+		if count != 1 {
+			t.Errorf("CountCategories() = %d, want 1", count)
+		}
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		workflow.Description = "Adds a review step"
+		if err := repo.Update(ctx, workflow); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		parent.WorkflowID = nil
+		if err := categoryRepo.Update(ctx, parent); err != nil {
+			t.Fatalf("Failed to update category: %v", err)
+		}
+		if err := repo.SoftDelete(ctx, workflow.ID, workflow.Version); err != nil {
+			t.Fatalf("SoftDelete() error = %v", err)
+		}
+		if _, err := repo.GetByID(ctx, workflow.ID); !domain.IsNotFoundError(err) {
+			t.Errorf("GetByID() after delete error = %v, want not found", err)
+		}
+	})
+}
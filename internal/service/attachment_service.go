@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+// blobDeletionBatchSize bounds the purged blobs deleted per round of garbage collection
+const blobDeletionBatchSize = 100
+
+type attachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+	taskRepo       repository.TaskRepository
+	store          repository.BlobStore
+	txManager      repository.TransactionManager
+	limits         domain.AttachmentLimits
+	logger         logger.Logger
+}
+
+// NewAttachmentService creates a new task attachment service
+func NewAttachmentService(
+	attachmentRepo repository.AttachmentRepository,
+	taskRepo repository.TaskRepository,
+	store repository.BlobStore,
+	txManager repository.TransactionManager,
+	limits domain.AttachmentLimits,
+	log logger.Logger,
+) AttachmentService {
+	return &attachmentService{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		store:          store,
+		txManager:      txManager,
+		limits:         limits,
+		logger:         log,
+	}
+}
+
+func (s *attachmentService) UploadAttachment(ctx context.Context, upload domain.AttachmentUpload, content io.Reader) (*domain.Attachment, error) {
+	s.logger.Info(ctx, "Uploading attachment", "task_id", upload.TaskID, "file_name", upload.FileName, "size", upload.SizeBytes)
+
+	uploaderID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if upload.TaskID == "" {
+		return nil, domain.ErrInvalidInput("task ID is required")
+	}
+	if err := domain.ValidateAttachmentFileName(upload.FileName); err != nil {
+		return nil, err
+	}
+	contentType, err := domain.NormalizeContentType(upload.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	expectedChecksum := strings.ToLower(upload.ChecksumSHA256)
+	if expectedChecksum != "" {
+		if _, err := hex.DecodeString(expectedChecksum); err != nil || len(expectedChecksum) != 64 {
+			return nil, domain.ErrInvalidInput("checksum must be a hex encoded SHA-256")
+		}
+	}
+	if _, err := s.taskRepo.GetByID(ctx, upload.TaskID); err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	// Uploads that cannot fit are turned away before their content is read; the check is
+	// repeated under the uploader's lock once the content is stored
+	if err := s.checkLimits(ctx, uploaderID, contentType, upload.SizeBytes); err != nil {
+		return nil, err
+	}
+
+	attachment := &domain.Attachment{
+		ID:          uuid.New().String(),
+		TaskID:      upload.TaskID,
+		UploaderID:  uploaderID,
+		FileName:    upload.FileName,
+		ContentType: contentType,
+		SizeBytes:   upload.SizeBytes,
+	}
+	attachment.StorageKey = domain.AttachmentStorageKey(attachment.TaskID, attachment.ID)
+
+	// The content is hashed while it streams into the store
+	reader := newUploadReader(content, upload.SizeBytes)
+	if err := s.store.Put(ctx, attachment.StorageKey, reader, upload.SizeBytes); err != nil {
+		if reader.err != nil {
+			return nil, reader.err
+		}
+		var domainErr domain.DomainError
+		if errors.As(err, &domainErr) {
+			return nil, domainErr
+		}
+		s.logger.Error(ctx, "Failed to store attachment content", "error", err, "task_id", upload.TaskID)
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	attachment.ChecksumSHA256 = hex.EncodeToString(reader.hash.Sum(nil))
+
+	if expectedChecksum != "" && expectedChecksum != attachment.ChecksumSHA256 {
+		s.deleteBlob(ctx, attachment.StorageKey)
+		return nil, domain.ErrInvalidInput("attachment content does not match its checksum")
+	}
+
+	// Concurrent uploads by the same user each passed the check above; holding the lock from
+	// the final check to the insert keeps them within the quota together
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.attachmentRepo.LockUploader(txCtx, uploaderID); err != nil {
+			return err
+		}
+		if err := s.checkLimits(txCtx, uploaderID, contentType, upload.SizeBytes); err != nil {
+			return err
+		}
+		return s.attachmentRepo.Create(txCtx, attachment)
+	})
+	if err != nil {
+		// Without metadata nothing refers to the blob any more
+		s.deleteBlob(ctx, attachment.StorageKey)
+		if domain.IsBusinessRuleError(err) {
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to create attachment", "error", err, "task_id", upload.TaskID)
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	s.logger.Info(ctx, "Attachment uploaded successfully", "attachment_id", attachment.ID, "size", attachment.SizeBytes)
+	return attachment, nil
+}
+
+func (s *attachmentService) OpenAttachment(ctx context.Context, id string) (*domain.Attachment, io.ReadCloser, error) {
+	s.logger.Info(ctx, "Opening attachment", "attachment_id", id)
+
+	attachment, err := s.getAttachment(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			s.logger.Error(ctx, "Attachment content is missing", "attachment_id", id, "storage_key", attachment.StorageKey)
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+
+	return attachment, content, nil
+}
+
+func (s *attachmentService) ListAttachments(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	s.logger.Debug(ctx, "Listing attachments", "task_id", taskID)
+
+	if taskID == "" {
+		return nil, domain.ErrInvalidInput("task ID is required")
+	}
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	attachments, err := s.attachmentRepo.ListByTask(ctx, taskID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list attachments", "error", err, "task_id", taskID)
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (s *attachmentService) DeleteAttachment(ctx context.Context, id string, version int64) error {
+	s.logger.Info(ctx, "Deleting attachment", "attachment_id", id, "version", version)
+
+	callerID, role, err := authenticatedCaller(ctx)
+	if err != nil {
+		return err
+	}
+	attachment, err := s.getAttachment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attachment.UploaderID != callerID && role != domain.UserRoleAdmin {
+		return domain.ErrPermissionDenied("only the uploader or an admin can delete an attachment")
+	}
+
+	if err := s.attachmentRepo.SoftDelete(ctx, id, version); err != nil {
+		if domain.IsVersionConflictError(err) {
+			return err
+		}
+		s.logger.Error(ctx, "Failed to delete attachment", "error", err, "attachment_id", id)
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	// The metadata is kept like other soft deleted records, but the content is not: a deleted
+	// attachment no longer counts against the quota, so its blob must not linger either.
+	// A blob that fails to delete here is queued again when its task is purged.
+	s.deleteBlob(ctx, attachment.StorageKey)
+
+	s.logger.Info(ctx, "Attachment deleted successfully", "attachment_id", id)
+	return nil
+}
+
+func (s *attachmentService) GetUsage(ctx context.Context) (*domain.AttachmentUsage, error) {
+	callerID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.attachmentRepo.UsageByUploader(ctx, callerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment usage: %w", err)
+	}
+
+	return &domain.AttachmentUsage{UsedBytes: used, QuotaBytes: s.limits.UserQuotaBytes}, nil
+}
+
+func (s *attachmentService) CollectGarbage(ctx context.Context) (int, error) {
+	deleted := 0
+	for {
+		keys, err := s.attachmentRepo.PendingBlobDeletions(ctx, blobDeletionBatchSize)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list pending blob deletions: %w", err)
+		}
+
+		var cleared []string
+		for _, key := range keys {
+			if err := s.store.Delete(ctx, key); err != nil {
+				// The key stays queued for the next run
+				s.logger.Warn(ctx, "Failed to delete purged attachment content", "error", err, "storage_key", key)
+				continue
+			}
+			cleared = append(cleared, key)
+		}
+		if err := s.attachmentRepo.ClearBlobDeletions(ctx, cleared); err != nil {
+			return deleted, fmt.Errorf("failed to clear blob deletions: %w", err)
+		}
+		deleted += len(cleared)
+
+		// Stop at the end of the queue, or when failures would hand back the same keys again
+		if len(keys) < blobDeletionBatchSize || len(cleared) < len(keys) {
+			return deleted, nil
+		}
+	}
+}
+
+func (s *attachmentService) getAttachment(ctx context.Context, id string) (*domain.Attachment, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput("attachment ID is required")
+	}
+	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// deleteBlob removes content no metadata refers to; failures are only logged
+func (s *attachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		s.logger.Warn(ctx, "Failed to delete attachment content", "error", err, "storage_key", key)
+	}
+}
+
+// checkLimits checks an upload against the attachment limits and the uploader's remaining quota
+func (s *attachmentService) checkLimits(ctx context.Context, uploaderID, contentType string, sizeBytes int64) error {
+	used, err := s.attachmentRepo.UsageByUploader(ctx, uploaderID)
+	if err != nil {
+		return fmt.Errorf("failed to get attachment usage: %w", err)
+	}
+	return s.limits.Check(contentType, sizeBytes, used)
+}
+
+// uploadReader hashes an upload as it is read and fails once the content turns out
+// shorter or longer than the size declared up front
+type uploadReader struct {
+	r         io.Reader
+	hash      hash.Hash
+	remaining int64
+	size      int64
+	err       error
+}
+
+func newUploadReader(r io.Reader, size int64) *uploadReader {
+	return &uploadReader{r: r, hash: sha256.New(), remaining: size, size: size}
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	if u.err != nil {
+		return 0, u.err
+	}
+	if u.remaining <= 0 {
+		var probe [1]byte
+		n, err := io.ReadFull(u.r, probe[:])
+		if n > 0 {
+			u.err = domain.ErrInvalidInput(fmt.Sprintf("attachment content is larger than the declared %d bytes", u.size))
+			return 0, u.err
+		}
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > u.remaining {
+		p = p[:u.remaining]
+	}
+	n, err := u.r.Read(p)
+	u.hash.Write(p[:n])
+	u.remaining -= int64(n)
+	if err == io.EOF {
+		if u.remaining > 0 {
+			u.err = domain.ErrInvalidInput(fmt.Sprintf("attachment content is smaller than the declared %d bytes", u.size))
+			return n, u.err
+		}
+		err = nil
+	}
+	return n, err
+}
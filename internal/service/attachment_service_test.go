@@ -0,0 +1,356 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/testutil"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+type mockAttachmentRepository struct {
+	attachments map[string]*domain.Attachment
+	// pending holds the storage keys queued for deletion by task purges
+	pending   []string
+	createErr error
+	// onLock runs when an upload takes the uploader's lock, standing in for an upload that
+	// finished just before
+	onLock func()
+	locks  int
+}
+
+func newMockAttachmentRepository() *mockAttachmentRepository {
+	return &mockAttachmentRepository{attachments: make(map[string]*domain.Attachment)}
+}
+
+func (m *mockAttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	attachment.Version = 1
+	stored := *attachment
+	m.attachments[attachment.ID] = &stored
+	return nil
+}
+
+func (m *mockAttachmentRepository) GetByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	stored, ok := m.attachments[id]
+	if !ok || stored.IsDeleted {
+		return nil, domain.ErrNotFound("attachment")
+	}
+	attachment := *stored
+	return &attachment, nil
+}
+
+func (m *mockAttachmentRepository) ListByTask(ctx context.Context, taskID string) ([]*domain.Attachment, error) {
+	var attachments []*domain.Attachment
+	for _, attachment := range m.attachments {
+		if attachment.TaskID == taskID && !attachment.IsDeleted {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, nil
+}
+
+func (m *mockAttachmentRepository) SoftDelete(ctx context.Context, id string, version int64) error {
+	stored, ok := m.attachments[id]
+	if !ok || stored.IsDeleted || stored.Version != version {
+		return domain.ErrVersionConflict("attachment", version, version+1)
+	}
+	stored.IsDeleted = true
+	stored.Version++
+	return nil
+}
+
+func (m *mockAttachmentRepository) UsageByUploader(ctx context.Context, uploaderID string) (int64, error) {
+	var used int64
+	for _, attachment := range m.attachments {
+		if attachment.UploaderID == uploaderID && !attachment.IsDeleted {
+			used += attachment.SizeBytes
+		}
+	}
+	return used, nil
+}
+
+func (m *mockAttachmentRepository) LockUploader(ctx context.Context, uploaderID string) error {
+	m.locks++
+	if m.onLock != nil {
+		m.onLock()
+	}
+	return nil
+}
+
+func (m *mockAttachmentRepository) PendingBlobDeletions(ctx context.Context, limit int) ([]string, error) {
+	if len(m.pending) > limit {
+		return append([]string(nil), m.pending[:limit]...), nil
+	}
+	return append([]string(nil), m.pending...), nil
+}
+
+func (m *mockAttachmentRepository) ClearBlobDeletions(ctx context.Context, keys []string) error {
+	cleared := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		cleared[key] = true
+	}
+	var remaining []string
+	for _, key := range m.pending {
+		if !cleared[key] {
+			remaining = append(remaining, key)
+		}
+	}
+	m.pending = remaining
+	return nil
+}
+
+// memoryBlobStore keeps blobs in memory; Delete fails for keys in failDelete
+type memoryBlobStore struct {
+	blobs      map[string][]byte
+	failDelete map[string]bool
+}
+
+func newMemoryBlobStore() *memoryBlobStore {
+	return &memoryBlobStore{blobs: make(map[string][]byte), failDelete: make(map[string]bool)}
+}
+
+func (m *memoryBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to write blob %s: %w", key, err)
+	}
+	if int64(len(data)) != size {
+		return errors.New("size mismatch")
+	}
+	m.blobs[key] = data
+	return nil
+}
+
+func (m *memoryBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := m.blobs[key]
+	if !ok {
+		return nil, domain.ErrNotFound("attachment content")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memoryBlobStore) Delete(ctx context.Context, key string) error {
+	if m.failDelete[key] {
+		return errors.New("store unavailable")
+	}
+	delete(m.blobs, key)
+	return nil
+}
+
+type attachmentTestEnv struct {
+	service     AttachmentService
+	attachments *mockAttachmentRepository
+	store       *memoryBlobStore
+	uploader    *domain.User
+	other       *domain.User
+	admin       *domain.User
+	task        *domain.Task
+}
+
+func setupAttachmentTest(t *testing.T, limits domain.AttachmentLimits) *attachmentTestEnv {
+	t.Helper()
+	tasks := newMockTaskRepository()
+	env := &attachmentTestEnv{attachments: newMockAttachmentRepository(), store: newMemoryBlobStore()}
+	env.service = NewAttachmentService(env.attachments, tasks, env.store, &mockTransactionManager{}, limits, logger.NewLogger("error"))
+
+	env.uploader, env.other, env.admin = testutil.TestUser(), testutil.TestUser(), testutil.TestAdminUser()
+	env.task = testutil.TestTask(env.uploader.ID)
+	tasks.Create(context.Background(), env.task)
+	return env
+}
+
+func (env *attachmentTestEnv) upload(ctx context.Context, name, contentType, content string) (*domain.Attachment, error) {
+	return env.service.UploadAttachment(ctx, domain.AttachmentUpload{
+		TaskID:      env.task.ID,
+		FileName:    name,
+		ContentType: contentType,
+		SizeBytes:   int64(len(content)),
+	}, strings.NewReader(content))
+}
+
+func TestAttachmentService_UploadAttachment(t *testing.T) {
+	limits := domain.AttachmentLimits{MaxSizeBytes: 10, AllowedTypes: []string{"image/*", "text/plain"}, UserQuotaBytes: 15}
+	env := setupAttachmentTest(t, limits)
+	ctx := asUser(env.uploader)
+
+	t.Run("stores content and metadata", func(t *testing.T) {
+		attachment, err := env.upload(ctx, "notes.txt", "Text/Plain; charset=utf-8", "hello")
+		if err != nil {
+			t.Fatalf("UploadAttachment() error = %v", err)
+		}
+		sum := sha256.Sum256([]byte("hello"))
+		if attachment.UploaderID != env.uploader.ID || attachment.ContentType != "text/plain" ||
+			attachment.SizeBytes != 5 || attachment.ChecksumSHA256 != hex.EncodeToString(sum[:]) {
+			t.Errorf("attachment = %+v", attachment)
+		}
+		if got := string(env.store.blobs[attachment.StorageKey]); got != "hello" {
+			t.Errorf("stored content = %q, want %q", got, "hello")
+		}
+	})
+
+	t.Run("matching checksum", func(t *testing.T) {
+		sum := sha256.Sum256([]byte("img"))
+		_, err := env.service.UploadAttachment(ctx, domain.AttachmentUpload{
+			TaskID: env.task.ID, FileName: "a.png", ContentType: "image/png", SizeBytes: 3,
+			ChecksumSHA256: strings.ToUpper(hex.EncodeToString(sum[:])),
+		}, strings.NewReader("img"))
+		if err != nil {
+			t.Errorf("UploadAttachment() error = %v", err)
+		}
+	})
+
+	// 8 of 15 quota bytes are used from here on
+	blobs := len(env.store.blobs)
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		upload  domain.AttachmentUpload
+		content string
+		checkFn func(error) bool
+	}{
+		{"unauthenticated", context.Background(), domain.AttachmentUpload{TaskID: env.task.ID, FileName: "a.txt", ContentType: "text/plain", SizeBytes: 1}, "a", isUnauthorizedError},
+		{"missing task", ctx, domain.AttachmentUpload{TaskID: "missing", FileName: "a.txt", ContentType: "text/plain", SizeBytes: 1}, "a", domain.IsNotFoundError},
+		{"path in file name", ctx, domain.AttachmentUpload{TaskID: env.task.ID, FileName: "../a.txt", ContentType: "text/plain", SizeBytes: 1}, "a", domain.IsInvalidInputError},
+		{"type not allowed", ctx, domain.AttachmentUpload{TaskID: env.task.ID, FileName: "a.exe", ContentType: "application/x-msdownload", SizeBytes: 1}, "a", domain.IsInvalidInputError},
+		{"too large", ctx, domain.AttachmentUpload{TaskID: env.task.ID, FileName: "a.txt", ContentType: "text/plain", SizeBytes: 11}, strings.Repeat("a", 11), domain.IsInvalidInputError},
+		{"quota exceeded", ctx, domain.AttachmentUpload{TaskID: env.task.ID, FileName: "a.txt", ContentType: "text/plain", SizeBytes: 8}, strings.Repeat("a", 8), domain.IsBusinessRuleError},
+		{"content shorter than declared", ctx, domain.AttachmentUpload{TaskID: env.task.ID, FileName: "a.txt", ContentType: "text/plain", SizeBytes: 4}, "abc", domain.IsInvalidInputError},
+		{"content longer than declared", ctx, domain.AttachmentUpload{TaskID: env.task.ID, FileName: "a.txt", ContentType: "text/plain", SizeBytes: 2}, "abc", domain.IsInvalidInputError},
+		{"checksum mismatch", ctx, domain.AttachmentUpload{TaskID: env.task.ID, FileName: "a.txt", ContentType: "text/plain", SizeBytes: 1, ChecksumSHA256: strings.Repeat("0", 64)}, "a", domain.IsInvalidInputError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.service.UploadAttachment(tt.ctx, tt.upload, strings.NewReader(tt.content)); !tt.checkFn(err) {
+				t.Errorf("UploadAttachment() error = %v", err)
+			}
+		})
+	}
+	if len(env.store.blobs) != blobs {
+		t.Errorf("rejected uploads left %d blobs behind", len(env.store.blobs)-blobs)
+	}
+
+	t.Run("failed metadata removes the content", func(t *testing.T) {
+		env.attachments.createErr = errors.New("database unavailable")
+		defer func() { env.attachments.createErr = nil }()
+
+		if _, err := env.upload(ctx, "b.txt", "text/plain", "b"); err == nil {
+			t.Fatal("UploadAttachment() expected error")
+		}
+		if len(env.store.blobs) != blobs {
+			t.Errorf("blob left behind after failed metadata write")
+		}
+	})
+	t.Run("concurrent upload uses up the quota", func(t *testing.T) {
+		// Another 5 byte upload finishes while this one streams, leaving 2 of 15 bytes
+		env.attachments.onLock = func() {
+			env.attachments.onLock = nil
+			env.attachments.Create(ctx, &domain.Attachment{ID: "concurrent", TaskID: env.task.ID, UploaderID: env.uploader.ID, SizeBytes: 5})
+		}
+		if _, err := env.upload(ctx, "c.txt", "text/plain", "ccc"); !domain.IsBusinessRuleError(err) {
+			t.Errorf("UploadAttachment() error = %v, want the quota exceeded", err)
+		}
+		if env.attachments.locks == 0 {
+			t.Error("UploadAttachment() did not lock the uploader")
+		}
+		if len(env.store.blobs) != blobs {
+			t.Errorf("blob left behind after the quota ran out")
+		}
+	})
+}
+
+func TestAttachmentService_OpenAttachment(t *testing.T) {
+	env := setupAttachmentTest(t, domain.AttachmentLimits{})
+	attachment, err := env.upload(asUser(env.uploader), "report.pdf", "application/pdf", "%PDF-1.7")
+	if err != nil {
+		t.Fatalf("UploadAttachment() error = %v", err)
+	}
+
+	opened, content, err := env.service.OpenAttachment(asUser(env.other), attachment.ID)
+	if err != nil {
+		t.Fatalf("OpenAttachment() error = %v", err)
+	}
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	if opened.FileName != "report.pdf" || string(data) != "%PDF-1.7" {
+		t.Errorf("OpenAttachment() = %+v with %q", opened, data)
+	}
+
+	if _, _, err := env.service.OpenAttachment(asUser(env.other), "missing"); !domain.IsNotFoundError(err) {
+		t.Errorf("OpenAttachment() of missing attachment error = %v, want not found", err)
+	}
+}
+
+func TestAttachmentService_DeleteAttachment(t *testing.T) {
+	env := setupAttachmentTest(t, domain.AttachmentLimits{UserQuotaBytes: 100})
+	first, _ := env.upload(asUser(env.uploader), "a.txt", "text/plain", "first")
+	second, _ := env.upload(asUser(env.uploader), "b.txt", "text/plain", "second")
+
+	if err := env.service.DeleteAttachment(asUser(env.other), first.ID, first.Version); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("DeleteAttachment() by another user error = %v, want permission denied", err)
+	}
+	if err := env.service.DeleteAttachment(asUser(env.uploader), first.ID, first.Version+1); !domain.IsVersionConflictError(err) {
+		t.Errorf("DeleteAttachment() with a stale version error = %v, want version conflict", err)
+	}
+
+	if err := env.service.DeleteAttachment(asUser(env.uploader), first.ID, first.Version); err != nil {
+		t.Fatalf("DeleteAttachment() by the uploader error = %v", err)
+	}
+	if err := env.service.DeleteAttachment(asUser(env.admin), second.ID, second.Version); err != nil {
+		t.Fatalf("DeleteAttachment() by an admin error = %v", err)
+	}
+	if len(env.store.blobs) != 0 {
+		t.Errorf("%d blobs left after deleting every attachment", len(env.store.blobs))
+	}
+
+	attachments, _ := env.service.ListAttachments(asUser(env.uploader), env.task.ID)
+	usage, err := env.service.GetUsage(asUser(env.uploader))
+	if err != nil {
+		t.Fatalf("GetUsage() error = %v", err)
+	}
+	if len(attachments) != 0 || usage.UsedBytes != 0 || usage.QuotaBytes != 100 {
+		t.Errorf("after delete: %d attachments, usage %+v", len(attachments), usage)
+	}
+}
+
+func TestAttachmentService_CollectGarbage(t *testing.T) {
+	env := setupAttachmentTest(t, domain.AttachmentLimits{})
+	ctx := context.Background()
+
+	for i := 0; i < blobDeletionBatchSize+5; i++ {
+		key := fmt.Sprintf("tasks/purged/%d", i)
+		env.store.blobs[key] = []byte("x")
+		env.attachments.pending = append(env.attachments.pending, key)
+	}
+	// Already gone from the store, e.g. deleted with its attachment
+	env.attachments.pending = append(env.attachments.pending, "tasks/purged/gone")
+	env.store.failDelete["tasks/purged/3"] = true
+
+	deleted, err := env.service.CollectGarbage(ctx)
+	if err != nil {
+		t.Fatalf("CollectGarbage() error = %v", err)
+	}
+	// The failure stops the run after the first batch
+	if deleted != blobDeletionBatchSize-1 {
+		t.Errorf("CollectGarbage() = %d, want %d", deleted, blobDeletionBatchSize-1)
+	}
+
+	delete(env.store.failDelete, "tasks/purged/3")
+	deleted, err = env.service.CollectGarbage(ctx)
+	if err != nil {
+		t.Fatalf("CollectGarbage() error = %v", err)
+	}
+	if deleted != 7 || len(env.store.blobs) != 0 || len(env.attachments.pending) != 0 {
+		t.Errorf("second run deleted %d, left %d blobs and %d queued keys", deleted, len(env.store.blobs), len(env.attachments.pending))
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// callerIdentity returns the caller's user and role; both are empty for anonymous callers
+func callerIdentity(ctx context.Context) (string, domain.UserRole) {
+	return requestctx.UserID(ctx), domain.UserRole(requestctx.UserRole(ctx))
+}
+
+// authenticatedCaller returns the authenticated user and their role, for operations that
+// must be attributed to a person
+func authenticatedCaller(ctx context.Context) (string, domain.UserRole, error) {
+	userID, role := callerIdentity(ctx)
+	if userID == "" {
+		return "", "", domain.ErrUnauthorized("an authenticated user is required")
+	}
+	return userID, role, nil
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+// maxCategoryCascadeDepth bounds subtree walks for cascading operations. It is far above any
+// configured maximum depth so trees created under a larger limit are still covered.
+const maxCategoryCascadeDepth = 1000
+
+func (s *categoryService) MoveCategory(ctx context.Context, id, parentID string, version int64) (*domain.Category, error) {
+	s.logger.Info(ctx, "Moving category", "category_id", id, "parent_id", parentID, "version", version)
+
+	category, err := s.visibleCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if category.Version != version {
+		return nil, domain.ErrVersionConflict("category", version, category.Version)
+	}
+
+	oldParentID := category.ParentID
+	moved := *category
+	moved.ParentID = nil
+	if parentID != "" {
+		moved.ParentID = &parentID
+	}
+
+	// The new parent's ancestors are checked under the hierarchy lock, so a concurrent move
+	// cannot slip the category in among them before this one commits
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.categoryRepo.LockHierarchy(txCtx); err != nil {
+			return err
+		}
+		if err := s.validateCategoryParent(txCtx, &moved); err != nil {
+			return err
+		}
+		if err := s.categoryRepo.Update(txCtx, &moved); err != nil {
+			return err
+		}
+		return recordCategoryHistory(txCtx, s.categoryRepo, id, domain.CategoryHistoryActionMoved, &domain.TaskHistoryDetails{
+			OldValues: map[string]interface{}{"parent_id": oldParentID},
+			NewValues: map[string]interface{}{"parent_id": moved.ParentID},
+			Changes:   []string{"parent_id"},
+		})
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) || domain.IsBusinessRuleError(err) || domain.IsInvalidInputError(err) {
+			s.logger.Warn(ctx, "Category move rejected", "category_id", id, "error", err)
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to move category", "error", err, "category_id", id)
+		return nil, fmt.Errorf("failed to move category: %w", err)
+	}
+
+	s.logger.Info(ctx, "Category moved successfully", "category_id", id, "new_version", moved.Version)
+	return &moved, nil
+}
+
+func (s *categoryService) MergeCategories(ctx context.Context, sourceID string, sourceVersion int64, targetID string, targetVersion int64) (*domain.Category, int, error) {
+	s.logger.Info(ctx, "Merging categories", "source_id", sourceID, "target_id", targetID)
+
+	if sourceID == targetID {
+		return nil, 0, domain.ErrInvalidInput("cannot merge a category into itself")
+	}
+
+	source, err := s.visibleCategory(ctx, sourceID)
+	if err != nil {
+		return nil, 0, err
+	}
+	target, err := s.visibleCategory(ctx, targetID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if source.Version != sourceVersion {
+		return nil, 0, domain.ErrVersionConflict("category", sourceVersion, source.Version)
+	}
+	if target.Version != targetVersion {
+		return nil, 0, domain.ErrVersionConflict("category", targetVersion, target.Version)
+	}
+
+	var taskIDs, childIDs []string
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		// The source's subcategories move under the target, so the check takes the hierarchy lock too
+		if err := s.categoryRepo.LockHierarchy(txCtx); err != nil {
+			return err
+		}
+		if err := s.validateMergeTarget(txCtx, source, target); err != nil {
+			return err
+		}
+		// Updating the target checks its version and keeps concurrent merges into it in order
+		if err := s.categoryRepo.Update(txCtx, target); err != nil {
+			return err
+		}
+
+		var err error
+		if taskIDs, err = s.categoryRepo.ReassignTasks(txCtx, sourceID, targetID); err != nil {
+			return err
+		}
+		if childIDs, err = s.categoryRepo.MoveChildren(txCtx, sourceID, targetID); err != nil {
+			return err
+		}
+		if err := s.categoryRepo.SoftDelete(txCtx, sourceID, sourceVersion); err != nil {
+			return err
+		}
+
+		for _, taskID := range taskIDs {
+			if err := recordTaskHistory(txCtx, s.taskRepo, taskID, domain.TaskHistoryActionUpdated, &domain.TaskHistoryDetails{
+				OldValues: map[string]interface{}{"category_id": sourceID},
+				NewValues: map[string]interface{}{"category_id": targetID},
+				Changes:   []string{"categories"},
+				Metadata:  map[string]interface{}{"merged_category_id": sourceID},
+			}); err != nil {
+				return err
+			}
+		}
+		details := &domain.TaskHistoryDetails{Metadata: map[string]interface{}{
+			"source_category_id": sourceID,
+			"target_category_id": targetID,
+			"task_ids":           taskIDs,
+			"subcategory_ids":    childIDs,
+		}}
+		if err := recordCategoryHistory(txCtx, s.categoryRepo, sourceID, domain.CategoryHistoryActionMerged, details); err != nil {
+			return err
+		}
+		return recordCategoryHistory(txCtx, s.categoryRepo, targetID, domain.CategoryHistoryActionMerged, details)
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) || domain.IsBusinessRuleError(err) {
+			s.logger.Warn(ctx, "Category merge rejected", "source_id", sourceID, "target_id", targetID, "error", err)
+			return nil, 0, err
+		}
+		s.logger.Error(ctx, "Failed to merge categories", "error", err, "source_id", sourceID, "target_id", targetID)
+		return nil, 0, fmt.Errorf("failed to merge categories: %w", err)
+	}
+
+	s.logger.Info(ctx, "Categories merged successfully", "source_id", sourceID, "target_id", targetID,
+		"tasks", len(taskIDs), "subcategories", len(childIDs))
+	return target, len(taskIDs), nil
+}
+
+// validateMergeTarget checks that the source's subcategories can move under the target:
+// the target must not be one of them, and the tree must stay within the maximum depth
+func (s *categoryService) validateMergeTarget(ctx context.Context, source, target *domain.Category) error {
+	ancestors, err := s.categoryRepo.GetAncestors(ctx, target.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get category ancestors: %w", err)
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == source.ID {
+			return domain.ErrBusinessRule("cannot merge a category into one of its subcategories")
+		}
+	}
+
+	nodes, err := s.categoryRepo.GetSubtree(ctx, source.ID, s.maxDepth+1)
+	if err != nil {
+		return fmt.Errorf("failed to get category subtree: %w", err)
+	}
+	height := 1
+	for _, root := range domain.BuildCategoryTree(nodes, nil) {
+		height = max(height, root.Height())
+	}
+	// The source's own level disappears; its subcategories start one level below the target
+	if depth := len(ancestors) + height; depth > s.maxDepth {
+		return domain.ErrBusinessRule(fmt.Sprintf("category tree would be %d levels deep, the maximum is %d", depth, s.maxDepth))
+	}
+	return nil
+}
+
+// visibleCategory loads a category the caller can see; others are reported as missing
+func (s *categoryService) visibleCategory(ctx context.Context, id string) (*domain.Category, error) {
+	category, err := s.GetCategoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	userID, role := callerIdentity(ctx)
+	if !category.VisibleTo(userID, role) {
+		return nil, domain.ErrNotFound("category")
+	}
+	return category, nil
+}
+
+func recordCategoryHistory(ctx context.Context, categoryRepo repository.CategoryRepository, categoryID string, action domain.CategoryHistoryAction, details *domain.TaskHistoryDetails) error {
+	entry := &domain.CategoryHistory{
+		CategoryID: categoryID,
+		Action:     action,
+		ActorID:    actorID(ctx),
+	}
+
+	if details != nil {
+		data, err := json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("failed to encode history details: %w", err)
+		}
+		entry.Details = data
+	}
+
+	return categoryRepo.AddHistory(ctx, entry)
+}
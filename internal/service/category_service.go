@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/todo-app/services/admin-service/internal/model/domain"
@@ -12,18 +13,28 @@ import (
 type categoryService struct {
 	categoryRepo repository.CategoryRepository
 	taskRepo     repository.TaskRepository
+	txManager    repository.TransactionManager
+	maxDepth     int
 	logger       logger.Logger
 }
 
-// NewCategoryService creates a new category service
+// NewCategoryService creates a new category service. maxDepth limits the number of levels in
+// a category tree; zero uses domain.DefaultCategoryMaxDepth.
 func NewCategoryService(
 	categoryRepo repository.CategoryRepository,
 	taskRepo repository.TaskRepository,
+	txManager repository.TransactionManager,
+	maxDepth int,
 	log logger.Logger,
 ) CategoryService {
+	if maxDepth <= 0 {
+		maxDepth = domain.DefaultCategoryMaxDepth
+	}
 	return &categoryService{
 		categoryRepo: categoryRepo,
 		taskRepo:     taskRepo,
+		txManager:    txManager,
+		maxDepth:     maxDepth,
 		logger:       log,
 	}
 }
@@ -35,6 +46,9 @@ func (s *categoryService) CreateCategory(ctx context.Context, category *domain.C
 	if err := s.validateCategoryForCreation(ctx, category); err != nil {
 		return nil, err
 	}
+	if err := s.validateCategoryParent(ctx, category); err != nil {
+		return nil, err
+	}
 
 	// Check for duplicate name
 	existing, err := s.findCategoryByName(ctx, category.Name)
@@ -99,12 +113,29 @@ func (s *categoryService) UpdateCategory(ctx context.Context, category *domain.C
 		}
 	}
 
+	// The workflow only changes through SetCategoryWorkflow
+	category.WorkflowID = existingCategory.WorkflowID
+
+	// A parent that was valid when it was set stays valid even if it is now hidden from the caller
+	if category.ParentID != nil && *category.ParentID == "" {
+		category.ParentID = nil
+	}
+	if category.ParentID != nil && (existingCategory.ParentID == nil || *existingCategory.ParentID != *category.ParentID) {
+		if err := s.validateCategoryParent(ctx, category); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update category
 	if err := s.categoryRepo.Update(ctx, category); err != nil {
 		if domain.IsVersionConflictError(err) {
 			s.logger.Warn(ctx, "Category update version conflict", "category_id", category.ID, "version", category.Version)
 			return nil, err
 		}
+		if domain.IsBusinessRuleError(err) {
+			// A concurrent move closed a cycle; the database rejected this one
+			return nil, err
+		}
 		s.logger.Error(ctx, "Failed to update category", "error", err, "category_id", category.ID)
 		return nil, fmt.Errorf("failed to update category: %w", err)
 	}
@@ -113,39 +144,107 @@ func (s *categoryService) UpdateCategory(ctx context.Context, category *domain.C
 	return category, nil
 }
 
-func (s *categoryService) DeleteCategory(ctx context.Context, id string, version int64) error {
-	s.logger.Info(ctx, "Soft deleting category", "category_id", id, "version", version)
+func (s *categoryService) DeleteCategory(ctx context.Context, id string, version int64, cascade bool) error {
+	s.logger.Info(ctx, "Soft deleting category", "category_id", id, "version", version, "cascade", cascade)
 
 	if id == "" {
 		return domain.ErrInvalidInput("category ID is required")
 	}
 
-	// Business rule: Check if category is in use
-	if err := s.ValidateCategoryUsage(ctx, id); err != nil {
-		return err
-	}
+	var deletedIDs []string
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		// Business rule: neither the category nor a subcategory deleted with it may be in use
+		usageIDs := []string{id}
+		if cascade {
+			nodes, err := s.categoryRepo.GetSubtree(txCtx, id, maxCategoryCascadeDepth)
+			if err != nil {
+				return fmt.Errorf("failed to get subcategories: %w", err)
+			}
+			for _, node := range nodes {
+				if node.Category.ID != id {
+					usageIDs = append(usageIDs, node.Category.ID)
+				}
+			}
+		}
+		if err := s.validateCategoriesUnused(txCtx, usageIDs); err != nil {
+			return err
+		}
 
-	if err := s.categoryRepo.SoftDelete(ctx, id, version); err != nil {
+		if err := s.categoryRepo.SoftDelete(txCtx, id, version); err != nil {
+			return err
+		}
+		if cascade {
+			var err error
+			if deletedIDs, err = s.categoryRepo.SoftDeleteDescendants(txCtx, id); err != nil {
+				return err
+			}
+		}
+
+		if err := recordCategoryHistory(txCtx, s.categoryRepo, id, domain.CategoryHistoryActionDeleted, &domain.TaskHistoryDetails{
+			Metadata: map[string]interface{}{"cascade": cascade, "subcategory_ids": deletedIDs},
+		}); err != nil {
+			return err
+		}
+		for _, deletedID := range deletedIDs {
+			if err := recordCategoryHistory(txCtx, s.categoryRepo, deletedID, domain.CategoryHistoryActionDeleted, &domain.TaskHistoryDetails{
+				Metadata: map[string]interface{}{"cascaded_from": id},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		if domain.IsVersionConflictError(err) {
 			s.logger.Warn(ctx, "Category deletion version conflict", "category_id", id, "version", version)
 			return err
 		}
+		if domain.IsBusinessRuleError(err) {
+			return err
+		}
 		s.logger.Error(ctx, "Failed to delete category", "error", err, "category_id", id)
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
 
-	s.logger.Info(ctx, "Category deleted successfully", "category_id", id)
+	s.logger.Info(ctx, "Category deleted successfully", "category_id", id, "subcategories", len(deletedIDs))
 	return nil
 }
 
-func (s *categoryService) RestoreCategory(ctx context.Context, id string, version int64) (*domain.Category, error) {
-	s.logger.Info(ctx, "Restoring category", "category_id", id, "version", version)
+func (s *categoryService) RestoreCategory(ctx context.Context, id string, version int64, cascade bool) (*domain.Category, error) {
+	s.logger.Info(ctx, "Restoring category", "category_id", id, "version", version, "cascade", cascade)
 
 	if id == "" {
 		return nil, domain.ErrInvalidInput("category ID is required")
 	}
 
-	if err := s.categoryRepo.Restore(ctx, id, version); err != nil {
+	var restoredIDs []string
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		// Subcategories are matched by the category's deletion time, so they go first
+		if cascade {
+			var err error
+			if restoredIDs, err = s.categoryRepo.RestoreDescendants(txCtx, id); err != nil {
+				return err
+			}
+		}
+		if err := s.categoryRepo.Restore(txCtx, id, version); err != nil {
+			return err
+		}
+
+		if err := recordCategoryHistory(txCtx, s.categoryRepo, id, domain.CategoryHistoryActionRestored, &domain.TaskHistoryDetails{
+			Metadata: map[string]interface{}{"cascade": cascade, "subcategory_ids": restoredIDs},
+		}); err != nil {
+			return err
+		}
+		for _, restoredID := range restoredIDs {
+			if err := recordCategoryHistory(txCtx, s.categoryRepo, restoredID, domain.CategoryHistoryActionRestored, &domain.TaskHistoryDetails{
+				Metadata: map[string]interface{}{"cascaded_from": id},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		if domain.IsVersionConflictError(err) {
 			s.logger.Warn(ctx, "Category restoration version conflict", "category_id", id, "version", version)
 			return nil, err
@@ -160,19 +259,14 @@ func (s *categoryService) RestoreCategory(ctx context.Context, id string, versio
 		return nil, fmt.Errorf("failed to get restored category: %w", err)
 	}
 
-	s.logger.Info(ctx, "Category restored successfully", "category_id", id)
+	s.logger.Info(ctx, "Category restored successfully", "category_id", id, "subcategories", len(restoredIDs))
 	return category, nil
 }
 
-func (s *categoryService) ListCategories(ctx context.Context, opts repository.ListOptions) ([]*domain.Category, int64, error) {
+func (s *categoryService) ListCategories(ctx context.Context, opts repository.CategoryListOptions) ([]*domain.Category, int64, error) {
 	s.logger.Debug(ctx, "Listing categories", "page", opts.Page, "page_size", opts.PageSize)
 
-	// Convert to CategoryListOptions - this will be implemented when we add specific filtering
-	categoryOpts := repository.CategoryListOptions{
-		ListOptions: opts,
-	}
-
-	categories, total, err := s.categoryRepo.List(ctx, categoryOpts)
+	categories, total, err := s.categoryRepo.List(ctx, opts)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to list categories", "error", err)
 		return nil, 0, fmt.Errorf("failed to list categories: %w", err)
@@ -182,12 +276,70 @@ func (s *categoryService) ListCategories(ctx context.Context, opts repository.Li
 	return categories, total, nil
 }
 
+func (s *categoryService) GetCategoryTree(ctx context.Context, rootID string, maxDepth int) ([]*domain.CategoryNode, []*domain.Category, error) {
+	s.logger.Debug(ctx, "Getting category tree", "root_id", rootID, "max_depth", maxDepth)
+
+	if maxDepth < 0 {
+		return nil, nil, domain.ErrInvalidInput("max depth must not be negative")
+	}
+	if maxDepth == 0 || maxDepth > s.maxDepth {
+		maxDepth = s.maxDepth
+	}
+
+	userID, role := callerIdentity(ctx)
+	visible := func(category *domain.Category) bool {
+		return category.VisibleTo(userID, role)
+	}
+
+	var ancestors []*domain.Category
+	if rootID != "" {
+		root, err := s.categoryRepo.GetByID(ctx, rootID)
+		if err != nil && !domain.IsNotFoundError(err) {
+			return nil, nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		if err != nil || !visible(root) {
+			return nil, nil, domain.ErrNotFound("category")
+		}
+
+		all, err := s.categoryRepo.GetAncestors(ctx, rootID)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get category ancestors", "error", err, "category_id", rootID)
+			return nil, nil, fmt.Errorf("failed to get category ancestors: %w", err)
+		}
+		for _, ancestor := range all {
+			if !ancestor.IsDeleted && visible(ancestor) {
+				ancestors = append(ancestors, ancestor)
+			}
+		}
+	}
+
+	nodes, err := s.categoryRepo.GetSubtree(ctx, rootID, maxDepth)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get category subtree", "error", err, "root_id", rootID)
+		return nil, nil, fmt.Errorf("failed to get category tree: %w", err)
+	}
+
+	// Hidden categories are left out together with everything below them
+	return domain.BuildCategoryTree(nodes, visible), ancestors, nil
+}
+
 func (s *categoryService) ValidateCategoryUsage(ctx context.Context, categoryID string) error {
-	taskCount, err := s.GetCategoryTaskCount(ctx, categoryID)
+	return s.validateCategoriesUnused(ctx, []string{categoryID})
+}
+
+// validateCategoriesUnused fails when any task uses one of the categories
+func (s *categoryService) validateCategoriesUnused(ctx context.Context, categoryIDs []string) error {
+	_, taskCount, err := s.taskRepo.List(ctx, repository.TaskListOptions{
+		ListOptions: repository.ListOptions{PageSize: 1}, // We only need the count
+		CategoryIDs: categoryIDs,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to check category usage: %w", err)
 	}
 
+	if taskCount > 0 && len(categoryIDs) > 1 {
+		return domain.ErrBusinessRule(fmt.Sprintf("cannot delete category: it and its subcategories are used by %d tasks", taskCount))
+	}
 	if taskCount > 0 {
 		return domain.ErrBusinessRule(fmt.Sprintf("cannot delete category: it is used by %d tasks", taskCount))
 	}
@@ -239,6 +391,59 @@ func (s *categoryService) validateCategoryForUpdate(ctx context.Context, categor
 	return nil
 }
 
+// validateCategoryParent checks that the category can sit under its parent: the parent must exist
+// and be visible to the caller, must not be the category itself or one of its subcategories, and
+// the tree must not grow deeper than the configured maximum
+func (s *categoryService) validateCategoryParent(ctx context.Context, category *domain.Category) error {
+	if category.ParentID == nil || *category.ParentID == "" {
+		category.ParentID = nil
+		return nil
+	}
+	parentID := *category.ParentID
+
+	if category.ID != "" && parentID == category.ID {
+		return domain.ErrBusinessRule("a category cannot be its own parent")
+	}
+
+	// Deleted parents and parents the caller cannot see are reported the same way,
+	// so private categories are not revealed
+	parent, err := s.categoryRepo.GetByID(ctx, parentID)
+	if err != nil && !domain.IsNotFoundError(err) {
+		return fmt.Errorf("failed to get parent category: %w", err)
+	}
+	userID, role := callerIdentity(ctx)
+	if err != nil || !parent.VisibleTo(userID, role) {
+		return domain.ErrInvalidInput("parent category not found")
+	}
+
+	ancestors, err := s.categoryRepo.GetAncestors(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to get parent category ancestors: %w", err)
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == category.ID {
+			return domain.ErrBusinessRule("a category cannot be moved under its own subcategory")
+		}
+	}
+
+	// The parent sits at level len(ancestors)+1, and the category brings its own subtree along
+	height := 1
+	if category.ID != "" {
+		nodes, err := s.categoryRepo.GetSubtree(ctx, category.ID, s.maxDepth+1)
+		if err != nil {
+			return fmt.Errorf("failed to get category subtree: %w", err)
+		}
+		for _, root := range domain.BuildCategoryTree(nodes, nil) {
+			height = max(height, root.Height())
+		}
+	}
+	if depth := len(ancestors) + 1 + height; depth > s.maxDepth {
+		return domain.ErrBusinessRule(fmt.Sprintf("category tree would be %d levels deep, the maximum is %d", depth, s.maxDepth))
+	}
+
+	return nil
+}
+
 func (s *categoryService) findCategoryByName(ctx context.Context, name string) (*domain.Category, error) {
 	// This is a simplified implementation - in a real system you might have a GetByName method
 	categories, _, err := s.categoryRepo.List(ctx, repository.CategoryListOptions{
@@ -2,23 +2,36 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository"
 	"github.com/todo-app/services/admin-service/internal/testutil"
 	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
 )
 
 type mockCategoryRepository struct {
 	categories map[string]*domain.Category
 	nameIndex  map[string]*domain.Category
+	deleted    map[string]*domain.Category
+	cascades   map[string]string // subcategory ID -> category whose delete cascaded to it
+	history    []*domain.CategoryHistory
+	tasks      *mockTaskRepository // optional, for ReassignTasks
+
+	hierarchyLocks int
 }
 
 func newMockCategoryRepository() *mockCategoryRepository {
 	return &mockCategoryRepository{
 		categories: make(map[string]*domain.Category),
 		nameIndex:  make(map[string]*domain.Category),
+		deleted:    make(map[string]*domain.Category),
+		cascades:   make(map[string]string),
 	}
 }
 
@@ -42,6 +55,15 @@ func (m *mockCategoryRepository) GetByID(ctx context.Context, id string) (*domai
 	return category, nil
 }
 
+func (m *mockCategoryRepository) GetByName(ctx context.Context, name string) (*domain.Category, error) {
+	for _, category := range m.categories {
+		if strings.EqualFold(category.Name, name) {
+			return category, nil
+		}
+	}
+	return nil, domain.ErrNotFound("category")
+}
+
 func (m *mockCategoryRepository) Update(ctx context.Context, category *domain.Category) error {
 	existing, exists := m.categories[category.ID]
 	if !exists {
@@ -73,11 +95,93 @@ func (m *mockCategoryRepository) SoftDelete(ctx context.Context, id string, vers
 	existing.Version++
 	delete(m.categories, id)
 	delete(m.nameIndex, existing.Name)
+	m.deleted[id] = existing
 	return nil
 }
 
 func (m *mockCategoryRepository) Restore(ctx context.Context, id string, version int64) error {
-	return nil // Not implemented for mock
+	existing, exists := m.deleted[id]
+	if !exists || existing.Version != version {
+		return domain.ErrVersionConflict("category", version, version+1)
+	}
+
+	existing.IsDeleted = false
+	existing.Version++
+	delete(m.deleted, id)
+	m.categories[id] = existing
+	m.nameIndex[existing.Name] = existing
+	return nil
+}
+
+func (m *mockCategoryRepository) SoftDeleteDescendants(ctx context.Context, id string) ([]string, error) {
+	var ids []string
+	for changed := true; changed; {
+		changed = false
+		for _, category := range m.categories {
+			if category.ParentID != nil && (*category.ParentID == id || m.cascades[*category.ParentID] == id) {
+				m.cascades[category.ID] = id
+				m.SoftDelete(ctx, category.ID, category.Version)
+				ids = append(ids, category.ID)
+				changed = true
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockCategoryRepository) RestoreDescendants(ctx context.Context, id string) ([]string, error) {
+	var ids []string
+	for childID, rootID := range m.cascades {
+		if rootID == id {
+			m.Restore(ctx, childID, m.deleted[childID].Version)
+			delete(m.cascades, childID)
+			ids = append(ids, childID)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockCategoryRepository) ReassignTasks(ctx context.Context, sourceID, targetID string) ([]string, error) {
+	var ids []string
+	for _, task := range m.tasks.tasks {
+		hasTarget, sourceIndex := false, -1
+		for i, category := range task.Categories {
+			hasTarget = hasTarget || category.ID == targetID
+			if category.ID == sourceID {
+				sourceIndex = i
+			}
+		}
+		if sourceIndex < 0 {
+			continue
+		}
+		task.Categories = append(task.Categories[:sourceIndex], task.Categories[sourceIndex+1:]...)
+		if !hasTarget {
+			task.Categories = append(task.Categories, domain.Category{ID: targetID})
+		}
+		ids = append(ids, task.ID)
+	}
+	return ids, nil
+}
+
+func (m *mockCategoryRepository) LockHierarchy(ctx context.Context) error {
+	m.hierarchyLocks++
+	return nil
+}
+
+func (m *mockCategoryRepository) MoveChildren(ctx context.Context, fromID, toID string) ([]string, error) {
+	var ids []string
+	for _, category := range m.categories {
+		if category.ParentID != nil && *category.ParentID == fromID {
+			category.ParentID = &toID
+			ids = append(ids, category.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockCategoryRepository) AddHistory(ctx context.Context, entry *domain.CategoryHistory) error {
+	m.history = append(m.history, entry)
+	return nil
 }
 
 func (m *mockCategoryRepository) List(ctx context.Context, opts repository.CategoryListOptions) ([]*domain.Category, int64, error) {
@@ -88,19 +192,67 @@ func (m *mockCategoryRepository) List(ctx context.Context, opts repository.Categ
 	return categories, int64(len(categories)), nil
 }
 
+func (m *mockCategoryRepository) GetSubtree(ctx context.Context, rootID string, maxDepth int) ([]*domain.CategoryNode, error) {
+	var level []*domain.CategoryNode
+	for _, category := range m.categories {
+		if rootID == category.ID || (rootID == "" && category.ParentID == nil) {
+			level = append(level, &domain.CategoryNode{Category: category, Depth: 1})
+		}
+	}
+
+	var nodes []*domain.CategoryNode
+	for depth := 1; len(level) > 0 && depth <= maxDepth; depth++ {
+		nodes = append(nodes, level...)
+		var next []*domain.CategoryNode
+		for _, parent := range level {
+			for _, category := range m.categories {
+				if category.ParentID != nil && *category.ParentID == parent.Category.ID {
+					next = append(next, &domain.CategoryNode{Category: category, Depth: depth + 1})
+				}
+			}
+		}
+		level = next
+	}
+	return nodes, nil
+}
+
+func (m *mockCategoryRepository) GetAncestors(ctx context.Context, id string) ([]*domain.Category, error) {
+	var ancestors []*domain.Category
+	category, ok := m.categories[id]
+	for ok && category.ParentID != nil && len(ancestors) < len(m.categories) {
+		category, ok = m.categories[*category.ParentID]
+		if ok {
+			ancestors = append(ancestors, category)
+		}
+	}
+	return ancestors, nil
+}
+
 type mockTaskRepository struct {
-	tasks map[string]*domain.Task
+	tasks    map[string]*domain.Task
+	deleted  map[string]*domain.Task
+	cascades map[string]string // subtask ID -> ID of the task whose delete took it along
+	history  []*domain.TaskHistory
+	blockers []*domain.TaskDependency
 }
 
 func newMockTaskRepository() *mockTaskRepository {
 	return &mockTaskRepository{
-		tasks: make(map[string]*domain.Task),
+		tasks:    make(map[string]*domain.Task),
+		deleted:  make(map[string]*domain.Task),
+		cascades: make(map[string]string),
 	}
 }
 
 func (m *mockTaskRepository) Create(ctx context.Context, task *domain.Task) error {
 	task.ID = "mock-task-" + task.Title
+	if task.SeriesIndex > 1 {
+		task.ID = fmt.Sprintf("%s-%d", task.ID, task.SeriesIndex)
+	}
 	task.Version = 1
+	if task.AssigneeID != "" && !mockHoldsRole(task, domain.TaskParticipant{UserID: task.AssigneeID, Role: domain.TaskRoleAssignee}) {
+		task.Participants = append([]domain.TaskParticipant{{UserID: task.AssigneeID, Role: domain.TaskRoleAssignee}}, task.Participants...)
+	}
 	m.tasks[task.ID] = task
 	return nil
 }
@@ -113,6 +265,14 @@ func (m *mockTaskRepository) GetByID(ctx context.Context, id string) (*domain.Ta
 	return task, nil
 }
 
+func (m *mockTaskRepository) GetDeletedByID(ctx context.Context, id string) (*domain.Task, error) {
+	task, exists := m.deleted[id]
+	if !exists {
+		return nil, domain.ErrNotFound("task")
+	}
+	return task, nil
+}
+
 func (m *mockTaskRepository) Update(ctx context.Context, task *domain.Task) error {
 	existing, exists := m.tasks[task.ID]
 	if !exists {
@@ -122,8 +282,9 @@ func (m *mockTaskRepository) Update(ctx context.Context, task *domain.Task) erro
 		return domain.ErrVersionConflict("task", task.Version, existing.Version)
 	}
 
+	// Update the stored task in place so tests holding it see the change
 	task.Version++
-	m.tasks[task.ID] = task
+	*existing = *task
 	return nil
 }
 
@@ -139,16 +300,190 @@ func (m *mockTaskRepository) SoftDelete(ctx context.Context, id string, version
 	existing.IsDeleted = true
 	existing.Version++
 	delete(m.tasks, id)
+	m.deleted[id] = existing
 	return nil
 }
 
 func (m *mockTaskRepository) Restore(ctx context.Context, id string, version int64) error {
+	existing, exists := m.deleted[id]
+	if !exists {
+		return nil
+	}
+	if existing.Version != version {
+		return domain.ErrVersionConflict("task", version, existing.Version)
+	}
+
+	existing.IsDeleted = false
+	existing.Version++
+	delete(m.deleted, id)
+	m.tasks[id] = existing
+	return nil
+}
+
+func (m *mockTaskRepository) GetSubtree(ctx context.Context, rootID string) ([]*domain.TaskNode, error) {
+	root, ok := m.tasks[rootID]
+	if !ok {
+		return nil, nil
+	}
+
+	var nodes []*domain.TaskNode
+	level := []*domain.TaskNode{{Task: root, Depth: 1}}
+	for depth := 1; len(level) > 0; depth++ {
+		nodes = append(nodes, level...)
+		var next []*domain.TaskNode
+		for _, parent := range level {
+			for _, task := range m.tasks {
+				if task.ParentID != nil && *task.ParentID == parent.Task.ID {
+					next = append(next, &domain.TaskNode{Task: task, Depth: depth + 1})
+				}
+			}
+		}
+		level = next
+	}
+	return nodes, nil
+}
+
+func (m *mockTaskRepository) SoftDeleteDescendants(ctx context.Context, id string) ([]string, error) {
+	var ids []string
+	for changed := true; changed; {
+		changed = false
+		for _, task := range m.tasks {
+			if task.ParentID != nil && (*task.ParentID == id || m.cascades[*task.ParentID] == id) {
+				m.cascades[task.ID] = id
+				m.SoftDelete(ctx, task.ID, task.Version)
+				ids = append(ids, task.ID)
+				changed = true
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockTaskRepository) RestoreDescendants(ctx context.Context, id string) ([]string, error) {
+	var ids []string
+	for childID, rootID := range m.cascades {
+		if rootID == id {
+			m.Restore(ctx, childID, m.deleted[childID].Version)
+			delete(m.cascades, childID)
+			ids = append(ids, childID)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockTaskRepository) AddDependency(ctx context.Context, dependency *domain.TaskDependency) error {
+	for _, existing := range m.blockers {
+		if existing.TaskID == dependency.TaskID && existing.BlockerID == dependency.BlockerID {
+			return domain.ErrConflict("the task is already blocked by this task")
+		}
+	}
+	m.blockers = append(m.blockers, dependency)
+	return nil
+}
+
+func (m *mockTaskRepository) RemoveDependency(ctx context.Context, taskID, blockerID string) error {
+	for i, existing := range m.blockers {
+		if existing.TaskID == taskID && existing.BlockerID == blockerID {
+			m.blockers = append(m.blockers[:i], m.blockers[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrNotFound("task dependency")
+}
+
+func (m *mockTaskRepository) ListBlockers(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	for _, dependency := range m.blockers {
+		if task, ok := m.tasks[dependency.BlockerID]; ok && dependency.TaskID == taskID {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockTaskRepository) ListDependents(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	for _, dependency := range m.blockers {
+		if task, ok := m.tasks[dependency.TaskID]; ok && dependency.BlockerID == taskID {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockTaskRepository) IsBlockedBy(ctx context.Context, taskID, blockerID string) (bool, error) {
+	seen := map[string]bool{taskID: true}
+	pending := []string{taskID}
+	for len(pending) > 0 {
+		id := pending[0]
+		pending = pending[1:]
+		for _, dependency := range m.blockers {
+			if dependency.TaskID != id || seen[dependency.BlockerID] {
+				continue
+			}
+			if dependency.BlockerID == blockerID {
+				return true, nil
+			}
+			seen[dependency.BlockerID] = true
+			pending = append(pending, dependency.BlockerID)
+		}
+	}
+	return false, nil
+}
+
+func (m *mockTaskRepository) ListDependencies(ctx context.Context, taskIDs []string) ([]*domain.TaskDependency, error) {
+	selected := make(map[string]bool, len(taskIDs))
+	for _, id := range taskIDs {
+		selected[id] = true
+	}
+	var dependencies []*domain.TaskDependency
+	for _, dependency := range m.blockers {
+		if selected[dependency.TaskID] && selected[dependency.BlockerID] {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies, nil
+}
+
+func (m *mockTaskRepository) LinkToSeries(ctx context.Context, task *domain.Task) error {
+	existing, exists := m.tasks[task.ID]
+	if !exists {
+		return domain.ErrNotFound("task")
+	}
+	if existing.Version != task.Version {
+		return domain.ErrVersionConflict("task", task.Version, existing.Version)
+	}
+
+	task.Version++
+	m.tasks[task.ID] = task
 	return nil
 }
 
+func (m *mockTaskRepository) ListSeriesInstances(ctx context.Context, seriesID string) ([]*domain.Task, error) {
+	var instances []*domain.Task
+	for _, task := range m.tasks {
+		if task.SeriesID != nil && *task.SeriesID == seriesID {
+			instances = append(instances, task)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].SeriesIndex < instances[j].SeriesIndex })
+	return instances, nil
+}
+
 func (m *mockTaskRepository) List(ctx context.Context, opts repository.TaskListOptions) ([]*domain.Task, int64, error) {
+	source := m.tasks
+	if opts.DeletedOnly {
+		source = m.deleted
+	}
+
 	tasks := make([]*domain.Task, 0)
-	for _, task := range m.tasks {
+	for _, task := range source {
+		if opts.AssigneeID != "" && !mockHasAnyRole(task, opts.AssigneeID, opts.AssigneeRoles) {
+			continue
+		}
+		if opts.CreatorID != "" && task.CreatorID != opts.CreatorID {
+			continue
+		}
 		// Filter by category if specified
 		if len(opts.CategoryIDs) > 0 {
 			hasCategory := false
@@ -173,6 +508,58 @@ func (m *mockTaskRepository) List(ctx context.Context, opts repository.TaskListO
 	return tasks, int64(len(tasks)), nil
 }
 
+func (m *mockTaskRepository) StreamForExport(ctx context.Context, opts repository.TaskListOptions, fn func(*domain.TaskExportRecord) error) error {
+	tasks, _, _ := m.List(ctx, opts)
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	for _, task := range tasks {
+		if err := fn(&domain.TaskExportRecord{Task: task}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Search matches the query as a case-insensitive substring of the title or description
+func (m *mockTaskRepository) Search(ctx context.Context, opts repository.TaskListOptions) ([]*domain.TaskSearchResult, int64, error) {
+	tasks, _, _ := m.List(ctx, opts)
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	query := strings.ToLower(opts.SearchQuery)
+	results := make([]*domain.TaskSearchResult, 0)
+	for _, task := range tasks {
+		if strings.Contains(strings.ToLower(task.Title), query) || strings.Contains(strings.ToLower(task.Description), query) {
+			results = append(results, &domain.TaskSearchResult{Task: task, Rank: 1, TitleHighlight: task.Title})
+		}
+	}
+	return results, int64(len(results)), nil
+}
+
+// Facets counts tasks by status and priority; other facets have no values
+func (m *mockTaskRepository) Facets(ctx context.Context, opts repository.TaskListOptions, facets []domain.TaskFacet) ([]*domain.FacetCounts, error) {
+	tasks, _, _ := m.List(ctx, opts)
+
+	results := make([]*domain.FacetCounts, 0, len(facets))
+	for _, facet := range facets {
+		counts := make(map[string]int64)
+		for _, task := range tasks {
+			switch facet {
+			case domain.TaskFacetStatus:
+				counts[string(task.Status)]++
+			case domain.TaskFacetPriority:
+				counts[string(task.Priority)]++
+			}
+		}
+
+		result := &domain.FacetCounts{Facet: facet, Values: []domain.FacetValue{}}
+		for value, count := range counts {
+			result.Values = append(result.Values, domain.FacetValue{Value: value, Label: value, Count: count})
+		}
+		sort.Slice(result.Values, func(i, j int) bool { return result.Values[i].Value < result.Values[j].Value })
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 func (m *mockTaskRepository) AddCategories(ctx context.Context, taskID string, categoryIDs []string, version int64) error {
 	return nil
 }
@@ -181,6 +568,75 @@ func (m *mockTaskRepository) RemoveCategories(ctx context.Context, taskID string
 	return nil
 }
 
+func (m *mockTaskRepository) AddParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) error {
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return domain.ErrNotFound("task")
+	}
+	if task.Version != version {
+		return domain.ErrVersionConflict("task", version, task.Version)
+	}
+	for _, participant := range participants {
+		if !mockHoldsRole(task, participant) {
+			participant.AddedAt = time.Now()
+			task.Participants = append(task.Participants, participant)
+		}
+	}
+	task.Version++
+	return nil
+}
+
+func (m *mockTaskRepository) RemoveParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) error {
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return domain.ErrNotFound("task")
+	}
+	if task.Version != version {
+		return domain.ErrVersionConflict("task", version, task.Version)
+	}
+	kept := task.Participants[:0]
+	for _, existing := range task.Participants {
+		removed := false
+		for _, participant := range participants {
+			if participant.UserID == existing.UserID && participant.Role == existing.Role {
+				removed = true
+			}
+		}
+		if !removed {
+			kept = append(kept, existing)
+		}
+	}
+	task.Participants = kept
+	if assignees := task.ParticipantsWithRole(domain.TaskRoleAssignee); len(assignees) > 0 && !mockHoldsRole(task, domain.TaskParticipant{UserID: task.AssigneeID, Role: domain.TaskRoleAssignee}) {
+		task.AssigneeID = assignees[0]
+	}
+	task.Version++
+	return nil
+}
+
+// mockHoldsRole reports whether the participant is among the task's loaded participants
+func mockHoldsRole(task *domain.Task, participant domain.TaskParticipant) bool {
+	for _, existing := range task.Participants {
+		if existing.UserID == participant.UserID && existing.Role == participant.Role {
+			return true
+		}
+	}
+	return false
+}
+
+// mockHasAnyRole matches the assignee filter: any of the roles, or any role at all
+func mockHasAnyRole(task *domain.Task, userID string, roles []domain.TaskParticipantRole) bool {
+	if len(roles) == 0 {
+		roles = domain.TaskParticipantRoles
+	}
+	for _, role := range roles {
+		if task.HasRole(userID, role) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *mockTaskRepository) AddTags(ctx context.Context, taskID string, tagIDs []string, version int64) error {
 	return nil
 }
@@ -189,6 +645,14 @@ func (m *mockTaskRepository) RemoveTags(ctx context.Context, taskID string, tagI
 	return nil
 }
 
+func (m *mockTaskRepository) AddHistory(ctx context.Context, entry *domain.TaskHistory) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	m.history = append(m.history, entry)
+	return nil
+}
+
 func (m *mockTaskRepository) GetHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error) {
 	return nil, nil
 }
@@ -197,7 +661,7 @@ func TestCategoryService_CreateCategory(t *testing.T) {
 	mockCategoryRepo := newMockCategoryRepository()
 	mockTaskRepo := newMockTaskRepository()
 	mockLogger := logger.NewLogger("debug")
-	service := NewCategoryService(mockCategoryRepo, mockTaskRepo, mockLogger)
+	service := NewCategoryService(mockCategoryRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -248,7 +712,7 @@ func TestCategoryService_ValidateCategoryUsage(t *testing.T) {
 	mockCategoryRepo := newMockCategoryRepository()
 	mockTaskRepo := newMockTaskRepository()
 	mockLogger := logger.NewLogger("debug")
-	service := NewCategoryService(mockCategoryRepo, mockTaskRepo, mockLogger)
+	service := NewCategoryService(mockCategoryRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	// Create a test category
@@ -291,7 +755,7 @@ func TestCategoryService_GetCategoryTaskCount(t *testing.T) {
 	mockCategoryRepo := newMockCategoryRepository()
 	mockTaskRepo := newMockTaskRepository()
 	mockLogger := logger.NewLogger("debug")
-	service := NewCategoryService(mockCategoryRepo, mockTaskRepo, mockLogger)
+	service := NewCategoryService(mockCategoryRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	// Create a test category
@@ -316,3 +780,292 @@ func TestCategoryService_GetCategoryTaskCount(t *testing.T) {
 		t.Errorf("GetCategoryTaskCount() = %d, want %d", count, expectedCount)
 	}
 }
+
+func TestCategoryService_CategoryParent(t *testing.T) {
+	mockCategoryRepo := newMockCategoryRepository()
+	service := NewCategoryService(mockCategoryRepo, newMockTaskRepository(), &mockTransactionManager{}, 3, logger.NewLogger("debug"))
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	create := func(name string, parentID *string, public bool, creatorID string) *domain.Category {
+		t.Helper()
+		category, err := service.CreateCategory(ctx, &domain.Category{Name: name, ParentID: parentID, IsPublic: public, CreatorID: creatorID})
+		if err != nil {
+			t.Fatalf("CreateCategory(%s) error = %v", name, err)
+		}
+		return category
+	}
+
+	work := create("work", nil, true, "user-1")
+	projects := create("projects", &work.ID, true, "user-1")
+	alpha := create("alpha", &projects.ID, true, "user-1")
+	private := create("private", nil, false, "user-2")
+	home := create("home", nil, true, "user-1")
+
+	t.Run("depth limit on create", func(t *testing.T) {
+		_, err := service.CreateCategory(ctx, &domain.Category{Name: "too deep", ParentID: &alpha.ID, CreatorID: "user-1"})
+		if !domain.IsBusinessRuleError(err) {
+			t.Errorf("CreateCategory() error = %v, want business rule violation", err)
+		}
+	})
+
+	t.Run("invisible or missing parent", func(t *testing.T) {
+		missing := "missing-id"
+		for _, parentID := range []*string{&private.ID, &missing} {
+			_, err := service.CreateCategory(ctx, &domain.Category{Name: "child " + *parentID, ParentID: parentID, CreatorID: "user-1"})
+			if !domain.IsInvalidInputError(err) || !strings.Contains(err.Error(), "parent category not found") {
+				t.Errorf("CreateCategory() under %s error = %v, want parent not found", *parentID, err)
+			}
+		}
+	})
+
+	t.Run("cycles", func(t *testing.T) {
+		for _, parentID := range []string{work.ID, alpha.ID} {
+			moved := *work
+			moved.ParentID = &parentID
+			if _, err := service.UpdateCategory(ctx, &moved); !domain.IsBusinessRuleError(err) {
+				t.Errorf("UpdateCategory() under %s error = %v, want business rule violation", parentID, err)
+			}
+		}
+	})
+
+	t.Run("moving a subtree keeps within the depth limit", func(t *testing.T) {
+		moved := *projects
+		moved.ParentID = &home.ID
+		if _, err := service.UpdateCategory(ctx, &moved); err != nil {
+			t.Fatalf("UpdateCategory() error = %v", err)
+		}
+
+		// home > projects > alpha is already three levels, so it cannot go under work
+		movedHome := *home
+		movedHome.ParentID = &work.ID
+		if _, err := service.UpdateCategory(ctx, &movedHome); !domain.IsBusinessRuleError(err) {
+			t.Errorf("UpdateCategory() error = %v, want business rule violation", err)
+		}
+	})
+}
+
+func TestCategoryService_GetCategoryTree(t *testing.T) {
+	mockCategoryRepo := newMockCategoryRepository()
+	service := NewCategoryService(mockCategoryRepo, newMockTaskRepository(), &mockTransactionManager{}, 0, logger.NewLogger("debug"))
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	work := &domain.Category{Name: "work", IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, work)
+	projects := &domain.Category{Name: "projects", ParentID: &work.ID, IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, projects)
+	alpha := &domain.Category{Name: "alpha", ParentID: &projects.ID, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, alpha)
+	secret := &domain.Category{Name: "secret", ParentID: &work.ID, CreatorID: "user-2"}
+	mockCategoryRepo.Create(ctx, secret)
+
+	roots, ancestors, err := service.GetCategoryTree(ctx, "", 0)
+	if err != nil {
+		t.Fatalf("GetCategoryTree() error = %v", err)
+	}
+	if len(roots) != 1 || len(ancestors) != 0 {
+		t.Fatalf("GetCategoryTree() = %d roots, %d ancestors; want 1, 0", len(roots), len(ancestors))
+	}
+	if len(roots[0].Children) != 1 || roots[0].Height() != 3 {
+		t.Errorf("work subtree = %v, want projects > alpha without the other user's private category", roots[0].Children)
+	}
+
+	roots, ancestors, err = service.GetCategoryTree(ctx, projects.ID, 1)
+	if err != nil {
+		t.Fatalf("GetCategoryTree(projects) error = %v", err)
+	}
+	if len(roots) != 1 || roots[0].Category.ID != projects.ID || len(roots[0].Children) != 0 {
+		t.Errorf("projects tree = %v, want projects alone at depth 1", roots)
+	}
+	if len(ancestors) != 1 || ancestors[0].ID != work.ID {
+		t.Errorf("ancestors = %v, want work", ancestors)
+	}
+
+	if _, _, err := service.GetCategoryTree(ctx, secret.ID, 0); !domain.IsNotFoundError(err) {
+		t.Errorf("GetCategoryTree(secret) error = %v, want not found", err)
+	}
+}
+
+func TestCategoryService_ListCategories_ParentFilter(t *testing.T) {
+	mockCategoryRepo := &recordingCategoryRepository{mockCategoryRepository: newMockCategoryRepository()}
+	service := NewCategoryService(mockCategoryRepo, newMockTaskRepository(), &mockTransactionManager{}, 0, logger.NewLogger("debug"))
+
+	parentID := "parent-1"
+	if _, _, err := service.ListCategories(context.Background(), repository.CategoryListOptions{ParentID: &parentID}); err != nil {
+		t.Fatalf("ListCategories() error = %v", err)
+	}
+	if mockCategoryRepo.opts.ParentID == nil || *mockCategoryRepo.opts.ParentID != parentID {
+		t.Errorf("parent filter = %v, want %s", mockCategoryRepo.opts.ParentID, parentID)
+	}
+}
+
+// recordingCategoryRepository captures the options categories are listed with
+type recordingCategoryRepository struct {
+	*mockCategoryRepository
+	opts repository.CategoryListOptions
+}
+
+func (r *recordingCategoryRepository) List(ctx context.Context, opts repository.CategoryListOptions) ([]*domain.Category, int64, error) {
+	r.opts = opts
+	return r.mockCategoryRepository.List(ctx, opts)
+}
+
+func TestCategoryService_MoveCategory(t *testing.T) {
+	mockCategoryRepo := newMockCategoryRepository()
+	service := NewCategoryService(mockCategoryRepo, newMockTaskRepository(), &mockTransactionManager{}, 0, logger.NewLogger("debug"))
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	work := &domain.Category{Name: "work", IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, work)
+	projects := &domain.Category{Name: "projects", ParentID: &work.ID, IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, projects)
+	home := &domain.Category{Name: "home", IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, home)
+
+	if _, err := service.MoveCategory(ctx, projects.ID, home.ID, projects.Version+1); !domain.IsVersionConflictError(err) {
+		t.Errorf("MoveCategory() with stale version error = %v, want version conflict", err)
+	}
+	if _, err := service.MoveCategory(ctx, work.ID, projects.ID, work.Version); !domain.IsBusinessRuleError(err) {
+		t.Errorf("MoveCategory() under a subcategory error = %v, want business rule violation", err)
+	}
+
+	moved, err := service.MoveCategory(ctx, projects.ID, home.ID, projects.Version)
+	if err != nil {
+		t.Fatalf("MoveCategory() error = %v", err)
+	}
+	if moved.ParentID == nil || *moved.ParentID != home.ID || moved.Version != 2 {
+		t.Errorf("moved category = %+v, want parent %s at version 2", moved, home.ID)
+	}
+
+	moved, err = service.MoveCategory(ctx, projects.ID, "", moved.Version)
+	if err != nil {
+		t.Fatalf("MoveCategory() to top level error = %v", err)
+	}
+	if moved.ParentID != nil {
+		t.Errorf("parent = %v, want top level", *moved.ParentID)
+	}
+
+	if len(mockCategoryRepo.history) != 2 || mockCategoryRepo.history[0].Action != domain.CategoryHistoryActionMoved {
+		t.Errorf("history = %v, want two moves", mockCategoryRepo.history)
+	}
+}
+
+func TestCategoryService_MoveCategory_ConcurrentMove(t *testing.T) {
+	mockCategoryRepo := newMockCategoryRepository()
+	txManager := &mockTransactionManager{}
+	service := NewCategoryService(mockCategoryRepo, newMockTaskRepository(), txManager, 0, logger.NewLogger("debug"))
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	work := &domain.Category{Name: "work", IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, work)
+	home := &domain.Category{Name: "home", IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, home)
+
+	// Work moves under home after this move has loaded home but before it writes
+	txManager.begin = func() { work.ParentID = &home.ID }
+	if _, err := service.MoveCategory(ctx, home.ID, work.ID, home.Version); !domain.IsBusinessRuleError(err) {
+		t.Errorf("MoveCategory() under a category moved beneath it meanwhile error = %v, want business rule violation", err)
+	}
+	if home.ParentID != nil || mockCategoryRepo.hierarchyLocks != 1 {
+		t.Errorf("home parent = %v after %d hierarchy locks, want it unmoved and the check made under the lock", home.ParentID, mockCategoryRepo.hierarchyLocks)
+	}
+}
+
+func TestCategoryService_MergeCategories(t *testing.T) {
+	mockCategoryRepo := newMockCategoryRepository()
+	mockTaskRepo := newMockTaskRepository()
+	mockCategoryRepo.tasks = mockTaskRepo
+	service := NewCategoryService(mockCategoryRepo, mockTaskRepo, &mockTransactionManager{}, 0, logger.NewLogger("debug"))
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	source := &domain.Category{Name: "errands", IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, source)
+	child := &domain.Category{Name: "groceries", ParentID: &source.ID, IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, child)
+	target := &domain.Category{Name: "home", IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, target)
+
+	onlySource := testutil.TestTask("assignee-123")
+	onlySource.Categories = []domain.Category{*source}
+	mockTaskRepo.Create(ctx, onlySource)
+	both := testutil.TestTask("assignee-123")
+	both.Title = "Both categories"
+	both.Categories = []domain.Category{*source, *target}
+	mockTaskRepo.Create(ctx, both)
+
+	if _, _, err := service.MergeCategories(ctx, source.ID, source.Version, source.ID, source.Version); !domain.IsInvalidInputError(err) {
+		t.Errorf("MergeCategories() into itself error = %v, want invalid input", err)
+	}
+	if _, _, err := service.MergeCategories(ctx, source.ID, source.Version, child.ID, child.Version); !domain.IsBusinessRuleError(err) {
+		t.Errorf("MergeCategories() into a subcategory error = %v, want business rule violation", err)
+	}
+	if _, _, err := service.MergeCategories(ctx, source.ID, source.Version, target.ID, target.Version+1); !domain.IsVersionConflictError(err) {
+		t.Errorf("MergeCategories() with stale target version error = %v, want version conflict", err)
+	}
+
+	merged, moved, err := service.MergeCategories(ctx, source.ID, source.Version, target.ID, target.Version)
+	if err != nil {
+		t.Fatalf("MergeCategories() error = %v", err)
+	}
+	if merged.ID != target.ID || moved != 2 {
+		t.Errorf("MergeCategories() = %s, %d tasks; want %s, 2", merged.ID, moved, target.ID)
+	}
+
+	// Tasks are linked to the target exactly once
+	for _, task := range []*domain.Task{onlySource, both} {
+		if len(task.Categories) != 1 || task.Categories[0].ID != target.ID {
+			t.Errorf("task %s categories = %v, want only the target", task.Title, task.Categories)
+		}
+	}
+	if _, err := mockCategoryRepo.GetByID(ctx, source.ID); !domain.IsNotFoundError(err) {
+		t.Errorf("source after merge error = %v, want not found", err)
+	}
+	if child.ParentID == nil || *child.ParentID != target.ID {
+		t.Errorf("subcategory parent = %v, want %s", child.ParentID, target.ID)
+	}
+	if len(mockTaskRepo.history) != 2 || len(mockCategoryRepo.history) != 2 {
+		t.Errorf("history = %d task and %d category entries, want 2 and 2", len(mockTaskRepo.history), len(mockCategoryRepo.history))
+	}
+}
+
+func TestCategoryService_CascadeDeleteAndRestore(t *testing.T) {
+	mockCategoryRepo := newMockCategoryRepository()
+	mockTaskRepo := newMockTaskRepository()
+	service := NewCategoryService(mockCategoryRepo, mockTaskRepo, &mockTransactionManager{}, 0, logger.NewLogger("debug"))
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	work := &domain.Category{Name: "work", IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, work)
+	projects := &domain.Category{Name: "projects", ParentID: &work.ID, IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, projects)
+	alpha := &domain.Category{Name: "alpha", ParentID: &projects.ID, IsPublic: true, CreatorID: "user-1"}
+	mockCategoryRepo.Create(ctx, alpha)
+
+	// A task in a subcategory blocks a cascading delete
+	task := testutil.TestTask("assignee-123")
+	task.Categories = []domain.Category{*alpha}
+	mockTaskRepo.Create(ctx, task)
+	if err := service.DeleteCategory(ctx, work.ID, work.Version, true); !domain.IsBusinessRuleError(err) {
+		t.Fatalf("DeleteCategory() with a used subcategory error = %v, want business rule violation", err)
+	}
+	task.Categories = nil
+
+	if err := service.DeleteCategory(ctx, work.ID, work.Version, true); err != nil {
+		t.Fatalf("DeleteCategory() error = %v", err)
+	}
+	for _, category := range []*domain.Category{work, projects, alpha} {
+		if !category.IsDeleted {
+			t.Errorf("category %s not deleted", category.Name)
+		}
+	}
+	if len(mockCategoryRepo.history) != 3 {
+		t.Errorf("history entries = %d, want one per deleted category", len(mockCategoryRepo.history))
+	}
+
+	restored, err := service.RestoreCategory(ctx, work.ID, work.Version, true)
+	if err != nil {
+		t.Fatalf("RestoreCategory() error = %v", err)
+	}
+	if restored.IsDeleted || projects.IsDeleted || alpha.IsDeleted {
+		t.Errorf("subtree not restored: work=%v projects=%v alpha=%v", restored.IsDeleted, projects.IsDeleted, alpha.IsDeleted)
+	}
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/events"
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+type commentService struct {
+	commentRepo repository.CommentRepository
+	taskRepo    repository.TaskRepository
+	userRepo    repository.UserRepository
+	publisher   events.Publisher
+	logger      logger.Logger
+}
+
+// NewCommentService creates a new task comment service
+func NewCommentService(
+	commentRepo repository.CommentRepository,
+	taskRepo repository.TaskRepository,
+	userRepo repository.UserRepository,
+	publisher events.Publisher,
+	log logger.Logger,
+) CommentService {
+	return &commentService{
+		commentRepo: commentRepo,
+		taskRepo:    taskRepo,
+		userRepo:    userRepo,
+		publisher:   publisher,
+		logger:      log,
+	}
+}
+
+func (s *commentService) CreateComment(ctx context.Context, taskID, body string) (*domain.Comment, error) {
+	s.logger.Info(ctx, "Creating comment", "task_id", taskID)
+
+	authorID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if taskID == "" {
+		return nil, domain.ErrInvalidInput("task ID is required")
+	}
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	comment := &domain.Comment{TaskID: taskID, AuthorID: authorID, Body: body}
+	if err := comment.IsValid(); err != nil {
+		return nil, err
+	}
+	mentioned, err := s.resolveMentions(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		s.logger.Error(ctx, "Failed to create comment", "error", err, "task_id", taskID)
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	s.publishMentions(ctx, comment, mentioned, nil)
+	s.logger.Info(ctx, "Comment created successfully", "comment_id", comment.ID, "mentions", len(mentioned))
+	return comment, nil
+}
+
+func (s *commentService) UpdateComment(ctx context.Context, id, body string, version int64) (*domain.Comment, error) {
+	s.logger.Info(ctx, "Updating comment", "comment_id", id, "version", version)
+
+	callerID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.getComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != callerID {
+		return nil, domain.ErrPermissionDenied("only the author can edit a comment")
+	}
+	if comment.Version != version {
+		return nil, domain.ErrVersionConflict("comment", version, comment.Version)
+	}
+
+	previous := comment.MentionedUserIDs
+	comment.Body = body
+	if err := comment.IsValid(); err != nil {
+		return nil, err
+	}
+	mentioned, err := s.resolveMentions(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.commentRepo.Update(ctx, comment); err != nil {
+		if domain.IsVersionConflictError(err) {
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to update comment", "error", err, "comment_id", id)
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	// Only users the edit mentions for the first time are told about it
+	s.publishMentions(ctx, comment, mentioned, previous)
+	s.logger.Info(ctx, "Comment updated successfully", "comment_id", id)
+	return comment, nil
+}
+
+func (s *commentService) DeleteComment(ctx context.Context, id string, version int64) error {
+	s.logger.Info(ctx, "Deleting comment", "comment_id", id, "version", version)
+
+	callerID, role, err := authenticatedCaller(ctx)
+	if err != nil {
+		return err
+	}
+	comment, err := s.getComment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != callerID && role != domain.UserRoleAdmin {
+		return domain.ErrPermissionDenied("only the author or an admin can delete a comment")
+	}
+
+	if err := s.commentRepo.SoftDelete(ctx, id, version); err != nil {
+		if domain.IsVersionConflictError(err) {
+			return err
+		}
+		s.logger.Error(ctx, "Failed to delete comment", "error", err, "comment_id", id)
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	s.logger.Info(ctx, "Comment deleted successfully", "comment_id", id)
+	return nil
+}
+
+func (s *commentService) ListTaskActivity(ctx context.Context, taskID string, opts repository.ListOptions) ([]*domain.ActivityItem, int64, error) {
+	s.logger.Debug(ctx, "Listing task activity", "task_id", taskID, "page", opts.Page, "page_size", opts.PageSize)
+
+	if taskID == "" {
+		return nil, 0, domain.ErrInvalidInput("task ID is required")
+	}
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, 0, err
+		}
+		return nil, 0, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	items, total, err := s.commentRepo.ListActivity(ctx, taskID, opts)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list task activity", "error", err, "task_id", taskID)
+		return nil, 0, fmt.Errorf("failed to list task activity: %w", err)
+	}
+
+	return items, total, nil
+}
+
+func (s *commentService) getComment(ctx context.Context, id string) (*domain.Comment, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidInput("comment ID is required")
+	}
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return comment, nil
+}
+
+// resolveMentions sets the comment's mentioned users from the @email mentions in its body and
+// returns them by user ID with the address they were mentioned as. Addresses of unknown users
+// and the author's own are ignored.
+func (s *commentService) resolveMentions(ctx context.Context, comment *domain.Comment) (map[string]string, error) {
+	emails := domain.ExtractMentions(comment.Body)
+	if len(emails) > domain.MaxCommentMentions {
+		return nil, domain.ErrInvalidInput(fmt.Sprintf("a comment can mention at most %d users", domain.MaxCommentMentions))
+	}
+
+	comment.MentionedUserIDs = nil
+	mentioned := make(map[string]string)
+	for _, email := range emails {
+		user, err := s.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			if domain.IsNotFoundError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to resolve mention %s: %w", email, err)
+		}
+		if user.ID == comment.AuthorID || mentioned[user.ID] != "" {
+			continue
+		}
+		mentioned[user.ID] = email
+		comment.MentionedUserIDs = append(comment.MentionedUserIDs, user.ID)
+	}
+	return mentioned, nil
+}
+
+// publishMentions publishes a mention event for each mentioned user not among the previous ones
+func (s *commentService) publishMentions(ctx context.Context, comment *domain.Comment, mentioned map[string]string, previous []string) {
+	skip := make(map[string]bool, len(previous))
+	for _, userID := range previous {
+		skip[userID] = true
+	}
+
+	at := time.Now()
+	for _, userID := range comment.MentionedUserIDs {
+		if skip[userID] {
+			continue
+		}
+		s.publisher.Publish(ctx, &domain.UserMentionedEvent{
+			TaskID:          comment.TaskID,
+			CommentID:       comment.ID,
+			AuthorID:        comment.AuthorID,
+			MentionedUserID: userID,
+			Email:           mentioned[userID],
+			At:              at,
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/testutil"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+type mockCommentRepository struct {
+	comments map[string]*domain.Comment
+	tasks    *mockTaskRepository
+	nextID   int
+}
+
+func newMockCommentRepository(tasks *mockTaskRepository) *mockCommentRepository {
+	return &mockCommentRepository{comments: make(map[string]*domain.Comment), tasks: tasks}
+}
+
+func (m *mockCommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	m.nextID++
+	comment.ID = fmt.Sprintf("comment-%d", m.nextID)
+	// Comments are a second apart so the activity order is deterministic
+	comment.CreatedAt = time.Date(2026, time.March, 2, 9, 0, m.nextID, 0, time.UTC)
+	comment.UpdatedAt = comment.CreatedAt
+	comment.Version = 1
+	stored := *comment
+	m.comments[comment.ID] = &stored
+	return nil
+}
+
+func (m *mockCommentRepository) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	stored, ok := m.comments[id]
+	if !ok || stored.IsDeleted {
+		return nil, domain.ErrNotFound("comment")
+	}
+	comment := *stored
+	return &comment, nil
+}
+
+func (m *mockCommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	stored, ok := m.comments[comment.ID]
+	if !ok || stored.IsDeleted || stored.Version != comment.Version {
+		return domain.ErrVersionConflict("comment", comment.Version, comment.Version+1)
+	}
+	now := time.Now()
+	comment.Version++
+	comment.UpdatedAt = now
+	comment.EditedAt = &now
+	updated := *comment
+	m.comments[comment.ID] = &updated
+	return nil
+}
+
+func (m *mockCommentRepository) SoftDelete(ctx context.Context, id string, version int64) error {
+	stored, ok := m.comments[id]
+	if !ok || stored.IsDeleted || stored.Version != version {
+		return domain.ErrVersionConflict("comment", version, version+1)
+	}
+	stored.IsDeleted = true
+	stored.Version++
+	return nil
+}
+
+func (m *mockCommentRepository) ListActivity(ctx context.Context, taskID string, opts repository.ListOptions) ([]*domain.ActivityItem, int64, error) {
+	var items []*domain.ActivityItem
+	for _, entry := range m.tasks.history {
+		if entry.TaskID == taskID {
+			items = append(items, &domain.ActivityItem{Timestamp: entry.Timestamp, History: entry})
+		}
+	}
+	for _, comment := range m.comments {
+		if comment.TaskID == taskID && !comment.IsDeleted {
+			items = append(items, &domain.ActivityItem{Timestamp: comment.CreatedAt, Comment: comment})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].Timestamp.Equal(items[j].Timestamp) {
+			return items[i].Timestamp.Before(items[j].Timestamp)
+		}
+		return items[i].History != nil && items[j].History == nil
+	})
+
+	total := int64(len(items))
+	start := int(opts.Page * opts.PageSize)
+	if start > len(items) {
+		start = len(items)
+	}
+	end := len(items)
+	if opts.PageSize > 0 && start+int(opts.PageSize) < end {
+		end = start + int(opts.PageSize)
+	}
+	return items[start:end], total, nil
+}
+
+// recordingPublisher keeps the events published to it
+type recordingPublisher struct {
+	events []domain.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event domain.Event) {
+	p.events = append(p.events, event)
+}
+
+func (p *recordingPublisher) mentionedUsers() []string {
+	var users []string
+	for _, event := range p.events {
+		users = append(users, event.(*domain.UserMentionedEvent).MentionedUserID)
+	}
+	return users
+}
+
+type commentTestEnv struct {
+	service   CommentService
+	tasks     *mockTaskRepository
+	comments  *mockCommentRepository
+	publisher *recordingPublisher
+	author    *domain.User
+	other     *domain.User
+	admin     *domain.User
+	task      *domain.Task
+}
+
+func setupCommentTest(t *testing.T) *commentTestEnv {
+	t.Helper()
+	ctx := context.Background()
+	env := &commentTestEnv{tasks: newMockTaskRepository(), publisher: &recordingPublisher{}}
+	env.comments = newMockCommentRepository(env.tasks)
+	userRepo := newMockUserRepository()
+	env.service = NewCommentService(env.comments, env.tasks, userRepo, env.publisher, logger.NewLogger("error"))
+
+	env.author, env.other, env.admin = testutil.TestUser(), testutil.TestUser(), testutil.TestAdminUser()
+	env.author.Email, env.other.Email, env.admin.Email = "author@example.com", "bob@example.com", "admin@example.com"
+	for _, user := range []*domain.User{env.author, env.other, env.admin} {
+		userRepo.Create(ctx, user)
+	}
+
+	env.task = testutil.TestTask(env.author.ID)
+	env.tasks.Create(ctx, env.task)
+	return env
+}
+
+func asUser(user *domain.User) context.Context {
+	return requestctx.WithUser(context.Background(), user.ID, string(user.Role))
+}
+
+func TestCommentService_CreateComment(t *testing.T) {
+	env := setupCommentTest(t)
+	ctx := asUser(env.author)
+
+	t.Run("author from the caller", func(t *testing.T) {
+		comment, err := env.service.CreateComment(ctx, env.task.ID, "Looks good, @bob@example.com please review. cc @nobody@example.com")
+		if err != nil {
+			t.Fatalf("CreateComment() error = %v", err)
+		}
+		if comment.AuthorID != env.author.ID || comment.TaskID != env.task.ID || comment.Version != 1 {
+			t.Errorf("comment = %+v", comment)
+		}
+		// Unknown addresses are not mentions
+		if len(comment.MentionedUserIDs) != 1 || comment.MentionedUserIDs[0] != env.other.ID {
+			t.Errorf("mentions = %v, want %s", comment.MentionedUserIDs, env.other.ID)
+		}
+		event, ok := env.publisher.events[0].(*domain.UserMentionedEvent)
+		if len(env.publisher.events) != 1 || !ok || event.CommentID != comment.ID || event.AuthorID != env.author.ID || event.Email != "bob@example.com" {
+			t.Errorf("events = %+v, want one mention of bob", env.publisher.events)
+		}
+	})
+
+	t.Run("self mentions are ignored", func(t *testing.T) {
+		env.publisher.events = nil
+		comment, err := env.service.CreateComment(ctx, env.task.ID, "Note to @author@example.com")
+		if err != nil || len(comment.MentionedUserIDs) != 0 || len(env.publisher.events) != 0 {
+			t.Errorf("CreateComment() = %+v, %v with %d events, want no mentions", comment, err, len(env.publisher.events))
+		}
+	})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		taskID  string
+		body    string
+		checkFn func(error) bool
+	}{
+		{"unauthenticated", context.Background(), env.task.ID, "hello", isUnauthorizedError},
+		{"empty body", ctx, env.task.ID, "   ", domain.IsInvalidInputError},
+		{"missing task", ctx, "missing", "hello", domain.IsNotFoundError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.service.CreateComment(tt.ctx, tt.taskID, tt.body); !tt.checkFn(err) {
+				t.Errorf("CreateComment() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCommentService_UpdateComment(t *testing.T) {
+	env := setupCommentTest(t)
+	ctx := asUser(env.author)
+
+	comment, err := env.service.CreateComment(ctx, env.task.ID, "Ping @bob@example.com")
+	if err != nil {
+		t.Fatalf("CreateComment() error = %v", err)
+	}
+
+	if _, err := env.service.UpdateComment(asUser(env.other), comment.ID, "hijacked", comment.Version); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("UpdateComment() by someone else error = %v, want permission denied", err)
+	}
+	if _, err := env.service.UpdateComment(ctx, comment.ID, "stale", comment.Version+1); !domain.IsVersionConflictError(err) {
+		t.Errorf("UpdateComment() with a stale version error = %v, want version conflict", err)
+	}
+
+	// bob was already mentioned, so only the admin is told about the edit
+	env.publisher.events = nil
+	updated, err := env.service.UpdateComment(ctx, comment.ID, "Ping @bob@example.com and @admin@example.com", comment.Version)
+	if err != nil {
+		t.Fatalf("UpdateComment() error = %v", err)
+	}
+	if updated.Version != 2 || updated.EditedAt == nil || len(updated.MentionedUserIDs) != 2 {
+		t.Errorf("updated = %+v, want an edited comment mentioning two users", updated)
+	}
+	if users := env.publisher.mentionedUsers(); len(users) != 1 || users[0] != env.admin.ID {
+		t.Errorf("mentioned = %v, want only %s", users, env.admin.ID)
+	}
+}
+
+func TestCommentService_DeleteComment(t *testing.T) {
+	env := setupCommentTest(t)
+	ctx := asUser(env.author)
+
+	first, _ := env.service.CreateComment(ctx, env.task.ID, "first")
+	second, _ := env.service.CreateComment(ctx, env.task.ID, "second")
+
+	if err := env.service.DeleteComment(asUser(env.other), first.ID, first.Version); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("DeleteComment() by someone else error = %v, want permission denied", err)
+	}
+	if err := env.service.DeleteComment(ctx, first.ID, first.Version); err != nil {
+		t.Errorf("DeleteComment() by the author error = %v", err)
+	}
+	if err := env.service.DeleteComment(asUser(env.admin), second.ID, second.Version); err != nil {
+		t.Errorf("DeleteComment() by an admin error = %v", err)
+	}
+	if _, err := env.service.UpdateComment(ctx, first.ID, "edited", first.Version); !domain.IsNotFoundError(err) {
+		t.Errorf("UpdateComment() of a deleted comment error = %v, want not found", err)
+	}
+}
+
+func TestCommentService_ListTaskActivity(t *testing.T) {
+	env := setupCommentTest(t)
+	ctx := asUser(env.author)
+
+	at := func(second int) time.Time { return time.Date(2026, time.March, 2, 9, 0, second, 0, time.UTC) }
+	env.tasks.history = []*domain.TaskHistory{
+		{ID: "created", TaskID: env.task.ID, Action: domain.TaskHistoryActionCreated, Timestamp: at(0)},
+		{ID: "assigned", TaskID: env.task.ID, Action: domain.TaskHistoryActionAssigned, Timestamp: at(2)},
+		{ID: "elsewhere", TaskID: "other-task", Action: domain.TaskHistoryActionCreated, Timestamp: at(1)},
+	}
+	// The mock repository writes comments at 09:00:01, 09:00:02 and 09:00:03
+	for _, body := range []string{"one", "two", "three"} {
+		if _, err := env.service.CreateComment(ctx, env.task.ID, body); err != nil {
+			t.Fatalf("CreateComment() error = %v", err)
+		}
+	}
+	deleted, _ := env.service.CreateComment(ctx, env.task.ID, "gone")
+	env.service.DeleteComment(ctx, deleted.ID, deleted.Version)
+
+	items, total, err := env.service.ListTaskActivity(ctx, env.task.ID, repository.ListOptions{PageSize: 3, Page: 1})
+	if err != nil {
+		t.Fatalf("ListTaskActivity() error = %v", err)
+	}
+	if total != 5 || len(items) != 2 {
+		t.Fatalf("ListTaskActivity() = %d items of %d, want the last 2 of 5", len(items), total)
+	}
+	// A change and a comment at the same instant list the change first
+	first, _, _ := env.service.ListTaskActivity(ctx, env.task.ID, repository.ListOptions{PageSize: 3})
+	if first[0].History == nil || first[1].Comment == nil || first[1].Comment.Body != "one" || first[2].History == nil || first[2].History.ID != "assigned" {
+		t.Errorf("first page = %+v, want created, one, assigned", first)
+	}
+	if items[0].Comment.Body != "two" || items[1].Comment.Body != "three" {
+		t.Errorf("second page = %+v, want two, three", items)
+	}
+
+	if _, _, err := env.service.ListTaskActivity(ctx, "missing", repository.ListOptions{}); !domain.IsNotFoundError(err) {
+		t.Errorf("ListTaskActivity() of a missing task error = %v, want not found", err)
+	}
+}
+
+func isUnauthorizedError(err error) bool {
+	domainErr, ok := err.(domain.DomainError)
+	return ok && domainErr.Type == "UNAUTHORIZED"
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository"
@@ -29,9 +31,13 @@ type TaskService interface {
 	CreateTask(ctx context.Context, task *domain.Task) (*domain.Task, error)
 	GetTaskByID(ctx context.Context, id string) (*domain.Task, error)
 	UpdateTask(ctx context.Context, task *domain.Task) (*domain.Task, error)
-	DeleteTask(ctx context.Context, id string, version int64) error
+	DeleteTask(ctx context.Context, id string, version int64, cascade bool) error
 	RestoreTask(ctx context.Context, id string, version int64) (*domain.Task, error)
 	ListTasks(ctx context.Context, opts repository.TaskListOptions) ([]*domain.Task, int64, error)
+	SearchTasks(ctx context.Context, opts repository.TaskListOptions) ([]*domain.TaskSearchResult, int64, error)
+	// GetTaskFacets counts the tasks matching opts by each facet (all of them when none
+	// are given). Each facet's own filter is ignored for its counts.
+	GetTaskFacets(ctx context.Context, opts repository.TaskListOptions, facets []domain.TaskFacet) ([]*domain.FacetCounts, error)
 
 	// Business logic methods
 	AssignTask(ctx context.Context, taskID, assigneeID string, version int64) (*domain.Task, error)
@@ -42,6 +48,71 @@ type TaskService interface {
 	AddTaskTags(ctx context.Context, taskID string, tagIDs []string, version int64) (*domain.Task, error)
 	RemoveTaskTags(ctx context.Context, taskID string, tagIDs []string, version int64) (*domain.Task, error)
 	GetTaskHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error)
+
+	// Participants. Roles users already hold are left alone when adding. A task always keeps an
+	// assignee; removing the primary assignee hands the task to the longest-standing other one.
+	AddTaskParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) (*domain.Task, error)
+	RemoveTaskParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) (*domain.Task, error)
+
+	// Subtasks. MoveTask with an empty parent makes the task a top-level task. ListSubtasks
+	// returns the parent with only its direct subtasks as children; progress covers every level.
+	MoveTask(ctx context.Context, id, parentID string, version int64) (*domain.Task, error)
+	ListSubtasks(ctx context.Context, parentID string) (*domain.TaskNode, error)
+	GetTaskTree(ctx context.Context, rootID string) (*domain.TaskNode, error)
+
+	// Dependencies. A task cannot start or complete while any of its blockers is open.
+	AddTaskDependency(ctx context.Context, taskID, blockerID string) (*domain.TaskDependency, error)
+	RemoveTaskDependency(ctx context.Context, taskID, blockerID string) error
+	ListTaskBlockers(ctx context.Context, taskID string) ([]*domain.Task, error)
+	ListTaskDependents(ctx context.Context, taskID string) ([]*domain.Task, error)
+	GetCriticalPath(ctx context.Context, taskIDs []string) (*domain.CriticalPath, error)
+
+	// Recurring tasks. UpdateTask edits a single instance; UpdateTaskSeries edits the rule and
+	// every open instance. Completing the latest instance generates the next one.
+	SetTaskRecurrence(ctx context.Context, taskID string, rule *domain.RecurrenceRule, version int64) (*domain.Task, *domain.TaskSeries, error)
+	GetTaskSeries(ctx context.Context, seriesID string) (*domain.TaskSeries, []*domain.Task, error)
+	UpdateTaskSeries(ctx context.Context, seriesID string, changes domain.TaskSeriesChanges, version int64) (*domain.TaskSeries, []*domain.Task, error)
+	StopTaskSeries(ctx context.Context, seriesID string, version int64) (*domain.TaskSeries, error)
+	// GenerateRecurringTasks creates the next instance of series whose latest instance is due by now
+	// and returns how many it created
+	GenerateRecurringTasks(ctx context.Context, now time.Time) (int, error)
+
+	// Bulk operations select tasks by reference or filter and report per-task results
+	BulkUpdateTasks(ctx context.Context, selection repository.BulkTaskSelection, changes domain.TaskChanges, mode domain.BulkMode) (*domain.BulkResult, error)
+	// With cascade, deleting a task also deletes its subtasks, and restoring it restores the
+	// subtasks deleted together with it
+	BulkDeleteTasks(ctx context.Context, selection repository.BulkTaskSelection, mode domain.BulkMode, cascade bool) (*domain.BulkResult, error)
+	BulkRestoreTasks(ctx context.Context, selection repository.BulkTaskSelection, mode domain.BulkMode, cascade bool) (*domain.BulkResult, error)
+}
+
+// TaskImportService defines the business logic for importing tasks from files
+type TaskImportService interface {
+	// ImportTasks parses src and reports the outcome of every row. Unless it is a dry
+	// run, valid rows are committed in batches and a failed import can be resumed.
+	ImportTasks(ctx context.Context, src io.Reader, opts domain.ImportOptions) (*domain.ImportReport, error)
+}
+
+// TaskExportService defines the business logic for exporting tasks
+type TaskExportService interface {
+	// ExportTasks streams every task matching filter to w and returns how many were
+	// written. Options are validated before any output is produced.
+	ExportTasks(ctx context.Context, filter repository.TaskListOptions, opts domain.ExportOptions, w io.Writer) (int, error)
+}
+
+// SavedViewService defines the business logic for saved task views. Callers must be
+// authenticated; they see their own views and those shared with their role.
+type SavedViewService interface {
+	CreateView(ctx context.Context, view *domain.SavedView) (*domain.SavedView, error)
+	GetView(ctx context.Context, id string) (*domain.SavedView, error)
+	UpdateView(ctx context.Context, view *domain.SavedView) (*domain.SavedView, error)
+	DeleteView(ctx context.Context, id string, version int64) error
+	ListViews(ctx context.Context, opts repository.ListOptions) ([]*domain.SavedView, int64, error)
+
+	// ListTasks lists the tasks matching the view's filter and sort, resolving its
+	// query for the caller. The view is returned with its stale flags.
+	ListTasks(ctx context.Context, viewID string, page, pageSize int32) ([]*domain.Task, int64, *domain.SavedView, error)
+	// GetTaskFacets counts the view's tasks by each facet, like TaskService.GetTaskFacets
+	GetTaskFacets(ctx context.Context, viewID string, facets []domain.TaskFacet) ([]*domain.FacetCounts, error)
 }
 
 // CategoryService defines the business logic for category operations
@@ -50,9 +121,20 @@ type CategoryService interface {
 	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
 	GetCategoryByID(ctx context.Context, id string) (*domain.Category, error)
 	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
-	DeleteCategory(ctx context.Context, id string, version int64) error
-	RestoreCategory(ctx context.Context, id string, version int64) (*domain.Category, error)
-	ListCategories(ctx context.Context, opts repository.ListOptions) ([]*domain.Category, int64, error)
+	// DeleteCategory with cascade also deletes every subcategory; RestoreCategory with cascade
+	// restores the subcategories that were deleted together with the category
+	DeleteCategory(ctx context.Context, id string, version int64, cascade bool) error
+	RestoreCategory(ctx context.Context, id string, version int64, cascade bool) (*domain.Category, error)
+	ListCategories(ctx context.Context, opts repository.CategoryListOptions) ([]*domain.Category, int64, error)
+
+	// Category hierarchy. GetCategoryTree returns the subtree under rootID (every top-level
+	// category when empty) and the root's ancestors, nearest first.
+	GetCategoryTree(ctx context.Context, rootID string, maxDepth int) ([]*domain.CategoryNode, []*domain.Category, error)
+	// MoveCategory re-parents a category with its subtree; an empty parentID moves it to the top level
+	MoveCategory(ctx context.Context, id, parentID string, version int64) (*domain.Category, error)
+	// MergeCategories moves the source's tasks and subcategories to the target, deletes the
+	// source and returns the target with the number of tasks moved
+	MergeCategories(ctx context.Context, sourceID string, sourceVersion int64, targetID string, targetVersion int64) (*domain.Category, int, error)
 
 	// Business logic methods
 	ValidateCategoryUsage(ctx context.Context, categoryID string) error
@@ -73,12 +155,91 @@ type TagService interface {
 	ValidateTagUsage(ctx context.Context, tagID string) error
 	GetTagTaskCount(ctx context.Context, tagID string) (int64, error)
 	FindOrCreateTag(ctx context.Context, name string) (*domain.Tag, error)
+
+	// Deduplication. MergeTags moves the sources' tasks and synonyms to the canonical tag,
+	// deletes the sources and keeps their names as synonyms. These operations are admin only,
+	// except listing synonyms.
+	MergeTags(ctx context.Context, canonical domain.TagRef, sources []domain.TagRef) (*domain.Tag, int, error)
+	AddTagSynonym(ctx context.Context, tagID, synonym string) (*domain.TagSynonym, error)
+	RemoveTagSynonym(ctx context.Context, synonym string) error
+	ListTagSynonyms(ctx context.Context, tagID string) ([]*domain.TagSynonym, error)
+	GetDuplicateTagReport(ctx context.Context, limit int) ([]*domain.TagDuplicate, error)
+
+	// SuggestTags returns the tags visible to the caller that best complete a type-ahead query.
+	// When the latency budget runs out it returns no suggestions and timedOut instead of an error.
+	SuggestTags(ctx context.Context, query string, limit int) (suggestions []*domain.TagSuggestion, timedOut bool, err error)
+}
+
+// WorkflowService defines workflow business operations. Creating, changing and deleting
+// workflows is limited to administrators.
+type WorkflowService interface {
+	CreateWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error)
+	GetWorkflow(ctx context.Context, id string) (*domain.Workflow, error)
+	// ListWorkflows also returns the workflow tasks follow when their categories have none
+	ListWorkflows(ctx context.Context, opts repository.ListOptions) ([]*domain.Workflow, int64, *domain.Workflow, error)
+	UpdateWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error)
+	DeleteWorkflow(ctx context.Context, id string, version int64) error
+	// SetCategoryWorkflow sets the workflow of a category; an empty ID makes it use its parent's
+	SetCategoryWorkflow(ctx context.Context, categoryID, workflowID string, version int64) (*domain.Category, error)
+}
+
+// CommentService defines the business logic for task comments. Comments are written by the
+// authenticated caller, who alone can edit them; admins can also delete them. Existing users
+// mentioned as @email are published as events.
+type CommentService interface {
+	CreateComment(ctx context.Context, taskID, body string) (*domain.Comment, error)
+	UpdateComment(ctx context.Context, id, body string, version int64) (*domain.Comment, error)
+	DeleteComment(ctx context.Context, id string, version int64) error
+	// ListTaskActivity pages through a task's history and comments merged oldest first
+	ListTaskActivity(ctx context.Context, taskID string, opts repository.ListOptions) ([]*domain.ActivityItem, int64, error)
+}
+
+// AttachmentService defines the business logic for task attachments. Uploads are charged to
+// the authenticated caller's quota and checked against the attachment limits; the uploader or
+// an admin can delete them. Content is streamed to and from the blob store.
+type AttachmentService interface {
+	// UploadAttachment stores exactly upload.SizeBytes bytes read from content
+	UploadAttachment(ctx context.Context, upload domain.AttachmentUpload, content io.Reader) (*domain.Attachment, error)
+	// OpenAttachment returns an attachment with its content, which the caller must close
+	OpenAttachment(ctx context.Context, id string) (*domain.Attachment, io.ReadCloser, error)
+	ListAttachments(ctx context.Context, taskID string) ([]*domain.Attachment, error)
+	DeleteAttachment(ctx context.Context, id string, version int64) error
+	// GetUsage reports the caller's attachment usage against their quota
+	GetUsage(ctx context.Context) (*domain.AttachmentUsage, error)
+	// CollectGarbage deletes the content of attachments whose tasks were purged and returns
+	// how many blobs it deleted
+	CollectGarbage(ctx context.Context) (int, error)
+}
+
+// NotificationService defines what the authenticated caller watches and how they are notified,
+// and the dispatcher that turns task activity into notifications. Watchers of a task, watchers
+// of its categories and their parent categories, and its assignee are notified.
+type NotificationService interface {
+	WatchTask(ctx context.Context, taskID string) error
+	UnwatchTask(ctx context.Context, taskID string) error
+	WatchCategory(ctx context.Context, categoryID string) error
+	UnwatchCategory(ctx context.Context, categoryID string) error
+	ListWatches(ctx context.Context) (*domain.Watches, error)
+	GetSettings(ctx context.Context) (*domain.NotificationSettings, error)
+	// UpdateSettings replaces the caller's settings if the version matches
+	UpdateSettings(ctx context.Context, settings *domain.NotificationSettings) (*domain.NotificationSettings, error)
+	// DispatchNotifications queues notifications for the activity and due dates since the last
+	// run and for the digests that fell due, then delivers the queued notifications that are
+	// due at now. It returns how many it delivered.
+	DispatchNotifications(ctx context.Context, now time.Time) (int, error)
 }
 
 // Services aggregates all service interfaces
 type Services struct {
-	User     UserService
-	Task     TaskService
-	Category CategoryService
-	Tag      TagService
+	User         UserService
+	Task         TaskService
+	Category     CategoryService
+	Tag          TagService
+	Import       TaskImportService
+	Export       TaskExportService
+	SavedView    SavedViewService
+	Workflow     WorkflowService
+	Comment      CommentService
+	Attachment   AttachmentService
+	Notification NotificationService
 }
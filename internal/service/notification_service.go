@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/notify"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+const (
+	// activityCursor names the cursor tracking how far task activity has been dispatched
+	activityCursor = "task_activity"
+	// activitySettleDelay keeps the dispatcher behind the newest activity, so a change whose
+	// transaction commits a little after it was timestamped is not skipped
+	activitySettleDelay = time.Minute
+	// notificationBatchSize bounds the notifications delivered per transaction
+	notificationBatchSize = 100
+)
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+	taskRepo         repository.TaskRepository
+	categoryRepo     repository.CategoryRepository
+	txManager        repository.TransactionManager
+	notifier         notify.Notifier
+	rules            domain.NotificationRules
+	logger           logger.Logger
+}
+
+// NewNotificationService creates a new watcher and notification service
+func NewNotificationService(
+	notificationRepo repository.NotificationRepository,
+	taskRepo repository.TaskRepository,
+	categoryRepo repository.CategoryRepository,
+	txManager repository.TransactionManager,
+	notifier notify.Notifier,
+	rules domain.NotificationRules,
+	log logger.Logger,
+) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		taskRepo:         taskRepo,
+		categoryRepo:     categoryRepo,
+		txManager:        txManager,
+		notifier:         notifier,
+		rules:            rules,
+		logger:           log,
+	}
+}
+
+func (s *notificationService) WatchTask(ctx context.Context, taskID string) error {
+	s.logger.Info(ctx, "Watching task", "task_id", taskID)
+
+	userID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if taskID == "" {
+		return domain.ErrInvalidInput("task ID is required")
+	}
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		if domain.IsNotFoundError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if err := s.notificationRepo.WatchTask(ctx, userID, taskID); err != nil {
+		s.logger.Error(ctx, "Failed to watch task", "error", err, "task_id", taskID)
+		return fmt.Errorf("failed to watch task: %w", err)
+	}
+	return nil
+}
+
+func (s *notificationService) UnwatchTask(ctx context.Context, taskID string) error {
+	s.logger.Info(ctx, "Unwatching task", "task_id", taskID)
+
+	userID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if taskID == "" {
+		return domain.ErrInvalidInput("task ID is required")
+	}
+
+	if err := s.notificationRepo.UnwatchTask(ctx, userID, taskID); err != nil {
+		s.logger.Error(ctx, "Failed to unwatch task", "error", err, "task_id", taskID)
+		return fmt.Errorf("failed to unwatch task: %w", err)
+	}
+	return nil
+}
+
+func (s *notificationService) WatchCategory(ctx context.Context, categoryID string) error {
+	s.logger.Info(ctx, "Watching category", "category_id", categoryID)
+
+	userID, role, err := authenticatedCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if categoryID == "" {
+		return domain.ErrInvalidInput("category ID is required")
+	}
+	category, err := s.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	// Private categories of other users are not revealed
+	if !category.VisibleTo(userID, role) {
+		return domain.ErrNotFound("category")
+	}
+
+	if err := s.notificationRepo.WatchCategory(ctx, userID, categoryID); err != nil {
+		s.logger.Error(ctx, "Failed to watch category", "error", err, "category_id", categoryID)
+		return fmt.Errorf("failed to watch category: %w", err)
+	}
+	return nil
+}
+
+func (s *notificationService) UnwatchCategory(ctx context.Context, categoryID string) error {
+	s.logger.Info(ctx, "Unwatching category", "category_id", categoryID)
+
+	userID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if categoryID == "" {
+		return domain.ErrInvalidInput("category ID is required")
+	}
+
+	if err := s.notificationRepo.UnwatchCategory(ctx, userID, categoryID); err != nil {
+		s.logger.Error(ctx, "Failed to unwatch category", "error", err, "category_id", categoryID)
+		return fmt.Errorf("failed to unwatch category: %w", err)
+	}
+	return nil
+}
+
+func (s *notificationService) ListWatches(ctx context.Context) (*domain.Watches, error) {
+	userID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	watches, err := s.notificationRepo.ListWatches(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watches: %w", err)
+	}
+	return watches, nil
+}
+
+func (s *notificationService) GetSettings(ctx context.Context) (*domain.NotificationSettings, error) {
+	userID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.notificationRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *notificationService) UpdateSettings(ctx context.Context, settings *domain.NotificationSettings) (*domain.NotificationSettings, error) {
+	s.logger.Info(ctx, "Updating notification settings", "version", settings.Version)
+
+	userID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	settings.UserID = userID
+	if settings.TimeZone == "" {
+		settings.TimeZone = "UTC"
+	}
+	if err := settings.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := s.notificationRepo.SaveSettings(ctx, settings); err != nil {
+		if domain.IsVersionConflictError(err) {
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to save notification settings", "error", err)
+		return nil, fmt.Errorf("failed to save notification settings: %w", err)
+	}
+
+	s.logger.Info(ctx, "Notification settings updated", "version", settings.Version)
+	return settings, nil
+}
+
+func (s *notificationService) DispatchNotifications(ctx context.Context, now time.Time) (int, error) {
+	run := &notificationRun{
+		now:      now,
+		settings: make(map[string]*domain.NotificationSettings),
+		watchers: make(map[string][]string),
+		tasks:    make(map[string]*domain.Task),
+	}
+
+	// The activity cursor stays locked while notifications are queued, so service instances
+	// dispatching at the same time take turns instead of queueing everything twice
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.queueActivity(txCtx, run); err != nil {
+			return err
+		}
+		if err := s.queueDueSoon(txCtx, run); err != nil {
+			return err
+		}
+		return s.queueDigests(txCtx, run)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue notifications: %w", err)
+	}
+
+	return s.deliver(ctx, now)
+}
+
+// notificationRun caches what a dispatcher run looks up repeatedly
+type notificationRun struct {
+	now      time.Time
+	settings map[string]*domain.NotificationSettings
+	watchers map[string][]string
+	tasks    map[string]*domain.Task
+}
+
+// queueActivity queues the immediate notifications for the status changes, reassignments
+// and comments made since the last run
+func (s *notificationService) queueActivity(ctx context.Context, run *notificationRun) error {
+	to := run.now.Add(-activitySettleDelay)
+	// The first run starts from now rather than notifying of all history
+	from, err := s.notificationRepo.LockCursor(ctx, activityCursor, to)
+	if err != nil {
+		return err
+	}
+	if !to.After(from) {
+		return nil
+	}
+
+	activity, err := s.notificationRepo.ListActivity(ctx, from, to, nil)
+	if err != nil {
+		return err
+	}
+	for _, entry := range activity {
+		items, err := s.activityItems(ctx, run, entry)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := s.queueImmediate(ctx, run, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.notificationRepo.SetCursor(ctx, activityCursor, to)
+}
+
+// queueDueSoon queues the immediate notifications for tasks whose due date came close
+func (s *notificationService) queueDueSoon(ctx context.Context, run *notificationRun) error {
+	if s.rules.DueSoon <= 0 {
+		return nil
+	}
+
+	tasks, err := s.notificationRepo.ClaimDueSoon(ctx, run.now, run.now.Add(s.rules.DueSoon))
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if err := s.queueImmediate(ctx, run, domain.DueSoonNotificationItem(task, run.now)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueImmediate queues an item for everyone notified of its task who wants it right away,
+// except whoever caused it
+func (s *notificationService) queueImmediate(ctx context.Context, run *notificationRun, item domain.NotificationItem) error {
+	watchers, ok := run.watchers[item.TaskID]
+	if !ok {
+		var err error
+		if watchers, err = s.notificationRepo.Watchers(ctx, item.TaskID); err != nil {
+			return err
+		}
+		run.watchers[item.TaskID] = watchers
+	}
+
+	var queued []*domain.Notification
+	seen := map[string]bool{item.ActorID: true}
+	for _, userID := range append(append([]string(nil), watchers...), item.Involved...) {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		settings, err := s.settingsFor(ctx, run, userID)
+		if err != nil {
+			return err
+		}
+		for _, channel := range domain.NotificationChannels {
+			if settings.Mode(item.Event, channel) != domain.NotificationImmediate {
+				continue
+			}
+			queued = append(queued, &domain.Notification{
+				UserID:       userID,
+				Channel:      channel,
+				Items:        []domain.NotificationItem{item},
+				CreatedAt:    run.now,
+				DeliverAfter: settings.DeliverAfter(run.now),
+			})
+		}
+	}
+	if len(queued) == 0 {
+		return nil
+	}
+	return s.notificationRepo.Enqueue(ctx, queued)
+}
+
+// queueDigests assembles the daily digests that fell due since the last run. A digest covers
+// the day up to the digest hour: the history and comments of the tasks the user is notified
+// of, and those tasks coming due within DueSoon.
+func (s *notificationService) queueDigests(ctx context.Context, run *notificationRun) error {
+	userIDs, err := s.notificationRepo.ListNotifiedUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		settings, err := s.settingsFor(ctx, run, userID)
+		if err != nil {
+			return err
+		}
+		slot := settings.DigestSlot(run.now, s.rules.DigestHour)
+
+		var watched []string
+		watchedLoaded := false
+		for _, channel := range domain.NotificationChannels {
+			if !settings.UsesDigest(channel) {
+				continue
+			}
+			lastSent, err := s.notificationRepo.LastDigest(ctx, userID, channel)
+			if err != nil {
+				return err
+			}
+			if lastSent != nil && !lastSent.Before(slot) {
+				continue
+			}
+			from := slot.AddDate(0, 0, -1)
+			if lastSent != nil && lastSent.After(from) {
+				from = *lastSent
+			}
+
+			if !watchedLoaded {
+				if watched, err = s.notificationRepo.WatchedTaskIDs(ctx, userID); err != nil {
+					return err
+				}
+				watchedLoaded = true
+			}
+			items, err := s.digestItems(ctx, run, settings, channel, watched, from, slot)
+			if err != nil {
+				return err
+			}
+			if len(items) > 0 {
+				digest := &domain.Notification{
+					UserID:       userID,
+					Channel:      channel,
+					Digest:       true,
+					Items:        items,
+					CreatedAt:    run.now,
+					DeliverAfter: settings.DeliverAfter(run.now),
+				}
+				if err := s.notificationRepo.Enqueue(ctx, []*domain.Notification{digest}); err != nil {
+					return err
+				}
+			}
+			if err := s.notificationRepo.MarkDigestSent(ctx, userID, channel, slot); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *notificationService) digestItems(
+	ctx context.Context,
+	run *notificationRun,
+	settings *domain.NotificationSettings,
+	channel domain.NotificationChannel,
+	watched []string,
+	from, to time.Time,
+) ([]domain.NotificationItem, error) {
+	if len(watched) == 0 {
+		return nil, nil
+	}
+
+	activity, err := s.notificationRepo.ListActivity(ctx, from, to, watched)
+	if err != nil {
+		return nil, err
+	}
+	var items []domain.NotificationItem
+	for _, entry := range activity {
+		entryItems, err := s.activityItems(ctx, run, entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range entryItems {
+			if item.ActorID != settings.UserID && settings.Mode(item.Event, channel) == domain.NotificationDigest {
+				items = append(items, item)
+			}
+		}
+	}
+
+	if s.rules.DueSoon > 0 && settings.Mode(domain.NotificationDueSoon, channel) == domain.NotificationDigest {
+		due, err := s.notificationRepo.ListDueTasks(ctx, watched, run.now, run.now.Add(s.rules.DueSoon))
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range due {
+			items = append(items, domain.DueSoonNotificationItem(task, run.now))
+		}
+	}
+	return items, nil
+}
+
+// activityItems returns the notification items of a history entry or comment; activity of
+// tasks deleted meanwhile has none
+func (s *notificationService) activityItems(ctx context.Context, run *notificationRun, entry *domain.ActivityItem) ([]domain.NotificationItem, error) {
+	taskID := ""
+	switch {
+	case entry.History != nil:
+		taskID = entry.History.TaskID
+	case entry.Comment != nil:
+		taskID = entry.Comment.TaskID
+	default:
+		return nil, nil
+	}
+
+	task, ok := run.tasks[taskID]
+	if !ok {
+		var err error
+		task, err = s.taskRepo.GetByID(ctx, taskID)
+		if err != nil && !domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		run.tasks[taskID] = task
+	}
+	if task == nil {
+		return nil, nil
+	}
+
+	if entry.History != nil {
+		return domain.HistoryNotificationItems(entry.History, task.Title), nil
+	}
+	return []domain.NotificationItem{domain.CommentNotificationItem(entry.Comment, task.Title)}, nil
+}
+
+func (s *notificationService) settingsFor(ctx context.Context, run *notificationRun, userID string) (*domain.NotificationSettings, error) {
+	if settings, ok := run.settings[userID]; ok {
+		return settings, nil
+	}
+	settings, err := s.notificationRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	run.settings[userID] = settings
+	return settings, nil
+}
+
+// deliver hands the queued notifications that are due to the notifier. Each batch is removed
+// from the queue in the transaction that delivered it, so a notification is delivered at
+// least once; failed ones stay queued for the next run.
+func (s *notificationService) deliver(ctx context.Context, now time.Time) (int, error) {
+	delivered := 0
+	for {
+		var pending, sent int
+		err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			notifications, err := s.notificationRepo.PendingNotifications(txCtx, now, notificationBatchSize)
+			if err != nil {
+				return err
+			}
+			pending = len(notifications)
+
+			var ids []string
+			for _, notification := range notifications {
+				if err := s.notifier.Notify(txCtx, notification); err != nil {
+					s.logger.Warn(ctx, "Failed to deliver notification", "error", err,
+						"notification_id", notification.ID, "channel", notification.Channel)
+					continue
+				}
+				ids = append(ids, notification.ID)
+			}
+			sent = len(ids)
+			return s.notificationRepo.DeleteNotifications(txCtx, ids)
+		})
+		if err != nil {
+			return delivered, fmt.Errorf("failed to deliver notifications: %w", err)
+		}
+		delivered += sent
+
+		// Stop at the end of the queue, or when failures would hand back the same notifications
+		if pending < notificationBatchSize || sent < pending {
+			return delivered, nil
+		}
+	}
+}
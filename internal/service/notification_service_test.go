@@ -0,0 +1,675 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/notify"
+	"github.com/todo-app/services/admin-service/internal/testutil"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+// mockNotificationRepository keeps watches, settings and the queue in memory. Task watchers
+// come from the tasks of the mock task repository; category watches cover a task's own
+// categories only.
+type mockNotificationRepository struct {
+	tasks         *mockTaskRepository
+	taskWatches   map[string]map[string]bool // task ID -> user IDs
+	categoryWatch map[string]map[string]bool // category ID -> user IDs
+	settings      map[string]*domain.NotificationSettings
+	digests       map[string]time.Time
+	cursors       map[string]time.Time
+	activity      []*domain.ActivityItem
+	claimed       map[string]bool
+	queue         []*domain.Notification
+	nextID        int
+}
+
+func newMockNotificationRepository(tasks *mockTaskRepository) *mockNotificationRepository {
+	return &mockNotificationRepository{
+		tasks:         tasks,
+		taskWatches:   make(map[string]map[string]bool),
+		categoryWatch: make(map[string]map[string]bool),
+		settings:      make(map[string]*domain.NotificationSettings),
+		digests:       make(map[string]time.Time),
+		cursors:       make(map[string]time.Time),
+		claimed:       make(map[string]bool),
+	}
+}
+
+func watch(watches map[string]map[string]bool, key, userID string) {
+	if watches[key] == nil {
+		watches[key] = make(map[string]bool)
+	}
+	watches[key][userID] = true
+}
+
+func watchedKeys(watches map[string]map[string]bool, userID string) []string {
+	keys := []string{}
+	for key, users := range watches {
+		if users[userID] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m *mockNotificationRepository) WatchTask(ctx context.Context, userID, taskID string) error {
+	watch(m.taskWatches, taskID, userID)
+	return nil
+}
+
+func (m *mockNotificationRepository) UnwatchTask(ctx context.Context, userID, taskID string) error {
+	delete(m.taskWatches[taskID], userID)
+	return nil
+}
+
+func (m *mockNotificationRepository) WatchCategory(ctx context.Context, userID, categoryID string) error {
+	watch(m.categoryWatch, categoryID, userID)
+	return nil
+}
+
+func (m *mockNotificationRepository) UnwatchCategory(ctx context.Context, userID, categoryID string) error {
+	delete(m.categoryWatch[categoryID], userID)
+	return nil
+}
+
+func (m *mockNotificationRepository) ListWatches(ctx context.Context, userID string) (*domain.Watches, error) {
+	return &domain.Watches{
+		TaskIDs:     watchedKeys(m.taskWatches, userID),
+		CategoryIDs: watchedKeys(m.categoryWatch, userID),
+	}, nil
+}
+
+func (m *mockNotificationRepository) Watchers(ctx context.Context, taskID string) ([]string, error) {
+	users := make(map[string]bool)
+	for userID := range m.taskWatches[taskID] {
+		users[userID] = true
+	}
+	if task, ok := m.tasks.tasks[taskID]; ok {
+		for _, category := range task.Categories {
+			for userID := range m.categoryWatch[category.ID] {
+				users[userID] = true
+			}
+		}
+		if task.AssigneeID != "" {
+			users[task.AssigneeID] = true
+		}
+		for _, participant := range task.Participants {
+			users[participant.UserID] = true
+		}
+	}
+
+	var userIDs []string
+	for userID := range users {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+func (m *mockNotificationRepository) WatchedTaskIDs(ctx context.Context, userID string) ([]string, error) {
+	var taskIDs []string
+	for taskID := range m.tasks.tasks {
+		watchers, _ := m.Watchers(ctx, taskID)
+		for _, watcher := range watchers {
+			if watcher == userID {
+				taskIDs = append(taskIDs, taskID)
+			}
+		}
+	}
+	sort.Strings(taskIDs)
+	return taskIDs, nil
+}
+
+func (m *mockNotificationRepository) ListNotifiedUsers(ctx context.Context) ([]string, error) {
+	users := make(map[string]bool)
+	for taskID := range m.tasks.tasks {
+		watchers, _ := m.Watchers(ctx, taskID)
+		for _, userID := range watchers {
+			users[userID] = true
+		}
+	}
+
+	var userIDs []string
+	for userID := range users {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+func (m *mockNotificationRepository) GetSettings(ctx context.Context, userID string) (*domain.NotificationSettings, error) {
+	stored, ok := m.settings[userID]
+	if !ok {
+		return domain.DefaultNotificationSettings(userID), nil
+	}
+	settings := *stored
+	return &settings, nil
+}
+
+func (m *mockNotificationRepository) SaveSettings(ctx context.Context, settings *domain.NotificationSettings) error {
+	current := int64(0)
+	if stored, ok := m.settings[settings.UserID]; ok {
+		current = stored.Version
+	}
+	if settings.Version != current {
+		return domain.ErrVersionConflict("notification settings", settings.Version, settings.Version+1)
+	}
+	settings.Version++
+	stored := *settings
+	m.settings[settings.UserID] = &stored
+	return nil
+}
+
+func (m *mockNotificationRepository) LastDigest(ctx context.Context, userID string, channel domain.NotificationChannel) (*time.Time, error) {
+	sent, ok := m.digests[userID+"/"+string(channel)]
+	if !ok {
+		return nil, nil
+	}
+	return &sent, nil
+}
+
+func (m *mockNotificationRepository) MarkDigestSent(ctx context.Context, userID string, channel domain.NotificationChannel, at time.Time) error {
+	m.digests[userID+"/"+string(channel)] = at
+	return nil
+}
+
+func (m *mockNotificationRepository) LockCursor(ctx context.Context, name string, initial time.Time) (time.Time, error) {
+	if position, ok := m.cursors[name]; ok {
+		return position, nil
+	}
+	m.cursors[name] = initial
+	return initial, nil
+}
+
+func (m *mockNotificationRepository) SetCursor(ctx context.Context, name string, position time.Time) error {
+	m.cursors[name] = position
+	return nil
+}
+
+func (m *mockNotificationRepository) ListActivity(ctx context.Context, from, to time.Time, taskIDs []string) ([]*domain.ActivityItem, error) {
+	included := make(map[string]bool, len(taskIDs))
+	for _, taskID := range taskIDs {
+		included[taskID] = true
+	}
+
+	// Like the repository, activity merges the task history with comments
+	items := append([]*domain.ActivityItem(nil), m.activity...)
+	for _, entry := range m.tasks.history {
+		items = append(items, &domain.ActivityItem{Timestamp: entry.Timestamp, History: entry})
+	}
+
+	var activity []*domain.ActivityItem
+	for _, item := range items {
+		if !item.Timestamp.After(from) || item.Timestamp.After(to) {
+			continue
+		}
+		taskID := ""
+		if item.History != nil {
+			taskID = item.History.TaskID
+		} else {
+			taskID = item.Comment.TaskID
+		}
+		if taskIDs == nil || included[taskID] {
+			activity = append(activity, item)
+		}
+	}
+	return activity, nil
+}
+
+func (m *mockNotificationRepository) ClaimDueSoon(ctx context.Context, from, to time.Time) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	for _, task := range m.dueTasks(nil, from, to) {
+		key := task.ID + "/" + task.DueDate.String()
+		if !m.claimed[key] {
+			m.claimed[key] = true
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockNotificationRepository) ListDueTasks(ctx context.Context, taskIDs []string, from, to time.Time) ([]*domain.Task, error) {
+	return m.dueTasks(taskIDs, from, to), nil
+}
+
+func (m *mockNotificationRepository) dueTasks(taskIDs []string, from, to time.Time) []*domain.Task {
+	var tasks []*domain.Task
+	for _, task := range m.tasks.tasks {
+		if task.DueDate == nil || !task.DueDate.After(from) || task.DueDate.After(to) || task.Status == domain.TaskStatusCompleted {
+			continue
+		}
+		if taskIDs != nil && !containsString(taskIDs, task.ID) {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].DueDate.Before(*tasks[j].DueDate) })
+	return tasks
+}
+
+func (m *mockNotificationRepository) Enqueue(ctx context.Context, notifications []*domain.Notification) error {
+	for _, notification := range notifications {
+		m.nextID++
+		notification.ID = fmt.Sprintf("notification-%d", m.nextID)
+		m.queue = append(m.queue, notification)
+	}
+	return nil
+}
+
+func (m *mockNotificationRepository) PendingNotifications(ctx context.Context, now time.Time, limit int) ([]*domain.Notification, error) {
+	var pending []*domain.Notification
+	for _, notification := range m.queue {
+		if len(pending) < limit && !notification.DeliverAfter.After(now) {
+			pending = append(pending, notification)
+		}
+	}
+	return pending, nil
+}
+
+func (m *mockNotificationRepository) DeleteNotifications(ctx context.Context, ids []string) error {
+	var remaining []*domain.Notification
+	for _, notification := range m.queue {
+		if !containsString(ids, notification.ID) {
+			remaining = append(remaining, notification)
+		}
+	}
+	m.queue = remaining
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type notificationTestEnv struct {
+	service       NotificationService
+	notifications *mockNotificationRepository
+	tasks         *mockTaskRepository
+	categories    *mockCategoryRepository
+	notifier      *notify.Memory
+	owner         *domain.User
+	watcher       *domain.User
+	other         *domain.User
+	task          *domain.Task
+}
+
+func setupNotificationTest(t *testing.T, rules domain.NotificationRules) *notificationTestEnv {
+	t.Helper()
+	env := &notificationTestEnv{tasks: newMockTaskRepository(), categories: newMockCategoryRepository(), notifier: notify.NewMemory()}
+	env.notifications = newMockNotificationRepository(env.tasks)
+	env.service = NewNotificationService(env.notifications, env.tasks, env.categories, &mockTransactionManager{},
+		env.notifier, rules, logger.NewLogger("error"))
+
+	env.owner, env.watcher, env.other = testutil.TestUser(), testutil.TestUser(), testutil.TestUser()
+	env.task = testutil.TestTask(env.owner.ID)
+	env.task.DueDate = nil
+	env.tasks.Create(context.Background(), env.task)
+	return env
+}
+
+// statusChange records a status change of the env's task made by actor at the given time
+func (env *notificationTestEnv) statusChange(t *testing.T, actor *domain.User, at time.Time, from, to domain.TaskStatus) {
+	t.Helper()
+	entry := &domain.TaskHistory{TaskID: env.task.ID, Action: domain.TaskHistoryActionUpdated, ActorID: actor.ID, Timestamp: at}
+	err := entry.SetDetails(&domain.TaskHistoryDetails{
+		Changes:   []string{"status"},
+		OldValues: map[string]interface{}{"status": string(from)},
+		NewValues: map[string]interface{}{"status": string(to)},
+	})
+	if err != nil {
+		t.Fatalf("SetDetails() error = %v", err)
+	}
+	env.notifications.activity = append(env.notifications.activity, &domain.ActivityItem{Timestamp: at, History: entry})
+}
+
+func TestNotificationService_Watch(t *testing.T) {
+	env := setupNotificationTest(t, domain.NotificationRules{})
+	ctx := asUser(env.watcher)
+
+	private := testutil.TestCategory()
+	private.IsPublic = false
+	private.CreatorID = env.owner.ID
+	env.categories.Create(context.Background(), private)
+	public := testutil.TestCategory()
+	env.categories.Create(context.Background(), public)
+
+	if err := env.service.WatchTask(ctx, env.task.ID); err != nil {
+		t.Fatalf("WatchTask() error = %v", err)
+	}
+	if err := env.service.WatchTask(ctx, env.task.ID); err != nil {
+		t.Errorf("WatchTask() again error = %v", err)
+	}
+	if err := env.service.WatchCategory(ctx, public.ID); err != nil {
+		t.Fatalf("WatchCategory() error = %v", err)
+	}
+
+	watches, err := env.service.ListWatches(ctx)
+	if err != nil {
+		t.Fatalf("ListWatches() error = %v", err)
+	}
+	if len(watches.TaskIDs) != 1 || watches.TaskIDs[0] != env.task.ID ||
+		len(watches.CategoryIDs) != 1 || watches.CategoryIDs[0] != public.ID {
+		t.Errorf("ListWatches() = %+v", watches)
+	}
+
+	tests := []struct {
+		name    string
+		fn      func() error
+		checkFn func(error) bool
+	}{
+		{"unauthenticated", func() error { return env.service.WatchTask(context.Background(), env.task.ID) }, isUnauthorizedError},
+		{"missing task", func() error { return env.service.WatchTask(ctx, "missing") }, domain.IsNotFoundError},
+		{"missing category", func() error { return env.service.WatchCategory(ctx, "missing") }, domain.IsNotFoundError},
+		{"private category of another user", func() error { return env.service.WatchCategory(ctx, private.ID) }, domain.IsNotFoundError},
+		{"empty task ID", func() error { return env.service.UnwatchTask(ctx, "") }, domain.IsInvalidInputError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); !tt.checkFn(err) {
+				t.Errorf("error = %v", err)
+			}
+		})
+	}
+
+	if err := env.service.UnwatchTask(ctx, env.task.ID); err != nil {
+		t.Fatalf("UnwatchTask() error = %v", err)
+	}
+	if err := env.service.UnwatchCategory(ctx, public.ID); err != nil {
+		t.Fatalf("UnwatchCategory() error = %v", err)
+	}
+	if watches, _ := env.service.ListWatches(ctx); len(watches.TaskIDs) != 0 || len(watches.CategoryIDs) != 0 {
+		t.Errorf("ListWatches() after unwatching = %+v", watches)
+	}
+}
+
+func TestNotificationService_UpdateSettings(t *testing.T) {
+	env := setupNotificationTest(t, domain.NotificationRules{})
+	ctx := asUser(env.watcher)
+
+	settings, err := env.service.GetSettings(ctx)
+	if err != nil {
+		t.Fatalf("GetSettings() error = %v", err)
+	}
+	if settings.Version != 0 || settings.Mode(domain.NotificationCommented, domain.NotificationChannelEmail) != domain.NotificationDigest {
+		t.Errorf("default settings = %+v", settings)
+	}
+
+	updated, err := env.service.UpdateSettings(ctx, &domain.NotificationSettings{
+		QuietHours:  &domain.QuietHours{Start: 22 * 60, End: 7 * 60},
+		Preferences: []domain.NotificationPreference{{Event: domain.NotificationCommented, Channel: domain.NotificationChannelEmail, Mode: domain.NotificationOff}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+	if updated.UserID != env.watcher.ID || updated.TimeZone != "UTC" || updated.Version != 1 {
+		t.Errorf("UpdateSettings() = %+v", updated)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		settings *domain.NotificationSettings
+		checkFn  func(error) bool
+	}{
+		{"unauthenticated", context.Background(), &domain.NotificationSettings{}, isUnauthorizedError},
+		{"stale version", ctx, &domain.NotificationSettings{Version: 0}, domain.IsVersionConflictError},
+		{"unknown time zone", ctx, &domain.NotificationSettings{TimeZone: "Mars/Olympus", Version: 1}, domain.IsInvalidInputError},
+		{"empty quiet hours", ctx, &domain.NotificationSettings{QuietHours: &domain.QuietHours{Start: 60, End: 60}, Version: 1}, domain.IsInvalidInputError},
+		{"unknown mode", ctx, &domain.NotificationSettings{
+			Preferences: []domain.NotificationPreference{{Event: domain.NotificationDueSoon, Channel: domain.NotificationChannelInApp}},
+			Version:     1,
+		}, domain.IsInvalidInputError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.service.UpdateSettings(tt.ctx, tt.settings); !tt.checkFn(err) {
+				t.Errorf("UpdateSettings() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNotificationService_DispatchNotifications(t *testing.T) {
+	env := setupNotificationTest(t, domain.NotificationRules{})
+	ctx := context.Background()
+	env.notifications.WatchTask(ctx, env.watcher.ID, env.task.ID)
+
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if _, err := env.service.DispatchNotifications(ctx, start); err != nil {
+		t.Fatalf("first DispatchNotifications() error = %v", err)
+	}
+	// Activity from before the first run is not notified
+	env.statusChange(t, env.watcher, start.Add(-2*time.Hour), domain.TaskStatusOpen, domain.TaskStatusInProgress)
+	env.statusChange(t, env.watcher, start.Add(time.Minute), domain.TaskStatusInProgress, domain.TaskStatusCompleted)
+
+	delivered, err := env.service.DispatchNotifications(ctx, start.Add(5*time.Minute))
+	if err != nil {
+		t.Fatalf("DispatchNotifications() error = %v", err)
+	}
+	// The assignee is told in-app; the watcher made the change and emails go to the digest
+	sent := env.notifier.SentTo(env.owner.ID, domain.NotificationChannelInApp)
+	if delivered != 1 || len(env.notifier.Sent()) != 1 || len(sent) != 1 {
+		t.Fatalf("delivered %d, sent %d, want one in-app notification to the assignee", delivered, len(env.notifier.Sent()))
+	}
+	item := sent[0].Items[0]
+	if item.Event != domain.NotificationStatusChanged || item.TaskID != env.task.ID || item.TaskTitle != env.task.Title ||
+		item.Summary != "Status changed from IN_PROGRESS to COMPLETED" {
+		t.Errorf("item = %+v", item)
+	}
+
+	if delivered, _ := env.service.DispatchNotifications(ctx, start.Add(10*time.Minute)); delivered != 0 {
+		t.Errorf("second dispatch delivered %d, want 0", delivered)
+	}
+}
+
+func TestNotificationService_TaskServiceChanges(t *testing.T) {
+	env := setupNotificationTest(t, domain.NotificationRules{})
+	ctx := context.Background()
+	env.notifications.WatchTask(ctx, env.watcher.ID, env.task.ID)
+
+	users := newMockUserRepository()
+	users.users[env.owner.ID] = env.owner
+	tasks := NewTaskService(env.tasks, users, env.categories, newMockTagRepository(), newMockWorkflowRepository(), newMockTaskSeriesRepository(),
+		&mockTransactionManager{}, domain.SubtaskRules{}, logger.NewLogger("error"))
+
+	if _, err := env.service.DispatchNotifications(ctx, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("first DispatchNotifications() error = %v", err)
+	}
+	if _, err := tasks.ChangeTaskStatus(asUser(env.owner), env.task.ID, domain.TaskStatusInProgress, env.task.Version); err != nil {
+		t.Fatalf("ChangeTaskStatus() error = %v", err)
+	}
+	if _, err := env.service.DispatchNotifications(ctx, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("DispatchNotifications() error = %v", err)
+	}
+
+	sent := env.notifier.SentTo(env.watcher.ID, domain.NotificationChannelInApp)
+	if len(sent) != 1 || len(sent[0].Items) != 1 {
+		t.Fatalf("sent %d notifications to the watcher, want one", len(sent))
+	}
+	if item := sent[0].Items[0]; item.Event != domain.NotificationStatusChanged || item.Summary != "Status changed from OPEN to IN_PROGRESS" {
+		t.Errorf("item = %+v", item)
+	}
+}
+
+func TestNotificationService_Recipients(t *testing.T) {
+	env := setupNotificationTest(t, domain.NotificationRules{})
+	ctx := context.Background()
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	env.service.DispatchNotifications(ctx, start)
+
+	category := testutil.TestCategory()
+	env.categories.Create(ctx, category)
+	env.task.Categories = []domain.Category{*category}
+	env.notifications.WatchCategory(ctx, env.watcher.ID, category.ID)
+
+	// other reassigns the task to themselves and mentions nobody; the previous assignee is told
+	reassigned := &domain.TaskHistory{TaskID: env.task.ID, ActorID: env.other.ID, Timestamp: start.Add(time.Minute)}
+	reassigned.SetDetails(&domain.TaskHistoryDetails{
+		Changes:   []string{"assignee_id"},
+		OldValues: map[string]interface{}{"assignee_id": env.owner.ID},
+		NewValues: map[string]interface{}{"assignee_id": env.other.ID},
+	})
+	mentioned := testutil.TestUser()
+	comment := &domain.Comment{
+		TaskID: env.task.ID, AuthorID: env.owner.ID, Body: "Please check @someone",
+		MentionedUserIDs: []string{mentioned.ID}, CreatedAt: start.Add(2 * time.Minute),
+	}
+	env.notifications.activity = append(env.notifications.activity,
+		&domain.ActivityItem{Timestamp: reassigned.Timestamp, History: reassigned},
+		&domain.ActivityItem{Timestamp: comment.CreatedAt, Comment: comment},
+	)
+	env.task.AssigneeID = env.other.ID
+
+	if _, err := env.service.DispatchNotifications(ctx, start.Add(5*time.Minute)); err != nil {
+		t.Fatalf("DispatchNotifications() error = %v", err)
+	}
+	events := func(user *domain.User) []domain.NotificationEvent {
+		var got []domain.NotificationEvent
+		for _, notification := range env.notifier.SentTo(user.ID, domain.NotificationChannelInApp) {
+			got = append(got, notification.Items[0].Event)
+		}
+		return got
+	}
+	want := map[*domain.User][]domain.NotificationEvent{
+		env.owner:   {domain.NotificationReassigned},
+		env.watcher: {domain.NotificationReassigned, domain.NotificationCommented},
+		env.other:   {domain.NotificationCommented},
+		mentioned:   {domain.NotificationCommented},
+	}
+	for user, wantEvents := range want {
+		if got := events(user); fmt.Sprint(got) != fmt.Sprint(wantEvents) {
+			t.Errorf("events of %s = %v, want %v", user.ID, got, wantEvents)
+		}
+	}
+}
+
+func TestNotificationService_QuietHoursAndPreferences(t *testing.T) {
+	env := setupNotificationTest(t, domain.NotificationRules{})
+	ctx := context.Background()
+	env.notifications.SaveSettings(ctx, &domain.NotificationSettings{
+		UserID:     env.owner.ID,
+		TimeZone:   "Europe/Berlin",
+		QuietHours: &domain.QuietHours{Start: 22 * 60, End: 7 * 60},
+	})
+	env.notifications.SaveSettings(ctx, &domain.NotificationSettings{
+		UserID:      env.watcher.ID,
+		TimeZone:    "UTC",
+		Preferences: []domain.NotificationPreference{{Event: domain.NotificationStatusChanged, Channel: domain.NotificationChannelInApp, Mode: domain.NotificationOff}},
+	})
+	env.notifications.WatchTask(ctx, env.watcher.ID, env.task.ID)
+
+	// 22:30 UTC is 23:30 in Berlin
+	start := time.Date(2026, 3, 2, 22, 30, 0, 0, time.UTC)
+	env.service.DispatchNotifications(ctx, start)
+	env.statusChange(t, env.other, start.Add(time.Minute), domain.TaskStatusOpen, domain.TaskStatusInProgress)
+
+	if delivered, _ := env.service.DispatchNotifications(ctx, start.Add(5*time.Minute)); delivered != 0 {
+		t.Errorf("delivered %d during quiet hours, want 0", delivered)
+	}
+	if len(env.notifications.queue) != 1 {
+		t.Fatalf("queued %d notifications, want the assignee's only", len(env.notifications.queue))
+	}
+
+	// Quiet hours end at 07:00 Berlin time, 06:00 UTC
+	end := time.Date(2026, 3, 3, 6, 0, 0, 0, time.UTC)
+	env.service.DispatchNotifications(ctx, end.Add(-time.Second))
+	if sent := env.notifier.SentTo(env.owner.ID, domain.NotificationChannelInApp); len(sent) != 0 {
+		t.Errorf("delivered %d before quiet hours ended", len(sent))
+	}
+	env.service.DispatchNotifications(ctx, end)
+	if sent := env.notifier.SentTo(env.owner.ID, domain.NotificationChannelInApp); len(sent) != 1 {
+		t.Errorf("delivered %d after quiet hours, want 1", len(sent))
+	}
+}
+
+func TestNotificationService_Digest(t *testing.T) {
+	env := setupNotificationTest(t, domain.NotificationRules{DueSoon: 24 * time.Hour, DigestHour: 8})
+	ctx := context.Background()
+	env.notifications.WatchTask(ctx, env.watcher.ID, env.task.ID)
+
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	env.service.DispatchNotifications(ctx, start)
+	env.statusChange(t, env.other, start.Add(time.Hour), domain.TaskStatusOpen, domain.TaskStatusInProgress)
+	env.statusChange(t, env.watcher, start.Add(2*time.Hour), domain.TaskStatusInProgress, domain.TaskStatusOpen)
+	env.service.DispatchNotifications(ctx, start.Add(3*time.Hour))
+
+	// The first digest slot only covers the day before it; nothing was pending yet
+	for _, notification := range env.notifier.Sent() {
+		if notification.Channel == domain.NotificationChannelEmail {
+			t.Fatalf("email sent before the digest hour: %+v", notification)
+		}
+	}
+
+	due := time.Date(2026, 3, 3, 20, 0, 0, 0, time.UTC)
+	env.task.DueDate = &due
+	digestTime := time.Date(2026, 3, 3, 8, 0, 30, 0, time.UTC)
+	if _, err := env.service.DispatchNotifications(ctx, digestTime); err != nil {
+		t.Fatalf("DispatchNotifications() error = %v", err)
+	}
+
+	digests := env.notifier.SentTo(env.watcher.ID, domain.NotificationChannelEmail)
+	if len(digests) != 1 || !digests[0].Digest {
+		t.Fatalf("watcher digests = %d, want 1", len(digests))
+	}
+	// The watcher's own change is left out
+	items := digests[0].Items
+	if len(items) != 2 || items[0].Event != domain.NotificationStatusChanged || items[1].Event != domain.NotificationDueSoon {
+		t.Errorf("digest items = %+v", items)
+	}
+
+	// The due date is announced immediately once, in-app
+	dueSoon := 0
+	for _, notification := range env.notifier.SentTo(env.watcher.ID, domain.NotificationChannelInApp) {
+		if notification.Items[0].Event == domain.NotificationDueSoon {
+			dueSoon++
+		}
+	}
+	if dueSoon != 1 {
+		t.Errorf("in-app due soon notifications = %d, want 1", dueSoon)
+	}
+
+	sent := len(env.notifier.Sent())
+	if _, err := env.service.DispatchNotifications(ctx, digestTime.Add(time.Hour)); err != nil {
+		t.Fatalf("DispatchNotifications() error = %v", err)
+	}
+	if len(env.notifier.Sent()) != sent {
+		t.Errorf("dispatch later the same day sent %d more notifications", len(env.notifier.Sent())-sent)
+	}
+}
+
+func TestNotificationService_DeliveryFailure(t *testing.T) {
+	env := setupNotificationTest(t, domain.NotificationRules{})
+	ctx := context.Background()
+	start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	env.service.DispatchNotifications(ctx, start)
+	env.statusChange(t, env.other, start.Add(time.Minute), domain.TaskStatusOpen, domain.TaskStatusInProgress)
+
+	env.notifier.FailWith(errors.New("provider unavailable"))
+	if delivered, err := env.service.DispatchNotifications(ctx, start.Add(5*time.Minute)); err != nil || delivered != 0 {
+		t.Fatalf("DispatchNotifications() = %d, %v; want 0, nil", delivered, err)
+	}
+	if len(env.notifications.queue) != 1 {
+		t.Fatalf("queued %d notifications after failure, want 1", len(env.notifications.queue))
+	}
+
+	env.notifier.FailWith(nil)
+	if delivered, _ := env.service.DispatchNotifications(ctx, start.Add(6*time.Minute)); delivered != 1 {
+		t.Errorf("retry delivered %d, want 1", delivered)
+	}
+	if len(env.notifications.queue) != 0 {
+		t.Errorf("queue not drained: %d left", len(env.notifications.queue))
+	}
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/taskquery"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+type savedViewService struct {
+	viewRepo    repository.SavedViewRepository
+	taskService TaskService
+	logger      logger.Logger
+}
+
+// NewSavedViewService creates a new saved view service
+func NewSavedViewService(
+	viewRepo repository.SavedViewRepository,
+	taskService TaskService,
+	log logger.Logger,
+) SavedViewService {
+	return &savedViewService{
+		viewRepo:    viewRepo,
+		taskService: taskService,
+		logger:      log,
+	}
+}
+
+func (s *savedViewService) CreateView(ctx context.Context, view *domain.SavedView) (*domain.SavedView, error) {
+	s.logger.Info(ctx, "Creating saved view", "name", view.Name, "visibility", view.Visibility)
+
+	userID, _, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	view.OwnerID = userID
+
+	if err := s.validateView(view); err != nil {
+		return nil, err
+	}
+
+	if err := s.viewRepo.Create(ctx, view); err != nil {
+		if domain.IsConflictError(err) {
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to create saved view", "error", err, "name", view.Name)
+		return nil, fmt.Errorf("failed to create saved view: %w", err)
+	}
+
+	s.logger.Info(ctx, "Saved view created successfully", "view_id", view.ID)
+	return view, nil
+}
+
+func (s *savedViewService) GetView(ctx context.Context, id string) (*domain.SavedView, error) {
+	s.logger.Debug(ctx, "Getting saved view", "view_id", id)
+
+	if id == "" {
+		return nil, domain.ErrInvalidInput("saved view ID is required")
+	}
+	userID, role, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := s.viewRepo.GetByID(ctx, id)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get saved view: %w", err)
+	}
+
+	// Views the caller cannot see are reported as missing rather than forbidden
+	if !view.VisibleTo(userID, role) {
+		return nil, domain.ErrNotFound("saved view")
+	}
+	if view.IsStale() {
+		s.logger.Warn(ctx, "Saved view refers to deleted categories or tags", "view_id", view.ID,
+			"missing_category_ids", view.MissingCategoryIDs, "missing_tag_ids", view.MissingTagIDs)
+	}
+
+	return view, nil
+}
+
+func (s *savedViewService) UpdateView(ctx context.Context, view *domain.SavedView) (*domain.SavedView, error) {
+	s.logger.Info(ctx, "Updating saved view", "view_id", view.ID, "version", view.Version)
+
+	existing, err := s.viewForChange(ctx, view.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Version != view.Version {
+		return nil, domain.ErrVersionConflict("saved view", view.Version, existing.Version)
+	}
+
+	// Ownership never changes hands through an update
+	view.OwnerID = existing.OwnerID
+	view.CreatedAt = existing.CreatedAt
+
+	if err := s.validateView(view); err != nil {
+		return nil, err
+	}
+
+	if err := s.viewRepo.Update(ctx, view); err != nil {
+		if domain.IsVersionConflictError(err) || domain.IsConflictError(err) {
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to update saved view", "error", err, "view_id", view.ID)
+		return nil, fmt.Errorf("failed to update saved view: %w", err)
+	}
+
+	// Reload so the stale flags reflect the new filter
+	updated, err := s.viewRepo.GetByID(ctx, view.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved view: %w", err)
+	}
+
+	s.logger.Info(ctx, "Saved view updated successfully", "view_id", view.ID, "new_version", updated.Version)
+	return updated, nil
+}
+
+func (s *savedViewService) DeleteView(ctx context.Context, id string, version int64) error {
+	s.logger.Info(ctx, "Deleting saved view", "view_id", id, "version", version)
+
+	existing, err := s.viewForChange(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.Version != version {
+		return domain.ErrVersionConflict("saved view", version, existing.Version)
+	}
+
+	if err := s.viewRepo.SoftDelete(ctx, id, version); err != nil {
+		if domain.IsVersionConflictError(err) {
+			return err
+		}
+		s.logger.Error(ctx, "Failed to delete saved view", "error", err, "view_id", id)
+		return fmt.Errorf("failed to delete saved view: %w", err)
+	}
+
+	s.logger.Info(ctx, "Saved view deleted successfully", "view_id", id)
+	return nil
+}
+
+func (s *savedViewService) ListViews(ctx context.Context, opts repository.ListOptions) ([]*domain.SavedView, int64, error) {
+	s.logger.Debug(ctx, "Listing saved views", "page", opts.Page, "page_size", opts.PageSize)
+
+	userID, role, err := authenticatedCaller(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	views, total, err := s.viewRepo.List(ctx, repository.SavedViewListOptions{
+		ListOptions: opts,
+		UserID:      userID,
+		Role:        role,
+	})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list saved views", "error", err)
+		return nil, 0, fmt.Errorf("failed to list saved views: %w", err)
+	}
+
+	return views, total, nil
+}
+
+func (s *savedViewService) ListTasks(ctx context.Context, viewID string, page, pageSize int32) ([]*domain.Task, int64, *domain.SavedView, error) {
+	view, err := s.GetView(ctx, viewID)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// Filters on deleted categories or tags are kept as they are: the view then
+	// matches fewer tasks instead of silently matching more
+	opts := savedViewTaskListOptions(view)
+	opts.Page = page
+	opts.PageSize = pageSize
+
+	tasks, total, err := s.taskService.ListTasks(ctx, opts)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return tasks, total, view, nil
+}
+
+func (s *savedViewService) GetTaskFacets(ctx context.Context, viewID string, facets []domain.TaskFacet) ([]*domain.FacetCounts, error) {
+	view, err := s.GetView(ctx, viewID)
+	if err != nil {
+		return nil, err
+	}
+	return s.taskService.GetTaskFacets(ctx, savedViewTaskListOptions(view), facets)
+}
+
+// viewForChange loads a view the caller is allowed to modify: their own, or any view for admins
+func (s *savedViewService) viewForChange(ctx context.Context, id string) (*domain.SavedView, error) {
+	view, err := s.GetView(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, role, _ := authenticatedCaller(ctx)
+	if view.OwnerID != userID && role != domain.UserRoleAdmin {
+		return nil, domain.ErrPermissionDenied("only the owner of a saved view can change it")
+	}
+	return view, nil
+}
+
+// validateView normalizes the view and checks what the repository cannot: the
+// query must parse and the columns must be known
+func (s *savedViewService) validateView(view *domain.SavedView) error {
+	view.Name = strings.TrimSpace(view.Name)
+	view.Filter.SearchQuery = strings.TrimSpace(view.Filter.SearchQuery)
+	view.Filter.Query = strings.TrimSpace(view.Filter.Query)
+
+	if view.Visibility == "" {
+		view.Visibility = domain.SavedViewPrivate
+	}
+
+	if len(view.Filter.SearchQuery) > domain.MaxSearchQueryLength {
+		return domain.ErrInvalidInput(fmt.Sprintf("search query must be at most %d characters", domain.MaxSearchQueryLength))
+	}
+	// The query is only parsed here; it is bound to the viewer each time the view is opened
+	if view.Filter.Query != "" {
+		if _, err := taskquery.Parse(view.Filter.Query); err != nil {
+			return err
+		}
+	}
+
+	if len(view.Columns) > 0 {
+		columns, err := exportColumns(view.Columns)
+		if err != nil {
+			return err
+		}
+		view.Columns = columns
+	}
+
+	return view.IsValid()
+}
+
+// savedViewTaskListOptions converts a view's filter and sort to task list options
+func savedViewTaskListOptions(view *domain.SavedView) repository.TaskListOptions {
+	opts := repository.TaskListOptions{
+		AssigneeID:   view.Filter.AssigneeID,
+		Status:       view.Filter.Status,
+		Priority:     view.Filter.Priority,
+		CategoryIDs:  view.Filter.CategoryIDs,
+		TagIDs:       view.Filter.TagIDs,
+		SearchLabels: view.Filter.SearchLabels,
+		Query:        view.Filter.Query,
+	}
+	opts.SearchQuery = view.Filter.SearchQuery
+	opts.AssigneeRoles = view.Filter.AssigneeRoles
+	opts.CreatorID = view.Filter.CreatorID
+	opts.SortBy = view.SortBy
+	opts.SortDesc = view.SortDesc
+
+	if view.Filter.DueBefore != nil {
+		dueBefore := view.Filter.DueBefore.Format(time.RFC3339)
+		opts.DueBefore = &dueBefore
+	}
+	if view.Filter.DueAfter != nil {
+		dueAfter := view.Filter.DueAfter.Format(time.RFC3339)
+		opts.DueAfter = &dueAfter
+	}
+	return opts
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// mockSavedViewRepository keeps views in memory; missing marks deleted categories and tags
+type mockSavedViewRepository struct {
+	views   map[string]*domain.SavedView
+	missing map[string]bool
+	nextID  int
+}
+
+func newMockSavedViewRepository() *mockSavedViewRepository {
+	return &mockSavedViewRepository{
+		views:   make(map[string]*domain.SavedView),
+		missing: make(map[string]bool),
+	}
+}
+
+func (m *mockSavedViewRepository) Create(ctx context.Context, view *domain.SavedView) error {
+	if err := view.IsValid(); err != nil {
+		return err
+	}
+	for _, existing := range m.views {
+		if existing.OwnerID == view.OwnerID && strings.EqualFold(existing.Name, view.Name) {
+			return domain.ErrConflict("duplicate saved view name")
+		}
+	}
+	m.nextID++
+	view.ID = fmt.Sprintf("view-%d", m.nextID)
+	view.Version = 1
+	stored := *view
+	m.views[view.ID] = &stored
+	return nil
+}
+
+func (m *mockSavedViewRepository) GetByID(ctx context.Context, id string) (*domain.SavedView, error) {
+	stored, ok := m.views[id]
+	if !ok {
+		return nil, domain.ErrNotFound("saved view")
+	}
+	view := *stored
+	view.MissingCategoryIDs, view.MissingTagIDs = nil, nil
+	for _, categoryID := range view.Filter.CategoryIDs {
+		if m.missing[categoryID] {
+			view.MissingCategoryIDs = append(view.MissingCategoryIDs, categoryID)
+		}
+	}
+	for _, tagID := range view.Filter.TagIDs {
+		if m.missing[tagID] {
+			view.MissingTagIDs = append(view.MissingTagIDs, tagID)
+		}
+	}
+	return &view, nil
+}
+
+func (m *mockSavedViewRepository) List(ctx context.Context, opts repository.SavedViewListOptions) ([]*domain.SavedView, int64, error) {
+	var views []*domain.SavedView
+	for id, view := range m.views {
+		if view.OwnerID == opts.UserID || (view.Visibility == domain.SavedViewRole && view.SharedRole == opts.Role) {
+			loaded, _ := m.GetByID(ctx, id)
+			views = append(views, loaded)
+		}
+	}
+	return views, int64(len(views)), nil
+}
+
+func (m *mockSavedViewRepository) Update(ctx context.Context, view *domain.SavedView) error {
+	stored, ok := m.views[view.ID]
+	if !ok || stored.Version != view.Version {
+		return domain.ErrVersionConflict("saved view", view.Version, view.Version+1)
+	}
+	view.Version++
+	updated := *view
+	m.views[view.ID] = &updated
+	return nil
+}
+
+func (m *mockSavedViewRepository) SoftDelete(ctx context.Context, id string, version int64) error {
+	stored, ok := m.views[id]
+	if !ok || stored.Version != version {
+		return domain.ErrVersionConflict("saved view", version, version+1)
+	}
+	delete(m.views, id)
+	return nil
+}
+
+// recordingTaskService captures the options saved views list tasks with
+type recordingTaskService struct {
+	TaskService
+	opts repository.TaskListOptions
+}
+
+func (r *recordingTaskService) ListTasks(ctx context.Context, opts repository.TaskListOptions) ([]*domain.Task, int64, error) {
+	r.opts = opts
+	return []*domain.Task{{ID: "task-1"}}, 1, nil
+}
+
+func TestSavedViewService_Lifecycle(t *testing.T) {
+	repo := newMockSavedViewRepository()
+	service := NewSavedViewService(repo, &recordingTaskService{}, logger.NewLogger("debug"))
+	owner := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	view, err := service.CreateView(owner, &domain.SavedView{
+		Name:    "  Team backlog ",
+		Filter:  domain.SavedViewFilter{Query: "status:open"},
+		Columns: []string{"Title", "status"},
+	})
+	if err != nil {
+		t.Fatalf("CreateView() error = %v", err)
+	}
+	if view.OwnerID != "user-1" || view.Name != "Team backlog" || view.Visibility != domain.SavedViewPrivate {
+		t.Errorf("created view = %+v", view)
+	}
+	if strings.Join(view.Columns, ",") != "title,status" {
+		t.Errorf("columns = %v, want normalized", view.Columns)
+	}
+
+	if _, err := service.CreateView(owner, &domain.SavedView{Name: "team BACKLOG"}); !domain.IsConflictError(err) {
+		t.Errorf("CreateView() duplicate error = %v, want conflict", err)
+	}
+
+	// Private views are hidden from other users but not from admins
+	other := requestctx.WithUser(context.Background(), "user-2", "user")
+	if _, err := service.GetView(other, view.ID); !domain.IsNotFoundError(err) {
+		t.Errorf("GetView() by other user error = %v, want not found", err)
+	}
+	admin := requestctx.WithUser(context.Background(), "admin-1", "admin")
+	if _, err := service.GetView(admin, view.ID); err != nil {
+		t.Errorf("GetView() by admin error = %v", err)
+	}
+
+	// Sharing with the user role makes the view readable, but not writable, by other users
+	view.Visibility, view.SharedRole = domain.SavedViewRole, domain.UserRoleUser
+	view, err = service.UpdateView(owner, view)
+	if err != nil {
+		t.Fatalf("UpdateView() error = %v", err)
+	}
+	if view.Version != 2 {
+		t.Errorf("version = %d, want 2", view.Version)
+	}
+	if _, err := service.GetView(other, view.ID); err != nil {
+		t.Errorf("GetView() of shared view error = %v", err)
+	}
+	views, total, err := service.ListViews(other, repository.ListOptions{})
+	if err != nil || total != 1 || views[0].ID != view.ID {
+		t.Errorf("ListViews() = %v, %d, %v; want the shared view", views, total, err)
+	}
+	if err := service.DeleteView(other, view.ID, view.Version); err == nil {
+		t.Errorf("DeleteView() by other user succeeded, want permission denied")
+	}
+
+	if err := service.DeleteView(owner, view.ID, 1); !domain.IsVersionConflictError(err) {
+		t.Errorf("DeleteView() with stale version error = %v, want version conflict", err)
+	}
+	if err := service.DeleteView(owner, view.ID, view.Version); err != nil {
+		t.Fatalf("DeleteView() error = %v", err)
+	}
+	if _, err := service.GetView(owner, view.ID); !domain.IsNotFoundError(err) {
+		t.Errorf("GetView() after delete error = %v, want not found", err)
+	}
+}
+
+func TestSavedViewService_Validation(t *testing.T) {
+	service := NewSavedViewService(newMockSavedViewRepository(), &recordingTaskService{}, logger.NewLogger("debug"))
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	tests := []struct {
+		name string
+		view *domain.SavedView
+	}{
+		{"missing name", &domain.SavedView{}},
+		{"invalid query", &domain.SavedView{Name: "v", Filter: domain.SavedViewFilter{Query: "status:"}}},
+		{"unknown column", &domain.SavedView{Name: "v", Columns: []string{"owner"}}},
+		{"unknown sort", &domain.SavedView{Name: "v", SortBy: "assignee"}},
+		{"shared without role", &domain.SavedView{Name: "v", Visibility: domain.SavedViewRole}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.CreateView(ctx, tt.view); !domain.IsInvalidInputError(err) {
+				t.Errorf("CreateView() error = %v, want invalid input", err)
+			}
+		})
+	}
+
+	if _, err := service.CreateView(context.Background(), &domain.SavedView{Name: "v"}); err == nil {
+		t.Errorf("CreateView() without a caller succeeded, want unauthorized")
+	}
+}
+
+func TestSavedViewService_ListTasks(t *testing.T) {
+	repo := newMockSavedViewRepository()
+	tasks := &recordingTaskService{}
+	service := NewSavedViewService(repo, tasks, logger.NewLogger("debug"))
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	dueBefore := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+	view, err := service.CreateView(ctx, &domain.SavedView{
+		Name: "My overdue urgent tasks",
+		Filter: domain.SavedViewFilter{
+			Priority:    domain.TaskPriorityUrgent,
+			CategoryIDs: []string{"cat-1", "cat-deleted"},
+			DueBefore:   &dueBefore,
+			Query:       "assignee:me",
+		},
+		SortBy:   "due_date",
+		SortDesc: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateView() error = %v", err)
+	}
+	repo.missing["cat-deleted"] = true
+
+	got, total, loaded, err := service.ListTasks(ctx, view.ID, 2, 25)
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if len(got) != 1 || total != 1 {
+		t.Errorf("ListTasks() = %d tasks, total %d", len(got), total)
+	}
+
+	// A deleted category is flagged, and the filter still includes it
+	if !loaded.IsStale() || strings.Join(loaded.MissingCategoryIDs, ",") != "cat-deleted" {
+		t.Errorf("view missing categories = %v, want cat-deleted", loaded.MissingCategoryIDs)
+	}
+	opts := tasks.opts
+	if strings.Join(opts.CategoryIDs, ",") != "cat-1,cat-deleted" {
+		t.Errorf("category filter = %v, want both categories", opts.CategoryIDs)
+	}
+	if opts.Priority != domain.TaskPriorityUrgent || opts.Query != "assignee:me" {
+		t.Errorf("filter = %+v", opts)
+	}
+	if opts.SortBy != "due_date" || !opts.SortDesc || opts.Page != 2 || opts.PageSize != 25 {
+		t.Errorf("sort and paging = %s desc=%v page=%d size=%d", opts.SortBy, opts.SortDesc, opts.Page, opts.PageSize)
+	}
+	if opts.DueBefore == nil || *opts.DueBefore != "2024-05-20T00:00:00Z" {
+		t.Errorf("due before = %v, want 2024-05-20T00:00:00Z", opts.DueBefore)
+	}
+}
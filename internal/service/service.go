@@ -1,6 +1,11 @@
 package service
 
 import (
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/events"
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/notify"
 	"github.com/todo-app/services/admin-service/internal/repository"
 	"github.com/todo-app/services/admin-service/pkg/logger"
 )
@@ -11,7 +16,35 @@ type ServiceDependencies struct {
 	TaskRepo     repository.TaskRepository
 	CategoryRepo repository.CategoryRepository
 	TagRepo      repository.TagRepository
+	ImportRepo   repository.TaskImportRepository
+	ViewRepo     repository.SavedViewRepository
+	WorkflowRepo repository.WorkflowRepository
+	SeriesRepo   repository.TaskSeriesRepository
+	CommentRepo  repository.CommentRepository
+	TxManager    repository.TransactionManager
 	Logger       logger.Logger
+
+	// AttachmentRepo keeps attachment metadata and BlobStore their content
+	AttachmentRepo repository.AttachmentRepository
+	BlobStore      repository.BlobStore
+
+	// Events receives the domain events services raise
+	Events events.Publisher
+
+	// NotificationRepo keeps watchers and queued notifications; Notifier delivers them
+	NotificationRepo repository.NotificationRepository
+	Notifier         notify.Notifier
+
+	// CategoryMaxDepth limits the depth of category trees; zero uses the default
+	CategoryMaxDepth int
+	// TagSuggestTimeout is the latency budget of tag suggestions; zero uses the default
+	TagSuggestTimeout time.Duration
+	// SubtaskRules control how parent tasks follow their subtasks
+	SubtaskRules domain.SubtaskRules
+	// AttachmentLimits bound attachment uploads
+	AttachmentLimits domain.AttachmentLimits
+	// NotificationRules control when notifications are raised
+	NotificationRules domain.NotificationRules
 }
 
 // NewServices creates a new Services instance with all service implementations
@@ -23,25 +56,70 @@ func NewServices(deps ServiceDependencies) *Services {
 		deps.UserRepo,
 		deps.CategoryRepo,
 		deps.TagRepo,
+		deps.WorkflowRepo,
+		deps.SeriesRepo,
+		deps.TxManager,
+		deps.SubtaskRules,
 		deps.Logger,
 	)
 
 	categoryService := NewCategoryService(
 		deps.CategoryRepo,
 		deps.TaskRepo,
+		deps.TxManager,
+		deps.CategoryMaxDepth,
 		deps.Logger,
 	)
 
 	tagService := NewTagService(
 		deps.TagRepo,
 		deps.TaskRepo,
+		deps.TxManager,
+		deps.TagSuggestTimeout,
+		deps.Logger,
+	)
+
+	importService := NewTaskImportService(
+		deps.TaskRepo,
+		deps.UserRepo,
+		deps.CategoryRepo,
+		deps.ImportRepo,
+		tagService,
+		deps.TxManager,
+		deps.Logger,
+	)
+
+	exportService := NewTaskExportService(deps.TaskRepo, deps.Logger)
+
+	savedViewService := NewSavedViewService(deps.ViewRepo, taskService, deps.Logger)
+
+	workflowService := NewWorkflowService(deps.WorkflowRepo, deps.CategoryRepo, deps.TxManager, deps.Logger)
+
+	commentService := NewCommentService(deps.CommentRepo, deps.TaskRepo, deps.UserRepo, deps.Events, deps.Logger)
+
+	attachmentService := NewAttachmentService(deps.AttachmentRepo, deps.TaskRepo, deps.BlobStore, deps.TxManager, deps.AttachmentLimits, deps.Logger)
+
+	notificationService := NewNotificationService(
+		deps.NotificationRepo,
+		deps.TaskRepo,
+		deps.CategoryRepo,
+		deps.TxManager,
+		deps.Notifier,
+		deps.NotificationRules,
 		deps.Logger,
 	)
 
 	return &Services{
-		User:     userService,
-		Task:     taskService,
-		Category: categoryService,
-		Tag:      tagService,
+		User:         userService,
+		Task:         taskService,
+		Category:     categoryService,
+		Tag:          tagService,
+		Import:       importService,
+		Export:       exportService,
+		SavedView:    savedViewService,
+		Workflow:     workflowService,
+		Comment:      commentService,
+		Attachment:   attachmentService,
+		Notification: notificationService,
 	}
 }
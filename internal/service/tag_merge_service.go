@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// duplicateReportPageSize is the page size used to read every tag for the duplicate report
+const duplicateReportPageSize = 500
+
+func (s *tagService) MergeTags(ctx context.Context, canonical domain.TagRef, sources []domain.TagRef) (*domain.Tag, int, error) {
+	s.logger.Info(ctx, "Merging tags", "canonical_id", canonical.ID, "sources", len(sources))
+
+	if err := requireTagAdmin(ctx, "merge tags"); err != nil {
+		return nil, 0, err
+	}
+	if canonical.ID == "" {
+		return nil, 0, domain.ErrInvalidInput("canonical tag ID is required")
+	}
+	if len(sources) == 0 {
+		return nil, 0, domain.ErrInvalidInput("at least one source tag is required")
+	}
+
+	target, err := s.GetTagByID(ctx, canonical.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if target.Version != canonical.Version {
+		return nil, 0, domain.ErrVersionConflict("tag", canonical.Version, target.Version)
+	}
+
+	seen := make(map[string]bool, len(sources))
+	merged := make([]*domain.Tag, 0, len(sources))
+	for _, ref := range sources {
+		if ref.ID == "" {
+			return nil, 0, domain.ErrInvalidInput("source tag ID is required")
+		}
+		if ref.ID == canonical.ID {
+			return nil, 0, domain.ErrInvalidInput("cannot merge a tag into itself")
+		}
+		if seen[ref.ID] {
+			return nil, 0, domain.ErrInvalidInput(fmt.Sprintf("source tag %s is listed more than once", ref.ID))
+		}
+		seen[ref.ID] = true
+
+		source, err := s.GetTagByID(ctx, ref.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if source.Version != ref.Version {
+			return nil, 0, domain.ErrVersionConflict("tag", ref.Version, source.Version)
+		}
+		merged = append(merged, source)
+	}
+
+	moved := 0
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		// Updating the canonical tag checks its version and keeps concurrent merges into it in order
+		if err := s.tagRepo.Update(txCtx, target); err != nil {
+			return err
+		}
+
+		for _, source := range merged {
+			taskIDs, err := s.tagRepo.ReassignTasks(txCtx, source.ID, target.ID)
+			if err != nil {
+				return err
+			}
+			for _, taskID := range taskIDs {
+				if err := recordTaskHistory(txCtx, s.taskRepo, taskID, domain.TaskHistoryActionUpdated, &domain.TaskHistoryDetails{
+					OldValues: map[string]interface{}{"tag_id": source.ID},
+					NewValues: map[string]interface{}{"tag_id": target.ID},
+					Changes:   []string{"tags"},
+					Metadata:  map[string]interface{}{"merged_tag_id": source.ID},
+				}); err != nil {
+					return err
+				}
+			}
+			moved += len(taskIDs)
+
+			if err := s.tagRepo.MoveSynonyms(txCtx, source.ID, target.ID); err != nil {
+				return err
+			}
+			if err := s.tagRepo.SoftDelete(txCtx, source.ID, source.Version); err != nil {
+				return err
+			}
+			// The merged name keeps resolving to the canonical tag
+			if source.Name != target.Name {
+				if err := s.tagRepo.AddSynonym(txCtx, &domain.TagSynonym{
+					Synonym:   source.Name,
+					TagID:     target.ID,
+					CreatedBy: actorID(ctx),
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) {
+			s.logger.Warn(ctx, "Tag merge version conflict", "canonical_id", canonical.ID, "error", err)
+			return nil, 0, err
+		}
+		s.logger.Error(ctx, "Failed to merge tags", "error", err, "canonical_id", canonical.ID)
+		return nil, 0, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tags merged successfully", "canonical_id", target.ID, "sources", len(merged), "tasks", moved)
+	return target, moved, nil
+}
+
+func (s *tagService) AddTagSynonym(ctx context.Context, tagID, name string) (*domain.TagSynonym, error) {
+	s.logger.Info(ctx, "Adding tag synonym", "tag_id", tagID, "synonym", name)
+
+	if err := requireTagAdmin(ctx, "manage tag synonyms"); err != nil {
+		return nil, err
+	}
+
+	name = s.formatTagName(name)
+	if name == "" {
+		return nil, domain.ErrInvalidInput("synonym is required")
+	}
+	if err := s.validateTagName(name); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.GetTagByID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	conflictTag, err := s.findTagByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for name conflict: %w", err)
+	}
+	if conflictTag != nil {
+		return nil, domain.ErrConflict("a tag with this name already exists")
+	}
+
+	existing, err := s.tagRepo.GetSynonym(ctx, name)
+	if err != nil && !domain.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to check for existing synonym: %w", err)
+	}
+	if existing != nil {
+		if existing.TagID != tag.ID {
+			return nil, domain.ErrConflict("synonym already belongs to another tag")
+		}
+		return existing, nil
+	}
+
+	synonym := &domain.TagSynonym{
+		Synonym:   name,
+		TagID:     tag.ID,
+		CreatedBy: actorID(ctx),
+	}
+	if err := s.tagRepo.AddSynonym(ctx, synonym); err != nil {
+		s.logger.Error(ctx, "Failed to add tag synonym", "error", err, "tag_id", tagID)
+		return nil, fmt.Errorf("failed to add tag synonym: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tag synonym added successfully", "tag_id", tag.ID, "synonym", name)
+	return synonym, nil
+}
+
+func (s *tagService) RemoveTagSynonym(ctx context.Context, name string) error {
+	s.logger.Info(ctx, "Removing tag synonym", "synonym", name)
+
+	if err := requireTagAdmin(ctx, "manage tag synonyms"); err != nil {
+		return err
+	}
+
+	name = s.formatTagName(name)
+	if name == "" {
+		return domain.ErrInvalidInput("synonym is required")
+	}
+
+	if err := s.tagRepo.DeleteSynonym(ctx, name); err != nil {
+		if domain.IsNotFoundError(err) {
+			return err
+		}
+		s.logger.Error(ctx, "Failed to remove tag synonym", "error", err, "synonym", name)
+		return fmt.Errorf("failed to remove tag synonym: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tag synonym removed successfully", "synonym", name)
+	return nil
+}
+
+func (s *tagService) ListTagSynonyms(ctx context.Context, tagID string) ([]*domain.TagSynonym, error) {
+	s.logger.Debug(ctx, "Listing tag synonyms", "tag_id", tagID)
+
+	if _, err := s.GetTagByID(ctx, tagID); err != nil {
+		return nil, err
+	}
+
+	synonyms, err := s.tagRepo.ListSynonyms(ctx, tagID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list tag synonyms", "error", err, "tag_id", tagID)
+		return nil, fmt.Errorf("failed to list tag synonyms: %w", err)
+	}
+	return synonyms, nil
+}
+
+func (s *tagService) GetDuplicateTagReport(ctx context.Context, limit int) ([]*domain.TagDuplicate, error) {
+	s.logger.Debug(ctx, "Building duplicate tag report", "limit", limit)
+
+	if err := requireTagAdmin(ctx, "review duplicate tags"); err != nil {
+		return nil, err
+	}
+	if limit < 0 || limit > domain.MaxDuplicateTagLimit {
+		return nil, domain.ErrInvalidInput(fmt.Sprintf("limit must be between 0 and %d", domain.MaxDuplicateTagLimit))
+	}
+	if limit == 0 {
+		limit = domain.DefaultDuplicateTagLimit
+	}
+
+	var tags []*domain.Tag
+	for page := int32(0); ; page++ {
+		batch, total, err := s.tagRepo.List(ctx, repository.TagListOptions{
+			ListOptions: repository.ListOptions{Page: page, PageSize: duplicateReportPageSize},
+		})
+		if err != nil {
+			s.logger.Error(ctx, "Failed to list tags for duplicate report", "error", err)
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+		tags = append(tags, batch...)
+		if len(batch) < duplicateReportPageSize || int64(len(tags)) >= total {
+			break
+		}
+	}
+
+	ids := make([]string, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.ID
+	}
+	counts, err := s.tagRepo.CountTasks(ctx, ids)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to count tag usage for duplicate report", "error", err)
+		return nil, fmt.Errorf("failed to count tag usage: %w", err)
+	}
+
+	duplicates := domain.FindDuplicateTags(tags, counts)
+	if len(duplicates) > limit {
+		duplicates = duplicates[:limit]
+	}
+
+	s.logger.Debug(ctx, "Built duplicate tag report", "tags", len(tags), "pairs", len(duplicates))
+	return duplicates, nil
+}
+
+// requireTagAdmin restricts tag maintenance to administrators, since it changes tags for everyone
+func requireTagAdmin(ctx context.Context, action string) error {
+	if domain.UserRole(requestctx.UserRole(ctx)) != domain.UserRoleAdmin {
+		return domain.ErrPermissionDenied("only administrators can " + action)
+	}
+	return nil
+}
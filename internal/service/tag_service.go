@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository"
@@ -11,21 +13,32 @@ import (
 )
 
 type tagService struct {
-	tagRepo  repository.TagRepository
-	taskRepo repository.TaskRepository
-	logger   logger.Logger
+	tagRepo   repository.TagRepository
+	taskRepo  repository.TaskRepository
+	txManager repository.TransactionManager
+	// suggestTimeout is the latency budget of SuggestTags
+	suggestTimeout time.Duration
+	logger         logger.Logger
 }
 
-// NewTagService creates a new tag service
+// NewTagService creates a new tag service. suggestTimeout bounds the time spent on tag
+// suggestions; zero uses domain.DefaultTagSuggestTimeout.
 func NewTagService(
 	tagRepo repository.TagRepository,
 	taskRepo repository.TaskRepository,
+	txManager repository.TransactionManager,
+	suggestTimeout time.Duration,
 	log logger.Logger,
 ) TagService {
+	if suggestTimeout <= 0 {
+		suggestTimeout = domain.DefaultTagSuggestTimeout
+	}
 	return &tagService{
-		tagRepo:  tagRepo,
-		taskRepo: taskRepo,
-		logger:   log,
+		tagRepo:        tagRepo,
+		taskRepo:       taskRepo,
+		txManager:      txManager,
+		suggestTimeout: suggestTimeout,
+		logger:         log,
 	}
 }
 
@@ -37,8 +50,12 @@ func (s *tagService) CreateTag(ctx context.Context, tag *domain.Tag) (*domain.Ta
 		return nil, err
 	}
 
-	// Normalize tag name
-	tag.Name = s.normalizeTagName(tag.Name)
+	// Normalize tag name; a synonym resolves to its canonical tag and so conflicts with it
+	name, err := s.normalizeTagName(ctx, tag.Name)
+	if err != nil {
+		return nil, err
+	}
+	tag.Name = name
 
 	// Check for duplicate name
 	existing, err := s.findTagByName(ctx, tag.Name)
@@ -87,8 +104,9 @@ func (s *tagService) UpdateTag(ctx context.Context, tag *domain.Tag) (*domain.Ta
 		return nil, err
 	}
 
-	// Normalize tag name
-	tag.Name = s.normalizeTagName(tag.Name)
+	// Normalize tag name. Synonyms are not resolved: renaming a tag to one of its own
+	// synonyms is allowed, and the synonym is dropped.
+	tag.Name = s.formatTagName(tag.Name)
 
 	// Check for duplicate name if name is being changed
 	existingTag, err := s.tagRepo.GetByID(ctx, tag.ID)
@@ -96,7 +114,10 @@ func (s *tagService) UpdateTag(ctx context.Context, tag *domain.Tag) (*domain.Ta
 		return nil, fmt.Errorf("failed to get existing tag: %w", err)
 	}
 
-	if existingTag.Name != tag.Name {
+	oldName := existingTag.Name
+	renamed := oldName != tag.Name
+	ownSynonym := false
+	if renamed {
 		conflictTag, err := s.findTagByName(ctx, tag.Name)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check for name conflict: %w", err)
@@ -104,10 +125,39 @@ func (s *tagService) UpdateTag(ctx context.Context, tag *domain.Tag) (*domain.Ta
 		if conflictTag != nil && conflictTag.ID != tag.ID {
 			return nil, domain.ErrConflict("tag name already in use")
 		}
+
+		synonym, err := s.tagRepo.GetSynonym(ctx, tag.Name)
+		if err != nil && !domain.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to check for synonym conflict: %w", err)
+		}
+		if synonym != nil {
+			if synonym.TagID != tag.ID {
+				return nil, domain.ErrConflict("tag name is a synonym of another tag")
+			}
+			ownSynonym = true
+		}
 	}
 
-	// Update tag
-	if err := s.tagRepo.Update(ctx, tag); err != nil {
+	// Update tag; the old name stays a synonym so lookups by it keep finding the tag
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.tagRepo.Update(txCtx, tag); err != nil {
+			return err
+		}
+		if !renamed {
+			return nil
+		}
+		if ownSynonym {
+			if err := s.tagRepo.DeleteSynonym(txCtx, tag.Name); err != nil {
+				return err
+			}
+		}
+		return s.tagRepo.AddSynonym(txCtx, &domain.TagSynonym{
+			Synonym:   oldName,
+			TagID:     tag.ID,
+			CreatedBy: actorID(ctx),
+		})
+	})
+	if err != nil {
 		if domain.IsVersionConflictError(err) {
 			s.logger.Warn(ctx, "Tag update version conflict", "tag_id", tag.ID, "version", tag.Version)
 			return nil, err
@@ -222,8 +272,11 @@ func (s *tagService) GetTagTaskCount(ctx context.Context, tagID string) (int64,
 func (s *tagService) FindOrCreateTag(ctx context.Context, name string) (*domain.Tag, error) {
 	s.logger.Debug(ctx, "Finding or creating tag", "name", name)
 
-	// Normalize name
-	normalizedName := s.normalizeTagName(name)
+	// Normalize name, resolving synonyms
+	normalizedName, err := s.normalizeTagName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
 
 	// Try to find existing tag
 	existing, err := s.findTagByName(ctx, normalizedName)
@@ -240,7 +293,7 @@ func (s *tagService) FindOrCreateTag(ctx context.Context, name string) (*domain.
 	newTag := &domain.Tag{
 		Name:      normalizedName,
 		Color:     s.generateDefaultTagColor(),
-		CreatorID: "system", // Auto-created tags have system as creator
+		CreatorID: domain.SystemUserID, // Auto-created tags have system as creator
 	}
 
 	return s.CreateTag(ctx, newTag)
@@ -299,7 +352,7 @@ func (s *tagService) validateTagName(name string) error {
 	return nil
 }
 
-func (s *tagService) normalizeTagName(name string) string {
+func (s *tagService) formatTagName(name string) string {
 	// Convert to lowercase and trim spaces
 	normalized := strings.ToLower(strings.TrimSpace(name))
 
@@ -308,25 +361,39 @@ func (s *tagService) normalizeTagName(name string) string {
 	return strings.Join(words, "-")
 }
 
-func (s *tagService) findTagByName(ctx context.Context, name string) (*domain.Tag, error) {
-	// This is a simplified implementation - in a real system you might have a GetByName method
-	tags, _, err := s.tagRepo.List(ctx, repository.TagListOptions{
-		ListOptions: repository.ListOptions{
-			PageSize:    100,
-			SearchQuery: name,
-		},
-	})
-	if err != nil {
-		return nil, err
+// normalizeTagName formats a tag name and resolves a synonym to the name of its canonical tag
+func (s *tagService) normalizeTagName(ctx context.Context, name string) (string, error) {
+	formatted := s.formatTagName(name)
+	if formatted == "" {
+		return formatted, nil
 	}
 
-	for _, tag := range tags {
-		if tag.Name == name {
-			return tag, nil
+	synonym, err := s.tagRepo.GetSynonym(ctx, formatted)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return formatted, nil
 		}
+		return "", fmt.Errorf("failed to resolve tag synonym: %w", err)
+	}
+
+	tag, err := s.tagRepo.GetByID(ctx, synonym.TagID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get canonical tag: %w", err)
 	}
+	s.logger.Debug(ctx, "Resolved tag synonym", "synonym", formatted, "tag_id", tag.ID)
+	return tag.Name, nil
+}
 
-	return nil, nil
+// findTagByName returns the tag with a normalized name, or nil if there is none
+func (s *tagService) findTagByName(ctx context.Context, name string) (*domain.Tag, error) {
+	tag, err := s.tagRepo.GetByName(ctx, name)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return tag, nil
 }
 
 func (s *tagService) generateDefaultTagColor() string {
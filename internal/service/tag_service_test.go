@@ -2,23 +2,33 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository"
 	"github.com/todo-app/services/admin-service/internal/testutil"
 	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
 )
 
 type mockTagRepositoryForTagService struct {
 	tags      map[string]*domain.Tag
 	nameIndex map[string]*domain.Tag
+	synonyms  map[string]*domain.TagSynonym
+	taskTags  map[string][]string // tag ID -> task IDs
+
+	suggestOpts repository.TagSuggestOptions
+	slowSuggest bool // Suggest blocks until its context is done
 }
 
 func newMockTagRepositoryForTagService() *mockTagRepositoryForTagService {
 	return &mockTagRepositoryForTagService{
 		tags:      make(map[string]*domain.Tag),
 		nameIndex: make(map[string]*domain.Tag),
+		synonyms:  make(map[string]*domain.TagSynonym),
+		taskTags:  make(map[string][]string),
 	}
 }
 
@@ -99,6 +109,102 @@ func (m *mockTagRepositoryForTagService) List(ctx context.Context, opts reposito
 	return tags, int64(len(tags)), nil
 }
 
+func (m *mockTagRepositoryForTagService) GetByName(ctx context.Context, name string) (*domain.Tag, error) {
+	tag, exists := m.nameIndex[name]
+	if !exists {
+		return nil, domain.ErrNotFound("tag")
+	}
+	return tag, nil
+}
+
+func (m *mockTagRepositoryForTagService) Suggest(ctx context.Context, opts repository.TagSuggestOptions) ([]*domain.TagSuggestion, error) {
+	m.suggestOpts = opts
+	if m.slowSuggest {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	var suggestions []*domain.TagSuggestion
+	for _, tag := range m.tags {
+		prefix := strings.HasPrefix(tag.Name, opts.Query)
+		if !prefix && !(opts.Trigram && strings.Contains(tag.Name, opts.Query)) {
+			continue
+		}
+		suggestions = append(suggestions, &domain.TagSuggestion{
+			Tag:         tag,
+			PrefixMatch: prefix,
+			Similarity:  float64(len(opts.Query)) / float64(len(tag.Name)),
+			UsageCount:  int64(len(m.taskTags[tag.ID])),
+		})
+	}
+	return suggestions, nil
+}
+
+func (m *mockTagRepositoryForTagService) ReassignTasks(ctx context.Context, sourceID, targetID string) ([]string, error) {
+	moved := m.taskTags[sourceID]
+	delete(m.taskTags, sourceID)
+	for _, taskID := range moved {
+		linked := false
+		for _, id := range m.taskTags[targetID] {
+			linked = linked || id == taskID
+		}
+		if !linked {
+			m.taskTags[targetID] = append(m.taskTags[targetID], taskID)
+		}
+	}
+	return moved, nil
+}
+
+func (m *mockTagRepositoryForTagService) CountTasks(ctx context.Context, tagIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	for _, id := range tagIDs {
+		if n := len(m.taskTags[id]); n > 0 {
+			counts[id] = int64(n)
+		}
+	}
+	return counts, nil
+}
+
+func (m *mockTagRepositoryForTagService) AddSynonym(ctx context.Context, synonym *domain.TagSynonym) error {
+	m.synonyms[synonym.Synonym] = synonym
+	return nil
+}
+
+func (m *mockTagRepositoryForTagService) GetSynonym(ctx context.Context, synonym string) (*domain.TagSynonym, error) {
+	result, exists := m.synonyms[synonym]
+	if !exists || m.tags[result.TagID] == nil {
+		return nil, domain.ErrNotFound("tag synonym")
+	}
+	return result, nil
+}
+
+func (m *mockTagRepositoryForTagService) ListSynonyms(ctx context.Context, tagID string) ([]*domain.TagSynonym, error) {
+	var synonyms []*domain.TagSynonym
+	for _, synonym := range m.synonyms {
+		if synonym.TagID == tagID {
+			synonyms = append(synonyms, synonym)
+		}
+	}
+	return synonyms, nil
+}
+
+func (m *mockTagRepositoryForTagService) DeleteSynonym(ctx context.Context, synonym string) error {
+	if _, exists := m.synonyms[synonym]; !exists {
+		return domain.ErrNotFound("tag synonym")
+	}
+	delete(m.synonyms, synonym)
+	return nil
+}
+
+func (m *mockTagRepositoryForTagService) MoveSynonyms(ctx context.Context, fromID, toID string) error {
+	for _, synonym := range m.synonyms {
+		if synonym.TagID == fromID {
+			synonym.TagID = toID
+		}
+	}
+	return nil
+}
+
 type mockTaskRepositoryForTagService struct {
 	tasks map[string]*domain.Task
 }
@@ -124,6 +230,22 @@ func (m *mockTaskRepositoryForTagService) GetByID(ctx context.Context, id string
 	return task, nil
 }
 
+func (m *mockTaskRepositoryForTagService) GetDeletedByID(ctx context.Context, id string) (*domain.Task, error) {
+	return nil, domain.ErrNotFound("task")
+}
+
+func (m *mockTaskRepositoryForTagService) StreamForExport(ctx context.Context, opts repository.TaskListOptions, fn func(*domain.TaskExportRecord) error) error {
+	return nil
+}
+
+func (m *mockTaskRepositoryForTagService) Search(ctx context.Context, opts repository.TaskListOptions) ([]*domain.TaskSearchResult, int64, error) {
+	return nil, 0, nil
+}
+
+func (m *mockTaskRepositoryForTagService) Facets(ctx context.Context, opts repository.TaskListOptions, facets []domain.TaskFacet) ([]*domain.FacetCounts, error) {
+	return nil, nil
+}
+
 func (m *mockTaskRepositoryForTagService) Update(ctx context.Context, task *domain.Task) error {
 	existing, exists := m.tasks[task.ID]
 	if !exists {
@@ -157,6 +279,50 @@ func (m *mockTaskRepositoryForTagService) Restore(ctx context.Context, id string
 	return nil
 }
 
+func (m *mockTaskRepositoryForTagService) GetSubtree(ctx context.Context, rootID string) ([]*domain.TaskNode, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForTagService) SoftDeleteDescendants(ctx context.Context, id string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForTagService) RestoreDescendants(ctx context.Context, id string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForTagService) AddDependency(ctx context.Context, dependency *domain.TaskDependency) error {
+	return nil
+}
+
+func (m *mockTaskRepositoryForTagService) RemoveDependency(ctx context.Context, taskID, blockerID string) error {
+	return nil
+}
+
+func (m *mockTaskRepositoryForTagService) ListBlockers(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForTagService) ListDependents(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForTagService) IsBlockedBy(ctx context.Context, taskID, blockerID string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockTaskRepositoryForTagService) ListDependencies(ctx context.Context, taskIDs []string) ([]*domain.TaskDependency, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepositoryForTagService) LinkToSeries(ctx context.Context, task *domain.Task) error {
+	return nil
+}
+
+func (m *mockTaskRepositoryForTagService) ListSeriesInstances(ctx context.Context, seriesID string) ([]*domain.Task, error) {
+	return nil, nil
+}
+
 func (m *mockTaskRepositoryForTagService) List(ctx context.Context, opts repository.TaskListOptions) ([]*domain.Task, int64, error) {
 	tasks := make([]*domain.Task, 0)
 	for _, task := range m.tasks {
@@ -192,6 +358,14 @@ func (m *mockTaskRepositoryForTagService) RemoveCategories(ctx context.Context,
 	return nil
 }
 
+func (m *mockTaskRepositoryForTagService) AddParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) error {
+	return nil
+}
+
+func (m *mockTaskRepositoryForTagService) RemoveParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) error {
+	return nil
+}
+
 func (m *mockTaskRepositoryForTagService) AddTags(ctx context.Context, taskID string, tagIDs []string, version int64) error {
 	return nil
 }
@@ -200,6 +374,10 @@ func (m *mockTaskRepositoryForTagService) RemoveTags(ctx context.Context, taskID
 	return nil
 }
 
+func (m *mockTaskRepositoryForTagService) AddHistory(ctx context.Context, entry *domain.TaskHistory) error {
+	return nil
+}
+
 func (m *mockTaskRepositoryForTagService) GetHistory(ctx context.Context, taskID string) ([]*domain.TaskHistory, error) {
 	return nil, nil
 }
@@ -208,7 +386,7 @@ func TestTagService_CreateTag(t *testing.T) {
 	mockTagRepo := newMockTagRepositoryForTagService()
 	mockTaskRepo := newMockTaskRepositoryForTagService()
 	mockLogger := logger.NewLogger("debug")
-	service := NewTagService(mockTagRepo, mockTaskRepo, mockLogger)
+	service := NewTagService(mockTagRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -278,14 +456,14 @@ func TestTagService_FindOrCreateTag(t *testing.T) {
 	mockTagRepo := newMockTagRepositoryForTagService()
 	mockTaskRepo := newMockTaskRepositoryForTagService()
 	mockLogger := logger.NewLogger("debug")
-	service := NewTagService(mockTagRepo, mockTaskRepo, mockLogger)
+	service := NewTagService(mockTagRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	// Pre-create a tag
 	existingTag := &domain.Tag{
 		Name:      "existing",
 		Color:     "#000000",
-		CreatorID: "system",
+		CreatorID: domain.SystemUserID,
 	}
 	mockTagRepo.Create(ctx, existingTag)
 
@@ -338,7 +516,7 @@ func TestTagService_FindOrCreateTag(t *testing.T) {
 					t.Error("Expected to find existing tag")
 				}
 
-				if !tt.expectFound && result.CreatorID != "system" {
+				if !tt.expectFound && result.CreatorID != domain.SystemUserID {
 					t.Error("Expected new tag to have system creator")
 				}
 			}
@@ -350,7 +528,7 @@ func TestTagService_ValidateTagUsage(t *testing.T) {
 	mockTagRepo := newMockTagRepositoryForTagService()
 	mockTaskRepo := newMockTaskRepositoryForTagService()
 	mockLogger := logger.NewLogger("debug")
-	service := NewTagService(mockTagRepo, mockTaskRepo, mockLogger)
+	service := NewTagService(mockTagRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	// Create a test tag
@@ -393,7 +571,7 @@ func TestTagService_GetTagTaskCount(t *testing.T) {
 	mockTagRepo := newMockTagRepositoryForTagService()
 	mockTaskRepo := newMockTaskRepositoryForTagService()
 	mockLogger := logger.NewLogger("debug")
-	service := NewTagService(mockTagRepo, mockTaskRepo, mockLogger)
+	service := NewTagService(mockTagRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	// Create a test tag
@@ -423,7 +601,7 @@ func TestTagService_UpdateTag(t *testing.T) {
 	mockTagRepo := newMockTagRepositoryForTagService()
 	mockTaskRepo := newMockTaskRepositoryForTagService()
 	mockLogger := logger.NewLogger("debug")
-	service := NewTagService(mockTagRepo, mockTaskRepo, mockLogger)
+	service := NewTagService(mockTagRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	// Create a test tag
@@ -484,7 +662,7 @@ func TestTagService_DeleteTag(t *testing.T) {
 	mockTagRepo := newMockTagRepositoryForTagService()
 	mockTaskRepo := newMockTaskRepositoryForTagService()
 	mockLogger := logger.NewLogger("debug")
-	service := NewTagService(mockTagRepo, mockTaskRepo, mockLogger)
+	service := NewTagService(mockTagRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	// Create test tags
@@ -541,7 +719,7 @@ func TestTagService_ListTags(t *testing.T) {
 	mockTagRepo := newMockTagRepositoryForTagService()
 	mockTaskRepo := newMockTaskRepositoryForTagService()
 	mockLogger := logger.NewLogger("debug")
-	service := NewTagService(mockTagRepo, mockTaskRepo, mockLogger)
+	service := NewTagService(mockTagRepo, mockTaskRepo, &mockTransactionManager{}, 0, mockLogger)
 	ctx := context.Background()
 
 	// Create some test tags
@@ -568,3 +746,220 @@ func TestTagService_ListTags(t *testing.T) {
 		t.Errorf("Expected total 3, got %d", total)
 	}
 }
+
+func newTagServiceForMergeTest(t *testing.T) (TagService, *mockTagRepositoryForTagService, context.Context) {
+	t.Helper()
+	mockTagRepo := newMockTagRepositoryForTagService()
+	service := NewTagService(mockTagRepo, newMockTaskRepositoryForTagService(), &mockTransactionManager{}, 0, logger.NewLogger("error"))
+	ctx := requestctx.WithUser(context.Background(), "admin-1", string(domain.UserRoleAdmin))
+	return service, mockTagRepo, ctx
+}
+
+func TestTagService_SynonymResolution(t *testing.T) {
+	service, mockTagRepo, ctx := newTagServiceForMergeTest(t)
+
+	bug, err := service.CreateTag(ctx, &domain.Tag{Name: "bug", Color: "#FF0000", CreatorID: "creator-1"})
+	if err != nil {
+		t.Fatalf("CreateTag() error = %v", err)
+	}
+	if _, err := service.AddTagSynonym(ctx, bug.ID, "Bugs"); err != nil {
+		t.Fatalf("AddTagSynonym() error = %v", err)
+	}
+
+	found, err := service.FindOrCreateTag(ctx, "  BUGS ")
+	if err != nil {
+		t.Fatalf("FindOrCreateTag() error = %v", err)
+	}
+	if found.ID != bug.ID {
+		t.Errorf("FindOrCreateTag() resolved to %s, want %s", found.ID, bug.ID)
+	}
+
+	if _, err := service.CreateTag(ctx, &domain.Tag{Name: "bugs", Color: "#FF0000", CreatorID: "creator-1"}); !domain.IsConflictError(err) {
+		t.Errorf("CreateTag() with a synonym error = %v, want conflict", err)
+	}
+	if _, err := service.AddTagSynonym(ctx, bug.ID, "bug"); !domain.IsConflictError(err) {
+		t.Errorf("AddTagSynonym() with a tag name error = %v, want conflict", err)
+	}
+
+	other, _ := service.CreateTag(ctx, &domain.Tag{Name: "feature", Color: "#00FF00", CreatorID: "creator-1"})
+	if _, err := service.AddTagSynonym(ctx, other.ID, "bugs"); !domain.IsConflictError(err) {
+		t.Errorf("AddTagSynonym() taken by another tag error = %v, want conflict", err)
+	}
+
+	if err := service.RemoveTagSynonym(ctx, "bugs"); err != nil {
+		t.Fatalf("RemoveTagSynonym() error = %v", err)
+	}
+	if _, exists := mockTagRepo.synonyms["bugs"]; exists {
+		t.Error("expected synonym to be removed")
+	}
+	if err := service.RemoveTagSynonym(ctx, "bugs"); !domain.IsNotFoundError(err) {
+		t.Errorf("RemoveTagSynonym() twice error = %v, want not found", err)
+	}
+}
+
+func TestTagService_UpdateTagRenamePropagation(t *testing.T) {
+	service, mockTagRepo, ctx := newTagServiceForMergeTest(t)
+
+	tag, _ := service.CreateTag(ctx, &domain.Tag{Name: "defect", Color: "#FF0000", CreatorID: "creator-1"})
+	mockTagRepo.synonyms["bug"] = &domain.TagSynonym{Synonym: "bug", TagID: tag.ID}
+
+	renamed, err := service.UpdateTag(ctx, &domain.Tag{ID: tag.ID, Name: "Bug", Color: tag.Color, CreatorID: tag.CreatorID, Version: tag.Version})
+	if err != nil {
+		t.Fatalf("UpdateTag() error = %v", err)
+	}
+	if renamed.Name != "bug" {
+		t.Errorf("UpdateTag() name = %q, want %q", renamed.Name, "bug")
+	}
+	if _, exists := mockTagRepo.synonyms["bug"]; exists {
+		t.Error("expected the new name to stop being a synonym")
+	}
+	if synonym := mockTagRepo.synonyms["defect"]; synonym == nil || synonym.TagID != tag.ID {
+		t.Errorf("expected old name to become a synonym of the tag, got %+v", synonym)
+	}
+
+	found, err := service.FindOrCreateTag(ctx, "defect")
+	if err != nil || found.ID != tag.ID {
+		t.Errorf("FindOrCreateTag(old name) = %v, %v; want tag %s", found, err, tag.ID)
+	}
+
+	other, _ := service.CreateTag(ctx, &domain.Tag{Name: "feature", Color: "#00FF00", CreatorID: "creator-1"})
+	_, err = service.UpdateTag(ctx, &domain.Tag{ID: other.ID, Name: "defect", Color: other.Color, CreatorID: other.CreatorID, Version: other.Version})
+	if !domain.IsConflictError(err) {
+		t.Errorf("UpdateTag() to another tag's synonym error = %v, want conflict", err)
+	}
+}
+
+func TestTagService_MergeTags(t *testing.T) {
+	service, mockTagRepo, ctx := newTagServiceForMergeTest(t)
+
+	bug, _ := service.CreateTag(ctx, &domain.Tag{Name: "bug", Color: "#FF0000", CreatorID: "creator-1"})
+	bugs, _ := service.CreateTag(ctx, &domain.Tag{Name: "bugs", Color: "#FF0000", CreatorID: "creator-1"})
+	defect, _ := service.CreateTag(ctx, &domain.Tag{Name: "defect", Color: "#FF0000", CreatorID: "creator-2"})
+	mockTagRepo.taskTags[bug.ID] = []string{"task-1"}
+	mockTagRepo.taskTags[bugs.ID] = []string{"task-1", "task-2"}
+	mockTagRepo.taskTags[defect.ID] = []string{"task-3"}
+	mockTagRepo.synonyms["issue"] = &domain.TagSynonym{Synonym: "issue", TagID: defect.ID}
+
+	canonical := domain.TagRef{ID: bug.ID, Version: bug.Version}
+	sources := []domain.TagRef{{ID: bugs.ID, Version: bugs.Version}, {ID: defect.ID, Version: defect.Version}}
+
+	if _, _, err := service.MergeTags(context.Background(), canonical, sources); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("MergeTags() as non-admin error = %v, want permission denied", err)
+	}
+	if _, _, err := service.MergeTags(ctx, canonical, []domain.TagRef{{ID: bug.ID, Version: bug.Version}}); !domain.IsInvalidInputError(err) {
+		t.Errorf("MergeTags() into itself error = %v, want invalid input", err)
+	}
+	if _, _, err := service.MergeTags(ctx, canonical, []domain.TagRef{{ID: bugs.ID, Version: 99}}); !domain.IsVersionConflictError(err) {
+		t.Errorf("MergeTags() with stale source error = %v, want version conflict", err)
+	}
+
+	merged, moved, err := service.MergeTags(ctx, canonical, sources)
+	if err != nil {
+		t.Fatalf("MergeTags() error = %v", err)
+	}
+	if merged.ID != bug.ID || moved != 3 {
+		t.Errorf("MergeTags() = %s, %d; want %s, 3", merged.ID, moved, bug.ID)
+	}
+	if got := len(mockTagRepo.taskTags[bug.ID]); got != 3 {
+		t.Errorf("canonical tag has %d tasks, want 3", got)
+	}
+	if _, err := mockTagRepo.GetByID(ctx, bugs.ID); !domain.IsNotFoundError(err) {
+		t.Error("expected source tag to be deleted")
+	}
+	for _, name := range []string{"bugs", "defect", "issue"} {
+		if synonym := mockTagRepo.synonyms[name]; synonym == nil || synonym.TagID != bug.ID {
+			t.Errorf("expected %q to be a synonym of the canonical tag, got %+v", name, synonym)
+		}
+	}
+}
+
+func TestTagService_GetDuplicateTagReport(t *testing.T) {
+	service, mockTagRepo, ctx := newTagServiceForMergeTest(t)
+
+	bug, _ := service.CreateTag(ctx, &domain.Tag{Name: "bug", Color: "#FF0000", CreatorID: "creator-1"})
+	bugs, _ := service.CreateTag(ctx, &domain.Tag{Name: "bugs", Color: "#FF0000", CreatorID: "creator-1"})
+	service.CreateTag(ctx, &domain.Tag{Name: "release", Color: "#00FF00", CreatorID: "creator-1"})
+	mockTagRepo.taskTags[bugs.ID] = []string{"task-1", "task-2"}
+
+	if _, err := service.GetDuplicateTagReport(context.Background(), 0); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("GetDuplicateTagReport() as non-admin error = %v, want permission denied", err)
+	}
+	if _, err := service.GetDuplicateTagReport(ctx, domain.MaxDuplicateTagLimit+1); !domain.IsInvalidInputError(err) {
+		t.Errorf("GetDuplicateTagReport() over the limit error = %v, want invalid input", err)
+	}
+
+	report, err := service.GetDuplicateTagReport(ctx, 0)
+	if err != nil {
+		t.Fatalf("GetDuplicateTagReport() error = %v", err)
+	}
+	if len(report) != 1 {
+		t.Fatalf("GetDuplicateTagReport() returned %d pairs, want 1", len(report))
+	}
+	if report[0].Tag.ID != bugs.ID || report[0].Duplicate.ID != bug.ID || report[0].TaskCount != 2 {
+		t.Errorf("unexpected pair %s/%s with %d tasks", report[0].Tag.Name, report[0].Duplicate.Name, report[0].TaskCount)
+	}
+}
+
+func TestTagService_SuggestTags(t *testing.T) {
+	service, mockTagRepo, adminCtx := newTagServiceForMergeTest(t)
+	ctx := requestctx.WithUser(context.Background(), "user-1", string(domain.UserRoleUser))
+
+	for _, name := range []string{"backend", "backlog", "bug", "debugging"} {
+		if _, err := service.CreateTag(ctx, &domain.Tag{Name: name, Color: "#FF0000", CreatorID: "user-1"}); err != nil {
+			t.Fatalf("CreateTag(%s) error = %v", name, err)
+		}
+	}
+	mockTagRepo.taskTags["mock-tag-backlog"] = []string{"task-1", "task-2", "task-3"}
+
+	suggestions, timedOut, err := service.SuggestTags(ctx, " Back", 0)
+	if err != nil || timedOut {
+		t.Fatalf("SuggestTags() = %v, %v", timedOut, err)
+	}
+	if len(suggestions) != 2 || suggestions[0].Tag.Name != "backlog" {
+		t.Errorf("expected the used tag first among prefix matches, got %v", suggestions)
+	}
+	opts := mockTagRepo.suggestOpts
+	if opts.Query != "back" || !opts.Trigram || opts.UserID != "user-1" || opts.AllTags {
+		t.Errorf("unexpected suggest options %+v", opts)
+	}
+
+	suggestions, _, err = service.SuggestTags(ctx, "bu", 0)
+	if err != nil {
+		t.Fatalf("SuggestTags() error = %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Tag.Name != "bug" {
+		t.Errorf("expected short queries to match by prefix only, got %v", suggestions)
+	}
+
+	if _, _, err := service.SuggestTags(adminCtx, "bug", 1); err != nil || !mockTagRepo.suggestOpts.AllTags {
+		t.Errorf("expected admins to see every tag, got %+v, %v", mockTagRepo.suggestOpts, err)
+	}
+
+	if _, _, err := service.SuggestTags(ctx, "   ", 0); !domain.IsInvalidInputError(err) {
+		t.Errorf("SuggestTags() with empty query error = %v, want invalid input", err)
+	}
+	if _, _, err := service.SuggestTags(ctx, "bug", domain.MaxTagSuggestionLimit+1); !domain.IsInvalidInputError(err) {
+		t.Errorf("SuggestTags() over the limit error = %v, want invalid input", err)
+	}
+}
+
+func TestTagService_SuggestTagsTimeout(t *testing.T) {
+	mockTagRepo := newMockTagRepositoryForTagService()
+	mockTagRepo.slowSuggest = true
+	service := NewTagService(mockTagRepo, newMockTaskRepositoryForTagService(), &mockTransactionManager{}, 10*time.Millisecond, logger.NewLogger("error"))
+
+	start := time.Now()
+	suggestions, timedOut, err := service.SuggestTags(context.Background(), "bug", 0)
+	if err != nil || !timedOut || len(suggestions) != 0 {
+		t.Errorf("SuggestTags() = %v, %v, %v; want a timeout", suggestions, timedOut, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SuggestTags() took %s, expected it to stop at the budget", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, timedOut, err := service.SuggestTags(ctx, "bug", 0); err == nil || timedOut {
+		t.Errorf("SuggestTags() with a cancelled request = %v, %v; want an error", timedOut, err)
+	}
+}
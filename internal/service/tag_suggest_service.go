@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+)
+
+func (s *tagService) SuggestTags(ctx context.Context, query string, limit int) ([]*domain.TagSuggestion, bool, error) {
+	s.logger.Debug(ctx, "Suggesting tags", "query", query, "limit", limit)
+
+	query = s.formatTagName(query)
+	if query == "" {
+		return nil, false, domain.ErrInvalidInput("query is required")
+	}
+	if len(query) > 50 {
+		return nil, false, domain.ErrInvalidInput("query cannot exceed 50 characters")
+	}
+	if limit < 0 || limit > domain.MaxTagSuggestionLimit {
+		return nil, false, domain.ErrInvalidInput(fmt.Sprintf("limit must be between 0 and %d", domain.MaxTagSuggestionLimit))
+	}
+	if limit == 0 {
+		limit = domain.DefaultTagSuggestionLimit
+	}
+
+	userID, role := callerIdentity(ctx)
+	opts := repository.TagSuggestOptions{
+		Query:       query,
+		Trigram:     len([]rune(query)) >= domain.TagSuggestionMinTrigramQuery,
+		UserID:      userID,
+		AllTags:     role == domain.UserRoleAdmin,
+		RecentSince: time.Now().Add(-domain.TagSuggestionRecentWindow),
+		Limit:       domain.TagSuggestionCandidates,
+	}
+
+	suggestCtx, cancel := context.WithTimeout(ctx, s.suggestTimeout)
+	defer cancel()
+
+	candidates, err := s.tagRepo.Suggest(suggestCtx, opts)
+	if err != nil {
+		// Only our own budget is reported as a timeout; a cancelled request stays an error
+		if suggestCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			s.logger.Warn(ctx, "Tag suggestions timed out", "query", query, "budget", s.suggestTimeout)
+			return nil, true, nil
+		}
+		s.logger.Error(ctx, "Failed to suggest tags", "error", err, "query", query)
+		return nil, false, fmt.Errorf("failed to suggest tags: %w", err)
+	}
+
+	suggestions := domain.RankTagSuggestions(candidates, limit)
+	s.logger.Debug(ctx, "Suggested tags", "query", query, "candidates", len(candidates), "count", len(suggestions))
+	return suggestions, false, nil
+}
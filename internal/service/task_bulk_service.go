@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// bulkListPageSize is the page size used to resolve filter-based selections
+const bulkListPageSize = 500
+
+func (s *taskService) BulkUpdateTasks(ctx context.Context, selection repository.BulkTaskSelection, changes domain.TaskChanges, mode domain.BulkMode) (*domain.BulkResult, error) {
+	s.logger.Info(ctx, "Bulk updating tasks", "mode", mode, "explicit_tasks", len(selection.Tasks), "by_filter", selection.Filter != nil)
+
+	if err := s.validateTaskChanges(ctx, changes); err != nil {
+		return nil, err
+	}
+
+	refs, err := s.resolveBulkSelection(ctx, selection, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.runBulk(ctx, "update", refs, mode, nil, func(ctx context.Context, ref domain.TaskRef) (*domain.Task, error) {
+		return s.applyTaskChanges(ctx, ref, changes)
+	})
+}
+
+func (s *taskService) BulkDeleteTasks(ctx context.Context, selection repository.BulkTaskSelection, mode domain.BulkMode, cascade bool) (*domain.BulkResult, error) {
+	s.logger.Info(ctx, "Bulk deleting tasks", "mode", mode, "explicit_tasks", len(selection.Tasks), "by_filter", selection.Filter != nil, "cascade", cascade)
+
+	refs, err := s.resolveBulkSelection(ctx, selection, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// A selected task may already have gone with a selected parent
+	cascaded := newBulkCascades()
+	return s.runBulk(ctx, "delete", refs, mode, cascaded.commit, func(ctx context.Context, ref domain.TaskRef) (*domain.Task, error) {
+		if cascaded.done[ref.ID] {
+			return nil, nil
+		}
+		task, err := s.taskRepo.GetByID(ctx, ref.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := authorizeTaskEdit(ctx, task); err != nil {
+			return nil, err
+		}
+		deletedIDs, err := s.softDeleteTask(ctx, ref.ID, ref.Version, cascade)
+		if err != nil {
+			return nil, err
+		}
+		cascaded.pending[ref.ID] = deletedIDs
+		return nil, nil
+	})
+}
+
+func (s *taskService) BulkRestoreTasks(ctx context.Context, selection repository.BulkTaskSelection, mode domain.BulkMode, cascade bool) (*domain.BulkResult, error) {
+	s.logger.Info(ctx, "Bulk restoring tasks", "mode", mode, "explicit_tasks", len(selection.Tasks), "by_filter", selection.Filter != nil, "cascade", cascade)
+
+	refs, err := s.resolveBulkSelection(ctx, selection, true)
+	if err != nil {
+		return nil, err
+	}
+
+	cascaded := newBulkCascades()
+	return s.runBulk(ctx, "restore", refs, mode, cascaded.commit, func(ctx context.Context, ref domain.TaskRef) (*domain.Task, error) {
+		if cascaded.done[ref.ID] {
+			return s.taskRepo.GetByID(ctx, ref.ID)
+		}
+		task, err := s.taskRepo.GetDeletedByID(ctx, ref.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := authorizeTaskEdit(ctx, task); err != nil {
+			return nil, err
+		}
+
+		// Subtasks are matched by the task's deletion time, so they go first
+		var restoredIDs []string
+		if cascade {
+			if restoredIDs, err = s.taskRepo.RestoreDescendants(ctx, ref.ID); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.taskRepo.Restore(ctx, ref.ID, ref.Version); err != nil {
+			return nil, err
+		}
+
+		if err := s.recordHistory(ctx, ref.ID, domain.TaskHistoryActionRestored, cascadeHistory(cascade, restoredIDs)); err != nil {
+			return nil, err
+		}
+		cascaded.pending[ref.ID] = restoredIDs
+		for _, restoredID := range restoredIDs {
+			if err := s.recordHistory(ctx, restoredID, domain.TaskHistoryActionRestored, &domain.TaskHistoryDetails{
+				Metadata: map[string]interface{}{"cascaded_from": ref.ID},
+			}); err != nil {
+				return nil, err
+			}
+		}
+		return s.taskRepo.GetByID(ctx, ref.ID)
+	})
+}
+
+// softDeleteTask deletes a task, and its subtasks when cascading, and records the deletions.
+// It returns the IDs of the subtasks deleted along with the task.
+func (s *taskService) softDeleteTask(ctx context.Context, id string, version int64, cascade bool) ([]string, error) {
+	if err := s.taskRepo.SoftDelete(ctx, id, version); err != nil {
+		return nil, err
+	}
+
+	var deletedIDs []string
+	if cascade {
+		var err error
+		if deletedIDs, err = s.taskRepo.SoftDeleteDescendants(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.recordHistory(ctx, id, domain.TaskHistoryActionDeleted, cascadeHistory(cascade, deletedIDs)); err != nil {
+		return nil, err
+	}
+	for _, deletedID := range deletedIDs {
+		if err := s.recordHistory(ctx, deletedID, domain.TaskHistoryActionDeleted, &domain.TaskHistoryDetails{
+			Metadata: map[string]interface{}{"cascaded_from": id},
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return deletedIDs, nil
+}
+
+// bulkCascades tracks the subtasks a bulk delete or restore took along with their parents.
+// They only count as done once the parent's transaction has committed.
+type bulkCascades struct {
+	done    map[string]bool
+	pending map[string][]string // parent ID -> subtask IDs
+}
+
+func newBulkCascades() *bulkCascades {
+	return &bulkCascades{done: make(map[string]bool), pending: make(map[string][]string)}
+}
+
+func (c *bulkCascades) commit(ref domain.TaskRef) {
+	for _, id := range c.pending[ref.ID] {
+		c.done[id] = true
+	}
+	delete(c.pending, ref.ID)
+}
+
+// cascadeHistory describes a cascading delete or restore; plain ones keep their history bare
+func cascadeHistory(cascade bool, subtaskIDs []string) *domain.TaskHistoryDetails {
+	if !cascade {
+		return nil
+	}
+	return &domain.TaskHistoryDetails{
+		Metadata: map[string]interface{}{"cascade": true, "subtask_ids": subtaskIDs},
+	}
+}
+
+// runBulk applies fn to every task. In all-or-nothing mode everything runs in one
+// transaction and the first failure rolls back the batch; in best-effort mode each
+// task gets its own transaction. committed, if set, is told about each task whose
+// changes are kept, before the next task is processed.
+func (s *taskService) runBulk(
+	ctx context.Context,
+	operation string,
+	refs []domain.TaskRef,
+	mode domain.BulkMode,
+	committed func(ref domain.TaskRef),
+	fn func(ctx context.Context, ref domain.TaskRef) (*domain.Task, error),
+) (*domain.BulkResult, error) {
+	if committed == nil {
+		committed = func(domain.TaskRef) {}
+	}
+
+	result := &domain.BulkResult{Results: make([]domain.BulkItemResult, len(refs))}
+
+	if mode == domain.BulkModeBestEffort {
+		for i, ref := range refs {
+			var task *domain.Task
+			err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+				var err error
+				task, err = fn(txCtx, ref)
+				return err
+			})
+			result.Results[i] = domain.BulkItemResult{TaskID: ref.ID, Success: err == nil, Task: task, Error: err}
+			if err == nil {
+				committed(ref)
+			}
+		}
+	} else {
+		failedIndex := -1
+		err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			for i, ref := range refs {
+				task, err := fn(txCtx, ref)
+				if err != nil {
+					failedIndex = i
+					result.Results[i] = domain.BulkItemResult{TaskID: ref.ID, Error: err}
+					return err
+				}
+				result.Results[i] = domain.BulkItemResult{TaskID: ref.ID, Success: true, Task: task}
+				// Later tasks only run if the batch is still going to commit
+				committed(ref)
+			}
+			return nil
+		})
+
+		if err != nil {
+			if failedIndex < 0 {
+				s.logger.Error(ctx, "Bulk task operation failed", "operation", operation, "error", err)
+				return nil, fmt.Errorf("failed to %s tasks: %w", operation, err)
+			}
+
+			result.RolledBack = true
+			for i, ref := range refs {
+				if i != failedIndex {
+					result.Results[i] = domain.BulkItemResult{TaskID: ref.ID, Error: domain.ErrBulkRolledBack()}
+				}
+			}
+		}
+	}
+
+	for _, item := range result.Results {
+		if item.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+
+	s.logger.Info(ctx, "Bulk task operation finished",
+		"operation", operation, "succeeded", result.Succeeded, "failed", result.Failed, "rolled_back", result.RolledBack)
+	return result, nil
+}
+
+// resolveBulkSelection turns explicit references or a filter into task references
+func (s *taskService) resolveBulkSelection(ctx context.Context, selection repository.BulkTaskSelection, deleted bool) ([]domain.TaskRef, error) {
+	if (len(selection.Tasks) > 0) == (selection.Filter != nil) {
+		return nil, domain.ErrInvalidInput("either task references or a filter must be provided")
+	}
+
+	if selection.Filter == nil {
+		if len(selection.Tasks) > domain.MaxBulkTasks {
+			return nil, domain.ErrInvalidInput(fmt.Sprintf("at most %d tasks can be changed at once", domain.MaxBulkTasks))
+		}
+
+		seen := make(map[string]bool, len(selection.Tasks))
+		for _, ref := range selection.Tasks {
+			if ref.ID == "" {
+				return nil, domain.ErrInvalidInput("task ID is required")
+			}
+			if seen[ref.ID] {
+				return nil, domain.ErrInvalidInput(fmt.Sprintf("task %s is listed more than once", ref.ID))
+			}
+			seen[ref.ID] = true
+		}
+		return selection.Tasks, nil
+	}
+
+	opts := *selection.Filter
+	opts.DeletedOnly = deleted
+	opts.PageSize = bulkListPageSize
+
+	var refs []domain.TaskRef
+	for opts.Page = 0; ; opts.Page++ {
+		tasks, total, err := s.taskRepo.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve tasks: %w", err)
+		}
+		if total > domain.MaxBulkTasks {
+			return nil, domain.ErrInvalidInput(fmt.Sprintf("filter matches %d tasks; at most %d can be changed at once", total, domain.MaxBulkTasks))
+		}
+
+		for _, task := range tasks {
+			refs = append(refs, domain.TaskRef{ID: task.ID, Version: task.Version})
+		}
+		if len(tasks) < bulkListPageSize || int64(len(refs)) >= total {
+			break
+		}
+	}
+
+	return refs, nil
+}
+
+// applyTaskChanges updates a single task through the same checks as a single update
+func (s *taskService) applyTaskChanges(ctx context.Context, ref domain.TaskRef, changes domain.TaskChanges) (*domain.Task, error) {
+	existing, err := s.taskRepo.GetByID(ctx, ref.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Version != ref.Version {
+		return nil, domain.ErrVersionConflict("task", ref.Version, existing.Version)
+	}
+	if err := authorizeTaskEdit(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	task := *existing
+	if changes.AssigneeID != nil {
+		task.AssigneeID = *changes.AssigneeID
+	}
+	if changes.Priority != nil {
+		task.Priority = *changes.Priority
+	}
+	if changes.Status != nil {
+		task.Status = *changes.Status
+	}
+
+	// Nothing to do; the task already has the requested values
+	details := taskUpdateHistory(existing, &task)
+	if len(details.Changes) == 0 {
+		return existing, nil
+	}
+	details.Metadata = map[string]interface{}{"bulk": true}
+
+	if err := s.validateTaskForUpdate(ctx, existing, &task); err != nil {
+		return nil, err
+	}
+
+	// Single-field changes use the more specific history actions
+	action := domain.TaskHistoryActionUpdated
+	if len(details.Changes) == 1 {
+		switch {
+		case details.Changes[0] == "assignee_id":
+			action = domain.TaskHistoryActionAssigned
+		case details.Changes[0] == "status" && task.Status == domain.TaskStatusCompleted:
+			action = domain.TaskHistoryActionCompleted
+		}
+	}
+
+	if err := s.saveTaskUpdate(ctx, existing, &task, action, details); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (s *taskService) validateTaskChanges(ctx context.Context, changes domain.TaskChanges) error {
+	if changes.IsEmpty() {
+		return domain.ErrInvalidInput("no changes requested")
+	}
+
+	if changes.Status != nil && !isKnownTaskStatus(*changes.Status) {
+		return domain.ErrInvalidInput(fmt.Sprintf("invalid status: %s", *changes.Status))
+	}
+
+	if changes.Priority != nil && !isKnownTaskPriority(*changes.Priority) {
+		return domain.ErrInvalidInput(fmt.Sprintf("invalid priority: %s", *changes.Priority))
+	}
+
+	// Validate the assignee once rather than per task
+	if changes.AssigneeID != nil {
+		if *changes.AssigneeID == "" {
+			return domain.ErrInvalidInput("assignee is required")
+		}
+		if _, err := s.userRepo.GetByID(ctx, *changes.AssigneeID); err != nil {
+			if domain.IsNotFoundError(err) {
+				return domain.ErrInvalidInput("assignee does not exist")
+			}
+			return fmt.Errorf("failed to validate assignee: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recordHistory writes a task history entry attributed to the current user
+func (s *taskService) recordHistory(ctx context.Context, taskID string, action domain.TaskHistoryAction, details *domain.TaskHistoryDetails) error {
+	return recordTaskHistory(ctx, s.taskRepo, taskID, action, details)
+}
+
+func recordTaskHistory(ctx context.Context, taskRepo repository.TaskRepository, taskID string, action domain.TaskHistoryAction, details *domain.TaskHistoryDetails) error {
+	entry := &domain.TaskHistory{
+		TaskID:  taskID,
+		Action:  action,
+		ActorID: actorID(ctx),
+	}
+
+	if details != nil {
+		data, err := json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("failed to encode history details: %w", err)
+		}
+		entry.Details = data
+	}
+
+	return taskRepo.AddHistory(ctx, entry)
+}
+
+// actorID returns the authenticated user, falling back to the system user
+func actorID(ctx context.Context) string {
+	if userID := requestctx.UserID(ctx); userID != "" {
+		return userID
+	}
+	return domain.SystemUserID
+}
+
+func isKnownTaskStatus(status domain.TaskStatus) bool {
+	switch status {
+	case domain.TaskStatusOpen, domain.TaskStatusInProgress, domain.TaskStatusInReview,
+		domain.TaskStatusBlocked, domain.TaskStatusCompleted, domain.TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func isKnownTaskPriority(priority domain.TaskPriority) bool {
+	switch priority {
+	case domain.TaskPriorityLow, domain.TaskPriorityMedium, domain.TaskPriorityHigh, domain.TaskPriorityUrgent:
+		return true
+	}
+	return false
+}
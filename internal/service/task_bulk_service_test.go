@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/testutil"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+func setupBulkTest(t *testing.T) (TaskService, *mockTaskRepository, *mockUserRepository, *mockTransactionManager, []*domain.Task) {
+	t.Helper()
+
+	mockTaskRepo := newMockTaskRepository()
+	mockUserRepo := newMockUserRepository()
+	txManager := &mockTransactionManager{}
+	service := NewTaskService(mockTaskRepo, mockUserRepo, newMockCategoryRepository(), newMockTagRepository(), newMockWorkflowRepository(), newMockTaskSeriesRepository(), txManager, domain.SubtaskRules{}, logger.NewLogger("error"))
+
+	ctx := context.Background()
+	assignee := testutil.TestUser()
+	mockUserRepo.Create(ctx, assignee)
+	var tasks []*domain.Task
+	for _, title := range []string{"Alpha", "Beta", "Gamma"} {
+		task := testutil.TestTask(assignee.ID)
+		task.Title = title
+		mockTaskRepo.Create(ctx, task)
+		tasks = append(tasks, task)
+	}
+
+	return service, mockTaskRepo, mockUserRepo, txManager, tasks
+}
+
+func refsOf(tasks ...*domain.Task) []domain.TaskRef {
+	refs := make([]domain.TaskRef, 0, len(tasks))
+	for _, task := range tasks {
+		refs = append(refs, domain.TaskRef{ID: task.ID, Version: task.Version})
+	}
+	return refs
+}
+
+func TestTaskService_BulkUpdateTasks(t *testing.T) {
+	ctx := requestctx.WithUser(context.Background(), "admin-1", "admin")
+	completed := domain.TaskStatusCompleted
+	high := domain.TaskPriorityHigh
+
+	t.Run("updates every task and records history", func(t *testing.T) {
+		service, mockTaskRepo, _, _, tasks := setupBulkTest(t)
+
+		result, err := service.BulkUpdateTasks(ctx, repository.BulkTaskSelection{Tasks: refsOf(tasks...)},
+			domain.TaskChanges{Status: &completed}, domain.BulkModeAllOrNothing)
+		if err != nil {
+			t.Fatalf("BulkUpdateTasks() error = %v", err)
+		}
+		if result.Succeeded != 3 || result.Failed != 0 || result.RolledBack {
+			t.Errorf("result = %+v, want 3 succeeded", result)
+		}
+		for _, task := range tasks {
+			if task.Status != domain.TaskStatusCompleted {
+				t.Errorf("task %s status = %s, want COMPLETED", task.ID, task.Status)
+			}
+		}
+		if len(mockTaskRepo.history) != 3 {
+			t.Fatalf("history entries = %d, want 3", len(mockTaskRepo.history))
+		}
+		entry := mockTaskRepo.history[0]
+		if entry.Action != domain.TaskHistoryActionCompleted || entry.ActorID != "admin-1" {
+			t.Errorf("history entry = %+v, want COMPLETED by admin-1", entry)
+		}
+	})
+
+	t.Run("all-or-nothing reports the batch as rolled back", func(t *testing.T) {
+		service, _, _, _, tasks := setupBulkTest(t)
+		refs := refsOf(tasks...)
+		refs[1].Version = 99 // stale version
+
+		result, err := service.BulkUpdateTasks(ctx, repository.BulkTaskSelection{Tasks: refs},
+			domain.TaskChanges{Priority: &high}, domain.BulkModeAllOrNothing)
+		if err != nil {
+			t.Fatalf("BulkUpdateTasks() error = %v", err)
+		}
+		if !result.RolledBack || result.Succeeded != 0 || result.Failed != 3 {
+			t.Errorf("result = %+v, want rolled back with 3 failures", result)
+		}
+		if !domain.IsVersionConflictError(result.Results[1].Error) {
+			t.Errorf("failing item error = %v, want version conflict", result.Results[1].Error)
+		}
+		if result.Results[0].Error == nil || domain.IsVersionConflictError(result.Results[0].Error) {
+			t.Errorf("other item error = %v, want rolled back error", result.Results[0].Error)
+		}
+	})
+
+	t.Run("best-effort applies the valid items", func(t *testing.T) {
+		service, _, _, txManager, tasks := setupBulkTest(t)
+		refs := refsOf(tasks...)
+		refs[1].Version = 99
+
+		result, err := service.BulkUpdateTasks(ctx, repository.BulkTaskSelection{Tasks: refs},
+			domain.TaskChanges{Priority: &high}, domain.BulkModeBestEffort)
+		if err != nil {
+			t.Fatalf("BulkUpdateTasks() error = %v", err)
+		}
+		if result.RolledBack || result.Succeeded != 2 || result.Failed != 1 {
+			t.Errorf("result = %+v, want 2 succeeded and 1 failed", result)
+		}
+		if txManager.calls != 3 {
+			t.Errorf("transactions = %d, want one per task", txManager.calls)
+		}
+	})
+
+	t.Run("selects tasks by filter", func(t *testing.T) {
+		service, _, mockUserRepo, _, tasks := setupBulkTest(t)
+		assignee := testutil.TestUser()
+		mockUserRepo.Create(ctx, assignee)
+
+		result, err := service.BulkUpdateTasks(ctx, repository.BulkTaskSelection{Filter: &repository.TaskListOptions{}},
+			domain.TaskChanges{AssigneeID: &assignee.ID}, domain.BulkModeBestEffort)
+		if err != nil {
+			t.Fatalf("BulkUpdateTasks() error = %v", err)
+		}
+		if result.Succeeded != len(tasks) {
+			t.Errorf("succeeded = %d, want %d", result.Succeeded, len(tasks))
+		}
+	})
+
+	invalid := []struct {
+		name      string
+		selection repository.BulkTaskSelection
+		changes   domain.TaskChanges
+	}{
+		{name: "no changes", selection: repository.BulkTaskSelection{Tasks: []domain.TaskRef{{ID: "a", Version: 1}}}},
+		{name: "no selection", changes: domain.TaskChanges{Priority: &high}},
+		{
+			name:      "both references and filter",
+			selection: repository.BulkTaskSelection{Tasks: []domain.TaskRef{{ID: "a", Version: 1}}, Filter: &repository.TaskListOptions{}},
+			changes:   domain.TaskChanges{Priority: &high},
+		},
+		{
+			name:      "clearing the assignee",
+			selection: repository.BulkTaskSelection{Tasks: []domain.TaskRef{{ID: "a", Version: 1}}},
+			changes:   domain.TaskChanges{AssigneeID: new(string)},
+		},
+		{
+			name:      "duplicate references",
+			selection: repository.BulkTaskSelection{Tasks: []domain.TaskRef{{ID: "a", Version: 1}, {ID: "a", Version: 1}}},
+			changes:   domain.TaskChanges{Priority: &high},
+		},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _, _, _ := setupBulkTest(t)
+			_, err := service.BulkUpdateTasks(ctx, tt.selection, tt.changes, domain.BulkModeBestEffort)
+			if !domain.IsInvalidInputError(err) {
+				t.Errorf("BulkUpdateTasks() error = %v, want invalid input", err)
+			}
+		})
+	}
+}
+
+func TestTaskService_BulkDeleteAndRestoreTasks(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	service, mockTaskRepo, _, _, tasks := setupBulkTest(t)
+
+	result, err := service.BulkDeleteTasks(ctx, repository.BulkTaskSelection{Tasks: refsOf(tasks[:2]...)}, domain.BulkModeAllOrNothing, false)
+	if err != nil {
+		t.Fatalf("BulkDeleteTasks() error = %v", err)
+	}
+	if result.Succeeded != 2 {
+		t.Fatalf("deleted = %d, want 2", result.Succeeded)
+	}
+	if len(mockTaskRepo.tasks) != 1 {
+		t.Errorf("remaining tasks = %d, want 1", len(mockTaskRepo.tasks))
+	}
+
+	// Restoring by filter only matches deleted tasks
+	result, err = service.BulkRestoreTasks(ctx, repository.BulkTaskSelection{Filter: &repository.TaskListOptions{}}, domain.BulkModeAllOrNothing, false)
+	if err != nil {
+		t.Fatalf("BulkRestoreTasks() error = %v", err)
+	}
+	if result.Succeeded != 2 {
+		t.Errorf("restored = %d, want 2", result.Succeeded)
+	}
+	if len(mockTaskRepo.tasks) != 3 {
+		t.Errorf("tasks after restore = %d, want 3", len(mockTaskRepo.tasks))
+	}
+
+	actions := map[domain.TaskHistoryAction]int{}
+	for _, entry := range mockTaskRepo.history {
+		actions[entry.Action]++
+		if entry.ActorID != domain.SystemUserID {
+			t.Errorf("history actor = %s, want system user without authenticated user", entry.ActorID)
+		}
+	}
+	if actions[domain.TaskHistoryActionDeleted] != 2 || actions[domain.TaskHistoryActionRestored] != 2 {
+		t.Errorf("history actions = %v, want 2 deletions and 2 restorations", actions)
+	}
+}
+
+func TestTaskService_BulkDeleteTasks_FailedCascade(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	service, _, _, txManager, tasks := setupBulkTest(t)
+	tasks[1].ParentID = &tasks[0].ID
+
+	// The parent's delete takes the subtask along but does not commit, so the subtask is
+	// not done yet. The mock does not roll back, which leaves it looked up again and missing.
+	txManager.failCommit = 1
+	result, err := service.BulkDeleteTasks(ctx, repository.BulkTaskSelection{Tasks: refsOf(tasks[0], tasks[1])}, domain.BulkModeBestEffort, true)
+	if err != nil {
+		t.Fatalf("BulkDeleteTasks() error = %v", err)
+	}
+	if result.Results[0].Success || result.Results[1].Success {
+		t.Errorf("result = %+v, want the subtask not reported as deleted with its parent", result)
+	}
+}
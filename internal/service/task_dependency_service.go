@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func (s *taskService) AddTaskDependency(ctx context.Context, taskID, blockerID string) (*domain.TaskDependency, error) {
+	s.logger.Info(ctx, "Adding task dependency", "task_id", taskID, "blocker_id", blockerID)
+
+	if taskID == "" || blockerID == "" {
+		return nil, domain.ErrInvalidInput("task ID and blocker ID are required")
+	}
+	if taskID == blockerID {
+		return nil, domain.ErrBusinessRule("a task cannot block itself")
+	}
+
+	task, err := s.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return nil, err
+	}
+	if _, err := s.taskRepo.GetByID(ctx, blockerID); err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, domain.ErrInvalidInput("blocking task not found")
+		}
+		return nil, fmt.Errorf("failed to get blocking task: %w", err)
+	}
+
+	blocked, err := s.taskRepo.IsBlockedBy(ctx, blockerID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check task dependencies: %w", err)
+	}
+	if blocked {
+		return nil, domain.ErrBusinessRule("the dependency would make the task block itself")
+	}
+
+	dependency := &domain.TaskDependency{TaskID: taskID, BlockerID: blockerID, CreatorID: actorID(ctx)}
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.taskRepo.AddDependency(txCtx, dependency); err != nil {
+			return err
+		}
+		return s.recordHistory(txCtx, taskID, domain.TaskHistoryActionUpdated, &domain.TaskHistoryDetails{
+			Changes:  []string{"blockers"},
+			Metadata: map[string]interface{}{"blocker_added": blockerID},
+		})
+	})
+	if err != nil {
+		if domain.IsConflictError(err) || domain.IsBusinessRuleError(err) {
+			s.logger.Warn(ctx, "Task dependency rejected", "task_id", taskID, "blocker_id", blockerID, "error", err)
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to add task dependency", "error", err, "task_id", taskID)
+		return nil, fmt.Errorf("failed to add task dependency: %w", err)
+	}
+
+	s.logger.Info(ctx, "Task dependency added successfully", "task_id", taskID, "blocker_id", blockerID)
+	return dependency, nil
+}
+
+func (s *taskService) RemoveTaskDependency(ctx context.Context, taskID, blockerID string) error {
+	s.logger.Info(ctx, "Removing task dependency", "task_id", taskID, "blocker_id", blockerID)
+
+	if taskID == "" || blockerID == "" {
+		return domain.ErrInvalidInput("task ID and blocker ID are required")
+	}
+
+	task, err := s.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.taskRepo.RemoveDependency(txCtx, taskID, blockerID); err != nil {
+			return err
+		}
+		return s.recordHistory(txCtx, taskID, domain.TaskHistoryActionUpdated, &domain.TaskHistoryDetails{
+			Changes:  []string{"blockers"},
+			Metadata: map[string]interface{}{"blocker_removed": blockerID},
+		})
+	})
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return err
+		}
+		s.logger.Error(ctx, "Failed to remove task dependency", "error", err, "task_id", taskID)
+		return fmt.Errorf("failed to remove task dependency: %w", err)
+	}
+
+	s.logger.Info(ctx, "Task dependency removed successfully", "task_id", taskID, "blocker_id", blockerID)
+	return nil
+}
+
+func (s *taskService) ListTaskBlockers(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	s.logger.Debug(ctx, "Listing task blockers", "task_id", taskID)
+
+	if _, err := s.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	blockers, err := s.taskRepo.ListBlockers(ctx, taskID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list task blockers", "error", err, "task_id", taskID)
+		return nil, fmt.Errorf("failed to list task blockers: %w", err)
+	}
+	return blockers, nil
+}
+
+func (s *taskService) ListTaskDependents(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	s.logger.Debug(ctx, "Listing task dependents", "task_id", taskID)
+
+	if _, err := s.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	dependents, err := s.taskRepo.ListDependents(ctx, taskID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list task dependents", "error", err, "task_id", taskID)
+		return nil, fmt.Errorf("failed to list task dependents: %w", err)
+	}
+	return dependents, nil
+}
+
+func (s *taskService) GetCriticalPath(ctx context.Context, taskIDs []string) (*domain.CriticalPath, error) {
+	s.logger.Debug(ctx, "Computing critical path", "task_count", len(taskIDs))
+
+	if len(taskIDs) == 0 {
+		return nil, domain.ErrInvalidInput("at least one task ID is required")
+	}
+	if len(taskIDs) > domain.MaxCriticalPathTasks {
+		return nil, domain.ErrInvalidInput(fmt.Sprintf("a critical path covers at most %d tasks", domain.MaxCriticalPathTasks))
+	}
+
+	seen := make(map[string]bool, len(taskIDs))
+	var ids []string
+	var tasks []*domain.Task
+	for _, id := range taskIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		task, err := s.taskRepo.GetByID(ctx, id)
+		if err != nil {
+			if domain.IsNotFoundError(err) {
+				return nil, domain.ErrInvalidInput(fmt.Sprintf("task %s does not exist", id))
+			}
+			return nil, fmt.Errorf("failed to get task %s: %w", id, err)
+		}
+		ids = append(ids, id)
+		tasks = append(tasks, task)
+	}
+
+	dependencies, err := s.taskRepo.ListDependencies(ctx, ids)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list task dependencies", "error", err)
+		return nil, fmt.Errorf("failed to compute critical path: %w", err)
+	}
+
+	return domain.ComputeCriticalPath(tasks, dependencies), nil
+}
+
+// checkBlockers refuses to start or complete a task while any of its blockers is open
+func (s *taskService) checkBlockers(ctx context.Context, task *domain.Task, status domain.TaskStatus) error {
+	if !domain.RequiresClosedBlockers(status) {
+		return nil
+	}
+
+	blockers, err := s.taskRepo.ListBlockers(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get task blockers: %w", err)
+	}
+	open := 0
+	for _, blocker := range blockers {
+		if domain.IsOpenBlocker(blocker) {
+			open++
+		}
+	}
+	if open > 0 {
+		return domain.ErrBusinessRule(fmt.Sprintf("task is blocked by %d open tasks", open))
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+// taskExportEncoder writes exported tasks one at a time
+type taskExportEncoder interface {
+	begin() error
+	encode(record *domain.TaskExportRecord) error
+	end() error
+}
+
+func newTaskExportEncoder(w io.Writer, format domain.ExportFormat, columns []string, loc *time.Location) taskExportEncoder {
+	switch format {
+	case domain.ExportFormatJSONL:
+		return &jsonlExportEncoder{w: bufio.NewWriter(w), columns: columns, loc: loc}
+	case domain.ExportFormatICalendar:
+		return &icalExportEncoder{w: bufio.NewWriter(w), loc: loc}
+	default:
+		return &csvExportEncoder{w: csv.NewWriter(w), columns: columns, loc: loc}
+	}
+}
+
+// exportTime renders a timestamp in the export time zone
+func exportTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(time.RFC3339)
+}
+
+func exportNames(record *domain.TaskExportRecord, column string) []string {
+	names := []string{}
+	if column == domain.ExportColumnCategories {
+		for _, category := range record.Task.Categories {
+			names = append(names, category.Name)
+		}
+		return names
+	}
+	for _, tag := range record.Task.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// exportValue returns a column as a JSON value: strings, numbers, lists or nil
+func exportValue(record *domain.TaskExportRecord, column string, loc *time.Location) interface{} {
+	task := record.Task
+	switch column {
+	case domain.ExportColumnID:
+		return task.ID
+	case domain.ExportColumnTitle:
+		return task.Title
+	case domain.ExportColumnDescription:
+		return task.Description
+	case domain.ExportColumnStatus:
+		return string(task.Status)
+	case domain.ExportColumnPriority:
+		return string(task.Priority)
+	case domain.ExportColumnAssigneeID:
+		return task.AssigneeID
+	case domain.ExportColumnAssigneeEmail:
+		return record.AssigneeEmail
+	case domain.ExportColumnDueDate:
+		if task.DueDate == nil {
+			return nil
+		}
+		return exportTime(*task.DueDate, loc)
+	case domain.ExportColumnCategories, domain.ExportColumnTags:
+		return exportNames(record, column)
+	case domain.ExportColumnCreatedAt:
+		return exportTime(task.CreatedAt, loc)
+	case domain.ExportColumnUpdatedAt:
+		return exportTime(task.UpdatedAt, loc)
+	case domain.ExportColumnVersion:
+		return task.Version
+	default:
+		return nil
+	}
+}
+
+type csvExportEncoder struct {
+	w       *csv.Writer
+	columns []string
+	loc     *time.Location
+}
+
+func (e *csvExportEncoder) begin() error {
+	return e.w.Write(e.columns)
+}
+
+func (e *csvExportEncoder) encode(record *domain.TaskExportRecord) error {
+	row := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		switch value := exportValue(record, column, e.loc).(type) {
+		case nil:
+		case string:
+			row[i] = value
+		case int64:
+			row[i] = strconv.FormatInt(value, 10)
+		case []string:
+			// Semicolons keep the cell importable
+			row[i] = strings.Join(value, ";")
+		}
+	}
+	return e.w.Write(row)
+}
+
+func (e *csvExportEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonlExportEncoder struct {
+	w       *bufio.Writer
+	columns []string
+	loc     *time.Location
+}
+
+func (e *jsonlExportEncoder) begin() error {
+	return nil
+}
+
+// encode writes the selected columns in order, which a map would not preserve
+func (e *jsonlExportEncoder) encode(record *domain.TaskExportRecord) error {
+	e.w.WriteByte('{')
+	for i, column := range e.columns {
+		if i > 0 {
+			e.w.WriteByte(',')
+		}
+		value, err := json.Marshal(exportValue(record, column, e.loc))
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", column, err)
+		}
+		fmt.Fprintf(e.w, "%q:", column)
+		e.w.Write(value)
+	}
+	e.w.WriteString("}\n")
+	return nil
+}
+
+func (e *jsonlExportEncoder) end() error {
+	return e.w.Flush()
+}
+
+// icalExportEncoder writes an RFC 5545 calendar with one VTODO per task
+type icalExportEncoder struct {
+	w   *bufio.Writer
+	loc *time.Location
+}
+
+const icalTimeFormat = "20060102T150405Z"
+
+func (e *icalExportEncoder) begin() error {
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:-//Todo App//Admin Service//EN")
+	e.line("CALSCALE:GREGORIAN")
+	if e.loc != time.UTC {
+		e.line("X-WR-TIMEZONE:" + e.loc.String())
+	}
+	return nil
+}
+
+func (e *icalExportEncoder) encode(record *domain.TaskExportRecord) error {
+	task := record.Task
+
+	e.line("BEGIN:VTODO")
+	e.line("UID:" + task.ID)
+	e.line("DTSTAMP:" + task.UpdatedAt.UTC().Format(icalTimeFormat))
+	e.line("CREATED:" + task.CreatedAt.UTC().Format(icalTimeFormat))
+	e.line("LAST-MODIFIED:" + task.UpdatedAt.UTC().Format(icalTimeFormat))
+	e.line("SEQUENCE:" + strconv.FormatInt(max(task.Version-1, 0), 10))
+	e.line("SUMMARY:" + icalEscape(task.Title))
+	if task.Description != "" {
+		e.line("DESCRIPTION:" + icalEscape(task.Description))
+	}
+	if status := icalStatus(task.Status); status != "" {
+		e.line("STATUS:" + status)
+	}
+	if priority := icalPriority(task.Priority); priority != 0 {
+		e.line("PRIORITY:" + strconv.Itoa(priority))
+	}
+
+	if task.DueDate != nil {
+		// Midnight in the export time zone is treated as a date-only due date
+		local := task.DueDate.In(e.loc)
+		if local.Hour() == 0 && local.Minute() == 0 && local.Second() == 0 {
+			e.line("DUE;VALUE=DATE:" + local.Format("20060102"))
+		} else {
+			e.line("DUE:" + task.DueDate.UTC().Format(icalTimeFormat))
+		}
+	}
+
+	var categories []string
+	for _, name := range append(exportNames(record, domain.ExportColumnCategories), exportNames(record, domain.ExportColumnTags)...) {
+		categories = append(categories, icalEscape(name))
+	}
+	if len(categories) > 0 {
+		e.line("CATEGORIES:" + strings.Join(categories, ","))
+	}
+
+	if record.AssigneeEmail != "" {
+		e.line("ATTENDEE;ROLE=REQ-PARTICIPANT:mailto:" + record.AssigneeEmail)
+	}
+
+	for _, reminder := range record.Reminders {
+		description := reminder.Message
+		if description == "" {
+			description = task.Title
+		}
+		e.line("BEGIN:VALARM")
+		e.line("ACTION:DISPLAY")
+		e.line("DESCRIPTION:" + icalEscape(description))
+		e.line("TRIGGER;VALUE=DATE-TIME:" + reminder.RemindAt.UTC().Format(icalTimeFormat))
+		e.line("END:VALARM")
+	}
+
+	e.line("END:VTODO")
+	return nil
+}
+
+func (e *icalExportEncoder) end() error {
+	e.line("END:VCALENDAR")
+	return e.w.Flush()
+}
+
+// line writes a content line, folded at 75 octets without splitting UTF-8 characters
+func (e *icalExportEncoder) line(content string) {
+	limit := 75
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		e.w.WriteString(content[:cut])
+		e.w.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = 74
+	}
+	e.w.WriteString(content)
+	e.w.WriteString("\r\n")
+}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icalEscape(text string) string {
+	return icalEscaper.Replace(text)
+}
+
+func icalStatus(status domain.TaskStatus) string {
+	switch status {
+	case domain.TaskStatusOpen, domain.TaskStatusBlocked:
+		return "NEEDS-ACTION"
+	case domain.TaskStatusInProgress, domain.TaskStatusInReview:
+		return "IN-PROCESS"
+	case domain.TaskStatusCompleted:
+		return "COMPLETED"
+	case domain.TaskStatusCancelled:
+		return "CANCELLED"
+	default:
+		return ""
+	}
+}
+
+// icalPriority maps priorities onto the RFC 5545 scale, where 1 is highest
+func icalPriority(priority domain.TaskPriority) int {
+	switch priority {
+	case domain.TaskPriorityUrgent:
+		return 1
+	case domain.TaskPriorityHigh:
+		return 3
+	case domain.TaskPriorityMedium:
+		return 5
+	case domain.TaskPriorityLow:
+		return 9
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func exportTestRecord() *domain.TaskExportRecord {
+	due := time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC) // midnight in Europe/Berlin
+	return &domain.TaskExportRecord{
+		Task: &domain.Task{
+			ID:          "task-1",
+			Title:       "Ship release",
+			Description: "Tag, build; publish\nthen announce",
+			Status:      domain.TaskStatusInProgress,
+			Priority:    domain.TaskPriorityHigh,
+			AssigneeID:  "user-1",
+			DueDate:     &due,
+			Categories:  []domain.Category{{Name: "Work"}},
+			Tags:        []domain.Tag{{Name: "backend"}, {Name: "release"}},
+			CreatedAt:   time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+			UpdatedAt:   time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC),
+			Version:     3,
+		},
+		AssigneeEmail: "dev@example.com",
+		Reminders: []domain.TaskReminder{
+			{RemindAt: time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC), Message: "Release day"},
+			{RemindAt: time.Date(2024, 3, 9, 8, 0, 0, 0, time.UTC)},
+		},
+	}
+}
+
+func encodeExport(t *testing.T, format domain.ExportFormat, columns []string, loc *time.Location, records ...*domain.TaskExportRecord) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := newTaskExportEncoder(&buf, format, columns, loc)
+	if err := encoder.begin(); err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	for _, record := range records {
+		if err := encoder.encode(record); err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+	}
+	if err := encoder.end(); err != nil {
+		t.Fatalf("end: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCSVExportEncoder(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	out := encodeExport(t, domain.ExportFormatCSV,
+		[]string{"title", "due_date", "tags", "version"}, berlin, exportTestRecord())
+
+	want := "title,due_date,tags,version\nShip release,2024-03-11T00:00:00+01:00,backend;release,3\n"
+	if out != want {
+		t.Errorf("CSV output = %q, want %q", out, want)
+	}
+}
+
+func TestCSVExportEncoder_RoundTripsThroughImport(t *testing.T) {
+	out := encodeExport(t, domain.ExportFormatCSV, domain.ExportColumns, time.UTC, exportTestRecord())
+
+	reader, err := newImportReader(domain.ImportFormatCSV, strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("newImportReader: %v", err)
+	}
+	record, err := reader.next()
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+
+	if record.title != "Ship release" || record.assignee != "dev@example.com" {
+		t.Errorf("got title %q assignee %q", record.title, record.assignee)
+	}
+	if strings.Join(record.tags, "|") != "backend|release" {
+		t.Errorf("tags = %v", record.tags)
+	}
+	if record.dueDate != "2024-03-10T23:00:00Z" {
+		t.Errorf("due date = %q", record.dueDate)
+	}
+}
+
+func TestJSONLExportEncoder(t *testing.T) {
+	record := exportTestRecord()
+	undated := exportTestRecord()
+	undated.Task.DueDate = nil
+	undated.Task.Tags = nil
+
+	out := encodeExport(t, domain.ExportFormatJSONL,
+		[]string{"id", "due_date", "tags", "version"}, time.UTC, record, undated)
+
+	want := `{"id":"task-1","due_date":"2024-03-10T23:00:00Z","tags":["backend","release"],"version":3}` + "\n" +
+		`{"id":"task-1","due_date":null,"tags":[],"version":3}` + "\n"
+	if out != want {
+		t.Errorf("JSONL output = %q, want %q", out, want)
+	}
+}
+
+func TestICalExportEncoder(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	out := encodeExport(t, domain.ExportFormatICalendar, nil, berlin, exportTestRecord())
+
+	for _, line := range []string{
+		"BEGIN:VCALENDAR",
+		"X-WR-TIMEZONE:Europe/Berlin",
+		"BEGIN:VTODO",
+		"UID:task-1",
+		"SEQUENCE:2",
+		"SUMMARY:Ship release",
+		`DESCRIPTION:Tag\, build\; publish\nthen announce`,
+		"STATUS:IN-PROCESS",
+		"PRIORITY:3",
+		"DUE;VALUE=DATE:20240311",
+		"CATEGORIES:Work,backend,release",
+		"ATTENDEE;ROLE=REQ-PARTICIPANT:mailto:dev@example.com",
+		"DESCRIPTION:Release day",
+		"TRIGGER;VALUE=DATE-TIME:20240310T080000Z",
+		"DESCRIPTION:Ship release",
+		"END:VTODO",
+		"END:VCALENDAR",
+	} {
+		if !strings.Contains(out, line+"\r\n") {
+			t.Errorf("output is missing line %q:\n%s", line, out)
+		}
+	}
+	if got := strings.Count(out, "BEGIN:VALARM"); got != 2 {
+		t.Errorf("got %d VALARM components, want 2", got)
+	}
+
+	// A due time that is not midnight in the export zone keeps its time
+	timed := exportTestRecord()
+	due := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+	timed.Task.DueDate = &due
+	if out := encodeExport(t, domain.ExportFormatICalendar, nil, berlin, timed); !strings.Contains(out, "DUE:20240310T153000Z\r\n") {
+		t.Errorf("expected a UTC date-time DUE:\n%s", out)
+	}
+}
+
+func TestICalExportEncoder_FoldsLongLines(t *testing.T) {
+	record := exportTestRecord()
+	record.Task.Title = strings.Repeat("ü", 100)
+
+	out := encodeExport(t, domain.ExportFormatICalendar, nil, time.UTC, record)
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line exceeds 75 octets: %q", line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a UTF-8 character: %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+record.Task.Title+"\r\n") {
+		t.Errorf("unfolded output does not contain the full summary")
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+type taskExportService struct {
+	taskRepo repository.TaskRepository
+	logger   logger.Logger
+}
+
+// NewTaskExportService creates a new task export service
+func NewTaskExportService(taskRepo repository.TaskRepository, log logger.Logger) TaskExportService {
+	return &taskExportService{
+		taskRepo: taskRepo,
+		logger:   log,
+	}
+}
+
+func (s *taskExportService) ExportTasks(ctx context.Context, filter repository.TaskListOptions, opts domain.ExportOptions, w io.Writer) (int, error) {
+	s.logger.Info(ctx, "Exporting tasks", "format", opts.Format, "time_zone", opts.TimeZone)
+
+	// Options are checked before anything is written so a bad request produces no output
+	columns, err := exportColumns(opts.Columns)
+	if err != nil {
+		return 0, err
+	}
+	loc, err := exportLocation(opts.TimeZone)
+	if err != nil {
+		return 0, err
+	}
+	switch opts.Format {
+	case domain.ExportFormatCSV, domain.ExportFormatJSONL, domain.ExportFormatICalendar:
+	default:
+		return 0, domain.ErrInvalidInput(fmt.Sprintf("unsupported export format: %s", opts.Format))
+	}
+	if err := bindTaskQuery(ctx, &filter); err != nil {
+		return 0, err
+	}
+
+	encoder := newTaskExportEncoder(w, opts.Format, columns, loc)
+	if err := encoder.begin(); err != nil {
+		return 0, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	count := 0
+	err = s.taskRepo.StreamForExport(ctx, filter, func(record *domain.TaskExportRecord) error {
+		if err := encoder.encode(record); err != nil {
+			return fmt.Errorf("failed to write task %s: %w", record.Task.ID, err)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		s.logger.Error(ctx, "Task export failed", "exported", count, "error", err)
+		return count, err
+	}
+
+	if err := encoder.end(); err != nil {
+		return count, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	s.logger.Info(ctx, "Tasks exported", "format", opts.Format, "count", count)
+	return count, nil
+}
+
+// exportColumns validates the requested columns; none selects all of them
+func exportColumns(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return domain.ExportColumns, nil
+	}
+
+	known := make(map[string]bool, len(domain.ExportColumns))
+	for _, column := range domain.ExportColumns {
+		known[column] = true
+	}
+
+	columns := make([]string, 0, len(requested))
+	seen := make(map[string]bool, len(requested))
+	for _, column := range requested {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !known[column] {
+			return nil, domain.ErrInvalidInput(fmt.Sprintf("unknown export column %q (valid columns: %s)", column, strings.Join(domain.ExportColumns, ", ")))
+		}
+		if seen[column] {
+			return nil, domain.ErrInvalidInput(fmt.Sprintf("export column %q is listed more than once", column))
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func exportLocation(timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, domain.ErrInvalidInput(fmt.Sprintf("unknown time zone %q", timeZone))
+	}
+	return loc, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/testutil"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+func TestTaskExportService_ExportTasks(t *testing.T) {
+	taskRepo := newMockTaskRepository()
+	for _, title := range []string{"First", "Second"} {
+		task := testutil.TestTask("user-1")
+		task.Title = title
+		if err := taskRepo.Create(context.Background(), task); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	service := NewTaskExportService(taskRepo, logger.NewLogger("debug"))
+
+	var buf bytes.Buffer
+	count, err := service.ExportTasks(context.Background(), repository.TaskListOptions{}, domain.ExportOptions{
+		Format:  domain.ExportFormatCSV,
+		Columns: []string{"Title", " id "},
+	}, &buf)
+	if err != nil {
+		t.Fatalf("ExportTasks: %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+	want := "title,id\nFirst,mock-task-First\nSecond,mock-task-Second\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTaskExportService_InvalidOptions(t *testing.T) {
+	service := NewTaskExportService(newMockTaskRepository(), logger.NewLogger("debug"))
+
+	tests := []struct {
+		name    string
+		opts    domain.ExportOptions
+		message string
+	}{
+		{"unknown column", domain.ExportOptions{Format: domain.ExportFormatCSV, Columns: []string{"owner"}}, "unknown export column"},
+		{"duplicate column", domain.ExportOptions{Format: domain.ExportFormatCSV, Columns: []string{"id", "ID"}}, "more than once"},
+		{"unknown time zone", domain.ExportOptions{Format: domain.ExportFormatJSONL, TimeZone: "Mars/Olympus"}, "unknown time zone"},
+		{"missing format", domain.ExportOptions{}, "unsupported export format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			_, err := service.ExportTasks(context.Background(), repository.TaskListOptions{}, tt.opts, &buf)
+			if !domain.IsInvalidInputError(err) {
+				t.Fatalf("expected an invalid input error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("error %q does not mention %q", err.Error(), tt.message)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("expected no output, got %q", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func (s *taskService) MoveTask(ctx context.Context, id, parentID string, version int64) (*domain.Task, error) {
+	s.logger.Info(ctx, "Moving task", "task_id", id, "parent_id", parentID, "version", version)
+
+	task, err := s.GetTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.Version != version {
+		return nil, domain.ErrVersionConflict("task", version, task.Version)
+	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return nil, err
+	}
+
+	oldParentID := task.ParentID
+	moved := *task
+	moved.ParentID = nil
+	if parentID != "" {
+		moved.ParentID = &parentID
+	}
+	if err := s.validateTaskParent(ctx, &moved); err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.taskRepo.Update(txCtx, &moved); err != nil {
+			return err
+		}
+		return s.recordHistory(txCtx, id, domain.TaskHistoryActionUpdated, &domain.TaskHistoryDetails{
+			OldValues: map[string]interface{}{"parent_id": oldParentID},
+			NewValues: map[string]interface{}{"parent_id": moved.ParentID},
+			Changes:   []string{"parent_id"},
+		})
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) || domain.IsBusinessRuleError(err) {
+			s.logger.Warn(ctx, "Task move rejected", "task_id", id, "error", err)
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to move task", "error", err, "task_id", id)
+		return nil, fmt.Errorf("failed to move task: %w", err)
+	}
+
+	s.logger.Info(ctx, "Task moved successfully", "task_id", id, "new_version", moved.Version)
+	return &moved, nil
+}
+
+func (s *taskService) ListSubtasks(ctx context.Context, parentID string) (*domain.TaskNode, error) {
+	s.logger.Debug(ctx, "Listing subtasks", "parent_id", parentID)
+
+	root, err := s.GetTaskTree(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	// Progress was computed over the whole tree, so the deeper levels can go
+	for _, child := range root.Children {
+		child.Children = nil
+	}
+	return root, nil
+}
+
+func (s *taskService) GetTaskTree(ctx context.Context, rootID string) (*domain.TaskNode, error) {
+	s.logger.Debug(ctx, "Getting task tree", "root_id", rootID)
+
+	if rootID == "" {
+		return nil, domain.ErrInvalidInput("task ID is required")
+	}
+
+	nodes, err := s.taskRepo.GetSubtree(ctx, rootID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to get task subtree", "error", err, "root_id", rootID)
+		return nil, fmt.Errorf("failed to get task tree: %w", err)
+	}
+	if len(nodes) == 0 {
+		return nil, domain.ErrNotFound("task")
+	}
+
+	return domain.BuildTaskTree(nodes), nil
+}
+
+// validateTaskParent checks that the task can sit under its parent: the parent must exist and
+// must not be the task itself or one of its subtasks
+func (s *taskService) validateTaskParent(ctx context.Context, task *domain.Task) error {
+	if task.ParentID == nil || *task.ParentID == "" {
+		task.ParentID = nil
+		return nil
+	}
+	parentID := *task.ParentID
+
+	if task.ID != "" && parentID == task.ID {
+		return domain.ErrBusinessRule("a task cannot be its own parent")
+	}
+
+	if _, err := s.taskRepo.GetByID(ctx, parentID); err != nil {
+		if domain.IsNotFoundError(err) {
+			return domain.ErrInvalidInput("parent task not found")
+		}
+		return fmt.Errorf("failed to get parent task: %w", err)
+	}
+
+	if task.ID == "" {
+		return nil
+	}
+	nodes, err := s.taskRepo.GetSubtree(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get task subtree: %w", err)
+	}
+	for _, node := range nodes {
+		if node.Task.ID == parentID {
+			return domain.ErrBusinessRule("a task cannot be moved under its own subtask")
+		}
+	}
+
+	return nil
+}
+
+// checkSubtaskCompletion refuses to complete a task with open subtasks when configured to
+func (s *taskService) checkSubtaskCompletion(ctx context.Context, task *domain.Task, status domain.TaskStatus) error {
+	if status != domain.TaskStatusCompleted || !s.subtaskRules.BlockParentCompletion {
+		return nil
+	}
+
+	nodes, err := s.taskRepo.GetSubtree(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get subtasks: %w", err)
+	}
+	tree := domain.BuildTaskTree(nodes)
+	if tree == nil {
+		return nil
+	}
+	if open := tree.OpenDescendants(); len(open) > 0 {
+		return domain.ErrBusinessRule(fmt.Sprintf("task has %d open subtasks", len(open)))
+	}
+	return nil
+}
+
+// completeParents completes the parents of a just completed task, nearest first, for as long
+// as the completed task was the last open one under them. A parent whose workflow does not
+// allow the change, or that has open blockers, stays as it is and ends the walk.
+func (s *taskService) completeParents(ctx context.Context, task *domain.Task) error {
+	if !s.subtaskRules.AutoCompleteParent {
+		return nil
+	}
+
+	for task.ParentID != nil {
+		parent, err := s.taskRepo.GetByID(ctx, *task.ParentID)
+		if err != nil {
+			if domain.IsNotFoundError(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to get parent task: %w", err)
+		}
+		if parent.Status == domain.TaskStatusCompleted || parent.Status == domain.TaskStatusCancelled {
+			return nil
+		}
+
+		nodes, err := s.taskRepo.GetSubtree(ctx, parent.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get subtasks: %w", err)
+		}
+		if tree := domain.BuildTaskTree(nodes); tree == nil || len(tree.OpenDescendants()) > 0 {
+			return nil
+		}
+
+		err = s.checkStatusTransition(ctx, parent, domain.TaskStatusCompleted)
+		if err == nil {
+			err = s.checkBlockers(ctx, parent, domain.TaskStatusCompleted)
+		}
+		if err != nil {
+			if domain.IsBusinessRuleError(err) || domain.IsPermissionDeniedError(err) {
+				s.logger.Info(ctx, "Parent task left open", "task_id", parent.ID, "reason", err)
+				return nil
+			}
+			return err
+		}
+
+		oldStatus := parent.Status
+		parent.Status = domain.TaskStatusCompleted
+		if err := s.taskRepo.Update(ctx, parent); err != nil {
+			return err
+		}
+		if err := s.recordHistory(ctx, parent.ID, domain.TaskHistoryActionCompleted, &domain.TaskHistoryDetails{
+			OldValues: map[string]interface{}{"status": oldStatus},
+			NewValues: map[string]interface{}{"status": parent.Status},
+			Changes:   []string{"status"},
+			Metadata:  map[string]interface{}{"auto_completed": true, "completed_subtask_id": task.ID},
+		}); err != nil {
+			return err
+		}
+
+		if err := s.continueSeries(ctx, parent); err != nil {
+			return err
+		}
+
+		s.logger.Info(ctx, "Parent task completed with its subtasks", "task_id", parent.ID)
+		task = parent
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+// maxImportLineSize bounds a single JSON Lines record
+const maxImportLineSize = 1 << 20
+
+// importRecord is a raw task row as read from the import file
+type importRecord struct {
+	row         int
+	title       string
+	description string
+	assignee    string // email
+	status      string
+	priority    string
+	dueDate     string
+	categories  []string
+	tags        []string
+	err         error // set when the row itself could not be parsed
+}
+
+// importReader reads import records one at a time and returns io.EOF at the end
+type importReader interface {
+	next() (*importRecord, error)
+}
+
+func newImportReader(format domain.ImportFormat, r io.Reader) (importReader, error) {
+	switch format {
+	case domain.ImportFormatCSV:
+		return newCSVImportReader(r)
+	case domain.ImportFormatJSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+		return &jsonlImportReader{scanner: scanner}, nil
+	default:
+		return nil, domain.ErrInvalidInput(fmt.Sprintf("unsupported import format: %s", format))
+	}
+}
+
+// csvColumnAliases maps accepted header names to import fields
+var csvColumnAliases = map[string]string{
+	"title":          "title",
+	"name":           "title",
+	"summary":        "title",
+	"description":    "description",
+	"assignee":       "assignee",
+	"assignee_email": "assignee",
+	"email":          "assignee",
+	"status":         "status",
+	"priority":       "priority",
+	"due":            "due_date",
+	"due_date":       "due_date",
+	"category":       "categories",
+	"categories":     "categories",
+	"tag":            "tags",
+	"tags":           "tags",
+}
+
+type csvImportReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+// newCSVImportReader reads the header row; unknown columns are ignored
+func newCSVImportReader(r io.Reader) (*csvImportReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, domain.ErrInvalidInput("CSV file is empty")
+		}
+		return nil, domain.ErrInvalidInput(fmt.Sprintf("invalid CSV header: %v", err))
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		key = strings.ReplaceAll(key, " ", "_")
+		if field, ok := csvColumnAliases[key]; ok {
+			if _, duplicate := columns[field]; duplicate {
+				return nil, domain.ErrInvalidInput(fmt.Sprintf("CSV header has more than one %s column", field))
+			}
+			columns[field] = i
+		}
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, domain.ErrInvalidInput("CSV header must include a title column")
+	}
+
+	// Rows are checked against the header length
+	reader.FieldsPerRecord = len(header)
+	return &csvImportReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvImportReader) next() (*importRecord, error) {
+	fields, err := r.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+
+	r.row++
+	record := &importRecord{row: r.row}
+
+	var parseErr *csv.ParseError
+	if err != nil {
+		if !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		// A bad row is reported; the reader continues with the next one
+		record.err = fmt.Errorf("malformed CSV row: %v", parseErr.Err)
+		return record, nil
+	}
+
+	get := func(field string) string {
+		if i, ok := r.columns[field]; ok {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+
+	record.title = get("title")
+	record.description = get("description")
+	record.assignee = get("assignee")
+	record.status = get("status")
+	record.priority = get("priority")
+	record.dueDate = get("due_date")
+	record.categories = splitImportList(get("categories"))
+	record.tags = splitImportList(get("tags"))
+	return record, nil
+}
+
+// splitImportList splits a multi-value cell on commas or semicolons
+func splitImportList(value string) []string {
+	var values []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+type jsonlImportReader struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+// jsonImportRow is a JSON Lines record. Lists may be given as arrays or as
+// comma separated strings; unknown fields are ignored.
+type jsonImportRow struct {
+	Title         string           `json:"title"`
+	Description   string           `json:"description"`
+	Assignee      string           `json:"assignee"`
+	AssigneeEmail string           `json:"assignee_email"`
+	Status        string           `json:"status"`
+	Priority      string           `json:"priority"`
+	DueDate       string           `json:"due_date"`
+	Categories    importStringList `json:"categories"`
+	Tags          importStringList `json:"tags"`
+}
+
+type importStringList []string
+
+func (l *importStringList) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*l = list
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("expected a string or a list of strings")
+	}
+	*l = splitImportList(value)
+	return nil
+}
+
+func (r *jsonlImportReader) next() (*importRecord, error) {
+	for r.scanner.Scan() {
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		r.row++
+		record := &importRecord{row: r.row}
+
+		var row jsonImportRow
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			record.err = fmt.Errorf("malformed JSON: %v", err)
+			return record, nil
+		}
+
+		record.title = strings.TrimSpace(row.Title)
+		record.description = row.Description
+		record.assignee = strings.TrimSpace(row.AssigneeEmail)
+		if record.assignee == "" {
+			record.assignee = strings.TrimSpace(row.Assignee)
+		}
+		record.status = strings.TrimSpace(row.Status)
+		record.priority = strings.TrimSpace(row.Priority)
+		record.dueDate = strings.TrimSpace(row.DueDate)
+		for _, category := range row.Categories {
+			if category = strings.TrimSpace(category); category != "" {
+				record.categories = append(record.categories, category)
+			}
+		}
+		for _, tag := range row.Tags {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				record.tags = append(record.tags, tag)
+			}
+		}
+		return record, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, domain.ErrInvalidInput(fmt.Sprintf("row %d exceeds %d bytes", r.row+1, maxImportLineSize))
+		}
+		return nil, err
+	}
+	return nil, io.EOF
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func readAllImportRecords(t *testing.T, format domain.ImportFormat, input string) []*importRecord {
+	t.Helper()
+
+	reader, err := newImportReader(format, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("newImportReader() error = %v", err)
+	}
+
+	var records []*importRecord
+	for {
+		record, err := reader.next()
+		if err == io.EOF {
+			return records
+		}
+		if err != nil {
+			t.Fatalf("next() error = %v", err)
+		}
+		records = append(records, record)
+	}
+}
+
+func TestCSVImportReader(t *testing.T) {
+	input := "Summary,Assignee Email,Status,Priority,Due Date,Categories,Tags,Notes\n" +
+		"Write docs,alice@example.com,todo,high,2024-05-01,Work; Docs,\"backend, api\",ignored\n" +
+		"Broken row,bob@example.com\n" +
+		"Ship it,,done,,,,,\n"
+
+	records := readAllImportRecords(t, domain.ImportFormatCSV, input)
+	if len(records) != 3 {
+		t.Fatalf("records = %d, want 3", len(records))
+	}
+
+	first := records[0]
+	if first.row != 1 || first.title != "Write docs" || first.assignee != "alice@example.com" ||
+		first.status != "todo" || first.priority != "high" || first.dueDate != "2024-05-01" {
+		t.Errorf("first record = %+v", first)
+	}
+	if !reflect.DeepEqual(first.categories, []string{"Work", "Docs"}) {
+		t.Errorf("categories = %v, want [Work Docs]", first.categories)
+	}
+	if !reflect.DeepEqual(first.tags, []string{"backend", "api"}) {
+		t.Errorf("tags = %v, want [backend api]", first.tags)
+	}
+
+	if records[1].err == nil {
+		t.Error("row with missing fields should have a parse error")
+	}
+	if records[2].row != 3 || records[2].title != "Ship it" || records[2].err != nil {
+		t.Errorf("reader should continue after a malformed row, got %+v", records[2])
+	}
+}
+
+func TestCSVImportReader_InvalidHeader(t *testing.T) {
+	for name, input := range map[string]string{
+		"empty file":        "",
+		"no title column":   "description,status\nsomething,open\n",
+		"duplicate columns": "title,name\na,b\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newImportReader(domain.ImportFormatCSV, strings.NewReader(input))
+			if !domain.IsInvalidInputError(err) {
+				t.Errorf("newImportReader() error = %v, want invalid input", err)
+			}
+		})
+	}
+}
+
+func TestJSONLImportReader(t *testing.T) {
+	input := `{"title": "Write docs", "assignee_email": "alice@example.com", "tags": ["backend", " api "], "categories": "Work;Docs"}
+
+{"title": "Broken"
+{"title": "Ship it", "assignee": "bob@example.com", "extra": true}
+`
+
+	records := readAllImportRecords(t, domain.ImportFormatJSONL, input)
+	if len(records) != 3 {
+		t.Fatalf("records = %d, want 3 (blank lines are not rows)", len(records))
+	}
+
+	if !reflect.DeepEqual(records[0].tags, []string{"backend", "api"}) ||
+		!reflect.DeepEqual(records[0].categories, []string{"Work", "Docs"}) {
+		t.Errorf("first record = %+v", records[0])
+	}
+	if records[1].row != 2 || records[1].err == nil {
+		t.Errorf("second record = %+v, want a parse error on row 2", records[1])
+	}
+	if records[2].assignee != "bob@example.com" || records[2].err != nil {
+		t.Errorf("third record = %+v", records[2])
+	}
+}
@@ -0,0 +1,503 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+type taskImportService struct {
+	taskRepo     repository.TaskRepository
+	userRepo     repository.UserRepository
+	categoryRepo repository.CategoryRepository
+	importRepo   repository.TaskImportRepository
+	tagService   TagService
+	txManager    repository.TransactionManager
+	logger       logger.Logger
+}
+
+// NewTaskImportService creates a new task import service
+func NewTaskImportService(
+	taskRepo repository.TaskRepository,
+	userRepo repository.UserRepository,
+	categoryRepo repository.CategoryRepository,
+	importRepo repository.TaskImportRepository,
+	tagService TagService,
+	txManager repository.TransactionManager,
+	log logger.Logger,
+) TaskImportService {
+	return &taskImportService{
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+		categoryRepo: categoryRepo,
+		importRepo:   importRepo,
+		tagService:   tagService,
+		txManager:    txManager,
+		logger:       log,
+	}
+}
+
+// importDueDateLayouts are the accepted due date formats, tried in order
+var importDueDateLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"}
+
+// pendingImportTask is a validated row waiting for its batch to be committed
+type pendingImportTask struct {
+	result      int // index into the report rows
+	task        *domain.Task
+	categoryIDs []string
+	tagNames    []string
+}
+
+// importLookups caches name resolution for the duration of an import
+type importLookups struct {
+	users      map[string]string // lower-case email -> user ID, "" when unknown
+	categories map[string]string // lower-case name -> category ID, "" when unknown
+	tags       map[string]string // tag name -> tag ID
+}
+
+func (s *taskImportService) ImportTasks(ctx context.Context, src io.Reader, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	s.logger.Info(ctx, "Importing tasks", "format", opts.Format, "dry_run", opts.DryRun, "resume_import_id", opts.ResumeImportID)
+
+	if opts.BatchSize == 0 {
+		opts.BatchSize = domain.DefaultImportBatchSize
+	}
+	if opts.BatchSize < 0 || opts.BatchSize > domain.MaxImportBatchSize {
+		return nil, domain.ErrInvalidInput(fmt.Sprintf("batch size must be between 1 and %d", domain.MaxImportBatchSize))
+	}
+	if opts.DryRun && opts.ResumeImportID != "" {
+		return nil, domain.ErrInvalidInput("a dry run cannot resume an import")
+	}
+
+	reader, err := newImportReader(opts.Format, src)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{DryRun: opts.DryRun, Status: domain.ImportStatusCompleted}
+
+	var job *domain.TaskImport
+	if !opts.DryRun {
+		if job, err = s.startImport(ctx, opts); err != nil {
+			return nil, err
+		}
+		report.ImportID = job.ID
+		report.LastCommittedRow = job.LastCommittedRow
+	}
+
+	lookups := &importLookups{
+		users:      make(map[string]string),
+		categories: make(map[string]string),
+		tags:       make(map[string]string),
+	}
+
+	var batch []pendingImportTask
+	lastRow := report.LastCommittedRow
+
+	for {
+		record, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if job == nil {
+				return nil, s.readError(err)
+			}
+			s.failImport(ctx, job, report, batch, s.readError(err))
+			return report, nil
+		}
+
+		report.TotalRows++
+		lastRow = record.row
+
+		if job != nil && record.row <= job.LastCommittedRow {
+			report.SkippedRows++
+			report.Rows = append(report.Rows, domain.ImportRowResult{Row: record.row, Title: record.title, Status: domain.ImportRowSkipped})
+			continue
+		}
+
+		pending, problems := s.buildImportTask(ctx, record, lookups)
+		if len(problems) > 0 {
+			report.InvalidRows++
+			report.Rows = append(report.Rows, domain.ImportRowResult{
+				Row:    record.row,
+				Title:  record.title,
+				Status: domain.ImportRowInvalid,
+				Errors: problems,
+			})
+			continue
+		}
+
+		report.ValidRows++
+		status := domain.ImportRowValid
+		if job != nil {
+			status = domain.ImportRowNotCommitted
+		}
+		pending.result = len(report.Rows)
+		report.Rows = append(report.Rows, domain.ImportRowResult{Row: record.row, Title: record.title, Status: status})
+
+		if job == nil {
+			continue
+		}
+
+		batch = append(batch, pending)
+		if len(batch) >= opts.BatchSize {
+			if err := s.commitBatch(ctx, job, report, batch, lookups, lastRow); err != nil {
+				s.failImport(ctx, job, report, batch, err)
+				return report, nil
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if job != nil {
+		// The final commit also advances the checkpoint past trailing invalid rows
+		if err := s.commitBatch(ctx, job, report, batch, lookups, lastRow); err != nil {
+			s.failImport(ctx, job, report, batch, err)
+			return report, nil
+		}
+
+		job.Status = domain.ImportStatusCompleted
+		if err := s.importRepo.Update(ctx, job); err != nil {
+			return nil, fmt.Errorf("failed to complete task import: %w", err)
+		}
+	}
+
+	s.logger.Info(ctx, "Task import finished",
+		"import_id", report.ImportID, "dry_run", report.DryRun, "rows", report.TotalRows,
+		"valid", report.ValidRows, "invalid", report.InvalidRows, "created", report.CreatedRows, "skipped", report.SkippedRows)
+	return report, nil
+}
+
+// startImport creates a new import, or reopens a failed one when resuming
+func (s *taskImportService) startImport(ctx context.Context, opts domain.ImportOptions) (*domain.TaskImport, error) {
+	if opts.ResumeImportID == "" {
+		job := &domain.TaskImport{
+			Format:  opts.Format,
+			Status:  domain.ImportStatusRunning,
+			ActorID: actorID(ctx),
+		}
+		if err := s.importRepo.Create(ctx, job); err != nil {
+			return nil, fmt.Errorf("failed to start task import: %w", err)
+		}
+		return job, nil
+	}
+
+	job, err := s.importRepo.GetByID(ctx, opts.ResumeImportID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == domain.ImportStatusCompleted {
+		return nil, domain.ErrBusinessRule("import has already completed")
+	}
+	if job.Format != opts.Format {
+		return nil, domain.ErrInvalidInput(fmt.Sprintf("import was started as %s, not %s", job.Format, opts.Format))
+	}
+
+	job.Status = domain.ImportStatusRunning
+	job.Error = ""
+	if err := s.importRepo.Update(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to resume task import: %w", err)
+	}
+
+	s.logger.Info(ctx, "Resuming task import", "import_id", job.ID, "last_committed_row", job.LastCommittedRow)
+	return job, nil
+}
+
+// buildImportTask converts a raw record into a task, collecting every problem with the row
+func (s *taskImportService) buildImportTask(ctx context.Context, record *importRecord, lookups *importLookups) (pendingImportTask, []string) {
+	if record.err != nil {
+		return pendingImportTask{}, []string{record.err.Error()}
+	}
+
+	var problems []string
+	task := &domain.Task{
+		Title:       record.title,
+		Description: record.description,
+	}
+
+	status, ok := parseImportStatus(record.status)
+	if !ok {
+		problems = append(problems, fmt.Sprintf("unknown status %q", record.status))
+	}
+	task.Status = status
+
+	priority, ok := parseImportPriority(record.priority)
+	if !ok {
+		problems = append(problems, fmt.Sprintf("unknown priority %q", record.priority))
+	}
+	task.Priority = priority
+
+	if record.dueDate != "" {
+		dueDate, err := parseImportDueDate(record.dueDate)
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else {
+			task.DueDate = &dueDate
+		}
+	}
+
+	if record.assignee != "" {
+		assigneeID, err := s.resolveAssignee(ctx, record.assignee, lookups)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+		task.AssigneeID = assigneeID
+	}
+
+	var categoryIDs []string
+	for _, name := range record.categories {
+		categoryID, err := s.resolveCategory(ctx, name, lookups)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		categoryIDs = appendUnique(categoryIDs, categoryID)
+	}
+
+	var tagNames []string
+	for _, name := range record.tags {
+		if err := (&domain.Tag{Name: name, CreatorID: domain.SystemUserID}).IsValid(); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		tagNames = appendUnique(tagNames, name)
+	}
+
+	// Unknown assignees and statuses were already reported above
+	validated := *task
+	if validated.AssigneeID == "" && record.assignee != "" {
+		validated.AssigneeID = record.assignee
+	}
+	if validated.Status == domain.TaskStatusUnspecified {
+		validated.Status = domain.TaskStatusOpen
+	}
+	if err := validated.IsValid(); err != nil {
+		var domainErr domain.DomainError
+		if errors.As(err, &domainErr) {
+			problems = append(problems, domainErr.Message)
+		} else {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	return pendingImportTask{task: task, categoryIDs: categoryIDs, tagNames: tagNames}, problems
+}
+
+func (s *taskImportService) resolveAssignee(ctx context.Context, email string, lookups *importLookups) (string, error) {
+	key := strings.ToLower(email)
+	userID, cached := lookups.users[key]
+	if !cached {
+		user, err := s.userRepo.GetByEmail(ctx, key)
+		switch {
+		case err == nil:
+			userID = user.ID
+		case domain.IsNotFoundError(err):
+			userID = ""
+		default:
+			return "", fmt.Errorf("failed to look up assignee %s: %v", email, err)
+		}
+		lookups.users[key] = userID
+	}
+
+	if userID == "" {
+		return "", fmt.Errorf("assignee %s does not exist", email)
+	}
+	return userID, nil
+}
+
+func (s *taskImportService) resolveCategory(ctx context.Context, name string, lookups *importLookups) (string, error) {
+	key := strings.ToLower(name)
+	categoryID, cached := lookups.categories[key]
+	if !cached {
+		category, err := s.categoryRepo.GetByName(ctx, name)
+		switch {
+		case err == nil:
+			categoryID = category.ID
+		case domain.IsNotFoundError(err):
+			categoryID = ""
+		default:
+			return "", fmt.Errorf("failed to look up category %s: %v", name, err)
+		}
+		lookups.categories[key] = categoryID
+	}
+
+	if categoryID == "" {
+		return "", fmt.Errorf("category %s does not exist", name)
+	}
+	return categoryID, nil
+}
+
+// commitBatch creates the batch's tasks and advances the import checkpoint to
+// throughRow in a single transaction
+func (s *taskImportService) commitBatch(
+	ctx context.Context,
+	job *domain.TaskImport,
+	report *domain.ImportReport,
+	batch []pendingImportTask,
+	lookups *importLookups,
+	throughRow int,
+) error {
+	// Tags are shared across tasks, so they are resolved (and created) outside the batch transaction
+	tagIDs := make([][]string, len(batch))
+	for i, pending := range batch {
+		for _, name := range pending.tagNames {
+			tagID, ok := lookups.tags[name]
+			if !ok {
+				tag, err := s.tagService.FindOrCreateTag(ctx, name)
+				if err != nil {
+					return fmt.Errorf("failed to resolve tag %s: %w", name, err)
+				}
+				tagID = tag.ID
+				lookups.tags[name] = tagID
+			}
+			tagIDs[i] = appendUnique(tagIDs[i], tagID)
+		}
+	}
+
+	created := job.CreatedCount
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		for i, pending := range batch {
+			task := pending.task
+			task.CreatorID = job.ActorID
+			if err := s.taskRepo.Create(txCtx, task); err != nil {
+				return fmt.Errorf("row %d: failed to create task: %w", report.Rows[pending.result].Row, err)
+			}
+
+			// Each association bumps the task version
+			if len(pending.categoryIDs) > 0 {
+				if err := s.taskRepo.AddCategories(txCtx, task.ID, pending.categoryIDs, task.Version); err != nil {
+					return fmt.Errorf("row %d: failed to add categories: %w", report.Rows[pending.result].Row, err)
+				}
+				task.Version++
+			}
+			if len(tagIDs[i]) > 0 {
+				if err := s.taskRepo.AddTags(txCtx, task.ID, tagIDs[i], task.Version); err != nil {
+					return fmt.Errorf("row %d: failed to add tags: %w", report.Rows[pending.result].Row, err)
+				}
+				task.Version++
+			}
+
+			details := &domain.TaskHistoryDetails{
+				Metadata: map[string]interface{}{"import_id": job.ID, "row": report.Rows[pending.result].Row},
+			}
+			if err := recordTaskHistory(txCtx, s.taskRepo, task.ID, domain.TaskHistoryActionCreated, details); err != nil {
+				return fmt.Errorf("row %d: failed to record history: %w", report.Rows[pending.result].Row, err)
+			}
+		}
+
+		checkpoint := *job
+		checkpoint.LastCommittedRow = throughRow
+		checkpoint.CreatedCount = created + len(batch)
+		return s.importRepo.Update(txCtx, &checkpoint)
+	})
+	if err != nil {
+		return err
+	}
+
+	job.LastCommittedRow = throughRow
+	job.CreatedCount = created + len(batch)
+	report.LastCommittedRow = throughRow
+	report.CreatedRows += len(batch)
+	for _, pending := range batch {
+		report.Rows[pending.result].Status = domain.ImportRowCreated
+		report.Rows[pending.result].TaskID = pending.task.ID
+	}
+
+	s.logger.Debug(ctx, "Committed import batch", "import_id", job.ID, "tasks", len(batch), "through_row", throughRow)
+	return nil
+}
+
+// failImport records a failed import so it can be resumed from its last committed row
+func (s *taskImportService) failImport(ctx context.Context, job *domain.TaskImport, report *domain.ImportReport, batch []pendingImportTask, cause error) {
+	s.logger.Error(ctx, "Task import failed", "import_id", job.ID, "last_committed_row", job.LastCommittedRow, "error", cause)
+
+	for _, pending := range batch {
+		report.Rows[pending.result].Errors = append(report.Rows[pending.result].Errors, "batch was not committed")
+	}
+
+	report.Status = domain.ImportStatusFailed
+	report.Error = cause.Error()
+
+	job.Status = domain.ImportStatusFailed
+	job.Error = cause.Error()
+	if err := s.importRepo.Update(ctx, job); err != nil {
+		s.logger.Error(ctx, "Failed to record task import failure", "import_id", job.ID, "error", err)
+	}
+}
+
+// readError keeps domain errors from the reader and wraps anything else
+func (s *taskImportService) readError(err error) error {
+	if _, ok := err.(domain.DomainError); ok {
+		return err
+	}
+	return fmt.Errorf("failed to read import: %w", err)
+}
+
+// parseImportStatus accepts the domain statuses and common spreadsheet spellings.
+// An empty status defaults to OPEN.
+func parseImportStatus(value string) (domain.TaskStatus, bool) {
+	switch normalizeImportValue(value) {
+	case "", "OPEN", "TODO", "TO_DO", "NEW":
+		return domain.TaskStatusOpen, true
+	case "IN_PROGRESS", "DOING", "STARTED":
+		return domain.TaskStatusInProgress, true
+	case "IN_REVIEW", "REVIEW":
+		return domain.TaskStatusInReview, true
+	case "BLOCKED", "ON_HOLD", "WAITING":
+		return domain.TaskStatusBlocked, true
+	case "COMPLETED", "DONE", "CLOSED", "RESOLVED":
+		return domain.TaskStatusCompleted, true
+	case "CANCELLED", "CANCELED":
+		return domain.TaskStatusCancelled, true
+	default:
+		return domain.TaskStatusUnspecified, false
+	}
+}
+
+// parseImportPriority accepts the domain priorities and common aliases.
+// An empty priority defaults to MEDIUM.
+func parseImportPriority(value string) (domain.TaskPriority, bool) {
+	switch normalizeImportValue(value) {
+	case "LOW":
+		return domain.TaskPriorityLow, true
+	case "", "MEDIUM", "NORMAL":
+		return domain.TaskPriorityMedium, true
+	case "HIGH":
+		return domain.TaskPriorityHigh, true
+	case "URGENT", "CRITICAL":
+		return domain.TaskPriorityUrgent, true
+	default:
+		return domain.TaskPriorityUnspecified, false
+	}
+}
+
+func parseImportDueDate(value string) (time.Time, error) {
+	for _, layout := range importDueDateLayouts {
+		if dueDate, err := time.Parse(layout, value); err == nil {
+			return dueDate, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid due date %q (use YYYY-MM-DD or RFC 3339)", value)
+}
+
+func normalizeImportValue(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(value)
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
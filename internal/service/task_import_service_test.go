@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+// mockTaskImportRepository is an in-memory repository.TaskImportRepository
+type mockTaskImportRepository struct {
+	imports map[string]*domain.TaskImport
+}
+
+func newMockTaskImportRepository() *mockTaskImportRepository {
+	return &mockTaskImportRepository{imports: make(map[string]*domain.TaskImport)}
+}
+
+func (m *mockTaskImportRepository) Create(ctx context.Context, taskImport *domain.TaskImport) error {
+	taskImport.ID = "mock-import-" + string(rune('a'+len(m.imports)))
+	stored := *taskImport
+	m.imports[taskImport.ID] = &stored
+	return nil
+}
+
+func (m *mockTaskImportRepository) GetByID(ctx context.Context, id string) (*domain.TaskImport, error) {
+	stored, exists := m.imports[id]
+	if !exists {
+		return nil, domain.ErrNotFound("task import")
+	}
+	copied := *stored
+	return &copied, nil
+}
+
+func (m *mockTaskImportRepository) Update(ctx context.Context, taskImport *domain.TaskImport) error {
+	if _, exists := m.imports[taskImport.ID]; !exists {
+		return domain.ErrNotFound("task import")
+	}
+	stored := *taskImport
+	m.imports[taskImport.ID] = &stored
+	return nil
+}
+
+// failingTaskRepository fails to create tasks with the given title
+type failingTaskRepository struct {
+	*mockTaskRepository
+	failTitle string
+}
+
+func (m *failingTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	if task.Title == m.failTitle {
+		return errors.New("connection reset")
+	}
+	return m.mockTaskRepository.Create(ctx, task)
+}
+
+type importTestEnv struct {
+	taskRepo   *failingTaskRepository
+	tagRepo    *mockTagRepository
+	importRepo *mockTaskImportRepository
+	txManager  *mockTransactionManager
+	service    TaskImportService
+}
+
+func setupImportTest(t *testing.T) *importTestEnv {
+	t.Helper()
+	ctx := context.Background()
+	log := logger.NewLogger("error")
+
+	userRepo := newMockUserRepository()
+	userRepo.Create(ctx, &domain.User{Name: "Alice", Email: "alice@example.com", Role: domain.UserRoleUser})
+
+	categoryRepo := newMockCategoryRepository()
+	categoryRepo.Create(ctx, &domain.Category{Name: "Work", CreatorID: domain.SystemUserID})
+
+	env := &importTestEnv{
+		taskRepo:   &failingTaskRepository{mockTaskRepository: newMockTaskRepository()},
+		tagRepo:    newMockTagRepository(),
+		importRepo: newMockTaskImportRepository(),
+		txManager:  &mockTransactionManager{},
+	}
+	tagService := NewTagService(env.tagRepo, env.taskRepo, env.txManager, 0, log)
+	env.service = NewTaskImportService(env.taskRepo, userRepo, categoryRepo, env.importRepo, tagService, env.txManager, log)
+	return env
+}
+
+const importTestCSV = `title,assignee,status,priority,due_date,categories,tags
+Task one,alice@example.com,open,high,2024-05-01,Work,backend
+Task two,ALICE@example.com,in progress,,,,
+Task three,nobody@example.com,sleeping,,not a date,Missing,
+,alice@example.com,,,,,
+Task five,alice@example.com,done,low,,work,backend;api
+`
+
+func TestTaskImportService_DryRun(t *testing.T) {
+	env := setupImportTest(t)
+
+	report, err := env.service.ImportTasks(context.Background(), strings.NewReader(importTestCSV),
+		domain.ImportOptions{Format: domain.ImportFormatCSV, DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportTasks() error = %v", err)
+	}
+
+	if report.TotalRows != 5 || report.ValidRows != 3 || report.InvalidRows != 2 || report.CreatedRows != 0 {
+		t.Errorf("report = %+v, want 5 rows, 3 valid, 2 invalid", report)
+	}
+	if report.ImportID != "" || len(env.importRepo.imports) != 0 {
+		t.Error("dry run should not record an import")
+	}
+	if len(env.taskRepo.tasks) != 0 || len(env.tagRepo.tags) != 0 {
+		t.Error("dry run should not create tasks or tags")
+	}
+
+	invalid := report.Rows[2]
+	if invalid.Status != domain.ImportRowInvalid || len(invalid.Errors) != 4 {
+		t.Errorf("row 3 = %+v, want invalid with assignee, status, due date and category errors", invalid)
+	}
+	if errs := report.Rows[3].Errors; len(errs) != 1 || errs[0] != "title is required" {
+		t.Errorf("row 4 errors = %v, want only the missing title", errs)
+	}
+	if report.Rows[0].Status != domain.ImportRowValid {
+		t.Errorf("row 1 status = %s, want VALID", report.Rows[0].Status)
+	}
+}
+
+func TestTaskImportService_Commit(t *testing.T) {
+	env := setupImportTest(t)
+
+	report, err := env.service.ImportTasks(context.Background(), strings.NewReader(importTestCSV),
+		domain.ImportOptions{Format: domain.ImportFormatCSV, BatchSize: 2})
+	if err != nil {
+		t.Fatalf("ImportTasks() error = %v", err)
+	}
+
+	if report.Status != domain.ImportStatusCompleted || report.CreatedRows != 3 || report.LastCommittedRow != 5 {
+		t.Errorf("report = %+v, want completed with 3 created through row 5", report)
+	}
+	// One batch of two valid rows and the final batch
+	if env.txManager.calls != 2 {
+		t.Errorf("transactions = %d, want 2", env.txManager.calls)
+	}
+
+	task := env.taskRepo.tasks[report.Rows[0].TaskID]
+	if task == nil || task.AssigneeID != "mock-user-alice@example.com" || task.Priority != domain.TaskPriorityHigh || task.DueDate == nil {
+		t.Errorf("imported task = %+v", task)
+	}
+	if _, ok := env.tagRepo.tags["mock-tag-backend"]; !ok || len(env.tagRepo.tags) != 2 {
+		t.Errorf("tags = %v, want backend and api created once", env.tagRepo.tags)
+	}
+	if len(env.taskRepo.history) != 3 || env.taskRepo.history[0].Action != domain.TaskHistoryActionCreated {
+		t.Errorf("history = %d entries, want one CREATED entry per task", len(env.taskRepo.history))
+	}
+
+	stored := env.importRepo.imports[report.ImportID]
+	if stored.Status != domain.ImportStatusCompleted || stored.CreatedCount != 3 {
+		t.Errorf("stored import = %+v", stored)
+	}
+}
+
+func TestTaskImportService_Resume(t *testing.T) {
+	env := setupImportTest(t)
+	ctx := context.Background()
+	opts := domain.ImportOptions{Format: domain.ImportFormatCSV, BatchSize: 1}
+
+	env.taskRepo.failTitle = "Task two"
+	report, err := env.service.ImportTasks(ctx, strings.NewReader(importTestCSV), opts)
+	if err != nil {
+		t.Fatalf("ImportTasks() error = %v", err)
+	}
+	if report.Status != domain.ImportStatusFailed || report.LastCommittedRow != 1 || report.CreatedRows != 1 {
+		t.Fatalf("report = %+v, want failed after committing row 1", report)
+	}
+	if report.Rows[1].Status != domain.ImportRowNotCommitted {
+		t.Errorf("row 2 status = %s, want NOT_COMMITTED", report.Rows[1].Status)
+	}
+	if stored := env.importRepo.imports[report.ImportID]; stored.Status != domain.ImportStatusFailed || stored.LastCommittedRow != 1 {
+		t.Errorf("stored import = %+v, want failed at row 1", stored)
+	}
+
+	env.taskRepo.failTitle = ""
+	opts.ResumeImportID = report.ImportID
+	resumed, err := env.service.ImportTasks(ctx, strings.NewReader(importTestCSV), opts)
+	if err != nil {
+		t.Fatalf("resume error = %v", err)
+	}
+	if resumed.Status != domain.ImportStatusCompleted || resumed.SkippedRows != 1 || resumed.CreatedRows != 2 {
+		t.Errorf("resumed report = %+v, want 1 skipped and 2 created", resumed)
+	}
+	if len(env.taskRepo.tasks) != 3 {
+		t.Errorf("tasks = %d, want 3 without duplicates", len(env.taskRepo.tasks))
+	}
+
+	if _, err := env.service.ImportTasks(ctx, strings.NewReader(importTestCSV), opts); !domain.IsBusinessRuleError(err) {
+		t.Errorf("resuming a completed import error = %v, want business rule error", err)
+	}
+}
+
+func TestTaskImportService_InvalidOptions(t *testing.T) {
+	env := setupImportTest(t)
+
+	tests := []struct {
+		name string
+		opts domain.ImportOptions
+	}{
+		{name: "unknown format", opts: domain.ImportOptions{Format: "XLSX"}},
+		{name: "batch size too large", opts: domain.ImportOptions{Format: domain.ImportFormatCSV, BatchSize: domain.MaxImportBatchSize + 1}},
+		{name: "dry run resume", opts: domain.ImportOptions{Format: domain.ImportFormatCSV, DryRun: true, ResumeImportID: "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.service.ImportTasks(context.Background(), strings.NewReader(importTestCSV), tt.opts)
+			if !domain.IsInvalidInputError(err) {
+				t.Errorf("ImportTasks() error = %v, want invalid input", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func (s *taskService) AddTaskParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) (*domain.Task, error) {
+	s.logger.Info(ctx, "Adding participants to task", "task_id", taskID, "participants", len(participants), "version", version)
+
+	task, err := s.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Version != version {
+		return nil, domain.ErrVersionConflict("task", version, task.Version)
+	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return nil, err
+	}
+	if err := s.validateParticipants(ctx, participants); err != nil {
+		return nil, err
+	}
+
+	var added []domain.TaskParticipant
+	for _, participant := range participants {
+		if !hasParticipant(task.Participants, participant) && !hasParticipant(added, participant) {
+			added = append(added, participant)
+		}
+	}
+	if len(added) == 0 {
+		return task, nil
+	}
+
+	details := &domain.TaskHistoryDetails{
+		OldValues: map[string]interface{}{"participants": domain.ParticipantsByRole(task.Participants)},
+		NewValues: map[string]interface{}{"participants": domain.ParticipantsByRole(append(added, task.Participants...))},
+		Changes:   []string{"participants"},
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.taskRepo.AddParticipants(txCtx, taskID, added, version); err != nil {
+			return err
+		}
+		return s.recordHistory(txCtx, taskID, domain.TaskHistoryActionUpdated, details)
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) {
+			s.logger.Warn(ctx, "Task participant change version conflict", "task_id", taskID, "version", version)
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to add participants to task", "error", err, "task_id", taskID)
+		return nil, fmt.Errorf("failed to add participants: %w", err)
+	}
+
+	return s.taskRepo.GetByID(ctx, taskID)
+}
+
+func (s *taskService) RemoveTaskParticipants(ctx context.Context, taskID string, participants []domain.TaskParticipant, version int64) (*domain.Task, error) {
+	s.logger.Info(ctx, "Removing participants from task", "task_id", taskID, "participants", len(participants), "version", version)
+
+	task, err := s.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Version != version {
+		return nil, domain.ErrVersionConflict("task", version, task.Version)
+	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return nil, err
+	}
+	if len(participants) == 0 {
+		return nil, domain.ErrInvalidInput("at least one participant is required")
+	}
+	for _, participant := range participants {
+		if err := participant.IsValid(); err != nil {
+			return nil, err
+		}
+	}
+
+	var kept []domain.TaskParticipant
+	for _, participant := range task.Participants {
+		if !hasParticipant(participants, participant) {
+			kept = append(kept, participant)
+		}
+	}
+	if len(kept) == len(task.Participants) {
+		return task, nil
+	}
+
+	// The longest-standing remaining assignee takes over from a removed primary assignee
+	after := &domain.Task{Participants: kept}
+	remaining := after.ParticipantsWithRole(domain.TaskRoleAssignee)
+	if len(remaining) == 0 {
+		return nil, domain.ErrBusinessRule("a task must keep an assignee")
+	}
+	details := &domain.TaskHistoryDetails{
+		OldValues: map[string]interface{}{"participants": domain.ParticipantsByRole(task.Participants)},
+		NewValues: map[string]interface{}{"participants": domain.ParticipantsByRole(kept)},
+		Changes:   []string{"participants"},
+	}
+	if !after.HasRole(task.AssigneeID, domain.TaskRoleAssignee) {
+		details.OldValues["assignee_id"] = task.AssigneeID
+		details.NewValues["assignee_id"] = remaining[0]
+		details.Changes = append(details.Changes, "assignee_id")
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.taskRepo.RemoveParticipants(txCtx, taskID, participants, version); err != nil {
+			return err
+		}
+		return s.recordHistory(txCtx, taskID, domain.TaskHistoryActionUpdated, details)
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) {
+			s.logger.Warn(ctx, "Task participant change version conflict", "task_id", taskID, "version", version)
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to remove participants from task", "error", err, "task_id", taskID)
+		return nil, fmt.Errorf("failed to remove participants: %w", err)
+	}
+
+	return s.taskRepo.GetByID(ctx, taskID)
+}
+
+// validateParticipants checks that participants are well-formed and refer to existing users
+func (s *taskService) validateParticipants(ctx context.Context, participants []domain.TaskParticipant) error {
+	if len(participants) == 0 {
+		return domain.ErrInvalidInput("at least one participant is required")
+	}
+	for _, participant := range participants {
+		if err := participant.IsValid(); err != nil {
+			return err
+		}
+		if _, err := s.userRepo.GetByID(ctx, participant.UserID); err != nil {
+			if domain.IsNotFoundError(err) {
+				return domain.ErrInvalidInput(fmt.Sprintf("user %s does not exist", participant.UserID))
+			}
+			return fmt.Errorf("failed to validate participant %s: %w", participant.UserID, err)
+		}
+	}
+	return nil
+}
+
+func hasParticipant(participants []domain.TaskParticipant, participant domain.TaskParticipant) bool {
+	for _, existing := range participants {
+		if existing.UserID == participant.UserID && existing.Role == participant.Role {
+			return true
+		}
+	}
+	return false
+}
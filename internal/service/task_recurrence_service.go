@@ -0,0 +1,417 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// recurrenceBatchSize bounds the series a single GenerateRecurringTasks call continues
+const recurrenceBatchSize = 100
+
+func (s *taskService) SetTaskRecurrence(ctx context.Context, taskID string, rule *domain.RecurrenceRule, version int64) (*domain.Task, *domain.TaskSeries, error) {
+	s.logger.Info(ctx, "Setting task recurrence", "task_id", taskID, "version", version)
+
+	if rule == nil {
+		return nil, nil, domain.ErrInvalidInput("recurrence rule is required")
+	}
+	if err := rule.IsValid(); err != nil {
+		return nil, nil, err
+	}
+
+	task, err := s.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return nil, nil, err
+	}
+	if task.Version != version {
+		return nil, nil, domain.ErrVersionConflict("task", version, task.Version)
+	}
+	if task.SeriesID != nil {
+		return nil, nil, domain.ErrBusinessRule("task already recurs; edit its series instead")
+	}
+	if task.DueDate == nil {
+		return nil, nil, domain.ErrBusinessRule("a recurring task needs a due date")
+	}
+	if rule.Until != nil && rule.Until.Before(*task.DueDate) {
+		return nil, nil, domain.ErrInvalidInput("recurrence UNTIL is before the task's due date")
+	}
+
+	series := &domain.TaskSeries{
+		Rule:          *rule,
+		StartsAt:      *task.DueDate,
+		InstanceCount: 1,
+		IsActive:      true,
+		CreatorID:     actorID(ctx),
+	}
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.seriesRepo.Create(txCtx, series); err != nil {
+			return err
+		}
+		task.SeriesID = &series.ID
+		task.SeriesIndex = 1
+		if err := s.taskRepo.LinkToSeries(txCtx, task); err != nil {
+			return err
+		}
+		if err := s.recordHistory(txCtx, task.ID, domain.TaskHistoryActionUpdated, &domain.TaskHistoryDetails{
+			NewValues: map[string]interface{}{"series_id": series.ID, "rule": rule.String()},
+			Changes:   []string{"series_id"},
+		}); err != nil {
+			return err
+		}
+		// A task completed before it was made recurring goes straight on to its next instance
+		if task.Status == domain.TaskStatusCompleted {
+			return s.continueSeries(txCtx, task)
+		}
+		return nil
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) {
+			s.logger.Warn(ctx, "Task recurrence version conflict", "task_id", taskID, "version", version)
+			return nil, nil, err
+		}
+		s.logger.Error(ctx, "Failed to set task recurrence", "error", err, "task_id", taskID)
+		return nil, nil, fmt.Errorf("failed to set task recurrence: %w", err)
+	}
+
+	s.logger.Info(ctx, "Task recurrence set successfully", "task_id", taskID, "series_id", series.ID)
+	return task, series, nil
+}
+
+func (s *taskService) GetTaskSeries(ctx context.Context, seriesID string) (*domain.TaskSeries, []*domain.Task, error) {
+	s.logger.Debug(ctx, "Getting task series", "series_id", seriesID)
+
+	if seriesID == "" {
+		return nil, nil, domain.ErrInvalidInput("series ID is required")
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, nil, err
+		}
+		s.logger.Error(ctx, "Failed to get task series", "error", err, "series_id", seriesID)
+		return nil, nil, fmt.Errorf("failed to get task series: %w", err)
+	}
+
+	instances, err := s.taskRepo.ListSeriesInstances(ctx, seriesID)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list series instances", "error", err, "series_id", seriesID)
+		return nil, nil, fmt.Errorf("failed to get task series: %w", err)
+	}
+
+	return series, instances, nil
+}
+
+func (s *taskService) UpdateTaskSeries(ctx context.Context, seriesID string, changes domain.TaskSeriesChanges, version int64) (*domain.TaskSeries, []*domain.Task, error) {
+	s.logger.Info(ctx, "Updating task series", "series_id", seriesID, "version", version)
+
+	if changes.Title != nil && *changes.Title == "" {
+		return nil, nil, domain.ErrInvalidInput("title is required")
+	}
+	if changes.Rule != nil {
+		if err := changes.Rule.IsValid(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if changes.AssigneeID != nil {
+		if *changes.AssigneeID == "" {
+			return nil, nil, domain.ErrInvalidInput("assignee is required")
+		}
+		if _, err := s.userRepo.GetByID(ctx, *changes.AssigneeID); err != nil {
+			if domain.IsNotFoundError(err) {
+				return nil, nil, domain.ErrInvalidInput("assignee does not exist")
+			}
+			return nil, nil, fmt.Errorf("failed to validate assignee: %w", err)
+		}
+	}
+
+	series, instances, err := s.GetTaskSeries(ctx, seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := authorizeSeriesEdit(ctx, series, instances); err != nil {
+		return nil, nil, err
+	}
+	if series.Version != version {
+		return nil, nil, domain.ErrVersionConflict("task series", version, series.Version)
+	}
+
+	var updated []*domain.Task
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		// The series is updated even when only instance fields change, so concurrent edits conflict
+		if changes.Rule != nil {
+			series.Rule = *changes.Rule
+		}
+		if err := s.seriesRepo.Update(txCtx, series); err != nil {
+			return err
+		}
+
+		// Closed instances are history and keep their values
+		for _, instance := range instances {
+			if instance.Status == domain.TaskStatusCompleted || instance.Status == domain.TaskStatusCancelled {
+				continue
+			}
+			details := applySeriesChanges(instance, changes)
+			if len(details.Changes) == 0 {
+				continue
+			}
+			details.Metadata = map[string]interface{}{"series_id": series.ID}
+			if err := s.taskRepo.Update(txCtx, instance); err != nil {
+				return err
+			}
+			if err := s.recordHistory(txCtx, instance.ID, domain.TaskHistoryActionUpdated, details); err != nil {
+				return err
+			}
+			updated = append(updated, instance)
+		}
+		return nil
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) {
+			s.logger.Warn(ctx, "Task series update version conflict", "series_id", seriesID, "version", version)
+			return nil, nil, err
+		}
+		s.logger.Error(ctx, "Failed to update task series", "error", err, "series_id", seriesID)
+		return nil, nil, fmt.Errorf("failed to update task series: %w", err)
+	}
+
+	s.logger.Info(ctx, "Task series updated successfully", "series_id", seriesID, "updated_tasks", len(updated))
+	return series, updated, nil
+}
+
+// applySeriesChanges sets the changed fields on an instance and describes what changed
+func applySeriesChanges(task *domain.Task, changes domain.TaskSeriesChanges) *domain.TaskHistoryDetails {
+	details := &domain.TaskHistoryDetails{
+		OldValues: map[string]interface{}{},
+		NewValues: map[string]interface{}{},
+	}
+	set := func(field string, old, new interface{}) {
+		details.OldValues[field] = old
+		details.NewValues[field] = new
+		details.Changes = append(details.Changes, field)
+	}
+
+	if changes.Title != nil && *changes.Title != task.Title {
+		set("title", task.Title, *changes.Title)
+		task.Title = *changes.Title
+	}
+	if changes.Description != nil && *changes.Description != task.Description {
+		set("description", task.Description, *changes.Description)
+		task.Description = *changes.Description
+	}
+	if changes.AssigneeID != nil && *changes.AssigneeID != task.AssigneeID {
+		set("assignee_id", task.AssigneeID, *changes.AssigneeID)
+		task.AssigneeID = *changes.AssigneeID
+	}
+	if changes.Priority != nil && *changes.Priority != task.Priority {
+		set("priority", task.Priority, *changes.Priority)
+		task.Priority = *changes.Priority
+	}
+	return details
+}
+
+func (s *taskService) StopTaskSeries(ctx context.Context, seriesID string, version int64) (*domain.TaskSeries, error) {
+	s.logger.Info(ctx, "Stopping task series", "series_id", seriesID, "version", version)
+
+	series, instances, err := s.GetTaskSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeSeriesEdit(ctx, series, instances); err != nil {
+		return nil, err
+	}
+	if series.Version != version {
+		return nil, domain.ErrVersionConflict("task series", version, series.Version)
+	}
+
+	series.IsActive = false
+	if err := s.seriesRepo.Update(ctx, series); err != nil {
+		if domain.IsVersionConflictError(err) {
+			s.logger.Warn(ctx, "Task series stop version conflict", "series_id", seriesID, "version", version)
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to stop task series", "error", err, "series_id", seriesID)
+		return nil, fmt.Errorf("failed to stop task series: %w", err)
+	}
+
+	s.logger.Info(ctx, "Task series stopped successfully", "series_id", seriesID)
+	return series, nil
+}
+
+// authorizeSeriesEdit lets the series' creator change it, as well as anyone who may
+// edit every one of its instances; administrators and internal work always can
+func authorizeSeriesEdit(ctx context.Context, series *domain.TaskSeries, instances []*domain.Task) error {
+	if requestctx.IsInternal(ctx) {
+		return nil
+	}
+	userID, role, err := authenticatedCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if role == domain.UserRoleAdmin || series.CreatorID == userID {
+		return nil
+	}
+	if len(instances) == 0 {
+		return domain.ErrPermissionDenied("only the series' creator or an administrator can change it")
+	}
+	for _, instance := range instances {
+		if !instance.EditableBy(userID, role) {
+			return domain.ErrPermissionDenied("only users who can change every task in the series can change it")
+		}
+	}
+	return nil
+}
+
+func (s *taskService) GenerateRecurringTasks(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.seriesRepo.ListDue(ctx, now, recurrenceBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due task series: %w", err)
+	}
+
+	generated := 0
+	for _, series := range due {
+		var next *domain.Task
+		err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+			latest, err := s.latestInstance(txCtx, series)
+			if err != nil || latest == nil {
+				return err
+			}
+			// Occurrences missed while nobody was generating them are skipped
+			next, err = s.generateNextInstance(txCtx, series, latest, now)
+			return err
+		})
+		if err != nil {
+			// Another generator, or the completion of the latest instance, got there first
+			if domain.IsVersionConflictError(err) {
+				continue
+			}
+			s.logger.Warn(ctx, "Failed to generate recurring task", "series_id", series.ID, "error", err)
+			continue
+		}
+		if next != nil {
+			generated++
+		}
+	}
+
+	if generated > 0 {
+		s.logger.Info(ctx, "Generated recurring tasks", "count", generated)
+	}
+	return generated, nil
+}
+
+func (s *taskService) latestInstance(ctx context.Context, series *domain.TaskSeries) (*domain.Task, error) {
+	instances, err := s.taskRepo.ListSeriesInstances(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(instances) == 0 {
+		return nil, nil
+	}
+	latest := instances[len(instances)-1]
+	if latest.SeriesIndex != series.InstanceCount {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+// continueSeries generates the next instance of a just completed recurring task, unless a
+// later instance already exists
+func (s *taskService) continueSeries(ctx context.Context, task *domain.Task) error {
+	if task.SeriesID == nil {
+		return nil
+	}
+
+	series, err := s.seriesRepo.GetByID(ctx, *task.SeriesID)
+	if err != nil {
+		return fmt.Errorf("failed to get task series: %w", err)
+	}
+	if !series.IsActive || task.SeriesIndex < series.InstanceCount {
+		return nil
+	}
+
+	after := time.Now()
+	if task.DueDate != nil {
+		after = *task.DueDate
+	}
+	_, err = s.generateNextInstance(ctx, series, task, after)
+	return err
+}
+
+// generateNextInstance creates the instance that follows latest, due at the first occurrence
+// after the given time, with the same fields, categories and tags. A series that has run out
+// is marked inactive instead.
+func (s *taskService) generateNextInstance(ctx context.Context, series *domain.TaskSeries, latest *domain.Task, after time.Time) (*domain.Task, error) {
+	dueDate, ok := series.NextDueDate(after)
+	if !ok {
+		series.IsActive = false
+		if err := s.seriesRepo.Update(ctx, series); err != nil {
+			return nil, err
+		}
+		s.logger.Info(ctx, "Task series ended", "series_id", series.ID, "instances", series.InstanceCount)
+		return nil, nil
+	}
+
+	next := &domain.Task{
+		Title:       latest.Title,
+		Description: latest.Description,
+		AssigneeID:  latest.AssigneeID,
+		Status:      domain.TaskStatusOpen,
+		Priority:    latest.Priority,
+		DueDate:     &dueDate,
+		ParentID:    latest.ParentID,
+		SeriesID:    &series.ID,
+		SeriesIndex: series.InstanceCount + 1,
+		CreatorID:   latest.CreatorID,
+	}
+	if err := s.taskRepo.Create(ctx, next); err != nil {
+		return nil, fmt.Errorf("failed to create recurring task: %w", err)
+	}
+
+	categoryIDs := make([]string, 0, len(latest.Categories))
+	for _, category := range latest.Categories {
+		categoryIDs = append(categoryIDs, category.ID)
+	}
+	if err := s.taskRepo.AddCategories(ctx, next.ID, categoryIDs, next.Version); err != nil {
+		return nil, fmt.Errorf("failed to copy categories: %w", err)
+	}
+	created, err := s.taskRepo.GetByID(ctx, next.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring task: %w", err)
+	}
+	tagIDs := make([]string, 0, len(latest.Tags))
+	for _, tag := range latest.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	if err := s.taskRepo.AddTags(ctx, next.ID, tagIDs, created.Version); err != nil {
+		return nil, fmt.Errorf("failed to copy tags: %w", err)
+	}
+
+	series.InstanceCount++
+	if series.Rule.Count > 0 && series.InstanceCount >= series.Rule.Count {
+		series.IsActive = false
+	}
+	if err := s.seriesRepo.Update(ctx, series); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordHistory(ctx, next.ID, domain.TaskHistoryActionCreated, &domain.TaskHistoryDetails{
+		NewValues: map[string]interface{}{"due_date": dueDate},
+		Metadata: map[string]interface{}{
+			"series_id":      series.ID,
+			"series_index":   next.SeriesIndex,
+			"generated_from": latest.ID,
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Recurring task generated", "task_id", next.ID, "series_id", series.ID, "series_index", next.SeriesIndex)
+	return s.taskRepo.GetByID(ctx, next.ID)
+}
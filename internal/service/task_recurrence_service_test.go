@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/testutil"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+type mockTaskSeriesRepository struct {
+	series map[string]*domain.TaskSeries
+	tasks  *mockTaskRepository
+	nextID int
+}
+
+func newMockTaskSeriesRepository() *mockTaskSeriesRepository {
+	return &mockTaskSeriesRepository{series: make(map[string]*domain.TaskSeries)}
+}
+
+func (m *mockTaskSeriesRepository) Create(ctx context.Context, series *domain.TaskSeries) error {
+	m.nextID++
+	series.ID = fmt.Sprintf("series-%d", m.nextID)
+	series.Version = 1
+	stored := *series
+	m.series[series.ID] = &stored
+	return nil
+}
+
+func (m *mockTaskSeriesRepository) GetByID(ctx context.Context, id string) (*domain.TaskSeries, error) {
+	stored, ok := m.series[id]
+	if !ok {
+		return nil, domain.ErrNotFound("task series")
+	}
+	series := *stored
+	return &series, nil
+}
+
+func (m *mockTaskSeriesRepository) Update(ctx context.Context, series *domain.TaskSeries) error {
+	stored, ok := m.series[series.ID]
+	if !ok {
+		return domain.ErrNotFound("task series")
+	}
+	if stored.Version != series.Version {
+		return domain.ErrVersionConflict("task series", series.Version, stored.Version)
+	}
+	series.Version++
+	updated := *series
+	m.series[series.ID] = &updated
+	return nil
+}
+
+func (m *mockTaskSeriesRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.TaskSeries, error) {
+	var due []*domain.TaskSeries
+	for id, stored := range m.series {
+		if !stored.IsActive {
+			continue
+		}
+		instances, _ := m.tasks.ListSeriesInstances(ctx, id)
+		if len(instances) == 0 {
+			continue
+		}
+		latest := instances[len(instances)-1]
+		if latest.SeriesIndex == stored.InstanceCount && latest.DueDate != nil && !latest.DueDate.After(now) {
+			series := *stored
+			due = append(due, &series)
+		}
+	}
+	return due, nil
+}
+
+func setupRecurrenceTest(t *testing.T, dueDate *time.Time) (TaskService, *mockTaskRepository, *mockTaskSeriesRepository, *domain.Task) {
+	t.Helper()
+	ctx := requestctx.WithInternal(context.Background())
+	mockUserRepo := newMockUserRepository()
+	mockTaskRepo := newMockTaskRepository()
+	seriesRepo := newMockTaskSeriesRepository()
+	seriesRepo.tasks = mockTaskRepo
+	service := NewTaskService(mockTaskRepo, mockUserRepo, newMockCategoryRepository(), newMockTagRepository(), newMockWorkflowRepository(), seriesRepo, &mockTransactionManager{}, domain.SubtaskRules{}, logger.NewLogger("error"))
+
+	user := testutil.TestUser()
+	mockUserRepo.Create(ctx, user)
+
+	task := testutil.TestTask(user.ID)
+	task.Title = "Weekly report"
+	task.DueDate = dueDate
+	created, err := service.CreateTask(ctx, task)
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	return service, mockTaskRepo, seriesRepo, created
+}
+
+func mustParseRule(t *testing.T, value string) *domain.RecurrenceRule {
+	t.Helper()
+	rule, err := domain.ParseRecurrenceRule(value)
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule(%q) error = %v", value, err)
+	}
+	return rule
+}
+
+func TestTaskService_SetTaskRecurrence(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+
+	t.Run("needs a due date", func(t *testing.T) {
+		service, _, _, task := setupRecurrenceTest(t, nil)
+		_, _, err := service.SetTaskRecurrence(ctx, task.ID, mustParseRule(t, "FREQ=DAILY"), task.Version)
+		if !domain.IsBusinessRuleError(err) {
+			t.Errorf("SetTaskRecurrence() without a due date error = %v, want business rule violation", err)
+		}
+	})
+
+	t.Run("links the task to a new series", func(t *testing.T) {
+		due := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+		service, mockTaskRepo, _, task := setupRecurrenceTest(t, &due)
+
+		linked, series, err := service.SetTaskRecurrence(ctx, task.ID, mustParseRule(t, "FREQ=WEEKLY"), task.Version)
+		if err != nil {
+			t.Fatalf("SetTaskRecurrence() error = %v", err)
+		}
+		if linked.SeriesID == nil || *linked.SeriesID != series.ID || linked.SeriesIndex != 1 {
+			t.Errorf("task series = %v #%d, want %s #1", linked.SeriesID, linked.SeriesIndex, series.ID)
+		}
+		if !series.StartsAt.Equal(due) || !series.IsActive || len(mockTaskRepo.history) != 2 {
+			t.Errorf("series = %+v, history = %d", series, len(mockTaskRepo.history))
+		}
+
+		if _, _, err := service.SetTaskRecurrence(ctx, task.ID, mustParseRule(t, "FREQ=DAILY"), linked.Version); !domain.IsBusinessRuleError(err) {
+			t.Errorf("SetTaskRecurrence() twice error = %v, want business rule violation", err)
+		}
+	})
+}
+
+func TestTaskService_RecurringTaskCompletion(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	due := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	service, mockTaskRepo, seriesRepo, task := setupRecurrenceTest(t, &due)
+
+	_, series, err := service.SetTaskRecurrence(ctx, task.ID, mustParseRule(t, "FREQ=WEEKLY;COUNT=3"), task.Version)
+	if err != nil {
+		t.Fatalf("SetTaskRecurrence() error = %v", err)
+	}
+
+	current := task
+	for i := 2; i <= 3; i++ {
+		if _, err := service.ChangeTaskStatus(ctx, current.ID, domain.TaskStatusCompleted, current.Version); err != nil {
+			t.Fatalf("ChangeTaskStatus(#%d) error = %v", i-1, err)
+		}
+		_, instances, err := service.GetTaskSeries(ctx, series.ID)
+		if err != nil || len(instances) != i {
+			t.Fatalf("GetTaskSeries() = %d instances, %v; want %d", len(instances), err, i)
+		}
+		next := instances[i-1]
+		wantDue := due.AddDate(0, 0, 7*(i-1))
+		if next.SeriesIndex != i || next.Status != domain.TaskStatusOpen || !next.DueDate.Equal(wantDue) || next.Title != task.Title {
+			t.Errorf("instance #%d = %+v, want an open copy due %v", i, next, wantDue)
+		}
+		current = next
+	}
+
+	// COUNT=3 is reached, so completing the last instance ends the series
+	if stored := seriesRepo.series[series.ID]; stored.IsActive || stored.InstanceCount != 3 {
+		t.Errorf("series = %+v, want three instances and inactive", stored)
+	}
+	if _, err := service.ChangeTaskStatus(ctx, current.ID, domain.TaskStatusCompleted, current.Version); err != nil {
+		t.Fatalf("ChangeTaskStatus(#3) error = %v", err)
+	}
+	if len(mockTaskRepo.tasks) != 3 {
+		t.Errorf("tasks = %d, want no instance past COUNT", len(mockTaskRepo.tasks))
+	}
+}
+
+func TestTaskService_GenerateRecurringTasks(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	due := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	service, _, _, task := setupRecurrenceTest(t, &due)
+
+	_, series, err := service.SetTaskRecurrence(ctx, task.ID, mustParseRule(t, "FREQ=DAILY"), task.Version)
+	if err != nil {
+		t.Fatalf("SetTaskRecurrence() error = %v", err)
+	}
+
+	if generated, err := service.GenerateRecurringTasks(ctx, due.Add(-time.Hour)); err != nil || generated != 0 {
+		t.Errorf("GenerateRecurringTasks() before the due date = %d, %v; want 0", generated, err)
+	}
+
+	// Missed occurrences are skipped: the next instance is the first one after now
+	now := due.AddDate(0, 0, 3).Add(time.Hour)
+	if generated, err := service.GenerateRecurringTasks(ctx, now); err != nil || generated != 1 {
+		t.Fatalf("GenerateRecurringTasks() = %d, %v; want 1", generated, err)
+	}
+	_, instances, _ := service.GetTaskSeries(ctx, series.ID)
+	if len(instances) != 2 || !instances[1].DueDate.Equal(due.AddDate(0, 0, 4)) {
+		t.Fatalf("instances = %d, want a second one due %v", len(instances), due.AddDate(0, 0, 4))
+	}
+	if instances[0].Status != domain.TaskStatusOpen {
+		t.Errorf("overdue instance status = %s, want it left open", instances[0].Status)
+	}
+
+	if generated, err := service.GenerateRecurringTasks(ctx, now); err != nil || generated != 0 {
+		t.Errorf("GenerateRecurringTasks() again = %d, %v; want 0", generated, err)
+	}
+	// Completing an instance that is no longer the latest does not add another
+	if _, err := service.ChangeTaskStatus(ctx, instances[0].ID, domain.TaskStatusCompleted, instances[0].Version); err != nil {
+		t.Fatalf("ChangeTaskStatus() error = %v", err)
+	}
+	if _, instances, _ = service.GetTaskSeries(ctx, series.ID); len(instances) != 2 {
+		t.Errorf("instances = %d, want 2", len(instances))
+	}
+}
+
+func TestTaskService_UpdateTaskSeries(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	due := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	service, _, _, task := setupRecurrenceTest(t, &due)
+
+	_, series, err := service.SetTaskRecurrence(ctx, task.ID, mustParseRule(t, "FREQ=WEEKLY"), task.Version)
+	if err != nil {
+		t.Fatalf("SetTaskRecurrence() error = %v", err)
+	}
+	if _, err := service.ChangeTaskStatus(ctx, task.ID, domain.TaskStatusCompleted, task.Version); err != nil {
+		t.Fatalf("ChangeTaskStatus() error = %v", err)
+	}
+	series, _, _ = service.GetTaskSeries(ctx, series.ID)
+
+	title := "Monthly report"
+	priority := domain.TaskPriorityHigh
+	changes := domain.TaskSeriesChanges{Title: &title, Priority: &priority, Rule: mustParseRule(t, "FREQ=MONTHLY")}
+	updatedSeries, updated, err := service.UpdateTaskSeries(ctx, series.ID, changes, series.Version)
+	if err != nil {
+		t.Fatalf("UpdateTaskSeries() error = %v", err)
+	}
+	// The completed first instance keeps its values
+	if len(updated) != 1 || updated[0].SeriesIndex != 2 || updated[0].Title != title || updated[0].Priority != priority {
+		t.Errorf("updated = %+v, want only the open second instance", updated)
+	}
+	if task.Title != "Weekly report" || updatedSeries.Rule.Frequency != domain.RecurrenceMonthly {
+		t.Errorf("first instance title = %q, rule = %s", task.Title, updatedSeries.Rule.String())
+	}
+
+	if _, _, err := service.UpdateTaskSeries(ctx, series.ID, changes, series.Version); !domain.IsVersionConflictError(err) {
+		t.Errorf("UpdateTaskSeries() with a stale version error = %v, want version conflict", err)
+	}
+
+	stopped, err := service.StopTaskSeries(ctx, series.ID, updatedSeries.Version)
+	if err != nil || stopped.IsActive {
+		t.Fatalf("StopTaskSeries() = %+v, %v", stopped, err)
+	}
+	if _, err := service.ChangeTaskStatus(ctx, updated[0].ID, domain.TaskStatusCompleted, updated[0].Version); err != nil {
+		t.Fatalf("ChangeTaskStatus() error = %v", err)
+	}
+	if _, instances, _ := service.GetTaskSeries(ctx, series.ID); len(instances) != 2 {
+		t.Errorf("instances = %d, want none added to a stopped series", len(instances))
+	}
+}
@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/taskquery"
 	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
 )
 
 type taskService struct {
@@ -14,6 +19,10 @@ type taskService struct {
 	userRepo     repository.UserRepository
 	categoryRepo repository.CategoryRepository
 	tagRepo      repository.TagRepository
+	workflowRepo repository.WorkflowRepository
+	seriesRepo   repository.TaskSeriesRepository
+	txManager    repository.TransactionManager
+	subtaskRules domain.SubtaskRules
 	logger       logger.Logger
 }
 
@@ -23,6 +32,10 @@ func NewTaskService(
 	userRepo repository.UserRepository,
 	categoryRepo repository.CategoryRepository,
 	tagRepo repository.TagRepository,
+	workflowRepo repository.WorkflowRepository,
+	seriesRepo repository.TaskSeriesRepository,
+	txManager repository.TransactionManager,
+	subtaskRules domain.SubtaskRules,
 	log logger.Logger,
 ) TaskService {
 	return &taskService{
@@ -30,6 +43,10 @@ func NewTaskService(
 		userRepo:     userRepo,
 		categoryRepo: categoryRepo,
 		tagRepo:      tagRepo,
+		workflowRepo: workflowRepo,
+		seriesRepo:   seriesRepo,
+		txManager:    txManager,
+		subtaskRules: subtaskRules,
 		logger:       log,
 	}
 }
@@ -37,6 +54,16 @@ func NewTaskService(
 func (s *taskService) CreateTask(ctx context.Context, task *domain.Task) (*domain.Task, error) {
 	s.logger.Info(ctx, "Creating new task", "title", task.Title, "assignee_id", task.AssigneeID)
 
+	// Callers create tasks as themselves; only the service's own jobs create them as the system user
+	creatorID := domain.SystemUserID
+	if !requestctx.IsInternal(ctx) {
+		userID, _, err := authenticatedCaller(ctx)
+		if err != nil {
+			return nil, err
+		}
+		creatorID = userID
+	}
+
 	// Business validation
 	if err := s.validateTaskForCreation(ctx, task); err != nil {
 		return nil, err
@@ -52,8 +79,32 @@ func (s *taskService) CreateTask(ctx context.Context, task *domain.Task) (*domai
 		}
 	}
 
-	// Create task
-	if err := s.taskRepo.Create(ctx, task); err != nil {
+	// Validate parent task exists
+	if err := s.validateTaskParent(ctx, task); err != nil {
+		return nil, err
+	}
+
+	task.CreatorID = creatorID
+
+	// Validate further participants; whoever creates the task owns it
+	if len(task.Participants) > 0 {
+		if err := s.validateParticipants(ctx, task.Participants); err != nil {
+			return nil, err
+		}
+	}
+	owner := domain.TaskParticipant{UserID: requestctx.UserID(ctx), Role: domain.TaskRoleOwner}
+	if owner.UserID != "" && !hasParticipant(task.Participants, owner) {
+		task.Participants = append(task.Participants, owner)
+	}
+
+	// Create task; its CREATED history records the creator for watchers and the activity feed
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.taskRepo.Create(txCtx, task); err != nil {
+			return err
+		}
+		return s.recordHistory(txCtx, task.ID, domain.TaskHistoryActionCreated, nil)
+	})
+	if err != nil {
 		s.logger.Error(ctx, "Failed to create task", "error", err, "title", task.Title)
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
@@ -85,23 +136,42 @@ func (s *taskService) GetTaskByID(ctx context.Context, id string) (*domain.Task,
 func (s *taskService) UpdateTask(ctx context.Context, task *domain.Task) (*domain.Task, error) {
 	s.logger.Info(ctx, "Updating task", "task_id", task.ID, "version", task.Version)
 
-	// Business validation
-	if err := s.validateTaskForUpdate(ctx, task); err != nil {
-		return nil, err
+	if task.ID == "" {
+		return nil, domain.ErrInvalidInput("task ID is required for update")
 	}
 
-	// Validate assignee exists if changed
-	if task.AssigneeID != "" {
-		if _, err := s.userRepo.GetByID(ctx, task.AssigneeID); err != nil {
-			if domain.IsNotFoundError(err) {
-				return nil, domain.ErrInvalidInput("assignee does not exist")
-			}
-			return nil, fmt.Errorf("failed to validate assignee: %w", err)
+	existing, err := s.taskRepo.GetByID(ctx, task.ID)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
 		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if err := authorizeTaskEdit(ctx, existing); err != nil {
+		return nil, err
+	}
+	// The creator is fixed, moves go through MoveTask and series links through the series
+	if task.CreatorID != existing.CreatorID {
+		return nil, domain.ErrInvalidInput("the creator of a task cannot be changed")
+	}
+	if !sameID(task.ParentID, existing.ParentID) {
+		return nil, domain.ErrInvalidInput("the parent of a task is changed with MoveTask")
+	}
+	if !sameID(task.SeriesID, existing.SeriesID) || task.SeriesIndex != existing.SeriesIndex {
+		return nil, domain.ErrInvalidInput("the series of a task cannot be changed")
 	}
 
-	// Update task
-	if err := s.taskRepo.Update(ctx, task); err != nil {
+	// Business validation
+	if err := s.validateTaskForUpdate(ctx, existing, task); err != nil {
+		return nil, err
+	}
+
+	// Update task; what changed is recorded in the same transaction
+	details := taskUpdateHistory(existing, task)
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		return s.saveTaskUpdate(txCtx, existing, task, domain.TaskHistoryActionUpdated, details)
+	})
+	if err != nil {
 		if domain.IsVersionConflictError(err) {
 			s.logger.Warn(ctx, "Task update version conflict", "task_id", task.ID, "version", task.Version)
 			return nil, err
@@ -114,14 +184,28 @@ func (s *taskService) UpdateTask(ctx context.Context, task *domain.Task) (*domai
 	return task, nil
 }
 
-func (s *taskService) DeleteTask(ctx context.Context, id string, version int64) error {
-	s.logger.Info(ctx, "Soft deleting task", "task_id", id, "version", version)
+func (s *taskService) DeleteTask(ctx context.Context, id string, version int64, cascade bool) error {
+	s.logger.Info(ctx, "Soft deleting task", "task_id", id, "version", version, "cascade", cascade)
 
 	if id == "" {
 		return domain.ErrInvalidInput("task ID is required")
 	}
 
-	if err := s.taskRepo.SoftDelete(ctx, id, version); err != nil {
+	task, err := s.GetTaskByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return err
+	}
+
+	var deletedIDs []string
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		var err error
+		deletedIDs, err = s.softDeleteTask(txCtx, id, version, cascade)
+		return err
+	})
+	if err != nil {
 		if domain.IsVersionConflictError(err) {
 			s.logger.Warn(ctx, "Task deletion version conflict", "task_id", id, "version", version)
 			return err
@@ -130,7 +214,7 @@ func (s *taskService) DeleteTask(ctx context.Context, id string, version int64)
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
 
-	s.logger.Info(ctx, "Task deleted successfully", "task_id", id)
+	s.logger.Info(ctx, "Task deleted successfully", "task_id", id, "subtasks", len(deletedIDs))
 	return nil
 }
 
@@ -141,6 +225,18 @@ func (s *taskService) RestoreTask(ctx context.Context, id string, version int64)
 		return nil, domain.ErrInvalidInput("task ID is required")
 	}
 
+	deleted, err := s.taskRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to get deleted task", "error", err, "task_id", id)
+		return nil, fmt.Errorf("failed to get deleted task: %w", err)
+	}
+	if err := authorizeTaskEdit(ctx, deleted); err != nil {
+		return nil, err
+	}
+
 	if err := s.taskRepo.Restore(ctx, id, version); err != nil {
 		if domain.IsVersionConflictError(err) {
 			s.logger.Warn(ctx, "Task restoration version conflict", "task_id", id, "version", version)
@@ -161,7 +257,11 @@ func (s *taskService) RestoreTask(ctx context.Context, id string, version int64)
 }
 
 func (s *taskService) ListTasks(ctx context.Context, opts repository.TaskListOptions) ([]*domain.Task, int64, error) {
-	s.logger.Debug(ctx, "Listing tasks", "page", opts.Page, "page_size", opts.PageSize)
+	s.logger.Debug(ctx, "Listing tasks", "page", opts.Page, "page_size", opts.PageSize, "query", opts.Query)
+
+	if err := bindTaskQuery(ctx, &opts); err != nil {
+		return nil, 0, err
+	}
 
 	tasks, total, err := s.taskRepo.List(ctx, opts)
 	if err != nil {
@@ -173,6 +273,94 @@ func (s *taskService) ListTasks(ctx context.Context, opts repository.TaskListOpt
 	return tasks, total, nil
 }
 
+func (s *taskService) SearchTasks(ctx context.Context, opts repository.TaskListOptions) ([]*domain.TaskSearchResult, int64, error) {
+	opts.SearchQuery = strings.TrimSpace(opts.SearchQuery)
+	s.logger.Debug(ctx, "Searching tasks", "query", opts.SearchQuery, "search_labels", opts.SearchLabels)
+
+	if opts.SearchQuery == "" {
+		return nil, 0, domain.ErrInvalidInput("search query is required")
+	}
+	if len(opts.SearchQuery) > domain.MaxSearchQueryLength {
+		return nil, 0, domain.ErrInvalidInput(fmt.Sprintf("search query must be at most %d characters", domain.MaxSearchQueryLength))
+	}
+
+	if err := bindTaskQuery(ctx, &opts); err != nil {
+		return nil, 0, err
+	}
+
+	results, total, err := s.taskRepo.Search(ctx, opts)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to search tasks", "error", err)
+		return nil, 0, fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	s.logger.Debug(ctx, "Searched tasks successfully", "count", len(results), "total", total)
+	return results, total, nil
+}
+
+func (s *taskService) GetTaskFacets(ctx context.Context, opts repository.TaskListOptions, facets []domain.TaskFacet) ([]*domain.FacetCounts, error) {
+	s.logger.Debug(ctx, "Counting task facets", "facets", facets, "query", opts.Query)
+
+	facets, err := normalizeTaskFacets(facets)
+	if err != nil {
+		return nil, err
+	}
+	if err := bindTaskQuery(ctx, &opts); err != nil {
+		return nil, err
+	}
+
+	counts, err := s.taskRepo.Facets(ctx, opts, facets)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to count task facets", "error", err)
+		return nil, fmt.Errorf("failed to count task facets: %w", err)
+	}
+	return counts, nil
+}
+
+// normalizeTaskFacets validates the requested facets and drops repeats; none selects all of them
+func normalizeTaskFacets(requested []domain.TaskFacet) ([]domain.TaskFacet, error) {
+	if len(requested) == 0 {
+		return domain.TaskFacets, nil
+	}
+
+	facets := make([]domain.TaskFacet, 0, len(requested))
+	seen := make(map[domain.TaskFacet]bool, len(requested))
+	for _, facet := range requested {
+		if !isKnownTaskFacet(facet) {
+			return nil, domain.ErrInvalidInput(fmt.Sprintf("unknown task facet %q", facet))
+		}
+		if !seen[facet] {
+			seen[facet] = true
+			facets = append(facets, facet)
+		}
+	}
+	return facets, nil
+}
+
+func isKnownTaskFacet(facet domain.TaskFacet) bool {
+	for _, known := range domain.TaskFacets {
+		if facet == known {
+			return true
+		}
+	}
+	return false
+}
+
+// bindTaskQuery parses opts.Query and binds it to the caller and the current time
+func bindTaskQuery(ctx context.Context, opts *repository.TaskListOptions) error {
+	opts.QueryExpr = nil
+	if strings.TrimSpace(opts.Query) == "" {
+		return nil
+	}
+
+	expr, err := taskquery.Parse(opts.Query)
+	if err != nil {
+		return err
+	}
+	opts.QueryExpr, err = taskquery.Bind(expr, taskquery.Env{UserID: requestctx.UserID(ctx), Now: time.Now()})
+	return err
+}
+
 func (s *taskService) AssignTask(ctx context.Context, taskID, assigneeID string, version int64) (*domain.Task, error) {
 	s.logger.Info(ctx, "Assigning task", "task_id", taskID, "assignee_id", assigneeID, "version", version)
 
@@ -198,8 +386,9 @@ func (s *taskService) AssignTask(ctx context.Context, taskID, assigneeID string,
 	}
 
 	// Update assignment
-	task.AssigneeID = assigneeID
-	return s.UpdateTask(ctx, task)
+	updated := *task
+	updated.AssigneeID = assigneeID
+	return s.UpdateTask(ctx, &updated)
 }
 
 func (s *taskService) ChangeTaskStatus(ctx context.Context, taskID string, status domain.TaskStatus, version int64) (*domain.Task, error) {
@@ -216,14 +405,10 @@ func (s *taskService) ChangeTaskStatus(ctx context.Context, taskID string, statu
 		return nil, domain.ErrVersionConflict("task", version, task.Version)
 	}
 
-	// Business validation for status change
-	if err := s.validateStatusTransition(task.Status, status); err != nil {
-		return nil, err
-	}
-
-	// Update status
-	task.Status = status
-	return s.UpdateTask(ctx, task)
+	// Update status; UpdateTask checks the change and completes what depends on it
+	updated := *task
+	updated.Status = status
+	return s.UpdateTask(ctx, &updated)
 }
 
 func (s *taskService) ChangeTaskPriority(ctx context.Context, taskID string, priority domain.TaskPriority, version int64) (*domain.Task, error) {
@@ -241,8 +426,9 @@ func (s *taskService) ChangeTaskPriority(ctx context.Context, taskID string, pri
 	}
 
 	// Update priority
-	task.Priority = priority
-	return s.UpdateTask(ctx, task)
+	updated := *task
+	updated.Priority = priority
+	return s.UpdateTask(ctx, &updated)
 }
 
 func (s *taskService) AddTaskCategories(ctx context.Context, taskID string, categoryIDs []string, version int64) (*domain.Task, error) {
@@ -258,6 +444,9 @@ func (s *taskService) AddTaskCategories(ctx context.Context, taskID string, cate
 	if task.Version != version {
 		return nil, domain.ErrVersionConflict("task", version, task.Version)
 	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return nil, err
+	}
 
 	// Validate categories exist
 	for _, categoryID := range categoryIDs {
@@ -292,6 +481,9 @@ func (s *taskService) RemoveTaskCategories(ctx context.Context, taskID string, c
 	if task.Version != version {
 		return nil, domain.ErrVersionConflict("task", version, task.Version)
 	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return nil, err
+	}
 
 	// Remove categories via repository
 	if err := s.taskRepo.RemoveCategories(ctx, taskID, categoryIDs, version); err != nil {
@@ -316,6 +508,9 @@ func (s *taskService) AddTaskTags(ctx context.Context, taskID string, tagIDs []s
 	if task.Version != version {
 		return nil, domain.ErrVersionConflict("task", version, task.Version)
 	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return nil, err
+	}
 
 	// Validate tags exist
 	for _, tagID := range tagIDs {
@@ -350,6 +545,9 @@ func (s *taskService) RemoveTaskTags(ctx context.Context, taskID string, tagIDs
 	if task.Version != version {
 		return nil, domain.ErrVersionConflict("task", version, task.Version)
 	}
+	if err := authorizeTaskEdit(ctx, task); err != nil {
+		return nil, err
+	}
 
 	// Remove tags via repository
 	if err := s.taskRepo.RemoveTags(ctx, taskID, tagIDs, version); err != nil {
@@ -392,52 +590,184 @@ func (s *taskService) validateTaskForCreation(ctx context.Context, task *domain.
 	return nil
 }
 
-func (s *taskService) validateTaskForUpdate(ctx context.Context, task *domain.Task) error {
-	if task.ID == "" {
-		return domain.ErrInvalidInput("task ID is required for update")
+// authorizeTaskEdit lets administrators, the task's creator and its participants change a task.
+// Work the service starts itself, such as scheduled jobs, is not restricted.
+func authorizeTaskEdit(ctx context.Context, task *domain.Task) error {
+	if requestctx.IsInternal(ctx) {
+		return nil
+	}
+	userID, role, err := authenticatedCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if !task.EditableBy(userID, role) {
+		return domain.ErrPermissionDenied("only the task's creator, its participants or an administrator can change it")
+	}
+	return nil
+}
+
+func (s *taskService) validateTaskForUpdate(ctx context.Context, existing, task *domain.Task) error {
+	// Workflow rules come first, so a refused status change is reported as such
+	if err := s.checkTaskUpdate(ctx, existing, task); err != nil {
+		return err
 	}
 
 	if err := task.IsValid(); err != nil {
 		return err
 	}
 
+	// Validate assignee exists if changed
+	if task.AssigneeID != "" {
+		if _, err := s.userRepo.GetByID(ctx, task.AssigneeID); err != nil {
+			if domain.IsNotFoundError(err) {
+				return domain.ErrInvalidInput("assignee does not exist")
+			}
+			return fmt.Errorf("failed to validate assignee: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// saveTaskUpdate writes a validated update and records it. Parents completed along with the
+// task, and the next instance of its series, share the caller's transaction.
+func (s *taskService) saveTaskUpdate(ctx context.Context, existing, task *domain.Task, action domain.TaskHistoryAction, details *domain.TaskHistoryDetails) error {
+	completes := task.Status == domain.TaskStatusCompleted && existing.Status != domain.TaskStatusCompleted
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return err
+	}
+	if len(details.Changes) > 0 {
+		if err := s.recordHistory(ctx, task.ID, action, details); err != nil {
+			return err
+		}
+	}
+
+	if !completes {
+		return nil
+	}
+	if err := s.completeParents(ctx, task); err != nil {
+		return err
+	}
+	return s.continueSeries(ctx, task)
+}
+
+// taskUpdateHistory describes the fields an update changes, in the form bulk updates record
+func taskUpdateHistory(existing, task *domain.Task) *domain.TaskHistoryDetails {
+	details := &domain.TaskHistoryDetails{
+		OldValues: map[string]interface{}{},
+		NewValues: map[string]interface{}{},
+	}
+	set := func(field string, old, new interface{}) {
+		details.OldValues[field] = old
+		details.NewValues[field] = new
+		details.Changes = append(details.Changes, field)
+	}
+
+	if task.Title != existing.Title {
+		set("title", existing.Title, task.Title)
+	}
+	if task.Description != existing.Description {
+		set("description", existing.Description, task.Description)
+	}
+	if task.AssigneeID != existing.AssigneeID {
+		set("assignee_id", existing.AssigneeID, task.AssigneeID)
+	}
+	if task.Status != existing.Status {
+		set("status", existing.Status, task.Status)
+	}
+	if task.Priority != existing.Priority {
+		set("priority", existing.Priority, task.Priority)
+	}
+	if !sameTime(task.DueDate, existing.DueDate) {
+		set("due_date", existing.DueDate, task.DueDate)
+	}
+	return details
+}
+
+func sameID(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// checkTaskUpdate holds an update to the workflow: a new status must be reachable from the
+// current one, and an update that keeps the status must not clear a field the status requires
+func (s *taskService) checkTaskUpdate(ctx context.Context, existing, task *domain.Task) error {
+	// Categories and participants only change through their own calls
+	proposed := *task
+	proposed.Categories = existing.Categories
+	proposed.Participants = existing.Participants
+
+	if task.Status == existing.Status {
+		return s.checkRequiredFields(ctx, existing, &proposed)
+	}
+	proposed.Status = existing.Status
+	if err := s.checkStatusTransition(ctx, &proposed, task.Status); err != nil {
+		return err
+	}
+	if err := s.checkSubtaskCompletion(ctx, &proposed, task.Status); err != nil {
+		return err
+	}
+	return s.checkBlockers(ctx, &proposed, task.Status)
+}
+
+// checkStatusTransition checks a status change against the workflow of each of the task's
+// categories. Tasks whose categories have no workflow follow the default one.
+func (s *taskService) checkStatusTransition(ctx context.Context, task *domain.Task, status domain.TaskStatus) error {
+	workflows, err := s.taskWorkflows(ctx, task)
+	if err != nil {
+		return err
+	}
+
+	callerID, role := callerIdentity(ctx)
+	for _, workflow := range workflows {
+		if err := workflow.CheckTransition(task, status, callerID, role); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (s *taskService) validateStatusTransition(currentStatus, newStatus domain.TaskStatus) error {
-	// Define valid status transitions
-	validTransitions := map[domain.TaskStatus][]domain.TaskStatus{
-		domain.TaskStatusOpen: {
-			domain.TaskStatusInProgress,
-			domain.TaskStatusCompleted,
-			domain.TaskStatusCancelled,
-		},
-		domain.TaskStatusInProgress: {
-			domain.TaskStatusCompleted,
-			domain.TaskStatusOpen,
-			domain.TaskStatusCancelled,
-		},
-		domain.TaskStatusCompleted: {
-			domain.TaskStatusOpen,
-			domain.TaskStatusInProgress,
-		},
-		domain.TaskStatusCancelled: {
-			domain.TaskStatusOpen,
-			domain.TaskStatusInProgress,
-		},
-	}
-
-	// Check if transition is valid
-	allowedTransitions, exists := validTransitions[currentStatus]
-	if !exists {
-		return domain.ErrBusinessRule(fmt.Sprintf("unknown current status: %s", currentStatus))
-	}
-
-	for _, allowed := range allowedTransitions {
-		if allowed == newStatus {
-			return nil // Valid transition
+// checkRequiredFields rejects an update that clears a field the task's status requires. Fields
+// the task already lacked, for example because its workflow changed, do not hold up other edits.
+func (s *taskService) checkRequiredFields(ctx context.Context, existing, task *domain.Task) error {
+	workflows, err := s.taskWorkflows(ctx, task)
+	if err != nil {
+		return err
+	}
+	for _, workflow := range workflows {
+		if err := workflow.CheckRequiredFields(task); err != nil && workflow.CheckRequiredFields(existing) == nil {
+			return err
 		}
 	}
+	return nil
+}
+
+// taskWorkflows returns the workflows of the task's categories, or the default workflow
+func (s *taskService) taskWorkflows(ctx context.Context, task *domain.Task) ([]*domain.Workflow, error) {
+	categoryIDs := make([]string, len(task.Categories))
+	for i, category := range task.Categories {
+		categoryIDs[i] = category.ID
+	}
 
-	return domain.ErrBusinessRule(fmt.Sprintf("invalid status transition from %s to %s", currentStatus, newStatus))
+	workflows, err := s.workflowRepo.GetForCategories(ctx, categoryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task workflows: %w", err)
+	}
+	if len(workflows) == 0 {
+		workflow, err := defaultWorkflow(ctx, s.workflowRepo)
+		if err != nil {
+			return nil, err
+		}
+		workflows = []*domain.Workflow{workflow}
+	}
+	return workflows, nil
 }
@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/todo-app/services/admin-service/internal/model/domain"
 	"github.com/todo-app/services/admin-service/internal/repository"
 	"github.com/todo-app/services/admin-service/internal/testutil"
 	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
 )
 
 func TestTaskService_CreateTask(t *testing.T) {
@@ -17,8 +22,8 @@ func TestTaskService_CreateTask(t *testing.T) {
 	mockTagRepo := newMockTagRepository()
 	mockLogger := logger.NewLogger("debug")
 
-	service := NewTaskService(mockTaskRepo, mockUserRepo, mockCategoryRepo, mockTagRepo, mockLogger)
-	ctx := context.Background()
+	service := NewTaskService(mockTaskRepo, mockUserRepo, mockCategoryRepo, mockTagRepo, newMockWorkflowRepository(), newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, mockLogger)
+	ctx := requestctx.WithUser(context.Background(), "reporter-1", "user")
 
 	// Create a test user for assignment
 	testUser := testutil.TestUser()
@@ -73,6 +78,12 @@ func TestTaskService_CreateTask(t *testing.T) {
 			}
 		})
 	}
+
+	// Tasks need someone to have created them
+	anonymous := &domain.Task{Title: "Anonymous", AssigneeID: testUser.ID, Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium}
+	if _, err := service.CreateTask(context.Background(), anonymous); !isUnauthorizedError(err) {
+		t.Errorf("CreateTask() without a caller error = %v, want unauthorized", err)
+	}
 }
 
 func TestTaskService_ChangeTaskStatus(t *testing.T) {
@@ -82,8 +93,8 @@ func TestTaskService_ChangeTaskStatus(t *testing.T) {
 	mockTagRepo := newMockTagRepository()
 	mockLogger := logger.NewLogger("debug")
 
-	service := NewTaskService(mockTaskRepo, mockUserRepo, mockCategoryRepo, mockTagRepo, mockLogger)
-	ctx := context.Background()
+	service := NewTaskService(mockTaskRepo, mockUserRepo, mockCategoryRepo, mockTagRepo, newMockWorkflowRepository(), newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, mockLogger)
+	ctx := requestctx.WithInternal(context.Background())
 
 	// Create a test task
 	testUser := testutil.TestUser()
@@ -140,8 +151,8 @@ func TestTaskService_AssignTask(t *testing.T) {
 	mockTagRepo := newMockTagRepository()
 	mockLogger := logger.NewLogger("debug")
 
-	service := NewTaskService(mockTaskRepo, mockUserRepo, mockCategoryRepo, mockTagRepo, mockLogger)
-	ctx := context.Background()
+	service := NewTaskService(mockTaskRepo, mockUserRepo, mockCategoryRepo, mockTagRepo, newMockWorkflowRepository(), newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, mockLogger)
+	ctx := requestctx.WithInternal(context.Background())
 
 	// Create test users
 	originalUser := testutil.TestUser()
@@ -196,6 +207,83 @@ func TestTaskService_AssignTask(t *testing.T) {
 	}
 }
 
+func TestTaskService_SearchTasks(t *testing.T) {
+	mockTaskRepo := newMockTaskRepository()
+	mockLogger := logger.NewLogger("debug")
+
+	service := NewTaskService(mockTaskRepo, newMockUserRepository(), newMockCategoryRepository(), newMockTagRepository(), newMockWorkflowRepository(), newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, mockLogger)
+	ctx := context.Background()
+
+	for _, title := range []string{"Deploy release", "Write release notes", "Plan sprint"} {
+		task := testutil.TestTask("user-1")
+		task.Title = title
+		mockTaskRepo.Create(ctx, task)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCount int
+		wantErr   bool
+	}{
+		{name: "matching query", query: "  release ", wantCount: 2},
+		{name: "no matches", query: "retro", wantCount: 0},
+		{name: "empty query", query: "   ", wantErr: true},
+		{name: "query too long", query: strings.Repeat("a", domain.MaxSearchQueryLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := repository.TaskListOptions{ListOptions: repository.ListOptions{SearchQuery: tt.query}}
+			results, total, err := service.SearchTasks(ctx, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SearchTasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !domain.IsInvalidInputError(err) {
+					t.Errorf("expected an invalid input error, got %v", err)
+				}
+				return
+			}
+			if len(results) != tt.wantCount || total != int64(tt.wantCount) {
+				t.Errorf("got %d results (total %d), want %d", len(results), total, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestTaskService_ListTasks_Query(t *testing.T) {
+	mockLogger := logger.NewLogger("debug")
+	service := NewTaskService(newMockTaskRepository(), newMockUserRepository(), newMockCategoryRepository(), newMockTagRepository(), newMockWorkflowRepository(), newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, mockLogger)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		query   string
+		wantErr string
+	}{
+		{name: "valid query", ctx: requestctx.WithUser(context.Background(), "user-1", "user"), query: "status:open assignee:me due<7d"},
+		{name: "syntax error", ctx: context.Background(), query: "status:open (tag:x", wantErr: "position 13"},
+		{name: "me without caller", ctx: context.Background(), query: "assignee:me", wantErr: "authenticated caller"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := repository.TaskListOptions{Query: tt.query}
+			_, _, err := service.ListTasks(tt.ctx, opts)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ListTasks() error = %v", err)
+				}
+				return
+			}
+			if !domain.IsInvalidInputError(err) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ListTasks() error = %v, want an invalid input error mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 type mockTagRepository struct {
 	tags map[string]*domain.Tag
 }
@@ -261,3 +349,621 @@ func (m *mockTagRepository) List(ctx context.Context, opts repository.TagListOpt
 	}
 	return tags, int64(len(tags)), nil
 }
+
+func (m *mockTagRepository) GetByName(ctx context.Context, name string) (*domain.Tag, error) {
+	for _, tag := range m.tags {
+		if tag.Name == name {
+			return tag, nil
+		}
+	}
+	return nil, domain.ErrNotFound("tag")
+}
+
+func (m *mockTagRepository) Suggest(ctx context.Context, opts repository.TagSuggestOptions) ([]*domain.TagSuggestion, error) {
+	return nil, nil
+}
+
+func (m *mockTagRepository) ReassignTasks(ctx context.Context, sourceID, targetID string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockTagRepository) CountTasks(ctx context.Context, tagIDs []string) (map[string]int64, error) {
+	return map[string]int64{}, nil
+}
+
+func (m *mockTagRepository) AddSynonym(ctx context.Context, synonym *domain.TagSynonym) error {
+	return nil
+}
+
+func (m *mockTagRepository) GetSynonym(ctx context.Context, synonym string) (*domain.TagSynonym, error) {
+	return nil, domain.ErrNotFound("tag synonym")
+}
+
+func (m *mockTagRepository) ListSynonyms(ctx context.Context, tagID string) ([]*domain.TagSynonym, error) {
+	return nil, nil
+}
+
+func (m *mockTagRepository) DeleteSynonym(ctx context.Context, synonym string) error {
+	return domain.ErrNotFound("tag synonym")
+}
+
+func (m *mockTagRepository) MoveSynonyms(ctx context.Context, fromID, toID string) error {
+	return nil
+}
+
+// mockTransactionManager runs the function without a real transaction
+type mockTransactionManager struct {
+	calls      int
+	failCommit int    // number of the call whose commit fails, counting from 1
+	begin      func() // runs as each transaction starts, standing in for concurrent commits
+}
+
+func (m *mockTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	m.calls++
+	if m.begin != nil {
+		m.begin()
+	}
+	if err := fn(ctx, nil); err != nil {
+		return err
+	}
+	if m.calls == m.failCommit {
+		return errors.New("commit failed")
+	}
+	return nil
+}
+
+func TestTaskService_GetTaskFacets(t *testing.T) {
+	taskRepo := newMockTaskRepository()
+	for _, status := range []domain.TaskStatus{domain.TaskStatusOpen, domain.TaskStatusOpen, domain.TaskStatusCompleted} {
+		task := testutil.TestTask("user-1")
+		task.Title = fmt.Sprintf("Task %d", len(taskRepo.tasks))
+		task.Status = status
+		if err := taskRepo.Create(context.Background(), task); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	service := NewTaskService(taskRepo, newMockUserRepository(), newMockCategoryRepository(), newMockTagRepository(), newMockWorkflowRepository(), newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, logger.NewLogger("debug"))
+
+	facets, err := service.GetTaskFacets(context.Background(), repository.TaskListOptions{},
+		[]domain.TaskFacet{domain.TaskFacetStatus, domain.TaskFacetStatus})
+	if err != nil {
+		t.Fatalf("GetTaskFacets() error = %v", err)
+	}
+	if len(facets) != 1 || facets[0].Facet != domain.TaskFacetStatus {
+		t.Fatalf("facets = %+v, want the status facet once", facets)
+	}
+	counts := map[string]int64{}
+	for _, value := range facets[0].Values {
+		counts[value.Value] = value.Count
+	}
+	if counts["OPEN"] != 2 || counts["COMPLETED"] != 1 {
+		t.Errorf("status counts = %v", counts)
+	}
+
+	all, err := service.GetTaskFacets(context.Background(), repository.TaskListOptions{}, nil)
+	if err != nil || len(all) != len(domain.TaskFacets) {
+		t.Errorf("GetTaskFacets() without facets = %d facets, %v; want all of them", len(all), err)
+	}
+
+	if _, err := service.GetTaskFacets(context.Background(), repository.TaskListOptions{}, []domain.TaskFacet{"owner"}); !domain.IsInvalidInputError(err) {
+		t.Errorf("GetTaskFacets() unknown facet error = %v, want invalid input", err)
+	}
+}
+
+// setupSubtaskTest creates a release task with build and docs subtasks, and api docs under docs
+func setupSubtaskTest(t *testing.T, rules domain.SubtaskRules) (TaskService, *mockTaskRepository, map[string]*domain.Task) {
+	t.Helper()
+	ctx := requestctx.WithInternal(context.Background())
+	mockUserRepo := newMockUserRepository()
+	mockTaskRepo := newMockTaskRepository()
+	service := NewTaskService(mockTaskRepo, mockUserRepo, newMockCategoryRepository(), newMockTagRepository(), newMockWorkflowRepository(), newMockTaskSeriesRepository(), &mockTransactionManager{}, rules, logger.NewLogger("debug"))
+
+	user := testutil.TestUser()
+	mockUserRepo.Create(ctx, user)
+
+	tasks := map[string]*domain.Task{}
+	for _, spec := range []struct{ name, parent string }{
+		{"release", ""}, {"build", "release"}, {"docs", "release"}, {"api-docs", "docs"},
+	} {
+		task := testutil.TestTask(user.ID)
+		task.Title = spec.name
+		if spec.parent != "" {
+			task.ParentID = &tasks[spec.parent].ID
+		}
+		created, err := service.CreateTask(ctx, task)
+		if err != nil {
+			t.Fatalf("CreateTask(%s) error = %v", spec.name, err)
+		}
+		tasks[spec.name] = created
+	}
+	return service, mockTaskRepo, tasks
+}
+
+func TestTaskService_MoveTask(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	service, _, tasks := setupSubtaskTest(t, domain.SubtaskRules{})
+
+	if _, err := service.MoveTask(ctx, tasks["release"].ID, tasks["api-docs"].ID, tasks["release"].Version); !domain.IsBusinessRuleError(err) {
+		t.Errorf("MoveTask() under own subtask error = %v, want business rule violation", err)
+	}
+	if _, err := service.MoveTask(ctx, tasks["docs"].ID, tasks["docs"].ID, tasks["docs"].Version); !domain.IsBusinessRuleError(err) {
+		t.Errorf("MoveTask() under itself error = %v, want business rule violation", err)
+	}
+	if _, err := service.MoveTask(ctx, tasks["docs"].ID, "missing", tasks["docs"].Version); !domain.IsInvalidInputError(err) {
+		t.Errorf("MoveTask() under missing parent error = %v, want invalid input", err)
+	}
+
+	// UpdateTask refuses to move a task; only MoveTask checks a move
+	edit := *tasks["release"]
+	edit.ParentID = &tasks["api-docs"].ID
+	if _, err := service.UpdateTask(ctx, &edit); !domain.IsInvalidInputError(err) || tasks["release"].ParentID != nil {
+		t.Errorf("UpdateTask() with another parent error = %v, want invalid input with the task left at the top level", err)
+	}
+	edit = *tasks["release"]
+	edit.CreatorID = "someone-else"
+	if _, err := service.UpdateTask(ctx, &edit); !domain.IsInvalidInputError(err) {
+		t.Errorf("UpdateTask() with another creator error = %v, want invalid input", err)
+	}
+
+	moved, err := service.MoveTask(ctx, tasks["api-docs"].ID, tasks["build"].ID, tasks["api-docs"].Version)
+	if err != nil {
+		t.Fatalf("MoveTask() error = %v", err)
+	}
+	if moved.ParentID == nil || *moved.ParentID != tasks["build"].ID {
+		t.Errorf("moved parent = %v, want build", moved.ParentID)
+	}
+
+	top, err := service.MoveTask(ctx, moved.ID, "", moved.Version)
+	if err != nil || top.ParentID != nil {
+		t.Errorf("MoveTask() to top level = %v, %v", top, err)
+	}
+}
+
+func TestTaskService_GetTaskTree(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	service, _, tasks := setupSubtaskTest(t, domain.SubtaskRules{})
+
+	if _, err := service.ChangeTaskStatus(ctx, tasks["api-docs"].ID, domain.TaskStatusCompleted, tasks["api-docs"].Version); err != nil {
+		t.Fatalf("ChangeTaskStatus() error = %v", err)
+	}
+
+	root, err := service.GetTaskTree(ctx, tasks["release"].ID)
+	if err != nil {
+		t.Fatalf("GetTaskTree() error = %v", err)
+	}
+	if len(root.Children) != 2 || root.Progress != (domain.TaskProgress{Completed: 1, Total: 3}) {
+		t.Errorf("tree = %d children, progress %+v", len(root.Children), root.Progress)
+	}
+
+	parent, err := service.ListSubtasks(ctx, tasks["release"].ID)
+	if err != nil {
+		t.Fatalf("ListSubtasks() error = %v", err)
+	}
+	if len(parent.Children) != 2 || parent.Progress.Total != 3 {
+		t.Errorf("ListSubtasks() = %d subtasks, progress %+v", len(parent.Children), parent.Progress)
+	}
+	for _, child := range parent.Children {
+		if len(child.Children) != 0 {
+			t.Errorf("subtask %s listed with its own subtasks", child.Task.Title)
+		}
+	}
+
+	if _, err := service.GetTaskTree(ctx, "missing"); !domain.IsNotFoundError(err) {
+		t.Errorf("GetTaskTree() missing task error = %v, want not found", err)
+	}
+}
+
+func TestTaskService_SubtaskCompletionRules(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+
+	t.Run("parent blocked by open subtasks", func(t *testing.T) {
+		service, _, tasks := setupSubtaskTest(t, domain.SubtaskRules{BlockParentCompletion: true})
+
+		_, err := service.ChangeTaskStatus(ctx, tasks["release"].ID, domain.TaskStatusCompleted, tasks["release"].Version)
+		if !domain.IsBusinessRuleError(err) {
+			t.Fatalf("ChangeTaskStatus() error = %v, want business rule violation", err)
+		}
+
+		// Cancelled subtasks do not hold the parent back
+		for _, name := range []string{"build", "api-docs"} {
+			if _, err := service.ChangeTaskStatus(ctx, tasks[name].ID, domain.TaskStatusCompleted, tasks[name].Version); err != nil {
+				t.Fatalf("ChangeTaskStatus(%s) error = %v", name, err)
+			}
+		}
+		if _, err := service.ChangeTaskStatus(ctx, tasks["docs"].ID, domain.TaskStatusCancelled, tasks["docs"].Version); err != nil {
+			t.Fatalf("ChangeTaskStatus(docs) error = %v", err)
+		}
+		if _, err := service.ChangeTaskStatus(ctx, tasks["release"].ID, domain.TaskStatusCompleted, tasks["release"].Version); err != nil {
+			t.Errorf("ChangeTaskStatus() with closed subtasks error = %v", err)
+		}
+	})
+
+	t.Run("parents completed with their last subtask", func(t *testing.T) {
+		service, mockTaskRepo, tasks := setupSubtaskTest(t, domain.SubtaskRules{BlockParentCompletion: true, AutoCompleteParent: true})
+
+		if _, err := service.ChangeTaskStatus(ctx, tasks["build"].ID, domain.TaskStatusCompleted, tasks["build"].Version); err != nil {
+			t.Fatalf("ChangeTaskStatus(build) error = %v", err)
+		}
+		if tasks["release"].Status != domain.TaskStatusOpen {
+			t.Fatalf("release status = %s while docs is open", tasks["release"].Status)
+		}
+
+		if _, err := service.ChangeTaskStatus(ctx, tasks["api-docs"].ID, domain.TaskStatusCompleted, tasks["api-docs"].Version); err != nil {
+			t.Fatalf("ChangeTaskStatus(api-docs) error = %v", err)
+		}
+		if tasks["docs"].Status != domain.TaskStatusCompleted || tasks["release"].Status != domain.TaskStatusCompleted {
+			t.Errorf("statuses = docs %s, release %s; want both completed", tasks["docs"].Status, tasks["release"].Status)
+		}
+		// The explicit changes record UPDATED entries of their own
+		completions := 0
+		for _, entry := range mockTaskRepo.history {
+			if entry.Action == domain.TaskHistoryActionCompleted {
+				completions++
+			}
+		}
+		// Besides the four CREATED entries of the fixtures
+		if completions != 2 || len(mockTaskRepo.history) != 8 {
+			t.Errorf("history entries = %d with %d completions, want one per change and per auto-completed parent", len(mockTaskRepo.history), completions)
+		}
+	})
+
+	t.Run("bulk completion", func(t *testing.T) {
+		service, _, tasks := setupSubtaskTest(t, domain.SubtaskRules{BlockParentCompletion: true, AutoCompleteParent: true})
+		completed := domain.TaskStatusCompleted
+
+		result, err := service.BulkUpdateTasks(ctx, repository.BulkTaskSelection{Tasks: refsOf(tasks["release"])}, domain.TaskChanges{Status: &completed}, domain.BulkModeBestEffort)
+		if err != nil || result.Failed != 1 || !domain.IsBusinessRuleError(result.Results[0].Error) {
+			t.Fatalf("BulkUpdateTasks() on an open parent = %+v, %v; want a business rule failure", result, err)
+		}
+
+		result, err = service.BulkUpdateTasks(ctx, repository.BulkTaskSelection{Tasks: refsOf(tasks["build"], tasks["api-docs"])}, domain.TaskChanges{Status: &completed}, domain.BulkModeAllOrNothing)
+		if err != nil || result.Succeeded != 2 {
+			t.Fatalf("BulkUpdateTasks() = %+v, %v", result, err)
+		}
+		if tasks["release"].Status != domain.TaskStatusCompleted {
+			t.Errorf("release status = %s, want completed", tasks["release"].Status)
+		}
+	})
+}
+
+func TestTaskService_CascadeDeleteAndRestore(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	service, mockTaskRepo, tasks := setupSubtaskTest(t, domain.SubtaskRules{})
+
+	// The docs subtask is selected too but already goes with its parent
+	result, err := service.BulkDeleteTasks(ctx, repository.BulkTaskSelection{Tasks: refsOf(tasks["release"], tasks["docs"])}, domain.BulkModeAllOrNothing, true)
+	if err != nil || result.Succeeded != 2 {
+		t.Fatalf("BulkDeleteTasks() = %+v, %v", result, err)
+	}
+	if len(mockTaskRepo.tasks) != 0 {
+		t.Errorf("remaining tasks = %d, want the whole tree deleted", len(mockTaskRepo.tasks))
+	}
+	if len(mockTaskRepo.history) != 8 {
+		t.Errorf("history entries = %d, want one per created and per deleted task", len(mockTaskRepo.history))
+	}
+
+	result, err = service.BulkRestoreTasks(ctx, repository.BulkTaskSelection{Tasks: refsOf(tasks["release"])}, domain.BulkModeAllOrNothing, true)
+	if err != nil || result.Succeeded != 1 {
+		t.Fatalf("BulkRestoreTasks() = %+v, %v", result, err)
+	}
+	if len(mockTaskRepo.tasks) != 4 {
+		t.Errorf("tasks after restore = %d, want the whole tree", len(mockTaskRepo.tasks))
+	}
+}
+
+func TestTaskService_DeleteTask_Cascade(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	service, mockTaskRepo, tasks := setupSubtaskTest(t, domain.SubtaskRules{})
+	created := len(mockTaskRepo.history)
+
+	if err := service.DeleteTask(ctx, tasks["build"].ID, tasks["build"].Version, false); err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+	if err := service.DeleteTask(ctx, tasks["docs"].ID, tasks["docs"].Version, true); err != nil {
+		t.Fatalf("DeleteTask() with cascade error = %v", err)
+	}
+	if _, deleted := mockTaskRepo.deleted[tasks["api-docs"].ID]; !deleted || len(mockTaskRepo.tasks) != 1 {
+		t.Errorf("remaining tasks = %d, want only the release left with the subtask of docs deleted", len(mockTaskRepo.tasks))
+	}
+
+	deletions := map[string]bool{}
+	for _, entry := range mockTaskRepo.history[created:] {
+		if entry.Action == domain.TaskHistoryActionDeleted {
+			deletions[entry.TaskID] = true
+		}
+	}
+	if len(deletions) != 3 || len(mockTaskRepo.history) != created+3 {
+		t.Errorf("deletions recorded for %v, want one entry for each of the three deleted tasks", deletions)
+	}
+}
+
+func TestTaskService_Dependencies(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	service, mockTaskRepo, tasks := setupSubtaskTest(t, domain.SubtaskRules{})
+	build, docs := tasks["build"], tasks["docs"]
+
+	if _, err := service.AddTaskDependency(ctx, docs.ID, build.ID); err != nil {
+		t.Fatalf("AddTaskDependency() error = %v", err)
+	}
+	if _, err := service.AddTaskDependency(ctx, docs.ID, build.ID); !domain.IsConflictError(err) {
+		t.Errorf("AddTaskDependency() twice error = %v, want conflict", err)
+	}
+	if _, err := service.AddTaskDependency(ctx, docs.ID, docs.ID); !domain.IsBusinessRuleError(err) {
+		t.Errorf("AddTaskDependency() on itself error = %v, want business rule violation", err)
+	}
+	if _, err := service.AddTaskDependency(ctx, docs.ID, "missing"); !domain.IsInvalidInputError(err) {
+		t.Errorf("AddTaskDependency() on a missing blocker error = %v, want invalid input", err)
+	}
+	if _, err := service.AddTaskDependency(ctx, tasks["api-docs"].ID, docs.ID); err != nil {
+		t.Fatalf("AddTaskDependency() error = %v", err)
+	}
+	// build -> docs -> api-docs; api-docs blocking build would close the loop
+	if _, err := service.AddTaskDependency(ctx, build.ID, tasks["api-docs"].ID); !domain.IsBusinessRuleError(err) {
+		t.Errorf("AddTaskDependency() closing a cycle error = %v, want business rule violation", err)
+	}
+
+	blockers, err := service.ListTaskBlockers(ctx, docs.ID)
+	if err != nil || len(blockers) != 1 || blockers[0].ID != build.ID {
+		t.Errorf("ListTaskBlockers() = %v, %v; want build", blockers, err)
+	}
+	dependents, err := service.ListTaskDependents(ctx, build.ID)
+	if err != nil || len(dependents) != 1 || dependents[0].ID != docs.ID {
+		t.Errorf("ListTaskDependents() = %v, %v; want docs", dependents, err)
+	}
+
+	if _, err := service.ChangeTaskStatus(ctx, docs.ID, domain.TaskStatusInProgress, docs.Version); !domain.IsBusinessRuleError(err) {
+		t.Errorf("ChangeTaskStatus() with an open blocker error = %v, want business rule violation", err)
+	}
+	for _, status := range []domain.TaskStatus{domain.TaskStatusInProgress, domain.TaskStatusCompleted} {
+		update := *docs
+		update.Status = status
+		if _, err := service.UpdateTask(ctx, &update); !domain.IsBusinessRuleError(err) || !strings.Contains(err.Error(), "blocked") {
+			t.Errorf("UpdateTask(%s) with an open blocker error = %v, want business rule violation", status, err)
+		}
+	}
+	inProgress := domain.TaskStatusInProgress
+	result, err := service.BulkUpdateTasks(ctx, repository.BulkTaskSelection{Tasks: refsOf(docs)}, domain.TaskChanges{Status: &inProgress}, domain.BulkModeBestEffort)
+	if err != nil || result.Failed != 1 || !domain.IsBusinessRuleError(result.Results[0].Error) {
+		t.Errorf("BulkUpdateTasks() with an open blocker = %+v, %v; want a business rule failure", result, err)
+	}
+
+	if _, err := service.ChangeTaskStatus(ctx, build.ID, domain.TaskStatusCompleted, build.Version); err != nil {
+		t.Fatalf("ChangeTaskStatus(build) error = %v", err)
+	}
+	if _, err := service.ChangeTaskStatus(ctx, docs.ID, domain.TaskStatusInProgress, docs.Version); err != nil {
+		t.Errorf("ChangeTaskStatus() with a completed blocker error = %v", err)
+	}
+
+	if err := service.RemoveTaskDependency(ctx, docs.ID, build.ID); err != nil {
+		t.Fatalf("RemoveTaskDependency() error = %v", err)
+	}
+	if err := service.RemoveTaskDependency(ctx, docs.ID, build.ID); !domain.IsNotFoundError(err) {
+		t.Errorf("RemoveTaskDependency() twice error = %v, want not found", err)
+	}
+	if len(mockTaskRepo.blockers) != 1 {
+		t.Errorf("dependencies = %d, want only api-docs blocked by docs", len(mockTaskRepo.blockers))
+	}
+}
+
+func TestTaskService_GetCriticalPath(t *testing.T) {
+	ctx := requestctx.WithInternal(context.Background())
+	service, _, tasks := setupSubtaskTest(t, domain.SubtaskRules{})
+
+	for _, link := range [][2]string{{"docs", "build"}, {"api-docs", "docs"}} {
+		if _, err := service.AddTaskDependency(ctx, tasks[link[0]].ID, tasks[link[1]].ID); err != nil {
+			t.Fatalf("AddTaskDependency(%s, %s) error = %v", link[0], link[1], err)
+		}
+	}
+
+	path, err := service.GetCriticalPath(ctx, []string{tasks["api-docs"].ID, tasks["build"].ID, tasks["docs"].ID, tasks["build"].ID})
+	if err != nil {
+		t.Fatalf("GetCriticalPath() error = %v", err)
+	}
+	if len(path.Tasks) != 3 || path.Tasks[0].ID != tasks["build"].ID || path.Tasks[2].ID != tasks["api-docs"].ID {
+		t.Errorf("GetCriticalPath() = %+v, want build, docs, api-docs", path.Tasks)
+	}
+
+	if _, err := service.GetCriticalPath(ctx, nil); !domain.IsInvalidInputError(err) {
+		t.Errorf("GetCriticalPath() without tasks error = %v, want invalid input", err)
+	}
+	if _, err := service.GetCriticalPath(ctx, []string{"missing"}); !domain.IsInvalidInputError(err) {
+		t.Errorf("GetCriticalPath() with a missing task error = %v, want invalid input", err)
+	}
+}
+
+func TestTaskService_Participants(t *testing.T) {
+	mockUserRepo := newMockUserRepository()
+	mockTaskRepo := newMockTaskRepository()
+	service := NewTaskService(mockTaskRepo, mockUserRepo, newMockCategoryRepository(), newMockTagRepository(), newMockWorkflowRepository(), newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, logger.NewLogger("debug"))
+
+	users := map[string]*domain.User{}
+	for _, name := range []string{"owner", "dev", "pair", "reviewer"} {
+		user := &domain.User{Name: name, Email: name + "@example.com", Role: domain.UserRoleUser}
+		mockUserRepo.Create(context.Background(), user)
+		users[name] = user
+	}
+	ctx := asUser(users["owner"])
+
+	task := testutil.TestTask(users["dev"].ID)
+	task.Participants = []domain.TaskParticipant{{UserID: users["reviewer"].ID, Role: domain.TaskRoleReviewer}}
+	task, err := service.CreateTask(ctx, task)
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	if !task.HasRole(users["owner"].ID, domain.TaskRoleOwner) || !task.HasRole(users["reviewer"].ID, domain.TaskRoleReviewer) {
+		t.Errorf("created participants = %+v, want the caller as owner and the reviewer", task.Participants)
+	}
+
+	t.Run("add", func(t *testing.T) {
+		pair := []domain.TaskParticipant{{UserID: users["pair"].ID, Role: domain.TaskRoleAssignee}}
+		if _, err := service.AddTaskParticipants(ctx, task.ID, pair, task.Version+1); !domain.IsVersionConflictError(err) {
+			t.Errorf("AddTaskParticipants() with a stale version error = %v, want version conflict", err)
+		}
+		if _, err := service.AddTaskParticipants(ctx, task.ID, []domain.TaskParticipant{{UserID: "missing", Role: domain.TaskRoleCollaborator}}, task.Version); !domain.IsInvalidInputError(err) {
+			t.Errorf("AddTaskParticipants() with an unknown user error = %v, want invalid input", err)
+		}
+		if _, err := service.AddTaskParticipants(ctx, task.ID, []domain.TaskParticipant{{UserID: users["pair"].ID}}, task.Version); !domain.IsInvalidInputError(err) {
+			t.Errorf("AddTaskParticipants() without a role error = %v, want invalid input", err)
+		}
+
+		updated, err := service.AddTaskParticipants(ctx, task.ID, pair, task.Version)
+		if err != nil {
+			t.Fatalf("AddTaskParticipants() error = %v", err)
+		}
+		if updated.AssigneeID != users["dev"].ID || !updated.HasRole(users["pair"].ID, domain.TaskRoleAssignee) {
+			t.Errorf("participants = %+v, want dev as primary and pair as second assignee", updated.Participants)
+		}
+		task = updated
+
+		entry := mockTaskRepo.history[len(mockTaskRepo.history)-1]
+		items := domain.HistoryNotificationItems(entry, task.Title)
+		if len(items) != 1 || len(items[0].Involved) != 1 || items[0].Involved[0] != users["pair"].ID {
+			t.Errorf("notification items = %+v, want the new assignee involved", items)
+		}
+	})
+
+	t.Run("list by role", func(t *testing.T) {
+		for _, tc := range []struct {
+			roles []domain.TaskParticipantRole
+			want  int
+		}{
+			{nil, 1},
+			{[]domain.TaskParticipantRole{domain.TaskRoleAssignee}, 1},
+			{[]domain.TaskParticipantRole{domain.TaskRoleReviewer, domain.TaskRoleOwner}, 0},
+		} {
+			opts := repository.TaskListOptions{AssigneeID: users["pair"].ID, AssigneeRoles: tc.roles}
+			if _, total, err := service.ListTasks(ctx, opts); err != nil || total != int64(tc.want) {
+				t.Errorf("ListTasks(%v) = %d, %v, want %d", tc.roles, total, err, tc.want)
+			}
+		}
+	})
+
+	t.Run("remove primary assignee", func(t *testing.T) {
+		dev := []domain.TaskParticipant{{UserID: users["dev"].ID, Role: domain.TaskRoleAssignee}}
+		updated, err := service.RemoveTaskParticipants(ctx, task.ID, dev, task.Version)
+		if err != nil {
+			t.Fatalf("RemoveTaskParticipants() error = %v", err)
+		}
+		if updated.AssigneeID != users["pair"].ID || updated.HasRole(users["dev"].ID, domain.TaskRoleAssignee) {
+			t.Errorf("assignee = %s, want pair to take over from dev", updated.AssigneeID)
+		}
+		task = updated
+
+		details, _ := mockTaskRepo.history[len(mockTaskRepo.history)-1].GetDetails()
+		if len(details.Changes) != 2 || details.NewValues["assignee_id"] != users["pair"].ID {
+			t.Errorf("history details = %+v, want participants and assignee changes", details)
+		}
+	})
+
+	t.Run("last assignee stays", func(t *testing.T) {
+		pair := []domain.TaskParticipant{{UserID: users["pair"].ID, Role: domain.TaskRoleAssignee}}
+		if _, err := service.RemoveTaskParticipants(ctx, task.ID, pair, task.Version); !domain.IsBusinessRuleError(err) {
+			t.Errorf("RemoveTaskParticipants() of the last assignee error = %v, want business rule violation", err)
+		}
+	})
+}
+
+func TestTaskService_CreatorAuthorization(t *testing.T) {
+	mockUserRepo := newMockUserRepository()
+	mockTaskRepo := newMockTaskRepository()
+	service := NewTaskService(mockTaskRepo, mockUserRepo, newMockCategoryRepository(), newMockTagRepository(), newMockWorkflowRepository(), newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, logger.NewLogger("debug"))
+
+	users := map[string]*domain.User{}
+	for _, name := range []string{"reporter", "dev", "other"} {
+		user := &domain.User{Name: name, Email: name + "@example.com", Role: domain.UserRoleUser}
+		mockUserRepo.Create(context.Background(), user)
+		users[name] = user
+	}
+	admin := requestctx.WithUser(context.Background(), "admin-1", string(domain.UserRoleAdmin))
+
+	task, err := service.CreateTask(asUser(users["reporter"]), testutil.TestTask(users["dev"].ID))
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	if task.CreatorID != users["reporter"].ID {
+		t.Fatalf("CreatorID = %q, want the caller %q", task.CreatorID, users["reporter"].ID)
+	}
+	if history := mockTaskRepo.history; len(history) != 1 || history[0].Action != domain.TaskHistoryActionCreated || history[0].ActorID != users["reporter"].ID {
+		t.Errorf("history = %+v, want a CREATED entry by the creator", history)
+	}
+
+	if _, total, err := service.ListTasks(admin, repository.TaskListOptions{CreatorID: users["reporter"].ID}); err != nil || total != 1 {
+		t.Errorf("ListTasks(creator) = %d, %v, want 1", total, err)
+	}
+	if _, total, err := service.ListTasks(admin, repository.TaskListOptions{CreatorID: users["dev"].ID}); err != nil || total != 0 {
+		t.Errorf("ListTasks(assignee as creator) = %d, %v, want 0", total, err)
+	}
+
+	if _, err := service.ChangeTaskPriority(asUser(users["other"]), task.ID, domain.TaskPriorityHigh, task.Version); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("ChangeTaskPriority() by an unrelated user error = %v, want permission denied", err)
+	}
+	if _, err := service.AddTaskTags(asUser(users["other"]), task.ID, []string{"tag-1"}, task.Version); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("AddTaskTags() by an unrelated user error = %v, want permission denied", err)
+	}
+	// Leaving out the user does not pass for the service's own work
+	if _, err := service.ChangeTaskPriority(context.Background(), task.ID, domain.TaskPriorityHigh, task.Version); !isUnauthorizedError(err) {
+		t.Errorf("ChangeTaskPriority() without a user error = %v, want unauthorized", err)
+	}
+
+	internal := requestctx.WithInternal(context.Background())
+	for _, ctx := range []context.Context{asUser(users["reporter"]), asUser(users["dev"]), admin, internal} {
+		updated, err := service.ChangeTaskPriority(ctx, task.ID, domain.TaskPriorityHigh, task.Version)
+		if err != nil {
+			t.Fatalf("ChangeTaskPriority() error = %v", err)
+		}
+		if updated.CreatorID != users["reporter"].ID {
+			t.Errorf("CreatorID after update = %q, want it unchanged", updated.CreatorID)
+		}
+		task = updated
+	}
+
+	// Deletes, restores, dependencies and series are guarded the same way
+	reporter, stranger := asUser(users["reporter"]), asUser(users["other"])
+	blocker, err := service.CreateTask(stranger, testutil.TestTask(users["other"].ID))
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	if _, err := service.AddTaskDependency(stranger, task.ID, blocker.ID); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("AddTaskDependency() by an unrelated user error = %v, want permission denied", err)
+	}
+	if _, _, err := service.SetTaskRecurrence(stranger, task.ID, mustParseRule(t, "FREQ=WEEKLY"), task.Version); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("SetTaskRecurrence() by an unrelated user error = %v, want permission denied", err)
+	}
+	if err := service.DeleteTask(stranger, task.ID, task.Version, false); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("DeleteTask() by an unrelated user error = %v, want permission denied", err)
+	}
+	selection := repository.BulkTaskSelection{Tasks: []domain.TaskRef{{ID: task.ID, Version: task.Version}}}
+	result, err := service.BulkDeleteTasks(stranger, selection, domain.BulkModeBestEffort, false)
+	if err != nil || result.Failed != 1 || !domain.IsPermissionDeniedError(result.Results[0].Error) {
+		t.Errorf("BulkDeleteTasks() by an unrelated user = %+v, %v, want the task denied", result, err)
+	}
+
+	_, series, err := service.SetTaskRecurrence(reporter, task.ID, mustParseRule(t, "FREQ=WEEKLY"), task.Version)
+	if err != nil {
+		t.Fatalf("SetTaskRecurrence() error = %v", err)
+	}
+	title := "Renamed"
+	if _, _, err := service.UpdateTaskSeries(stranger, series.ID, domain.TaskSeriesChanges{Title: &title}, series.Version); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("UpdateTaskSeries() by an unrelated user error = %v, want permission denied", err)
+	}
+	if _, err := service.StopTaskSeries(stranger, series.ID, series.Version); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("StopTaskSeries() by an unrelated user error = %v, want permission denied", err)
+	}
+
+	task, _ = service.GetTaskByID(reporter, task.ID)
+	if err := service.DeleteTask(reporter, task.ID, task.Version, false); err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+	deleted := mockTaskRepo.deleted[task.ID]
+	if _, err := service.RestoreTask(stranger, task.ID, deleted.Version); !domain.IsPermissionDeniedError(err) {
+		t.Errorf("RestoreTask() by an unrelated user error = %v, want permission denied", err)
+	}
+	selection = repository.BulkTaskSelection{Tasks: []domain.TaskRef{{ID: task.ID, Version: deleted.Version}}}
+	result, err = service.BulkRestoreTasks(stranger, selection, domain.BulkModeBestEffort, false)
+	if err != nil || result.Failed != 1 || !domain.IsPermissionDeniedError(result.Results[0].Error) {
+		t.Errorf("BulkRestoreTasks() by an unrelated user = %+v, %v, want the task denied", result, err)
+	}
+	if _, err := service.RestoreTask(reporter, task.ID, deleted.Version); err != nil {
+		t.Errorf("RestoreTask() by the creator error = %v", err)
+	}
+}
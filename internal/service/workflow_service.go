@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+)
+
+type workflowService struct {
+	workflowRepo repository.WorkflowRepository
+	categoryRepo repository.CategoryRepository
+	txManager    repository.TransactionManager
+	logger       logger.Logger
+}
+
+// NewWorkflowService creates a new workflow service
+func NewWorkflowService(
+	workflowRepo repository.WorkflowRepository,
+	categoryRepo repository.CategoryRepository,
+	txManager repository.TransactionManager,
+	log logger.Logger,
+) WorkflowService {
+	return &workflowService{
+		workflowRepo: workflowRepo,
+		categoryRepo: categoryRepo,
+		txManager:    txManager,
+		logger:       log,
+	}
+}
+
+func (s *workflowService) CreateWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error) {
+	s.logger.Info(ctx, "Creating workflow", "name", workflow.Name, "is_default", workflow.IsDefault)
+
+	if err := requireWorkflowAdmin(ctx, "create workflows"); err != nil {
+		return nil, err
+	}
+	workflow.CreatorID = actorID(ctx)
+	if err := workflow.IsValid(); err != nil {
+		return nil, err
+	}
+
+	// Making a workflow the default unmarks the previous one in the same transaction
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		return s.workflowRepo.Create(txCtx, workflow)
+	})
+	if err != nil {
+		if domain.IsConflictError(err) {
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to create workflow", "error", err, "name", workflow.Name)
+		return nil, fmt.Errorf("failed to create workflow: %w", err)
+	}
+
+	s.logger.Info(ctx, "Workflow created successfully", "workflow_id", workflow.ID)
+	return workflow, nil
+}
+
+func (s *workflowService) GetWorkflow(ctx context.Context, id string) (*domain.Workflow, error) {
+	s.logger.Debug(ctx, "Getting workflow", "workflow_id", id)
+
+	if id == "" {
+		return nil, domain.ErrInvalidInput("workflow ID is required")
+	}
+
+	workflow, err := s.workflowRepo.GetByID(ctx, id)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to get workflow", "error", err, "workflow_id", id)
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	return workflow, nil
+}
+
+func (s *workflowService) ListWorkflows(ctx context.Context, opts repository.ListOptions) ([]*domain.Workflow, int64, *domain.Workflow, error) {
+	s.logger.Debug(ctx, "Listing workflows", "page", opts.Page, "page_size", opts.PageSize)
+
+	workflows, total, err := s.workflowRepo.List(ctx, opts)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list workflows", "error", err)
+		return nil, 0, nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	defaultWorkflow, err := defaultWorkflow(ctx, s.workflowRepo)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return workflows, total, defaultWorkflow, nil
+}
+
+func (s *workflowService) UpdateWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error) {
+	s.logger.Info(ctx, "Updating workflow", "workflow_id", workflow.ID, "version", workflow.Version)
+
+	if err := requireWorkflowAdmin(ctx, "change workflows"); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.GetWorkflow(ctx, workflow.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Version != workflow.Version {
+		return nil, domain.ErrVersionConflict("workflow", workflow.Version, existing.Version)
+	}
+	// Tasks need a default workflow, so one is only replaced by making another the default
+	if existing.IsDefault && !workflow.IsDefault {
+		return nil, domain.ErrBusinessRule("make another workflow the default instead")
+	}
+
+	workflow.CreatorID = existing.CreatorID
+	workflow.CreatedAt = existing.CreatedAt
+	if err := workflow.IsValid(); err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		return s.workflowRepo.Update(txCtx, workflow)
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) || domain.IsConflictError(err) {
+			s.logger.Warn(ctx, "Workflow update rejected", "workflow_id", workflow.ID, "error", err)
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to update workflow", "error", err, "workflow_id", workflow.ID)
+		return nil, fmt.Errorf("failed to update workflow: %w", err)
+	}
+
+	s.logger.Info(ctx, "Workflow updated successfully", "workflow_id", workflow.ID, "new_version", workflow.Version)
+	return workflow, nil
+}
+
+func (s *workflowService) DeleteWorkflow(ctx context.Context, id string, version int64) error {
+	s.logger.Info(ctx, "Deleting workflow", "workflow_id", id, "version", version)
+
+	if err := requireWorkflowAdmin(ctx, "delete workflows"); err != nil {
+		return err
+	}
+
+	existing, err := s.GetWorkflow(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.Version != version {
+		return domain.ErrVersionConflict("workflow", version, existing.Version)
+	}
+	if existing.IsDefault {
+		return domain.ErrBusinessRule("the default workflow cannot be deleted")
+	}
+
+	categories, err := s.workflowRepo.CountCategories(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check workflow usage: %w", err)
+	}
+	if categories > 0 {
+		return domain.ErrBusinessRule(fmt.Sprintf("workflow is used by %d categories", categories))
+	}
+
+	if err := s.workflowRepo.SoftDelete(ctx, id, version); err != nil {
+		if domain.IsVersionConflictError(err) {
+			return err
+		}
+		s.logger.Error(ctx, "Failed to delete workflow", "error", err, "workflow_id", id)
+		return fmt.Errorf("failed to delete workflow: %w", err)
+	}
+
+	s.logger.Info(ctx, "Workflow deleted successfully", "workflow_id", id)
+	return nil
+}
+
+func (s *workflowService) SetCategoryWorkflow(ctx context.Context, categoryID, workflowID string, version int64) (*domain.Category, error) {
+	s.logger.Info(ctx, "Setting category workflow", "category_id", categoryID, "workflow_id", workflowID, "version", version)
+
+	if categoryID == "" {
+		return nil, domain.ErrInvalidInput("category ID is required")
+	}
+
+	category, err := s.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	userID, role := callerIdentity(ctx)
+	if !category.VisibleTo(userID, role) {
+		return nil, domain.ErrNotFound("category")
+	}
+	// The workflow decides how everyone's tasks in the category move, so only its owner picks it
+	if role != domain.UserRoleAdmin && category.CreatorID != userID {
+		return nil, domain.ErrPermissionDenied("only the category creator or an administrator can change its workflow")
+	}
+	if category.Version != version {
+		return nil, domain.ErrVersionConflict("category", version, category.Version)
+	}
+
+	oldWorkflowID := category.WorkflowID
+	category.WorkflowID = nil
+	if workflowID != "" {
+		if _, err := s.GetWorkflow(ctx, workflowID); err != nil {
+			if domain.IsNotFoundError(err) {
+				return nil, domain.ErrInvalidInput("workflow does not exist")
+			}
+			return nil, err
+		}
+		category.WorkflowID = &workflowID
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context, _ *sql.Tx) error {
+		if err := s.categoryRepo.Update(txCtx, category); err != nil {
+			return err
+		}
+		return recordCategoryHistory(txCtx, s.categoryRepo, categoryID, domain.CategoryHistoryActionWorkflowChanged, &domain.TaskHistoryDetails{
+			OldValues: map[string]interface{}{"workflow_id": oldWorkflowID},
+			NewValues: map[string]interface{}{"workflow_id": category.WorkflowID},
+			Changes:   []string{"workflow_id"},
+		})
+	})
+	if err != nil {
+		if domain.IsVersionConflictError(err) {
+			s.logger.Warn(ctx, "Category workflow version conflict", "category_id", categoryID, "error", err)
+			return nil, err
+		}
+		s.logger.Error(ctx, "Failed to set category workflow", "error", err, "category_id", categoryID)
+		return nil, fmt.Errorf("failed to set category workflow: %w", err)
+	}
+
+	s.logger.Info(ctx, "Category workflow set successfully", "category_id", categoryID, "new_version", category.Version)
+	return category, nil
+}
+
+// defaultWorkflow returns the workflow marked as default, or the built-in one if none is
+func defaultWorkflow(ctx context.Context, workflowRepo repository.WorkflowRepository) (*domain.Workflow, error) {
+	workflow, err := workflowRepo.GetDefault(ctx)
+	if err != nil {
+		if domain.IsNotFoundError(err) {
+			return domain.DefaultWorkflow(), nil
+		}
+		return nil, fmt.Errorf("failed to get default workflow: %w", err)
+	}
+	return workflow, nil
+}
+
+// requireWorkflowAdmin restricts workflow changes to administrators, since workflows apply to everyone
+func requireWorkflowAdmin(ctx context.Context, action string) error {
+	if _, role := callerIdentity(ctx); role != domain.UserRoleAdmin {
+		return domain.ErrPermissionDenied("only administrators can " + action)
+	}
+	return nil
+}
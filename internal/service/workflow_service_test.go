@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+	"github.com/todo-app/services/admin-service/internal/repository"
+	"github.com/todo-app/services/admin-service/internal/testutil"
+	"github.com/todo-app/services/admin-service/pkg/logger"
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// mockWorkflowRepository keeps workflows in memory; categories, when set, resolve category workflows
+type mockWorkflowRepository struct {
+	workflows  map[string]*domain.Workflow
+	categories *mockCategoryRepository
+	nextID     int
+}
+
+func newMockWorkflowRepository() *mockWorkflowRepository {
+	return &mockWorkflowRepository{workflows: make(map[string]*domain.Workflow)}
+}
+
+func (m *mockWorkflowRepository) Create(ctx context.Context, workflow *domain.Workflow) error {
+	if err := workflow.IsValid(); err != nil {
+		return err
+	}
+	if workflow.IsDefault {
+		m.clearDefault()
+	}
+	m.nextID++
+	workflow.ID = fmt.Sprintf("workflow-%d", m.nextID)
+	workflow.Version = 1
+	stored := *workflow
+	m.workflows[workflow.ID] = &stored
+	return nil
+}
+
+func (m *mockWorkflowRepository) GetByID(ctx context.Context, id string) (*domain.Workflow, error) {
+	stored, ok := m.workflows[id]
+	if !ok {
+		return nil, domain.ErrNotFound("workflow")
+	}
+	workflow := *stored
+	return &workflow, nil
+}
+
+func (m *mockWorkflowRepository) GetDefault(ctx context.Context) (*domain.Workflow, error) {
+	for _, workflow := range m.workflows {
+		if workflow.IsDefault {
+			return m.GetByID(ctx, workflow.ID)
+		}
+	}
+	return nil, domain.ErrNotFound("workflow")
+}
+
+func (m *mockWorkflowRepository) GetForCategories(ctx context.Context, categoryIDs []string) ([]*domain.Workflow, error) {
+	if m.categories == nil {
+		return nil, nil
+	}
+	seen := make(map[string]bool)
+	var workflows []*domain.Workflow
+	for _, id := range categoryIDs {
+		category := m.categories.categories[id]
+		for category != nil && category.WorkflowID == nil && category.ParentID != nil {
+			category = m.categories.categories[*category.ParentID]
+		}
+		if category == nil || category.WorkflowID == nil || seen[*category.WorkflowID] {
+			continue
+		}
+		seen[*category.WorkflowID] = true
+		workflow, err := m.GetByID(ctx, *category.WorkflowID)
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, workflow)
+	}
+	return workflows, nil
+}
+
+func (m *mockWorkflowRepository) List(ctx context.Context, opts repository.ListOptions) ([]*domain.Workflow, int64, error) {
+	var workflows []*domain.Workflow
+	for _, workflow := range m.workflows {
+		workflows = append(workflows, workflow)
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].Name < workflows[j].Name })
+	return workflows, int64(len(workflows)), nil
+}
+
+func (m *mockWorkflowRepository) Update(ctx context.Context, workflow *domain.Workflow) error {
+	existing, ok := m.workflows[workflow.ID]
+	if !ok {
+		return domain.ErrNotFound("workflow")
+	}
+	if existing.Version != workflow.Version {
+		return domain.ErrVersionConflict("workflow", workflow.Version, existing.Version)
+	}
+	if workflow.IsDefault {
+		m.clearDefault()
+	}
+	workflow.Version++
+	stored := *workflow
+	m.workflows[workflow.ID] = &stored
+	return nil
+}
+
+func (m *mockWorkflowRepository) SoftDelete(ctx context.Context, id string, version int64) error {
+	existing, ok := m.workflows[id]
+	if !ok || existing.Version != version {
+		return domain.ErrVersionConflict("workflow", version, version+1)
+	}
+	delete(m.workflows, id)
+	return nil
+}
+
+func (m *mockWorkflowRepository) CountCategories(ctx context.Context, id string) (int64, error) {
+	if m.categories == nil {
+		return 0, nil
+	}
+	var count int64
+	for _, category := range m.categories.categories {
+		if category.WorkflowID != nil && *category.WorkflowID == id {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockWorkflowRepository) clearDefault() {
+	for _, workflow := range m.workflows {
+		workflow.IsDefault = false
+	}
+}
+
+// reviewWorkflow adds a review step and forbids reopening completed tasks
+func reviewWorkflow() *domain.Workflow {
+	return &domain.Workflow{
+		Name: "review",
+		States: []domain.WorkflowState{
+			{Status: domain.TaskStatusOpen},
+			{Status: domain.TaskStatusInProgress},
+			{Status: domain.TaskStatusInReview},
+			{Status: domain.TaskStatusCompleted},
+		},
+		Transitions: []domain.WorkflowTransition{
+			{From: domain.TaskStatusOpen, To: domain.TaskStatusInProgress, Guards: []domain.WorkflowGuard{domain.WorkflowGuardHasAssignee}},
+			{From: domain.TaskStatusInProgress, To: domain.TaskStatusInReview},
+			{From: domain.TaskStatusInReview, To: domain.TaskStatusInProgress},
+			{From: domain.TaskStatusInReview, To: domain.TaskStatusCompleted},
+		},
+	}
+}
+
+func TestTaskService_ChangeTaskStatus_Workflow(t *testing.T) {
+	admin := requestctx.WithUser(context.Background(), "admin-1", "admin")
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	categoryRepo := newMockCategoryRepository()
+	workflowRepo := newMockWorkflowRepository()
+	workflowRepo.categories = categoryRepo
+	taskRepo := newMockTaskRepository()
+	userRepo := newMockUserRepository()
+	service := NewTaskService(taskRepo, userRepo, categoryRepo, newMockTagRepository(), workflowRepo, newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, logger.NewLogger("error"))
+	workflows := NewWorkflowService(workflowRepo, categoryRepo, &mockTransactionManager{}, logger.NewLogger("error"))
+
+	review, err := workflows.CreateWorkflow(admin, reviewWorkflow())
+	if err != nil {
+		t.Fatalf("CreateWorkflow() error = %v", err)
+	}
+	work := &domain.Category{Name: "work", IsPublic: true, CreatorID: "user-1"}
+	categoryRepo.Create(ctx, work)
+	projects := &domain.Category{Name: "projects", ParentID: &work.ID, IsPublic: true, CreatorID: "user-1"}
+	categoryRepo.Create(ctx, projects)
+	if _, err := workflows.SetCategoryWorkflow(ctx, work.ID, review.ID, work.Version); err != nil {
+		t.Fatalf("SetCategoryWorkflow() error = %v", err)
+	}
+
+	// The subcategory has no workflow of its own and uses its parent's
+	task := &domain.Task{Title: "ship it", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, Categories: []domain.Category{*projects}, CreatorID: "user-1"}
+	taskRepo.Create(ctx, task)
+
+	if _, err := service.ChangeTaskStatus(ctx, task.ID, domain.TaskStatusInProgress, task.Version); !domain.IsBusinessRuleError(err) {
+		t.Fatalf("starting an unassigned task: error = %v, want a business rule error", err)
+	}
+	assignee := testutil.TestUser()
+	userRepo.Create(ctx, assignee)
+	task.AssigneeID = assignee.ID
+
+	steps := []struct {
+		status  domain.TaskStatus
+		wantErr bool
+	}{
+		{domain.TaskStatusInProgress, false},
+		{domain.TaskStatusCompleted, true}, // review comes first
+		{domain.TaskStatusInReview, false},
+		{domain.TaskStatusCompleted, false},
+		{domain.TaskStatusOpen, true}, // no reopening
+	}
+	for _, step := range steps {
+		_, err := service.ChangeTaskStatus(ctx, task.ID, step.status, task.Version)
+		if (err != nil) != step.wantErr {
+			t.Fatalf("ChangeTaskStatus(%s) error = %v, wantErr %v", step.status, err, step.wantErr)
+		}
+		if err != nil && !domain.IsBusinessRuleError(err) {
+			t.Fatalf("ChangeTaskStatus(%s) error = %v, want a business rule error", step.status, err)
+		}
+	}
+
+	// Tasks outside the category keep the default workflow
+	other := &domain.Task{Title: "other", Status: domain.TaskStatusCompleted, Priority: domain.TaskPriorityMedium, AssigneeID: assignee.ID, CreatorID: "user-1"}
+	taskRepo.Create(ctx, other)
+	if _, err := service.ChangeTaskStatus(ctx, other.ID, domain.TaskStatusOpen, other.Version); err != nil {
+		t.Errorf("reopening a task with the default workflow: error = %v", err)
+	}
+}
+
+func TestTaskService_UpdateTask_Workflow(t *testing.T) {
+	admin := requestctx.WithUser(context.Background(), "admin-1", "admin")
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	workflowRepo := newMockWorkflowRepository()
+	taskRepo := newMockTaskRepository()
+	userRepo := newMockUserRepository()
+	service := NewTaskService(taskRepo, userRepo, newMockCategoryRepository(), newMockTagRepository(), workflowRepo, newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, logger.NewLogger("error"))
+	workflows := NewWorkflowService(workflowRepo, newMockCategoryRepository(), &mockTransactionManager{}, logger.NewLogger("error"))
+
+	strict := reviewWorkflow()
+	strict.IsDefault = true
+	strict.States[2].RequiredFields = []string{domain.WorkflowFieldDescription}
+	if _, err := workflows.CreateWorkflow(admin, strict); err != nil {
+		t.Fatalf("CreateWorkflow() error = %v", err)
+	}
+
+	assignee := testutil.TestUser()
+	userRepo.Create(ctx, assignee)
+	task := &domain.Task{Title: "strict", Description: "ready", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, AssigneeID: assignee.ID, CreatorID: "user-1"}
+	taskRepo.Create(ctx, task)
+
+	// UpdateTask follows the same workflow as ChangeTaskStatus
+	steps := []struct {
+		name    string
+		change  func(task *domain.Task)
+		wantErr bool
+	}{
+		{"skipping review", func(task *domain.Task) { task.Status = domain.TaskStatusCompleted }, true},
+		{"starting", func(task *domain.Task) { task.Status = domain.TaskStatusInProgress }, false},
+		{"submitting for review", func(task *domain.Task) { task.Status = domain.TaskStatusInReview }, false},
+		{"clearing a required field", func(task *domain.Task) { task.Description = "" }, true},
+		{"other edits", func(task *domain.Task) { task.Priority = domain.TaskPriorityHigh }, false},
+	}
+	for _, step := range steps {
+		update := *task
+		step.change(&update)
+		_, err := service.UpdateTask(ctx, &update)
+		if (err != nil) != step.wantErr {
+			t.Fatalf("UpdateTask(%s) error = %v, wantErr %v", step.name, err, step.wantErr)
+		}
+		if err != nil && !domain.IsBusinessRuleError(err) {
+			t.Fatalf("UpdateTask(%s) error = %v, want a business rule error", step.name, err)
+		}
+	}
+	if task.Status != domain.TaskStatusInReview || task.Description != "ready" {
+		t.Errorf("task = %s with description %q, want it in review with its description", task.Status, task.Description)
+	}
+}
+
+func TestTaskService_ChangeTaskStatus_DefaultWorkflow(t *testing.T) {
+	admin := requestctx.WithUser(context.Background(), "admin-1", "admin")
+	ctx := requestctx.WithUser(context.Background(), "user-1", "user")
+
+	workflowRepo := newMockWorkflowRepository()
+	taskRepo := newMockTaskRepository()
+	service := NewTaskService(taskRepo, newMockUserRepository(), newMockCategoryRepository(), newMockTagRepository(), workflowRepo, newMockTaskSeriesRepository(), &mockTransactionManager{}, domain.SubtaskRules{}, logger.NewLogger("error"))
+	workflows := NewWorkflowService(workflowRepo, newMockCategoryRepository(), &mockTransactionManager{}, logger.NewLogger("error"))
+
+	strict := reviewWorkflow()
+	strict.IsDefault = true
+	if _, err := workflows.CreateWorkflow(admin, strict); err != nil {
+		t.Fatalf("CreateWorkflow() error = %v", err)
+	}
+
+	task := &domain.Task{Title: "strict", Status: domain.TaskStatusOpen, Priority: domain.TaskPriorityMedium, CreatorID: "user-1"}
+	taskRepo.Create(ctx, task)
+	if _, err := service.ChangeTaskStatus(ctx, task.ID, domain.TaskStatusCompleted, task.Version); !domain.IsBusinessRuleError(err) {
+		t.Errorf("ChangeTaskStatus() error = %v, want the default workflow to forbid skipping review", err)
+	}
+}
+
+func TestWorkflowService(t *testing.T) {
+	admin := requestctx.WithUser(context.Background(), "admin-1", "admin")
+	owner := requestctx.WithUser(context.Background(), "user-1", "user")
+	stranger := requestctx.WithUser(context.Background(), "user-2", "user")
+
+	categoryRepo := newMockCategoryRepository()
+	workflowRepo := newMockWorkflowRepository()
+	workflowRepo.categories = categoryRepo
+	service := NewWorkflowService(workflowRepo, categoryRepo, &mockTransactionManager{}, logger.NewLogger("error"))
+
+	t.Run("only admins create workflows", func(t *testing.T) {
+		if _, err := service.CreateWorkflow(owner, reviewWorkflow()); !domain.IsPermissionDeniedError(err) {
+			t.Errorf("CreateWorkflow() error = %v, want permission denied", err)
+		}
+	})
+
+	t.Run("invalid definition", func(t *testing.T) {
+		workflow := reviewWorkflow()
+		workflow.States = workflow.States[1:]
+		if _, err := service.CreateWorkflow(admin, workflow); !domain.IsInvalidInputError(err) {
+			t.Errorf("CreateWorkflow() error = %v, want invalid input", err)
+		}
+	})
+
+	t.Run("built-in default", func(t *testing.T) {
+		_, _, defaultWorkflow, err := service.ListWorkflows(owner, repository.ListOptions{})
+		if err != nil {
+			t.Fatalf("ListWorkflows() error = %v", err)
+		}
+		if defaultWorkflow.ID != "" || defaultWorkflow.Name != domain.DefaultWorkflowName {
+			t.Errorf("default workflow = %+v, want the built-in one", defaultWorkflow)
+		}
+	})
+
+	review, err := service.CreateWorkflow(admin, reviewWorkflow())
+	if err != nil {
+		t.Fatalf("CreateWorkflow() error = %v", err)
+	}
+	category := &domain.Category{Name: "team", IsPublic: true, CreatorID: "user-1"}
+	categoryRepo.Create(owner, category)
+
+	t.Run("only the creator or an admin sets a category workflow", func(t *testing.T) {
+		if _, err := service.SetCategoryWorkflow(stranger, category.ID, review.ID, category.Version); !domain.IsPermissionDeniedError(err) {
+			t.Errorf("SetCategoryWorkflow() error = %v, want permission denied", err)
+		}
+		if _, err := service.SetCategoryWorkflow(owner, category.ID, "missing", category.Version); !domain.IsInvalidInputError(err) {
+			t.Errorf("SetCategoryWorkflow() error = %v, want invalid input", err)
+		}
+		updated, err := service.SetCategoryWorkflow(owner, category.ID, review.ID, category.Version)
+		if err != nil {
+			t.Fatalf("SetCategoryWorkflow() error = %v", err)
+		}
+		if updated.WorkflowID == nil || *updated.WorkflowID != review.ID {
+			t.Errorf("category workflow = %v, want %s", updated.WorkflowID, review.ID)
+		}
+		last := categoryRepo.history[len(categoryRepo.history)-1]
+		if last.Action != domain.CategoryHistoryActionWorkflowChanged {
+			t.Errorf("history action = %s, want %s", last.Action, domain.CategoryHistoryActionWorkflowChanged)
+		}
+	})
+
+	t.Run("workflows in use cannot be deleted", func(t *testing.T) {
+		if err := service.DeleteWorkflow(admin, review.ID, review.Version); !domain.IsBusinessRuleError(err) {
+			t.Errorf("DeleteWorkflow() error = %v, want a business rule error", err)
+		}
+		if _, err := service.SetCategoryWorkflow(admin, category.ID, "", category.Version); err != nil {
+			t.Fatalf("SetCategoryWorkflow() error = %v", err)
+		}
+		if err := service.DeleteWorkflow(admin, review.ID, review.Version); err != nil {
+			t.Errorf("DeleteWorkflow() error = %v", err)
+		}
+	})
+
+	t.Run("the default workflow stays until another replaces it", func(t *testing.T) {
+		first := reviewWorkflow()
+		first.Name, first.IsDefault = "first", true
+		if _, err := service.CreateWorkflow(admin, first); err != nil {
+			t.Fatalf("CreateWorkflow() error = %v", err)
+		}
+		if err := service.DeleteWorkflow(admin, first.ID, first.Version); !domain.IsBusinessRuleError(err) {
+			t.Errorf("DeleteWorkflow() error = %v, want a business rule error", err)
+		}
+		unset := *first
+		unset.IsDefault = false
+		if _, err := service.UpdateWorkflow(admin, &unset); !domain.IsBusinessRuleError(err) {
+			t.Errorf("UpdateWorkflow() error = %v, want a business rule error", err)
+		}
+
+		second := reviewWorkflow()
+		second.Name, second.IsDefault = "second", true
+		if _, err := service.CreateWorkflow(admin, second); err != nil {
+			t.Fatalf("CreateWorkflow() error = %v", err)
+		}
+		_, _, defaultWorkflow, err := service.ListWorkflows(admin, repository.ListOptions{})
+		if err != nil {
+			t.Fatalf("ListWorkflows() error = %v", err)
+		}
+		if defaultWorkflow.ID != second.ID {
+			t.Errorf("default workflow = %s, want %s", defaultWorkflow.ID, second.ID)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+// Package taskquery parses the task query language used to filter task lists.
+//
+// A query is a sequence of terms that must all match:
+//
+//	status:open priority>=high assignee:me tag:backend due<7d -category:archived
+//
+// Terms are combined with AND (the default), OR and NOT, grouped with parentheses
+// and negated with a leading minus. A field term is field, operator and value;
+// values containing spaces are quoted. Any other word, or a quoted phrase, is a
+// full-text search over titles and descriptions.
+//
+// Parse builds the syntax tree; Bind then validates fields and values against an
+// Env and resolves "me" and relative dates, producing the tree that the
+// repository compiles to SQL.
+package taskquery
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Op is a comparison operator
+type Op string
+
+const (
+	OpMatch Op = ":" // equality, containment for title
+	OpEq    Op = "="
+	OpNe    Op = "!="
+	OpLt    Op = "<"
+	OpLe    Op = "<="
+	OpGt    Op = ">"
+	OpGe    Op = ">="
+)
+
+// Field names
+const (
+	FieldStatus   = "status"
+	FieldPriority = "priority"
+	FieldAssignee = "assignee"
+	FieldTag      = "tag"
+	FieldCategory = "category"
+	FieldDue      = "due"
+	FieldCreated  = "created"
+	FieldUpdated  = "updated"
+	FieldTitle    = "title"
+	// FieldText is a free-text word or phrase
+	FieldText = "text"
+)
+
+// Expr is a node of the query syntax tree
+type Expr interface {
+	// Pos is the 1-based character position of the node in the query
+	Pos() int
+}
+
+// And matches when both sides match
+type And struct {
+	Left, Right Expr
+}
+
+// Or matches when either side matches
+type Or struct {
+	Left, Right Expr
+}
+
+// Not matches when its operand does not
+type Not struct {
+	Expr   Expr
+	Offset int
+}
+
+// Term is a single condition
+type Term struct {
+	Field  string
+	Op     Op
+	Value  string
+	Quoted bool
+	Offset int
+
+	// Set by Bind. Values holds the normalized values of status, priority, assignee,
+	// tag and category terms, any of which may match; Time holds the bound of date
+	// terms. None marks tag:none, category:none and due:none.
+	Values []string
+	Time   time.Time
+	None   bool
+}
+
+func (e *And) Pos() int  { return e.Left.Pos() }
+func (e *Or) Pos() int   { return e.Left.Pos() }
+func (e *Not) Pos() int  { return e.Offset }
+func (e *Term) Pos() int { return e.Offset }
+
+// String renders a syntax tree with explicit grouping, for logs and tests
+func String(expr Expr) string {
+	switch e := expr.(type) {
+	case *And:
+		return fmt.Sprintf("(%s AND %s)", String(e.Left), String(e.Right))
+	case *Or:
+		return fmt.Sprintf("(%s OR %s)", String(e.Left), String(e.Right))
+	case *Not:
+		return "NOT " + String(e.Expr)
+	case *Term:
+		value := e.Value
+		if e.Quoted {
+			value = strconv.Quote(value)
+		}
+		if e.Field == FieldText {
+			return value
+		}
+		return e.Field + string(e.Op) + value
+	default:
+		return ""
+	}
+}
+
+// WithoutField drops the parts of a conjunction that only constrain field, so a
+// facet can be counted as if its own filter were not set. Conditions on field
+// nested in OR or NOT together with other fields are kept. It returns nil when
+// nothing is left.
+func WithoutField(expr Expr, field string) Expr {
+	if expr == nil || onlyField(expr, field) {
+		return nil
+	}
+	and, ok := expr.(*And)
+	if !ok {
+		return expr
+	}
+
+	left := WithoutField(and.Left, field)
+	right := WithoutField(and.Right, field)
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	default:
+		return &And{Left: left, Right: right}
+	}
+}
+
+// onlyField reports whether every term of expr is on field
+func onlyField(expr Expr, field string) bool {
+	switch e := expr.(type) {
+	case *And:
+		return onlyField(e.Left, field) && onlyField(e.Right, field)
+	case *Or:
+		return onlyField(e.Left, field) && onlyField(e.Right, field)
+	case *Not:
+		return onlyField(e.Expr, field)
+	case *Term:
+		return e.Field == field
+	default:
+		return false
+	}
+}
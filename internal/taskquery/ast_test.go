@@ -0,0 +1,30 @@
+package taskquery
+
+import "testing"
+
+func TestWithoutField(t *testing.T) {
+	tests := []struct {
+		query string
+		field string
+		want  string
+	}{
+		{"status:open priority>=high", FieldStatus, "priority>=high"},
+		{"(status:open OR status:in_progress) tag:backend -status:cancelled", FieldStatus, "tag:backend"},
+		{"status:open OR priority:urgent", FieldStatus, "(status:open OR priority:urgent)"},
+		{"NOT (tag:ops OR tag:infra) created>-2w", FieldTag, "created>-2w"},
+		{"tag:backend", FieldTag, ""},
+		{"tag:backend deploy", FieldStatus, "(tag:backend AND deploy)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query+" without "+tt.field, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+			if got := String(WithoutField(expr, tt.field)); got != tt.want {
+				t.Errorf("WithoutField() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package taskquery
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+// Env is what a query is bound against
+type Env struct {
+	// UserID is the caller, used for assignee:me
+	UserID string
+	// Now and Location anchor relative dates such as today and 7d
+	Now      time.Time
+	Location *time.Location
+}
+
+var queryStatuses = map[string]domain.TaskStatus{
+	"OPEN":        domain.TaskStatusOpen,
+	"IN_PROGRESS": domain.TaskStatusInProgress,
+	"IN_REVIEW":   domain.TaskStatusInReview,
+	"BLOCKED":     domain.TaskStatusBlocked,
+	"COMPLETED":   domain.TaskStatusCompleted,
+	"CANCELLED":   domain.TaskStatusCancelled,
+	"CANCELED":    domain.TaskStatusCancelled,
+}
+
+// queryPriorities are ordered from lowest to highest
+var queryPriorities = []domain.TaskPriority{
+	domain.TaskPriorityLow,
+	domain.TaskPriorityMedium,
+	domain.TaskPriorityHigh,
+	domain.TaskPriorityUrgent,
+}
+
+// relativeDate matches offsets from now such as 7d, -2w or 12h
+var relativeDate = regexp.MustCompile(`^([+-]?\d{1,4})([hdw])$`)
+
+// Bind validates a parsed query and resolves its values: statuses and priorities
+// are normalized, priority comparisons become the matching priorities, "me"
+// becomes the caller and dates become absolute times in env.Location.
+func Bind(expr Expr, env Env) (Expr, error) {
+	if env.Location == nil {
+		env.Location = time.UTC
+	}
+	if env.Now.IsZero() {
+		env.Now = time.Now()
+	}
+	env.Now = env.Now.In(env.Location)
+	return bind(expr, env)
+}
+
+func bind(expr Expr, env Env) (Expr, error) {
+	switch e := expr.(type) {
+	case *And:
+		left, right, err := bindPair(e.Left, e.Right, env)
+		if err != nil {
+			return nil, err
+		}
+		return &And{Left: left, Right: right}, nil
+	case *Or:
+		left, right, err := bindPair(e.Left, e.Right, env)
+		if err != nil {
+			return nil, err
+		}
+		return &Or{Left: left, Right: right}, nil
+	case *Not:
+		operand, err := bind(e.Expr, env)
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: operand, Offset: e.Offset}, nil
+	case *Term:
+		return bindTerm(*e, env)
+	default:
+		return nil, domain.ErrInvalidInput("invalid query")
+	}
+}
+
+func bindPair(left, right Expr, env Env) (Expr, Expr, error) {
+	boundLeft, err := bind(left, env)
+	if err != nil {
+		return nil, nil, err
+	}
+	boundRight, err := bind(right, env)
+	if err != nil {
+		return nil, nil, err
+	}
+	return boundLeft, boundRight, nil
+}
+
+func bindTerm(t Term, env Env) (Expr, error) {
+	switch t.Field {
+	case FieldText:
+		if strings.TrimSpace(t.Value) == "" {
+			return nil, errorAt(t.Offset, "empty search phrase")
+		}
+		return &t, nil
+	case FieldTitle:
+		if t.Op != OpMatch && t.Op != OpNe {
+			return nil, errorAt(t.Offset, "title supports : and !=")
+		}
+		return &t, nil
+	case FieldStatus:
+		return bindStatus(t)
+	case FieldPriority:
+		return bindPriority(t)
+	case FieldAssignee:
+		return bindAssignee(t, env)
+	case FieldTag, FieldCategory:
+		if err := requireEquality(t); err != nil {
+			return nil, err
+		}
+		t.Op = equalityOp(t.Op)
+		if !t.Quoted && strings.EqualFold(t.Value, "none") {
+			t.None = true
+			return &t, nil
+		}
+		t.Values = splitValues(strings.ToLower(t.Value))
+		return &t, nil
+	case FieldDue, FieldCreated, FieldUpdated:
+		return bindDate(t, env)
+	default:
+		return nil, errorAt(t.Offset, "unknown field %q (fields: status, priority, assignee, tag, category, due, created, updated, title)", t.Field)
+	}
+}
+
+// requireEquality rejects comparisons on fields that only support equality
+func requireEquality(t Term) error {
+	switch t.Op {
+	case OpMatch, OpEq, OpNe:
+		return nil
+	default:
+		return errorAt(t.Offset, "%s supports :, = and !=", t.Field)
+	}
+}
+
+// equalityOp normalizes : to = so bound terms only use = and !=
+func equalityOp(op Op) Op {
+	if op == OpNe {
+		return OpNe
+	}
+	return OpEq
+}
+
+// splitValues splits a comma separated list of alternatives
+func splitValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func bindStatus(t Term) (Expr, error) {
+	if err := requireEquality(t); err != nil {
+		return nil, err
+	}
+
+	for _, value := range splitValues(t.Value) {
+		key := strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(value))
+		status, ok := queryStatuses[key]
+		if !ok {
+			return nil, errorAt(t.Offset, "unknown status %q (statuses: %s)", value, statusNames())
+		}
+		t.Values = append(t.Values, string(status))
+	}
+	t.Op = equalityOp(t.Op)
+	return &t, nil
+}
+
+// statusNames lists the statuses a query accepts, in the spelling of the query language
+func statusNames() string {
+	names := make([]string, 0, len(domain.WorkflowStatuses))
+	for _, status := range domain.WorkflowStatuses {
+		names = append(names, strings.ToLower(string(status)))
+	}
+	return strings.Join(names, ", ")
+}
+
+func priorityRank(value string) (int, bool) {
+	for i, priority := range queryPriorities {
+		if strings.EqualFold(value, string(priority)) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// bindPriority turns comparisons into the set of matching priorities
+func bindPriority(t Term) (Expr, error) {
+	values := splitValues(t.Value)
+	if t.Op != OpMatch && t.Op != OpEq && t.Op != OpNe && len(values) != 1 {
+		return nil, errorAt(t.Offset, "priority%s compares against a single priority", t.Op)
+	}
+
+	var ranks []int
+	for _, value := range values {
+		rank, ok := priorityRank(value)
+		if !ok {
+			return nil, errorAt(t.Offset, "unknown priority %q (priorities: low, medium, high, urgent)", value)
+		}
+		ranks = append(ranks, rank)
+	}
+
+	matches := func(rank int) bool {
+		switch t.Op {
+		case OpLt:
+			return rank < ranks[0]
+		case OpLe:
+			return rank <= ranks[0]
+		case OpGt:
+			return rank > ranks[0]
+		case OpGe:
+			return rank >= ranks[0]
+		default:
+			for _, r := range ranks {
+				if rank == r {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	t.Values = []string{}
+	for rank, priority := range queryPriorities {
+		if matches(rank) {
+			t.Values = append(t.Values, string(priority))
+		}
+	}
+	t.Op = equalityOp(t.Op)
+	return &t, nil
+}
+
+// bindAssignee accepts user IDs, emails and "me"
+func bindAssignee(t Term, env Env) (Expr, error) {
+	if err := requireEquality(t); err != nil {
+		return nil, err
+	}
+
+	for _, value := range splitValues(t.Value) {
+		if strings.EqualFold(value, "me") && !t.Quoted {
+			if env.UserID == "" {
+				return nil, errorAt(t.Offset, "assignee:me needs an authenticated caller")
+			}
+			value = env.UserID
+		}
+		t.Values = append(t.Values, strings.ToLower(value))
+	}
+	t.Op = equalityOp(t.Op)
+	return &t, nil
+}
+
+// bindDate resolves a date term. A whole day (today, 2024-05-01) compared with :
+// matches any time that day; < and >= start at its beginning, <= and > at its end.
+func bindDate(t Term, env Env) (Expr, error) {
+	if !t.Quoted && strings.EqualFold(t.Value, "none") {
+		if t.Field != FieldDue {
+			return nil, errorAt(t.Offset, "%s is always set", t.Field)
+		}
+		if err := requireEquality(t); err != nil {
+			return nil, err
+		}
+		t.None = true
+		t.Op = equalityOp(t.Op)
+		return &t, nil
+	}
+
+	at, wholeDay, err := parseDate(t.Value, env)
+	if err != nil {
+		return nil, errorAt(t.Offset, "%v", err)
+	}
+	nextDay := at.AddDate(0, 0, 1)
+
+	bound := func(op Op, at time.Time) *Term {
+		b := t
+		b.Op = op
+		b.Time = at
+		return &b
+	}
+
+	switch t.Op {
+	case OpMatch, OpEq, OpNe:
+		if !wholeDay {
+			return nil, errorAt(t.Offset, "%s%s needs a date such as today or 2024-05-01; compare times with < or >", t.Field, t.Op)
+		}
+		day := &And{Left: bound(OpGe, at), Right: bound(OpLt, nextDay)}
+		if t.Op == OpNe {
+			return &Not{Expr: day, Offset: t.Offset}, nil
+		}
+		return day, nil
+	case OpLe:
+		if wholeDay {
+			return bound(OpLt, nextDay), nil
+		}
+	case OpGt:
+		if wholeDay {
+			return bound(OpGe, nextDay), nil
+		}
+	}
+	return bound(t.Op, at), nil
+}
+
+// parseDate parses today, tomorrow, yesterday, offsets from now (7d, -2w, 12h),
+// YYYY-MM-DD and RFC 3339 times. wholeDay reports values that name a day.
+func parseDate(value string, env Env) (at time.Time, wholeDay bool, err error) {
+	now := env.Now
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, env.Location)
+
+	switch strings.ToLower(value) {
+	case "today":
+		return today, true, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true, nil
+	}
+
+	if m := relativeDate.FindStringSubmatch(strings.ToLower(value)); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "h":
+			return now.Add(time.Duration(n) * time.Hour), false, nil
+		case "d":
+			return now.AddDate(0, 0, n), false, nil
+		default:
+			return now.AddDate(0, 0, 7*n), false, nil
+		}
+	}
+
+	if day, err := time.ParseInLocation("2006-01-02", value, env.Location); err == nil {
+		return day, true, nil
+	}
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q (use today, tomorrow, 7d, -2w, 2024-05-01 or an RFC 3339 time)", value)
+}
@@ -0,0 +1,153 @@
+package taskquery
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+// bindTestEnv is Wednesday 2024-05-15 14:30 in Berlin
+func bindTestEnv(t *testing.T) Env {
+	t.Helper()
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	return Env{
+		UserID:   "user-1",
+		Now:      time.Date(2024, 5, 15, 14, 30, 0, 0, berlin),
+		Location: berlin,
+	}
+}
+
+func mustBind(t *testing.T, query string, env Env) Expr {
+	t.Helper()
+	expr, err := Parse(query)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", query, err)
+	}
+	bound, err := Bind(expr, env)
+	if err != nil {
+		t.Fatalf("Bind(%q) error = %v", query, err)
+	}
+	return bound
+}
+
+func TestBind_Values(t *testing.T) {
+	env := bindTestEnv(t)
+
+	tests := []struct {
+		query  string
+		op     Op
+		values []string
+	}{
+		{"status:in-progress,open", OpEq, []string{"IN_PROGRESS", "OPEN"}},
+		{"status!=cancelled", OpNe, []string{"CANCELLED"}},
+		{"priority>=high", OpEq, []string{"HIGH", "URGENT"}},
+		{"priority<medium", OpEq, []string{"LOW"}},
+		{"priority>urgent", OpEq, []string{}},
+		{"priority:low,urgent", OpEq, []string{"LOW", "URGENT"}},
+		{"assignee:me,Dev@Example.com", OpEq, []string{"user-1", "dev@example.com"}},
+		{"tag:Backend", OpEq, []string{"backend"}},
+		{`category!="Team Backlog"`, OpNe, []string{"team backlog"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			term, ok := mustBind(t, tt.query, env).(*Term)
+			if !ok {
+				t.Fatalf("expected a term")
+			}
+			if term.Op != tt.op {
+				t.Errorf("op = %s, want %s", term.Op, tt.op)
+			}
+			if strings.Join(term.Values, ",") != strings.Join(tt.values, ",") {
+				t.Errorf("values = %v, want %v", term.Values, tt.values)
+			}
+		})
+	}
+}
+
+func TestBind_Dates(t *testing.T) {
+	env := bindTestEnv(t)
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, env.Location) }
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"due<7d", "due<" + env.Now.AddDate(0, 0, 7).String()},
+		{"created>-2w", "created>" + env.Now.AddDate(0, 0, -14).String()},
+		{"due<today", "due<" + day(15).String()},
+		{"due<=today", "due<" + day(16).String()},
+		{"due>tomorrow", "due>=" + day(17).String()},
+		{"due:2024-05-20", "(due>=" + day(20).String() + " AND due<" + day(21).String() + ")"},
+		{"due!=yesterday", "NOT (due>=" + day(14).String() + " AND due<" + day(15).String() + ")"},
+		{"due:none", "due=none"},
+	}
+
+	render := func(expr Expr) string {
+		var walk func(Expr) string
+		walk = func(expr Expr) string {
+			switch e := expr.(type) {
+			case *And:
+				return "(" + walk(e.Left) + " AND " + walk(e.Right) + ")"
+			case *Not:
+				return "NOT " + walk(e.Expr)
+			case *Term:
+				if e.None {
+					return e.Field + string(e.Op) + "none"
+				}
+				return e.Field + string(e.Op) + e.Time.String()
+			}
+			return "?"
+		}
+		return walk(expr)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := render(mustBind(t, tt.query, env)); got != tt.want {
+				t.Errorf("Bind(%q) = %s, want %s", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBind_Errors(t *testing.T) {
+	env := bindTestEnv(t)
+
+	tests := []struct {
+		query string
+		env   Env
+		want  string
+	}{
+		{"owner:me", env, `position 1: unknown field "owner"`},
+		{"status:open status:doing", env, `position 13: unknown status "doing"`},
+		{"status:doing", env, "statuses: open, in_progress, in_review, blocked, completed, cancelled)"},
+		{"priority>=high,low", env, "compares against a single priority"},
+		{"tag>backend", env, "tag supports :, = and !="},
+		{"due:7d", env, "needs a date"},
+		{"due<soon", env, `invalid date "soon"`},
+		{"created:none", env, "created is always set"},
+		{"assignee:me", Env{}, "needs an authenticated caller"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+			_, err = Bind(expr, tt.env)
+			if !domain.IsInvalidInputError(err) {
+				t.Fatalf("Bind(%q) error = %v, want an invalid input error", tt.query, err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Bind(%q) error = %q, want it to contain %q", tt.query, err.Error(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package taskquery
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+// Query limits keep parsing and the generated SQL small
+const (
+	MaxQueryLength = 1024
+	MaxQueryTerms  = 64
+	maxQueryDepth  = 16
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokLParen
+	tokRParen
+	tokMinus
+	tokAnd
+	tokOr
+	tokNot
+	tokTerm
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	term *Term
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokLParen:
+		return "'('"
+	case tokRParen:
+		return "')'"
+	case tokMinus:
+		return "'-'"
+	case tokAnd:
+		return "AND"
+	case tokOr:
+		return "OR"
+	case tokNot:
+		return "NOT"
+	default:
+		return fmt.Sprintf("%q", t.term.Value)
+	}
+}
+
+// errorAt reports a problem at a 1-based character position of the query
+func errorAt(pos int, format string, args ...interface{}) error {
+	return domain.ErrInvalidInput(fmt.Sprintf("invalid query at position %d: %s", pos, fmt.Sprintf(format, args...)))
+}
+
+// Parse parses a query into its syntax tree. Syntax errors are INVALID_INPUT
+// domain errors that include the position of the problem.
+func Parse(input string) (Expr, error) {
+	if !utf8.ValidString(input) {
+		return nil, domain.ErrInvalidInput("query must be valid UTF-8")
+	}
+	if len(input) > MaxQueryLength {
+		return nil, domain.ErrInvalidInput(fmt.Sprintf("query must be at most %d characters", MaxQueryLength))
+	}
+
+	tokens, err := lex([]rune(input))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, errorAt(1, "query is empty")
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, errorAt(tok.pos, "unexpected %s", tok)
+	}
+	return expr, nil
+}
+
+func lex(input []rune) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		r := input[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, pos: pos})
+			i++
+		case r == '-' && i+1 < len(input) && !unicode.IsSpace(input[i+1]) && input[i+1] != ')':
+			tokens = append(tokens, token{kind: tokMinus, pos: pos})
+			i++
+		case r == '"':
+			value, next, err := lexQuoted(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokTerm, pos: pos, term: &Term{Field: FieldText, Value: value, Quoted: true, Offset: pos}})
+			i = next
+		default:
+			tok, next, err := lexWord(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i = next
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(input) + 1}), nil
+}
+
+// lexWord reads a field term such as priority>=high, a keyword or a free-text word
+func lexWord(input []rune, start int) (token, int, error) {
+	pos := start + 1
+
+	end := start
+	for end < len(input) && (unicode.IsLetter(input[end]) || unicode.IsDigit(input[end]) || input[end] == '_') {
+		end++
+	}
+
+	if end > start && end < len(input) && strings.ContainsRune(":=!<>", input[end]) {
+		field := strings.ToLower(string(input[start:end]))
+		op, next, err := lexOp(input, end)
+		if err != nil {
+			return token{}, 0, err
+		}
+
+		term := &Term{Field: field, Op: op, Offset: pos}
+		if next < len(input) && input[next] == '"' {
+			term.Value, next, err = lexQuoted(input, next)
+			if err != nil {
+				return token{}, 0, err
+			}
+			term.Quoted = true
+		} else {
+			valueStart := next
+			for next < len(input) && !isWordDelimiter(input[next]) {
+				next++
+			}
+			term.Value = string(input[valueStart:next])
+			if term.Value == "" {
+				return token{}, 0, errorAt(next+1, "missing value after %s%s", field, op)
+			}
+		}
+		return token{kind: tokTerm, pos: pos, term: term}, next, nil
+	}
+
+	for end < len(input) && !isWordDelimiter(input[end]) {
+		end++
+	}
+	word := string(input[start:end])
+
+	// Keywords are upper case so the words "and", "or" and "not" can still be searched for
+	switch word {
+	case "AND":
+		return token{kind: tokAnd, pos: pos}, end, nil
+	case "OR":
+		return token{kind: tokOr, pos: pos}, end, nil
+	case "NOT":
+		return token{kind: tokNot, pos: pos}, end, nil
+	}
+	return token{kind: tokTerm, pos: pos, term: &Term{Field: FieldText, Value: word, Offset: pos}}, end, nil
+}
+
+func lexOp(input []rune, start int) (Op, int, error) {
+	if start+1 < len(input) && input[start+1] == '=' {
+		switch input[start] {
+		case '<':
+			return OpLe, start + 2, nil
+		case '>':
+			return OpGe, start + 2, nil
+		case '!':
+			return OpNe, start + 2, nil
+		}
+	}
+
+	switch input[start] {
+	case ':':
+		return OpMatch, start + 1, nil
+	case '=':
+		return OpEq, start + 1, nil
+	case '<':
+		return OpLt, start + 1, nil
+	case '>':
+		return OpGt, start + 1, nil
+	default:
+		return "", 0, errorAt(start+1, "expected '=' after '!'")
+	}
+}
+
+// lexQuoted reads a double-quoted string; a backslash escapes the next character
+func lexQuoted(input []rune, start int) (string, int, error) {
+	var value strings.Builder
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			if i+1 < len(input) {
+				i++
+				value.WriteRune(input[i])
+			}
+		case '"':
+			return value.String(), i + 1, nil
+		default:
+			value.WriteRune(input[i])
+		}
+	}
+	return "", 0, errorAt(start+1, "unterminated quoted string")
+}
+
+func isWordDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"'
+}
+
+type parser struct {
+	tokens []token
+	i      int
+	depth  int
+	terms  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+// parseOr parses: and { OR and }
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary { [AND] unary }
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokAnd:
+			p.next()
+		case tokTerm, tokLParen, tokMinus, tokNot:
+		default:
+			return left, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+}
+
+// parseUnary parses: { NOT | - } primary
+func (p *parser) parseUnary() (Expr, error) {
+	tok := p.peek()
+	if tok.kind != tokNot && tok.kind != tokMinus {
+		return p.parsePrimary()
+	}
+
+	p.next()
+	if err := p.enter(tok.pos); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	p.depth--
+	return &Not{Expr: operand, Offset: tok.pos}, nil
+}
+
+// parsePrimary parses: term | ( or )
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokTerm:
+		p.terms++
+		if p.terms > MaxQueryTerms {
+			return nil, errorAt(tok.pos, "query has more than %d terms", MaxQueryTerms)
+		}
+		return tok.term, nil
+	case tokLParen:
+		if err := p.enter(tok.pos); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, errorAt(tok.pos, "unclosed '('")
+		}
+		p.next()
+		p.depth--
+		return expr, nil
+	default:
+		return nil, errorAt(tok.pos, "expected a term but found %s", tok)
+	}
+}
+
+func (p *parser) enter(pos int) error {
+	p.depth++
+	if p.depth > maxQueryDepth {
+		return errorAt(pos, "query is nested more than %d levels deep", maxQueryDepth)
+	}
+	return nil
+}
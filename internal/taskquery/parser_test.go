@@ -0,0 +1,93 @@
+package taskquery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/todo-app/services/admin-service/internal/model/domain"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "implicit and",
+			query: "status:open priority>=high assignee:me",
+			want:  "((status:open AND priority>=high) AND assignee:me)",
+		},
+		{
+			name:  "minus negates",
+			query: "tag:backend due<7d -category:archived",
+			want:  "((tag:backend AND due<7d) AND NOT category:archived)",
+		},
+		{
+			name:  "and binds tighter than or",
+			query: "status:open OR status:in_progress AND priority:urgent",
+			want:  "(status:open OR (status:in_progress AND priority:urgent))",
+		},
+		{
+			name:  "parentheses and not",
+			query: "NOT (tag:ops OR tag:infra) created>-2w",
+			want:  "(NOT (tag:ops OR tag:infra) AND created>-2w)",
+		},
+		{
+			name:  "quoted values and phrases",
+			query: `category:"Team Backlog" "release notes" deploy`,
+			want:  `((category:"Team Backlog" AND "release notes") AND deploy)`,
+		},
+		{
+			name:  "lower case keywords are words",
+			query: "salt and pepper",
+			want:  "((salt AND and) AND pepper)",
+		},
+		{
+			name:  "field names are case insensitive",
+			query: "Priority!=LOW",
+			want:  "priority!=LOW",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+			if got := String(expr); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "position 1: query is empty"},
+		{"status:", "position 8: missing value after status:"},
+		{"(status:open", "position 1: unclosed '('"},
+		{"status:open)", "position 12: unexpected ')'"},
+		{"status:open OR", "position 15: expected a term but found end of query"},
+		{"AND tag:x", "position 1: expected a term but found AND"},
+		{`title:"unterminated`, "position 7: unterminated quoted string"},
+		{"priority!high", "position 9: expected '=' after '!'"},
+		{strings.Repeat("(", 20) + "x" + strings.Repeat(")", 20), "nested more than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			if !domain.IsInvalidInputError(err) {
+				t.Fatalf("Parse(%q) error = %v, want an invalid input error", tt.query, err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.query, err.Error(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
+)
+
+// contextHandler decorates a slog.Handler with request-scoped attributes
+// (request ID, user ID, gRPC method and trace ID) taken from the context
+type contextHandler struct {
+	handler slog.Handler
+}
+
+func newContextHandler(handler slog.Handler) *contextHandler {
+	return &contextHandler{handler: handler}
+}
+
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if requestID := requestctx.RequestID(ctx); requestID != "" {
+			record.AddAttrs(slog.String("request_id", requestID))
+		}
+		if userID := requestctx.UserID(ctx); userID != "" {
+			record.AddAttrs(slog.String("user_id", userID))
+		}
+		if method := requestctx.Method(ctx); method != "" {
+			record.AddAttrs(slog.String("method", method))
+		}
+		if traceID := requestctx.TraceID(ctx); traceID != "" {
+			record.AddAttrs(slog.String("trace_id", traceID))
+		}
+	}
+	return h.handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return newContextHandler(h.handler.WithAttrs(attrs))
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return newContextHandler(h.handler.WithGroup(name))
+}
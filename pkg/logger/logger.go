@@ -2,6 +2,7 @@ package logger
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
@@ -20,33 +21,64 @@ type slogLogger struct {
 	logger *slog.Logger
 }
 
+// Level is a log level that can be changed while the logger is in use
+type Level struct {
+	level slog.LevelVar
+}
+
+// NewLevel creates a Level from its name; unknown names default to info
+func NewLevel(level string) *Level {
+	l := &Level{}
+	l.Set(level)
+	return l
+}
+
+// Set changes the level of every logger created with it
+func (l *Level) Set(level string) {
+	l.level.Set(parseLevel(level))
+}
+
+// String returns the current level name
+func (l *Level) String() string {
+	return strings.ToLower(l.level.Level().String())
+}
+
 // NewLogger creates a new structured logger
 func NewLogger(level string) Logger {
-	var logLevel slog.Level
+	return NewLoggerWithLevel(NewLevel(level))
+}
 
-	switch strings.ToLower(level) {
-	case "debug":
-		logLevel = slog.LevelDebug
-	case "info":
-		logLevel = slog.LevelInfo
-	case "warn":
-		logLevel = slog.LevelWarn
-	case "error":
-		logLevel = slog.LevelError
-	default:
-		logLevel = slog.LevelInfo
-	}
+// NewLoggerWithLevel creates a structured logger whose level can be changed at runtime
+func NewLoggerWithLevel(level *Level) Logger {
+	return newLogger(os.Stdout, level)
+}
 
+func newLogger(w io.Writer, level *Level) Logger {
 	opts := &slog.HandlerOptions{
-		Level: logLevel,
+		Level: &level.level,
 	}
 
-	handler := slog.NewJSONHandler(os.Stdout, opts)
+	handler := newContextHandler(slog.NewJSONHandler(w, opts))
 	logger := slog.New(handler)
 
 	return &slogLogger{logger: logger}
 }
 
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "info":
+		return slog.LevelInfo
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
 func (l *slogLogger) Debug(ctx context.Context, msg string, args ...any) {
 	l.logger.DebugContext(ctx, msg, args...)
 }
@@ -1,8 +1,12 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
+
+	"github.com/todo-app/services/admin-service/pkg/requestctx"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -122,3 +126,66 @@ func TestLogger_WithKeyValuePairs(t *testing.T) {
 		})
 	}
 }
+
+func TestLogger_RequestContextAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(&buf, NewLevel("debug"))
+
+	ctx := requestctx.WithRequestID(context.Background(), "req-123")
+	ctx = requestctx.WithUser(ctx, "user-456", "admin")
+	ctx = requestctx.WithMethod(ctx, "/todo.v1.AdminService/GetTask")
+	ctx = requestctx.WithTraceID(ctx, "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	logger.With("component", "test").Info(ctx, "test message")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log output %q: %v", buf.String(), err)
+	}
+
+	expected := map[string]string{
+		"request_id": "req-123",
+		"user_id":    "user-456",
+		"method":     "/todo.v1.AdminService/GetTask",
+		"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+		"component":  "test",
+	}
+	for key, want := range expected {
+		if got := entry[key]; got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+
+	// Without request-scoped values no extra attributes are added
+	buf.Reset()
+	logger.Info(context.Background(), "plain message")
+	entry = map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log output %q: %v", buf.String(), err)
+	}
+	if _, exists := entry["request_id"]; exists {
+		t.Errorf("request_id should not be present without request context")
+	}
+}
+
+func TestLogger_LevelChange(t *testing.T) {
+	var buf bytes.Buffer
+	level := NewLevel("warn")
+	logger := newLogger(&buf, level)
+	ctx := context.Background()
+
+	logger.Info(ctx, "suppressed")
+	if buf.Len() != 0 {
+		t.Fatalf("Info should be suppressed at warn level, got %q", buf.String())
+	}
+
+	level.Set("debug")
+	if level.String() != "debug" {
+		t.Errorf("Level.String() = %v, want debug", level.String())
+	}
+
+	logger.Debug(ctx, "emitted")
+	if buf.Len() == 0 {
+		t.Error("Debug should be emitted after lowering the level")
+	}
+}
@@ -0,0 +1,86 @@
+package requestctx
+
+import (
+	"context"
+)
+
+// contextKey is an unexported type for request-scoped context keys
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	userIDKey
+	userRoleKey
+	methodKey
+	traceIDKey
+	internalKey
+)
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the request ID stored in the context, if any
+func RequestID(ctx context.Context) string {
+	return stringValue(ctx, requestIDKey)
+}
+
+// WithUser returns a context carrying the authenticated user ID and role
+func WithUser(ctx context.Context, userID, role string) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, userID)
+	return context.WithValue(ctx, userRoleKey, role)
+}
+
+// UserID returns the authenticated user ID stored in the context, if any
+func UserID(ctx context.Context) string {
+	return stringValue(ctx, userIDKey)
+}
+
+// UserRole returns the authenticated user role stored in the context, if any
+func UserRole(ctx context.Context) string {
+	return stringValue(ctx, userRoleKey)
+}
+
+// WithMethod returns a context carrying the full gRPC method name
+func WithMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodKey, method)
+}
+
+// Method returns the full gRPC method name stored in the context, if any
+func Method(ctx context.Context) string {
+	return stringValue(ctx, methodKey)
+}
+
+// WithTraceID returns a context carrying the distributed trace ID
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// TraceID returns the distributed trace ID stored in the context, if any
+func TraceID(ctx context.Context) string {
+	return stringValue(ctx, traceIDKey)
+}
+
+// WithInternal returns a context for work the service starts itself, such as scheduled jobs,
+// rather than on behalf of a caller
+func WithInternal(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internalKey, true)
+}
+
+// IsInternal reports whether the context belongs to work the service started itself
+func IsInternal(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	internal, _ := ctx.Value(internalKey).(bool)
+	return internal
+}
+
+func stringValue(ctx context.Context, key contextKey) string {
+	if ctx == nil {
+		return ""
+	}
+	value, _ := ctx.Value(key).(string)
+	return value
+}
@@ -19,13 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	AdminService_ListUsers_FullMethodName      = "/todo.v1.AdminService/ListUsers"
-	AdminService_GetUser_FullMethodName        = "/todo.v1.AdminService/GetUser"
-	AdminService_CreateTask_FullMethodName     = "/todo.v1.AdminService/CreateTask"
-	AdminService_ListTasks_FullMethodName      = "/todo.v1.AdminService/ListTasks"
-	AdminService_GetTask_FullMethodName        = "/todo.v1.AdminService/GetTask"
-	AdminService_UpdateTask_FullMethodName     = "/todo.v1.AdminService/UpdateTask"
-	AdminService_GetTaskHistory_FullMethodName = "/todo.v1.AdminService/GetTaskHistory"
+	AdminService_ListUsers_FullMethodName              = "/todo.v1.AdminService/ListUsers"
+	AdminService_GetUser_FullMethodName                = "/todo.v1.AdminService/GetUser"
+	AdminService_CreateTask_FullMethodName             = "/todo.v1.AdminService/CreateTask"
+	AdminService_ListTasks_FullMethodName              = "/todo.v1.AdminService/ListTasks"
+	AdminService_GetTask_FullMethodName                = "/todo.v1.AdminService/GetTask"
+	AdminService_SearchTasks_FullMethodName            = "/todo.v1.AdminService/SearchTasks"
+	AdminService_UpdateTask_FullMethodName             = "/todo.v1.AdminService/UpdateTask"
+	AdminService_DeleteTask_FullMethodName             = "/todo.v1.AdminService/DeleteTask"
+	AdminService_GetTaskHistory_FullMethodName         = "/todo.v1.AdminService/GetTaskHistory"
+	AdminService_MoveTask_FullMethodName               = "/todo.v1.AdminService/MoveTask"
+	AdminService_ListSubtasks_FullMethodName           = "/todo.v1.AdminService/ListSubtasks"
+	AdminService_GetTaskTree_FullMethodName            = "/todo.v1.AdminService/GetTaskTree"
+	AdminService_AddTaskParticipants_FullMethodName    = "/todo.v1.AdminService/AddTaskParticipants"
+	AdminService_RemoveTaskParticipants_FullMethodName = "/todo.v1.AdminService/RemoveTaskParticipants"
+	AdminService_AddTaskDependency_FullMethodName      = "/todo.v1.AdminService/AddTaskDependency"
+	AdminService_RemoveTaskDependency_FullMethodName   = "/todo.v1.AdminService/RemoveTaskDependency"
+	AdminService_ListTaskBlockers_FullMethodName       = "/todo.v1.AdminService/ListTaskBlockers"
+	AdminService_ListTaskDependents_FullMethodName     = "/todo.v1.AdminService/ListTaskDependents"
+	AdminService_GetCriticalPath_FullMethodName        = "/todo.v1.AdminService/GetCriticalPath"
+	AdminService_SetTaskRecurrence_FullMethodName      = "/todo.v1.AdminService/SetTaskRecurrence"
+	AdminService_GetTaskSeries_FullMethodName          = "/todo.v1.AdminService/GetTaskSeries"
+	AdminService_UpdateTaskSeries_FullMethodName       = "/todo.v1.AdminService/UpdateTaskSeries"
+	AdminService_StopTaskSeries_FullMethodName         = "/todo.v1.AdminService/StopTaskSeries"
+	AdminService_BulkUpdateTasks_FullMethodName        = "/todo.v1.AdminService/BulkUpdateTasks"
+	AdminService_BulkDeleteTasks_FullMethodName        = "/todo.v1.AdminService/BulkDeleteTasks"
+	AdminService_BulkRestoreTasks_FullMethodName       = "/todo.v1.AdminService/BulkRestoreTasks"
+	AdminService_ImportTasks_FullMethodName            = "/todo.v1.AdminService/ImportTasks"
+	AdminService_ExportTasks_FullMethodName            = "/todo.v1.AdminService/ExportTasks"
 )
 
 // AdminServiceClient is the client API for AdminService service.
@@ -41,9 +62,37 @@ type AdminServiceClient interface {
 	CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*CreateTaskResponse, error)
 	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
 	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error)
+	SearchTasks(ctx context.Context, in *SearchTasksRequest, opts ...grpc.CallOption) (*SearchTasksResponse, error)
 	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error)
+	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error)
 	// Task history
 	GetTaskHistory(ctx context.Context, in *GetTaskHistoryRequest, opts ...grpc.CallOption) (*GetTaskHistoryResponse, error)
+	// Subtasks
+	MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*MoveTaskResponse, error)
+	ListSubtasks(ctx context.Context, in *ListSubtasksRequest, opts ...grpc.CallOption) (*ListSubtasksResponse, error)
+	GetTaskTree(ctx context.Context, in *GetTaskTreeRequest, opts ...grpc.CallOption) (*GetTaskTreeResponse, error)
+	// Task participants
+	AddTaskParticipants(ctx context.Context, in *AddTaskParticipantsRequest, opts ...grpc.CallOption) (*AddTaskParticipantsResponse, error)
+	RemoveTaskParticipants(ctx context.Context, in *RemoveTaskParticipantsRequest, opts ...grpc.CallOption) (*RemoveTaskParticipantsResponse, error)
+	// Task dependencies
+	AddTaskDependency(ctx context.Context, in *AddTaskDependencyRequest, opts ...grpc.CallOption) (*AddTaskDependencyResponse, error)
+	RemoveTaskDependency(ctx context.Context, in *RemoveTaskDependencyRequest, opts ...grpc.CallOption) (*RemoveTaskDependencyResponse, error)
+	ListTaskBlockers(ctx context.Context, in *ListTaskBlockersRequest, opts ...grpc.CallOption) (*ListTaskBlockersResponse, error)
+	ListTaskDependents(ctx context.Context, in *ListTaskDependentsRequest, opts ...grpc.CallOption) (*ListTaskDependentsResponse, error)
+	GetCriticalPath(ctx context.Context, in *GetCriticalPathRequest, opts ...grpc.CallOption) (*GetCriticalPathResponse, error)
+	// Recurring tasks
+	SetTaskRecurrence(ctx context.Context, in *SetTaskRecurrenceRequest, opts ...grpc.CallOption) (*SetTaskRecurrenceResponse, error)
+	GetTaskSeries(ctx context.Context, in *GetTaskSeriesRequest, opts ...grpc.CallOption) (*GetTaskSeriesResponse, error)
+	UpdateTaskSeries(ctx context.Context, in *UpdateTaskSeriesRequest, opts ...grpc.CallOption) (*UpdateTaskSeriesResponse, error)
+	StopTaskSeries(ctx context.Context, in *StopTaskSeriesRequest, opts ...grpc.CallOption) (*StopTaskSeriesResponse, error)
+	// Bulk task operations
+	BulkUpdateTasks(ctx context.Context, in *BulkUpdateTasksRequest, opts ...grpc.CallOption) (*BulkUpdateTasksResponse, error)
+	BulkDeleteTasks(ctx context.Context, in *BulkDeleteTasksRequest, opts ...grpc.CallOption) (*BulkDeleteTasksResponse, error)
+	BulkRestoreTasks(ctx context.Context, in *BulkRestoreTasksRequest, opts ...grpc.CallOption) (*BulkRestoreTasksResponse, error)
+	// Task import (client streaming upload)
+	ImportTasks(ctx context.Context, opts ...grpc.CallOption) (AdminService_ImportTasksClient, error)
+	// Task export (server streaming download)
+	ExportTasks(ctx context.Context, in *ExportTasksRequest, opts ...grpc.CallOption) (AdminService_ExportTasksClient, error)
 }
 
 type adminServiceClient struct {
@@ -104,6 +153,16 @@ func (c *adminServiceClient) GetTask(ctx context.Context, in *GetTaskRequest, op
 	return out, nil
 }
 
+func (c *adminServiceClient) SearchTasks(ctx context.Context, in *SearchTasksRequest, opts ...grpc.CallOption) (*SearchTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTasksResponse)
+	err := c.cc.Invoke(ctx, AdminService_SearchTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateTaskResponse)
@@ -114,6 +173,16 @@ func (c *adminServiceClient) UpdateTask(ctx context.Context, in *UpdateTaskReque
 	return out, nil
 }
 
+func (c *adminServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTaskResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) GetTaskHistory(ctx context.Context, in *GetTaskHistoryRequest, opts ...grpc.CallOption) (*GetTaskHistoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetTaskHistoryResponse)
@@ -124,6 +193,244 @@ func (c *adminServiceClient) GetTaskHistory(ctx context.Context, in *GetTaskHist
 	return out, nil
 }
 
+func (c *adminServiceClient) MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*MoveTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveTaskResponse)
+	err := c.cc.Invoke(ctx, AdminService_MoveTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListSubtasks(ctx context.Context, in *ListSubtasksRequest, opts ...grpc.CallOption) (*ListSubtasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubtasksResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListSubtasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetTaskTree(ctx context.Context, in *GetTaskTreeRequest, opts ...grpc.CallOption) (*GetTaskTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskTreeResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetTaskTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AddTaskParticipants(ctx context.Context, in *AddTaskParticipantsRequest, opts ...grpc.CallOption) (*AddTaskParticipantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTaskParticipantsResponse)
+	err := c.cc.Invoke(ctx, AdminService_AddTaskParticipants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemoveTaskParticipants(ctx context.Context, in *RemoveTaskParticipantsRequest, opts ...grpc.CallOption) (*RemoveTaskParticipantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTaskParticipantsResponse)
+	err := c.cc.Invoke(ctx, AdminService_RemoveTaskParticipants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AddTaskDependency(ctx context.Context, in *AddTaskDependencyRequest, opts ...grpc.CallOption) (*AddTaskDependencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTaskDependencyResponse)
+	err := c.cc.Invoke(ctx, AdminService_AddTaskDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemoveTaskDependency(ctx context.Context, in *RemoveTaskDependencyRequest, opts ...grpc.CallOption) (*RemoveTaskDependencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTaskDependencyResponse)
+	err := c.cc.Invoke(ctx, AdminService_RemoveTaskDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListTaskBlockers(ctx context.Context, in *ListTaskBlockersRequest, opts ...grpc.CallOption) (*ListTaskBlockersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskBlockersResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListTaskBlockers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListTaskDependents(ctx context.Context, in *ListTaskDependentsRequest, opts ...grpc.CallOption) (*ListTaskDependentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskDependentsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListTaskDependents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetCriticalPath(ctx context.Context, in *GetCriticalPathRequest, opts ...grpc.CallOption) (*GetCriticalPathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCriticalPathResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetCriticalPath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetTaskRecurrence(ctx context.Context, in *SetTaskRecurrenceRequest, opts ...grpc.CallOption) (*SetTaskRecurrenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTaskRecurrenceResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetTaskRecurrence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetTaskSeries(ctx context.Context, in *GetTaskSeriesRequest, opts ...grpc.CallOption) (*GetTaskSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskSeriesResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetTaskSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateTaskSeries(ctx context.Context, in *UpdateTaskSeriesRequest, opts ...grpc.CallOption) (*UpdateTaskSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTaskSeriesResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateTaskSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) StopTaskSeries(ctx context.Context, in *StopTaskSeriesRequest, opts ...grpc.CallOption) (*StopTaskSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopTaskSeriesResponse)
+	err := c.cc.Invoke(ctx, AdminService_StopTaskSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) BulkUpdateTasks(ctx context.Context, in *BulkUpdateTasksRequest, opts ...grpc.CallOption) (*BulkUpdateTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkUpdateTasksResponse)
+	err := c.cc.Invoke(ctx, AdminService_BulkUpdateTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) BulkDeleteTasks(ctx context.Context, in *BulkDeleteTasksRequest, opts ...grpc.CallOption) (*BulkDeleteTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkDeleteTasksResponse)
+	err := c.cc.Invoke(ctx, AdminService_BulkDeleteTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) BulkRestoreTasks(ctx context.Context, in *BulkRestoreTasksRequest, opts ...grpc.CallOption) (*BulkRestoreTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkRestoreTasksResponse)
+	err := c.cc.Invoke(ctx, AdminService_BulkRestoreTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ImportTasks(ctx context.Context, opts ...grpc.CallOption) (AdminService_ImportTasksClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[0], AdminService_ImportTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceImportTasksClient{ClientStream: stream}
+	return x, nil
+}
+
+type AdminService_ImportTasksClient interface {
+	Send(*ImportTasksRequest) error
+	CloseAndRecv() (*ImportTasksResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceImportTasksClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceImportTasksClient) Send(m *ImportTasksRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminServiceImportTasksClient) CloseAndRecv() (*ImportTasksResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportTasksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ExportTasks(ctx context.Context, in *ExportTasksRequest, opts ...grpc.CallOption) (AdminService_ExportTasksClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[1], AdminService_ExportTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceExportTasksClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_ExportTasksClient interface {
+	Recv() (*ExportTasksResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceExportTasksClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceExportTasksClient) Recv() (*ExportTasksResponse, error) {
+	m := new(ExportTasksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations must embed UnimplementedAdminServiceServer
 // for forward compatibility
@@ -137,9 +444,37 @@ type AdminServiceServer interface {
 	CreateTask(context.Context, *CreateTaskRequest) (*CreateTaskResponse, error)
 	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
 	GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error)
+	SearchTasks(context.Context, *SearchTasksRequest) (*SearchTasksResponse, error)
 	UpdateTask(context.Context, *UpdateTaskRequest) (*UpdateTaskResponse, error)
+	DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error)
 	// Task history
 	GetTaskHistory(context.Context, *GetTaskHistoryRequest) (*GetTaskHistoryResponse, error)
+	// Subtasks
+	MoveTask(context.Context, *MoveTaskRequest) (*MoveTaskResponse, error)
+	ListSubtasks(context.Context, *ListSubtasksRequest) (*ListSubtasksResponse, error)
+	GetTaskTree(context.Context, *GetTaskTreeRequest) (*GetTaskTreeResponse, error)
+	// Task participants
+	AddTaskParticipants(context.Context, *AddTaskParticipantsRequest) (*AddTaskParticipantsResponse, error)
+	RemoveTaskParticipants(context.Context, *RemoveTaskParticipantsRequest) (*RemoveTaskParticipantsResponse, error)
+	// Task dependencies
+	AddTaskDependency(context.Context, *AddTaskDependencyRequest) (*AddTaskDependencyResponse, error)
+	RemoveTaskDependency(context.Context, *RemoveTaskDependencyRequest) (*RemoveTaskDependencyResponse, error)
+	ListTaskBlockers(context.Context, *ListTaskBlockersRequest) (*ListTaskBlockersResponse, error)
+	ListTaskDependents(context.Context, *ListTaskDependentsRequest) (*ListTaskDependentsResponse, error)
+	GetCriticalPath(context.Context, *GetCriticalPathRequest) (*GetCriticalPathResponse, error)
+	// Recurring tasks
+	SetTaskRecurrence(context.Context, *SetTaskRecurrenceRequest) (*SetTaskRecurrenceResponse, error)
+	GetTaskSeries(context.Context, *GetTaskSeriesRequest) (*GetTaskSeriesResponse, error)
+	UpdateTaskSeries(context.Context, *UpdateTaskSeriesRequest) (*UpdateTaskSeriesResponse, error)
+	StopTaskSeries(context.Context, *StopTaskSeriesRequest) (*StopTaskSeriesResponse, error)
+	// Bulk task operations
+	BulkUpdateTasks(context.Context, *BulkUpdateTasksRequest) (*BulkUpdateTasksResponse, error)
+	BulkDeleteTasks(context.Context, *BulkDeleteTasksRequest) (*BulkDeleteTasksResponse, error)
+	BulkRestoreTasks(context.Context, *BulkRestoreTasksRequest) (*BulkRestoreTasksResponse, error)
+	// Task import (client streaming upload)
+	ImportTasks(AdminService_ImportTasksServer) error
+	// Task export (server streaming download)
+	ExportTasks(*ExportTasksRequest, AdminService_ExportTasksServer) error
 	mustEmbedUnimplementedAdminServiceServer()
 }
 
@@ -162,12 +497,75 @@ func (UnimplementedAdminServiceServer) ListTasks(context.Context, *ListTasksRequ
 func (UnimplementedAdminServiceServer) GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
 }
+func (UnimplementedAdminServiceServer) SearchTasks(context.Context, *SearchTasksRequest) (*SearchTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTasks not implemented")
+}
 func (UnimplementedAdminServiceServer) UpdateTask(context.Context, *UpdateTaskRequest) (*UpdateTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
 }
+func (UnimplementedAdminServiceServer) DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
+}
 func (UnimplementedAdminServiceServer) GetTaskHistory(context.Context, *GetTaskHistoryRequest) (*GetTaskHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTaskHistory not implemented")
 }
+func (UnimplementedAdminServiceServer) MoveTask(context.Context, *MoveTaskRequest) (*MoveTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTask not implemented")
+}
+func (UnimplementedAdminServiceServer) ListSubtasks(context.Context, *ListSubtasksRequest) (*ListSubtasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubtasks not implemented")
+}
+func (UnimplementedAdminServiceServer) GetTaskTree(context.Context, *GetTaskTreeRequest) (*GetTaskTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskTree not implemented")
+}
+func (UnimplementedAdminServiceServer) AddTaskParticipants(context.Context, *AddTaskParticipantsRequest) (*AddTaskParticipantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTaskParticipants not implemented")
+}
+func (UnimplementedAdminServiceServer) RemoveTaskParticipants(context.Context, *RemoveTaskParticipantsRequest) (*RemoveTaskParticipantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTaskParticipants not implemented")
+}
+func (UnimplementedAdminServiceServer) AddTaskDependency(context.Context, *AddTaskDependencyRequest) (*AddTaskDependencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTaskDependency not implemented")
+}
+func (UnimplementedAdminServiceServer) RemoveTaskDependency(context.Context, *RemoveTaskDependencyRequest) (*RemoveTaskDependencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTaskDependency not implemented")
+}
+func (UnimplementedAdminServiceServer) ListTaskBlockers(context.Context, *ListTaskBlockersRequest) (*ListTaskBlockersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskBlockers not implemented")
+}
+func (UnimplementedAdminServiceServer) ListTaskDependents(context.Context, *ListTaskDependentsRequest) (*ListTaskDependentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskDependents not implemented")
+}
+func (UnimplementedAdminServiceServer) GetCriticalPath(context.Context, *GetCriticalPathRequest) (*GetCriticalPathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCriticalPath not implemented")
+}
+func (UnimplementedAdminServiceServer) SetTaskRecurrence(context.Context, *SetTaskRecurrenceRequest) (*SetTaskRecurrenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTaskRecurrence not implemented")
+}
+func (UnimplementedAdminServiceServer) GetTaskSeries(context.Context, *GetTaskSeriesRequest) (*GetTaskSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskSeries not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateTaskSeries(context.Context, *UpdateTaskSeriesRequest) (*UpdateTaskSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskSeries not implemented")
+}
+func (UnimplementedAdminServiceServer) StopTaskSeries(context.Context, *StopTaskSeriesRequest) (*StopTaskSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopTaskSeries not implemented")
+}
+func (UnimplementedAdminServiceServer) BulkUpdateTasks(context.Context, *BulkUpdateTasksRequest) (*BulkUpdateTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkUpdateTasks not implemented")
+}
+func (UnimplementedAdminServiceServer) BulkDeleteTasks(context.Context, *BulkDeleteTasksRequest) (*BulkDeleteTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDeleteTasks not implemented")
+}
+func (UnimplementedAdminServiceServer) BulkRestoreTasks(context.Context, *BulkRestoreTasksRequest) (*BulkRestoreTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkRestoreTasks not implemented")
+}
+func (UnimplementedAdminServiceServer) ImportTasks(AdminService_ImportTasksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportTasks not implemented")
+}
+func (UnimplementedAdminServiceServer) ExportTasks(*ExportTasksRequest, AdminService_ExportTasksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTasks not implemented")
+}
 func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
 
 // UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -271,6 +669,24 @@ func _AdminService_GetTask_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_SearchTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SearchTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SearchTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SearchTasks(ctx, req.(*SearchTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateTaskRequest)
 	if err := dec(in); err != nil {
@@ -289,6 +705,24 @@ func _AdminService_UpdateTask_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteTask(ctx, req.(*DeleteTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_GetTaskHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTaskHistoryRequest)
 	if err := dec(in); err != nil {
@@ -307,407 +741,2384 @@ func _AdminService_GetTaskHistory_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var AdminService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "todo.v1.AdminService",
-	HandlerType: (*AdminServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ListUsers",
-			Handler:    _AdminService_ListUsers_Handler,
-		},
-		{
-			MethodName: "GetUser",
-			Handler:    _AdminService_GetUser_Handler,
-		},
-		{
-			MethodName: "CreateTask",
-			Handler:    _AdminService_CreateTask_Handler,
-		},
-		{
-			MethodName: "ListTasks",
-			Handler:    _AdminService_ListTasks_Handler,
-		},
-		{
-			MethodName: "GetTask",
-			Handler:    _AdminService_GetTask_Handler,
-		},
-		{
-			MethodName: "UpdateTask",
-			Handler:    _AdminService_UpdateTask_Handler,
-		},
-		{
-			MethodName: "GetTaskHistory",
-			Handler:    _AdminService_GetTaskHistory_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "todo.proto",
-}
-
+func _AdminService_MoveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).MoveTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_MoveTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).MoveTask(ctx, req.(*MoveTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListSubtasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubtasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListSubtasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListSubtasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListSubtasks(ctx, req.(*ListSubtasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetTaskTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetTaskTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetTaskTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetTaskTree(ctx, req.(*GetTaskTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddTaskParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTaskParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddTaskParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AddTaskParticipants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddTaskParticipants(ctx, req.(*AddTaskParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemoveTaskParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTaskParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemoveTaskParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RemoveTaskParticipants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemoveTaskParticipants(ctx, req.(*RemoveTaskParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddTaskDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTaskDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddTaskDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AddTaskDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddTaskDependency(ctx, req.(*AddTaskDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemoveTaskDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTaskDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemoveTaskDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RemoveTaskDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemoveTaskDependency(ctx, req.(*RemoveTaskDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListTaskBlockers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskBlockersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListTaskBlockers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListTaskBlockers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListTaskBlockers(ctx, req.(*ListTaskBlockersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListTaskDependents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskDependentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListTaskDependents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListTaskDependents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListTaskDependents(ctx, req.(*ListTaskDependentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetCriticalPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCriticalPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetCriticalPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetCriticalPath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetCriticalPath(ctx, req.(*GetCriticalPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetTaskRecurrence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTaskRecurrenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetTaskRecurrence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetTaskRecurrence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetTaskRecurrence(ctx, req.(*SetTaskRecurrenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetTaskSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetTaskSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetTaskSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetTaskSeries(ctx, req.(*GetTaskSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateTaskSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateTaskSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateTaskSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateTaskSeries(ctx, req.(*UpdateTaskSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_StopTaskSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopTaskSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StopTaskSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_StopTaskSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StopTaskSeries(ctx, req.(*StopTaskSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_BulkUpdateTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).BulkUpdateTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_BulkUpdateTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).BulkUpdateTasks(ctx, req.(*BulkUpdateTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_BulkDeleteTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).BulkDeleteTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_BulkDeleteTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).BulkDeleteTasks(ctx, req.(*BulkDeleteTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_BulkRestoreTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkRestoreTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).BulkRestoreTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_BulkRestoreTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).BulkRestoreTasks(ctx, req.(*BulkRestoreTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ImportTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServiceServer).ImportTasks(&adminServiceImportTasksServer{ServerStream: stream})
+}
+
+type AdminService_ImportTasksServer interface {
+	SendAndClose(*ImportTasksResponse) error
+	Recv() (*ImportTasksRequest, error)
+	grpc.ServerStream
+}
+
+type adminServiceImportTasksServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceImportTasksServer) SendAndClose(m *ImportTasksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *adminServiceImportTasksServer) Recv() (*ImportTasksRequest, error) {
+	m := new(ImportTasksRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _AdminService_ExportTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTasksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).ExportTasks(m, &adminServiceExportTasksServer{ServerStream: stream})
+}
+
+type AdminService_ExportTasksServer interface {
+	Send(*ExportTasksResponse) error
+	grpc.ServerStream
+}
+
+type adminServiceExportTasksServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceExportTasksServer) Send(m *ExportTasksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUsers",
+			Handler:    _AdminService_ListUsers_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _AdminService_GetUser_Handler,
+		},
+		{
+			MethodName: "CreateTask",
+			Handler:    _AdminService_CreateTask_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _AdminService_ListTasks_Handler,
+		},
+		{
+			MethodName: "GetTask",
+			Handler:    _AdminService_GetTask_Handler,
+		},
+		{
+			MethodName: "SearchTasks",
+			Handler:    _AdminService_SearchTasks_Handler,
+		},
+		{
+			MethodName: "UpdateTask",
+			Handler:    _AdminService_UpdateTask_Handler,
+		},
+		{
+			MethodName: "DeleteTask",
+			Handler:    _AdminService_DeleteTask_Handler,
+		},
+		{
+			MethodName: "GetTaskHistory",
+			Handler:    _AdminService_GetTaskHistory_Handler,
+		},
+		{
+			MethodName: "MoveTask",
+			Handler:    _AdminService_MoveTask_Handler,
+		},
+		{
+			MethodName: "ListSubtasks",
+			Handler:    _AdminService_ListSubtasks_Handler,
+		},
+		{
+			MethodName: "GetTaskTree",
+			Handler:    _AdminService_GetTaskTree_Handler,
+		},
+		{
+			MethodName: "AddTaskParticipants",
+			Handler:    _AdminService_AddTaskParticipants_Handler,
+		},
+		{
+			MethodName: "RemoveTaskParticipants",
+			Handler:    _AdminService_RemoveTaskParticipants_Handler,
+		},
+		{
+			MethodName: "AddTaskDependency",
+			Handler:    _AdminService_AddTaskDependency_Handler,
+		},
+		{
+			MethodName: "RemoveTaskDependency",
+			Handler:    _AdminService_RemoveTaskDependency_Handler,
+		},
+		{
+			MethodName: "ListTaskBlockers",
+			Handler:    _AdminService_ListTaskBlockers_Handler,
+		},
+		{
+			MethodName: "ListTaskDependents",
+			Handler:    _AdminService_ListTaskDependents_Handler,
+		},
+		{
+			MethodName: "GetCriticalPath",
+			Handler:    _AdminService_GetCriticalPath_Handler,
+		},
+		{
+			MethodName: "SetTaskRecurrence",
+			Handler:    _AdminService_SetTaskRecurrence_Handler,
+		},
+		{
+			MethodName: "GetTaskSeries",
+			Handler:    _AdminService_GetTaskSeries_Handler,
+		},
+		{
+			MethodName: "UpdateTaskSeries",
+			Handler:    _AdminService_UpdateTaskSeries_Handler,
+		},
+		{
+			MethodName: "StopTaskSeries",
+			Handler:    _AdminService_StopTaskSeries_Handler,
+		},
+		{
+			MethodName: "BulkUpdateTasks",
+			Handler:    _AdminService_BulkUpdateTasks_Handler,
+		},
+		{
+			MethodName: "BulkDeleteTasks",
+			Handler:    _AdminService_BulkDeleteTasks_Handler,
+		},
+		{
+			MethodName: "BulkRestoreTasks",
+			Handler:    _AdminService_BulkRestoreTasks_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportTasks",
+			Handler:       _AdminService_ImportTasks_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportTasks",
+			Handler:       _AdminService_ExportTasks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "todo.proto",
+}
+
+const (
+	UserService_Login_FullMethodName              = "/todo.v1.UserService/Login"
+	UserService_RefreshToken_FullMethodName       = "/todo.v1.UserService/RefreshToken"
+	UserService_GetMyTasks_FullMethodName         = "/todo.v1.UserService/GetMyTasks"
+	UserService_CompleteTask_FullMethodName       = "/todo.v1.UserService/CompleteTask"
+	UserService_MarkTaskUndoable_FullMethodName   = "/todo.v1.UserService/MarkTaskUndoable"
+	UserService_UpdateTaskProgress_FullMethodName = "/todo.v1.UserService/UpdateTaskProgress"
+	UserService_SyncTasks_FullMethodName          = "/todo.v1.UserService/SyncTasks"
+	UserService_GetTaskUpdates_FullMethodName     = "/todo.v1.UserService/GetTaskUpdates"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// User Service - for mobile interface
+type UserServiceClient interface {
+	// Authentication
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	// User tasks
+	GetMyTasks(ctx context.Context, in *GetMyTasksRequest, opts ...grpc.CallOption) (*GetMyTasksResponse, error)
+	CompleteTask(ctx context.Context, in *CompleteTaskRequest, opts ...grpc.CallOption) (*CompleteTaskResponse, error)
+	// Moves the task to TASK_STATUS_BLOCKED
+	MarkTaskUndoable(ctx context.Context, in *MarkTaskUndoableRequest, opts ...grpc.CallOption) (*MarkTaskUndoableResponse, error)
+	UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressRequest, opts ...grpc.CallOption) (*UpdateTaskProgressResponse, error)
+	// Sync operations for offline support
+	SyncTasks(ctx context.Context, in *SyncTasksRequest, opts ...grpc.CallOption) (*SyncTasksResponse, error)
+	GetTaskUpdates(ctx context.Context, in *GetTaskUpdatesRequest, opts ...grpc.CallOption) (*GetTaskUpdatesResponse, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetMyTasks(ctx context.Context, in *GetMyTasksRequest, opts ...grpc.CallOption) (*GetMyTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMyTasksResponse)
+	err := c.cc.Invoke(ctx, UserService_GetMyTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CompleteTask(ctx context.Context, in *CompleteTaskRequest, opts ...grpc.CallOption) (*CompleteTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteTaskResponse)
+	err := c.cc.Invoke(ctx, UserService_CompleteTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) MarkTaskUndoable(ctx context.Context, in *MarkTaskUndoableRequest, opts ...grpc.CallOption) (*MarkTaskUndoableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkTaskUndoableResponse)
+	err := c.cc.Invoke(ctx, UserService_MarkTaskUndoable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressRequest, opts ...grpc.CallOption) (*UpdateTaskProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTaskProgressResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateTaskProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SyncTasks(ctx context.Context, in *SyncTasksRequest, opts ...grpc.CallOption) (*SyncTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncTasksResponse)
+	err := c.cc.Invoke(ctx, UserService_SyncTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetTaskUpdates(ctx context.Context, in *GetTaskUpdatesRequest, opts ...grpc.CallOption) (*GetTaskUpdatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskUpdatesResponse)
+	err := c.cc.Invoke(ctx, UserService_GetTaskUpdates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility
+//
+// User Service - for mobile interface
+type UserServiceServer interface {
+	// Authentication
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	// User tasks
+	GetMyTasks(context.Context, *GetMyTasksRequest) (*GetMyTasksResponse, error)
+	CompleteTask(context.Context, *CompleteTaskRequest) (*CompleteTaskResponse, error)
+	// Moves the task to TASK_STATUS_BLOCKED
+	MarkTaskUndoable(context.Context, *MarkTaskUndoableRequest) (*MarkTaskUndoableResponse, error)
+	UpdateTaskProgress(context.Context, *UpdateTaskProgressRequest) (*UpdateTaskProgressResponse, error)
+	// Sync operations for offline support
+	SyncTasks(context.Context, *SyncTasksRequest) (*SyncTasksResponse, error)
+	GetTaskUpdates(context.Context, *GetTaskUpdatesRequest) (*GetTaskUpdatesResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserServiceServer struct {
+}
+
+func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedUserServiceServer) GetMyTasks(context.Context, *GetMyTasksRequest) (*GetMyTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyTasks not implemented")
+}
+func (UnimplementedUserServiceServer) CompleteTask(context.Context, *CompleteTaskRequest) (*CompleteTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteTask not implemented")
+}
+func (UnimplementedUserServiceServer) MarkTaskUndoable(context.Context, *MarkTaskUndoableRequest) (*MarkTaskUndoableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkTaskUndoable not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateTaskProgress(context.Context, *UpdateTaskProgressRequest) (*UpdateTaskProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskProgress not implemented")
+}
+func (UnimplementedUserServiceServer) SyncTasks(context.Context, *SyncTasksRequest) (*SyncTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncTasks not implemented")
+}
+func (UnimplementedUserServiceServer) GetTaskUpdates(context.Context, *GetTaskUpdatesRequest) (*GetTaskUpdatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskUpdates not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetMyTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetMyTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetMyTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetMyTasks(ctx, req.(*GetMyTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CompleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CompleteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CompleteTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CompleteTask(ctx, req.(*CompleteTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_MarkTaskUndoable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkTaskUndoableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).MarkTaskUndoable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_MarkTaskUndoable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).MarkTaskUndoable(ctx, req.(*MarkTaskUndoableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateTaskProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateTaskProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateTaskProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateTaskProgress(ctx, req.(*UpdateTaskProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SyncTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SyncTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SyncTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SyncTasks(ctx, req.(*SyncTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetTaskUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskUpdatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetTaskUpdates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetTaskUpdates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetTaskUpdates(ctx, req.(*GetTaskUpdatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _UserService_Login_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _UserService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "GetMyTasks",
+			Handler:    _UserService_GetMyTasks_Handler,
+		},
+		{
+			MethodName: "CompleteTask",
+			Handler:    _UserService_CompleteTask_Handler,
+		},
+		{
+			MethodName: "MarkTaskUndoable",
+			Handler:    _UserService_MarkTaskUndoable_Handler,
+		},
+		{
+			MethodName: "UpdateTaskProgress",
+			Handler:    _UserService_UpdateTaskProgress_Handler,
+		},
+		{
+			MethodName: "SyncTasks",
+			Handler:    _UserService_SyncTasks_Handler,
+		},
+		{
+			MethodName: "GetTaskUpdates",
+			Handler:    _UserService_GetTaskUpdates_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todo.proto",
+}
+
+const (
+	CategoryService_CreateCategory_FullMethodName  = "/todo.v1.CategoryService/CreateCategory"
+	CategoryService_ListCategories_FullMethodName  = "/todo.v1.CategoryService/ListCategories"
+	CategoryService_UpdateCategory_FullMethodName  = "/todo.v1.CategoryService/UpdateCategory"
+	CategoryService_DeleteCategory_FullMethodName  = "/todo.v1.CategoryService/DeleteCategory"
+	CategoryService_GetCategoryTree_FullMethodName = "/todo.v1.CategoryService/GetCategoryTree"
+	CategoryService_RestoreCategory_FullMethodName = "/todo.v1.CategoryService/RestoreCategory"
+	CategoryService_MoveCategory_FullMethodName    = "/todo.v1.CategoryService/MoveCategory"
+	CategoryService_MergeCategories_FullMethodName = "/todo.v1.CategoryService/MergeCategories"
+)
+
+// CategoryServiceClient is the client API for CategoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Category and Tag Management Service (used by both Admin and User services)
+type CategoryServiceClient interface {
+	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CreateCategoryResponse, error)
+	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error)
+	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error)
+	GetCategoryTree(ctx context.Context, in *GetCategoryTreeRequest, opts ...grpc.CallOption) (*GetCategoryTreeResponse, error)
+	RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*RestoreCategoryResponse, error)
+	MoveCategory(ctx context.Context, in *MoveCategoryRequest, opts ...grpc.CallOption) (*MoveCategoryResponse, error)
+	MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*MergeCategoriesResponse, error)
+}
+
+type categoryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCategoryServiceClient(cc grpc.ClientConnInterface) CategoryServiceClient {
+	return &categoryServiceClient{cc}
+}
+
+func (c *categoryServiceClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CreateCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCategoryResponse)
+	err := c.cc.Invoke(ctx, CategoryService_CreateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoriesResponse)
+	err := c.cc.Invoke(ctx, CategoryService_ListCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCategoryResponse)
+	err := c.cc.Invoke(ctx, CategoryService_UpdateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCategoryResponse)
+	err := c.cc.Invoke(ctx, CategoryService_DeleteCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) GetCategoryTree(ctx context.Context, in *GetCategoryTreeRequest, opts ...grpc.CallOption) (*GetCategoryTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryTreeResponse)
+	err := c.cc.Invoke(ctx, CategoryService_GetCategoryTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*RestoreCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreCategoryResponse)
+	err := c.cc.Invoke(ctx, CategoryService_RestoreCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) MoveCategory(ctx context.Context, in *MoveCategoryRequest, opts ...grpc.CallOption) (*MoveCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveCategoryResponse)
+	err := c.cc.Invoke(ctx, CategoryService_MoveCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*MergeCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeCategoriesResponse)
+	err := c.cc.Invoke(ctx, CategoryService_MergeCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CategoryServiceServer is the server API for CategoryService service.
+// All implementations must embed UnimplementedCategoryServiceServer
+// for forward compatibility
+//
+// Category and Tag Management Service (used by both Admin and User services)
+type CategoryServiceServer interface {
+	CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error)
+	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
+	UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error)
+	DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error)
+	GetCategoryTree(context.Context, *GetCategoryTreeRequest) (*GetCategoryTreeResponse, error)
+	RestoreCategory(context.Context, *RestoreCategoryRequest) (*RestoreCategoryResponse, error)
+	MoveCategory(context.Context, *MoveCategoryRequest) (*MoveCategoryResponse, error)
+	MergeCategories(context.Context, *MergeCategoriesRequest) (*MergeCategoriesResponse, error)
+	mustEmbedUnimplementedCategoryServiceServer()
+}
+
+// UnimplementedCategoryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCategoryServiceServer struct {
+}
+
+func (UnimplementedCategoryServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedCategoryServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
+}
+func (UnimplementedCategoryServiceServer) UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCategory not implemented")
+}
+func (UnimplementedCategoryServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+}
+func (UnimplementedCategoryServiceServer) GetCategoryTree(context.Context, *GetCategoryTreeRequest) (*GetCategoryTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryTree not implemented")
+}
+func (UnimplementedCategoryServiceServer) RestoreCategory(context.Context, *RestoreCategoryRequest) (*RestoreCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCategory not implemented")
+}
+func (UnimplementedCategoryServiceServer) MoveCategory(context.Context, *MoveCategoryRequest) (*MoveCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCategory not implemented")
+}
+func (UnimplementedCategoryServiceServer) MergeCategories(context.Context, *MergeCategoriesRequest) (*MergeCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCategories not implemented")
+}
+func (UnimplementedCategoryServiceServer) mustEmbedUnimplementedCategoryServiceServer() {}
+
+// UnsafeCategoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CategoryServiceServer will
+// result in compilation errors.
+type UnsafeCategoryServiceServer interface {
+	mustEmbedUnimplementedCategoryServiceServer()
+}
+
+func RegisterCategoryServiceServer(s grpc.ServiceRegistrar, srv CategoryServiceServer) {
+	s.RegisterService(&CategoryService_ServiceDesc, srv)
+}
+
+func _CategoryService_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_CreateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).CreateCategory(ctx, req.(*CreateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).ListCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_ListCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).ListCategories(ctx, req.(*ListCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_UpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).UpdateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_UpdateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).UpdateCategory(ctx, req.(*UpdateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).DeleteCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_DeleteCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).DeleteCategory(ctx, req.(*DeleteCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_GetCategoryTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).GetCategoryTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_GetCategoryTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).GetCategoryTree(ctx, req.(*GetCategoryTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_RestoreCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).RestoreCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_RestoreCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).RestoreCategory(ctx, req.(*RestoreCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_MoveCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).MoveCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_MoveCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).MoveCategory(ctx, req.(*MoveCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_MergeCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).MergeCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CategoryService_MergeCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).MergeCategories(ctx, req.(*MergeCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CategoryService_ServiceDesc is the grpc.ServiceDesc for CategoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CategoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.CategoryService",
+	HandlerType: (*CategoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCategory",
+			Handler:    _CategoryService_CreateCategory_Handler,
+		},
+		{
+			MethodName: "ListCategories",
+			Handler:    _CategoryService_ListCategories_Handler,
+		},
+		{
+			MethodName: "UpdateCategory",
+			Handler:    _CategoryService_UpdateCategory_Handler,
+		},
+		{
+			MethodName: "DeleteCategory",
+			Handler:    _CategoryService_DeleteCategory_Handler,
+		},
+		{
+			MethodName: "GetCategoryTree",
+			Handler:    _CategoryService_GetCategoryTree_Handler,
+		},
+		{
+			MethodName: "RestoreCategory",
+			Handler:    _CategoryService_RestoreCategory_Handler,
+		},
+		{
+			MethodName: "MoveCategory",
+			Handler:    _CategoryService_MoveCategory_Handler,
+		},
+		{
+			MethodName: "MergeCategories",
+			Handler:    _CategoryService_MergeCategories_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todo.proto",
+}
+
+const (
+	TagService_CreateTag_FullMethodName             = "/todo.v1.TagService/CreateTag"
+	TagService_ListTags_FullMethodName              = "/todo.v1.TagService/ListTags"
+	TagService_UpdateTag_FullMethodName             = "/todo.v1.TagService/UpdateTag"
+	TagService_DeleteTag_FullMethodName             = "/todo.v1.TagService/DeleteTag"
+	TagService_MergeTags_FullMethodName             = "/todo.v1.TagService/MergeTags"
+	TagService_AddTagSynonym_FullMethodName         = "/todo.v1.TagService/AddTagSynonym"
+	TagService_RemoveTagSynonym_FullMethodName      = "/todo.v1.TagService/RemoveTagSynonym"
+	TagService_ListTagSynonyms_FullMethodName       = "/todo.v1.TagService/ListTagSynonyms"
+	TagService_GetDuplicateTagReport_FullMethodName = "/todo.v1.TagService/GetDuplicateTagReport"
+	TagService_SuggestTags_FullMethodName           = "/todo.v1.TagService/SuggestTags"
+)
+
+// TagServiceClient is the client API for TagService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TagServiceClient interface {
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*UpdateTagResponse, error)
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error)
+	AddTagSynonym(ctx context.Context, in *AddTagSynonymRequest, opts ...grpc.CallOption) (*AddTagSynonymResponse, error)
+	RemoveTagSynonym(ctx context.Context, in *RemoveTagSynonymRequest, opts ...grpc.CallOption) (*RemoveTagSynonymResponse, error)
+	ListTagSynonyms(ctx context.Context, in *ListTagSynonymsRequest, opts ...grpc.CallOption) (*ListTagSynonymsResponse, error)
+	GetDuplicateTagReport(ctx context.Context, in *GetDuplicateTagReportRequest, opts ...grpc.CallOption) (*GetDuplicateTagReportResponse, error)
+	SuggestTags(ctx context.Context, in *SuggestTagsRequest, opts ...grpc.CallOption) (*SuggestTagsResponse, error)
+}
+
+type tagServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTagServiceClient(cc grpc.ClientConnInterface) TagServiceClient {
+	return &tagServiceClient{cc}
+}
+
+func (c *tagServiceClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTagResponse)
+	err := c.cc.Invoke(ctx, TagService_CreateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*UpdateTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTagResponse)
+	err := c.cc.Invoke(ctx, TagService_UpdateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTagResponse)
+	err := c.cc.Invoke(ctx, TagService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) AddTagSynonym(ctx context.Context, in *AddTagSynonymRequest, opts ...grpc.CallOption) (*AddTagSynonymResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTagSynonymResponse)
+	err := c.cc.Invoke(ctx, TagService_AddTagSynonym_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) RemoveTagSynonym(ctx context.Context, in *RemoveTagSynonymRequest, opts ...grpc.CallOption) (*RemoveTagSynonymResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTagSynonymResponse)
+	err := c.cc.Invoke(ctx, TagService_RemoveTagSynonym_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) ListTagSynonyms(ctx context.Context, in *ListTagSynonymsRequest, opts ...grpc.CallOption) (*ListTagSynonymsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagSynonymsResponse)
+	err := c.cc.Invoke(ctx, TagService_ListTagSynonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) GetDuplicateTagReport(ctx context.Context, in *GetDuplicateTagReportRequest, opts ...grpc.CallOption) (*GetDuplicateTagReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDuplicateTagReportResponse)
+	err := c.cc.Invoke(ctx, TagService_GetDuplicateTagReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) SuggestTags(ctx context.Context, in *SuggestTagsRequest, opts ...grpc.CallOption) (*SuggestTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestTagsResponse)
+	err := c.cc.Invoke(ctx, TagService_SuggestTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TagServiceServer is the server API for TagService service.
+// All implementations must embed UnimplementedTagServiceServer
+// for forward compatibility
+type TagServiceServer interface {
+	CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	UpdateTag(context.Context, *UpdateTagRequest) (*UpdateTagResponse, error)
+	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
+	MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error)
+	AddTagSynonym(context.Context, *AddTagSynonymRequest) (*AddTagSynonymResponse, error)
+	RemoveTagSynonym(context.Context, *RemoveTagSynonymRequest) (*RemoveTagSynonymResponse, error)
+	ListTagSynonyms(context.Context, *ListTagSynonymsRequest) (*ListTagSynonymsResponse, error)
+	GetDuplicateTagReport(context.Context, *GetDuplicateTagReportRequest) (*GetDuplicateTagReportResponse, error)
+	SuggestTags(context.Context, *SuggestTagsRequest) (*SuggestTagsResponse, error)
+	mustEmbedUnimplementedTagServiceServer()
+}
+
+// UnimplementedTagServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTagServiceServer struct {
+}
+
+func (UnimplementedTagServiceServer) CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedTagServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedTagServiceServer) UpdateTag(context.Context, *UpdateTagRequest) (*UpdateTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTag not implemented")
+}
+func (UnimplementedTagServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedTagServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedTagServiceServer) AddTagSynonym(context.Context, *AddTagSynonymRequest) (*AddTagSynonymResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTagSynonym not implemented")
+}
+func (UnimplementedTagServiceServer) RemoveTagSynonym(context.Context, *RemoveTagSynonymRequest) (*RemoveTagSynonymResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTagSynonym not implemented")
+}
+func (UnimplementedTagServiceServer) ListTagSynonyms(context.Context, *ListTagSynonymsRequest) (*ListTagSynonymsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTagSynonyms not implemented")
+}
+func (UnimplementedTagServiceServer) GetDuplicateTagReport(context.Context, *GetDuplicateTagReportRequest) (*GetDuplicateTagReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDuplicateTagReport not implemented")
+}
+func (UnimplementedTagServiceServer) SuggestTags(context.Context, *SuggestTagsRequest) (*SuggestTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestTags not implemented")
+}
+func (UnimplementedTagServiceServer) mustEmbedUnimplementedTagServiceServer() {}
+
+// UnsafeTagServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TagServiceServer will
+// result in compilation errors.
+type UnsafeTagServiceServer interface {
+	mustEmbedUnimplementedTagServiceServer()
+}
+
+func RegisterTagServiceServer(s grpc.ServiceRegistrar, srv TagServiceServer) {
+	s.RegisterService(&TagService_ServiceDesc, srv)
+}
+
+func _TagService_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_CreateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_UpdateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).UpdateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_UpdateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).UpdateTag(ctx, req.(*UpdateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_AddTagSynonym_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagSynonymRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).AddTagSynonym(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_AddTagSynonym_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).AddTagSynonym(ctx, req.(*AddTagSynonymRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_RemoveTagSynonym_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagSynonymRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).RemoveTagSynonym(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_RemoveTagSynonym_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).RemoveTagSynonym(ctx, req.(*RemoveTagSynonymRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_ListTagSynonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagSynonymsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).ListTagSynonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_ListTagSynonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).ListTagSynonyms(ctx, req.(*ListTagSynonymsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_GetDuplicateTagReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDuplicateTagReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetDuplicateTagReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_GetDuplicateTagReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetDuplicateTagReport(ctx, req.(*GetDuplicateTagReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_SuggestTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).SuggestTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_SuggestTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).SuggestTags(ctx, req.(*SuggestTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TagService_ServiceDesc is the grpc.ServiceDesc for TagService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TagService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.TagService",
+	HandlerType: (*TagServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTag",
+			Handler:    _TagService_CreateTag_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _TagService_ListTags_Handler,
+		},
+		{
+			MethodName: "UpdateTag",
+			Handler:    _TagService_UpdateTag_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _TagService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _TagService_MergeTags_Handler,
+		},
+		{
+			MethodName: "AddTagSynonym",
+			Handler:    _TagService_AddTagSynonym_Handler,
+		},
+		{
+			MethodName: "RemoveTagSynonym",
+			Handler:    _TagService_RemoveTagSynonym_Handler,
+		},
+		{
+			MethodName: "ListTagSynonyms",
+			Handler:    _TagService_ListTagSynonyms_Handler,
+		},
+		{
+			MethodName: "GetDuplicateTagReport",
+			Handler:    _TagService_GetDuplicateTagReport_Handler,
+		},
+		{
+			MethodName: "SuggestTags",
+			Handler:    _TagService_SuggestTags_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todo.proto",
+}
+
 const (
-	UserService_Login_FullMethodName              = "/todo.v1.UserService/Login"
-	UserService_RefreshToken_FullMethodName       = "/todo.v1.UserService/RefreshToken"
-	UserService_GetMyTasks_FullMethodName         = "/todo.v1.UserService/GetMyTasks"
-	UserService_CompleteTask_FullMethodName       = "/todo.v1.UserService/CompleteTask"
-	UserService_MarkTaskUndoable_FullMethodName   = "/todo.v1.UserService/MarkTaskUndoable"
-	UserService_UpdateTaskProgress_FullMethodName = "/todo.v1.UserService/UpdateTaskProgress"
-	UserService_SyncTasks_FullMethodName          = "/todo.v1.UserService/SyncTasks"
-	UserService_GetTaskUpdates_FullMethodName     = "/todo.v1.UserService/GetTaskUpdates"
+	SavedViewService_CreateSavedView_FullMethodName = "/todo.v1.SavedViewService/CreateSavedView"
+	SavedViewService_GetSavedView_FullMethodName    = "/todo.v1.SavedViewService/GetSavedView"
+	SavedViewService_ListSavedViews_FullMethodName  = "/todo.v1.SavedViewService/ListSavedViews"
+	SavedViewService_UpdateSavedView_FullMethodName = "/todo.v1.SavedViewService/UpdateSavedView"
+	SavedViewService_DeleteSavedView_FullMethodName = "/todo.v1.SavedViewService/DeleteSavedView"
 )
 
-// UserServiceClient is the client API for UserService service.
+// SavedViewServiceClient is the client API for SavedViewService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// User Service - for mobile interface
-type UserServiceClient interface {
-	// Authentication
-	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
-	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
-	// User tasks
-	GetMyTasks(ctx context.Context, in *GetMyTasksRequest, opts ...grpc.CallOption) (*GetMyTasksResponse, error)
-	CompleteTask(ctx context.Context, in *CompleteTaskRequest, opts ...grpc.CallOption) (*CompleteTaskResponse, error)
-	MarkTaskUndoable(ctx context.Context, in *MarkTaskUndoableRequest, opts ...grpc.CallOption) (*MarkTaskUndoableResponse, error)
-	UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressRequest, opts ...grpc.CallOption) (*UpdateTaskProgressResponse, error)
-	// Sync operations for offline support
-	SyncTasks(ctx context.Context, in *SyncTasksRequest, opts ...grpc.CallOption) (*SyncTasksResponse, error)
-	GetTaskUpdates(ctx context.Context, in *GetTaskUpdatesRequest, opts ...grpc.CallOption) (*GetTaskUpdatesResponse, error)
+// Saved views store task filters, sort order and visible columns
+type SavedViewServiceClient interface {
+	CreateSavedView(ctx context.Context, in *CreateSavedViewRequest, opts ...grpc.CallOption) (*CreateSavedViewResponse, error)
+	GetSavedView(ctx context.Context, in *GetSavedViewRequest, opts ...grpc.CallOption) (*GetSavedViewResponse, error)
+	ListSavedViews(ctx context.Context, in *ListSavedViewsRequest, opts ...grpc.CallOption) (*ListSavedViewsResponse, error)
+	UpdateSavedView(ctx context.Context, in *UpdateSavedViewRequest, opts ...grpc.CallOption) (*UpdateSavedViewResponse, error)
+	DeleteSavedView(ctx context.Context, in *DeleteSavedViewRequest, opts ...grpc.CallOption) (*DeleteSavedViewResponse, error)
 }
 
-type userServiceClient struct {
+type savedViewServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
-	return &userServiceClient{cc}
+func NewSavedViewServiceClient(cc grpc.ClientConnInterface) SavedViewServiceClient {
+	return &savedViewServiceClient{cc}
 }
 
-func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+func (c *savedViewServiceClient) CreateSavedView(ctx context.Context, in *CreateSavedViewRequest, opts ...grpc.CallOption) (*CreateSavedViewResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(LoginResponse)
-	err := c.cc.Invoke(ctx, UserService_Login_FullMethodName, in, out, cOpts...)
+	out := new(CreateSavedViewResponse)
+	err := c.cc.Invoke(ctx, SavedViewService_CreateSavedView_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
+func (c *savedViewServiceClient) GetSavedView(ctx context.Context, in *GetSavedViewRequest, opts ...grpc.CallOption) (*GetSavedViewResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RefreshTokenResponse)
-	err := c.cc.Invoke(ctx, UserService_RefreshToken_FullMethodName, in, out, cOpts...)
+	out := new(GetSavedViewResponse)
+	err := c.cc.Invoke(ctx, SavedViewService_GetSavedView_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) GetMyTasks(ctx context.Context, in *GetMyTasksRequest, opts ...grpc.CallOption) (*GetMyTasksResponse, error) {
+func (c *savedViewServiceClient) ListSavedViews(ctx context.Context, in *ListSavedViewsRequest, opts ...grpc.CallOption) (*ListSavedViewsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetMyTasksResponse)
-	err := c.cc.Invoke(ctx, UserService_GetMyTasks_FullMethodName, in, out, cOpts...)
+	out := new(ListSavedViewsResponse)
+	err := c.cc.Invoke(ctx, SavedViewService_ListSavedViews_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) CompleteTask(ctx context.Context, in *CompleteTaskRequest, opts ...grpc.CallOption) (*CompleteTaskResponse, error) {
+func (c *savedViewServiceClient) UpdateSavedView(ctx context.Context, in *UpdateSavedViewRequest, opts ...grpc.CallOption) (*UpdateSavedViewResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CompleteTaskResponse)
-	err := c.cc.Invoke(ctx, UserService_CompleteTask_FullMethodName, in, out, cOpts...)
+	out := new(UpdateSavedViewResponse)
+	err := c.cc.Invoke(ctx, SavedViewService_UpdateSavedView_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) MarkTaskUndoable(ctx context.Context, in *MarkTaskUndoableRequest, opts ...grpc.CallOption) (*MarkTaskUndoableResponse, error) {
+func (c *savedViewServiceClient) DeleteSavedView(ctx context.Context, in *DeleteSavedViewRequest, opts ...grpc.CallOption) (*DeleteSavedViewResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(MarkTaskUndoableResponse)
-	err := c.cc.Invoke(ctx, UserService_MarkTaskUndoable_FullMethodName, in, out, cOpts...)
+	out := new(DeleteSavedViewResponse)
+	err := c.cc.Invoke(ctx, SavedViewService_DeleteSavedView_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) UpdateTaskProgress(ctx context.Context, in *UpdateTaskProgressRequest, opts ...grpc.CallOption) (*UpdateTaskProgressResponse, error) {
+// SavedViewServiceServer is the server API for SavedViewService service.
+// All implementations must embed UnimplementedSavedViewServiceServer
+// for forward compatibility
+//
+// Saved views store task filters, sort order and visible columns
+type SavedViewServiceServer interface {
+	CreateSavedView(context.Context, *CreateSavedViewRequest) (*CreateSavedViewResponse, error)
+	GetSavedView(context.Context, *GetSavedViewRequest) (*GetSavedViewResponse, error)
+	ListSavedViews(context.Context, *ListSavedViewsRequest) (*ListSavedViewsResponse, error)
+	UpdateSavedView(context.Context, *UpdateSavedViewRequest) (*UpdateSavedViewResponse, error)
+	DeleteSavedView(context.Context, *DeleteSavedViewRequest) (*DeleteSavedViewResponse, error)
+	mustEmbedUnimplementedSavedViewServiceServer()
+}
+
+// UnimplementedSavedViewServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSavedViewServiceServer struct {
+}
+
+func (UnimplementedSavedViewServiceServer) CreateSavedView(context.Context, *CreateSavedViewRequest) (*CreateSavedViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSavedView not implemented")
+}
+func (UnimplementedSavedViewServiceServer) GetSavedView(context.Context, *GetSavedViewRequest) (*GetSavedViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSavedView not implemented")
+}
+func (UnimplementedSavedViewServiceServer) ListSavedViews(context.Context, *ListSavedViewsRequest) (*ListSavedViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavedViews not implemented")
+}
+func (UnimplementedSavedViewServiceServer) UpdateSavedView(context.Context, *UpdateSavedViewRequest) (*UpdateSavedViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSavedView not implemented")
+}
+func (UnimplementedSavedViewServiceServer) DeleteSavedView(context.Context, *DeleteSavedViewRequest) (*DeleteSavedViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSavedView not implemented")
+}
+func (UnimplementedSavedViewServiceServer) mustEmbedUnimplementedSavedViewServiceServer() {}
+
+// UnsafeSavedViewServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SavedViewServiceServer will
+// result in compilation errors.
+type UnsafeSavedViewServiceServer interface {
+	mustEmbedUnimplementedSavedViewServiceServer()
+}
+
+func RegisterSavedViewServiceServer(s grpc.ServiceRegistrar, srv SavedViewServiceServer) {
+	s.RegisterService(&SavedViewService_ServiceDesc, srv)
+}
+
+func _SavedViewService_CreateSavedView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSavedViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SavedViewServiceServer).CreateSavedView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SavedViewService_CreateSavedView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SavedViewServiceServer).CreateSavedView(ctx, req.(*CreateSavedViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SavedViewService_GetSavedView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSavedViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SavedViewServiceServer).GetSavedView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SavedViewService_GetSavedView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SavedViewServiceServer).GetSavedView(ctx, req.(*GetSavedViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SavedViewService_ListSavedViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedViewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SavedViewServiceServer).ListSavedViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SavedViewService_ListSavedViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SavedViewServiceServer).ListSavedViews(ctx, req.(*ListSavedViewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SavedViewService_UpdateSavedView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSavedViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SavedViewServiceServer).UpdateSavedView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SavedViewService_UpdateSavedView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SavedViewServiceServer).UpdateSavedView(ctx, req.(*UpdateSavedViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SavedViewService_DeleteSavedView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavedViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SavedViewServiceServer).DeleteSavedView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SavedViewService_DeleteSavedView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SavedViewServiceServer).DeleteSavedView(ctx, req.(*DeleteSavedViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SavedViewService_ServiceDesc is the grpc.ServiceDesc for SavedViewService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SavedViewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.SavedViewService",
+	HandlerType: (*SavedViewServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSavedView",
+			Handler:    _SavedViewService_CreateSavedView_Handler,
+		},
+		{
+			MethodName: "GetSavedView",
+			Handler:    _SavedViewService_GetSavedView_Handler,
+		},
+		{
+			MethodName: "ListSavedViews",
+			Handler:    _SavedViewService_ListSavedViews_Handler,
+		},
+		{
+			MethodName: "UpdateSavedView",
+			Handler:    _SavedViewService_UpdateSavedView_Handler,
+		},
+		{
+			MethodName: "DeleteSavedView",
+			Handler:    _SavedViewService_DeleteSavedView_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todo.proto",
+}
+
+const (
+	WorkflowService_CreateWorkflow_FullMethodName      = "/todo.v1.WorkflowService/CreateWorkflow"
+	WorkflowService_GetWorkflow_FullMethodName         = "/todo.v1.WorkflowService/GetWorkflow"
+	WorkflowService_ListWorkflows_FullMethodName       = "/todo.v1.WorkflowService/ListWorkflows"
+	WorkflowService_UpdateWorkflow_FullMethodName      = "/todo.v1.WorkflowService/UpdateWorkflow"
+	WorkflowService_DeleteWorkflow_FullMethodName      = "/todo.v1.WorkflowService/DeleteWorkflow"
+	WorkflowService_SetCategoryWorkflow_FullMethodName = "/todo.v1.WorkflowService/SetCategoryWorkflow"
+)
+
+// WorkflowServiceClient is the client API for WorkflowService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Workflows define the statuses and status transitions of tasks per category
+type WorkflowServiceClient interface {
+	CreateWorkflow(ctx context.Context, in *CreateWorkflowRequest, opts ...grpc.CallOption) (*CreateWorkflowResponse, error)
+	GetWorkflow(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*GetWorkflowResponse, error)
+	ListWorkflows(ctx context.Context, in *ListWorkflowsRequest, opts ...grpc.CallOption) (*ListWorkflowsResponse, error)
+	UpdateWorkflow(ctx context.Context, in *UpdateWorkflowRequest, opts ...grpc.CallOption) (*UpdateWorkflowResponse, error)
+	DeleteWorkflow(ctx context.Context, in *DeleteWorkflowRequest, opts ...grpc.CallOption) (*DeleteWorkflowResponse, error)
+	SetCategoryWorkflow(ctx context.Context, in *SetCategoryWorkflowRequest, opts ...grpc.CallOption) (*SetCategoryWorkflowResponse, error)
+}
+
+type workflowServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkflowServiceClient(cc grpc.ClientConnInterface) WorkflowServiceClient {
+	return &workflowServiceClient{cc}
+}
+
+func (c *workflowServiceClient) CreateWorkflow(ctx context.Context, in *CreateWorkflowRequest, opts ...grpc.CallOption) (*CreateWorkflowResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateTaskProgressResponse)
-	err := c.cc.Invoke(ctx, UserService_UpdateTaskProgress_FullMethodName, in, out, cOpts...)
+	out := new(CreateWorkflowResponse)
+	err := c.cc.Invoke(ctx, WorkflowService_CreateWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowServiceClient) GetWorkflow(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*GetWorkflowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWorkflowResponse)
+	err := c.cc.Invoke(ctx, WorkflowService_GetWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowServiceClient) ListWorkflows(ctx context.Context, in *ListWorkflowsRequest, opts ...grpc.CallOption) (*ListWorkflowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkflowsResponse)
+	err := c.cc.Invoke(ctx, WorkflowService_ListWorkflows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowServiceClient) UpdateWorkflow(ctx context.Context, in *UpdateWorkflowRequest, opts ...grpc.CallOption) (*UpdateWorkflowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateWorkflowResponse)
+	err := c.cc.Invoke(ctx, WorkflowService_UpdateWorkflow_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) SyncTasks(ctx context.Context, in *SyncTasksRequest, opts ...grpc.CallOption) (*SyncTasksResponse, error) {
+func (c *workflowServiceClient) DeleteWorkflow(ctx context.Context, in *DeleteWorkflowRequest, opts ...grpc.CallOption) (*DeleteWorkflowResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(SyncTasksResponse)
-	err := c.cc.Invoke(ctx, UserService_SyncTasks_FullMethodName, in, out, cOpts...)
+	out := new(DeleteWorkflowResponse)
+	err := c.cc.Invoke(ctx, WorkflowService_DeleteWorkflow_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) GetTaskUpdates(ctx context.Context, in *GetTaskUpdatesRequest, opts ...grpc.CallOption) (*GetTaskUpdatesResponse, error) {
+func (c *workflowServiceClient) SetCategoryWorkflow(ctx context.Context, in *SetCategoryWorkflowRequest, opts ...grpc.CallOption) (*SetCategoryWorkflowResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetTaskUpdatesResponse)
-	err := c.cc.Invoke(ctx, UserService_GetTaskUpdates_FullMethodName, in, out, cOpts...)
+	out := new(SetCategoryWorkflowResponse)
+	err := c.cc.Invoke(ctx, WorkflowService_SetCategoryWorkflow_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// UserServiceServer is the server API for UserService service.
-// All implementations must embed UnimplementedUserServiceServer
+// WorkflowServiceServer is the server API for WorkflowService service.
+// All implementations must embed UnimplementedWorkflowServiceServer
 // for forward compatibility
 //
-// User Service - for mobile interface
-type UserServiceServer interface {
-	// Authentication
-	Login(context.Context, *LoginRequest) (*LoginResponse, error)
-	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
-	// User tasks
-	GetMyTasks(context.Context, *GetMyTasksRequest) (*GetMyTasksResponse, error)
-	CompleteTask(context.Context, *CompleteTaskRequest) (*CompleteTaskResponse, error)
-	MarkTaskUndoable(context.Context, *MarkTaskUndoableRequest) (*MarkTaskUndoableResponse, error)
-	UpdateTaskProgress(context.Context, *UpdateTaskProgressRequest) (*UpdateTaskProgressResponse, error)
-	// Sync operations for offline support
-	SyncTasks(context.Context, *SyncTasksRequest) (*SyncTasksResponse, error)
-	GetTaskUpdates(context.Context, *GetTaskUpdatesRequest) (*GetTaskUpdatesResponse, error)
-	mustEmbedUnimplementedUserServiceServer()
+// Workflows define the statuses and status transitions of tasks per category
+type WorkflowServiceServer interface {
+	CreateWorkflow(context.Context, *CreateWorkflowRequest) (*CreateWorkflowResponse, error)
+	GetWorkflow(context.Context, *GetWorkflowRequest) (*GetWorkflowResponse, error)
+	ListWorkflows(context.Context, *ListWorkflowsRequest) (*ListWorkflowsResponse, error)
+	UpdateWorkflow(context.Context, *UpdateWorkflowRequest) (*UpdateWorkflowResponse, error)
+	DeleteWorkflow(context.Context, *DeleteWorkflowRequest) (*DeleteWorkflowResponse, error)
+	SetCategoryWorkflow(context.Context, *SetCategoryWorkflowRequest) (*SetCategoryWorkflowResponse, error)
+	mustEmbedUnimplementedWorkflowServiceServer()
 }
 
-// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedUserServiceServer struct {
+// UnimplementedWorkflowServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWorkflowServiceServer struct {
 }
 
-func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+func (UnimplementedWorkflowServiceServer) CreateWorkflow(context.Context, *CreateWorkflowRequest) (*CreateWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkflow not implemented")
 }
-func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+func (UnimplementedWorkflowServiceServer) GetWorkflow(context.Context, *GetWorkflowRequest) (*GetWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflow not implemented")
 }
-func (UnimplementedUserServiceServer) GetMyTasks(context.Context, *GetMyTasksRequest) (*GetMyTasksResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetMyTasks not implemented")
+func (UnimplementedWorkflowServiceServer) ListWorkflows(context.Context, *ListWorkflowsRequest) (*ListWorkflowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkflows not implemented")
 }
-func (UnimplementedUserServiceServer) CompleteTask(context.Context, *CompleteTaskRequest) (*CompleteTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CompleteTask not implemented")
+func (UnimplementedWorkflowServiceServer) UpdateWorkflow(context.Context, *UpdateWorkflowRequest) (*UpdateWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorkflow not implemented")
 }
-func (UnimplementedUserServiceServer) MarkTaskUndoable(context.Context, *MarkTaskUndoableRequest) (*MarkTaskUndoableResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MarkTaskUndoable not implemented")
+func (UnimplementedWorkflowServiceServer) DeleteWorkflow(context.Context, *DeleteWorkflowRequest) (*DeleteWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWorkflow not implemented")
 }
-func (UnimplementedUserServiceServer) UpdateTaskProgress(context.Context, *UpdateTaskProgressRequest) (*UpdateTaskProgressResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskProgress not implemented")
+func (UnimplementedWorkflowServiceServer) SetCategoryWorkflow(context.Context, *SetCategoryWorkflowRequest) (*SetCategoryWorkflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCategoryWorkflow not implemented")
 }
-func (UnimplementedUserServiceServer) SyncTasks(context.Context, *SyncTasksRequest) (*SyncTasksResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SyncTasks not implemented")
+func (UnimplementedWorkflowServiceServer) mustEmbedUnimplementedWorkflowServiceServer() {}
+
+// UnsafeWorkflowServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkflowServiceServer will
+// result in compilation errors.
+type UnsafeWorkflowServiceServer interface {
+	mustEmbedUnimplementedWorkflowServiceServer()
 }
-func (UnimplementedUserServiceServer) GetTaskUpdates(context.Context, *GetTaskUpdatesRequest) (*GetTaskUpdatesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTaskUpdates not implemented")
+
+func RegisterWorkflowServiceServer(s grpc.ServiceRegistrar, srv WorkflowServiceServer) {
+	s.RegisterService(&WorkflowService_ServiceDesc, srv)
 }
-func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
-// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to UserServiceServer will
-// result in compilation errors.
-type UnsafeUserServiceServer interface {
-	mustEmbedUnimplementedUserServiceServer()
+func _WorkflowService_CreateWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowServiceServer).CreateWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkflowService_CreateWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowServiceServer).CreateWorkflow(ctx, req.(*CreateWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
-	s.RegisterService(&UserService_ServiceDesc, srv)
+func _WorkflowService_GetWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowServiceServer).GetWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkflowService_GetWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowServiceServer).GetWorkflow(ctx, req.(*GetWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
+func _WorkflowService_ListWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkflowsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).Login(ctx, in)
+		return srv.(WorkflowServiceServer).ListWorkflows(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_Login_FullMethodName,
+		FullMethod: WorkflowService_ListWorkflows_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).Login(ctx, req.(*LoginRequest))
+		return srv.(WorkflowServiceServer).ListWorkflows(ctx, req.(*ListWorkflowsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RefreshTokenRequest)
+func _WorkflowService_UpdateWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWorkflowRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).RefreshToken(ctx, in)
+		return srv.(WorkflowServiceServer).UpdateWorkflow(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_RefreshToken_FullMethodName,
+		FullMethod: WorkflowService_UpdateWorkflow_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+		return srv.(WorkflowServiceServer).UpdateWorkflow(ctx, req.(*UpdateWorkflowRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetMyTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMyTasksRequest)
+func _WorkflowService_DeleteWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWorkflowRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetMyTasks(ctx, in)
+		return srv.(WorkflowServiceServer).DeleteWorkflow(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_GetMyTasks_FullMethodName,
+		FullMethod: WorkflowService_DeleteWorkflow_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetMyTasks(ctx, req.(*GetMyTasksRequest))
+		return srv.(WorkflowServiceServer).DeleteWorkflow(ctx, req.(*DeleteWorkflowRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_CompleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CompleteTaskRequest)
+func _WorkflowService_SetCategoryWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCategoryWorkflowRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).CompleteTask(ctx, in)
+		return srv.(WorkflowServiceServer).SetCategoryWorkflow(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_CompleteTask_FullMethodName,
+		FullMethod: WorkflowService_SetCategoryWorkflow_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).CompleteTask(ctx, req.(*CompleteTaskRequest))
+		return srv.(WorkflowServiceServer).SetCategoryWorkflow(ctx, req.(*SetCategoryWorkflowRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_MarkTaskUndoable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MarkTaskUndoableRequest)
+// WorkflowService_ServiceDesc is the grpc.ServiceDesc for WorkflowService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WorkflowService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.WorkflowService",
+	HandlerType: (*WorkflowServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWorkflow",
+			Handler:    _WorkflowService_CreateWorkflow_Handler,
+		},
+		{
+			MethodName: "GetWorkflow",
+			Handler:    _WorkflowService_GetWorkflow_Handler,
+		},
+		{
+			MethodName: "ListWorkflows",
+			Handler:    _WorkflowService_ListWorkflows_Handler,
+		},
+		{
+			MethodName: "UpdateWorkflow",
+			Handler:    _WorkflowService_UpdateWorkflow_Handler,
+		},
+		{
+			MethodName: "DeleteWorkflow",
+			Handler:    _WorkflowService_DeleteWorkflow_Handler,
+		},
+		{
+			MethodName: "SetCategoryWorkflow",
+			Handler:    _WorkflowService_SetCategoryWorkflow_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todo.proto",
+}
+
+const (
+	CommentService_CreateComment_FullMethodName    = "/todo.v1.CommentService/CreateComment"
+	CommentService_UpdateComment_FullMethodName    = "/todo.v1.CommentService/UpdateComment"
+	CommentService_DeleteComment_FullMethodName    = "/todo.v1.CommentService/DeleteComment"
+	CommentService_ListTaskActivity_FullMethodName = "/todo.v1.CommentService/ListTaskActivity"
+)
+
+// CommentServiceClient is the client API for CommentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Comment Service - discussion on tasks
+type CommentServiceClient interface {
+	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error)
+	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error)
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+	ListTaskActivity(ctx context.Context, in *ListTaskActivityRequest, opts ...grpc.CallOption) (*ListTaskActivityResponse, error)
+}
+
+type commentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCommentServiceClient(cc grpc.ClientConnInterface) CommentServiceClient {
+	return &commentServiceClient{cc}
+}
+
+func (c *commentServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCommentResponse)
+	err := c.cc.Invoke(ctx, CommentService_CreateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCommentResponse)
+	err := c.cc.Invoke(ctx, CommentService_UpdateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, CommentService_DeleteComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) ListTaskActivity(ctx context.Context, in *ListTaskActivityRequest, opts ...grpc.CallOption) (*ListTaskActivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskActivityResponse)
+	err := c.cc.Invoke(ctx, CommentService_ListTaskActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CommentServiceServer is the server API for CommentService service.
+// All implementations must embed UnimplementedCommentServiceServer
+// for forward compatibility
+//
+// Comment Service - discussion on tasks
+type CommentServiceServer interface {
+	CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
+	UpdateComment(context.Context, *UpdateCommentRequest) (*UpdateCommentResponse, error)
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+	ListTaskActivity(context.Context, *ListTaskActivityRequest) (*ListTaskActivityResponse, error)
+	mustEmbedUnimplementedCommentServiceServer()
+}
+
+// UnimplementedCommentServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCommentServiceServer struct {
+}
+
+func (UnimplementedCommentServiceServer) CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateComment not implemented")
+}
+func (UnimplementedCommentServiceServer) UpdateComment(context.Context, *UpdateCommentRequest) (*UpdateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
+func (UnimplementedCommentServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedCommentServiceServer) ListTaskActivity(context.Context, *ListTaskActivityRequest) (*ListTaskActivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskActivity not implemented")
+}
+func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
+
+// UnsafeCommentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CommentServiceServer will
+// result in compilation errors.
+type UnsafeCommentServiceServer interface {
+	mustEmbedUnimplementedCommentServiceServer()
+}
+
+func RegisterCommentServiceServer(s grpc.ServiceRegistrar, srv CommentServiceServer) {
+	s.RegisterService(&CommentService_ServiceDesc, srv)
+}
+
+func _CommentService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).MarkTaskUndoable(ctx, in)
+		return srv.(CommentServiceServer).CreateComment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_MarkTaskUndoable_FullMethodName,
+		FullMethod: CommentService_CreateComment_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).MarkTaskUndoable(ctx, req.(*MarkTaskUndoableRequest))
+		return srv.(CommentServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_UpdateTaskProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateTaskProgressRequest)
+func _CommentService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UpdateTaskProgress(ctx, in)
+		return srv.(CommentServiceServer).UpdateComment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_UpdateTaskProgress_FullMethodName,
+		FullMethod: CommentService_UpdateComment_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UpdateTaskProgress(ctx, req.(*UpdateTaskProgressRequest))
+		return srv.(CommentServiceServer).UpdateComment(ctx, req.(*UpdateCommentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_SyncTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SyncTasksRequest)
+func _CommentService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).SyncTasks(ctx, in)
+		return srv.(CommentServiceServer).DeleteComment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_SyncTasks_FullMethodName,
+		FullMethod: CommentService_DeleteComment_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).SyncTasks(ctx, req.(*SyncTasksRequest))
+		return srv.(CommentServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_GetTaskUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTaskUpdatesRequest)
+func _CommentService_ListTaskActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskActivityRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).GetTaskUpdates(ctx, in)
+		return srv.(CommentServiceServer).ListTaskActivity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_GetTaskUpdates_FullMethodName,
+		FullMethod: CommentService_ListTaskActivity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetTaskUpdates(ctx, req.(*GetTaskUpdatesRequest))
+		return srv.(CommentServiceServer).ListTaskActivity(ctx, req.(*ListTaskActivityRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var UserService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "todo.v1.UserService",
-	HandlerType: (*UserServiceServer)(nil),
+var CommentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.CommentService",
+	HandlerType: (*CommentServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Login",
-			Handler:    _UserService_Login_Handler,
-		},
-		{
-			MethodName: "RefreshToken",
-			Handler:    _UserService_RefreshToken_Handler,
-		},
-		{
-			MethodName: "GetMyTasks",
-			Handler:    _UserService_GetMyTasks_Handler,
-		},
-		{
-			MethodName: "CompleteTask",
-			Handler:    _UserService_CompleteTask_Handler,
-		},
-		{
-			MethodName: "MarkTaskUndoable",
-			Handler:    _UserService_MarkTaskUndoable_Handler,
+			MethodName: "CreateComment",
+			Handler:    _CommentService_CreateComment_Handler,
 		},
 		{
-			MethodName: "UpdateTaskProgress",
-			Handler:    _UserService_UpdateTaskProgress_Handler,
+			MethodName: "UpdateComment",
+			Handler:    _CommentService_UpdateComment_Handler,
 		},
 		{
-			MethodName: "SyncTasks",
-			Handler:    _UserService_SyncTasks_Handler,
+			MethodName: "DeleteComment",
+			Handler:    _CommentService_DeleteComment_Handler,
 		},
 		{
-			MethodName: "GetTaskUpdates",
-			Handler:    _UserService_GetTaskUpdates_Handler,
+			MethodName: "ListTaskActivity",
+			Handler:    _CommentService_ListTaskActivity_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -715,413 +3126,639 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	CategoryService_CreateCategory_FullMethodName = "/todo.v1.CategoryService/CreateCategory"
-	CategoryService_ListCategories_FullMethodName = "/todo.v1.CategoryService/ListCategories"
-	CategoryService_UpdateCategory_FullMethodName = "/todo.v1.CategoryService/UpdateCategory"
-	CategoryService_DeleteCategory_FullMethodName = "/todo.v1.CategoryService/DeleteCategory"
+	AttachmentService_UploadAttachment_FullMethodName   = "/todo.v1.AttachmentService/UploadAttachment"
+	AttachmentService_DownloadAttachment_FullMethodName = "/todo.v1.AttachmentService/DownloadAttachment"
+	AttachmentService_ListAttachments_FullMethodName    = "/todo.v1.AttachmentService/ListAttachments"
+	AttachmentService_DeleteAttachment_FullMethodName   = "/todo.v1.AttachmentService/DeleteAttachment"
+	AttachmentService_GetAttachmentUsage_FullMethodName = "/todo.v1.AttachmentService/GetAttachmentUsage"
 )
 
-// CategoryServiceClient is the client API for CategoryService service.
+// AttachmentServiceClient is the client API for AttachmentService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// Category and Tag Management Service (used by both Admin and User services)
-type CategoryServiceClient interface {
-	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CreateCategoryResponse, error)
-	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
-	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error)
-	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error)
+// Task attachments. Content is streamed in chunks in both directions.
+type AttachmentServiceClient interface {
+	// The first message carries the attachment info, the following ones the content
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (AttachmentService_UploadAttachmentClient, error)
+	// The first message carries the attachment, the following ones the content
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (AttachmentService_DownloadAttachmentClient, error)
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error)
+	GetAttachmentUsage(ctx context.Context, in *GetAttachmentUsageRequest, opts ...grpc.CallOption) (*GetAttachmentUsageResponse, error)
+}
+
+type attachmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAttachmentServiceClient(cc grpc.ClientConnInterface) AttachmentServiceClient {
+	return &attachmentServiceClient{cc}
+}
+
+func (c *attachmentServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (AttachmentService_UploadAttachmentClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AttachmentService_ServiceDesc.Streams[0], AttachmentService_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &attachmentServiceUploadAttachmentClient{ClientStream: stream}
+	return x, nil
+}
+
+type AttachmentService_UploadAttachmentClient interface {
+	Send(*UploadAttachmentRequest) error
+	CloseAndRecv() (*UploadAttachmentResponse, error)
+	grpc.ClientStream
+}
+
+type attachmentServiceUploadAttachmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *attachmentServiceUploadAttachmentClient) Send(m *UploadAttachmentRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *attachmentServiceUploadAttachmentClient) CloseAndRecv() (*UploadAttachmentResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadAttachmentResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *attachmentServiceClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (AttachmentService_DownloadAttachmentClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AttachmentService_ServiceDesc.Streams[1], AttachmentService_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &attachmentServiceDownloadAttachmentClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-type categoryServiceClient struct {
-	cc grpc.ClientConnInterface
+type AttachmentService_DownloadAttachmentClient interface {
+	Recv() (*DownloadAttachmentResponse, error)
+	grpc.ClientStream
 }
 
-func NewCategoryServiceClient(cc grpc.ClientConnInterface) CategoryServiceClient {
-	return &categoryServiceClient{cc}
+type attachmentServiceDownloadAttachmentClient struct {
+	grpc.ClientStream
 }
 
-func (c *categoryServiceClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CreateCategoryResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CreateCategoryResponse)
-	err := c.cc.Invoke(ctx, CategoryService_CreateCategory_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func (x *attachmentServiceDownloadAttachmentClient) Recv() (*DownloadAttachmentResponse, error) {
+	m := new(DownloadAttachmentResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *categoryServiceClient) ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+func (c *attachmentServiceClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListCategoriesResponse)
-	err := c.cc.Invoke(ctx, CategoryService_ListCategories_FullMethodName, in, out, cOpts...)
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_ListAttachments_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *categoryServiceClient) UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*UpdateCategoryResponse, error) {
+func (c *attachmentServiceClient) DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*DeleteAttachmentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateCategoryResponse)
-	err := c.cc.Invoke(ctx, CategoryService_UpdateCategory_FullMethodName, in, out, cOpts...)
+	out := new(DeleteAttachmentResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_DeleteAttachment_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *categoryServiceClient) DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error) {
+func (c *attachmentServiceClient) GetAttachmentUsage(ctx context.Context, in *GetAttachmentUsageRequest, opts ...grpc.CallOption) (*GetAttachmentUsageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteCategoryResponse)
-	err := c.cc.Invoke(ctx, CategoryService_DeleteCategory_FullMethodName, in, out, cOpts...)
+	out := new(GetAttachmentUsageResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_GetAttachmentUsage_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// CategoryServiceServer is the server API for CategoryService service.
-// All implementations must embed UnimplementedCategoryServiceServer
+// AttachmentServiceServer is the server API for AttachmentService service.
+// All implementations must embed UnimplementedAttachmentServiceServer
 // for forward compatibility
 //
-// Category and Tag Management Service (used by both Admin and User services)
-type CategoryServiceServer interface {
-	CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error)
-	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
-	UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error)
-	DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error)
-	mustEmbedUnimplementedCategoryServiceServer()
+// Task attachments. Content is streamed in chunks in both directions.
+type AttachmentServiceServer interface {
+	// The first message carries the attachment info, the following ones the content
+	UploadAttachment(AttachmentService_UploadAttachmentServer) error
+	// The first message carries the attachment, the following ones the content
+	DownloadAttachment(*DownloadAttachmentRequest, AttachmentService_DownloadAttachmentServer) error
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error)
+	GetAttachmentUsage(context.Context, *GetAttachmentUsageRequest) (*GetAttachmentUsageResponse, error)
+	mustEmbedUnimplementedAttachmentServiceServer()
 }
 
-// UnimplementedCategoryServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedCategoryServiceServer struct {
+// UnimplementedAttachmentServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAttachmentServiceServer struct {
 }
 
-func (UnimplementedCategoryServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+func (UnimplementedAttachmentServiceServer) UploadAttachment(AttachmentService_UploadAttachmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
 }
-func (UnimplementedCategoryServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
+func (UnimplementedAttachmentServiceServer) DownloadAttachment(*DownloadAttachmentRequest, AttachmentService_DownloadAttachmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
 }
-func (UnimplementedCategoryServiceServer) UpdateCategory(context.Context, *UpdateCategoryRequest) (*UpdateCategoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateCategory not implemented")
+func (UnimplementedAttachmentServiceServer) ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
 }
-func (UnimplementedCategoryServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+func (UnimplementedAttachmentServiceServer) DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*DeleteAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
 }
-func (UnimplementedCategoryServiceServer) mustEmbedUnimplementedCategoryServiceServer() {}
+func (UnimplementedAttachmentServiceServer) GetAttachmentUsage(context.Context, *GetAttachmentUsageRequest) (*GetAttachmentUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachmentUsage not implemented")
+}
+func (UnimplementedAttachmentServiceServer) mustEmbedUnimplementedAttachmentServiceServer() {}
 
-// UnsafeCategoryServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to CategoryServiceServer will
+// UnsafeAttachmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AttachmentServiceServer will
 // result in compilation errors.
-type UnsafeCategoryServiceServer interface {
-	mustEmbedUnimplementedCategoryServiceServer()
+type UnsafeAttachmentServiceServer interface {
+	mustEmbedUnimplementedAttachmentServiceServer()
 }
 
-func RegisterCategoryServiceServer(s grpc.ServiceRegistrar, srv CategoryServiceServer) {
-	s.RegisterService(&CategoryService_ServiceDesc, srv)
+func RegisterAttachmentServiceServer(s grpc.ServiceRegistrar, srv AttachmentServiceServer) {
+	s.RegisterService(&AttachmentService_ServiceDesc, srv)
 }
 
-func _CategoryService_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateCategoryRequest)
-	if err := dec(in); err != nil {
+func _AttachmentService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AttachmentServiceServer).UploadAttachment(&attachmentServiceUploadAttachmentServer{ServerStream: stream})
+}
+
+type AttachmentService_UploadAttachmentServer interface {
+	SendAndClose(*UploadAttachmentResponse) error
+	Recv() (*UploadAttachmentRequest, error)
+	grpc.ServerStream
+}
+
+type attachmentServiceUploadAttachmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *attachmentServiceUploadAttachmentServer) SendAndClose(m *UploadAttachmentResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *attachmentServiceUploadAttachmentServer) Recv() (*UploadAttachmentRequest, error) {
+	m := new(UploadAttachmentRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(CategoryServiceServer).CreateCategory(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: CategoryService_CreateCategory_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CategoryServiceServer).CreateCategory(ctx, req.(*CreateCategoryRequest))
+	return m, nil
+}
+
+func _AttachmentService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(AttachmentServiceServer).DownloadAttachment(m, &attachmentServiceDownloadAttachmentServer{ServerStream: stream})
 }
 
-func _CategoryService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListCategoriesRequest)
+type AttachmentService_DownloadAttachmentServer interface {
+	Send(*DownloadAttachmentResponse) error
+	grpc.ServerStream
+}
+
+type attachmentServiceDownloadAttachmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *attachmentServiceDownloadAttachmentServer) Send(m *DownloadAttachmentResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AttachmentService_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CategoryServiceServer).ListCategories(ctx, in)
+		return srv.(AttachmentServiceServer).ListAttachments(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CategoryService_ListCategories_FullMethodName,
+		FullMethod: AttachmentService_ListAttachments_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CategoryServiceServer).ListCategories(ctx, req.(*ListCategoriesRequest))
+		return srv.(AttachmentServiceServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CategoryService_UpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateCategoryRequest)
+func _AttachmentService_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttachmentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CategoryServiceServer).UpdateCategory(ctx, in)
+		return srv.(AttachmentServiceServer).DeleteAttachment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CategoryService_UpdateCategory_FullMethodName,
+		FullMethod: AttachmentService_DeleteAttachment_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CategoryServiceServer).UpdateCategory(ctx, req.(*UpdateCategoryRequest))
+		return srv.(AttachmentServiceServer).DeleteAttachment(ctx, req.(*DeleteAttachmentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CategoryService_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteCategoryRequest)
+func _AttachmentService_GetAttachmentUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentUsageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CategoryServiceServer).DeleteCategory(ctx, in)
+		return srv.(AttachmentServiceServer).GetAttachmentUsage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: CategoryService_DeleteCategory_FullMethodName,
+		FullMethod: AttachmentService_GetAttachmentUsage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CategoryServiceServer).DeleteCategory(ctx, req.(*DeleteCategoryRequest))
+		return srv.(AttachmentServiceServer).GetAttachmentUsage(ctx, req.(*GetAttachmentUsageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// CategoryService_ServiceDesc is the grpc.ServiceDesc for CategoryService service.
+// AttachmentService_ServiceDesc is the grpc.ServiceDesc for AttachmentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var CategoryService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "todo.v1.CategoryService",
-	HandlerType: (*CategoryServiceServer)(nil),
+var AttachmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.AttachmentService",
+	HandlerType: (*AttachmentServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateCategory",
-			Handler:    _CategoryService_CreateCategory_Handler,
+			MethodName: "ListAttachments",
+			Handler:    _AttachmentService_ListAttachments_Handler,
 		},
 		{
-			MethodName: "ListCategories",
-			Handler:    _CategoryService_ListCategories_Handler,
+			MethodName: "DeleteAttachment",
+			Handler:    _AttachmentService_DeleteAttachment_Handler,
 		},
 		{
-			MethodName: "UpdateCategory",
-			Handler:    _CategoryService_UpdateCategory_Handler,
+			MethodName: "GetAttachmentUsage",
+			Handler:    _AttachmentService_GetAttachmentUsage_Handler,
 		},
+	},
+	Streams: []grpc.StreamDesc{
 		{
-			MethodName: "DeleteCategory",
-			Handler:    _CategoryService_DeleteCategory_Handler,
+			StreamName:    "UploadAttachment",
+			Handler:       _AttachmentService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _AttachmentService_DownloadAttachment_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "todo.proto",
 }
 
 const (
-	TagService_CreateTag_FullMethodName = "/todo.v1.TagService/CreateTag"
-	TagService_ListTags_FullMethodName  = "/todo.v1.TagService/ListTags"
-	TagService_UpdateTag_FullMethodName = "/todo.v1.TagService/UpdateTag"
-	TagService_DeleteTag_FullMethodName = "/todo.v1.TagService/DeleteTag"
+	NotificationService_WatchTask_FullMethodName                  = "/todo.v1.NotificationService/WatchTask"
+	NotificationService_UnwatchTask_FullMethodName                = "/todo.v1.NotificationService/UnwatchTask"
+	NotificationService_WatchCategory_FullMethodName              = "/todo.v1.NotificationService/WatchCategory"
+	NotificationService_UnwatchCategory_FullMethodName            = "/todo.v1.NotificationService/UnwatchCategory"
+	NotificationService_ListWatches_FullMethodName                = "/todo.v1.NotificationService/ListWatches"
+	NotificationService_GetNotificationSettings_FullMethodName    = "/todo.v1.NotificationService/GetNotificationSettings"
+	NotificationService_UpdateNotificationSettings_FullMethodName = "/todo.v1.NotificationService/UpdateNotificationSettings"
 )
 
-// TagServiceClient is the client API for TagService service.
+// NotificationServiceClient is the client API for NotificationService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type TagServiceClient interface {
-	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error)
-	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
-	UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*UpdateTagResponse, error)
-	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
+//
+// Watchers and notification settings. Watchers of a task, or of one of its categories or
+// their parent categories, are notified of its status changes, reassignment, comments and
+// approaching due date. The caller is always the authenticated user.
+type NotificationServiceClient interface {
+	WatchTask(ctx context.Context, in *WatchTaskRequest, opts ...grpc.CallOption) (*WatchTaskResponse, error)
+	UnwatchTask(ctx context.Context, in *UnwatchTaskRequest, opts ...grpc.CallOption) (*UnwatchTaskResponse, error)
+	WatchCategory(ctx context.Context, in *WatchCategoryRequest, opts ...grpc.CallOption) (*WatchCategoryResponse, error)
+	UnwatchCategory(ctx context.Context, in *UnwatchCategoryRequest, opts ...grpc.CallOption) (*UnwatchCategoryResponse, error)
+	ListWatches(ctx context.Context, in *ListWatchesRequest, opts ...grpc.CallOption) (*ListWatchesResponse, error)
+	GetNotificationSettings(ctx context.Context, in *GetNotificationSettingsRequest, opts ...grpc.CallOption) (*GetNotificationSettingsResponse, error)
+	UpdateNotificationSettings(ctx context.Context, in *UpdateNotificationSettingsRequest, opts ...grpc.CallOption) (*UpdateNotificationSettingsResponse, error)
+}
+
+type notificationServiceClient struct {
+	cc grpc.ClientConnInterface
 }
 
-type tagServiceClient struct {
-	cc grpc.ClientConnInterface
+func NewNotificationServiceClient(cc grpc.ClientConnInterface) NotificationServiceClient {
+	return &notificationServiceClient{cc}
 }
 
-func NewTagServiceClient(cc grpc.ClientConnInterface) TagServiceClient {
-	return &tagServiceClient{cc}
+func (c *notificationServiceClient) WatchTask(ctx context.Context, in *WatchTaskRequest, opts ...grpc.CallOption) (*WatchTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WatchTaskResponse)
+	err := c.cc.Invoke(ctx, NotificationService_WatchTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *tagServiceClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error) {
+func (c *notificationServiceClient) UnwatchTask(ctx context.Context, in *UnwatchTaskRequest, opts ...grpc.CallOption) (*UnwatchTaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CreateTagResponse)
-	err := c.cc.Invoke(ctx, TagService_CreateTag_FullMethodName, in, out, cOpts...)
+	out := new(UnwatchTaskResponse)
+	err := c.cc.Invoke(ctx, NotificationService_UnwatchTask_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *tagServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+func (c *notificationServiceClient) WatchCategory(ctx context.Context, in *WatchCategoryRequest, opts ...grpc.CallOption) (*WatchCategoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListTagsResponse)
-	err := c.cc.Invoke(ctx, TagService_ListTags_FullMethodName, in, out, cOpts...)
+	out := new(WatchCategoryResponse)
+	err := c.cc.Invoke(ctx, NotificationService_WatchCategory_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *tagServiceClient) UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*UpdateTagResponse, error) {
+func (c *notificationServiceClient) UnwatchCategory(ctx context.Context, in *UnwatchCategoryRequest, opts ...grpc.CallOption) (*UnwatchCategoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateTagResponse)
-	err := c.cc.Invoke(ctx, TagService_UpdateTag_FullMethodName, in, out, cOpts...)
+	out := new(UnwatchCategoryResponse)
+	err := c.cc.Invoke(ctx, NotificationService_UnwatchCategory_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *tagServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+func (c *notificationServiceClient) ListWatches(ctx context.Context, in *ListWatchesRequest, opts ...grpc.CallOption) (*ListWatchesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteTagResponse)
-	err := c.cc.Invoke(ctx, TagService_DeleteTag_FullMethodName, in, out, cOpts...)
+	out := new(ListWatchesResponse)
+	err := c.cc.Invoke(ctx, NotificationService_ListWatches_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// TagServiceServer is the server API for TagService service.
-// All implementations must embed UnimplementedTagServiceServer
+func (c *notificationServiceClient) GetNotificationSettings(ctx context.Context, in *GetNotificationSettingsRequest, opts ...grpc.CallOption) (*GetNotificationSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotificationSettingsResponse)
+	err := c.cc.Invoke(ctx, NotificationService_GetNotificationSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) UpdateNotificationSettings(ctx context.Context, in *UpdateNotificationSettingsRequest, opts ...grpc.CallOption) (*UpdateNotificationSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateNotificationSettingsResponse)
+	err := c.cc.Invoke(ctx, NotificationService_UpdateNotificationSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationServiceServer is the server API for NotificationService service.
+// All implementations must embed UnimplementedNotificationServiceServer
 // for forward compatibility
-type TagServiceServer interface {
-	CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error)
-	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
-	UpdateTag(context.Context, *UpdateTagRequest) (*UpdateTagResponse, error)
-	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
-	mustEmbedUnimplementedTagServiceServer()
+//
+// Watchers and notification settings. Watchers of a task, or of one of its categories or
+// their parent categories, are notified of its status changes, reassignment, comments and
+// approaching due date. The caller is always the authenticated user.
+type NotificationServiceServer interface {
+	WatchTask(context.Context, *WatchTaskRequest) (*WatchTaskResponse, error)
+	UnwatchTask(context.Context, *UnwatchTaskRequest) (*UnwatchTaskResponse, error)
+	WatchCategory(context.Context, *WatchCategoryRequest) (*WatchCategoryResponse, error)
+	UnwatchCategory(context.Context, *UnwatchCategoryRequest) (*UnwatchCategoryResponse, error)
+	ListWatches(context.Context, *ListWatchesRequest) (*ListWatchesResponse, error)
+	GetNotificationSettings(context.Context, *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error)
+	UpdateNotificationSettings(context.Context, *UpdateNotificationSettingsRequest) (*UpdateNotificationSettingsResponse, error)
+	mustEmbedUnimplementedNotificationServiceServer()
 }
 
-// UnimplementedTagServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedTagServiceServer struct {
+// UnimplementedNotificationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedNotificationServiceServer struct {
 }
 
-func (UnimplementedTagServiceServer) CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+func (UnimplementedNotificationServiceServer) WatchTask(context.Context, *WatchTaskRequest) (*WatchTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WatchTask not implemented")
 }
-func (UnimplementedTagServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+func (UnimplementedNotificationServiceServer) UnwatchTask(context.Context, *UnwatchTaskRequest) (*UnwatchTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnwatchTask not implemented")
 }
-func (UnimplementedTagServiceServer) UpdateTag(context.Context, *UpdateTagRequest) (*UpdateTagResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateTag not implemented")
+func (UnimplementedNotificationServiceServer) WatchCategory(context.Context, *WatchCategoryRequest) (*WatchCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WatchCategory not implemented")
 }
-func (UnimplementedTagServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+func (UnimplementedNotificationServiceServer) UnwatchCategory(context.Context, *UnwatchCategoryRequest) (*UnwatchCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnwatchCategory not implemented")
 }
-func (UnimplementedTagServiceServer) mustEmbedUnimplementedTagServiceServer() {}
+func (UnimplementedNotificationServiceServer) ListWatches(context.Context, *ListWatchesRequest) (*ListWatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWatches not implemented")
+}
+func (UnimplementedNotificationServiceServer) GetNotificationSettings(context.Context, *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationSettings not implemented")
+}
+func (UnimplementedNotificationServiceServer) UpdateNotificationSettings(context.Context, *UpdateNotificationSettingsRequest) (*UpdateNotificationSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotificationSettings not implemented")
+}
+func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
 
-// UnsafeTagServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to TagServiceServer will
+// UnsafeNotificationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NotificationServiceServer will
 // result in compilation errors.
-type UnsafeTagServiceServer interface {
-	mustEmbedUnimplementedTagServiceServer()
+type UnsafeNotificationServiceServer interface {
+	mustEmbedUnimplementedNotificationServiceServer()
 }
 
-func RegisterTagServiceServer(s grpc.ServiceRegistrar, srv TagServiceServer) {
-	s.RegisterService(&TagService_ServiceDesc, srv)
+func RegisterNotificationServiceServer(s grpc.ServiceRegistrar, srv NotificationServiceServer) {
+	s.RegisterService(&NotificationService_ServiceDesc, srv)
 }
 
-func _TagService_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTagRequest)
+func _NotificationService_WatchTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TagServiceServer).CreateTag(ctx, in)
+		return srv.(NotificationServiceServer).WatchTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TagService_CreateTag_FullMethodName,
+		FullMethod: NotificationService_WatchTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TagServiceServer).CreateTag(ctx, req.(*CreateTagRequest))
+		return srv.(NotificationServiceServer).WatchTask(ctx, req.(*WatchTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TagService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTagsRequest)
+func _NotificationService_UnwatchTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnwatchTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TagServiceServer).ListTags(ctx, in)
+		return srv.(NotificationServiceServer).UnwatchTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TagService_ListTags_FullMethodName,
+		FullMethod: NotificationService_UnwatchTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TagServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+		return srv.(NotificationServiceServer).UnwatchTask(ctx, req.(*UnwatchTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TagService_UpdateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateTagRequest)
+func _NotificationService_WatchCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchCategoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TagServiceServer).UpdateTag(ctx, in)
+		return srv.(NotificationServiceServer).WatchCategory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TagService_UpdateTag_FullMethodName,
+		FullMethod: NotificationService_WatchCategory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TagServiceServer).UpdateTag(ctx, req.(*UpdateTagRequest))
+		return srv.(NotificationServiceServer).WatchCategory(ctx, req.(*WatchCategoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TagService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteTagRequest)
+func _NotificationService_UnwatchCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnwatchCategoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TagServiceServer).DeleteTag(ctx, in)
+		return srv.(NotificationServiceServer).UnwatchCategory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TagService_DeleteTag_FullMethodName,
+		FullMethod: NotificationService_UnwatchCategory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TagServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+		return srv.(NotificationServiceServer).UnwatchCategory(ctx, req.(*UnwatchCategoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// TagService_ServiceDesc is the grpc.ServiceDesc for TagService service.
+func _NotificationService_ListWatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).ListWatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_ListWatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).ListWatches(ctx, req.(*ListWatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_GetNotificationSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).GetNotificationSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_GetNotificationSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).GetNotificationSettings(ctx, req.(*GetNotificationSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_UpdateNotificationSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNotificationSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).UpdateNotificationSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_UpdateNotificationSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).UpdateNotificationSettings(ctx, req.(*UpdateNotificationSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var TagService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "todo.v1.TagService",
-	HandlerType: (*TagServiceServer)(nil),
+var NotificationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todo.v1.NotificationService",
+	HandlerType: (*NotificationServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateTag",
-			Handler:    _TagService_CreateTag_Handler,
+			MethodName: "WatchTask",
+			Handler:    _NotificationService_WatchTask_Handler,
 		},
 		{
-			MethodName: "ListTags",
-			Handler:    _TagService_ListTags_Handler,
+			MethodName: "UnwatchTask",
+			Handler:    _NotificationService_UnwatchTask_Handler,
 		},
 		{
-			MethodName: "UpdateTag",
-			Handler:    _TagService_UpdateTag_Handler,
+			MethodName: "WatchCategory",
+			Handler:    _NotificationService_WatchCategory_Handler,
 		},
 		{
-			MethodName: "DeleteTag",
-			Handler:    _TagService_DeleteTag_Handler,
+			MethodName: "UnwatchCategory",
+			Handler:    _NotificationService_UnwatchCategory_Handler,
+		},
+		{
+			MethodName: "ListWatches",
+			Handler:    _NotificationService_ListWatches_Handler,
+		},
+		{
+			MethodName: "GetNotificationSettings",
+			Handler:    _NotificationService_GetNotificationSettings_Handler,
+		},
+		{
+			MethodName: "UpdateNotificationSettings",
+			Handler:    _NotificationService_UpdateNotificationSettings_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},